@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go-kit/env"
+	"github.com/anatolykoptev/go-stealth/proxypool"
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+)
+
+// doctorTimeout bounds each preflight check.
+const doctorTimeout = 20 * time.Second
+
+// doctorProxyTarget is fetched through one proxy to prove the pool works.
+const doctorProxyTarget = "https://www.google.com/generate_204"
+
+// Preflight check outcomes.
+const (
+	checkOK   = "OK"
+	checkWarn = "WARN"
+	checkFail = "FAIL"
+	checkSkip = "SKIP"
+)
+
+// checkResult is one line of the doctor report.
+type checkResult struct {
+	name   string
+	status string
+	detail string
+}
+
+// runDoctor validates the configuration against the live dependencies and prints
+// a readiness report. It returns the process exit code: 1 if any check failed.
+// It changes nothing: the resume database is checked, not migrated.
+func runDoctor(w io.Writer) int {
+	c := loadConfig()
+	var results []checkResult
+
+	if apiKey := os.Getenv("WEBSHARE_API_KEY"); apiKey != "" {
+		pool, err := proxypool.NewWebshare(apiKey)
+		if err != nil {
+			results = append(results, checkResult{"proxy pool", checkFail, err.Error()})
+		} else {
+			c.ProxyPool = pool
+		}
+	}
+	e := engine.New(c)
+
+	results = append(results,
+		probe(func(ctx context.Context) checkResult { return checkLLM(ctx, &c, e.TestLLM) }),
+		probe(func(ctx context.Context) checkResult { return checkSearXNG(ctx, &c, e.PingSearXNG) }),
+	)
+	results = append(results, doctorResumeDB(c.DatabaseURL)...)
+	results = append(results, probe(func(ctx context.Context) checkResult {
+		return checkMemDB(ctx, &c, func(ctx context.Context) error {
+			return jobs.NewMemDBClient(c.MemDBURL, c.MemDBServiceSecret).Ping(ctx)
+		})
+	}))
+
+	redisURL := env.Str("REDIS_URL", "")
+	if redisURL != "" {
+		e.InitCache(redisURL, time.Minute, 1)
+	}
+	results = append(results,
+		probe(func(ctx context.Context) checkResult { return checkRedis(ctx, redisURL, e.PingRedis) }),
+		probe(func(ctx context.Context) checkResult { return checkProxy(ctx, c.ProxyPool, e.TestProxy) }),
+	)
+
+	return printDoctorReport(w, results)
+}
+
+// checkLLM runs a 1-token completion, which proves key, model and endpoint.
+func checkLLM(ctx context.Context, c *engine.Config, test func(context.Context) error) checkResult {
+	if err := test(ctx); err != nil {
+		return checkResult{"llm", checkFail, fmt.Sprintf("%s (%s): %v", c.LLMModel, c.LLMAPIBase, err)}
+	}
+	return checkResult{"llm", checkOK, c.LLMModel + " answered"}
+}
+
+func checkSearXNG(ctx context.Context, c *engine.Config, ping func(context.Context) error) checkResult {
+	switch err := ping(ctx); {
+	case errors.Is(err, engine.ErrNotConfigured):
+		return checkResult{"searxng", checkFail, "SEARXNG_URL not set; web search tools will return nothing"}
+	case err != nil:
+		return checkResult{"searxng", checkFail, fmt.Sprintf("%s: %v", c.SearxngURL, err)}
+	default:
+		return checkResult{"searxng", checkOK, c.SearxngURL}
+	}
+}
+
+func checkMemDB(ctx context.Context, c *engine.Config, ping func(context.Context) error) checkResult {
+	if c.MemDBURL == "" || c.MemDBServiceSecret == "" {
+		return checkResult{"memdb", checkSkip, "MEMDB_URL or INTERNAL_SERVICE_SECRET not set"}
+	}
+	if err := ping(ctx); err != nil {
+		return checkResult{"memdb", checkWarn, err.Error()}
+	}
+	return checkResult{"memdb", checkOK, c.MemDBURL}
+}
+
+func checkRedis(ctx context.Context, redisURL string, ping func(context.Context) error) checkResult {
+	if redisURL == "" {
+		return checkResult{"redis", checkSkip, "REDIS_URL not set; L1 cache only"}
+	}
+	if err := ping(ctx); err != nil {
+		return checkResult{"redis", checkWarn, err.Error()}
+	}
+	return checkResult{"redis", checkOK, "L2 cache reachable"}
+}
+
+func checkProxy(ctx context.Context, pool proxypool.ProxyPool, test func(context.Context, string) (string, error)) checkResult {
+	if pool == nil {
+		return checkResult{"proxy", checkSkip, "WEBSHARE_API_KEY not set; scrapers run without proxy"}
+	}
+	host, err := test(ctx, doctorProxyTarget)
+	if err != nil {
+		return checkResult{"proxy", checkWarn, fmt.Sprintf("%s: %v", host, err)}
+	}
+	return checkResult{"proxy", checkOK, fmt.Sprintf("%s works (%d in pool)", host, pool.Len())}
+}
+
+// checkMigrations reports the migrations the server would apply on its next start.
+func checkMigrations(pending []string, err error) checkResult {
+	switch {
+	case err != nil:
+		return checkResult{"migrations", checkFail, err.Error()}
+	case len(pending) > 0:
+		return checkResult{"migrations", checkWarn, fmt.Sprintf("%d pending, applied on server start: %s", len(pending), strings.Join(pending, ", "))}
+	default:
+		return checkResult{"migrations", checkOK, "schema up to date"}
+	}
+}
+
+func checkAGE(nodes int, err error) checkResult {
+	if err != nil {
+		return checkResult{"age", checkWarn, "Apache AGE unavailable, resume graph disabled: " + err.Error()}
+	}
+	return checkResult{"age", checkOK, fmt.Sprintf("resume_graph has %d nodes", nodes)}
+}
+
+// doctorResumeDB opens a plain Postgres connection, then reports pending migrations
+// and AGE without changing the schema.
+func doctorResumeDB(databaseURL string) []checkResult {
+	if databaseURL == "" {
+		return []checkResult{{"postgres", checkSkip, "DATABASE_URL not set; resume tools disabled"}}
+	}
+	var db *jobs.ResumeDB
+	result := probe(func(ctx context.Context) checkResult {
+		var err error
+		if db, err = jobs.OpenResumeDB(ctx, databaseURL); err != nil {
+			return checkResult{"postgres", checkFail, err.Error()}
+		}
+		return checkResult{"postgres", checkOK, "connected"}
+	})
+	if db == nil {
+		return []checkResult{result}
+	}
+	defer db.Close()
+
+	return []checkResult{
+		result,
+		probe(func(ctx context.Context) checkResult { return checkMigrations(db.PendingMigrations(ctx)) }),
+		probe(func(ctx context.Context) checkResult { return checkAGE(db.CountGraphNodes(ctx)) }),
+	}
+}
+
+// probe runs fn with a doctorTimeout context.
+func probe(fn func(ctx context.Context) checkResult) checkResult {
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+func printDoctorReport(w io.Writer, results []checkResult) int {
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.status]++
+		fmt.Fprintf(w, "%-4s  %-10s  %s\n", r.status, r.name, r.detail)
+	}
+	fmt.Fprintln(w)
+	if counts[checkFail] > 0 {
+		fmt.Fprintf(w, "NOT READY: %d failed, %d warnings\n", counts[checkFail], counts[checkWarn])
+		return 1
+	}
+	if counts[checkWarn] > 0 {
+		fmt.Fprintf(w, "READY with %d warnings (optional features degraded)\n", counts[checkWarn])
+		return 0
+	}
+	fmt.Fprintln(w, "READY")
+	return 0
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/anatolykoptev/go-stealth/proxypool"
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestDoctorChecks(t *testing.T) {
+	ctx := context.Background()
+	c := &engine.Config{
+		LLMModel: "m", LLMAPIBase: "http://llm", SearxngURL: "http://searx",
+		MemDBURL: "http://memdb", MemDBServiceSecret: "s",
+	}
+	noMemDB := &engine.Config{MemDBURL: "http://memdb"}
+	ok := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return errors.New("refused") }
+	notConfigured := func(context.Context) error { return engine.ErrNotConfigured }
+	proxyOK := func(context.Context, string) (string, error) { return "p1:80", nil }
+	proxyFail := func(context.Context, string) (string, error) { return "p1:80", errors.New("timeout") }
+	pool := proxypool.NewStatic("http://p1:80", "http://p2:80")
+
+	tests := []struct {
+		name       string
+		got        checkResult
+		wantStatus string
+		wantDetail string
+	}{
+		{"llm ok", checkLLM(ctx, c, ok), checkOK, "m answered"},
+		{"llm fail", checkLLM(ctx, c, fail), checkFail, "m (http://llm): refused"},
+		{"searxng ok", checkSearXNG(ctx, c, ok), checkOK, "http://searx"},
+		{"searxng unset", checkSearXNG(ctx, c, notConfigured), checkFail, "SEARXNG_URL not set"},
+		{"searxng down", checkSearXNG(ctx, c, fail), checkFail, "http://searx: refused"},
+		{"memdb ok", checkMemDB(ctx, c, ok), checkOK, "http://memdb"},
+		{"memdb down", checkMemDB(ctx, c, fail), checkWarn, "refused"},
+		{"memdb no secret", checkMemDB(ctx, noMemDB, fail), checkSkip, "INTERNAL_SERVICE_SECRET"},
+		{"redis unset", checkRedis(ctx, "", fail), checkSkip, "L1 cache only"},
+		{"redis ok", checkRedis(ctx, "redis://r", ok), checkOK, "reachable"},
+		{"redis down", checkRedis(ctx, "redis://r", fail), checkWarn, "refused"},
+		{"proxy unset", checkProxy(ctx, nil, proxyFail), checkSkip, "WEBSHARE_API_KEY"},
+		{"proxy ok", checkProxy(ctx, pool, proxyOK), checkOK, "p1:80 works (2 in pool)"},
+		{"proxy fail", checkProxy(ctx, pool, proxyFail), checkWarn, "p1:80: timeout"},
+		{"migrations applied", checkMigrations(nil, nil), checkOK, "up to date"},
+		{"migrations pending", checkMigrations([]string{"009_a.sql", "010_b.sql"}, nil), checkWarn, "2 pending, applied on server start: 009_a.sql, 010_b.sql"},
+		{"migrations unreadable", checkMigrations(nil, errors.New("permission denied")), checkFail, "permission denied"},
+		{"age ok", checkAGE(12, nil), checkOK, "12 nodes"},
+		{"age missing", checkAGE(0, errors.New("no graph")), checkWarn, "resume graph disabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.status != tt.wantStatus || !strings.Contains(tt.got.detail, tt.wantDetail) {
+				t.Errorf("got %s %q, want %s containing %q", tt.got.status, tt.got.detail, tt.wantStatus, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestPrintDoctorReport(t *testing.T) {
+	tests := []struct {
+		statuses []string
+		wantCode int
+		wantLast string
+	}{
+		{[]string{checkOK, checkSkip}, 0, "READY"},
+		{[]string{checkOK, checkWarn}, 0, "READY with 1 warnings (optional features degraded)"},
+		{[]string{checkWarn, checkFail}, 1, "NOT READY: 1 failed, 1 warnings"},
+	}
+	for _, tt := range tests {
+		var results []checkResult
+		for _, s := range tt.statuses {
+			results = append(results, checkResult{"x", s, "d"})
+		}
+		var buf bytes.Buffer
+		if code := printDoctorReport(&buf, results); code != tt.wantCode {
+			t.Errorf("%v: exit code %d, want %d", tt.statuses, code, tt.wantCode)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if last := lines[len(lines)-1]; last != tt.wantLast {
+			t.Errorf("%v: last line %q, want %q", tt.statuses, last, tt.wantLast)
+		}
+	}
+}
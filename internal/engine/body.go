@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/anatolykoptev/go-engine/text"
+)
+
+// Memory bounds for multi-source fan-outs such as job_search platform=all, where every
+// source holds a response body and its parsed listings at the same time.
+
+// bodyPool recycles response buffers between source fetches.
+var bodyPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// maxPooledBody keeps buffers grown by unusually large bodies out of the pool.
+const maxPooledBody = 8 << 20
+
+// ReadBody reads at most limit bytes of r into a pooled buffer and calls parse with
+// them. Bytes past limit are dropped, as with io.LimitReader. The slice is only valid
+// during parse: keep nothing that aliases it (json, xml and html decoding copy).
+func ReadBody(r io.Reader, limit int64, parse func(body []byte) error) error {
+	buf := bodyPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBody {
+			bodyPool.Put(buf)
+		}
+	}()
+	if _, err := buf.ReadFrom(io.LimitReader(r, limit)); err != nil {
+		return err
+	}
+	return parse(buf.Bytes())
+}
+
+// TrimContent cuts s to the per-source token budget the summarizer applies anyway, so
+// oversized descriptions are dropped before merge rather than after.
+func TrimContent(s string, contentLimit int) string {
+	if contentLimit <= 0 {
+		return s
+	}
+	return text.TruncateToTokenBudget(s, contentLimit, defaultCharsPerToken)
+}
+
+// TrimSourceResults keeps at most maxResults results and trims each Content to
+// contentLimit tokens.
+func TrimSourceResults(results []SearxngResult, maxResults, contentLimit int) []SearxngResult {
+	if maxResults > 0 && len(results) > maxResults {
+		// Copy so the dropped tail is not kept alive by the backing array.
+		results = append([]SearxngResult(nil), results[:maxResults]...)
+	}
+	for i := range results {
+		results[i].Content = TrimContent(results[i].Content, contentLimit)
+	}
+	return results
+}
+
+// AcquireSourceSlot blocks until one of Config.MaxSourceFetches source fetch slots
+// of the engine bound to ctx is free, bounding the bodies in memory under concurrent
+// fan-outs. Call release when the source's results are trimmed. A limit <= 0
+// disables the cap.
+func AcquireSourceSlot(ctx context.Context) (release func(), err error) {
+	return From(ctx).sources.Acquire(ctx)
+}
@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadBodyLimit(t *testing.T) {
+	var got string
+	err := ReadBody(strings.NewReader("abcdefgh"), 5, func(body []byte) error {
+		got = string(body)
+		return nil
+	})
+	if err != nil || got != "abcde" {
+		t.Fatalf("ReadBody = %q, %v; want the first 5 bytes", got, err)
+	}
+	// A recycled buffer must not leak the previous body.
+	_ = ReadBody(strings.NewReader("xy"), 5, func(body []byte) error {
+		got = string(body)
+		return nil
+	})
+	if got != "xy" {
+		t.Errorf("second ReadBody = %q, want %q", got, "xy")
+	}
+}
+
+func TestTrimSourceResults(t *testing.T) {
+	long := strings.Repeat("word ", 1000) // 5000 bytes
+	in := []SearxngResult{{Content: long}, {Content: "short"}, {Content: "dropped"}}
+	out := TrimSourceResults(in, 2, 100)
+	if len(out) != 2 {
+		t.Fatalf("kept %d results, want 2", len(out))
+	}
+	if n := len(out[0].Content); n > 100*defaultCharsPerToken {
+		t.Errorf("content not trimmed: %d bytes", n)
+	}
+	if out[1].Content != "short" {
+		t.Errorf("short content changed: %q", out[1].Content)
+	}
+	if got := TrimContent(long, 0); got != long {
+		t.Error("a zero limit must leave content alone")
+	}
+}
@@ -7,8 +7,6 @@ package engine
 import (
 	"context"
 	"net/http"
-	"net/url"
-	"strings"
 
 	stealth "github.com/anatolykoptev/go-stealth"
 
@@ -26,6 +24,9 @@ type BrowserClient = stealth.BrowserClient
 // RetryConfig controls retry behavior.
 type RetryConfig = fetch.RetryConfig
 
+// HTTPStatusError is returned by page fetches for a non-OK HTTP status.
+type HTTPStatusError = fetch.HttpStatusError
+
 // DefaultRetryConfig is suitable for most HTTP calls.
 var DefaultRetryConfig = fetch.DefaultRetryConfig
 
@@ -96,54 +97,30 @@ func ExtractLibraryName(query string) string { return text.ExtractLibraryName(qu
 
 // ---- Fetch + Extract ----
 
-// FetchURLContent extracts main text content from a URL.
+// FetchURLContent extracts main text content from a URL using the engine bound to ctx.
 // Returns (title, content, error). Falls back through extraction tiers.
 func FetchURLContent(ctx context.Context, rawURL string) (title, content string, err error) {
-	reg.Incr(MetricFetchRequests)
-	defer func() {
-		if err != nil {
-			reg.Incr(MetricFetchErrors)
-		}
-	}()
-
-	ctx, cancel := context.WithTimeout(ctx, cfg.FetchTimeout)
-	defer cancel()
-
-	body, err := fetcherProxy.FetchBody(ctx, rawURL)
-	if err != nil {
-		return "", "", err
-	}
+	defer TrackPhase(ctx, PhaseFetch)()
+	return From(ctx).FetchURLContent(ctx, rawURL)
+}
 
-	parsedURL, _ := url.Parse(rawURL)
-	result, err := extractorInst.Extract(ctx, body, parsedURL)
-	if err != nil {
-		return "", "", err
-	}
+// FetchJobPage extracts the title and JD text of a job posting page using the engine
+// bound to ctx: the JobPosting JSON-LD when present, else the main content without page chrome.
+func FetchJobPage(ctx context.Context, rawURL string) (title, content string, err error) {
+	defer TrackPhase(ctx, PhaseFetch)()
+	return From(ctx).FetchJobPage(ctx, rawURL)
+}
 
-	txt := result.Content
-	txt = strings.TrimSpace(txt)
-	if len(txt) > cfg.MaxContentChars {
-		txt = txt[:cfg.MaxContentChars] + "..."
-	}
-	return result.Title, txt, nil
+// FetchJobPageHTML is FetchJobPage that also returns the raw page HTML.
+func FetchJobPageHTML(ctx context.Context, rawURL string) (title, content, rawHTML string, err error) {
+	defer TrackPhase(ctx, PhaseFetch)()
+	return From(ctx).FetchJobPageHTML(ctx, rawURL)
 }
 
-// FetchRawContent fetches a URL as plain text (no readability extraction).
-// Uses direct fetcher (no proxy) for API-like endpoints.
+// FetchRawContent fetches a URL as plain text (no readability extraction) using the engine bound to ctx.
 func FetchRawContent(ctx context.Context, rawURL string) (string, error) {
-	ctx, cancel := context.WithTimeout(ctx, cfg.FetchTimeout)
-	defer cancel()
-
-	body, err := fetcherDirect.FetchBody(ctx, rawURL)
-	if err != nil {
-		return "", err
-	}
-
-	txt := strings.TrimSpace(string(body))
-	if len(txt) > cfg.MaxContentChars {
-		txt = txt[:cfg.MaxContentChars] + "..."
-	}
-	return txt, nil
+	defer TrackPhase(ctx, PhaseFetch)()
+	return From(ctx).FetchRawContent(ctx, rawURL)
 }
 
 // ---- Output ----
@@ -69,7 +69,8 @@ func FetchContentsParallel(ctx context.Context, results []SearxngResult, skipURL
 			continue
 		}
 		wg.Add(1)
-		go func(u string) {
+		u := r.URL
+		GoOutbound(ctx, func() {
 			defer wg.Done()
 			_, text, err := FetchURLContent(ctx, u)
 			if err == nil && text != "" {
@@ -77,7 +78,7 @@ func FetchContentsParallel(ctx context.Context, results []SearxngResult, skipURL
 				contents[u] = text
 				mu.Unlock()
 			}
-		}(r.URL)
+		})
 	}
 	wg.Wait()
 	return contents
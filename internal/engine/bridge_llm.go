@@ -4,6 +4,7 @@ package engine
 
 import (
 	"context"
+	"strings"
 
 	"github.com/anatolykoptev/go-engine/llm"
 )
@@ -11,30 +12,36 @@ import (
 // defaultCharsPerToken is the average characters per LLM token for budget estimation.
 const defaultCharsPerToken = 3.5
 
-// CallLLM sends a prompt using the configured temperature and max_tokens.
+// CallLLM sends a prompt to the engine bound to ctx using the configured temperature and max_tokens.
 func CallLLM(ctx context.Context, prompt string) (string, error) {
-	reg.Incr(MetricLLMCalls)
-	raw, err := llmInst.Complete(ctx, prompt)
-	if err != nil {
-		reg.Incr(MetricLLMErrors)
-		return "", err
-	}
-	return raw, nil
+	defer TrackPhase(ctx, PhaseLLM)()
+	raw, err := From(ctx).CallLLM(ctx, prompt)
+	recordLLM(ctx, len(prompt), len(raw))
+	return raw, err
 }
 
 // RewriteQuery uses the LLM to convert a conversational query into search form.
 func RewriteQuery(ctx context.Context, query string) string {
-	return llmInst.RewriteQuery(ctx, query)
+	defer TrackPhase(ctx, PhaseLLM)()
+	out := From(ctx).llm.RewriteQuery(ctx, query)
+	recordLLM(ctx, len(query), len(out))
+	return out
 }
 
 // ExpandSearchQueries generates semantically diverse query variants.
 func ExpandSearchQueries(ctx context.Context, query string, n int) ([]string, error) {
-	return llmInst.ExpandSearchQueries(ctx, query, n)
+	defer TrackPhase(ctx, PhaseLLM)()
+	out, err := From(ctx).llm.ExpandSearchQueries(ctx, query, n)
+	recordLLM(ctx, len(query), len(strings.Join(out, "\n")))
+	return out, err
 }
 
 // ExpandWebSearchQueries generates diverse web search query variants.
 func ExpandWebSearchQueries(ctx context.Context, query string, n int) ([]string, error) {
-	return llmInst.ExpandWebSearchQueries(ctx, query, n)
+	defer TrackPhase(ctx, PhaseLLM)()
+	out, err := From(ctx).llm.ExpandWebSearchQueries(ctx, query, n)
+	recordLLM(ctx, len(query), len(strings.Join(out, "\n")))
+	return out, err
 }
 
 // BuildSourcesText formats search results and fetched content for LLM context.
@@ -44,20 +51,53 @@ func BuildSourcesText(results []SearxngResult, contents map[string]string, conte
 
 // summarizeWithLLM builds context from search results and calls the LLM API.
 func summarizeWithLLM(ctx context.Context, query string, results []SearxngResult, contents map[string]string) (*LLMStructuredOutput, error) {
-	return llmInst.Summarize(ctx, query, cfg.MaxContentChars, defaultCharsPerToken, results, contents)
+	defer TrackPhase(ctx, PhaseLLM)()
+	e := From(ctx)
+	out, err := e.llm.Summarize(ctx, query, e.cfg.MaxContentChars, defaultCharsPerToken, results, contents)
+	recordSummarize(ctx, query, "", e.cfg.MaxContentChars, results, contents, out)
+	return out, err
 }
 
 // SummarizeWithInstruction summarizes search results using a custom instruction.
 func SummarizeWithInstruction(ctx context.Context, query, instruction string, contentLimit int, results []SearxngResult, contents map[string]string) (*LLMStructuredOutput, error) {
-	return llmInst.SummarizeWithInstruction(ctx, query, instruction, contentLimit, defaultCharsPerToken, results, contents)
+	defer TrackPhase(ctx, PhaseLLM)()
+	out, err := From(ctx).llm.SummarizeWithInstruction(ctx, query, instruction, contentLimit, defaultCharsPerToken, results, contents)
+	recordSummarize(ctx, query, instruction, contentLimit, results, contents, out)
+	return out, err
 }
 
 // SummarizeDeep summarizes using exhaustive fact extraction.
 func SummarizeDeep(ctx context.Context, query, instruction string, contentLimit int, results []SearxngResult, contents map[string]string) (*LLMStructuredOutput, error) {
-	return llmInst.SummarizeDeep(ctx, query, instruction, contentLimit, defaultCharsPerToken, results, contents)
+	defer TrackPhase(ctx, PhaseLLM)()
+	out, err := From(ctx).llm.SummarizeDeep(ctx, query, instruction, contentLimit, defaultCharsPerToken, results, contents)
+	recordSummarize(ctx, query, instruction, contentLimit, results, contents, out)
+	return out, err
 }
 
 // SummarizeToJSON builds an LLM prompt from search results and parses as JSON.
 func SummarizeToJSON[T any](ctx context.Context, query, instruction string, contentLimit int, results []SearxngResult, contents map[string]string) (*T, string, error) {
-	return llm.SummarizeToJSON[T](ctx, llmInst, query, instruction, contentLimit, defaultCharsPerToken, results, contents)
+	defer TrackPhase(ctx, PhaseLLM)()
+	out, raw, err := llm.SummarizeToJSON[T](ctx, From(ctx).llm, query, instruction, contentLimit, defaultCharsPerToken, results, contents)
+	recordLLM(ctx, summarizePromptChars(query, instruction, contentLimit, results, contents), len(raw))
+	return out, raw, err
+}
+
+// recordSummarize records a summarization call in the call metadata.
+func recordSummarize(ctx context.Context, query, instruction string, contentLimit int, results []SearxngResult, contents map[string]string, out *LLMStructuredOutput) {
+	if callMetaFrom(ctx) == nil {
+		return
+	}
+	completion := 0
+	if out != nil {
+		completion = len(out.Answer)
+		for _, f := range out.Facts {
+			completion += len(f.Point)
+		}
+	}
+	recordLLM(ctx, summarizePromptChars(query, instruction, contentLimit, results, contents), completion)
+}
+
+// summarizePromptChars approximates the size of a summarization prompt.
+func summarizePromptChars(query, instruction string, contentLimit int, results []SearxngResult, contents map[string]string) int {
+	return len(query) + len(instruction) + len(BuildSourcesText(results, contents, contentLimit))
 }
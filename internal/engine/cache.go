@@ -8,40 +8,29 @@ import (
 	"github.com/anatolykoptev/go-kit/cache"
 )
 
-// searchCache is the singleton cache instance.
-var searchCache *cache.Cache
-
-// CacheTTL controls how long results stay cached.
-var CacheTTL = 15 * time.Minute
+// defaultCacheTTL is how long results stay cached until InitCache sets the TTL.
+const defaultCacheTTL = 15 * time.Minute
 
 // JobDetailsTTL controls how long job details stay cached (descriptions rarely change).
 var JobDetailsTTL = 24 * time.Hour
 
-// InitCache sets up the 2-tier cache. Call after Init().
-// redisURL can be empty to disable L2.
-func InitCache(redisURL string, ttl time.Duration, maxEntries int, _ time.Duration) {
-	CacheTTL = ttl
-	searchCache = cache.New(cache.Config{
-		RedisURL:      redisURL,
-		Prefix:        "gj:",
-		L1MaxItems:    maxEntries,
-		L1TTL:         ttl,
-		L2TTL:         ttl,
-		JitterPercent: 0.1,
-	})
-}
+// LookupTTL controls how long name → ID resolutions (LinkedIn geo/company IDs) stay cached.
+var LookupTTL = 30 * 24 * time.Hour
 
 // CacheKey builds a deterministic cache key from parts.
 func CacheKey(parts ...string) string {
 	return cache.Key(parts...)
 }
 
-// CacheGet tries L1, then L2. Returns the cached SmartSearchOutput and true on hit.
+// CacheGet tries L1, then L2 of the engine bound to ctx. Returns the cached
+// SmartSearchOutput and true on hit.
 func CacheGet(ctx context.Context, key string) (SmartSearchOutput, bool) {
-	if searchCache == nil {
+	c := From(ctx).cache
+	if c == nil {
 		return SmartSearchOutput{}, false
 	}
-	data, ok := searchCache.Get(ctx, key)
+	data, ok := c.Get(ctx, key)
+	recordCache(ctx, ok)
 	if !ok {
 		return SmartSearchOutput{}, false
 	}
@@ -54,45 +43,81 @@ func CacheGet(ctx context.Context, key string) (SmartSearchOutput, bool) {
 
 // CacheSet stores value in both L1 and L2.
 func CacheSet(ctx context.Context, key string, value SmartSearchOutput) {
-	if searchCache == nil {
+	c := From(ctx).cache
+	if c == nil {
 		return
 	}
 	data, err := json.Marshal(value)
 	if err != nil {
 		return
 	}
-	searchCache.Set(ctx, key, data)
+	c.Set(ctx, key, data)
 }
 
 // CacheStats returns current cache hit/miss counters.
-func CacheStats() (hits, misses int64) {
-	if searchCache == nil {
+func (e *Engine) CacheStats() (hits, misses int64) {
+	if e.cache == nil {
 		return 0, 0
 	}
-	s := searchCache.Stats()
+	s := e.cache.Stats()
 	return s.L1Hits + s.L2Hits, s.L1Misses + s.L2Misses
 }
 
-// CacheGetJobDetails retrieves cached job details by URL.
+// CacheGetJobDetails retrieves cached job details by canonical job URL.
 func CacheGetJobDetails(ctx context.Context, jobURL string) (string, bool) {
-	if searchCache == nil {
+	c := From(ctx).cache
+	if c == nil || ctx.Value(freshJobDetailsKey{}) != nil {
 		return "", false
 	}
-	key := CacheKey("jd", jobURL)
-	data, ok := searchCache.Get(ctx, key)
+	key := CacheKey("jd", CanonicalJobURL(jobURL))
+	data, ok := c.Get(ctx, key)
+	recordCache(ctx, ok)
 	if !ok {
 		return "", false
 	}
 	return string(data), true
 }
 
-// CacheSetJobDetails stores job details by URL.
+type freshJobDetailsKey struct{}
+
+// WithFreshJobDetails returns a context in which job details lookups miss, so postings
+// are re-fetched; the fresh results are still cached.
+func WithFreshJobDetails(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshJobDetailsKey{}, true)
+}
+
+// CacheSetJobDetails stores job details by canonical job URL.
 func CacheSetJobDetails(ctx context.Context, jobURL, details string) {
-	if searchCache == nil {
+	c := From(ctx).cache
+	if c == nil {
+		return
+	}
+	key := CacheKey("jd", CanonicalJobURL(jobURL))
+	c.SetWithTTL(ctx, key, []byte(details), JobDetailsTTL)
+}
+
+// CacheGetLookup retrieves a cached name → ID resolution of the given kind.
+// A hit with an empty value means the name is known not to resolve.
+func CacheGetLookup(ctx context.Context, kind, name string) (string, bool) {
+	c := From(ctx).cache
+	if c == nil {
+		return "", false
+	}
+	data, ok := c.Get(ctx, CacheKey("lookup", kind, name))
+	recordCache(ctx, ok)
+	if !ok {
+		return "", false
+	}
+	return string(data), true
+}
+
+// CacheSetLookup stores a name → ID resolution for LookupTTL.
+func CacheSetLookup(ctx context.Context, kind, name, id string) {
+	c := From(ctx).cache
+	if c == nil {
 		return
 	}
-	key := CacheKey("jd", jobURL)
-	searchCache.SetWithTTL(ctx, key, []byte(details), JobDetailsTTL)
+	c.SetWithTTL(ctx, CacheKey("lookup", kind, name), []byte(id), LookupTTL)
 }
 
 // CacheLoadJSON tries to load a cached value of type T from the engine cache.
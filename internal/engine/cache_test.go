@@ -32,11 +32,17 @@ func TestCacheKey(t *testing.T) {
 	})
 }
 
-func TestCacheGetSet(t *testing.T) {
-	InitCache("", 1*time.Minute, 100, 5*time.Minute)
-	defer searchCache.Close()
+// cacheEngine returns a context bound to a fresh engine with an L1-only cache.
+func cacheEngine(t *testing.T, ttl time.Duration, maxEntries int) (context.Context, *Engine) {
+	t.Helper()
+	e := New(Config{})
+	e.InitCache("", ttl, maxEntries)
+	t.Cleanup(func() { e.cache.Close() })
+	return WithEngine(context.Background(), e), e
+}
 
-	ctx := context.Background()
+func TestCacheGetSet(t *testing.T) {
+	ctx, _ := cacheEngine(t, 1*time.Minute, 100)
 	key := CacheKey("test", "round-trip")
 
 	// Miss
@@ -60,10 +66,7 @@ func TestCacheGetSet(t *testing.T) {
 }
 
 func TestCacheExpiration(t *testing.T) {
-	InitCache("", 1*time.Millisecond, 100, 5*time.Minute)
-	defer searchCache.Close()
-
-	ctx := context.Background()
+	ctx, _ := cacheEngine(t, 1*time.Millisecond, 100)
 	key := CacheKey("test", "expiry")
 
 	CacheSet(ctx, key, SmartSearchOutput{Answer: "temp"})
@@ -76,10 +79,7 @@ func TestCacheExpiration(t *testing.T) {
 }
 
 func TestCacheEviction(t *testing.T) {
-	InitCache("", 1*time.Minute, 3, 5*time.Minute)
-	defer searchCache.Close()
-
-	ctx := context.Background()
+	ctx, e := cacheEngine(t, 1*time.Minute, 3)
 
 	// Add 5 entries
 	for i := 0; i < 5; i++ {
@@ -87,23 +87,20 @@ func TestCacheEviction(t *testing.T) {
 		CacheSet(ctx, key, SmartSearchOutput{Answer: fmt.Sprintf("v%d", i)})
 	}
 
-	s := searchCache.Stats()
+	s := e.cache.Stats()
 	if s.L1Size > 3 {
 		t.Errorf("expected at most 3 entries after eviction, got %d", s.L1Size)
 	}
 }
 
 func TestCacheStats(t *testing.T) {
-	InitCache("", 1*time.Minute, 100, 5*time.Minute)
-	defer searchCache.Close()
-
-	ctx := context.Background()
+	ctx, e := cacheEngine(t, 1*time.Minute, 100)
 	key := CacheKey("stats", "test")
 
 	// Miss
 	CacheGet(ctx, key)
 	var hits, misses int64
-	_, misses = CacheStats()
+	_, misses = e.CacheStats()
 	if misses != 1 {
 		t.Errorf("misses = %d, want 1", misses)
 	}
@@ -112,7 +109,7 @@ func TestCacheStats(t *testing.T) {
 	CacheSet(ctx, key, SmartSearchOutput{Answer: "x"})
 	CacheGet(ctx, key)
 
-	hits, misses = CacheStats()
+	hits, misses = e.CacheStats()
 	if hits != 1 {
 		t.Errorf("hits = %d, want 1", hits)
 	}
@@ -122,10 +119,7 @@ func TestCacheStats(t *testing.T) {
 }
 
 func TestCacheJobDetails(t *testing.T) {
-	InitCache("", 1*time.Minute, 100, 5*time.Minute)
-	defer searchCache.Close()
-
-	ctx := context.Background()
+	ctx, _ := cacheEngine(t, 1*time.Minute, 100)
 
 	// Miss
 	_, ok := CacheGetJobDetails(ctx, "https://example.com/job/123")
@@ -145,10 +139,7 @@ func TestCacheJobDetails(t *testing.T) {
 }
 
 func TestCacheLoadStoreJSON(t *testing.T) {
-	InitCache("", 1*time.Minute, 100, 5*time.Minute)
-	defer searchCache.Close()
-
-	ctx := context.Background()
+	ctx, _ := cacheEngine(t, 1*time.Minute, 100)
 	key := CacheKey("json", "test")
 
 	type payload struct {
@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Phase names reported in ToolMeta.PhasesMS.
+const (
+	PhaseSources = "sources"
+	PhaseSearch  = "search"
+	PhaseFetch   = "fetch"
+	PhaseLLM     = "llm"
+)
+
+// ToolMeta is the cost/latency block attached to every tool result (under _meta.go_job).
+type ToolMeta struct {
+	ElapsedMS        int64            `json:"elapsed_ms"`
+	PhasesMS         map[string]int64 `json:"phases_ms,omitempty"` // wall time with at least one call of the phase in flight
+	LLMCalls         int              `json:"llm_calls,omitempty"`
+	PromptTokens     int              `json:"prompt_tokens_est,omitempty"` // estimated from characters, the LLM client does not report usage
+	CompletionTokens int              `json:"completion_tokens_est,omitempty"`
+	CacheHits        int              `json:"cache_hits,omitempty"`
+	CacheMisses      int              `json:"cache_misses,omitempty"`
+	Sources          []string         `json:"sources,omitempty"`
+}
+
+// CallMeta accumulates ToolMeta for one tool call. All methods are safe for
+// concurrent use and no-ops on a nil receiver.
+type CallMeta struct {
+	mu      sync.Mutex
+	start   time.Time
+	phases  map[string]time.Duration
+	active  map[string]int
+	since   map[string]time.Time
+	meta    ToolMeta
+	sources map[string]bool
+}
+
+type callMetaKey struct{}
+
+// WithCallMeta returns a context that collects call metadata, and the collector.
+func WithCallMeta(ctx context.Context) (context.Context, *CallMeta) {
+	m := &CallMeta{
+		start:   time.Now(),
+		phases:  make(map[string]time.Duration),
+		active:  make(map[string]int),
+		since:   make(map[string]time.Time),
+		sources: make(map[string]bool),
+	}
+	return context.WithValue(ctx, callMetaKey{}, m), m
+}
+
+func callMetaFrom(ctx context.Context) *CallMeta {
+	m, _ := ctx.Value(callMetaKey{}).(*CallMeta)
+	return m
+}
+
+// TrackPhase marks the start of a phase and returns the function that ends it:
+//
+//	defer engine.TrackPhase(ctx, engine.PhaseFetch)()
+//
+// Overlapping calls of one phase are counted once, so concurrent fetches report wall time.
+func TrackPhase(ctx context.Context, phase string) func() {
+	m := callMetaFrom(ctx)
+	if m == nil {
+		return func() {}
+	}
+	m.mu.Lock()
+	if m.active[phase] == 0 {
+		m.since[phase] = time.Now()
+	}
+	m.active[phase]++
+	m.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.active[phase]--
+			if m.active[phase] == 0 {
+				m.phases[phase] += time.Since(m.since[phase])
+			}
+		})
+	}
+}
+
+// RecordSource notes that a data source was consulted during the call.
+func RecordSource(ctx context.Context, name string) {
+	m := callMetaFrom(ctx)
+	if m == nil || name == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.sources[name] {
+		m.sources[name] = true
+		m.meta.Sources = append(m.meta.Sources, name)
+	}
+}
+
+// recordLLM counts an LLM call with token estimates from prompt and completion sizes.
+func recordLLM(ctx context.Context, promptChars, completionChars int) {
+	m := callMetaFrom(ctx)
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.meta.LLMCalls++
+	m.meta.PromptTokens += int(float64(promptChars) / defaultCharsPerToken)
+	m.meta.CompletionTokens += int(float64(completionChars) / defaultCharsPerToken)
+}
+
+// recordCache counts a cache lookup.
+func recordCache(ctx context.Context, hit bool) {
+	m := callMetaFrom(ctx)
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hit {
+		m.meta.CacheHits++
+	} else {
+		m.meta.CacheMisses++
+	}
+}
+
+// Snapshot returns the metadata collected so far. Phases still in flight count up to now.
+func (m *CallMeta) Snapshot() ToolMeta {
+	if m == nil {
+		return ToolMeta{}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := m.meta
+	out.Sources = append([]string(nil), m.meta.Sources...)
+	out.ElapsedMS = time.Since(m.start).Milliseconds()
+	now := time.Now()
+	for phase, d := range m.phases {
+		if out.PhasesMS == nil {
+			out.PhasesMS = make(map[string]int64)
+		}
+		out.PhasesMS[phase] = d.Milliseconds()
+	}
+	for phase, n := range m.active {
+		if n > 0 {
+			if out.PhasesMS == nil {
+				out.PhasesMS = make(map[string]int64)
+			}
+			out.PhasesMS[phase] += now.Sub(m.since[phase]).Milliseconds()
+		}
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCallMeta(t *testing.T) {
+	ctx, m := WithCallMeta(context.Background())
+
+	end1 := TrackPhase(ctx, PhaseFetch)
+	end2 := TrackPhase(ctx, PhaseFetch)
+	time.Sleep(20 * time.Millisecond)
+	end1()
+	end1() // idempotent
+	end2()
+	RecordSource(ctx, "linkedin")
+	RecordSource(ctx, "linkedin")
+	RecordSource(ctx, "searxng")
+	recordLLM(ctx, 350, 70)
+	recordCache(ctx, true)
+	recordCache(ctx, false)
+
+	got := m.Snapshot()
+	if fetch := got.PhasesMS[PhaseFetch]; fetch < 20 || fetch > 200 {
+		t.Errorf("overlapping fetches should count wall time once, got %dms", fetch)
+	}
+	if got.LLMCalls != 1 || got.PromptTokens != 100 || got.CompletionTokens != 20 {
+		t.Errorf("llm = %d calls, %d/%d tokens", got.LLMCalls, got.PromptTokens, got.CompletionTokens)
+	}
+	if got.CacheHits != 1 || got.CacheMisses != 1 {
+		t.Errorf("cache = %d hits, %d misses", got.CacheHits, got.CacheMisses)
+	}
+	if len(got.Sources) != 2 || got.Sources[0] != "linkedin" {
+		t.Errorf("sources = %v", got.Sources)
+	}
+	if got.ElapsedMS < got.PhasesMS[PhaseFetch] {
+		t.Errorf("elapsed %dms shorter than fetch phase", got.ElapsedMS)
+	}
+}
+
+func TestCallMeta_NoCollector(t *testing.T) {
+	ctx := context.Background()
+	TrackPhase(ctx, PhaseLLM)()
+	RecordSource(ctx, "x")
+	recordLLM(ctx, 1, 1)
+	recordCache(ctx, true)
+	if got := (*CallMeta)(nil).Snapshot(); got.ElapsedMS != 0 {
+		t.Errorf("nil collector snapshot = %+v", got)
+	}
+}
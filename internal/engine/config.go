@@ -1,15 +1,11 @@
 package engine
 
 import (
-	"log/slog"
 	"net/http"
+	"path"
+	"strings"
 	"time"
 
-	"github.com/anatolykoptev/go-engine/extract"
-	"github.com/anatolykoptev/go-engine/fetch"
-	engllm "github.com/anatolykoptev/go-engine/llm"
-	"github.com/anatolykoptev/go-engine/metrics"
-	"github.com/anatolykoptev/go-engine/search"
 	linkedin "github.com/anatolykoptev/go-linkedin"
 	"github.com/anatolykoptev/go-stealth/proxypool"
 	twitter "github.com/anatolykoptev/go-twitter"
@@ -18,7 +14,10 @@ import (
 
 // Config holds all engine configuration, injected from main.
 type Config struct {
-	SearxngURL                string
+	SearxngURL                string // comma-separated SearXNG instances
+	SearxngEngines            string // SEARXNG_ENGINES weighted engine set, e.g. "bing:3,google:2"
+	BraveAPIKey               string // BRAVE_API_KEY; Brave Search API fallback when SearXNG is down
+	SerpAPIKey                string // SERPAPI_KEY; SerpAPI (Google) fallback when SearXNG is down
 	LLMAPIKey                 string
 	LLMAPIKeyFallbacks        []string
 	LLMAPIBase                string
@@ -43,6 +42,8 @@ type Config struct {
 	DirectBrave               bool                // enable Brave direct scraper
 	DirectReddit              bool                // enable Reddit direct scraper
 	IndeedAPIKey              string              // overrideable via INDEED_API_KEY env
+	USAJobsAPIKey             string              // USAJOBS_API_KEY; developer.usajobs.gov key, empty = usajobs source disabled
+	USAJobsEmail              string              // USAJOBS_EMAIL; the email the key was issued to, sent as User-Agent
 	TwitterClient             *twitter.Client     // nil = Twitter search disabled
 	SocialClient              *social.Client      // nil = go-social disabled, use local twitter
 	LinkedInClient            *linkedin.Client    // nil = LinkedIn tools disabled
@@ -50,6 +51,19 @@ type Config struct {
 	MemDBURL                  string              // MEMDB_URL for vector search
 	MemDBServiceSecret        string              // INTERNAL_SERVICE_SECRET for MemDB auth
 	EmbedURL                  string              // EMBED_URL for direct embedding server
+	ResumeSiteToken           string              // RESUME_SITE_TOKEN; empty = /resume endpoints disabled
+	APIToken                  string              // API_TOKEN; bearer token for the /api/v1 REST facade; empty = disabled
+	HHAccessToken             string              // HH_ACCESS_TOKEN; user OAuth token for hh.ru resume sync
+	HHUserAgent               string              // HH_USER_AGENT; "App/1.0 (contact)" as hh.ru requires
+	NotionToken               string              // NOTION_TOKEN; integration token for job_export format=notion
+	ReadOnly                  bool                // GO_JOB_READONLY; reject tools that change stored data or write files
+	DisabledTools             []string            // GO_JOB_DISABLED_TOOLS; tool names or globs ("resume_*") left unregistered
+	DisabledSources           []string            // GO_JOB_DISABLED_SOURCES; platforms job_search, remote_work_search and freelance_search never query ("craigslist")
+	MaxSourceFetches          int                 // GO_JOB_MAX_SOURCE_FETCHES; concurrent job_search source fetches across calls, separate from MaxOutboundFetches (0 = unlimited)
+	MaxOutboundFetches        int                 // GO_JOB_MAX_OUTBOUND_FETCHES; per-item fetches a source or tool fans out, across all tools (0 = unlimited)
+	FourDayWeekCompanies      []string            // GO_JOB_FOUR_DAY_WEEK_COMPANIES; employers known to work a 4-day week, on top of the built-in list
+	ImpactSalaryDiscount      int                 // GO_JOB_IMPACT_SALARY_DISCOUNT; percent the salary floor/target drop for impact-sector listings (default 20)
+	ReliefWebAppName          string              // GO_JOB_RELIEFWEB_APPNAME; appname sent to the ReliefWeb API (default go_job)
 
 	// Bounty search tuning.
 	BountyHighConfidence float32 // cosine threshold for high-confidence tier (default 0.82)
@@ -64,78 +78,31 @@ type Config struct {
 	BountyNotifyChatID    string        // BOUNTY_NOTIFY_CHAT_ID (default "428660")
 	BountyMonitorInterval time.Duration // BOUNTY_MONITOR_INTERVAL (default 15m)
 
+	// Posting monitor.
+	PostingCheckInterval time.Duration // POSTING_CHECK_INTERVAL (default 24h; 0 disables)
+
 	// Computed fields — populated by Init(), not set by caller.
 	HTTPClient    *http.Client   // plain HTTP client for API calls
 	BrowserClient *BrowserClient // proxy browser client (nil if no proxy)
 }
 
-// Package-level go-engine instances, set by Init().
-var (
-	cfg           Config
-	fetcherProxy  *fetch.Fetcher     // with proxy, for web pages
-	fetcherDirect *fetch.Fetcher     // no proxy, for raw content + internal APIs
-	extractorInst *extract.Extractor // HTML content extraction
-	searxngInst   *search.SearXNG    // SearXNG client
-	llmInst       *engllm.Client     // LLM client
-	reg           *metrics.Registry  // metrics counters
-	httpClient    *http.Client       // plain HTTP client for GitHub API etc.
-)
-
-// Cfg exposes the engine configuration for sub-packages (jobs, sources).
-var Cfg = &cfg
-
-// Init initializes the engine with the given configuration.
-func Init(c Config) {
-	cfg = c
-	Cfg = &cfg
-
-	// Metrics registry.
-	reg = metrics.New()
-
-	// Fetcher with proxy (for web pages, direct scrapers).
-	fetcherOpts := []fetch.Option{fetch.WithTimeout(c.FetchTimeout)}
-	if c.ProxyPool != nil {
-		fetcherOpts = append(fetcherOpts, fetch.WithProxyPool(c.ProxyPool))
-	}
-	fetcherProxy = fetch.New(fetcherOpts...)
-
-	// Fetcher without proxy (for raw content, internal APIs).
-	fetcherDirect = fetch.New(fetch.WithTimeout(c.FetchTimeout))
-
-	// HTML content extractor.
-	extractorInst = extract.New(extract.WithMaxContentLen(c.MaxContentChars))
-
-	// SearXNG client (local, no proxy needed — optional).
-	if c.SearxngURL != "" {
-		searxngInst = search.NewSearXNG(c.SearxngURL, search.WithMetrics(reg))
+// ToolDisabled reports whether GO_JOB_DISABLED_TOOLS matches the tool name, exactly
+// or as a glob.
+func (c *Config) ToolDisabled(name string) bool {
+	for _, p := range c.DisabledTools {
+		if ok, _ := path.Match(strings.TrimSpace(p), name); ok {
+			return true
+		}
 	}
+	return false
+}
 
-	// LLM client.
-	llmOpts := []engllm.Option{
-		engllm.WithAPIBase(c.LLMAPIBase),
-		engllm.WithAPIKey(c.LLMAPIKey),
-		engllm.WithModel(c.LLMModel),
-		engllm.WithTemperature(c.LLMTemperature),
-		engllm.WithMaxTokens(c.LLMMaxTokens),
-		engllm.WithMetrics(reg),
-	}
-	if len(c.LLMAPIKeyFallbacks) > 0 {
-		llmOpts = append(llmOpts, engllm.WithAPIKeyFallbacks(c.LLMAPIKeyFallbacks))
+// SourceDisabled reports whether GO_JOB_DISABLED_SOURCES names the source.
+func (c *Config) SourceDisabled(name string) bool {
+	for _, s := range c.DisabledSources {
+		if strings.EqualFold(strings.TrimSpace(s), name) {
+			return true
+		}
 	}
-	llmInst = engllm.New(llmOpts...)
-
-	// Plain HTTP client for GitHub API and similar direct calls.
-	httpClient = &http.Client{Timeout: 15 * time.Second}
-
-	// Populate computed Config fields for sub-packages (jobs, sources).
-	cfg.HTTPClient = httpClient
-	cfg.BrowserClient = fetcherProxy.BrowserClient()
-
-	slog.Info("engine: initialized",
-		slog.Bool("proxy", c.ProxyPool != nil),
-		slog.Bool("ddg", c.DirectDDG),
-		slog.Bool("startpage", c.DirectStartpage),
-		slog.Bool("brave", c.DirectBrave),
-		slog.Bool("reddit", c.DirectReddit),
-	)
+	return false
 }
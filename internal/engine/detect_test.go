@@ -33,9 +33,6 @@ func TestDetectQueryType(t *testing.T) {
 }
 
 func TestDetectQueryDomain(t *testing.T) {
-	// Init with minimal config so ExtractLibraryName doesn't panic
-	Init(Config{})
-
 	tests := []struct {
 		query string
 		want  QueryDomain
@@ -0,0 +1,232 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anatolykoptev/go-engine/extract"
+	"github.com/anatolykoptev/go-engine/fetch"
+	engllm "github.com/anatolykoptev/go-engine/llm"
+	"github.com/anatolykoptev/go-engine/metrics"
+	"github.com/anatolykoptev/go-kit/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// Engine carries the configuration and every client built from it: fetchers,
+// extractor, SearXNG, LLM, metrics, HTTP client and the search cache.
+// Construct one with New and bind it to the request context with WithEngine;
+// two engines in one process share no state.
+type Engine struct {
+	cfg           Config
+	fetcherProxy  *fetch.Fetcher     // with proxy, for web pages
+	fetcherDirect *fetch.Fetcher     // no proxy, for raw content + internal APIs
+	extractor     *extract.Extractor // HTML content extraction
+	searxng       *searxngPool       // nil when SEARXNG_URL is unset
+	fallback      []SearchProvider   // direct search APIs for SearXNG outages
+	llm           *engllm.Client
+	reg           *metrics.Registry
+	http          *http.Client // plain HTTP client for GitHub API etc.
+	cache         *cache.Cache // nil until InitCache
+	cacheTTL      time.Duration
+	redisProbe    *redis.Client // health pings only; nil without REDIS_URL
+	outbound      *WorkPool     // shared detail-fetch workers; nil = unbounded
+	sources       *WorkPool     // job_search source fetches; nil = unbounded
+}
+
+// New builds an Engine from c. It has no side effects on package state.
+func New(c Config) *Engine {
+	e := &Engine{cfg: c, reg: metrics.New(), cacheTTL: defaultCacheTTL}
+
+	// Fetcher with proxy (for web pages, direct scrapers).
+	fetcherOpts := []fetch.Option{fetch.WithTimeout(c.FetchTimeout)}
+	if c.ProxyPool != nil {
+		fetcherOpts = append(fetcherOpts, fetch.WithProxyPool(c.ProxyPool))
+	}
+	e.fetcherProxy = fetch.New(fetcherOpts...)
+
+	// Fetcher without proxy (for raw content, internal APIs).
+	e.fetcherDirect = fetch.New(fetch.WithTimeout(c.FetchTimeout))
+
+	e.extractor = extract.New(extract.WithMaxContentLen(c.MaxContentChars))
+
+	// SearXNG instances (local, no proxy needed — optional).
+	e.searxng = newSearxngPool(c.SearxngURL, c.SearxngEngines, e.reg)
+
+	llmOpts := []engllm.Option{
+		engllm.WithAPIBase(c.LLMAPIBase),
+		engllm.WithAPIKey(c.LLMAPIKey),
+		engllm.WithModel(c.LLMModel),
+		engllm.WithTemperature(c.LLMTemperature),
+		engllm.WithMaxTokens(c.LLMMaxTokens),
+		engllm.WithMetrics(e.reg),
+	}
+	if len(c.LLMAPIKeyFallbacks) > 0 {
+		llmOpts = append(llmOpts, engllm.WithAPIKeyFallbacks(c.LLMAPIKeyFallbacks))
+	}
+	e.llm = engllm.New(llmOpts...)
+
+	e.http = &http.Client{Timeout: 15 * time.Second}
+	e.fallback = fallbackProviders(c, e.http)
+	e.outbound = newWorkPool("outbound", c.MaxOutboundFetches, e.reg)
+	e.sources = newWorkPool("source", c.MaxSourceFetches, e.reg)
+
+	// Populate computed Config fields for sub-packages (jobs, sources).
+	e.cfg.HTTPClient = e.http
+	e.cfg.BrowserClient = e.fetcherProxy.BrowserClient()
+	return e
+}
+
+type engineKey struct{}
+
+// WithEngine returns a context carrying e. The package-level functions
+// (CallLLM, FetchURLContent, CacheGet, ...) and engine.From read it from there.
+func WithEngine(ctx context.Context, e *Engine) context.Context {
+	return context.WithValue(ctx, engineKey{}, e)
+}
+
+// unconfigured is the engine From returns for a context without one: zero Config,
+// no cache. It is never replaced, so it holds no settings to leak between engines.
+var unconfigured = sync.OnceValue(func() *Engine { return New(Config{}) })
+
+// From returns the engine bound to ctx by WithEngine, or an unconfigured engine
+// (zero Config, no cache) when ctx carries none.
+func From(ctx context.Context) *Engine {
+	if e, ok := ctx.Value(engineKey{}).(*Engine); ok && e != nil {
+		return e
+	}
+	return unconfigured()
+}
+
+// Bound reports whether ctx carries an engine bound with WithEngine.
+func Bound(ctx context.Context) bool {
+	e, ok := ctx.Value(engineKey{}).(*Engine)
+	return ok && e != nil
+}
+
+// ConfigFrom returns the configuration of the engine bound to ctx.
+func ConfigFrom(ctx context.Context) *Config { return From(ctx).Config() }
+
+// LogSettings logs which optional search backends the engine has enabled.
+func (e *Engine) LogSettings() {
+	slog.Info("engine: initialized",
+		slog.Bool("proxy", e.cfg.ProxyPool != nil),
+		slog.Bool("ddg", e.cfg.DirectDDG),
+		slog.Bool("startpage", e.cfg.DirectStartpage),
+		slog.Bool("brave", e.cfg.DirectBrave),
+		slog.Bool("reddit", e.cfg.DirectReddit),
+	)
+}
+
+// Config returns the engine configuration.
+func (e *Engine) Config() *Config { return &e.cfg }
+
+// HTTPClient returns the plain HTTP client for direct API calls.
+func (e *Engine) HTTPClient() *http.Client { return e.http }
+
+// Metrics returns the engine's metrics registry.
+func (e *Engine) Metrics() *metrics.Registry { return e.reg }
+
+// InitCache sets up the 2-tier search cache. redisURL can be empty to disable L2.
+func (e *Engine) InitCache(redisURL string, ttl time.Duration, maxEntries int) {
+	e.cacheTTL = ttl
+	e.cache = cache.New(cache.Config{
+		RedisURL:      redisURL,
+		Prefix:        "gj:",
+		L1MaxItems:    maxEntries,
+		L1TTL:         ttl,
+		L2TTL:         ttl,
+		JitterPercent: 0.1,
+	})
+	e.redisProbe = newRedisProbe(redisURL)
+}
+
+// CallLLM sends a prompt using the configured temperature and max_tokens.
+func (e *Engine) CallLLM(ctx context.Context, prompt string) (string, error) {
+	e.reg.Incr(MetricLLMCalls)
+	raw, err := e.llm.Complete(ctx, prompt)
+	if err != nil {
+		e.reg.Incr(MetricLLMErrors)
+		return "", err
+	}
+	return raw, nil
+}
+
+// SearchSearXNG queries SearXNG and returns raw results. Instances are used round-robin
+// with failover; empty engines selects from the configured engine set (see searxngPool).
+// When every instance fails, or SearXNG is not configured, the fallback providers
+// (BRAVE_API_KEY, SERPAPI_KEY) answer instead. Returns nil, nil when neither is configured.
+func (e *Engine) SearchSearXNG(ctx context.Context, query, language, timeRange, engines string) ([]SearxngResult, error) {
+	if e.searxng != nil {
+		results, err := e.searxng.search(ctx, query, language, timeRange, engines)
+		if err == nil || len(e.fallback) == 0 || ctx.Err() != nil {
+			return results, err
+		}
+		slog.Warn("searxng unavailable, using fallback search", slog.Any("error", err))
+	}
+	if len(e.fallback) == 0 {
+		return nil, nil
+	}
+	return e.searchFallback(ctx, query, language, timeRange)
+}
+
+// HasSearchFallback reports whether a direct search API can stand in for SearXNG.
+func (e *Engine) HasSearchFallback() bool { return len(e.fallback) > 0 }
+
+// HasWebSearch reports whether SearXNG or a fallback search provider is configured.
+func (e *Engine) HasWebSearch() bool {
+	return e.searxng != nil || len(e.fallback) > 0
+}
+
+// FetchURLContent extracts main text content from a URL.
+// Returns (title, content, error). Falls back through extraction tiers.
+func (e *Engine) FetchURLContent(ctx context.Context, rawURL string) (title, content string, err error) {
+	e.reg.Incr(MetricFetchRequests)
+	defer func() {
+		if err != nil {
+			e.reg.Incr(MetricFetchErrors)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.FetchTimeout)
+	defer cancel()
+
+	body, err := e.fetcherProxy.FetchBody(ctx, rawURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	parsedURL, _ := url.Parse(rawURL)
+	result, err := e.extractor.Extract(ctx, body, parsedURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	txt := strings.TrimSpace(result.Content)
+	if len(txt) > e.cfg.MaxContentChars {
+		txt = txt[:e.cfg.MaxContentChars] + "..."
+	}
+	return result.Title, txt, nil
+}
+
+// FetchRawContent fetches a URL as plain text (no readability extraction).
+// Uses direct fetcher (no proxy) for API-like endpoints.
+func (e *Engine) FetchRawContent(ctx context.Context, rawURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.FetchTimeout)
+	defer cancel()
+
+	body, err := e.fetcherDirect.FetchBody(ctx, rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	txt := strings.TrimSpace(string(body))
+	if len(txt) > e.cfg.MaxContentChars {
+		txt = txt[:e.cfg.MaxContentChars] + "..."
+	}
+	return txt, nil
+}
@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNew_IsolatedEngine(t *testing.T) {
+	isolated := New(Config{MaxContentChars: 42, SearxngURL: ""})
+
+	if got := isolated.Config().MaxContentChars; got != 42 {
+		t.Errorf("isolated config MaxContentChars = %d, want 42", got)
+	}
+	if isolated.HTTPClient() == nil || isolated.Config().HTTPClient != isolated.HTTPClient() {
+		t.Error("computed HTTPClient not populated on engine config")
+	}
+
+	// No SearXNG configured: search is a no-op rather than an error.
+	res, err := isolated.SearchSearXNG(context.Background(), "golang", "en", "", "")
+	if err != nil || res != nil {
+		t.Errorf("SearchSearXNG without SearXNG = %v, %v; want nil, nil", res, err)
+	}
+}
+
+func TestWithEngine_EnginesShareNoState(t *testing.T) {
+	a, b := New(Config{MaxContentChars: 7}), New(Config{MaxContentChars: 9})
+	a.InitCache("", defaultCacheTTL, 10)
+	b.InitCache("", defaultCacheTTL, 10)
+	ctxA := WithEngine(context.Background(), a)
+	ctxB := WithEngine(context.Background(), b)
+
+	if From(ctxA) != a || ConfigFrom(ctxB).MaxContentChars != 9 {
+		t.Fatal("context does not carry its engine")
+	}
+	if got := ConfigFrom(context.Background()).MaxContentChars; got != 0 {
+		t.Errorf("context without engine MaxContentChars = %d, want unconfigured 0", got)
+	}
+
+	CacheSet(ctxA, "k", SmartSearchOutput{Query: "q"})
+	if out, ok := CacheGet(ctxA, "k"); !ok || out.Query != "q" {
+		t.Error("cache miss on the engine that stored the key")
+	}
+	if _, ok := CacheGet(ctxB, "k"); ok {
+		t.Error("second engine saw the first engine's cache")
+	}
+
+	IncrHabrRequests(ctxA)
+	if a.GetMetrics()[MetricHabrRequests] != 1 || b.GetMetrics()[MetricHabrRequests] != 0 {
+		t.Error("metrics counted on the wrong engine")
+	}
+}
+
+func TestModuleLogLevels(t *testing.T) {
+	c := ParseLogConfig([]string{"LOG_LEVEL=warn", "LOG_LEVEL_linkedin=debug", "LOG_LEVEL_engine=bogus", "LOG_FORMAT=json", "PATH=/bin"})
+	if c.Level != slog.LevelWarn || c.Modules["linkedin"] != slog.LevelDebug || !c.JSON {
+		t.Fatalf("config = %+v", c)
+	}
+	if len(c.Invalid) != 1 || c.Invalid[0] != "LOG_LEVEL_engine=bogus" {
+		t.Errorf("invalid = %v", c.Invalid)
+	}
+
+	var buf bytes.Buffer
+	logger := NewLogger(&buf, c)
+	logger.Debug("hidden") // engine_test.go is not the linkedin module
+	logger.Warn("shown")
+	if out := buf.String(); strings.Contains(out, "hidden") || !strings.Contains(out, `"msg":"shown"`) {
+		t.Errorf("module level not applied: %s", out)
+	}
+
+	buf.Reset()
+	logger = NewLogger(&buf, ParseLogConfig([]string{"LOG_LEVEL=error", "LOG_LEVEL_engine=debug"}))
+	logger.With(slog.String("k", "v")).Debug("package debug")
+	if out := buf.String(); !strings.Contains(out, "package debug") || !strings.Contains(out, "k=v") {
+		t.Errorf("package module not at debug: %q", out)
+	}
+}
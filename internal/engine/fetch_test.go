@@ -1,6 +1,12 @@
 package engine
 
-import "testing"
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/anatolykoptev/go-engine/extract"
+)
 
 func TestGithubRawURL(t *testing.T) {
 	tests := []struct {
@@ -43,3 +49,34 @@ func TestGithubRawURL(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractJobPage(t *testing.T) {
+	desc := "<p>Acme builds payment rails for small businesses.</p><h3>What you will do</h3><ul><li>Design Go services handling millions of requests</li><li>Own Postgres schemas and migrations</li></ul><h3>Requirements</h3><ul><li>5+ years of backend experience</li><li>Kubernetes in production</li></ul>"
+	page := `<html><head><title>Careers</title><script type="application/ld+json">{"@context":"https://schema.org","@graph":[{"@type":"Organization","name":"Acme"},
+{"@type":"JobPosting","title":"Senior Go Engineer","description":` + jsonString(desc) + `,"hiringOrganization":{"@type":"Organization","name":"Acme"},
+"jobLocationType":"TELECOMMUTE","applicantLocationRequirements":{"@type":"Country","name":"Germany"},"employmentType":["FULL_TIME"],
+"baseSalary":{"@type":"MonetaryAmount","currency":"EUR","value":{"@type":"QuantitativeValue","minValue":80000,"maxValue":100000,"unitText":"YEAR"}}}]}</script></head>
+<body><nav>Jobs | Teams | Sign in</nav><p>We use cookies to improve your experience.</p></body></html>`
+
+	title, text := extractJobPage(context.Background(), extract.New(), []byte(page), nil)
+	if title != "Senior Go Engineer" {
+		t.Errorf("title = %q", title)
+	}
+	for _, want := range []string{"**Company:** Acme", "**Location:** Remote (Germany)", "**Type:** FULL_TIME", "**Salary:** 80000-100000 EUR per year", "- Own Postgres schemas and migrations", "### Requirements"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "cookies") || strings.Contains(text, "Sign in") {
+		t.Errorf("page chrome leaked into JSON-LD text:\n%s", text)
+	}
+
+	cleaned := CleanJobPageText("Skip to main content\n[Jobs](/jobs) | [Teams](/teams)\n\n# Backend Engineer\n\n\n\nWe use cookies on this site.\nBuild APIs in Go.\n\nApply now\n\n## Similar jobs\nFrontend Engineer")
+	if cleaned != "# Backend Engineer\n\nBuild APIs in Go." {
+		t.Errorf("CleanJobPageText = %q", cleaned)
+	}
+}
+
+func jsonString(s string) string {
+	return `"` + strings.NewReplacer(`"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,226 @@
+package engine
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Freelance deduplication: the same project often shows up under several URLs —
+// Upwork and Freelancer.com links with tracking parameters, regional Freelancer
+// domains, and aggregator sites mirroring the description. Results are keyed by a
+// canonical project URL, near-identical descriptions are detected with word shingles,
+// and a matching title merges only with a second signal: the same client or a
+// partly shared description.
+
+const (
+	shingleSize = 3
+	// mirrorSimilarity is the shingle Jaccard similarity above which two descriptions
+	// are treated as the same project.
+	mirrorSimilarity = 0.6
+	// titleSimilarity is the lower shingle similarity that merges two entries with the
+	// same title; a title alone ("Go developer needed") is too common to merge on.
+	titleSimilarity = 0.25
+	// minShingles is the least number of shingles a description needs to be compared;
+	// short snippets share too many stock phrases.
+	minShingles = 8
+)
+
+var (
+	upworkJobIDRe      = regexp.MustCompile(`~0[0-9a-z]{10,}`)
+	freelanceTitleTail = regexp.MustCompile(`(?i)\s*[-|–—]\s*(?:upwork|freelancer(?:\.com)?|freelance jobs?\b.*|hire .*)$`)
+	trackingParams     = []string{"utm_", "ref", "source", "fbclid", "gclid", "referrer", "trk", "src"}
+)
+
+// CanonicalFreelanceURL normalizes a freelance project URL for deduplication: tracking
+// parameters, fragments and "www." are dropped, Upwork links reduce to their job ID
+// (~01…) and Freelancer.com links on any regional domain to the project slug.
+func CanonicalFreelanceURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return strings.ToLower(strings.TrimSpace(raw))
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	path := strings.TrimRight(u.Path, "/")
+
+	if strings.HasSuffix(host, "upwork.com") {
+		if id := upworkJobIDRe.FindString(strings.ToLower(path + "?" + u.RawQuery)); id != "" {
+			return "upwork:" + id
+		}
+	}
+	if host == "freelancer.com" || strings.HasPrefix(host, "freelancer.") {
+		segs := strings.Split(path, "/")
+		if len(segs) >= 3 && segs[1] == "projects" {
+			return "freelancer:" + strings.ToLower(segs[len(segs)-1])
+		}
+	}
+
+	q := u.Query()
+	for k := range q {
+		if isTrackingParam(k, trackingParams) {
+			q.Del(k)
+		}
+	}
+	key := host + strings.ToLower(path)
+	if enc := q.Encode(); enc != "" {
+		key += "?" + enc
+	}
+	return key
+}
+
+// freelanceTitleKey normalizes a project title, dropping platform suffixes such as
+// " - Freelance Job in Web Development - Upwork".
+func freelanceTitleKey(title string) string {
+	for {
+		t := freelanceTitleTail.ReplaceAllString(title, "")
+		if t == title {
+			break
+		}
+		title = t
+	}
+	key := CanonicalJobKey(title, "")
+	if len(key) < 12 { // "|" plus a few words: too generic to merge on
+		return ""
+	}
+	return key
+}
+
+// shingles returns the set of word n-grams of s.
+func shingles(s string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := make(map[string]bool)
+	for i := 0; i+shingleSize <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleSize], " ")] = true
+	}
+	return set
+}
+
+// ShingleSimilarity returns the Jaccard similarity of the word shingles of a and b,
+// or 0 when either is too short to compare.
+func ShingleSimilarity(a, b string) float64 {
+	return shingleJaccard(shingles(a), shingles(b))
+}
+
+func shingleJaccard(a, b map[string]bool) float64 {
+	if len(a) < minShingles || len(b) < minShingles {
+		return 0
+	}
+	inter := 0
+	for s := range a {
+		if b[s] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
+
+// isNativeFreelanceURL reports whether a URL is on Upwork or Freelancer.com rather
+// than an aggregator mirror.
+func isNativeFreelanceURL(raw string) bool {
+	c := CanonicalFreelanceURL(raw)
+	return strings.HasPrefix(c, "upwork:") || strings.HasPrefix(c, "freelancer:")
+}
+
+// freelanceDeduper finds the earlier entry a new item duplicates.
+type freelanceDeduper struct {
+	byURL   map[string]int
+	byTitle map[string][]freelanceEntry
+	texts   []freelanceEntry // entries with enough shingles to compare
+}
+
+// freelanceEntry is a recorded entry with the signals a title match is checked against.
+type freelanceEntry struct {
+	idx    int
+	client string
+	text   map[string]bool
+}
+
+func newFreelanceDeduper() *freelanceDeduper {
+	return &freelanceDeduper{byURL: make(map[string]int), byTitle: make(map[string][]freelanceEntry)}
+}
+
+// match returns the index of the entry the item duplicates, or -1 after recording it
+// as entry idx. client is the poster's details, empty when unknown.
+func (d *freelanceDeduper) match(idx int, rawURL, title, client, text string) int {
+	cu := CanonicalFreelanceURL(rawURL)
+	if i, ok := d.byURL[cu]; ok && cu != "" {
+		return i
+	}
+	e := freelanceEntry{idx: idx, client: strings.ToLower(strings.TrimSpace(client)), text: shingles(text)}
+	tk := freelanceTitleKey(title)
+	if tk != "" {
+		for _, other := range d.byTitle[tk] {
+			if (e.client != "" && e.client == other.client) || shingleJaccard(e.text, other.text) >= titleSimilarity {
+				return other.idx
+			}
+		}
+	}
+	for _, other := range d.texts {
+		if shingleJaccard(e.text, other.text) >= mirrorSimilarity {
+			return other.idx
+		}
+	}
+	if cu != "" {
+		d.byURL[cu] = idx
+	}
+	if tk != "" {
+		d.byTitle[tk] = append(d.byTitle[tk], e)
+	}
+	if len(e.text) >= minShingles {
+		d.texts = append(d.texts, e)
+	}
+	return -1
+}
+
+// DedupFreelanceResults drops search results that repeat an earlier project: same
+// canonical URL, a mirrored description, or the same title with a partly shared
+// description. When a duplicate is on the
+// platform itself and the kept result is an aggregator mirror, the platform URL wins.
+func DedupFreelanceResults(results []SearxngResult) []SearxngResult {
+	d := newFreelanceDeduper()
+	out := make([]SearxngResult, 0, len(results))
+	for _, r := range results {
+		if i := d.match(len(out), r.URL, r.Title, "", r.Content); i >= 0 {
+			if isNativeFreelanceURL(r.URL) && !isNativeFreelanceURL(out[i].URL) {
+				out[i].URL = r.URL
+			}
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// DedupFreelanceProjects merges projects describing the same gig, filling fields
+// missing from the kept entry (budget, skills, client info) from its duplicates.
+func DedupFreelanceProjects(projects []FreelanceProject) []FreelanceProject {
+	d := newFreelanceDeduper()
+	out := make([]FreelanceProject, 0, len(projects))
+	for _, p := range projects {
+		i := d.match(len(out), p.URL, p.Title, p.ClientInfo, p.Description)
+		if i < 0 {
+			out = append(out, p)
+			continue
+		}
+		kept := &out[i]
+		if isNativeFreelanceURL(p.URL) && !isNativeFreelanceURL(kept.URL) {
+			kept.URL, kept.Platform = p.URL, p.Platform
+		}
+		if kept.Budget == "" || kept.Budget == "not specified" {
+			kept.Budget = p.Budget
+		}
+		if len(kept.Skills) == 0 {
+			kept.Skills = p.Skills
+		}
+		if kept.ClientInfo == "" {
+			kept.ClientInfo = p.ClientInfo
+		}
+		if kept.Posted == "" {
+			kept.Posted = p.Posted
+		}
+	}
+	return out
+}
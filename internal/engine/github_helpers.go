@@ -38,10 +38,11 @@ func searchRepoTree(ctx context.Context, owner, repo, filename string) ([]string
 		return nil, err
 	}
 	req.Header.Set("User-Agent", UserAgentBot)
-	if cfg.GithubToken != "" {
-		req.Header.Set("Authorization", "Bearer "+cfg.GithubToken)
+	c := From(ctx).cfg
+	if c.GithubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.GithubToken)
 	}
-	resp, err := cfg.HTTPClient.Do(req) //nolint:gosec // GitHub API URL, intentional outbound request
+	resp, err := c.HTTPClient.Do(req) //nolint:gosec // GitHub API URL, intentional outbound request
 	if err != nil {
 		return nil, err
 	}
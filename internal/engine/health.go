@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNotConfigured is returned by the Ping and Test helpers when the dependency is disabled.
+var ErrNotConfigured = errors.New("not configured")
+
+// newRedisProbe returns a single-connection Redis client used only for health pings.
+func newRedisProbe(redisURL string) *redis.Client {
+	if redisURL == "" {
+		return nil
+	}
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil
+	}
+	opts.PoolSize = 1
+	return redis.NewClient(opts)
+}
+
+// PingRedis checks the Redis L2 cache. Returns ErrNotConfigured without REDIS_URL.
+func (e *Engine) PingRedis(ctx context.Context) error {
+	if e.redisProbe == nil {
+		return ErrNotConfigured
+	}
+	return e.redisProbe.Ping(ctx).Err()
+}
+
+// PingSearXNG checks the SearXNG instances via /healthz; it fails only if none answers.
+func (e *Engine) PingSearXNG(ctx context.Context) error {
+	if e.searxng == nil {
+		return ErrNotConfigured
+	}
+	return e.searxng.ping(ctx, func(ctx context.Context, url string) error {
+		return e.probe(ctx, url, "")
+	})
+}
+
+// PingLLM checks that the LLM API is reachable and accepts the configured key,
+// by listing models (no tokens spent).
+func (e *Engine) PingLLM(ctx context.Context) error {
+	if e.cfg.LLMAPIBase == "" {
+		return ErrNotConfigured
+	}
+	return e.probe(ctx, strings.TrimRight(e.cfg.LLMAPIBase, "/")+"/models", e.cfg.LLMAPIKey)
+}
+
+// ProxyPoolSize returns the number of proxies available, or -1 when no pool is configured.
+func (e *Engine) ProxyPoolSize() int {
+	if e.cfg.ProxyPool == nil {
+		return -1
+	}
+	return e.cfg.ProxyPool.Len()
+}
+
+// probe GETs url and expects a 2xx response.
+func (e *Engine) probe(ctx context.Context, url, bearer string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	resp, err := e.http.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// TestLLM sends a 1-token completion, proving the key, model and endpoint all work.
+func (e *Engine) TestLLM(ctx context.Context) error {
+	_, err := e.llm.CompleteParams(ctx, "Reply with OK.", 0, 1)
+	return err
+}
+
+// TestProxy fetches a small page through the next proxy in the pool and returns
+// the proxy host it used.
+func (e *Engine) TestProxy(ctx context.Context, target string) (string, error) {
+	if e.cfg.ProxyPool == nil {
+		return "", ErrNotConfigured
+	}
+	raw := e.cfg.ProxyPool.Next()
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("parse proxy: %w", err)
+	}
+	client := &http.Client{
+		Timeout:   e.cfg.FetchTimeout,
+		Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return proxyURL.Host, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return proxyURL.Host, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return proxyURL.Host, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return proxyURL.Host, nil
+}
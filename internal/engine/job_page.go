@@ -0,0 +1,340 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	htmltomarkdown "github.com/JohannesKaufmann/html-to-markdown/v2"
+	"github.com/anatolykoptev/go-engine/extract"
+)
+
+// Job page extraction: FetchURLContent is tuned for articles, so job pages come back
+// with navigation, cookie banners, "similar jobs" rails and footers around the JD.
+// FetchJobPage prefers the schema.org JobPosting JSON-LD most job boards and ATS
+// pages embed, and otherwise extracts the main content as markdown (keeping lists
+// and section headings) and drops boilerplate lines.
+
+// minJSONLDDescription is the shortest JSON-LD description used on its own; shorter
+// ones are teasers and are followed by the extracted page text.
+const minJSONLDDescription = 200
+
+// maxJobPageHTML caps the raw HTML FetchJobPageHTML returns.
+const maxJobPageHTML = 2 << 20
+
+var (
+	ldJSONScriptRe = regexp.MustCompile(`(?is)<script[^>]+type\s*=\s*["']application/ld\+json["'][^>]*>(.*?)</script>`)
+	// jobPageTailRe starts the listing rails and footers that follow a JD.
+	jobPageTailRe = regexp.MustCompile(`(?i)^#*\s*(similar|related|recommended|more|other) (jobs|positions|roles|openings)\b|^#*\s*(people also viewed|jobs you may like|explore more jobs)`)
+	// jobPageNoiseRe matches whole lines of page chrome.
+	jobPageNoiseRe  = regexp.MustCompile(`(?i)^(apply( now| for this job)?|easy apply|save( job)?|share( this job)?|report (this )?job|back to (all )?(jobs|search results|careers)|sign in|log in|create (job )?alert|get job alerts.*|skip to (main )?content|menu|search jobs|view all jobs|follow us.*|privacy policy|terms( of (use|service))?|cookie (policy|settings)|accept( all)?( cookies)?|reject all|manage (cookies|preferences)|all rights reserved.*|©.*|copyright .*|powered by .*)$`)
+	jobPageCookieRe = regexp.MustCompile(`(?i)\b(we use cookies|this (web)?site uses cookies|by (continuing|clicking|using)[^.]*cookies)\b`)
+	mdLinkOnlyRe    = regexp.MustCompile(`^[-*]?\s*(\[[^\]]*\]\([^)]*\)\s*[|·•]?\s*)+$`)
+)
+
+// FetchJobPage fetches a job posting page and returns its title and clean JD text.
+func (e *Engine) FetchJobPage(ctx context.Context, rawURL string) (title, content string, err error) {
+	title, content, _, err = e.FetchJobPageHTML(ctx, rawURL)
+	return title, content, err
+}
+
+// FetchJobPageHTML is FetchJobPage that also returns the raw page HTML, capped at
+// maxJobPageHTML bytes.
+func (e *Engine) FetchJobPageHTML(ctx context.Context, rawURL string) (title, content, rawHTML string, err error) {
+	e.reg.Incr(MetricFetchRequests)
+	defer func() {
+		if err != nil {
+			e.reg.Incr(MetricFetchErrors)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.FetchTimeout)
+	defer cancel()
+
+	body, err := e.fetcherProxy.FetchBody(ctx, rawURL)
+	if err != nil {
+		return "", "", "", err
+	}
+	pageURL, _ := url.Parse(rawURL)
+	title, content = extractJobPage(ctx, e.extractor, body, pageURL)
+	if content == "" {
+		return "", "", "", fmt.Errorf("fetch job page: no content extracted from %s", rawURL)
+	}
+	if len(content) > e.cfg.MaxContentChars && e.cfg.MaxContentChars > 0 {
+		content = content[:e.cfg.MaxContentChars] + "..."
+	}
+	if len(body) > maxJobPageHTML {
+		body = body[:maxJobPageHTML]
+	}
+	return title, content, string(body), nil
+}
+
+// extractJobPage turns a job page into JD text: the JobPosting JSON-LD when it has a
+// full description, else the main content with boilerplate removed (after the
+// JSON-LD fields, when there are any).
+func extractJobPage(ctx context.Context, ext *extract.Extractor, body []byte, pageURL *url.URL) (title, content string) {
+	title, posting, desc := JobPostingFromHTML(string(body))
+	if posting != "" && len(desc) >= minJSONLDDescription {
+		return title, posting
+	}
+	var page string
+	if res, err := ext.Extract(ctx, body, pageURL); err == nil {
+		page = CleanJobPageText(res.Content)
+		if title == "" {
+			title = strings.TrimSpace(res.Title)
+		}
+	}
+	switch {
+	case posting == "":
+		return title, page
+	case page == "":
+		return title, posting
+	default:
+		return title, posting + "\n\n" + page
+	}
+}
+
+// JobPostingFromHTML finds the schema.org JobPosting JSON-LD of a page and formats it
+// as markdown fields followed by the description. It returns the posting title, the
+// formatted text and the description alone; all empty when the page has none.
+func JobPostingFromHTML(page string) (title, text, description string) {
+	for _, m := range ldJSONScriptRe.FindAllStringSubmatch(page, -1) {
+		var data any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(m[1])), &data); err != nil {
+			continue
+		}
+		if p := findJobPosting(data); p != nil {
+			return formatJobPosting(p)
+		}
+	}
+	return "", "", ""
+}
+
+// findJobPosting walks JSON-LD (objects, arrays, @graph) for a JobPosting object.
+func findJobPosting(v any) map[string]any {
+	switch t := v.(type) {
+	case []any:
+		for _, e := range t {
+			if p := findJobPosting(e); p != nil {
+				return p
+			}
+		}
+	case map[string]any:
+		if ldHasType(t["@type"], "JobPosting") {
+			return t
+		}
+		if g, ok := t["@graph"]; ok {
+			return findJobPosting(g)
+		}
+	}
+	return nil
+}
+
+func ldHasType(v any, want string) bool {
+	switch t := v.(type) {
+	case string:
+		return t == want
+	case []any:
+		for _, e := range t {
+			if s, ok := e.(string); ok && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ldString returns a JSON-LD value as text: strings, numbers, the "name" of objects,
+// and lists of those joined with ", ".
+func ldString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(html.UnescapeString(t))
+	case float64:
+		return fmt.Sprintf("%g", t)
+	case map[string]any:
+		return ldString(t["name"])
+	case []any:
+		var parts []string
+		for _, e := range t {
+			if s := ldString(e); s != "" && !containsFold(parts, s) {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ""
+}
+
+func containsFold(list []string, s string) bool {
+	for _, e := range list {
+		if strings.EqualFold(e, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ldLocation formats jobLocation: a Place, a list of them, or plain text.
+func ldLocation(v any) string {
+	switch t := v.(type) {
+	case []any:
+		var parts []string
+		for _, e := range t {
+			if s := ldLocation(e); s != "" && !containsFold(parts, s) {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, "; ")
+	case map[string]any:
+		addr, ok := t["address"].(map[string]any)
+		if !ok {
+			if s := ldString(t["address"]); s != "" {
+				return s
+			}
+			return ldString(t)
+		}
+		var parts []string
+		for _, k := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+			if s := ldString(addr[k]); s != "" && !containsFold(parts, s) {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ldString(v)
+}
+
+// ldSalary formats baseSalary: a MonetaryAmount with a QuantitativeValue or a number.
+func ldSalary(v any) string {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return ldString(v)
+	}
+	currency := ldString(m["currency"])
+	var lo, hi float64
+	var unit string
+	switch val := m["value"].(type) {
+	case map[string]any:
+		lo, _ = val["minValue"].(float64)
+		hi, _ = val["maxValue"].(float64)
+		if x, ok := val["value"].(float64); ok && lo == 0 && hi == 0 {
+			lo = x
+		}
+		unit = ldString(val["unitText"])
+	case float64:
+		lo = val
+	}
+	var s string
+	switch {
+	case lo > 0 && hi > 0 && hi != lo:
+		s = fmt.Sprintf("%.0f-%.0f", lo, hi)
+	case lo > 0:
+		s = fmt.Sprintf("%.0f", lo)
+	case hi > 0:
+		s = fmt.Sprintf("up to %.0f", hi)
+	default:
+		return ""
+	}
+	if currency != "" {
+		s += " " + currency
+	}
+	if unit != "" {
+		s += " per " + strings.ToLower(unit)
+	}
+	return s
+}
+
+// formatJobPosting renders a JobPosting object.
+func formatJobPosting(p map[string]any) (title, text, description string) {
+	title = ldString(p["title"])
+	var parts []string
+	field := func(label, value string) {
+		if value != "" {
+			parts = append(parts, "**"+label+":** "+value)
+		}
+	}
+	field("Title", title)
+	field("Company", ldString(p["hiringOrganization"]))
+	location := ldLocation(p["jobLocation"])
+	if ldString(p["jobLocationType"]) == "TELECOMMUTE" {
+		remote := "Remote"
+		if req := ldString(p["applicantLocationRequirements"]); req != "" {
+			remote += " (" + req + ")"
+		}
+		if location != "" {
+			remote += "; " + location
+		}
+		location = remote
+	}
+	field("Location", location)
+	field("Type", ldString(p["employmentType"]))
+	field("Salary", ldSalary(p["baseSalary"]))
+	field("Posted", ldString(p["datePosted"]))
+	field("Valid through", ldString(p["validThrough"]))
+
+	description = ldHTMLText(p["description"])
+	for _, k := range []struct{ key, heading string }{
+		{"responsibilities", "Responsibilities"},
+		{"qualifications", "Qualifications"},
+		{"skills", "Skills"},
+		{"experienceRequirements", "Experience"},
+		{"jobBenefits", "Benefits"},
+	} {
+		if s := ldHTMLText(p[k.key]); s != "" && !strings.Contains(description, s) {
+			description += "\n\n**" + k.heading + ":**\n" + s
+		}
+	}
+	description = strings.TrimSpace(description)
+	if description != "" {
+		parts = append(parts, "**Description:**\n"+description)
+	}
+	return title, strings.Join(parts, "\n\n"), description
+}
+
+// ldHTMLText converts an HTML (possibly entity-escaped) JSON-LD text field to markdown.
+func ldHTMLText(v any) string {
+	s, ok := v.(string)
+	if !ok {
+		return ldString(v)
+	}
+	if strings.Contains(s, "&lt;") {
+		s = html.UnescapeString(s)
+	}
+	if md, err := htmltomarkdown.ConvertString(s); err == nil {
+		s = md
+	}
+	return strings.TrimSpace(s)
+}
+
+// CleanJobPageText drops page chrome from extracted job page text: cookie notices,
+// apply/share/sign-in buttons, link-only navigation lines, and everything from a
+// "Similar jobs"-style rail on. Blank-line runs collapse to one.
+func CleanJobPageText(text string) string {
+	var out []string
+	blank := false
+	for _, line := range strings.Split(text, "\n") {
+		t := strings.TrimSpace(line)
+		plain := strings.Trim(t, "#*_ ")
+		if jobPageTailRe.MatchString(plain) {
+			break
+		}
+		if t == "" {
+			blank = len(out) > 0
+			continue
+		}
+		if jobPageNoiseRe.MatchString(plain) || jobPageCookieRe.MatchString(t) || mdLinkOnlyRe.MatchString(t) {
+			continue
+		}
+		if blank {
+			out = append(out, "")
+			blank = false
+		}
+		out = append(out, strings.TrimRight(line, " \t"))
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Job URL canonicalization: the same posting arrives under many URLs — LinkedIn
+// search pages with currentJobId, Indeed click-through links carrying jk, company
+// career pages embedding Greenhouse with gh_jid, all with tracking parameters. Dedup,
+// the seen-jobs store, the tracker and the job-details cache key postings by
+// CanonicalJobURL so these variants meet.
+
+var (
+	linkedinJobIDRe   = regexp.MustCompile(`/jobs/view/(?:[^/]*-)?(\d{6,})`)
+	greenhouseJobRe   = regexp.MustCompile(`^/([^/]+)/jobs/(\d+)`)
+	leverPostingRe    = regexp.MustCompile(`^/([^/]+)/([0-9a-f-]{36})`)
+	jobTrackingParams = []string{
+		"utm_", "refid", "src", "trk", "trkinfo", "trackingid", "fbclid", "gclid",
+		"referrer", "lipi", "gh_src",
+	}
+	// Generic names that select content on arbitrary sites ("?source=rss" feeds,
+	// "?from=2024-01" archives) but only track the click on these job boards.
+	jobBoardTrackingParams = []string{"from", "source", "ref"}
+	jobBoardHosts          = []string{
+		"glassdoor.com", "ziprecruiter.com", "dice.com", "monster.com", "simplyhired.com",
+		"wellfound.com", "angel.co", "builtin.com", "otta.com", "welcometothejungle.com",
+		"remoteok.com", "weworkremotely.com", "remotive.com", "himalayas.app", "workingnomads.com",
+		"hh.ru", "habr.com", "apply.workable.com", "jobs.ashbyhq.com", "jobs.lever.co",
+		"boards.greenhouse.io", "job-boards.greenhouse.io", "myworkdayjobs.com", "smartrecruiters.com",
+	}
+)
+
+// isJobBoardHost reports whether host (lowercase, without "www.") is, or is a
+// subdomain of, one of jobBoardHosts.
+func isJobBoardHost(host string) bool {
+	for _, h := range jobBoardHosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrackingParam reports whether a query parameter only tracks the click. Entries
+// ending in "_" match as prefixes.
+func isTrackingParam(key string, params []string) bool {
+	k := strings.ToLower(key)
+	for _, p := range params {
+		if k == p || (strings.HasSuffix(p, "_") && strings.HasPrefix(k, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanonicalJobURL normalizes a job posting URL: https, lowercase host without "www.",
+// no fragment, trailing slash or tracking parameters (from, source and ref only on
+// known job boards), and per-site rules —
+// LinkedIn reduces to /jobs/view/<id> (also from a currentJobId parameter), Indeed to
+// /viewjob?jk=<jk> (also from vjk), Greenhouse boards and gh_jid career pages to the
+// job ID, Lever to the posting without /apply. Unparseable input is returned trimmed.
+func CanonicalJobURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	path := strings.TrimRight(u.EscapedPath(), "/")
+	q := u.Query()
+	canon := func(h, p string, query url.Values) string {
+		c := "https://" + h + p
+		if enc := query.Encode(); enc != "" {
+			c += "?" + enc
+		}
+		return c
+	}
+
+	switch {
+	case host == "linkedin.com" || strings.HasSuffix(host, ".linkedin.com"):
+		if m := linkedinJobIDRe.FindStringSubmatch(path); m != nil {
+			return canon("linkedin.com", "/jobs/view/"+m[1], nil)
+		}
+		if id := q.Get("currentJobId"); id != "" {
+			return canon("linkedin.com", "/jobs/view/"+id, nil)
+		}
+	case host == "indeed.com" || strings.HasSuffix(host, ".indeed.com"):
+		jk := q.Get("jk")
+		if jk == "" {
+			jk = q.Get("vjk")
+		}
+		if jk != "" {
+			return canon(host, "/viewjob", url.Values{"jk": {jk}})
+		}
+	case host == "boards.greenhouse.io" || host == "job-boards.greenhouse.io":
+		if m := greenhouseJobRe.FindStringSubmatch(path); m != nil {
+			return canon("boards.greenhouse.io", "/"+strings.ToLower(m[1])+"/jobs/"+m[2], nil)
+		}
+		if board, token := q.Get("for"), q.Get("token"); board != "" && token != "" { // embed/job_app
+			return canon("boards.greenhouse.io", "/"+strings.ToLower(board)+"/jobs/"+token, nil)
+		}
+	case host == "jobs.lever.co":
+		if m := leverPostingRe.FindStringSubmatch(path); m != nil {
+			return canon(host, "/"+strings.ToLower(m[1])+"/"+m[2], nil)
+		}
+	}
+	// Career pages embedding a Greenhouse board: the job ID is the posting.
+	if jid := q.Get("gh_jid"); jid != "" {
+		return canon(host, path, url.Values{"gh_jid": {jid}})
+	}
+
+	board := isJobBoardHost(host)
+	for k := range q {
+		if isTrackingParam(k, jobTrackingParams) || (board && isTrackingParam(k, jobBoardTrackingParams)) {
+			q.Del(k)
+		}
+	}
+	return canon(host, path, q)
+}
@@ -0,0 +1,283 @@
+package jobs
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"golang.org/x/net/html"
+)
+
+// Academic and research positions: EURAXESS (European research jobs, RSS feed) and
+// HigherEdJobs (US faculty and staff, scraped search page), queried by job_search with
+// platform=academic. Listings carry institution, tenure_track and the application
+// deadline.
+
+const (
+	euraxessFeedURL    = "https://euraxess.ec.europa.eu/jobs/search/rss?keywords=%s"
+	euraxessSiteSearch = "site:euraxess.ec.europa.eu/jobs"
+	higherEdSearchURL  = "https://www.higheredjobs.com/search/advanced_action.cfm?Keyword=%s&NumJobs=25&SortBy=1"
+	higherEdSiteSearch = "site:higheredjobs.com"
+	euraxessSource     = "EURAXESS"
+	higherEdSource     = "HigherEdJobs"
+)
+
+var (
+	euraxessJobRe  = regexp.MustCompile(`euraxess\.ec\.europa\.eu/jobs/\d+`)
+	higherEdJobRe  = regexp.MustCompile(`higheredjobs\.com/.*details\.cfm\?JobCode=\d+`)
+	euraxessOrgRe  = regexp.MustCompile(`(?i)\b(?:organi[sz]ation(?:/company)?|institution)\s*:\s*([^\n|]+)`)
+	euraxessLocRe  = regexp.MustCompile(`(?i)\b(?:country|location)\s*:\s*([^\n|]+)`)
+	tenureTrackRe  = regexp.MustCompile(`(?i)\b(tenure[- ]track|tenure[- ]eligible|tenured (?:position|professor|faculty)|with tenure)\b`)
+	noTenureRe     = regexp.MustCompile(`(?i)\b(non[- ]tenure[- ]track|not tenure[- ]track|non[- ]tenured?)\b`)
+	institutionRe  = regexp.MustCompile(`(?i)\b(universit(?:y|ät|é|à|eit|ad|idade)|college|polytechnic|institute of technology|école|hochschule|max planck|cnrs|inria|fraunhofer|helmholtz|national laborator(?:y|ies)|research (?:institute|centre|center|council))\b`)
+	htmlBreakRe    = regexp.MustCompile(`(?i)<(?:br|/p|/div|/li|/h\d)\s*/?>`)
+	academicHostRe = regexp.MustCompile(`(?i)(euraxess\.ec\.europa\.eu|higheredjobs\.com|academicpositions\.|jobs\.ac\.uk|chronicle\.com/jobs|\.edu/)`)
+)
+
+type euraxessRSS struct {
+	Channel struct {
+		Items []struct {
+			Title       string `xml:"title"`
+			Link        string `xml:"link"`
+			Description string `xml:"description"`
+			PubDate     string `xml:"pubDate"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// SearchEuraxessJobs reads the EURAXESS job search feed, falling back to a SearXNG site
+// search when the feed is unavailable.
+func SearchEuraxessJobs(ctx context.Context, query, location string, limit int) ([]engine.JobListing, error) {
+	engine.IncrAcademicRequests(ctx)
+	body, err := fetchRemoteBoard(ctx, fmt.Sprintf(euraxessFeedURL, url.QueryEscape(strings.TrimSpace(query+" "+location))), "application/rss+xml, application/xml")
+	if err == nil {
+		var listings []engine.JobListing
+		if listings, err = parseEuraxessRSS(body, time.Now()); err == nil && len(listings) > 0 {
+			return capListings(listings, limit), nil
+		}
+	}
+	if err != nil {
+		slog.Warn("euraxess: feed failed, falling back to SearXNG", slog.Any("error", err))
+	}
+	return searchAcademicSite(ctx, query, location, euraxessSiteSearch, euraxessSource, euraxessJobRe, limit)
+}
+
+func parseEuraxessRSS(body []byte, now time.Time) ([]engine.JobListing, error) {
+	var rss euraxessRSS
+	if err := xml.Unmarshal(body, &rss); err != nil {
+		return nil, fmt.Errorf("euraxess RSS parse: %w", err)
+	}
+	var listings []engine.JobListing
+	for _, item := range rss.Channel.Items {
+		if item.Title == "" || item.Link == "" {
+			continue
+		}
+		// Keep line breaks so the "Field: value" lines stay apart.
+		text := strings.TrimSpace(engine.CleanHTML(htmlBreakRe.ReplaceAllString(item.Description, "\n$0")))
+		j := engine.JobListing{
+			Title:       strings.TrimSpace(item.Title),
+			URL:         strings.TrimSpace(item.Link),
+			Source:      euraxessSource,
+			Description: text,
+			Posted:      rssDate(item.PubDate),
+		}
+		if m := euraxessOrgRe.FindStringSubmatch(text); m != nil {
+			j.Company = strings.TrimSpace(m[1])
+			j.Institution = j.Company
+		}
+		if m := euraxessLocRe.FindStringSubmatch(text); m != nil {
+			j.Location = strings.TrimSpace(m[1])
+		}
+		if d, ok := ExtractDeadline(text, now); ok {
+			j.Deadline, j.DeadlineKind = d.Date.Format(time.DateOnly), d.Kind
+		}
+		j.TenureTrack = isTenureTrack(j.Title + "\n" + text)
+		listings = append(listings, j)
+	}
+	return listings, nil
+}
+
+// rssDate converts an RSS pubDate to YYYY-MM-DD, or returns it unchanged.
+func rssDate(s string) string {
+	for _, layout := range []string{time.RFC1123Z, time.RFC1123} {
+		if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return t.Format(time.DateOnly)
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// SearchHigherEdJobs scrapes the HigherEdJobs keyword search page, falling back to a
+// SearXNG site search when the page cannot be read.
+func SearchHigherEdJobs(ctx context.Context, query, location string, limit int) ([]engine.JobListing, error) {
+	engine.IncrAcademicRequests(ctx)
+	pageURL := fmt.Sprintf(higherEdSearchURL, url.QueryEscape(strings.TrimSpace(query+" "+location)))
+	body, err := fetchRemoteBoard(ctx, pageURL, "text/html")
+	if err == nil {
+		if listings := parseHigherEdHTML(string(body), pageURL); len(listings) > 0 {
+			return capListings(listings, limit), nil
+		}
+	} else {
+		slog.Warn("higheredjobs: scrape failed, falling back to SearXNG", slog.Any("error", err))
+	}
+	return searchAcademicSite(ctx, query, location, higherEdSiteSearch, higherEdSource, higherEdJobRe, limit)
+}
+
+// parseHigherEdHTML extracts listings from a HigherEdJobs search page: each result is a
+// details.cfm link followed by the institution and location, separated by <br>.
+func parseHigherEdHTML(body, pageURL string) []engine.JobListing {
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return nil
+	}
+	base, _ := url.Parse(pageURL)
+	seen := make(map[string]bool)
+	var listings []engine.JobListing
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" && strings.Contains(getAttr(n, "href"), "details.cfm?JobCode=") {
+			link := getAttr(n, "href")
+			if ref, err := url.Parse(link); err == nil && base != nil {
+				link = base.ResolveReference(ref).String()
+			}
+			title := strings.Join(strings.Fields(textContent(n)), " ")
+			if title != "" && !seen[link] {
+				seen[link] = true
+				j := engine.JobListing{Title: title, URL: link, Source: higherEdSource}
+				lines := siblingTextLines(n)
+				if len(lines) > 0 {
+					j.Company, j.Institution = lines[0], lines[0]
+				}
+				if len(lines) > 1 {
+					j.Location = lines[1]
+				}
+				listings = append(listings, j)
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return listings
+}
+
+// siblingTextLines returns the non-empty text runs after n among its siblings, split at
+// <br> and block elements.
+func siblingTextLines(n *html.Node) []string {
+	var lines []string
+	var cur strings.Builder
+	flush := func() {
+		if s := strings.Join(strings.Fields(cur.String()), " "); s != "" {
+			lines = append(lines, s)
+		}
+		cur.Reset()
+	}
+	for s := n.NextSibling; s != nil; s = s.NextSibling {
+		if s.Type == html.ElementNode && (s.Data == "br" || s.Data == "div" || s.Data == "p") {
+			flush()
+		}
+		if s.Type == html.ElementNode && s.Data == "a" {
+			break
+		}
+		cur.WriteString(textContent(s))
+	}
+	flush()
+	return lines
+}
+
+// searchAcademicSite finds job pages of an academic board via SearXNG. The listings
+// carry no description, so job_search fetches the posting.
+func searchAcademicSite(ctx context.Context, query, location, site, source string, jobRe *regexp.Regexp, limit int) ([]engine.JobListing, error) {
+	searxQuery := strings.Join(strings.Fields(query+" "+location+" "+site), " ")
+	results, err := engine.SearchSearXNG(ctx, searxQuery, "all", "", engine.DefaultSearchEngine)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	var listings []engine.JobListing
+	for _, r := range results {
+		if jobRe.MatchString(r.URL) {
+			listings = append(listings, engine.JobListing{Title: r.Title, URL: r.URL, Source: source})
+		}
+	}
+	return capListings(listings, limit), nil
+}
+
+func capListings(listings []engine.JobListing, limit int) []engine.JobListing {
+	if limit > 0 && len(listings) > limit {
+		return listings[:limit]
+	}
+	return listings
+}
+
+// AcademicListingsToSearxngResults renders academic listings for the job summarizer.
+// Listings without a description are left for job_search to fetch.
+func AcademicListingsToSearxngResults(listings []engine.JobListing) []engine.SearxngResult {
+	results := make([]engine.SearxngResult, 0, len(listings))
+	for _, j := range listings {
+		r := engine.SearxngResult{Title: j.Title, URL: j.URL, Score: 0.8}
+		if j.Description != "" {
+			parts := []string{"**Source:** " + j.Source, "**Institution:** " + j.Institution, "**Location:** " + j.Location}
+			if j.Posted != "" {
+				parts = append(parts, "**Posted:** "+j.Posted)
+			}
+			if j.Deadline != "" {
+				parts = append(parts, "**Application deadline:** "+j.Deadline)
+			}
+			r.Content = strings.Join(parts, " | ") + "\n\n" + engine.TrimContent(j.Description, 3000)
+		}
+		if j.Institution != "" {
+			r.Title = j.Title + " at " + j.Institution
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// MergeAcademicListing fills what the LLM summary left empty from the structured
+// academic listing for the same URL.
+func MergeAcademicListing(dst *engine.JobListing, src engine.JobListing) {
+	if dst.Company == "" {
+		dst.Company = src.Company
+	}
+	if dst.Institution == "" {
+		dst.Institution = src.Institution
+	}
+	if dst.Location == "" {
+		dst.Location = src.Location
+	}
+	if dst.Posted == "" || dst.Posted == "not specified" {
+		dst.Posted = src.Posted
+	}
+	if src.Deadline != "" {
+		dst.Deadline, dst.DeadlineKind = src.Deadline, src.DeadlineKind
+	}
+	dst.TenureTrack = dst.TenureTrack || src.TenureTrack
+	dst.Source = src.Source
+}
+
+// isTenureTrack reports whether text describes a tenure-track or tenured post.
+func isTenureTrack(text string) bool {
+	return tenureTrackRe.MatchString(noTenureRe.ReplaceAllString(text, ""))
+}
+
+// AnnotateAcademic sets institution on listings from universities and research
+// institutes (or academic boards) and tenure_track from the posting text.
+func AnnotateAcademic(listings []engine.JobListing) {
+	for i := range listings {
+		j := &listings[i]
+		if j.Institution == "" && j.Company != "" && (institutionRe.MatchString(j.Company) || academicHostRe.MatchString(j.URL)) {
+			j.Institution = j.Company
+		}
+		if !j.TenureTrack {
+			j.TenureTrack = isTenureTrack(j.Title + "\n" + j.Description)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestParseEuraxessRSS(t *testing.T) {
+	feed := []byte(`<?xml version="1.0"?><rss version="2.0"><channel>
+<item><title>PhD position in computational biology</title>
+<link>https://euraxess.ec.europa.eu/jobs/312345</link>
+<description>&lt;p&gt;Organisation/Company: Universiteit Leiden&lt;/p&gt;&lt;p&gt;Country: Netherlands&lt;/p&gt;&lt;p&gt;Application Deadline: 30 Nov 2026 - 23:59 (Europe/Brussels)&lt;/p&gt;</description>
+<pubDate>Tue, 13 Oct 2026 10:00:00 +0200</pubDate></item>
+<item><title></title><link>https://euraxess.ec.europa.eu/jobs/1</link></item>
+</channel></rss>`)
+	listings, err := parseEuraxessRSS(feed, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listings) != 1 {
+		t.Fatalf("got %d listings", len(listings))
+	}
+	j := listings[0]
+	if j.Institution != "Universiteit Leiden" || j.Location != "Netherlands" || j.Posted != "2026-10-13" {
+		t.Errorf("listing = %+v", j)
+	}
+	if j.Deadline != "2026-11-30" || j.DeadlineKind != DeadlineApplication {
+		t.Errorf("deadline = %q (%s)", j.Deadline, j.DeadlineKind)
+	}
+}
+
+func TestParseHigherEdHTML(t *testing.T) {
+	page := `<html><body>
+<div class="row record"><div class="col-sm-7">
+<a href="details.cfm?JobCode=178123456&Title=Assistant%20Professor">Assistant Professor of Computer Science</a><br>
+Oberlin College<br>
+Oberlin, OH
+</div><div class="col-sm-5">Computer Science<br>Posted 10/12/26</div></div>
+<div class="row record"><div class="col-sm-7"><a href="details.cfm?JobCode=178123457">Lab Manager</a><br>Rice University<br>Houston, TX</div></div>
+</body></html>`
+	listings := parseHigherEdHTML(page, "https://www.higheredjobs.com/search/advanced_action.cfm?Keyword=cs")
+	if len(listings) != 2 {
+		t.Fatalf("got %d listings", len(listings))
+	}
+	if j := listings[0]; j.Institution != "Oberlin College" || j.Location != "Oberlin, OH" ||
+		j.URL != "https://www.higheredjobs.com/search/details.cfm?JobCode=178123456&Title=Assistant%20Professor" {
+		t.Errorf("listing = %+v", j)
+	}
+}
+
+func TestAnnotateAcademic(t *testing.T) {
+	listings := []engine.JobListing{
+		{Title: "Assistant Professor", Company: "Stanford University", Description: "Tenure-track position in statistics."},
+		{Title: "Lecturer", Company: "MIT", URL: "https://www.higheredjobs.com/details.cfm?JobCode=1", Description: "Non-tenure-track teaching post."},
+		{Title: "Backend Engineer", Company: "Acme", Description: "Go services."},
+	}
+	AnnotateAcademic(listings)
+	if listings[0].Institution != "Stanford University" || !listings[0].TenureTrack {
+		t.Errorf("professor = %+v", listings[0])
+	}
+	if listings[1].Institution != "MIT" || listings[1].TenureTrack {
+		t.Errorf("lecturer = %+v", listings[1])
+	}
+	if listings[2].Institution != "" || listings[2].TenureTrack {
+		t.Errorf("engineer = %+v", listings[2])
+	}
+}
@@ -83,9 +83,9 @@ func SearchAlgora(ctx context.Context, limit int) ([]engine.BountyListing, error
 
 // scrapeAlgoraBounties fetches bounties by scraping the algora.io HTML page.
 func scrapeAlgoraBounties(ctx context.Context, limit int) ([]engine.BountyListing, error) {
-	engine.IncrAlgoraRequests()
+	engine.IncrAlgoraRequests(ctx)
 
-	fetchCtx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, algoraBountiesURL, nil)
@@ -96,7 +96,7 @@ func scrapeAlgoraBounties(ctx context.Context, limit int) ([]engine.BountyListin
 	req.Header.Set("Accept", "text/html,application/xhtml+xml")
 
 	resp, err := engine.RetryHTTP(fetchCtx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return nil, err
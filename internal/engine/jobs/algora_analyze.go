@@ -13,7 +13,7 @@ import (
 
 // FetchGitHubIssueBody fetches the issue body from GitHub API.
 func FetchGitHubIssueBody(ctx context.Context, owner, repo string, number int) (string, error) {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%d", owner, repo, number)
@@ -23,11 +23,11 @@ func FetchGitHubIssueBody(ctx context.Context, owner, repo string, number int) (
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	if engine.Cfg.GithubToken != "" {
-		req.Header.Set("Authorization", "Bearer "+engine.Cfg.GithubToken)
+	if engine.ConfigFrom(ctx).GithubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
 	}
 
-	resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec
 	if err != nil {
 		return "", fmt.Errorf("fetch issue: %w", err)
 	}
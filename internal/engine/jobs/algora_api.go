@@ -52,9 +52,9 @@ type algoraAPIBounty struct {
 // searchAlgoraAPI fetches bounties from Algora's public tRPC API.
 // Returns nil, nil to signal caller should try scraping fallback.
 func searchAlgoraAPI(ctx context.Context, limit int) ([]engine.BountyListing, error) {
-	engine.IncrAlgoraRequests()
+	engine.IncrAlgoraRequests(ctx)
 
-	fetchCtx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	// Build tRPC query: input={"json":{"limit":N}}
@@ -68,7 +68,7 @@ func searchAlgoraAPI(ctx context.Context, limit int) ([]engine.BountyListing, er
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := engine.RetryHTTP(fetchCtx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return nil, fmt.Errorf("algora tRPC request failed: %w", err)
@@ -23,7 +23,7 @@ type commentResponse struct {
 
 // CommentOnIssue posts a comment on a GitHub issue and returns the comment HTML URL.
 func CommentOnIssue(ctx context.Context, owner, repo string, number int, body string) (string, error) {
-	if engine.Cfg.GithubToken == "" {
+	if engine.ConfigFrom(ctx).GithubToken == "" {
 		return "", fmt.Errorf("GITHUB_TOKEN is not set; cannot comment on issues")
 	}
 
@@ -41,9 +41,9 @@ func CommentOnIssue(ctx context.Context, owner, repo string, number int, body st
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	req.Header.Set("Authorization", "Bearer "+engine.Cfg.GithubToken)
+	req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec // GitHub API URL
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // GitHub API URL
 	if err != nil {
 		return "", fmt.Errorf("post comment: %w", err)
 	}
@@ -38,7 +38,7 @@ func SearchAlgoraWithEmbeddings(ctx context.Context, limit int) ([]BountyWithVec
 	}
 
 	// Try to embed titles.
-	client := GetEmbedClient()
+	client := StoresFrom(ctx).Embed
 	if client == nil {
 		// No embed client — return bounties without vectors.
 		result := make([]BountyWithVector, len(bounties))
@@ -132,7 +132,7 @@ func SearchAlgoraEnriched(ctx context.Context, limit int) ([]BountyWithVector, e
 	}
 
 	// 5. Embed with skills included in text.
-	client := GetEmbedClient()
+	client := StoresFrom(ctx).Embed
 	if client == nil {
 		// No embed client — return enriched bounties without vectors.
 		result := make([]BountyWithVector, len(enriched))
@@ -28,7 +28,7 @@ type githubIssueInfo struct {
 
 // FilterOpenBounties checks GitHub issue status in parallel and returns only open issues.
 func FilterOpenBounties(ctx context.Context, bounties []engine.BountyListing) []engine.BountyListing {
-	if len(bounties) == 0 || engine.Cfg.GithubToken == "" {
+	if len(bounties) == 0 || engine.ConfigFrom(ctx).GithubToken == "" {
 		return bounties // no token — skip filtering, return all
 	}
 
@@ -48,11 +48,11 @@ func FilterOpenBounties(ctx context.Context, bounties []engine.BountyListing) []
 		}
 
 		wg.Add(1)
-		go func(idx int, owner, repo string, number int) {
+		engine.GoOutbound(ctx, func() {
 			defer wg.Done()
 			open := checkIssueOpen(ctx, owner, repo, number)
-			results <- result{idx: idx, open: open}
-		}(i, owner, repo, number)
+			results <- result{idx: i, open: open}
+		})
 	}
 
 	go func() {
@@ -82,7 +82,7 @@ func FilterOpenBounties(ctx context.Context, bounties []engine.BountyListing) []
 
 // checkIssueOpen returns true if the GitHub issue is open (or if we can't determine status).
 func checkIssueOpen(ctx context.Context, owner, repo string, number int) bool {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%d", owner, repo, number)
@@ -92,11 +92,11 @@ func checkIssueOpen(ctx context.Context, owner, repo string, number int) bool {
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	if engine.Cfg.GithubToken != "" {
-		req.Header.Set("Authorization", "Bearer "+engine.Cfg.GithubToken)
+	if engine.ConfigFrom(ctx).GithubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
 	}
 
-	resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec // GitHub API URL
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // GitHub API URL
 	if err != nil {
 		return true // error — keep bounty
 	}
@@ -136,10 +136,10 @@ func ParseGitHubIssueURL(u string) (owner, repo string, number int, ok bool) {
 // FetchGitHubIssueTitle fetches the issue title from GitHub API.
 // Returns empty string on any failure (graceful degradation).
 func FetchGitHubIssueTitle(ctx context.Context, owner, repo string, number int) string {
-	if engine.Cfg.GithubToken == "" {
+	if engine.ConfigFrom(ctx).GithubToken == "" {
 		return ""
 	}
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%d", owner, repo, number)
@@ -149,9 +149,9 @@ func FetchGitHubIssueTitle(ctx context.Context, owner, repo string, number int)
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	req.Header.Set("Authorization", "Bearer "+engine.Cfg.GithubToken)
+	req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec
 	if err != nil {
 		return ""
 	}
@@ -14,7 +14,7 @@ import (
 // Returns a map[URL] → githubIssueInfo. On error for a given URL, defaults to open state.
 func fetchIssueInfoBatch(ctx context.Context, bounties []engine.BountyListing) map[string]githubIssueInfo {
 	result := make(map[string]githubIssueInfo, len(bounties))
-	if len(bounties) == 0 || engine.Cfg.GithubToken == "" {
+	if len(bounties) == 0 || engine.ConfigFrom(ctx).GithubToken == "" {
 		// No token — return empty map (all bounties kept with defaults).
 		return result
 	}
@@ -33,11 +33,11 @@ func fetchIssueInfoBatch(ctx context.Context, bounties []engine.BountyListing) m
 			continue
 		}
 		wg.Add(1)
-		go func(url, owner, repo string, number int) {
+		engine.GoOutbound(ctx, func() {
 			defer wg.Done()
 			info := fetchSingleIssueInfo(ctx, owner, repo, number)
-			ch <- kv{url: url, info: info}
-		}(b.URL, owner, repo, number)
+			ch <- kv{url: b.URL, info: info}
+		})
 	}
 
 	go func() {
@@ -66,7 +66,7 @@ func fetchIssueInfoBatch(ctx context.Context, bounties []engine.BountyListing) m
 
 // fetchSingleIssueInfo fetches title, state, and labels for a single GitHub issue.
 func fetchSingleIssueInfo(ctx context.Context, owner, repo string, number int) githubIssueInfo {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%d", owner, repo, number)
@@ -76,9 +76,9 @@ func fetchSingleIssueInfo(ctx context.Context, owner, repo string, number int) g
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	req.Header.Set("Authorization", "Bearer "+engine.Cfg.GithubToken)
+	req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec
 	if err != nil {
 		return githubIssueInfo{State: "open"}
 	}
@@ -121,7 +121,7 @@ func fetchRepoLanguages(ctx context.Context, bounties []engine.BountyListing) ma
 			repos[owner+"/"+repo] = true
 		}
 	}
-	if len(repos) == 0 || engine.Cfg.GithubToken == "" {
+	if len(repos) == 0 || engine.ConfigFrom(ctx).GithubToken == "" {
 		return nil
 	}
 
@@ -134,13 +134,13 @@ func fetchRepoLanguages(ctx context.Context, bounties []engine.BountyListing) ma
 
 	for repo := range repos {
 		wg.Add(1)
-		go func(repo string) {
+		engine.GoOutbound(ctx, func() {
 			defer wg.Done()
 			lang := fetchRepoLanguage(ctx, repo)
 			if lang != "" {
 				ch <- kv{repo: repo, lang: lang}
 			}
-		}(repo)
+		})
 	}
 	go func() {
 		wg.Wait()
@@ -156,7 +156,7 @@ func fetchRepoLanguages(ctx context.Context, bounties []engine.BountyListing) ma
 
 // fetchRepoLanguage fetches the primary language for a GitHub repo.
 func fetchRepoLanguage(ctx context.Context, repo string) string {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	url := "https://api.github.com/repos/" + repo
@@ -166,9 +166,9 @@ func fetchRepoLanguage(ctx context.Context, repo string) string {
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	req.Header.Set("Authorization", "Bearer "+engine.Cfg.GithubToken)
+	req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec
 	if err != nil {
 		return ""
 	}
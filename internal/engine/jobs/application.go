@@ -61,7 +61,7 @@ func PrepareApplication(ctx context.Context, resume, jobDescription, company, to
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		cl, err := GenerateCoverLetter(ctx, resumeTrunc, jdTrunc, tone)
+		cl, err := GenerateCoverLetter(ctx, resumeTrunc, jdTrunc, tone, company)
 		if err != nil {
 			slog.Warn("application_prep: cover letter failed", slog.Any("error", err))
 			setErr(fmt.Errorf("cover letter: %w", err))
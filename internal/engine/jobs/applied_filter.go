@@ -0,0 +1,122 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// priorApplications returns the latest tracked application per CompanyKey, counting
+// only jobs past saved (applied, interview, offer, rejected).
+func priorApplications(ctx context.Context) (map[string]engine.PriorApplication, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, `SELECT company, title, status, updated_at FROM jobs
+		WHERE kind = 'job' AND status IN (?, ?, ?, ?) ORDER BY updated_at`,
+		StatusApplied, StatusInterview, StatusOffer, StatusRejected)
+	if err != nil {
+		return nil, fmt.Errorf("tracker: prior applications: %w", err)
+	}
+	defer rows.Close()
+	prior := make(map[string]engine.PriorApplication)
+	for rows.Next() {
+		var company, title, status, updated string
+		if err := rows.Scan(&company, &title, &status, &updated); err != nil {
+			return nil, err
+		}
+		if key := CompanyKey(company); key != "" {
+			// Rows come oldest first, so the latest application wins.
+			prior[key] = engine.PriorApplication{Status: status, Title: title, Date: dateOf(updated)}
+		}
+	}
+	return prior, rows.Err()
+}
+
+// dateOf returns the YYYY-MM-DD prefix of an RFC 3339 timestamp.
+func dateOf(ts string) string {
+	if len(ts) >= len(time.DateOnly) {
+		return ts[:len(time.DateOnly)]
+	}
+	return ts
+}
+
+// CompanyMatcher matches a comma-separated list of company names or keywords (the
+// job_search blacklist) by CompanyKey, so "Acme Inc." and "ACME" are the same entry.
+type CompanyMatcher []string
+
+// NewCompanyMatcher parses a comma-separated list; empty entries are skipped.
+func NewCompanyMatcher(list string) CompanyMatcher {
+	var m CompanyMatcher
+	for _, name := range strings.Split(list, ",") {
+		if key := CompanyKey(name); key != "" {
+			m = append(m, key)
+		}
+	}
+	return m
+}
+
+// Matches reports whether text — a company name, or a raw result whose company is not
+// extracted yet — contains an entry as whole words: "Meta" matches "Meta Platforms"
+// but not "Metabase", "staffing" matches "XYZ Staffing Ltd".
+func (m CompanyMatcher) Matches(text string) bool {
+	if len(m) == 0 {
+		return false
+	}
+	padded := " " + CompanyKey(text) + " "
+	for _, key := range m {
+		if strings.Contains(padded, " "+key+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// ExcludeAppliedCompanies cross-references listings with the job tracker and the
+// blacklist. Listings at blacklisted companies are dropped, as are listings at
+// companies applied to or rejected by within withinDays (0 disables that drop).
+// Remaining listings at companies with a tracked application get PriorApp.
+// Returns the kept listings and how many were dropped because of the tracker.
+func ExcludeAppliedCompanies(ctx context.Context, listings []engine.JobListing, blacklist string, withinDays int, now time.Time) ([]engine.JobListing, int) {
+	blocked := NewCompanyMatcher(blacklist)
+	prior, err := priorApplications(ctx)
+	if err != nil {
+		slog.Debug("job_search: prior applications unavailable", slog.Any("error", err))
+	}
+	cutoff := now.UTC().AddDate(0, 0, -withinDays).Format(time.DateOnly)
+	kept := listings[:0]
+	dropped := 0
+	for _, j := range listings {
+		key := CompanyKey(j.Company)
+		if key == "" {
+			kept = append(kept, j)
+			continue
+		}
+		if blocked.Matches(j.Company) {
+			continue
+		}
+		if p, ok := prior[key]; ok {
+			if withinDays > 0 && p.Date >= cutoff {
+				dropped++
+				continue
+			}
+			j.PriorApp = &p
+		}
+		kept = append(kept, j)
+	}
+	return kept, dropped
+}
+
+// AppliedDroppedNote explains the listings ExcludeAppliedCompanies dropped, for the
+// search summary.
+func AppliedDroppedNote(dropped, withinDays int) string {
+	if dropped == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" Hid %d listing(s) at companies you applied to or were rejected by in the last %d days; set exclude_applied_within_days=0 to see them.", dropped, withinDays)
+}
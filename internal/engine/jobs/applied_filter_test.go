@@ -0,0 +1,71 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestExcludeAppliedCompanies(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	for _, in := range []JobTrackerAddInput{
+		{Title: "Go Developer", Company: "Stripe", Status: "applied"},
+		{Title: "Platform Engineer", Company: "OldCo", Status: "rejected"},
+		{Title: "SRE", Company: "Acme", Status: "saved"},
+	} {
+		if _, err := AddTrackedJob(ctx, in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db, _ := openTrackerDB()
+	if _, err := db.ExecContext(ctx, `UPDATE jobs SET updated_at = '2025-01-10T00:00:00Z' WHERE company = 'OldCo'`); err != nil {
+		t.Fatal(err)
+	}
+
+	listings := func() []engine.JobListing {
+		return []engine.JobListing{
+			{Title: "Backend", Company: "Stripe, Inc."},
+			{Title: "Infra", Company: "OldCo"},
+			{Title: "SRE", Company: "Acme"},
+			{Title: "Recruiter", Company: "StaffingCo"},
+		}
+	}
+	kept, dropped := ExcludeAppliedCompanies(ctx, listings(), "StaffingCo", 30, time.Now())
+	if dropped != 1 || len(kept) != 2 || kept[0].Company != "OldCo" || kept[1].Company != "Acme" {
+		t.Fatalf("kept %+v, dropped %d", kept, dropped)
+	}
+	if kept[0].PriorApp == nil || kept[0].PriorApp.Status != "rejected" || kept[0].PriorApp.Date != "2025-01-10" {
+		t.Errorf("OldCo prior application = %+v", kept[0].PriorApp)
+	}
+	if kept[1].PriorApp != nil {
+		t.Errorf("a saved job is not an application: %+v", kept[1].PriorApp)
+	}
+
+	// Without a window the applied company is only tagged.
+	kept, dropped = ExcludeAppliedCompanies(ctx, listings(), "", 0, time.Now())
+	if dropped != 0 || len(kept) != 4 || kept[0].PriorApp == nil || kept[0].PriorApp.Status != "applied" {
+		t.Errorf("tag-only run: kept %d, dropped %d, first %+v", len(kept), dropped, kept[0].PriorApp)
+	}
+}
+
+func TestCompanyMatcher(t *testing.T) {
+	m := NewCompanyMatcher("Meta, staffing, , Acme Inc.")
+	for text, want := range map[string]bool{
+		"Meta Platforms":                    true,
+		"Metabase":                          false,
+		"XYZ Staffing Ltd":                  true,
+		"ACME":                              true,
+		"Go Developer at Acme Corp. Remote": true,
+		"Acmeville Bank":                    false,
+	} {
+		if got := m.Matches(text); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", text, got, want)
+		}
+	}
+	if NewCompanyMatcher("").Matches("Meta") {
+		t.Error("empty blacklist matched")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -43,7 +42,7 @@ type greenhouseResponse struct {
 
 // SearchGreenhouseJobs discovers company slugs via SearXNG then hits the public JSON API.
 func SearchGreenhouseJobs(ctx context.Context, query, location string, limit int) ([]engine.SearxngResult, error) {
-	engine.IncrGreenhouseRequests()
+	engine.IncrGreenhouseRequests(ctx)
 
 	searxQuery := query + " " + greenhouseSiteSearch
 	if location != "" {
@@ -73,10 +72,11 @@ func SearchGreenhouseJobs(ctx context.Context, query, location string, limit int
 	}
 	ch := make(chan fetchResult, len(slugs))
 	for _, slug := range slugs {
-		go func(s string) {
+		s := slug
+		engine.GoOutbound(ctx, func() {
 			jobs, err := fetchGreenhouseJobs(ctx, s)
 			ch <- fetchResult{s, jobs, err}
-		}(slug)
+		})
 	}
 
 	keywords := strings.Fields(strings.ToLower(query))
@@ -137,7 +137,7 @@ func fetchGreenhouseJobs(ctx context.Context, slug string) ([]greenhouseJob, err
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // ATS API URL from argument, intentional outbound request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // ATS API URL from argument, intentional outbound request
 	})
 	if err != nil {
 		return nil, err
@@ -151,16 +151,14 @@ func fetchGreenhouseJobs(ctx context.Context, slug string) ([]greenhouseJob, err
 		return nil, fmt.Errorf("greenhouse API status %d for %s", resp.StatusCode, slug)
 	}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 2*1024*1024))
-	if err != nil {
-		return nil, err
-	}
-
 	var gr greenhouseResponse
-	if err := json.Unmarshal(body, &gr); err != nil {
-		return nil, fmt.Errorf("greenhouse parse: %w", err)
-	}
-	return gr.Jobs, nil
+	err = engine.ReadBody(resp.Body, 2*1024*1024, func(body []byte) error {
+		if err := json.Unmarshal(body, &gr); err != nil {
+			return fmt.Errorf("greenhouse parse: %w", err)
+		}
+		return nil
+	})
+	return gr.Jobs, err
 }
 
 // extractGreenhouseSlugs extracts unique company slugs from SearXNG result URLs.
@@ -213,7 +211,7 @@ type leverPosting struct {
 
 // SearchLeverJobs discovers company slugs via SearXNG then hits the public JSON API.
 func SearchLeverJobs(ctx context.Context, query, location string, limit int) ([]engine.SearxngResult, error) {
-	engine.IncrLeverRequests()
+	engine.IncrLeverRequests(ctx)
 
 	searxQuery := query + " " + leverSiteSearch
 	if location != "" {
@@ -241,10 +239,11 @@ func SearchLeverJobs(ctx context.Context, query, location string, limit int) ([]
 	}
 	ch := make(chan fetchResult, len(slugs))
 	for _, slug := range slugs {
-		go func(s string) {
+		s := slug
+		engine.GoOutbound(ctx, func() {
 			postings, err := fetchLeverPostings(ctx, s)
 			ch <- fetchResult{s, postings, err}
-		}(slug)
+		})
 	}
 
 	keywords := strings.Fields(strings.ToLower(query))
@@ -320,7 +319,7 @@ func fetchLeverPostings(ctx context.Context, slug string) ([]leverPosting, error
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // ATS API URL from argument, intentional outbound request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // ATS API URL from argument, intentional outbound request
 	})
 	if err != nil {
 		return nil, err
@@ -334,16 +333,14 @@ func fetchLeverPostings(ctx context.Context, slug string) ([]leverPosting, error
 		return nil, fmt.Errorf("lever API status %d for %s", resp.StatusCode, slug)
 	}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 2*1024*1024))
-	if err != nil {
-		return nil, err
-	}
-
 	var postings []leverPosting
-	if err := json.Unmarshal(body, &postings); err != nil {
-		return nil, fmt.Errorf("lever parse: %w", err)
-	}
-	return postings, nil
+	err = engine.ReadBody(resp.Body, 2*1024*1024, func(body []byte) error {
+		if err := json.Unmarshal(body, &postings); err != nil {
+			return fmt.Errorf("lever parse: %w", err)
+		}
+		return nil
+	})
+	return postings, err
 }
 
 // extractLeverSlugs extracts unique company slugs from SearXNG result URLs.
@@ -0,0 +1,165 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Company-specific ATS board ---
+
+// companyBoardNone marks a company whose board was looked up and not found.
+const companyBoardNone = "none"
+
+// SearchCompanyATSJobs lists open roles matching query on a company's own Greenhouse
+// or Lever board. The board is found by trying slugs derived from the company name;
+// the result (including "no board") is cached for engine.LookupTTL.
+func SearchCompanyATSJobs(ctx context.Context, company, query string, limit int) ([]engine.SearxngResult, error) {
+	key := strings.ToLower(strings.TrimSpace(company))
+	if key == "" {
+		return nil, nil
+	}
+
+	board, cached := engine.CacheGetLookup(ctx, "ats_board", key)
+	if board == companyBoardNone {
+		return nil, nil
+	}
+	ats, slug, _ := strings.Cut(board, ":")
+
+	var (
+		ghJobs    []greenhouseJob
+		lvPosting []leverPosting
+		err       error
+	)
+	if cached {
+		switch ats {
+		case "greenhouse":
+			ghJobs, err = fetchGreenhouseJobs(ctx, slug)
+		case "lever":
+			lvPosting, err = fetchLeverPostings(ctx, slug)
+		}
+	} else if c, _ := GetCompany(ctx, company); c != nil && (c.ATS == "greenhouse" || c.ATS == "lever") && c.ATSSlug != "" {
+		// The company store already knows the board: skip probing slug candidates.
+		ats, slug = c.ATS, c.ATSSlug
+		if ats == "greenhouse" {
+			ghJobs, err = fetchGreenhouseJobs(ctx, slug)
+		} else {
+			lvPosting, err = fetchLeverPostings(ctx, slug)
+		}
+		if err == nil {
+			engine.CacheSetLookup(ctx, "ats_board", key, ats+":"+slug)
+		}
+	} else {
+		ats, slug, ghJobs, lvPosting, err = findCompanyBoard(ctx, company)
+		if err == nil {
+			board = companyBoardNone
+			if ats != "" {
+				board = ats + ":" + slug
+				if uErr := UpsertCompany(ctx, Company{Name: company, ATS: ats, ATSSlug: slug}); uErr != nil {
+					slog.Debug("company ats: store board failed", slog.Any("error", uErr))
+				}
+			}
+			engine.CacheSetLookup(ctx, "ats_board", key, board)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s board for %s: %w", ats, company, err)
+	}
+
+	keywords := strings.Fields(strings.ToLower(query))
+	var results []engine.SearxngResult
+	for i := range ghJobs {
+		job := &ghJobs[i]
+		if !matchesKeywords(job.Title+" "+job.Location.Name, keywords) {
+			continue
+		}
+		jobURL := job.AbsoluteURL
+		if jobURL == "" {
+			jobURL = fmt.Sprintf("https://boards.greenhouse.io/%s/jobs/%d", slug, job.ID)
+		}
+		results = append(results, engine.SearxngResult{
+			Title:   job.Title,
+			Content: engine.TruncateRunes(formatGreenhousePosting(slug, job), 800, "..."),
+			URL:     jobURL,
+			Score:   0.95,
+		})
+	}
+	for i := range lvPosting {
+		p := &lvPosting[i]
+		if !matchesKeywords(p.Text+" "+p.Categories.Location+" "+p.Categories.Team, keywords) {
+			continue
+		}
+		jobURL := p.HostedURL
+		if jobURL == "" {
+			jobURL = fmt.Sprintf("https://jobs.lever.co/%s/%s", slug, p.ID)
+		}
+		results = append(results, engine.SearxngResult{
+			Title:   p.Text,
+			Content: engine.TruncateRunes(formatLeverPosting(slug, &leverPostingDetail{leverPosting: *p}), 800, "..."),
+			URL:     jobURL,
+			Score:   0.95,
+		})
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	slog.Debug("company ats: search complete", slog.String("company", company), slog.String("board", ats+":"+slug), slog.Int("results", len(results)))
+	return results, nil
+}
+
+// findCompanyBoard probes Greenhouse, then Lever, for each slug candidate. A board
+// with zero open jobs counts as not found. Returns ats == "" when nothing matched.
+func findCompanyBoard(ctx context.Context, company string) (ats, slug string, gh []greenhouseJob, lv []leverPosting, err error) {
+	for _, s := range companySlugCandidates(company) {
+		engine.IncrGreenhouseRequests(ctx)
+		jobs, gErr := fetchGreenhouseJobs(ctx, s)
+		if gErr == nil && len(jobs) > 0 {
+			return "greenhouse", s, jobs, nil, nil
+		}
+		engine.IncrLeverRequests(ctx)
+		postings, lErr := fetchLeverPostings(ctx, s)
+		if lErr == nil && len(postings) > 0 {
+			return "lever", s, nil, postings, nil
+		}
+		if ctx.Err() != nil {
+			return "", "", nil, nil, ctx.Err()
+		}
+	}
+	return "", "", nil, nil, nil
+}
+
+// companySlugCandidates derives likely board slugs: "Acme Robotics, Inc." →
+// acmerobotics, acme-robotics, acme.
+func companySlugCandidates(company string) []string {
+	words := strings.FieldsFunc(strings.ToLower(company), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var kept []string
+	for _, w := range words {
+		switch w {
+		case "inc", "llc", "ltd", "gmbh", "corp", "corporation", "co", "company", "the":
+			continue
+		}
+		kept = append(kept, w)
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+
+	var out []string
+	seen := make(map[string]bool)
+	add := func(s string) {
+		if s != "" && !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	add(strings.Join(kept, ""))
+	add(strings.Join(kept, "-"))
+	add(kept[0])
+	return out
+}
@@ -0,0 +1,200 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Full ATS postings ---
+
+const (
+	greenhouseJobAPI = "https://boards-api.greenhouse.io/v1/boards/%s/jobs/%s"
+	leverPostingAPI  = "https://api.lever.co/v0/postings/%s/%s"
+)
+
+var (
+	// greenhousePostingRe matches boards.greenhouse.io/<slug>/jobs/<id> (and job-boards.*).
+	greenhousePostingRe = regexp.MustCompile(`(?:job-)?boards\.greenhouse\.io/([^/?#]+)/jobs/(\d+)`)
+	// leverPostingRe matches jobs.lever.co/<slug>/<uuid>.
+	leverPostingRe = regexp.MustCompile(`jobs\.lever\.co/([^/?#]+)/([0-9a-fA-F-]{36})`)
+)
+
+// leverPostingDetail is a single Lever posting; unlike the list API it carries the
+// requirement lists and closing text.
+type leverPostingDetail struct {
+	leverPosting
+	Lists []struct {
+		Text    string `json:"text"`
+		Content string `json:"content"`
+	} `json:"lists"`
+	AdditionalPlain string `json:"additionalPlain"`
+}
+
+// parseATSPostingURL identifies a Greenhouse or Lever posting URL. ats is "greenhouse",
+// "lever" or "" when the URL is not a single posting.
+func parseATSPostingURL(jobURL string) (ats, slug, id string) {
+	if m := greenhousePostingRe.FindStringSubmatch(jobURL); m != nil {
+		return "greenhouse", strings.ToLower(m[1]), m[2]
+	}
+	if m := leverPostingRe.FindStringSubmatch(jobURL); m != nil {
+		return "lever", strings.ToLower(m[1]), strings.ToLower(m[2])
+	}
+	return "", "", ""
+}
+
+// IsATSPostingURL reports whether jobURL is a single Greenhouse or Lever posting.
+func IsATSPostingURL(jobURL string) bool {
+	ats, _, _ := parseATSPostingURL(jobURL)
+	return ats != ""
+}
+
+// FetchATSPosting returns the full description of a Greenhouse or Lever posting from the
+// board's public JSON API, formatted like the ATS search results. Results are cached
+// for engine.JobDetailsTTL.
+func FetchATSPosting(ctx context.Context, jobURL string) (string, error) {
+	ats, slug, id := parseATSPostingURL(jobURL)
+	if ats == "" {
+		return "", fmt.Errorf("not an ATS posting URL: %s", jobURL)
+	}
+	if cached, ok := engine.CacheGetJobDetails(ctx, jobURL); ok {
+		return cached, nil
+	}
+
+	var text string
+	switch ats {
+	case "greenhouse":
+		engine.IncrGreenhouseRequests(ctx)
+		var job greenhouseJob
+		if err := fetchATSJSON(ctx, fmt.Sprintf(greenhouseJobAPI, slug, id), &job); err != nil {
+			return "", fmt.Errorf("greenhouse posting: %w", err)
+		}
+		text = formatGreenhousePosting(slug, &job)
+	case "lever":
+		engine.IncrLeverRequests(ctx)
+		var p leverPostingDetail
+		if err := fetchATSJSON(ctx, fmt.Sprintf(leverPostingAPI, slug, id), &p); err != nil {
+			return "", fmt.Errorf("lever posting: %w", err)
+		}
+		text = formatLeverPosting(slug, &p)
+	}
+
+	if limit := engine.ConfigFrom(ctx).MaxContentChars; limit > 0 {
+		text = engine.TruncateRunes(text, limit, "...")
+	}
+	engine.CacheSetJobDetails(ctx, jobURL, text)
+	return text, nil
+}
+
+// EnrichATSResults replaces the snippet of every Greenhouse/Lever posting in results with
+// its full description, fetched on the outbound pool. Failed fetches keep the snippet.
+// Returns the number enriched.
+func EnrichATSResults(ctx context.Context, results []engine.SearxngResult) int {
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+		n  int
+	)
+	for i := range results {
+		if !IsATSPostingURL(results[i].URL) {
+			continue
+		}
+		wg.Add(1)
+		engine.GoOutbound(ctx, func() {
+			defer wg.Done()
+			text, err := FetchATSPosting(ctx, results[i].URL)
+			if err != nil {
+				slog.Debug("ats posting fetch failed", slog.String("url", results[i].URL), slog.Any("error", err))
+				return
+			}
+			results[i].Content = text
+			mu.Lock()
+			n++
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	return n
+}
+
+func formatGreenhousePosting(slug string, job *greenhouseJob) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**Source:** Greenhouse | **Company:** %s | **Title:** %s | **Location:** %s", slug, job.Title, job.Location.Name)
+	if len(job.Departments) > 0 {
+		sb.WriteString(" | **Dept:** " + job.Departments[0].Name)
+	}
+	if len(job.UpdatedAt) >= 10 {
+		sb.WriteString(" | **Updated:** " + job.UpdatedAt[:10])
+	}
+	// The API returns the description as entity-escaped HTML.
+	if desc := engine.CleanHTML(html.UnescapeString(job.Content)); desc != "" {
+		sb.WriteString("\n\n" + desc)
+	}
+	return sb.String()
+}
+
+func formatLeverPosting(slug string, p *leverPostingDetail) string {
+	var sb strings.Builder
+	loc := p.Categories.Location
+	if loc == "" {
+		loc = strings.Join(p.Categories.AllLocations, ", ")
+	}
+	fmt.Fprintf(&sb, "**Source:** Lever | **Company:** %s | **Title:** %s | **Location:** %s", slug, p.Text, loc)
+	if p.Categories.Team != "" {
+		sb.WriteString(" | **Team:** " + p.Categories.Team)
+	}
+	if p.Categories.Commitment != "" {
+		sb.WriteString(" | **Type:** " + p.Categories.Commitment)
+	}
+	if p.WorkplaceType != "" {
+		sb.WriteString(" | **Remote:** " + p.WorkplaceType)
+	}
+	if p.SalaryRange.Min > 0 {
+		fmt.Fprintf(&sb, " | **Salary:** %d-%d %s", p.SalaryRange.Min, p.SalaryRange.Max, p.SalaryRange.Currency)
+	}
+	if p.DescriptionPlain != "" {
+		sb.WriteString("\n\n" + strings.TrimSpace(p.DescriptionPlain))
+	}
+	for _, l := range p.Lists {
+		sb.WriteString("\n\n" + l.Text + ":\n" + engine.CleanHTML(l.Content))
+	}
+	if p.AdditionalPlain != "" {
+		sb.WriteString("\n\n" + strings.TrimSpace(p.AdditionalPlain))
+	}
+	return sb.String()
+}
+
+// fetchATSJSON GETs an ATS API URL and decodes the JSON body into v.
+func fetchATSJSON(ctx context.Context, apiURL string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", engine.UserAgentBot)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // ATS API URL built from a matched posting URL
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 2*1024*1024))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
@@ -342,3 +342,72 @@ func TestLeverResultContent(t *testing.T) {
 		t.Errorf("missing description: %s", result.Content)
 	}
 }
+
+// --- ATS postings ---
+
+func TestParseATSPostingURL(t *testing.T) {
+	tests := []struct {
+		url           string
+		ats, slug, id string
+	}{
+		{"https://boards.greenhouse.io/Stripe/jobs/123456?gh_src=x", "greenhouse", "stripe", "123456"},
+		{"https://job-boards.greenhouse.io/anthropic/jobs/789", "greenhouse", "anthropic", "789"},
+		{"https://jobs.lever.co/netflix/0b9c5a3e-1f2d-4c5b-9a8e-7d6c5b4a3f21/apply", "lever", "netflix", "0b9c5a3e-1f2d-4c5b-9a8e-7d6c5b4a3f21"},
+		{"https://boards.greenhouse.io/stripe", "", "", ""},
+		{"https://jobs.lever.co/netflix", "", "", ""},
+		{"https://example.com/jobs/1", "", "", ""},
+	}
+	for _, tt := range tests {
+		ats, slug, id := parseATSPostingURL(tt.url)
+		if ats != tt.ats || slug != tt.slug || id != tt.id {
+			t.Errorf("parseATSPostingURL(%q) = %q, %q, %q; want %q, %q, %q", tt.url, ats, slug, id, tt.ats, tt.slug, tt.id)
+		}
+	}
+}
+
+func TestFormatGreenhousePosting(t *testing.T) {
+	job := &greenhouseJob{Title: "Backend Engineer", Content: "&lt;p&gt;Build &lt;b&gt;Go&lt;/b&gt; services&lt;/p&gt;"}
+	job.Location.Name = "Remote"
+	got := formatGreenhousePosting("acme", job)
+	if !strings.Contains(got, "**Source:** Greenhouse") || !strings.Contains(got, "Remote") {
+		t.Errorf("missing header fields: %q", got)
+	}
+	if !strings.Contains(got, "Build Go services") || strings.Contains(got, "&lt;") {
+		t.Errorf("description not unescaped and cleaned: %q", got)
+	}
+}
+
+func TestFormatLeverPosting(t *testing.T) {
+	var p leverPostingDetail
+	p.Text = "SRE"
+	p.Categories.AllLocations = []string{"Berlin", "Remote"}
+	p.DescriptionPlain = "Keep things running."
+	p.Lists = append(p.Lists, struct {
+		Text    string `json:"text"`
+		Content string `json:"content"`
+	}{Text: "Requirements", Content: "<li>Kubernetes</li>"})
+	got := formatLeverPosting("acme", &p)
+	for _, want := range []string{"**Source:** Lever", "Berlin, Remote", "Keep things running.", "Requirements:", "Kubernetes"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in %q", want, got)
+		}
+	}
+}
+
+func TestCompanySlugCandidates(t *testing.T) {
+	tests := []struct {
+		company string
+		want    []string
+	}{
+		{"Stripe", []string{"stripe"}},
+		{"Acme Robotics, Inc.", []string{"acmerobotics", "acme-robotics", "acme"}},
+		{"The Browser Company", []string{"browser"}},
+		{"  ", nil},
+	}
+	for _, tt := range tests {
+		got := companySlugCandidates(tt.company)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("companySlugCandidates(%q) = %v, want %v", tt.company, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Audit log ---
+
+// Every call of a data-mutating tool (tracker changes, resume rebuilds, enrichment,
+// memory and profile updates) is recorded with its time, tool name and a digest of
+// its input, so an unexpected change — master_resume_build clears the previous
+// master resume — can be traced back to the call that made it. Inputs are not
+// stored, only their SHA-256; compare a digest with AuditDigest of a known input.
+
+// AuditEntry is one recorded mutation.
+type AuditEntry struct {
+	ID          int64  `json:"id"`
+	At          string `json:"at"` // RFC 3339, UTC
+	Tool        string `json:"tool"`
+	InputDigest string `json:"input_digest"` // hex SHA-256 of the compacted JSON input
+	Error       string `json:"error,omitempty"`
+}
+
+// AuditLogResult is the result of audit_log.
+type AuditLogResult struct {
+	Entries []AuditEntry `json:"entries"`
+	Summary string       `json:"summary"`
+}
+
+const (
+	auditDefaultLimit = 50
+	auditMaxLimit     = 500
+)
+
+// initAuditLogSchema creates the audit_log table in the tracker database.
+func initAuditLogSchema(db *sql.DB) error {
+	schema := `CREATE TABLE IF NOT EXISTS audit_log (
+		id           INTEGER PRIMARY KEY AUTOINCREMENT,
+		at           TEXT NOT NULL,
+		tool         TEXT NOT NULL,
+		input_digest TEXT NOT NULL,
+		error        TEXT
+	);
+	CREATE INDEX IF NOT EXISTS idx_audit_log_tool ON audit_log(tool, at)`
+	_, err := db.Exec(schema) //nolint:noctx // schema init, no user context available
+	return err
+}
+
+// AuditDigest is the hex SHA-256 of a JSON input, compacted first so whitespace
+// does not change it.
+func AuditDigest(input []byte) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, input); err == nil {
+		input = buf.Bytes()
+	}
+	sum := sha256.Sum256(input)
+	return hex.EncodeToString(sum[:])
+}
+
+// RecordAudit records a call of a mutating tool. callErr is the call's error, nil on
+// success. Failures to record are logged, never returned: the audit log must not
+// break the call it describes.
+func RecordAudit(ctx context.Context, tool string, input []byte, callErr error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		slog.Warn("audit: open tracker failed", slog.String("tool", tool), slog.Any("error", err))
+		return
+	}
+	var errText *string
+	if callErr != nil {
+		s := engine.TruncateRunes(callErr.Error(), 500, "...")
+		errText = &s
+	}
+	_, err = db.ExecContext(ctx, `INSERT INTO audit_log (at, tool, input_digest, error) VALUES (?, ?, ?, ?)`,
+		time.Now().UTC().Format(time.RFC3339), tool, AuditDigest(input), errText)
+	if err != nil {
+		slog.Warn("audit: record failed", slog.String("tool", tool), slog.Any("error", err))
+	}
+}
+
+// ListAuditLog returns recorded mutations, newest first, optionally filtered by tool
+// and a since date (YYYY-MM-DD or RFC 3339).
+func ListAuditLog(ctx context.Context, input engine.AuditLogInput) (*AuditLogResult, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT id, at, tool, input_digest, error FROM audit_log WHERE 1=1`
+	var args []any
+	if tool := strings.TrimSpace(input.Tool); tool != "" {
+		query += ` AND tool = ?`
+		args = append(args, tool)
+	}
+	if since := strings.TrimSpace(input.Since); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			if t, err = time.Parse(time.DateOnly, since); err != nil {
+				return nil, fmt.Errorf("audit_log: since %q must be YYYY-MM-DD or RFC 3339", input.Since)
+			}
+		}
+		query += ` AND at >= ?`
+		args = append(args, t.UTC().Format(time.RFC3339))
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = auditDefaultLimit
+	}
+	limit = min(limit, auditMaxLimit)
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("audit_log: query: %w", err)
+	}
+	defer rows.Close()
+	result := &AuditLogResult{Entries: []AuditEntry{}}
+	failed := 0
+	for rows.Next() {
+		var e AuditEntry
+		var errText sql.NullString
+		if err := rows.Scan(&e.ID, &e.At, &e.Tool, &e.InputDigest, &errText); err != nil {
+			return nil, fmt.Errorf("audit_log: scan: %w", err)
+		}
+		e.Error = errText.String
+		if e.Error != "" {
+			failed++
+		}
+		result.Entries = append(result.Entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("audit_log: rows: %w", err)
+	}
+	result.Summary = fmt.Sprintf("%d mutation(s)", len(result.Entries))
+	if failed > 0 {
+		result.Summary += fmt.Sprintf(", %d failed", failed)
+	}
+	if len(result.Entries) == limit {
+		result.Summary += fmt.Sprintf(" (limit %d reached)", limit)
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Availability: the profile's earliest start date and notice period go into cover
+// letters, and listings demanding an immediate start the user cannot make are flagged.
+
+// immediateStartDays is how soon an "immediate start" listing expects the hire to begin.
+const immediateStartDays = 14
+
+var immediateStartRe = regexp.MustCompile(`(?i)\b(immediate start|start(ing)? immediately|available immediately|immediately available|immediate joiners?|join immediately|start asap|asap start|urgently (hiring|needed))\b`)
+
+// EarliestStart returns the first day the user can start: the later of AvailableFrom
+// and today plus the notice period. ok is false when the profile records neither.
+func (p *UserProfile) EarliestStart(now time.Time) (time.Time, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start, ok := today, false
+	if p.NoticePeriodDays > 0 {
+		start, ok = today.AddDate(0, 0, p.NoticePeriodDays), true
+	}
+	if from, err := time.Parse(time.DateOnly, p.AvailableFrom); err == nil {
+		if from.After(start) {
+			start = from
+		}
+		ok = true
+	}
+	return start, ok
+}
+
+// AvailabilityText describes the user's availability for prompts, or "" when unknown.
+func (p *UserProfile) AvailabilityText(now time.Time) string {
+	start, ok := p.EarliestStart(now)
+	if !ok {
+		return ""
+	}
+	text := "Available to start " + start.Format(time.DateOnly)
+	if p.NoticePeriodDays > 0 {
+		text += fmt.Sprintf(" (%d-day notice period)", p.NoticePeriodDays)
+	}
+	return text
+}
+
+// FlagStartConflicts marks listings that demand an immediate start when the user's
+// earliest start is more than immediateStartDays away.
+func FlagStartConflicts(listings []engine.JobListing, p *UserProfile, now time.Time) {
+	start, ok := p.EarliestStart(now)
+	if !ok || !start.After(now.AddDate(0, 0, immediateStartDays)) {
+		return
+	}
+	for i := range listings {
+		if e := listings[i].Eligibility; e != nil && e.ImmediateStart {
+			e.StartConflict = "earliest start " + start.Format(time.DateOnly)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestFlagStartConflicts(t *testing.T) {
+	now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	p := &UserProfile{AvailableFrom: "2026-03-20", NoticePeriodDays: 30}
+	if start, ok := p.EarliestStart(now); !ok || start.Format(time.DateOnly) != "2026-04-01" {
+		t.Errorf("EarliestStart = %v, %v; want notice period to win", start, ok)
+	}
+	if got := p.AvailabilityText(now); got != "Available to start 2026-04-01 (30-day notice period)" {
+		t.Errorf("AvailabilityText = %q", got)
+	}
+
+	listings := []engine.JobListing{
+		{Title: "Go Engineer", Description: "Immediate start, contract role."},
+		{Title: "Backend Engineer", Description: "Start date flexible."},
+	}
+	BuildListingV2(listings)
+	FlagStartConflicts(listings, &UserProfile{}, now)
+	if listings[0].Eligibility.StartConflict != "" {
+		t.Error("without availability nothing should be flagged")
+	}
+	FlagStartConflicts(listings, p, now)
+	if listings[0].Eligibility.StartConflict != "earliest start 2026-04-01" {
+		t.Errorf("eligibility = %+v", listings[0].Eligibility)
+	}
+	if listings[1].Eligibility != nil {
+		t.Errorf("listing without start demands got %+v", listings[1].Eligibility)
+	}
+}
@@ -0,0 +1,247 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// backgroundJobTimeout bounds a single background job, independent of the MCP request that started it.
+const backgroundJobTimeout = 30 * time.Minute
+
+// backgroundQueueMax bounds the jobs queued behind the running one on the same key.
+const backgroundQueueMax = 4
+
+// MasterResumeJobKey serializes background jobs that write the master resume.
+const MasterResumeJobKey = "master_resume"
+
+// ErrBackgroundQueueFull is returned when a key already has backgroundQueueMax jobs queued.
+var ErrBackgroundQueueFull = errors.New("too many background jobs queued; poll job_status and retry when one finishes")
+
+// backgroundQueues runs background jobs that share a key one at a time, in start
+// order. An entry lives only while jobs on its key run or wait.
+var (
+	backgroundQueuesMu sync.Mutex
+	backgroundQueues   = make(map[string]*backgroundQueue)
+)
+
+type backgroundQueue struct {
+	tail chan struct{} // closed when the last job queued on the key finishes
+	refs int           // jobs running or waiting
+}
+
+// enqueueBackgroundJob appends a job to key's queue and returns the funcs that wait
+// for the jobs ahead of it and mark it finished. It fails when the queue is full.
+func enqueueBackgroundJob(key string) (wait func(), done func(), err error) {
+	backgroundQueuesMu.Lock()
+	defer backgroundQueuesMu.Unlock()
+	q := backgroundQueues[key]
+	if q == nil {
+		q = &backgroundQueue{}
+		backgroundQueues[key] = q
+	}
+	if q.refs > backgroundQueueMax {
+		return nil, nil, ErrBackgroundQueueFull
+	}
+	q.refs++
+	prev, finished := q.tail, make(chan struct{})
+	q.tail = finished
+	wait = func() {
+		if prev != nil {
+			<-prev
+		}
+	}
+	done = func() {
+		close(finished)
+		backgroundQueuesMu.Lock()
+		defer backgroundQueuesMu.Unlock()
+		if q.refs--; q.refs == 0 {
+			delete(backgroundQueues, key)
+		}
+	}
+	return wait, done, nil
+}
+
+// Background job states.
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// BackgroundJob is the persisted state of a long-running tool call.
+type BackgroundJob struct {
+	ID        string `json:"job_id"`
+	Kind      string `json:"kind"`
+	Status    string `json:"status"`
+	Progress  int    `json:"progress"` // 0–100
+	Stage     string `json:"stage,omitempty"`
+	Result    any    `json:"result,omitempty"`
+	Error     string `json:"error,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ProgressFunc receives progress updates (percent 0–100 and a short stage name).
+type ProgressFunc func(percent int, stage string)
+
+type progressKey struct{}
+
+// WithProgress returns a context whose long-running operations report progress to fn.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// reportProgress forwards progress to the ProgressFunc in ctx, if any.
+func reportProgress(ctx context.Context, percent int, stage string) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok {
+		fn(percent, stage)
+	}
+}
+
+// initBackgroundJobsSchema creates the background_jobs table. Jobs still queued or running
+// belonged to a previous process and can never finish, so they are marked failed.
+func initBackgroundJobsSchema(db *sql.DB) error {
+	schema := `CREATE TABLE IF NOT EXISTS background_jobs (
+		id         TEXT PRIMARY KEY,
+		kind       TEXT NOT NULL,
+		status     TEXT NOT NULL,
+		progress   INTEGER NOT NULL DEFAULT 0,
+		stage      TEXT,
+		result     TEXT,
+		error      TEXT,
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL
+	)`
+	if _, err := db.Exec(schema); err != nil { //nolint:noctx // schema init, no user context available
+		return err
+	}
+	_, err := db.Exec( //nolint:noctx // schema init, no user context available
+		`UPDATE background_jobs SET status = ?, error = 'interrupted by server restart', updated_at = ?
+		 WHERE status IN (?, ?)`,
+		JobFailed, time.Now().UTC().Format(time.RFC3339), JobQueued, JobRunning)
+	return err
+}
+
+// StartBackgroundJob persists a queued job and runs fn in a background goroutine detached
+// from ctx's cancellation. Jobs with the same non-empty key run one at a time in start
+// order, and at most backgroundQueueMax wait; past that it returns ErrBackgroundQueueFull.
+// fn reports progress through the context (see WithProgress); its result is stored as
+// JSON and returned by GetBackgroundJob.
+func StartBackgroundJob(ctx context.Context, kind, key string, fn func(ctx context.Context) (any, error)) (*BackgroundJob, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	wait, done := func() {}, func() {}
+	if key != "" {
+		if wait, done, err = enqueueBackgroundJob(key); err != nil {
+			return nil, err
+		}
+	}
+
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, fmt.Errorf("background job id: %w", err)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	job := &BackgroundJob{
+		ID:        "job_" + hex.EncodeToString(b[:]),
+		Kind:      kind,
+		Status:    JobQueued,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	_, err = db.ExecContext(ctx,
+		`INSERT INTO background_jobs (id, kind, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
+		job.ID, job.Kind, job.Status, now, now)
+	if err != nil {
+		done()
+		return nil, fmt.Errorf("background job insert: %w", err)
+	}
+
+	go func() {
+		defer done()
+		wait()
+		runBackgroundJob(context.WithoutCancel(ctx), db, job.ID, fn)
+	}()
+	return job, nil
+}
+
+func runBackgroundJob(base context.Context, db *sql.DB, id string, fn func(ctx context.Context) (any, error)) {
+	ctx, cancel := context.WithTimeout(base, backgroundJobTimeout)
+	defer cancel()
+
+	// Status writes use base so the final update still lands after a timeout.
+	update := func(query string, args ...any) {
+		args = append(args, time.Now().UTC().Format(time.RFC3339), id)
+		if _, err := db.ExecContext(base, query+`, updated_at = ? WHERE id = ?`, args...); err != nil {
+			slog.Warn("background job: update failed", slog.String("id", id), slog.Any("error", err))
+		}
+	}
+
+	update(`UPDATE background_jobs SET status = ?`, JobRunning)
+	ctx = WithProgress(ctx, func(percent int, stage string) {
+		update(`UPDATE background_jobs SET progress = ?, stage = ?`, percent, stage)
+	})
+
+	result, err := runRecovered(ctx, fn)
+	if err != nil {
+		slog.Warn("background job failed", slog.String("id", id), slog.Any("error", err))
+		update(`UPDATE background_jobs SET status = ?, error = ?`, JobFailed, err.Error())
+		return
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		update(`UPDATE background_jobs SET status = ?, error = ?`, JobFailed, "encode result: "+err.Error())
+		return
+	}
+	update(`UPDATE background_jobs SET status = ?, progress = 100, result = ?`, JobSucceeded, string(data))
+}
+
+// runRecovered calls fn, turning a panic into an error so a job never stays "running".
+func runRecovered(ctx context.Context, fn func(ctx context.Context) (any, error)) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
+
+// GetBackgroundJob returns the current state of a background job.
+func GetBackgroundJob(ctx context.Context, id string) (*BackgroundJob, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		job                  BackgroundJob
+		stage, result, jbErr sql.NullString
+	)
+	err = db.QueryRowContext(ctx,
+		`SELECT id, kind, status, progress, stage, result, error, created_at, updated_at
+		 FROM background_jobs WHERE id = ?`, id,
+	).Scan(&job.ID, &job.Kind, &job.Status, &job.Progress, &stage, &result, &jbErr, &job.CreatedAt, &job.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("job %q not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("background job query: %w", err)
+	}
+	job.Stage, job.Error = stage.String, jbErr.String
+	if result.String != "" {
+		if err := json.Unmarshal([]byte(result.String), &job.Result); err != nil {
+			return nil, fmt.Errorf("background job result: %w", err)
+		}
+	}
+	return &job, nil
+}
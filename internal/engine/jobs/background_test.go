@@ -0,0 +1,200 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func waitForJob(t *testing.T, id string) *BackgroundJob {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := GetBackgroundJob(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.Status == JobSucceeded || job.Status == JobFailed {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return nil
+}
+
+func TestBackgroundJob_Succeeds(t *testing.T) {
+	resetTracker(t)
+
+	release := make(chan struct{})
+	job, err := StartBackgroundJob(context.Background(), "test", "", func(ctx context.Context) (any, error) {
+		reportProgress(ctx, 40, "halfway")
+		<-release
+		return map[string]int{"items": 3}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != JobQueued || job.ID == "" {
+		t.Fatalf("unexpected initial job: %+v", job)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, err := GetBackgroundJob(context.Background(), job.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Progress == 40 {
+			if got.Status != JobRunning || got.Stage != "halfway" {
+				t.Errorf("in-flight job = %+v", got)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("progress never reported")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(release)
+
+	done := waitForJob(t, job.ID)
+	if done.Status != JobSucceeded || done.Progress != 100 {
+		t.Fatalf("finished job = %+v", done)
+	}
+	res, ok := done.Result.(map[string]any)
+	if !ok || res["items"] != float64(3) {
+		t.Errorf("result = %#v", done.Result)
+	}
+}
+
+func TestBackgroundJob_FailsAndRecoversPanics(t *testing.T) {
+	resetTracker(t)
+
+	failing, err := StartBackgroundJob(context.Background(), "test", "", func(context.Context) (any, error) {
+		return nil, errors.New("boom")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	panicking, err := StartBackgroundJob(context.Background(), "test", "", func(context.Context) (any, error) {
+		panic("bad input")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := waitForJob(t, failing.ID); got.Status != JobFailed || got.Error != "boom" {
+		t.Errorf("failing job = %+v", got)
+	}
+	if got := waitForJob(t, panicking.ID); got.Status != JobFailed || got.Error != "panic: bad input" {
+		t.Errorf("panicking job = %+v", got)
+	}
+}
+
+func TestBackgroundJob_InterruptedByRestart(t *testing.T) {
+	resetTracker(t)
+
+	release := make(chan struct{})
+	defer close(release)
+	job, err := StartBackgroundJob(context.Background(), "test", "", func(context.Context) (any, error) {
+		<-release
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a restart: reopen the database without resetting HOME.
+	trackerDB = nil
+	trackerErr = nil
+	trackerOnce = sync.Once{}
+
+	got, err := GetBackgroundJob(context.Background(), job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != JobFailed || got.Error == "" {
+		t.Errorf("job after restart = %+v", got)
+	}
+	if _, err := GetBackgroundJob(context.Background(), "job_missing"); err == nil {
+		t.Error("expected error for unknown job")
+	}
+}
+
+func TestBackgroundJob_SerializesByKey(t *testing.T) {
+	resetTracker(t)
+
+	var (
+		mu      sync.Mutex
+		order   []int
+		running int
+		overlap bool
+	)
+	release := make(chan struct{})
+	var ids []string
+	for i := range backgroundQueueMax + 1 {
+		job, err := StartBackgroundJob(context.Background(), "test", "resume", func(context.Context) (any, error) {
+			mu.Lock()
+			running++
+			overlap = overlap || running > 1
+			order = append(order, i)
+			mu.Unlock()
+			<-release
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatalf("job %d: %v", i, err)
+		}
+		ids = append(ids, job.ID)
+	}
+	if _, err := StartBackgroundJob(context.Background(), "test", "resume", func(context.Context) (any, error) { return nil, nil }); !errors.Is(err, ErrBackgroundQueueFull) {
+		t.Fatalf("job past the queue bound: err = %v, want ErrBackgroundQueueFull", err)
+	}
+	other, err := StartBackgroundJob(context.Background(), "test", "other", func(context.Context) (any, error) { return nil, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := waitForJob(t, other.ID); got.Status != JobSucceeded {
+		t.Errorf("job on another key = %+v", got)
+	}
+	if got, _ := GetBackgroundJob(context.Background(), ids[1]); got.Status != JobQueued {
+		t.Errorf("second job on the key = %s, want queued", got.Status)
+	}
+
+	close(release)
+	for _, id := range ids {
+		waitForJob(t, id)
+	}
+	mu.Lock()
+	if overlap {
+		t.Error("jobs on the same key ran concurrently")
+	}
+	for i, n := range order {
+		if n != i {
+			t.Errorf("run order = %v, want start order", order)
+			break
+		}
+	}
+	mu.Unlock()
+
+	// The queue entry is dropped just after the last status write.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		backgroundQueuesMu.Lock()
+		left := len(backgroundQueues)
+		backgroundQueuesMu.Unlock()
+		if left == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d queues left after all jobs finished", left)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
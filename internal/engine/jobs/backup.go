@@ -0,0 +1,636 @@
+package jobs
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Backup and restore ---
+
+// data_export writes all user data into one zip archive: the resume tables (IDs
+// kept), the resume graph as replayable node/edge ops, a copy of the SQLite tracker,
+// the search profile and the last job_search results. data_import restores it —
+// migrating between deployments, or undoing a master_resume_build that replaced the
+// master resume. MemDB vectors are not archived: they are rebuilt from the restored
+// records by vectors_resync.
+
+// Backup sections.
+const (
+	BackupResume     = "resume"
+	BackupGraph      = "graph"
+	BackupTracker    = "tracker"
+	BackupProfile    = "profile"
+	BackupLastSearch = "last_search"
+)
+
+var backupSections = []string{BackupResume, BackupGraph, BackupTracker, BackupProfile, BackupLastSearch}
+
+// backupFormatVersion is bumped when an archive entry changes incompatibly.
+const backupFormatVersion = 1
+
+// backupFiles maps a section to its archive entry.
+var backupFiles = map[string]string{
+	BackupResume:     "resume.json",
+	BackupGraph:      "graph.json",
+	BackupTracker:    "tracker.db",
+	BackupProfile:    "profile.json",
+	BackupLastSearch: "last_search.json",
+}
+
+// trackerBackupSkip are tracker tables that describe this deployment rather than the
+// user's data; they are neither archived over nor cleared on restore.
+var trackerBackupSkip = map[string]bool{
+	"background_jobs":  true,
+	"idempotency_keys": true,
+	"audit_log":        true,
+}
+
+// DataExportInput is the input for data_export.
+type DataExportInput struct {
+	Path        string `json:"path,omitempty" jsonschema:"Archive file name under ~/.go_job/backups (default <timestamp>_go_job_backup.zip); paths outside that directory are rejected"`
+	IncludeData bool   `json:"include_data,omitempty" jsonschema:"Also return the archive as base64, to carry it to another deployment"`
+}
+
+// DataImportInput is the input for data_import.
+type DataImportInput struct {
+	Path     string   `json:"path,omitempty" jsonschema:"Archive written by data_export: its path or file name under ~/.go_job/backups"`
+	Data     string   `json:"data,omitempty" jsonschema:"The archive as base64 (data_export with include_data), instead of path"`
+	Sections []string `json:"sections,omitempty" jsonschema:"Only restore these: resume, graph, tracker, profile, last_search (default: all in the archive)"`
+	DryRun   bool     `json:"dry_run,omitempty" jsonschema:"Only report what the archive contains"`
+}
+
+// backupManifest is the manifest.json entry of an archive.
+type backupManifest struct {
+	Version   int            `json:"version"`
+	CreatedAt string         `json:"created_at"`
+	Sections  []string       `json:"sections"`
+	Counts    map[string]int `json:"counts"`
+}
+
+// backupLastSearch is the last_search.json entry.
+type backupLastSearch struct {
+	Query string              `json:"query"`
+	Jobs  []engine.JobListing `json:"jobs"`
+}
+
+// DataExportResult is the output of data_export.
+type DataExportResult struct {
+	Path     string         `json:"path"`
+	Bytes    int            `json:"bytes"`
+	Sections []string       `json:"sections"`
+	Counts   map[string]int `json:"counts"`            // rows per resume/tracker table, graph_nodes, graph_edges, last_search_jobs
+	Skipped  []string       `json:"skipped,omitempty"` // sections left out, with the reason
+	Data     string         `json:"data,omitempty"`    // base64 archive, with include_data
+	Summary  string         `json:"summary"`
+}
+
+// DataImportResult is the output of data_import.
+type DataImportResult struct {
+	CreatedAt    string               `json:"created_at"` // when the archive was written
+	Sections     []string             `json:"sections"`   // restored (or, with dry_run, restorable)
+	Counts       map[string]int       `json:"counts"`
+	SafetyBackup string               `json:"safety_backup,omitempty"` // archive of the data replaced
+	GraphFailed  int                  `json:"graph_failed,omitempty"`  // graph ops that could not be written
+	Vectors      *VectorsResyncResult `json:"vectors,omitempty"`
+	Notes        []string             `json:"notes,omitempty"`
+	Summary      string               `json:"summary"`
+}
+
+// DataExport writes all user data into a zip archive. Sections whose store is not
+// configured are skipped and reported.
+func DataExport(ctx context.Context, input DataExportInput) (*DataExportResult, error) {
+	archive, manifest, skipped, err := buildBackup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := input.Path
+	if name == "" {
+		name = time.Now().UTC().Format("20060102-150405") + "_go_job_backup.zip"
+	}
+	path, err := backupPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("data_export: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("data_export: mkdir %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, archive, 0o600); err != nil {
+		return nil, fmt.Errorf("data_export: write: %w", err)
+	}
+	result := &DataExportResult{
+		Path:     path,
+		Bytes:    len(archive),
+		Sections: manifest.Sections,
+		Counts:   manifest.Counts,
+		Skipped:  skipped,
+	}
+	if input.IncludeData {
+		result.Data = base64.StdEncoding.EncodeToString(archive)
+	}
+	result.Summary = fmt.Sprintf("Backed up %s to %s (%d KB).", strings.Join(manifest.Sections, ", "), path, (len(archive)+1023)/1024)
+	if len(skipped) > 0 {
+		result.Summary += " Skipped: " + strings.Join(skipped, "; ") + "."
+	}
+	return result, nil
+}
+
+// buildBackup collects every available section into a zip archive.
+func buildBackup(ctx context.Context) ([]byte, *backupManifest, []string, error) {
+	manifest := &backupManifest{
+		Version:   backupFormatVersion,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Counts:    make(map[string]int),
+	}
+	entries := make(map[string][]byte)
+	var skipped []string
+	add := func(section string, v any) error {
+		data, ok := v.([]byte)
+		if !ok {
+			var err error
+			if data, err = json.MarshalIndent(v, "", "  "); err != nil {
+				return fmt.Errorf("data_export: encode %s: %w", section, err)
+			}
+		}
+		entries[backupFiles[section]] = data
+		manifest.Sections = append(manifest.Sections, section)
+		return nil
+	}
+
+	if db := StoresFrom(ctx).ResumeDB; db == nil {
+		skipped = append(skipped, "resume and graph: resume database not configured (set DATABASE_URL)")
+	} else {
+		tables, err := db.DumpResumeTables(ctx)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("data_export: %w", err)
+		}
+		for t, raw := range tables {
+			var rows []json.RawMessage
+			_ = json.Unmarshal(raw, &rows)
+			manifest.Counts[t] = len(rows)
+		}
+		if err := add(BackupResume, tables); err != nil {
+			return nil, nil, nil, err
+		}
+		ops, err := db.DumpGraph(ctx)
+		if err != nil {
+			skipped = append(skipped, "graph: "+err.Error())
+		} else {
+			for _, op := range ops {
+				if op.Edge == "" {
+					manifest.Counts["graph_nodes"]++
+				} else {
+					manifest.Counts["graph_edges"]++
+				}
+			}
+			if err := add(BackupGraph, ops); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	}
+
+	tracker, counts, err := snapshotTrackerDB(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("data_export: %w", err)
+	}
+	for t, n := range counts {
+		manifest.Counts["tracker_"+t] = n
+	}
+	if err := add(BackupTracker, tracker); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := add(BackupProfile, LoadProfile()); err != nil {
+		return nil, nil, nil, err
+	}
+	query, listings := lastSearch()
+	manifest.Counts["last_search_jobs"] = len(listings)
+	if err := add(BackupLastSearch, backupLastSearch{Query: query, Jobs: listings}); err != nil {
+		return nil, nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	write := func(name string, data []byte) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	manifestJSON, _ := json.MarshalIndent(manifest, "", "  ")
+	if err := write("manifest.json", manifestJSON); err != nil {
+		return nil, nil, nil, fmt.Errorf("data_export: zip: %w", err)
+	}
+	for _, s := range manifest.Sections {
+		if err := write(backupFiles[s], entries[backupFiles[s]]); err != nil {
+			return nil, nil, nil, fmt.Errorf("data_export: zip: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, nil, nil, fmt.Errorf("data_export: zip: %w", err)
+	}
+	return buf.Bytes(), manifest, skipped, nil
+}
+
+// DataImport restores an archive written by DataExport. The data it replaces is
+// first backed up (SafetyBackup), so an import can itself be undone.
+func DataImport(ctx context.Context, input DataImportInput) (*DataImportResult, error) {
+	archive, err := readBackupInput(input)
+	if err != nil {
+		return nil, err
+	}
+	manifest, entries, err := openBackup(archive)
+	if err != nil {
+		return nil, err
+	}
+	sections := manifest.Sections
+	if len(input.Sections) > 0 {
+		sections = nil
+		for _, s := range input.Sections {
+			s = strings.ToLower(strings.TrimSpace(s))
+			if !slices.Contains(backupSections, s) {
+				return nil, fmt.Errorf("data_import: unknown section %q (valid: %s)", s, strings.Join(backupSections, ", "))
+			}
+			if !slices.Contains(manifest.Sections, s) {
+				return nil, fmt.Errorf("data_import: the archive has no %s section", s)
+			}
+			if !slices.Contains(sections, s) {
+				sections = append(sections, s)
+			}
+		}
+	}
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil && (slices.Contains(sections, BackupResume) || slices.Contains(sections, BackupGraph)) {
+		return nil, errors.New("data_import: resume database not configured (set DATABASE_URL), restore only tracker, profile and last_search")
+	}
+	result := &DataImportResult{CreatedAt: manifest.CreatedAt, Sections: sections, Counts: manifest.Counts}
+	if input.DryRun {
+		result.Summary = fmt.Sprintf("Archive from %s holds %s. Dry run: nothing restored.", manifest.CreatedAt, strings.Join(sections, ", "))
+		return result, nil
+	}
+
+	safety, err := DataExport(ctx, DataExportInput{})
+	if err != nil {
+		return nil, fmt.Errorf("data_import: safety backup: %w", err)
+	}
+	result.SafetyBackup = safety.Path
+	result.Counts = make(map[string]int)
+
+	for _, s := range sections {
+		data := entries[backupFiles[s]]
+		switch s {
+		case BackupResume:
+			var tables map[string]json.RawMessage
+			if err := json.Unmarshal(data, &tables); err != nil {
+				return result, fmt.Errorf("data_import: resume: %w", err)
+			}
+			counts, err := db.RestoreResumeTables(ctx, tables)
+			if err != nil {
+				return result, fmt.Errorf("data_import: resume: %w", err)
+			}
+			for t, n := range counts {
+				result.Counts[t] = n
+			}
+		case BackupGraph:
+			var ops []graphOp
+			if err := json.Unmarshal(data, &ops); err != nil {
+				return result, fmt.Errorf("data_import: graph: %w", err)
+			}
+			if err := db.ClearGraph(ctx); err != nil {
+				return result, fmt.Errorf("data_import: clear graph: %w", err)
+			}
+			failed, err := db.ApplyGraphOps(ctx, ops)
+			if err != nil {
+				return result, fmt.Errorf("data_import: graph: %w", err)
+			}
+			result.GraphFailed = failed
+			result.Counts["graph_ops"] = len(ops) - failed
+		case BackupTracker:
+			counts, err := restoreTrackerDB(ctx, data)
+			if err != nil {
+				return result, fmt.Errorf("data_import: %w", err)
+			}
+			for t, n := range counts {
+				result.Counts["tracker_"+t] = n
+			}
+		case BackupProfile:
+			var p UserProfile
+			if err := json.Unmarshal(data, &p); err != nil {
+				return result, fmt.Errorf("data_import: profile: %w", err)
+			}
+			if err := SaveProfile(&p); err != nil {
+				return result, fmt.Errorf("data_import: profile: %w", err)
+			}
+		case BackupLastSearch:
+			var ls backupLastSearch
+			if err := json.Unmarshal(data, &ls); err != nil {
+				return result, fmt.Errorf("data_import: last_search: %w", err)
+			}
+			SetLastSearch(ls.Query, ls.Jobs)
+			result.Counts["last_search_jobs"] = len(ls.Jobs)
+		}
+	}
+
+	if slices.Contains(sections, BackupResume) {
+		if StoresFrom(ctx).MemDB == nil {
+			result.Notes = append(result.Notes, "MemDB not configured: run vectors_resync once it is, to rebuild resume vectors")
+		} else if v, err := ResyncVectors(ctx, false); err != nil {
+			result.Notes = append(result.Notes, "vectors_resync failed, run it again: "+err.Error())
+		} else {
+			result.Vectors = v
+		}
+	}
+	if result.GraphFailed > 0 {
+		result.Notes = append(result.Notes, fmt.Sprintf("%d graph op(s) could not be written; master_resume_status repair=true replays the build plan", result.GraphFailed))
+	}
+	result.Summary = fmt.Sprintf("Restored %s from the archive of %s. The replaced data is in %s.",
+		strings.Join(sections, ", "), manifest.CreatedAt, safety.Path)
+	slog.Info("data_import: restored", slog.String("sections", strings.Join(sections, ",")), slog.String("safety_backup", safety.Path))
+	return result, nil
+}
+
+// backupPath resolves a backup archive name to a file in ~/.go_job/backups.
+func backupPath(name string) (string, error) {
+	return goJobFile("backups", name)
+}
+
+// goJobFile resolves a file name to a file in ~/.go_job/<sub>. A path is accepted
+// only when it points into that directory, so tool input cannot read or overwrite
+// other files.
+func goJobFile(sub, name string) (string, error) {
+	dir := filepath.Join(os.Getenv("HOME"), ".go_job", sub)
+	path := filepath.Clean(name)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if filepath.Dir(path) != dir || filepath.Base(path) == ".." {
+		return "", fmt.Errorf("path %q is outside %s; give a file name in that directory", name, dir)
+	}
+	return path, nil
+}
+
+func readBackupInput(input DataImportInput) ([]byte, error) {
+	switch {
+	case input.Data != "":
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(input.Data))
+		if err != nil {
+			return nil, fmt.Errorf("data_import: data is not base64: %w", err)
+		}
+		return data, nil
+	case input.Path != "":
+		path, err := backupPath(input.Path)
+		if err != nil {
+			return nil, fmt.Errorf("data_import: %w", err)
+		}
+		data, err := os.ReadFile(path) //nolint:gosec // confined to the backups directory
+		if err != nil {
+			return nil, fmt.Errorf("data_import: %w", err)
+		}
+		return data, nil
+	}
+	return nil, errors.New("data_import: path or data is required")
+}
+
+// openBackup reads the manifest and section entries of an archive.
+func openBackup(archive []byte) (*backupManifest, map[string][]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("data_import: not a data_export archive: %w", err)
+	}
+	entries := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, nil, fmt.Errorf("data_import: %s: %w", f.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("data_import: %s: %w", f.Name, err)
+		}
+		entries[f.Name] = data
+	}
+	var manifest backupManifest
+	if err := json.Unmarshal(entries["manifest.json"], &manifest); err != nil {
+		return nil, nil, errors.New("data_import: not a data_export archive: missing manifest.json")
+	}
+	if manifest.Version > backupFormatVersion {
+		return nil, nil, fmt.Errorf("data_import: archive format %d is newer than this server supports (%d)", manifest.Version, backupFormatVersion)
+	}
+	for _, s := range manifest.Sections {
+		if _, ok := entries[backupFiles[s]]; !ok {
+			return nil, nil, fmt.Errorf("data_import: archive is missing %s", backupFiles[s])
+		}
+	}
+	return &manifest, entries, nil
+}
+
+// snapshotTrackerDB copies the tracker database with VACUUM INTO and returns the
+// copy with the row count of each backed-up table.
+func snapshotTrackerDB(ctx context.Context) ([]byte, map[string]int, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, nil, err
+	}
+	tmp := filepath.Join(os.TempDir(), fmt.Sprintf("go_job-tracker-%d.db", time.Now().UnixNano()))
+	defer os.Remove(tmp)
+	if _, err := db.ExecContext(ctx, `VACUUM INTO ?`, tmp); err != nil {
+		return nil, nil, fmt.Errorf("tracker snapshot: %w", err)
+	}
+	data, err := os.ReadFile(tmp) //nolint:gosec // temp file written above
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracker snapshot: %w", err)
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracker snapshot: %w", err)
+	}
+	defer conn.Close()
+	tables, err := trackerBackupTables(ctx, conn, "main")
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracker snapshot: %w", err)
+	}
+	counts := make(map[string]int, len(tables))
+	for t := range tables {
+		var n int
+		if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM main."`+t+`"`).Scan(&n); err == nil {
+			counts[t] = n
+		}
+	}
+	return data, counts, nil
+}
+
+// restoreTrackerDB replaces the user tables of the tracker with those of a snapshot,
+// in one transaction over the attached snapshot. Tables the snapshot lacks are
+// emptied; columns it lacks take their defaults. Returns the rows restored per table.
+func restoreTrackerDB(ctx context.Context, snapshot []byte) (map[string]int, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	tmp := filepath.Join(os.TempDir(), fmt.Sprintf("go_job-restore-%d.db", time.Now().UnixNano()))
+	if err := os.WriteFile(tmp, snapshot, 0o600); err != nil {
+		return nil, fmt.Errorf("tracker restore: %w", err)
+	}
+	defer os.Remove(tmp)
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tracker restore: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, `ATTACH DATABASE ? AS backup`, tmp); err != nil {
+		return nil, fmt.Errorf("tracker restore: attach: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), `DETACH DATABASE backup`); err != nil {
+			slog.Warn("tracker restore: detach failed", slog.Any("error", err))
+		}
+	}()
+
+	mainTables, err := trackerBackupTables(ctx, conn, "main")
+	if err != nil {
+		return nil, fmt.Errorf("tracker restore: %w", err)
+	}
+	backupTables, err := trackerBackupTables(ctx, conn, "backup")
+	if err != nil {
+		return nil, fmt.Errorf("tracker restore: snapshot is not a tracker database: %w", err)
+	}
+	// Regular tables first; virtual tables (the local_search index) last, replacing
+	// what the jobs triggers inserted on the way.
+	names := make([]string, 0, len(mainTables))
+	for t := range mainTables {
+		names = append(names, t)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if mainTables[names[i]] != mainTables[names[j]] {
+			return !mainTables[names[i]]
+		}
+		return names[i] < names[j]
+	})
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("tracker restore: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+		return nil, fmt.Errorf("tracker restore: %w", err)
+	}
+	counts := make(map[string]int)
+	for _, t := range names {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM main."`+t+`"`); err != nil {
+			return nil, fmt.Errorf("tracker restore: clear %s: %w", t, err)
+		}
+		if _, ok := backupTables[t]; !ok {
+			continue
+		}
+		cols, err := sharedTrackerColumns(ctx, tx, t)
+		if err != nil {
+			return nil, fmt.Errorf("tracker restore: %s: %w", t, err)
+		}
+		if len(cols) == 0 {
+			continue
+		}
+		list := `"` + strings.Join(cols, `", "`) + `"`
+		res, err := tx.ExecContext(ctx, `INSERT INTO main."`+t+`" (`+list+`) SELECT `+list+` FROM backup."`+t+`"`)
+		if err != nil {
+			return nil, fmt.Errorf("tracker restore: %s: %w", t, err)
+		}
+		n, _ := res.RowsAffected()
+		counts[t] = int(n)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tracker restore: commit: %w", err)
+	}
+	return counts, nil
+}
+
+// trackerBackupTables lists the user tables of a tracker schema ("main" or an
+// attached one): name → whether it is a virtual table. SQLite's own tables, the
+// shadow tables of virtual tables and trackerBackupSkip are left out.
+func trackerBackupTables(ctx context.Context, conn *sql.Conn, schema string) (map[string]bool, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT name, COALESCE(sql, '') FROM `+schema+`.sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tables := make(map[string]bool)
+	for rows.Next() {
+		var name, ddl string
+		if err := rows.Scan(&name, &ddl); err != nil {
+			return nil, err
+		}
+		if !trackerBackupSkip[name] {
+			tables[name] = strings.HasPrefix(strings.ToUpper(ddl), "CREATE VIRTUAL TABLE")
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for name, virtual := range tables {
+		if !virtual {
+			continue
+		}
+		for shadow := range tables {
+			if strings.HasPrefix(shadow, name+"_") {
+				delete(tables, shadow)
+			}
+		}
+	}
+	if _, ok := tables["jobs"]; !ok {
+		return nil, errors.New("no jobs table")
+	}
+	return tables, nil
+}
+
+// sharedTrackerColumns returns the columns of table t present in both main and backup.
+func sharedTrackerColumns(ctx context.Context, tx *sql.Tx, t string) ([]string, error) {
+	columns := func(schema string) ([]string, error) {
+		rows, err := tx.QueryContext(ctx, `SELECT name FROM pragma_table_info(?, ?)`, t, schema)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		var cols []string
+		for rows.Next() {
+			var c string
+			if err := rows.Scan(&c); err != nil {
+				return nil, err
+			}
+			cols = append(cols, c)
+		}
+		return cols, rows.Err()
+	}
+	mainCols, err := columns("main")
+	if err != nil {
+		return nil, err
+	}
+	backupCols, err := columns("backup")
+	if err != nil {
+		return nil, err
+	}
+	var shared []string
+	for _, c := range mainCols {
+		if slices.Contains(backupCols, c) {
+			shared = append(shared, c)
+		}
+	}
+	return shared, nil
+}
@@ -0,0 +1,210 @@
+package jobs
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Equity and benefits: the summarizer extracts equity, benefits, pto_policy and
+// 401k_match from each posting; AnnotateBenefits fills what it missed from the
+// description and normalizes benefits to the canonical names below, so job_search can
+// filter on them (require_benefits) and sort by them (sort_by=benefits).
+
+// Benefit names that are not entries of JobListing.Benefits but have their own field.
+const (
+	BenefitEquity       = "equity"
+	Benefit401kMatch    = "401k_match"
+	BenefitUnlimitedPTO = "unlimited_pto"
+)
+
+// benefitPatterns recognizes canonical benefits in posting text.
+var benefitPatterns = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"health", regexp.MustCompile(`(?i)\b(health|medical) (insurance|care|coverage|benefits|plan)\b|\bhealthcare\b`)},
+	{"dental", regexp.MustCompile(`(?i)\bdental\b`)},
+	{"vision", regexp.MustCompile(`(?i)\bvision (insurance|coverage|care|plan)\b`)},
+	{"401k", regexp.MustCompile(`(?i)\b401\(?k\)?`)},
+	{"pension", regexp.MustCompile(`(?i)\bpension\b|\bretirement (plan|savings)\b`)},
+	{"parental_leave", regexp.MustCompile(`(?i)\b(parental|maternity|paternity|family) leave\b`)},
+	{"remote_stipend", regexp.MustCompile(`(?i)\b(home office|remote work|wfh|co-?working) (stipend|budget|allowance)\b`)},
+	{"learning_budget", regexp.MustCompile(`(?i)\b(learning|education|training|conference|professional development) (budget|stipend|allowance)\b`)},
+	{"wellness", regexp.MustCompile(`(?i)\b(gym (membership|stipend)|wellness (stipend|budget|program|allowance)|fitness (stipend|allowance))\b`)},
+	{"relocation", regexp.MustCompile(`(?i)\brelocation (assistance|package|support|bonus|help)\b`)},
+	{"bonus", regexp.MustCompile(`(?i)\b(annual|performance|signing|sign-on|yearly) bonus\b`)},
+	{"flexible_hours", regexp.MustCompile(`(?i)\bflexible (hours|schedule|working hours)\b`)},
+	{"four_day_week", regexp.MustCompile(`(?i)\b(4|four)[- ]day (work ?)?week\b`)},
+}
+
+var (
+	equityRangeRe  = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?%\s*(?:-|–|to)\s*\d+(?:\.\d+)?%)\s*(?:equity|stock|options)`)
+	unlimitedPTORe = regexp.MustCompile(`(?i)\bunlimited (pto|vacation|paid time off|time off|holidays?|leave)\b`)
+	ptoDaysRe      = regexp.MustCompile(`(?i)\b(\d{1,2})\+? (?:days|working days) (?:of )?(?:paid )?(?:pto|vacation|holidays?|annual leave|paid time off)\b`)
+	match401kRe    = regexp.MustCompile(`(?i)(\d{1,2}(?:\.\d+)?%)\s+401\(?k\)?\s+match|401\(?k\)?[^.\n]{0,30}?\bmatch(?:ing|ed)?\b[^\n%]{0,20}?(\d{1,2}(?:\.\d+)?%)`)
+	match401kAnyRe = regexp.MustCompile(`(?i)401\(?k\)?[^.\n]{0,20}\bmatch|\bmatch(?:ing)? 401\(?k\)?`)
+	// "Diversity, equity and inclusion" and "pay equity" are not equity compensation.
+	nonCompEquityRe = regexp.MustCompile(`(?i)\bdiversity,? equity\b|\bequity,? (?:and|&) inclusion\b|\b(?:pay|health|racial|gender) equity\b`)
+)
+
+// KnownBenefits lists the names require_benefits accepts.
+func KnownBenefits() []string {
+	names := []string{BenefitEquity, Benefit401kMatch, BenefitUnlimitedPTO}
+	for _, p := range benefitPatterns {
+		names = append(names, p.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseRequiredBenefits splits a comma-separated require_benefits input into canonical
+// names, rejecting unknown ones.
+func ParseRequiredBenefits(s string) ([]string, error) {
+	known := KnownBenefits()
+	var names []string
+	for _, part := range strings.Split(s, ",") {
+		name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(part)), " ", "_")
+		switch name {
+		case "":
+			continue
+		case "401(k)", "401k_matching", "401(k)_match":
+			name = Benefit401kMatch
+		case "stock_options", "options", "rsu", "rsus":
+			name = BenefitEquity
+		}
+		if !slices.Contains(known, name) {
+			return nil, fmt.Errorf("unknown benefit %q in require_benefits (valid: %s)", part, strings.Join(known, ", "))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// canonicalBenefits maps benefit phrases to canonical names. Phrases matching no
+// pattern are kept lowercased.
+func canonicalBenefits(phrases []string, text string) []string {
+	var out []string
+	add := func(name string) {
+		if name != "" && !slices.Contains(out, name) {
+			out = append(out, name)
+		}
+	}
+	for _, ph := range phrases {
+		matched := false
+		for _, p := range benefitPatterns {
+			if p.re.MatchString(ph) {
+				add(p.name)
+				matched = true
+			}
+		}
+		if !matched && !equityRe.MatchString(ph) && !unlimitedPTORe.MatchString(ph) {
+			add(strings.ToLower(strings.TrimSpace(ph)))
+		}
+	}
+	for _, p := range benefitPatterns {
+		if p.re.MatchString(text) {
+			add(p.name)
+		}
+	}
+	return out
+}
+
+// AnnotateBenefits normalizes each listing's benefits and fills equity, pto_policy and
+// 401k_match from its text where the summarizer left them empty.
+func AnnotateBenefits(listings []engine.JobListing) {
+	for i := range listings {
+		j := &listings[i]
+		text := j.Title + "\n" + j.Salary + "\n" + j.Description
+		if j.Equity == "" {
+			text := nonCompEquityRe.ReplaceAllString(text, "")
+			if m := equityRangeRe.FindStringSubmatch(text); m != nil {
+				j.Equity = m[1] + " equity"
+			} else if m := equityRe.FindString(text); m != "" {
+				j.Equity = strings.ToLower(m)
+			}
+		}
+		if j.PTOPolicy == "" {
+			if unlimitedPTORe.MatchString(text) {
+				j.PTOPolicy = "unlimited"
+			} else if m := ptoDaysRe.FindStringSubmatch(text); m != nil {
+				j.PTOPolicy = m[1] + " days"
+			}
+		}
+		if j.Match401k == "" {
+			if m := match401kRe.FindStringSubmatch(text); m != nil {
+				j.Match401k = m[1] + m[2]
+			} else if match401kAnyRe.MatchString(text) {
+				j.Match401k = "yes"
+			}
+		}
+		j.Benefits = canonicalBenefits(j.Benefits, text)
+	}
+}
+
+// hasBenefit reports whether j offers the named benefit.
+func hasBenefit(j engine.JobListing, name string) bool {
+	switch name {
+	case BenefitEquity:
+		return j.Equity != ""
+	case Benefit401kMatch:
+		return j.Match401k != ""
+	case BenefitUnlimitedPTO:
+		return strings.Contains(strings.ToLower(j.PTOPolicy), "unlimited")
+	}
+	return slices.Contains(j.Benefits, name)
+}
+
+// FilterByBenefits keeps listings offering every one of required, returning how many
+// were dropped.
+func FilterByBenefits(listings []engine.JobListing, required []string) ([]engine.JobListing, int) {
+	if len(required) == 0 {
+		return listings, 0
+	}
+	out := listings[:0:0]
+	for _, j := range listings {
+		ok := true
+		for _, name := range required {
+			if !hasBenefit(j, name) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			out = append(out, j)
+		}
+	}
+	return out, len(listings) - len(out)
+}
+
+// BenefitsDroppedNote is the summary suffix for FilterByBenefits.
+func BenefitsDroppedNote(dropped int, required []string) string {
+	if dropped == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" Hid %d listing(s) not mentioning %s.", dropped, strings.Join(required, ", "))
+}
+
+// benefitCount is how many perks a listing states: its benefits plus equity, a PTO
+// policy and a 401k match.
+func benefitCount(j engine.JobListing) int {
+	n := len(j.Benefits)
+	for _, set := range []bool{j.Equity != "", j.PTOPolicy != "", j.Match401k != ""} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// SortByBenefits orders listings by how many perks they state, most first; ties keep
+// their order.
+func SortByBenefits(listings []engine.JobListing) {
+	sort.SliceStable(listings, func(a, b int) bool {
+		return benefitCount(listings[a]) > benefitCount(listings[b])
+	})
+}
@@ -0,0 +1,54 @@
+package jobs
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestAnnotateBenefits(t *testing.T) {
+	listings := []engine.JobListing{
+		{Title: "Backend Engineer", Description: "0.1% - 0.5% equity, unlimited PTO, 4% 401(k) match, dental and vision insurance.",
+			Benefits: []string{"Health insurance", "Free lunch"}},
+		{Title: "Analyst", Description: "We value diversity, equity and inclusion. 25 days of paid vacation. Annual bonus."},
+		{Title: "SRE", Description: "Go and Kubernetes."},
+	}
+	AnnotateBenefits(listings)
+
+	a := listings[0]
+	if a.Equity != "0.1% - 0.5% equity" || a.PTOPolicy != "unlimited" || a.Match401k != "4%" {
+		t.Errorf("first listing: equity %q, pto %q, 401k %q", a.Equity, a.PTOPolicy, a.Match401k)
+	}
+	if want := []string{"health", "free lunch", "dental", "vision", "401k"}; !slices.Equal(a.Benefits, want) {
+		t.Errorf("benefits = %q, want %q", a.Benefits, want)
+	}
+	b := listings[1]
+	if b.Equity != "" || b.PTOPolicy != "25 days" || !slices.Equal(b.Benefits, []string{"bonus"}) {
+		t.Errorf("second listing: equity %q, pto %q, benefits %q", b.Equity, b.PTOPolicy, b.Benefits)
+	}
+
+	// Annotating again changes nothing.
+	again := slices.Clone(a.Benefits)
+	AnnotateBenefits(listings)
+	if !slices.Equal(listings[0].Benefits, again) {
+		t.Errorf("not idempotent: %q -> %q", again, listings[0].Benefits)
+	}
+
+	required, err := ParseRequiredBenefits("Equity, 401(k)")
+	if err != nil || !slices.Equal(required, []string{BenefitEquity, Benefit401kMatch}) {
+		t.Fatalf("ParseRequiredBenefits = %q, %v", required, err)
+	}
+	kept, dropped := FilterByBenefits(listings, required)
+	if dropped != 2 || len(kept) != 1 || kept[0].Title != "Backend Engineer" {
+		t.Errorf("kept %d, dropped %d", len(kept), dropped)
+	}
+	if _, err := ParseRequiredBenefits("yacht"); err == nil {
+		t.Error("expected an error for an unknown benefit")
+	}
+
+	SortByBenefits(listings)
+	if listings[0].Title != "Backend Engineer" || listings[2].Title != "SRE" {
+		t.Errorf("sort order: %s, %s, %s", listings[0].Title, listings[1].Title, listings[2].Title)
+	}
+}
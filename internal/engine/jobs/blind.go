@@ -0,0 +1,219 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Blind (teamblind.com) ---
+
+// Anonymous employee posts from Blind's public post pages, found via SearXNG. They are
+// an extra evidence stream for company_research (culture sentiment) and salary_research
+// (comp datapoints), always labeled anecdotal: posts are unverified and self-selected.
+
+const (
+	blindPostsMax = 10
+	blindNote     = "Anecdotal: anonymous, unverified employee posts from Blind (teamblind.com); self-selected and not a representative sample."
+)
+
+// BlindComp is a compensation datapoint stated in a Blind post (annual USD).
+type BlindComp struct {
+	TotalComp int    `json:"total_comp,omitempty"`
+	Base      int    `json:"base,omitempty"`
+	Level     string `json:"level,omitempty"`
+	URL       string `json:"url"`
+}
+
+// BlindPost is one Blind post.
+type BlindPost struct {
+	Title     string     `json:"title"`
+	URL       string     `json:"url"`
+	Snippet   string     `json:"snippet"`
+	Sentiment string     `json:"sentiment"` // "positive", "negative", "neutral"
+	Comp      *BlindComp `json:"comp,omitempty"`
+}
+
+// BlindSignals summarizes the Blind posts about a company or role.
+type BlindSignals struct {
+	Anecdotal      bool        `json:"anecdotal"` // always true; see Note
+	Note           string      `json:"note"`
+	Sentiment      string      `json:"sentiment"` // "positive", "negative", "mixed", "neutral"
+	Positive       int         `json:"positive"`
+	Negative       int         `json:"negative"`
+	CompDatapoints []BlindComp `json:"comp_datapoints,omitempty"`
+	MedianTC       int         `json:"median_total_comp,omitempty"`
+	Posts          []BlindPost `json:"posts"`
+}
+
+var (
+	blindPositiveRe = regexp.MustCompile(`(?i)\b(great (wlb|work[- ]life balance|culture|team|manager)|good (wlb|culture|comp)|love (it|working)|supportive|chill|recommend(ed)?|happy (here|with)|best (job|company)|well[- ]paid|generous)\b`)
+	blindNegativeRe = regexp.MustCompile(`(?i)\b(toxic|burn(ed|t)? ?out|layoffs?|laid off|pip(ped)?|micromanag\w*|avoid|terrible|awful|stressful|politics|stack rank\w*|underpaid|low ?ball\w*|overworked|bad (wlb|culture|management)|run away|sinking ship)\b`)
+	blindTCRe       = regexp.MustCompile(`(?i)\b(?:tc|total comp(?:ensation)?)\s*(?:is|of|[:=~])?\s*\$?\s*(\d{2,4}(?:[.,]\d+)?)\s*(k|m)?\b`)
+	blindBaseRe     = regexp.MustCompile(`(?i)\bbase\s*(?:is|of|[:=~])?\s*\$?\s*(\d{2,4}(?:[.,]\d+)?)\s*(k|m)?\b`)
+	blindLevelRe    = regexp.MustCompile(`\b(L[3-9]|E[3-9]|IC[1-7]|SDE ?(?:I{1,3}|[1-3])|Senior|Staff|Principal)\b`)
+)
+
+// SearchBlind searches Blind's public posts for query, cached per query.
+func SearchBlind(ctx context.Context, query string) ([]BlindPost, error) {
+	cacheKey := engine.CacheKey("blind", strings.ToLower(query))
+	if cached, ok := engine.CacheLoadJSON[[]BlindPost](ctx, cacheKey); ok {
+		return cached, nil
+	}
+	results, err := engine.SearchSearXNG(ctx, query+" site:teamblind.com", "all", "", engine.DefaultSearchEngine)
+	if err != nil {
+		return nil, fmt.Errorf("blind: %w", err)
+	}
+	posts := parseBlindResults(results)
+	engine.CacheStoreJSON(ctx, cacheKey, query, posts)
+	return posts, nil
+}
+
+// parseBlindResults turns search results on teamblind.com into posts, classifying
+// their sentiment and reading any comp datapoint.
+func parseBlindResults(results []engine.SearxngResult) []BlindPost {
+	posts := []BlindPost{}
+	seen := make(map[string]bool)
+	for _, r := range results {
+		if !strings.Contains(r.URL, "teamblind.com") || seen[r.URL] {
+			continue
+		}
+		seen[r.URL] = true
+		text := r.Title + "\n" + r.Content
+		p := BlindPost{
+			Title:     strings.TrimSpace(r.Title),
+			URL:       r.URL,
+			Snippet:   engine.TruncateRunes(strings.TrimSpace(r.Content), 300, "..."),
+			Sentiment: blindSentiment(text),
+		}
+		if c := parseBlindComp(text); c != nil {
+			c.URL = r.URL
+			p.Comp = c
+		}
+		posts = append(posts, p)
+		if len(posts) == blindPostsMax {
+			break
+		}
+	}
+	return posts
+}
+
+func blindSentiment(text string) string {
+	pos := len(blindPositiveRe.FindAllString(text, -1))
+	neg := len(blindNegativeRe.FindAllString(text, -1))
+	switch {
+	case neg > pos:
+		return SentimentNegative
+	case pos > neg:
+		return SentimentPositive
+	default:
+		return SentimentNeutral
+	}
+}
+
+// parseBlindComp reads "TC: 250k", "base 180k" and a level from a post, or nil when it
+// states no plausible annual figure.
+func parseBlindComp(text string) *BlindComp {
+	var c BlindComp
+	if m := blindTCRe.FindStringSubmatch(text); m != nil {
+		c.TotalComp = blindAmount(m[1], m[2])
+	}
+	if m := blindBaseRe.FindStringSubmatch(text); m != nil {
+		c.Base = blindAmount(m[1], m[2])
+	}
+	if c.TotalComp == 0 && c.Base == 0 {
+		return nil
+	}
+	if m := blindLevelRe.FindStringSubmatch(text); m != nil {
+		c.Level = m[1]
+	}
+	return &c
+}
+
+// blindAmount converts "250" + "k" to 250000. Bare numbers under 1000 are read as
+// thousands, as Blind posters write them; results outside 20k-5M are dropped.
+func blindAmount(num, unit string) int {
+	v, err := strconv.ParseFloat(strings.ReplaceAll(num, ",", "."), 64)
+	if err != nil {
+		return 0
+	}
+	switch strings.ToLower(unit) {
+	case "m":
+		v *= 1_000_000
+	default:
+		if v < 1000 {
+			v *= 1000
+		}
+	}
+	if v < 20_000 || v > 5_000_000 {
+		return 0
+	}
+	return int(math.Round(v))
+}
+
+// summarizeBlind aggregates posts into signals.
+func summarizeBlind(posts []BlindPost) *BlindSignals {
+	if len(posts) == 0 {
+		return nil
+	}
+	s := &BlindSignals{Anecdotal: true, Note: blindNote, Posts: posts}
+	var tcs []int
+	for _, p := range posts {
+		switch p.Sentiment {
+		case SentimentPositive:
+			s.Positive++
+		case SentimentNegative:
+			s.Negative++
+		}
+		if p.Comp != nil {
+			s.CompDatapoints = append(s.CompDatapoints, *p.Comp)
+			if p.Comp.TotalComp > 0 {
+				tcs = append(tcs, p.Comp.TotalComp)
+			}
+		}
+	}
+	switch {
+	case s.Positive == 0 && s.Negative == 0:
+		s.Sentiment = SentimentNeutral
+	case s.Negative == 0:
+		s.Sentiment = SentimentPositive
+	case s.Positive == 0:
+		s.Sentiment = SentimentNegative
+	default:
+		s.Sentiment = "mixed"
+	}
+	if len(tcs) > 0 {
+		sort.Ints(tcs)
+		s.MedianTC = tcs[len(tcs)/2]
+	}
+	return s
+}
+
+// BlindCompanySignals returns employee sentiment and comp datapoints from Blind posts
+// about a company, or nil when none were found.
+func BlindCompanySignals(ctx context.Context, company string) *BlindSignals {
+	posts, err := SearchBlind(ctx, company+" work life balance culture OR TC")
+	if err != nil {
+		slog.Debug("company_research: blind search failed", slog.Any("error", err))
+		return nil
+	}
+	return summarizeBlind(posts)
+}
+
+// BlindCompSignals returns comp datapoints from Blind posts about a role, or nil when
+// none were found.
+func BlindCompSignals(ctx context.Context, role, location string) *BlindSignals {
+	posts, err := SearchBlind(ctx, strings.TrimSpace(role+" "+location)+" TC offer")
+	if err != nil {
+		slog.Debug("salary_research: blind search failed", slog.Any("error", err))
+		return nil
+	}
+	return summarizeBlind(posts)
+}
@@ -0,0 +1,96 @@
+package jobs
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JobBookmarksInput is the input for job_bookmarks.
+type JobBookmarksInput struct {
+	Source string `json:"source,omitempty" jsonschema:"What to export: last_search (default, the most recent job_search results) or tracker"`
+	Status string `json:"status,omitempty" jsonschema:"Tracker only: export just this status (saved, applied, interview, offer, rejected)"`
+}
+
+// JobBookmarksResult is the output of job_bookmarks.
+type JobBookmarksResult struct {
+	Jobs      int    `json:"jobs"`
+	Companies int    `json:"companies"`
+	Path      string `json:"path"` // file written under ~/.go_job/exports
+	HTML      string `json:"html"`
+}
+
+// ExportJobBookmarks renders the last search (or tracked jobs) as a Netscape bookmarks
+// file that Chrome, Firefox and Safari import, one folder per company, and writes it
+// under ~/.go_job/exports.
+func ExportJobBookmarks(input JobBookmarksInput) (*JobBookmarksResult, error) {
+	result, fileName, err := RenderJobBookmarks(input)
+	if err != nil {
+		return nil, err
+	}
+	if result.Path, err = writeExportFile(fileName, []byte(result.HTML)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RenderJobBookmarks renders the bookmarks page like ExportJobBookmarks without writing
+// it (Path is empty), returning the file name to offer it under.
+func RenderJobBookmarks(input JobBookmarksInput) (result *JobBookmarksResult, fileName string, err error) {
+	source := input.Source
+	if source == "" {
+		source = "last_search"
+	}
+	rows, name, err := exportRows(JobExportInput{Source: source, Status: input.Status})
+	if err != nil {
+		return nil, "", err
+	}
+	page, jobs, companies := renderBookmarks("go_job: "+strings.ReplaceAll(name, "_", " "), rows, time.Now())
+	if jobs == 0 {
+		return nil, "", fmt.Errorf("job_bookmarks: no jobs with URLs in %s", name)
+	}
+	return &JobBookmarksResult{Jobs: jobs, Companies: companies, HTML: string(page)}, name + ".html", nil
+}
+
+// renderBookmarks writes rows with a URL into one folder per company. Companies keep
+// the order of their first (best-ranked) job. Returns the page and the job and company counts.
+func renderBookmarks(folder string, rows []exportRow, now time.Time) (page []byte, jobs, companies int) {
+	var order []string
+	byCompany := make(map[string][]exportRow)
+	for _, r := range rows {
+		if r[5] == "" {
+			continue
+		}
+		company := r[1]
+		if company == "" {
+			company = "Other"
+		}
+		if _, ok := byCompany[company]; !ok {
+			order = append(order, company)
+		}
+		byCompany[company] = append(byCompany[company], r)
+		jobs++
+	}
+
+	ts := strconv.FormatInt(now.Unix(), 10)
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE NETSCAPE-Bookmark-file-1>\n")
+	b.WriteString(`<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">` + "\n")
+	b.WriteString("<TITLE>Bookmarks</TITLE>\n<H1>Bookmarks</H1>\n<DL><p>\n")
+	fmt.Fprintf(&b, "    <DT><H3 ADD_DATE=%q>%s</H3>\n    <DL><p>\n", ts, html.EscapeString(folder))
+	for _, company := range order {
+		fmt.Fprintf(&b, "        <DT><H3 ADD_DATE=%q>%s</H3>\n        <DL><p>\n", ts, html.EscapeString(company))
+		for _, r := range byCompany[company] {
+			title := r[0]
+			if r[3] != "" {
+				title += " — " + r[3]
+			}
+			fmt.Fprintf(&b, "            <DT><A HREF=\"%s\" ADD_DATE=%q>%s</A>\n", html.EscapeString(r[5]), ts, html.EscapeString(title))
+		}
+		b.WriteString("        </DL><p>\n")
+	}
+	b.WriteString("    </DL><p>\n</DL><p>\n")
+	return []byte(b.String()), jobs, len(order)
+}
@@ -56,7 +56,7 @@ func SearchBoss(ctx context.Context, limit int) ([]engine.BountyListing, error)
 }
 
 func fetchBoss(ctx context.Context) ([]engine.BountyListing, error) {
-	fetchCtx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, bossAPIURL, nil)
@@ -65,7 +65,7 @@ func fetchBoss(ctx context.Context) ([]engine.BountyListing, error) {
 	}
 	req.Header.Set("User-Agent", engine.UserAgentChrome)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("boss request failed: %w", err)
 	}
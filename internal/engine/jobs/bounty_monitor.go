@@ -15,12 +15,12 @@ const bountySeenIDsKey = "bounty_seen_ids"
 // StartBountyMonitor launches a background goroutine that polls for new bounties
 // and sends Telegram notifications via vaelor.
 func StartBountyMonitor(ctx context.Context) {
-	interval := engine.Cfg.BountyMonitorInterval
+	interval := engine.ConfigFrom(ctx).BountyMonitorInterval
 	if interval <= 0 {
 		interval = 15 * time.Minute
 	}
 
-	if engine.Cfg.VaelorNotifyURL == "" {
+	if engine.ConfigFrom(ctx).VaelorNotifyURL == "" {
 		slog.Info("bounty_monitor: disabled (VAELOR_NOTIFY_URL not set)")
 		return
 	}
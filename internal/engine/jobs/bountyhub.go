@@ -65,7 +65,7 @@ func SearchBountyHub(ctx context.Context, limit int) ([]engine.BountyListing, er
 }
 
 func fetchBountyHub(ctx context.Context) ([]engine.BountyListing, error) {
-	fetchCtx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	url := bountyHubAPIURL + `?page=1&limit=50&filters={"solved":false}&sort=[{"orderBy":"totalAmount","order":"desc"}]`
@@ -76,7 +76,7 @@ func fetchBountyHub(ctx context.Context) ([]engine.BountyListing, error) {
 	}
 	req.Header.Set("User-Agent", engine.UserAgentChrome)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("bountyhub request failed: %w", err)
 	}
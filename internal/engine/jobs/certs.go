@@ -0,0 +1,275 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Certification / course recommendations ---
+
+// CertRecommendResult is the structured output of cert_recommend.
+type CertRecommendResult struct {
+	Skills  []CertSkill `json:"skills"`
+	Summary string      `json:"summary"`
+}
+
+// CertSkill is one gap skill with the certifications and courses that address it.
+type CertSkill struct {
+	Skill      string       `json:"skill"`
+	Priority   string       `json:"priority,omitempty"`    // from skill_gap: critical, high, medium
+	TargetJobs []string     `json:"target_jobs,omitempty"` // "Title at Company" of target jobs listing the skill
+	Options    []CertOption `json:"options"`
+}
+
+// CertOption is a single certification or course.
+type CertOption struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	Type     string `json:"type"` // vendor_cert, mooc, university, professional_cert
+	URL      string `json:"url,omitempty"`
+	Cost     string `json:"cost"`
+	Duration string `json:"duration"`
+	Level    string `json:"level,omitempty"`
+	Why      string `json:"why"`
+}
+
+const certRecommendPrompt = `You are a career advisor recommending certifications and courses to close skill gaps.
+
+SKILLS AND SEARCH RESULTS:
+%s
+
+For each skill, recommend 2-4 reputable options found in its search results:
+- vendor certifications (AWS, Google Cloud, Microsoft, CNCF, HashiCorp, Oracle, Cisco, ...)
+- university courses and MOOCs (Coursera, edX, Udacity, MIT OpenCourseWare, Stanford Online, ...)
+- recognised professional certificates (PMI, ISC2, CompTIA, Linux Foundation, ...)
+Skip unknown vendors, content farms and "certificate mills".
+
+Return a JSON object with this exact structure:
+{
+  "skills": [
+    {
+      "skill": "<skill name, as given>",
+      "options": [
+        {
+          "name": "<certification or course name>",
+          "provider": "<issuer or platform>",
+          "type": "<vendor_cert|mooc|university|professional_cert>",
+          "url": "<URL from the search results, or empty>",
+          "cost": "<price, e.g. '$150 exam', 'free to audit, $49/month certificate', or 'unknown'>",
+          "duration": "<typical time to complete, e.g. '4-6 weeks at 5h/week', or 'unknown'>",
+          "level": "<beginner|intermediate|advanced>",
+          "why": "<one sentence: why it is worth it for this skill>"
+        }
+      ]
+    }
+  ]
+}
+
+Only use URLs that appear in the search results. Say "unknown" instead of guessing cost or duration.
+Return ONLY the JSON object, no markdown, no explanation.`
+
+// certPriorityRank orders skill_gap priorities, most urgent first.
+var certPriorityRank = map[string]int{"critical": 0, "high": 1, "medium": 2}
+
+// RecommendCertifications finds certifications and courses for the top missing skills
+// via SearXNG + LLM synthesis. Skills come from the input or, when empty, from
+// skill_gap on the resume (default: master resume) and the job description.
+func RecommendCertifications(ctx context.Context, input engine.CertRecommendInput) (*CertRecommendResult, error) {
+	limit := input.MaxSkills
+	if limit <= 0 {
+		limit = 3
+	}
+	if limit > 5 {
+		limit = 5
+	}
+
+	gaps, err := certGapSkills(ctx, input, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(gaps) == 0 {
+		return &CertRecommendResult{Skills: []CertSkill{}, Summary: "No skill gaps found — nothing to recommend."}, nil
+	}
+
+	type searchRes struct {
+		skill   int
+		results []engine.SearxngResult
+		err     error
+	}
+	type certQuery struct {
+		skill int
+		query string
+	}
+	var queries []certQuery
+	for i, g := range gaps {
+		queries = append(queries,
+			certQuery{i, g.Skill + " certification exam cost official"},
+			certQuery{i, g.Skill + " course site:coursera.org OR site:edx.org OR site:udacity.com OR site:ocw.mit.edu"},
+		)
+	}
+	ch := make(chan searchRes, len(queries))
+	for _, q := range queries {
+		engine.GoOutbound(ctx, func() {
+			r, err := engine.SearchSearXNG(ctx, q.query, "all", "", engine.DefaultSearchEngine)
+			ch <- searchRes{q.skill, r, err}
+		})
+	}
+
+	snippets := make([][]string, len(gaps))
+	seenURLs := make(map[string]bool)
+	for range queries {
+		res := <-ch
+		if res.err != nil {
+			continue
+		}
+		for _, r := range res.results {
+			if r.Content == "" || seenURLs[r.URL] {
+				continue
+			}
+			seenURLs[r.URL] = true
+			snippets[res.skill] = append(snippets[res.skill], fmt.Sprintf("**%s**\n%s\n%s", r.Title, r.URL, engine.TruncateRunes(r.Content, 300, "...")))
+		}
+	}
+	if len(seenURLs) == 0 {
+		return nil, errors.New("cert_recommend: no search results found")
+	}
+
+	var b strings.Builder
+	perSkill := 6000 / len(gaps)
+	for i, g := range gaps {
+		fmt.Fprintf(&b, "=== SKILL: %s ===\n", g.Skill)
+		b.WriteString(engine.TruncateRunes(strings.Join(snippets[i], "\n\n"), perSkill, ""))
+		b.WriteString("\n\n")
+	}
+
+	raw, err := engine.CallLLM(ctx, fmt.Sprintf(certRecommendPrompt, b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("cert_recommend LLM: %w", err)
+	}
+	raw = StripMarkdownFences(raw)
+
+	var parsed struct {
+		Skills []CertSkill `json:"skills"`
+	}
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		return nil, fmt.Errorf("cert_recommend parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+	}
+
+	options := make(map[string][]CertOption, len(parsed.Skills))
+	for _, s := range parsed.Skills {
+		for _, o := range s.Options {
+			if !seenURLs[o.URL] {
+				o.URL = "" // not from the search results — don't pass on a guessed link
+			}
+			options[strings.ToLower(s.Skill)] = append(options[strings.ToLower(s.Skill)], o)
+		}
+	}
+
+	result := &CertRecommendResult{}
+	total := 0
+	for _, g := range gaps {
+		g.Options = options[strings.ToLower(g.Skill)]
+		if g.Options == nil {
+			g.Options = []CertOption{}
+		}
+		total += len(g.Options)
+		result.Skills = append(result.Skills, g)
+	}
+	names := make([]string, len(gaps))
+	for i, g := range gaps {
+		names[i] = g.Skill
+	}
+	result.Summary = fmt.Sprintf("Found %d certifications/courses for %d skill gaps: %s.", total, len(gaps), strings.Join(names, ", "))
+	return result, nil
+}
+
+// certGapSkills returns the skills to cover, with the target jobs listing each.
+func certGapSkills(ctx context.Context, input engine.CertRecommendInput, limit int) ([]CertSkill, error) {
+	var gaps []CertSkill
+	if len(input.Skills) > 0 {
+		for _, s := range input.Skills {
+			if s = strings.TrimSpace(s); s != "" {
+				gaps = append(gaps, CertSkill{Skill: s})
+			}
+		}
+	} else {
+		if input.JobDescription == "" {
+			return nil, errors.New("skills or job_description is required")
+		}
+		resume := input.Resume
+		if resume == "" {
+			feed, err := BuildResumeFeed(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("resume is required without a master resume: %w", err)
+			}
+			resume = ResumeFeedText(feed)
+		}
+		gap, err := AnalyzeSkillGap(ctx, resume, input.JobDescription)
+		if err != nil {
+			return nil, err
+		}
+		missing := gap.MissingSkills
+		sort.SliceStable(missing, func(a, b int) bool {
+			return certPriority(missing[a].Priority) < certPriority(missing[b].Priority)
+		})
+		for _, m := range missing {
+			if m.Category == "soft_skill" {
+				continue // no certification closes these
+			}
+			gaps = append(gaps, CertSkill{Skill: m.Skill, Priority: m.Priority})
+		}
+	}
+	if len(gaps) > limit {
+		gaps = gaps[:limit]
+	}
+
+	_, listings := lastSearch()
+	for i := range gaps {
+		gaps[i].TargetJobs = certTargetJobs(gaps[i].Skill, input.JobDescription, listings)
+	}
+	return gaps, nil
+}
+
+func certPriority(p string) int {
+	if r, ok := certPriorityRank[strings.ToLower(p)]; ok {
+		return r
+	}
+	return len(certPriorityRank)
+}
+
+// certTargetJobs lists the target jobs mentioning a skill: the given job description
+// and the listings of the last job_search (up to 5 in total).
+func certTargetJobs(skill, jobDescription string, listings []engine.JobListing) []string {
+	var out []string
+	key := strings.ToLower(skill)
+	if jobDescription != "" && strings.Contains(strings.ToLower(jobDescription), key) {
+		out = append(out, "target job description")
+	}
+	for _, l := range listings {
+		if len(out) >= 5 {
+			break
+		}
+		mentioned := strings.Contains(strings.ToLower(l.Title), key)
+		for _, s := range l.Skills {
+			if strings.EqualFold(strings.TrimSpace(s), skill) {
+				mentioned = true
+				break
+			}
+		}
+		if !mentioned {
+			continue
+		}
+		label := l.Title
+		if l.Company != "" {
+			label += " at " + l.Company
+		}
+		out = append(out, label)
+	}
+	return out
+}
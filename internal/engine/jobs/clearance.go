@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Regulated postings: listings that require a security clearance or a professional
+// license (FINRA series exams, medical, bar, CPA) are noise for most applicants. They
+// are tagged in eligibility.clearance_required and dropped from job_search with
+// exclude_clearance_required.
+
+// Clearance and license kinds.
+const (
+	ClearanceSecurity = "security_clearance"
+	LicenseFINRA      = "finra_license"
+	LicenseMedical    = "medical_license"
+	LicenseBar        = "bar_admission"
+	LicenseCPA        = "cpa_license"
+)
+
+var (
+	clearancePatterns = []struct {
+		kind string
+		re   *regexp.Regexp
+	}{
+		{ClearanceSecurity, regexp.MustCompile(`(?i)\b(ts/sci|top secret|(?:active |current |dod |sc |dv |secret |government )?security clearance|secret clearance|public trust (?:clearance|position)|(?:full[- ]scope |ci )?polygraph|sc cleared|dv cleared|nv[12] clearance|baseline clearance)\b`)},
+		{LicenseFINRA, regexp.MustCompile(`(?i)\b(series (?:3|6|7|9|10|24|63|65|66|79|86|87)\b|finra licen[cs](?:e|ed|ing))`)},
+		{LicenseMedical, regexp.MustCompile(`(?i)\b((?:active|current|valid|unrestricted)(?: state)? (?:medical|nursing|rn|pharmacy|pharmacist|physician|clinical) licen[cs]e|licensed (?:physician|nurse|pharmacist|clinician|therapist)|board[- ]certified|dea registration|rn licen[cs]e)\b`)},
+		{LicenseBar, regexp.MustCompile(`(?i)\b(admitted to (?:practice|the [a-z ]{0,20}bar)|bar admission|member of the [a-z ]{0,20}bar in good standing|licensed attorney|active bar (?:license|membership))\b`)},
+		{LicenseCPA, regexp.MustCompile(`(?i)\b(cpa licen[cs]e|licensed cpa|active cpa|cpa (?:is )?required|certified public accountant \(cpa\) required)\b`)},
+	}
+	// "No clearance required" and the like must not flag the listing.
+	noClearanceRe = regexp.MustCompile(`(?i)\b(?:no|not|without|doesn't|does not)\b[^.\n]{0,30}\b(?:clearance|licen[cs]e)\b|\b(?:clearance|licen[cs]e) (?:is )?(?:not|never) (?:required|needed)\b`)
+)
+
+// detectClearance returns the comma-separated clearance and license kinds text requires,
+// or "".
+func detectClearance(text string) string {
+	var kinds []string
+	negated := noClearanceRe.MatchString(text)
+	for _, p := range clearancePatterns {
+		loc := p.re.FindStringIndex(text)
+		if loc == nil {
+			continue
+		}
+		// A negation only cancels the mention it sits next to.
+		if negated && noClearanceRe.MatchString(text[max(0, loc[0]-40):min(len(text), loc[1]+40)]) {
+			continue
+		}
+		kinds = append(kinds, p.kind)
+	}
+	return strings.Join(kinds, ",")
+}
+
+// listingClearance returns the clearance kinds of j, detected from its text when the
+// eligibility block was not built (cached results of older versions).
+func listingClearance(j engine.JobListing) string {
+	if j.Eligibility != nil && j.Eligibility.ClearanceRequired != "" {
+		return j.Eligibility.ClearanceRequired
+	}
+	return detectClearance(j.Title + "\n" + j.Description)
+}
+
+// FilterClearanceRequired drops listings that require a security clearance or a
+// professional license, returning the kept listings and how many were dropped.
+func FilterClearanceRequired(listings []engine.JobListing) ([]engine.JobListing, int) {
+	out := listings[:0:0]
+	for _, j := range listings {
+		if listingClearance(j) == "" {
+			out = append(out, j)
+		}
+	}
+	return out, len(listings) - len(out)
+}
+
+// ClearanceDroppedNote is the summary suffix for FilterClearanceRequired.
+func ClearanceDroppedNote(dropped int) string {
+	if dropped == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" Hid %d listing(s) requiring a security clearance or professional license; set exclude_clearance_required=false to see them.", dropped)
+}
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestDetectClearance(t *testing.T) {
+	for text, want := range map[string]string{
+		"Active TS/SCI with full-scope polygraph required.":                 ClearanceSecurity,
+		"Must hold an active Secret security clearance.":                    ClearanceSecurity,
+		"Candidates must hold Series 7 and Series 63 licenses.":             LicenseFINRA,
+		"Active unrestricted state medical license; board-certified in IM.": LicenseMedical,
+		"Admitted to the New York bar and in good standing.":                LicenseBar,
+		"Licensed CPA preferred. Series 7 a plus.":                          LicenseFINRA + "," + LicenseCPA,
+		"No security clearance required.":                                   "",
+		"Backend engineer, Go and PostgreSQL, Series A startup.":            "",
+	} {
+		if got := detectClearance(text); got != want {
+			t.Errorf("detectClearance(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestFilterClearanceRequired(t *testing.T) {
+	listings := []engine.JobListing{
+		{Title: "Cleared Backend Engineer", Description: "TS/SCI required."},
+		{Title: "Backend Engineer", Description: "Go services."},
+		{Title: "Advisor", Eligibility: &engine.Eligibility{ClearanceRequired: LicenseFINRA}},
+	}
+	kept, dropped := FilterClearanceRequired(listings)
+	if dropped != 2 || len(kept) != 1 || kept[0].Title != "Backend Engineer" {
+		t.Errorf("kept %+v, dropped %d", kept, dropped)
+	}
+	if note := ClearanceDroppedNote(dropped); note == "" {
+		t.Error("expected a note for dropped listings")
+	}
+}
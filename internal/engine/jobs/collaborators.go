@@ -60,7 +60,7 @@ func SearchCollaborators(ctx context.Context, limit int) ([]engine.BountyListing
 }
 
 func fetchCollaborators(ctx context.Context) ([]engine.BountyListing, error) {
-	fetchCtx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, collaboratorsAPIURL, nil)
@@ -69,7 +69,7 @@ func fetchCollaborators(ctx context.Context) ([]engine.BountyListing, error) {
 	}
 	req.Header.Set("User-Agent", engine.UserAgentChrome)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("collaborators request failed: %w", err)
 	}
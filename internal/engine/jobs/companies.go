@@ -0,0 +1,413 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Company entity store: what the tools learn about a company — names it goes by, its
+// domain and ATS board, size, headquarters, rating, tech stack and the last
+// company_research result — kept in the tracker database so repeated lookups are
+// instant and job results can be joined with known company data.
+
+// companyResearchTTL is how long a stored company_research result is served as is.
+const companyResearchTTL = 30 * 24 * time.Hour
+
+// Company is a stored company record.
+type Company struct {
+	Name         string    `json:"name"`
+	Aliases      []string  `json:"aliases,omitempty"`
+	Domain       string    `json:"domain,omitempty"`
+	ATS          string    `json:"ats,omitempty"` // "greenhouse", "lever", "ashby"
+	ATSSlug      string    `json:"ats_slug,omitempty"`
+	Size         string    `json:"size,omitempty"`
+	HQ           string    `json:"hq,omitempty"`
+	Industry     string    `json:"industry,omitempty"`
+	Rating       float64   `json:"rating,omitempty"`
+	TechStack    []string  `json:"tech_stack,omitempty"`
+	EmployerRisk string    `json:"employer_risk,omitempty"` // "low", "medium", "high" from the last company_research
+	RiskSignals  []string  `json:"risk_signals,omitempty"`
+	ResearchedAt time.Time `json:"researched_at,omitzero"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// initCompaniesSchema creates the company tables in the tracker database.
+func initCompaniesSchema(db *sql.DB) error {
+	schema := `CREATE TABLE IF NOT EXISTS companies (
+		key           TEXT PRIMARY KEY,
+		name          TEXT NOT NULL,
+		domain        TEXT NOT NULL DEFAULT '',
+		ats           TEXT NOT NULL DEFAULT '',
+		ats_slug      TEXT NOT NULL DEFAULT '',
+		size          TEXT NOT NULL DEFAULT '',
+		hq            TEXT NOT NULL DEFAULT '',
+		industry      TEXT NOT NULL DEFAULT '',
+		rating        REAL NOT NULL DEFAULT 0,
+		tech_stack    TEXT NOT NULL DEFAULT '[]',
+		research      TEXT NOT NULL DEFAULT '',
+		researched_at TEXT NOT NULL DEFAULT '',
+		updated_at    TEXT NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS company_aliases (
+		alias TEXT PRIMARY KEY,
+		key   TEXT NOT NULL,
+		name  TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_company_aliases_key ON company_aliases(key)`
+	if _, err := db.Exec(schema); err != nil { //nolint:noctx // schema init, no user context available
+		return err
+	}
+	// Columns added after the initial schema.
+	for _, col := range []struct{ name, decl string }{
+		{"employer_risk", "TEXT NOT NULL DEFAULT ''"},
+		{"risk_signals", "TEXT NOT NULL DEFAULT '[]'"},
+	} {
+		if err := addColumnIfMissing(db, "companies", col.name, col.decl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var companySuffixRe = regexp.MustCompile(`\b(inc|incorporated|llc|ltd|limited|gmbh|corp|corporation|co|plc|ag|sa|bv|oy|ab|pty|the)\b`)
+
+// CompanyKey normalizes a company name for matching: "The Acme Robotics, Inc." →
+// "acme robotics".
+func CompanyKey(name string) string {
+	s := strings.ToLower(name)
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s)
+	s = companySuffixRe.ReplaceAllString(s, " ")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// resolveCompanyKey returns the record key a name or alias belongs to, or "".
+func resolveCompanyKey(ctx context.Context, db *sql.DB, name string) (string, error) {
+	alias := CompanyKey(name)
+	if alias == "" {
+		return "", nil
+	}
+	var key string
+	err := db.QueryRowContext(ctx, `SELECT key FROM company_aliases WHERE alias = ?`, alias).Scan(&key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return key, err
+}
+
+// UpsertCompany merges c into the store: non-empty fields overwrite, the tech stack is
+// unioned, and the name and aliases all resolve to the record. A name already known as
+// an alias updates that record.
+func UpsertCompany(ctx context.Context, c Company) error {
+	db, err := openTrackerDB()
+	if err != nil {
+		return err
+	}
+	return upsertCompany(ctx, db, c, "")
+}
+
+func upsertCompany(ctx context.Context, db *sql.DB, c Company, research string) error {
+	key, err := resolveCompanyKey(ctx, db, c.Name)
+	if err != nil {
+		return fmt.Errorf("companies: resolve: %w", err)
+	}
+	if key == "" {
+		for _, a := range c.Aliases {
+			if key, err = resolveCompanyKey(ctx, db, a); err != nil || key != "" {
+				break
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("companies: resolve: %w", err)
+		}
+	}
+	if key == "" {
+		key = CompanyKey(c.Name)
+	}
+	if key == "" {
+		return errors.New("companies: company name is required")
+	}
+
+	var stackJSON string
+	if err := db.QueryRowContext(ctx, `SELECT tech_stack FROM companies WHERE key = ?`, key).Scan(&stackJSON); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("companies: get: %w", err)
+	}
+	var stack []string
+	_ = json.Unmarshal([]byte(stackJSON), &stack)
+	stack = mergeTechStack(stack, c.TechStack)
+	stackBytes, _ := json.Marshal(stack)
+	signalBytes, _ := json.Marshal(c.RiskSignals)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	researchedAt := ""
+	if research != "" {
+		researchedAt = now
+	}
+	_, err = db.ExecContext(ctx, `INSERT INTO companies
+		(key, name, domain, ats, ats_slug, size, hq, industry, rating, tech_stack, employer_risk, risk_signals,
+			research, researched_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET
+			domain = COALESCE(NULLIF(excluded.domain, ''), domain),
+			ats = COALESCE(NULLIF(excluded.ats, ''), ats),
+			ats_slug = COALESCE(NULLIF(excluded.ats_slug, ''), ats_slug),
+			size = COALESCE(NULLIF(excluded.size, ''), size),
+			hq = COALESCE(NULLIF(excluded.hq, ''), hq),
+			industry = COALESCE(NULLIF(excluded.industry, ''), industry),
+			rating = CASE WHEN excluded.rating > 0 THEN excluded.rating ELSE rating END,
+			tech_stack = excluded.tech_stack,
+			employer_risk = COALESCE(NULLIF(excluded.employer_risk, ''), employer_risk),
+			risk_signals = CASE WHEN excluded.research != '' THEN excluded.risk_signals ELSE risk_signals END,
+			research = COALESCE(NULLIF(excluded.research, ''), research),
+			researched_at = COALESCE(NULLIF(excluded.researched_at, ''), researched_at),
+			updated_at = excluded.updated_at`,
+		key, strings.TrimSpace(c.Name), c.Domain, c.ATS, c.ATSSlug, c.Size, c.HQ, c.Industry, c.Rating,
+		string(stackBytes), c.EmployerRisk, string(signalBytes), research, researchedAt, now)
+	if err != nil {
+		return fmt.Errorf("companies: upsert: %w", err)
+	}
+	for _, a := range append([]string{c.Name}, c.Aliases...) {
+		if alias := CompanyKey(a); alias != "" {
+			if _, err := db.ExecContext(ctx, `INSERT OR IGNORE INTO company_aliases (alias, key, name) VALUES (?, ?, ?)`,
+				alias, key, strings.TrimSpace(a)); err != nil {
+				return fmt.Errorf("companies: alias: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// mergeTechStack unions two stacks case-insensitively, keeping the first spelling.
+func mergeTechStack(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var out []string
+	for _, t := range append(append([]string(nil), a...), b...) {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[strings.ToLower(t)] {
+			continue
+		}
+		seen[strings.ToLower(t)] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+// GetCompany looks a company up by name or alias. Returns nil, nil when unknown.
+func GetCompany(ctx context.Context, name string) (*Company, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	c, _, err := getCompany(ctx, db, name)
+	return c, err
+}
+
+// getCompany returns the company record and its stored research JSON.
+func getCompany(ctx context.Context, db *sql.DB, name string) (*Company, string, error) {
+	key, err := resolveCompanyKey(ctx, db, name)
+	if err != nil || key == "" {
+		return nil, "", err
+	}
+	var c Company
+	var stackJSON, signalsJSON, research, researchedAt, updatedAt string
+	err = db.QueryRowContext(ctx, `SELECT name, domain, ats, ats_slug, size, hq, industry, rating, tech_stack,
+		employer_risk, risk_signals, research, researched_at, updated_at FROM companies WHERE key = ?`, key).Scan(
+		&c.Name, &c.Domain, &c.ATS, &c.ATSSlug, &c.Size, &c.HQ, &c.Industry, &c.Rating, &stackJSON,
+		&c.EmployerRisk, &signalsJSON, &research, &researchedAt, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("companies: get: %w", err)
+	}
+	_ = json.Unmarshal([]byte(stackJSON), &c.TechStack)
+	_ = json.Unmarshal([]byte(signalsJSON), &c.RiskSignals)
+	c.ResearchedAt, _ = time.Parse(time.RFC3339, researchedAt)
+	c.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	rows, err := db.QueryContext(ctx, `SELECT name FROM company_aliases WHERE key = ? ORDER BY alias`, key)
+	if err != nil {
+		return nil, "", fmt.Errorf("companies: aliases: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a string
+		if err := rows.Scan(&a); err != nil {
+			return nil, "", fmt.Errorf("companies: scan alias: %w", err)
+		}
+		if !strings.EqualFold(a, c.Name) {
+			c.Aliases = append(c.Aliases, a)
+		}
+	}
+	return &c, research, rows.Err()
+}
+
+// storedCompanyResearch returns the stored company_research result for a company when
+// it is younger than companyResearchTTL.
+func storedCompanyResearch(ctx context.Context, name string) *CompanyResearchResult {
+	res, _ := cachedCompanyResearch(ctx, name)
+	return res
+}
+
+// cachedCompanyResearch is storedCompanyResearch with the time of the research.
+func cachedCompanyResearch(ctx context.Context, name string) (*CompanyResearchResult, time.Time) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, time.Time{}
+	}
+	c, research, err := getCompany(ctx, db, name)
+	if err != nil || c == nil || research == "" || time.Since(c.ResearchedAt) > companyResearchTTL {
+		return nil, time.Time{}
+	}
+	var res CompanyResearchResult
+	if err := json.Unmarshal([]byte(research), &res); err != nil {
+		return nil, time.Time{}
+	}
+	return &res, c.ResearchedAt
+}
+
+// recordCompanyResearch stores a company_research result under the queried name and
+// the official name it returned. Errors are logged.
+func recordCompanyResearch(ctx context.Context, query string, res *CompanyResearchResult) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return
+	}
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return
+	}
+	name := res.Name
+	if name == "" {
+		name = query
+	}
+	c := Company{
+		Name:         name,
+		Aliases:      []string{query},
+		Domain:       companyDomain(res.Website),
+		Size:         res.Size,
+		HQ:           res.Headquarters,
+		Industry:     res.Industry,
+		Rating:       res.GlassdoorRating,
+		TechStack:    res.TechStack,
+		EmployerRisk: res.EmployerRisk,
+		RiskSignals:  res.RiskSignals,
+	}
+	if err := upsertCompany(ctx, db, c, string(raw)); err != nil {
+		slog.Warn("companies: record research failed", slog.String("company", name), slog.Any("error", err))
+	}
+}
+
+// companyDomain extracts the bare host from a website URL.
+func companyDomain(website string) string {
+	website = strings.TrimSpace(website)
+	if website == "" {
+		return ""
+	}
+	if !strings.Contains(website, "://") {
+		website = "https://" + website
+	}
+	u, err := url.Parse(website)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+var atsBoardRe = regexp.MustCompile(`(?i)^https?://(?:boards|job-boards)\.greenhouse\.io/([a-z0-9_-]+)|^https?://jobs\.lever\.co/([a-z0-9_.-]+)|^https?://jobs\.ashbyhq\.com/([a-z0-9_.%-]+)`)
+
+// atsFromURL returns the ATS and board slug of a job posting URL, if any.
+func atsFromURL(rawURL string) (ats, slug string) {
+	m := atsBoardRe.FindStringSubmatch(rawURL)
+	switch {
+	case m == nil:
+		return "", ""
+	case m[1] != "":
+		return "greenhouse", strings.ToLower(m[1])
+	case m[2] != "":
+		return "lever", strings.ToLower(m[2])
+	}
+	return "ashby", strings.ToLower(m[3])
+}
+
+// RecordListingCompanies adds the companies of parsed job listings to the store, with
+// their ATS board when the posting URL is on one. Store errors are logged.
+func RecordListingCompanies(ctx context.Context, listings []engine.JobListing) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return
+	}
+	seen := make(map[string]bool)
+	for _, j := range listings {
+		key := CompanyKey(j.Company)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		ats, slug := atsFromURL(j.URL)
+		if err := upsertCompany(ctx, db, Company{Name: j.Company, ATS: ats, ATSSlug: slug}, ""); err != nil {
+			slog.Warn("companies: record listing company failed", slog.Any("error", err))
+			return
+		}
+	}
+}
+
+// AnnotateKnownCompanies joins listings with stored company data (output_version 2).
+func AnnotateKnownCompanies(ctx context.Context, listings []engine.JobListing) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return
+	}
+	cache := make(map[string]*engine.CompanyInfo)
+	for i := range listings {
+		j := &listings[i]
+		key := CompanyKey(j.Company)
+		if key == "" {
+			continue
+		}
+		info, ok := cache[key]
+		if !ok {
+			if c, _, err := getCompany(ctx, db, j.Company); err == nil && c != nil {
+				info = companyInfo(c)
+			}
+			cache[key] = info
+		}
+		j.CompanyInfo = info
+	}
+}
+
+// companyInfo is the listing-facing summary of a company record, or nil when the record
+// holds nothing beyond the name.
+func companyInfo(c *Company) *engine.CompanyInfo {
+	info := &engine.CompanyInfo{
+		Domain:       c.Domain,
+		ATS:          c.ATS,
+		Size:         c.Size,
+		HQ:           c.HQ,
+		Industry:     c.Industry,
+		Rating:       c.Rating,
+		TechStack:    c.TechStack,
+		EmployerRisk: c.EmployerRisk,
+		RiskSignals:  c.RiskSignals,
+	}
+	if info.Domain == "" && info.ATS == "" && info.Size == "" && info.HQ == "" && info.Industry == "" &&
+		info.Rating == 0 && len(info.TechStack) == 0 && info.EmployerRisk == "" {
+		return nil
+	}
+	return info
+}
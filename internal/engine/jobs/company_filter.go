@@ -0,0 +1,225 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// job_search company_size and industry filters. LinkedIn takes industries as f_I codes;
+// its job search has no company-size filter. Everything not filtered at the source is
+// classified after the merge from the company entity store (size and industry recorded
+// by company_research). Listings at companies the store knows nothing about are kept.
+
+// Company size buckets.
+const (
+	CompanySizeStartup    = "startup"    // up to 200 employees
+	CompanySizeSMB        = "smb"        // 201-1000
+	CompanySizeEnterprise = "enterprise" // over 1000
+)
+
+// ParseCompanySize validates a company_size input, returning the bucket or "".
+func ParseCompanySize(s string) (string, error) {
+	switch v := strings.ToLower(strings.TrimSpace(s)); v {
+	case "":
+		return "", nil
+	case CompanySizeStartup, CompanySizeSMB, CompanySizeEnterprise:
+		return v, nil
+	case "sme", "mid-size", "midsize":
+		return CompanySizeSMB, nil
+	}
+	return "", fmt.Errorf("invalid company_size %q (valid: startup, smb, enterprise)", s)
+}
+
+// Employee counts: a size field that is only a count or range ("51-200", "10,000+",
+// "~5k"), or a count next to a headcount keyword. Other numbers in free text, such
+// as "founded 2015", are not sizes.
+const employeeCountExpr = `~?\s*(\d[\d,.]*)\s*(k\b)?(?:\s*[-–]\s*\d[\d,.]*\s*k?)?\s*\+?`
+
+var (
+	bareEmployeeCountRe   = regexp.MustCompile(`^\s*` + employeeCountExpr + `\s*$`)
+	employeeCountBeforeRe = regexp.MustCompile(employeeCountExpr + `\s*(?:employees|employee|staff|people|headcount)\b`)
+	employeeCountAfterRe  = regexp.MustCompile(`\b(?:headcount|employees|team size|staff)\s*(?:of|is|:)?\s*` + employeeCountExpr)
+)
+
+// employeeCount returns the (lower-bound) employee count stated in lower-case text.
+func employeeCount(lower string) (float64, bool) {
+	for _, re := range []*regexp.Regexp{bareEmployeeCountRe, employeeCountBeforeRe, employeeCountAfterRe} {
+		m := re.FindStringSubmatch(lower)
+		if m == nil {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+		if err != nil {
+			continue
+		}
+		if m[2] != "" {
+			n *= 1000
+		}
+		return n, true
+	}
+	return 0, false
+}
+
+// ClassifyCompanySize buckets a free-text company size such as "51-200 employees",
+// "10,000+", "~5k" or "Series A startup". A range is bucketed by its lower bound.
+// Returns "" when the text says nothing usable.
+func ClassifyCompanySize(size string) string {
+	lower := strings.ToLower(size)
+	if n, ok := employeeCount(lower); ok {
+		switch {
+		case n <= 200:
+			return CompanySizeStartup
+		case n <= 1000:
+			return CompanySizeSMB
+		default:
+			return CompanySizeEnterprise
+		}
+	}
+	switch {
+	case strings.Contains(lower, "startup"), strings.Contains(lower, "seed"), strings.Contains(lower, "series a"):
+		return CompanySizeStartup
+	case strings.Contains(lower, "enterprise"), strings.Contains(lower, "fortune"), strings.Contains(lower, "large"):
+		return CompanySizeEnterprise
+	case strings.Contains(lower, "mid-size"), strings.Contains(lower, "midsize"), strings.Contains(lower, "medium"),
+		strings.Contains(lower, "small"):
+		return CompanySizeSMB
+	}
+	return ""
+}
+
+// industryFilter maps an industry input onto LinkedIn f_I codes and the words that
+// identify it in a stored company industry.
+type industryFilter struct {
+	names    []string // accepted inputs, lower-case
+	linkedIn []string // LinkedIn industry codes (f_I)
+	keywords []string // substrings of a matching company industry, lower-case
+}
+
+var industryFilters = []industryFilter{
+	{[]string{"software", "saas"}, []string{"4"}, []string{"software", "saas", "developer tools", "devtools", "cloud"}},
+	{[]string{"internet", "tech"}, []string{"6"}, []string{"internet", "online", "marketplace", "social media", "technology"}},
+	{[]string{"it services", "consulting"}, []string{"96"}, []string{"it services", "consulting", "outsourcing"}},
+	{[]string{"fintech", "finance", "financial services", "banking"}, []string{"43", "41"},
+		[]string{"fintech", "financ", "payments", "banking", "bank", "crypto", "trading"}},
+	{[]string{"insurance", "insurtech"}, []string{"42"}, []string{"insur"}},
+	{[]string{"healthcare", "health", "healthtech"}, []string{"14"}, []string{"health", "medical", "hospital", "clinic"}},
+	{[]string{"biotech", "pharma"}, []string{"12", "15"}, []string{"biotech", "pharma", "life science"}},
+	{[]string{"retail", "e-commerce", "ecommerce"}, []string{"27"}, []string{"retail", "e-commerce", "ecommerce", "consumer goods"}},
+	{[]string{"gaming", "games"}, []string{"109"}, []string{"gaming", "games", "game "}},
+	{[]string{"education", "edtech"}, []string{"68", "132"}, []string{"education", "edtech", "e-learning", "learning"}},
+	{[]string{"telecom", "telecommunications"}, []string{"8"}, []string{"telecom"}},
+	{[]string{"advertising", "adtech", "marketing"}, []string{"80"}, []string{"advertising", "adtech", "marketing"}},
+	{[]string{"staffing", "recruiting"}, []string{"104"}, []string{"staffing", "recruit"}},
+	{[]string{"hardware", "semiconductors"}, []string{"3", "7"}, []string{"hardware", "semiconductor", "electronics"}},
+	{[]string{"automotive"}, []string{"53"}, []string{"automotive", "vehicle", "mobility"}},
+}
+
+// lookupIndustry returns the filter for an industry input, or nil when it is not one of
+// the mapped industries.
+func lookupIndustry(industry string) *industryFilter {
+	v := strings.ToLower(strings.TrimSpace(industry))
+	for i, f := range industryFilters {
+		for _, name := range f.names {
+			if v == name {
+				return &industryFilters[i]
+			}
+		}
+	}
+	return nil
+}
+
+// LinkedInIndustryCodes returns the f_I value for an industry input, "" when LinkedIn
+// has no mapped code for it.
+func LinkedInIndustryCodes(industry string) string {
+	if f := lookupIndustry(industry); f != nil {
+		return strings.Join(f.linkedIn, ",")
+	}
+	return ""
+}
+
+// industryMatches reports whether a stored company industry belongs to the requested one.
+// Industries without a mapping match on the input text itself.
+func industryMatches(stored, industry string) bool {
+	stored = strings.ToLower(stored)
+	keywords := []string{strings.ToLower(strings.TrimSpace(industry))}
+	if f := lookupIndustry(industry); f != nil {
+		keywords = f.keywords
+	}
+	for _, k := range keywords {
+		if strings.Contains(stored, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByCompanyProfile drops listings whose company is known to be of another size
+// bucket or industry. Listings the store cannot classify are kept and counted in
+// unknown. LinkedIn listings are not re-checked on a mapped industry (f_I filtered
+// them), and workatastartup.com listings count as startups.
+func FilterByCompanyProfile(ctx context.Context, listings []engine.JobListing, companySize, industry string) (kept []engine.JobListing, dropped, unknown int) {
+	size, _ := ParseCompanySize(companySize)
+	industry = strings.TrimSpace(industry)
+	if size == "" && industry == "" {
+		return listings, 0, 0
+	}
+	db, err := openTrackerDB()
+	if err != nil {
+		return listings, 0, 0
+	}
+	linkedInIndustry := LinkedInIndustryCodes(industry) != ""
+	cache := make(map[string]*Company)
+	kept = listings[:0]
+	for _, j := range listings {
+		c, ok := cache[CompanyKey(j.Company)]
+		if !ok {
+			c, _, _ = getCompany(ctx, db, j.Company)
+			cache[CompanyKey(j.Company)] = c
+		}
+		classified := true
+		if size != "" {
+			got := ""
+			if c != nil {
+				got = ClassifyCompanySize(c.Size)
+			}
+			if got == "" && strings.Contains(j.URL, "workatastartup.com") {
+				got = CompanySizeStartup
+			}
+			if got != "" && got != size {
+				dropped++
+				continue
+			}
+			classified = got != ""
+		}
+		if industry != "" && !(linkedInIndustry && strings.Contains(j.URL, "linkedin.com")) {
+			if c == nil || c.Industry == "" {
+				classified = false
+			} else if !industryMatches(c.Industry, industry) {
+				dropped++
+				continue
+			}
+		}
+		if !classified {
+			unknown++
+		}
+		kept = append(kept, j)
+	}
+	return kept, dropped, unknown
+}
+
+// CompanyProfileNote is the summary suffix for FilterByCompanyProfile.
+func CompanyProfileNote(dropped, unknown int) string {
+	var note string
+	if dropped > 0 {
+		note += fmt.Sprintf(" Hid %d listing(s) at companies of another size or industry.", dropped)
+	}
+	if unknown > 0 {
+		note += fmt.Sprintf(" Kept %d listing(s) at companies without a stored size or industry; company_research classifies them.", unknown)
+	}
+	return note
+}
@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestClassifyCompanySize(t *testing.T) {
+	for size, want := range map[string]string{
+		"51-200 employees":                      CompanySizeStartup,
+		"201-500":                               CompanySizeSMB,
+		"1,001-5,000 employees":                 CompanySizeEnterprise,
+		"10,000+":                               CompanySizeEnterprise,
+		"~5k":                                   CompanySizeEnterprise,
+		"Series A startup":                      CompanySizeStartup,
+		"Mid-size":                              CompanySizeSMB,
+		"Founded 2015, small team":              CompanySizeSMB,
+		"Founded 2015, about 40 employees":      CompanySizeStartup,
+		"Fintech, headcount: 3,500 (2024)":      CompanySizeEnterprise,
+		"Series A startup, founded in 2021":     CompanySizeStartup,
+		"Remote-first, offices in 12 countries": "",
+		"":                                      "",
+		"unknown":                               "",
+	} {
+		if got := ClassifyCompanySize(size); got != want {
+			t.Errorf("ClassifyCompanySize(%q) = %q, want %q", size, got, want)
+		}
+	}
+	if _, err := ParseCompanySize("huge"); err == nil {
+		t.Error("expected an error for an unknown company_size")
+	}
+	if got := LinkedInIndustryCodes("FinTech"); got != "43,41" {
+		t.Errorf("fintech codes = %q", got)
+	}
+}
+
+func TestFilterByCompanyProfile(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	for _, c := range []Company{
+		{Name: "Tiny", Size: "11-50 employees", Industry: "Financial Services"},
+		{Name: "Mega", Size: "10,001+ employees", Industry: "Banking"},
+		{Name: "Games Co", Size: "51-200", Industry: "Computer Games"},
+	} {
+		if err := UpsertCompany(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	listings := func() []engine.JobListing {
+		return []engine.JobListing{
+			{Title: "Go", Company: "Tiny"},
+			{Title: "Go", Company: "Mega"},
+			{Title: "Go", Company: "Games Co"},
+			{Title: "Go", Company: "Nobody"},
+			{Title: "Go", Company: "Games Co", URL: "https://www.linkedin.com/jobs/view/123"},
+		}
+	}
+
+	kept, dropped, unknown := FilterByCompanyProfile(ctx, listings(), "startup", "fintech")
+	// Games Co is dropped on industry, except on LinkedIn, which filtered by f_I.
+	if len(kept) != 3 || kept[0].Company != "Tiny" || kept[1].Company != "Nobody" || kept[2].URL == "" {
+		t.Fatalf("kept %+v", kept)
+	}
+	if dropped != 2 || unknown != 1 {
+		t.Errorf("dropped %d, unknown %d", dropped, unknown)
+	}
+
+	kept, dropped, _ = FilterByCompanyProfile(ctx, listings(), "enterprise", "")
+	if len(kept) != 2 || kept[0].Company != "Mega" || dropped != 3 {
+		t.Errorf("enterprise: kept %+v, dropped %d", kept, dropped)
+	}
+}
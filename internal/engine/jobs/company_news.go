@@ -0,0 +1,266 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Company news timeline ---
+
+// News categories.
+const (
+	NewsFunding     = "funding"
+	NewsLayoffs     = "layoffs"
+	NewsProduct     = "product_launch"
+	NewsLawsuit     = "lawsuit"
+	NewsAcquisition = "acquisition"
+	NewsLeadership  = "leadership"
+	NewsOther       = "other"
+)
+
+// News sentiments.
+const (
+	SentimentPositive = "positive"
+	SentimentNegative = "negative"
+	SentimentNeutral  = "neutral"
+)
+
+const companyNewsMax = 15
+
+// NewsItem is one dated news story about a company.
+type NewsItem struct {
+	Date      string `json:"date,omitempty"` // YYYY-MM-DD, YYYY-MM or YYYY as stated
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	Source    string `json:"source"` // publisher domain
+	Category  string `json:"category"`
+	Sentiment string `json:"sentiment"`
+	Snippet   string `json:"snippet,omitempty"`
+}
+
+// CompanyNewsResult is the structured output of company_news.
+type CompanyNewsResult struct {
+	Company   string         `json:"company"`
+	Items     []NewsItem     `json:"items"`
+	Counts    map[string]int `json:"counts"`    // items per category
+	Sentiment string         `json:"sentiment"` // overall: "positive", "negative", "mixed", "neutral"
+	Summary   string         `json:"summary"`
+}
+
+// newsRules classify a story by its headline and snippet; the first match wins, so the
+// more specific negative categories come first.
+var newsRules = []struct {
+	category, sentiment string
+	re                  *regexp.Regexp
+}{
+	{NewsLayoffs, SentimentNegative, layoffRe},
+	{NewsLayoffs, SentimentNegative, freezeRe},
+	{NewsLawsuit, SentimentNegative, regexp.MustCompile(`(?i)\b(lawsuits?|sued|sues|suing|class action|antitrust|settlement|fined?|penalt(y|ies)|regulators?|probe|investigation|indicted|litigation)\b`)},
+	{NewsFunding, SentimentPositive, regexp.MustCompile(`(?i)\b(raises?|raised|funding round|series [a-f]\b|seed round|valuation|ipo|goes public|investment from|backed by)\b`)},
+	{NewsAcquisition, SentimentNeutral, regexp.MustCompile(`(?i)\b(acquires?|acquired|acquisition|merger|merges|buys|bought|takeover)\b`)},
+	{NewsProduct, SentimentPositive, regexp.MustCompile(`(?i)\b(launch(es|ed)?|unveils?|introduces?|releases?|rolls out|announces? (new|the)|general availability|now available)\b`)},
+	{NewsLeadership, SentimentNeutral, regexp.MustCompile(`(?i)\b(ceo|cto|cfo|coo|chief \w+ officer|steps down|resigns?|appoints?|names new|hires .* as)\b`)},
+}
+
+var (
+	negativeNewsRe = regexp.MustCompile(`(?i)\b(breach|outage|scandal|bankrupt(cy)?|shut(s|ting)? down|losses|decline|plunge|struggl\w*|controversy|backlash)\b`)
+	isoDateRe      = regexp.MustCompile(`\b(20\d{2})-(\d{2})-(\d{2})\b`)
+)
+
+// CompanyNews returns a dated, classified list of recent news about a company, cached
+// per company.
+func CompanyNews(ctx context.Context, company string) (*CompanyNewsResult, error) {
+	company = strings.TrimSpace(company)
+	cacheKey := engine.CacheKey("company_news", strings.ToLower(company))
+	if cached, ok := engine.CacheLoadJSON[CompanyNewsResult](ctx, cacheKey); ok {
+		return &cached, nil
+	}
+
+	queries := []string{
+		company + " news",
+		company + " funding OR layoffs OR launch OR lawsuit OR acquisition",
+	}
+	type searchRes struct {
+		results []engine.SearxngResult
+		err     error
+	}
+	ch := make(chan searchRes, len(queries))
+	for _, q := range queries {
+		engine.GoOutbound(ctx, func() {
+			r, err := engine.SearchSearXNG(ctx, q, "all", "year", engine.DefaultSearchEngine)
+			ch <- searchRes{r, err}
+		})
+	}
+	var results []engine.SearxngResult
+	var lastErr error
+	for range queries {
+		res := <-ch
+		if res.err != nil {
+			slog.Debug("company_news: search failed", slog.Any("error", res.err))
+			lastErr = res.err
+			continue
+		}
+		results = append(results, res.results...)
+	}
+	if len(results) == 0 && lastErr != nil {
+		return nil, fmt.Errorf("company_news: %w", lastErr)
+	}
+
+	result := buildCompanyNews(company, results)
+	engine.CacheStoreJSON(ctx, cacheKey, company, *result)
+	return result, nil
+}
+
+// buildCompanyNews classifies the search results about company into a timeline,
+// newest first; undated items go last.
+func buildCompanyNews(company string, results []engine.SearxngResult) *CompanyNewsResult {
+	out := &CompanyNewsResult{Company: company, Items: []NewsItem{}, Counts: map[string]int{}}
+	name := strings.ToLower(company)
+	seen := make(map[string]bool)
+	for _, r := range results {
+		text := r.Title + "\n" + r.Content
+		if r.URL == "" || seen[r.URL] || !strings.Contains(strings.ToLower(text), name) {
+			continue
+		}
+		seen[r.URL] = true
+		item := NewsItem{
+			Date:    newsDate(text),
+			Title:   strings.TrimSpace(r.Title),
+			URL:     r.URL,
+			Source:  newsSource(r.URL),
+			Snippet: engine.TruncateRunes(strings.TrimSpace(r.Content), 240, "..."),
+		}
+		item.Category, item.Sentiment = classifyNews(text)
+		out.Items = append(out.Items, item)
+	}
+	sort.SliceStable(out.Items, func(i, j int) bool { return out.Items[i].Date > out.Items[j].Date })
+	if len(out.Items) > companyNewsMax {
+		out.Items = out.Items[:companyNewsMax]
+	}
+
+	var pos, neg int
+	for _, it := range out.Items {
+		out.Counts[it.Category]++
+		switch it.Sentiment {
+		case SentimentPositive:
+			pos++
+		case SentimentNegative:
+			neg++
+		}
+	}
+	switch {
+	case pos == 0 && neg == 0:
+		out.Sentiment = SentimentNeutral
+	case neg == 0:
+		out.Sentiment = SentimentPositive
+	case pos == 0:
+		out.Sentiment = SentimentNegative
+	default:
+		out.Sentiment = "mixed"
+	}
+	out.Summary = fmt.Sprintf("%d news items about %s (%d positive, %d negative); overall %s.", len(out.Items), company, pos, neg, out.Sentiment)
+	return out
+}
+
+// classifyNews returns a story's category and sentiment.
+func classifyNews(text string) (category, sentiment string) {
+	category, sentiment = NewsOther, SentimentNeutral
+	for _, r := range newsRules {
+		if r.re.MatchString(text) {
+			category, sentiment = r.category, r.sentiment
+			break
+		}
+	}
+	if sentiment != SentimentNegative && negativeNewsRe.MatchString(text) {
+		sentiment = SentimentNegative
+	}
+	return category, sentiment
+}
+
+// newsDate finds the date a story states: an ISO date, a month and year, or a year.
+func newsDate(text string) string {
+	if m := isoDateRe.FindString(text); m != "" {
+		return m
+	}
+	if m := monthRe.FindStringSubmatch(text); m != nil {
+		return fmt.Sprintf("%s-%02d", m[2], monthNumbers[strings.ToLower(m[1][:3])])
+	}
+	return yearRe.FindString(text)
+}
+
+func newsSource(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(u.Hostname(), "www.")
+}
+
+// BuildNewsContext formats the company's news as a prompt context block, or "" when
+// there is none.
+func BuildNewsContext(news *CompanyNewsResult) string {
+	if news == nil || len(news.Items) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nRECENT COMPANY NEWS (%s, overall %s):\n", news.Company, news.Sentiment)
+	for i, it := range news.Items {
+		if i == 8 {
+			break
+		}
+		date := it.Date
+		if date == "" {
+			date = "undated"
+		}
+		fmt.Fprintf(&b, "- [%s, %s, %s] %s\n", date, it.Category, it.Sentiment, it.Title)
+	}
+	return b.String()
+}
+
+// newsRedFlags turns negative company news from the last 12 months into red flags:
+// layoffs and lawsuits, with the headline as evidence.
+func newsRedFlags(news *CompanyNewsResult, now time.Time) []RedFlag {
+	if news == nil {
+		return nil
+	}
+	var flags []RedFlag
+	seen := make(map[string]bool)
+	for _, it := range news.Items {
+		if it.Category != NewsLayoffs && it.Category != NewsLawsuit {
+			continue
+		}
+		if months, ok := monthsSince(newsMonth(it.Date), now); !ok || months > layoffRecentMonths {
+			continue
+		}
+		category := "company_" + it.Category
+		if seen[category] {
+			continue
+		}
+		seen[category] = true
+		flag := RedFlag{Category: category, Severity: "medium", Evidence: it.Title, Source: "news"}
+		if it.Category == NewsLayoffs {
+			flag.Severity = "high"
+			flag.Explanation = fmt.Sprintf("%s reported layoffs or a hiring freeze recently (%s, %s).", news.Company, it.Date, it.Source)
+		} else {
+			flag.Explanation = fmt.Sprintf("%s is in recent legal or regulatory news (%s, %s).", news.Company, it.Date, it.Source)
+		}
+		flags = append(flags, flag)
+	}
+	return flags
+}
+
+// newsMonth trims a YYYY-MM-DD date to the YYYY-MM form monthsSince reads.
+func newsMonth(date string) string {
+	if len(date) == len("2006-01-02") {
+		return date[:7]
+	}
+	return date
+}
@@ -0,0 +1,273 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Employer risk for company_research: layoffs and hiring freezes reported by
+// layoffs.fyi and the news (found via SearXNG and read by a rule-based extractor), and
+// hiring velocity from the postings recorded in the seen-jobs store.
+
+// Employer risk levels.
+const (
+	EmployerRiskLow    = "low"
+	EmployerRiskMedium = "medium"
+	EmployerRiskHigh   = "high"
+)
+
+const (
+	layoffRecentMonths = 12 // a layoff this recent makes an employer high risk
+	layoffWatchMonths  = 24 // older than this, layoffs are ignored
+	velocityWindow     = 30 * 24 * time.Hour
+	maxLayoffEvents    = 5
+)
+
+// LayoffEvent is one reported layoff round.
+type LayoffEvent struct {
+	Date     string  `json:"date,omitempty"` // YYYY-MM or YYYY
+	Count    int     `json:"count,omitempty"`
+	Percent  float64 `json:"percent,omitempty"`
+	Headline string  `json:"headline"`
+	Source   string  `json:"source"`
+}
+
+// LayoffSignal is what the web reports about a company's layoffs and hiring freezes.
+type LayoffSignal struct {
+	Events       []LayoffEvent `json:"events,omitempty"`
+	HiringFreeze bool          `json:"hiring_freeze,omitempty"`
+}
+
+// HiringVelocity compares a company's new postings seen in the last 30 days with the
+// 30 days before. It reflects what job_search has seen, not the company's whole board.
+type HiringVelocity struct {
+	Last30 int    `json:"last_30_days"`
+	Prev30 int    `json:"previous_30_days"`
+	Trend  string `json:"trend"` // "growing", "steady", "slowing", "stalled"
+}
+
+var (
+	layoffRe  = regexp.MustCompile(`(?i)\b(lay(s|ing)? off|laid off|layoffs?|job cuts?|cuts? [\d,]+ (jobs|roles|positions)|workforce reduction|reduc(es|ing|ed) (its )?(workforce|headcount|staff)|redundanc(y|ies)|restructuring)\b`)
+	freezeRe  = regexp.MustCompile(`(?i)\b(hiring freeze|freez(es|ing) hiring|paus(es|ed|ing) (all )?hiring|halts? hiring)\b`)
+	countRe   = regexp.MustCompile(`(?i)\b(\d{1,3}(?:,\d{3})+|\d+)\s+(employees|workers|staff|people|jobs|positions|roles)\b`)
+	percentRe = regexp.MustCompile(`(?i)\b(\d{1,2}(?:\.\d+)?)\s?%\s+(?:of\s+)?(?:its\s+|the\s+|their\s+)?(?:global\s+)?(workforce|staff|employees|headcount|team|jobs)`)
+	monthRe   = regexp.MustCompile(`(?i)\b(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?\s+(?:\d{1,2},?\s+)?(20\d{2})\b`)
+	yearRe    = regexp.MustCompile(`\b(20\d{2})\b`)
+)
+
+var monthNumbers = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+// ResearchLayoffs searches layoffs.fyi and the news for a company's layoffs and hiring
+// freezes. Returns nil when nothing was found.
+func ResearchLayoffs(ctx context.Context, company string) *LayoffSignal {
+	queries := []string{
+		company + " layoffs site:layoffs.fyi",
+		company + " layoffs OR \"hiring freeze\" OR \"job cuts\"",
+	}
+	type searchRes struct {
+		results []engine.SearxngResult
+		err     error
+	}
+	ch := make(chan searchRes, len(queries))
+	for _, q := range queries {
+		engine.GoOutbound(ctx, func() {
+			r, err := engine.SearchSearXNG(ctx, q, "all", "", engine.DefaultSearchEngine)
+			ch <- searchRes{r, err}
+		})
+	}
+	var results []engine.SearxngResult
+	for range queries {
+		res := <-ch
+		if res.err != nil {
+			slog.Debug("company_research: layoffs search failed", slog.Any("error", res.err))
+			continue
+		}
+		results = append(results, res.results...)
+	}
+	return extractLayoffSignal(company, results)
+}
+
+// extractLayoffSignal reads layoff rounds and hiring freezes about company from search
+// results. Results not naming the company are ignored.
+func extractLayoffSignal(company string, results []engine.SearxngResult) *LayoffSignal {
+	name := strings.ToLower(strings.TrimSpace(company))
+	if name == "" {
+		return nil
+	}
+	var sig LayoffSignal
+	seen := make(map[string]bool)
+	for _, r := range results {
+		text := r.Title + "\n" + r.Content
+		if !strings.Contains(strings.ToLower(text), name) {
+			continue
+		}
+		if freezeRe.MatchString(text) {
+			sig.HiringFreeze = true
+		}
+		if !layoffRe.MatchString(text) {
+			continue
+		}
+		ev := LayoffEvent{Headline: engine.TruncateRunes(strings.TrimSpace(r.Title), 160, "..."), Source: r.URL}
+		if m := monthRe.FindStringSubmatch(text); m != nil {
+			ev.Date = fmt.Sprintf("%s-%02d", m[2], monthNumbers[strings.ToLower(m[1][:3])])
+		} else if m := yearRe.FindStringSubmatch(text); m != nil {
+			ev.Date = m[1]
+		}
+		if m := countRe.FindStringSubmatch(text); m != nil {
+			ev.Count, _ = strconv.Atoi(strings.ReplaceAll(m[1], ",", ""))
+		}
+		if m := percentRe.FindStringSubmatch(text); m != nil {
+			ev.Percent, _ = strconv.ParseFloat(m[1], 64)
+		}
+		key := fmt.Sprintf("%s|%d|%g", ev.Date, ev.Count, ev.Percent)
+		if ev.Date == "" && ev.Count == 0 && ev.Percent == 0 {
+			key = r.URL
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		sig.Events = append(sig.Events, ev)
+	}
+	if len(sig.Events) == 0 && !sig.HiringFreeze {
+		return nil
+	}
+	sort.SliceStable(sig.Events, func(i, j int) bool { return sig.Events[i].Date > sig.Events[j].Date })
+	if len(sig.Events) > maxLayoffEvents {
+		sig.Events = sig.Events[:maxLayoffEvents]
+	}
+	return &sig
+}
+
+// CompanyHiringVelocity counts a company's postings first seen in the last 30 days and
+// the 30 days before. Returns nil when too few postings were seen to tell.
+func CompanyHiringVelocity(ctx context.Context, company string, now time.Time) (*HiringVelocity, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := companyAliasKeys(ctx, db, company)
+	if err != nil || len(keys) == 0 {
+		return nil, err
+	}
+	match := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		match[k] = true
+	}
+	since := now.Add(-2 * velocityWindow).UTC().Format(time.RFC3339)
+	rows, err := db.QueryContext(ctx, `SELECT company, first_seen FROM seen_jobs WHERE first_seen >= ?`, since)
+	if err != nil {
+		return nil, fmt.Errorf("hiring velocity: %w", err)
+	}
+	defer rows.Close()
+	var v HiringVelocity
+	for rows.Next() {
+		var name, firstSeen string
+		if err := rows.Scan(&name, &firstSeen); err != nil {
+			return nil, fmt.Errorf("hiring velocity: scan: %w", err)
+		}
+		if !match[CompanyKey(name)] {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, firstSeen)
+		if err != nil {
+			continue
+		}
+		if now.Sub(t) <= velocityWindow {
+			v.Last30++
+		} else {
+			v.Prev30++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if v.Last30+v.Prev30 < 3 {
+		return nil, nil
+	}
+	switch {
+	case v.Last30 == 0:
+		v.Trend = "stalled"
+	case float64(v.Last30) >= 1.5*float64(v.Prev30):
+		v.Trend = "growing"
+	case float64(v.Last30) <= 0.5*float64(v.Prev30):
+		v.Trend = "slowing"
+	default:
+		v.Trend = "steady"
+	}
+	return &v, nil
+}
+
+// assessEmployerRisk rates an employer from its layoffs and hiring velocity, with the
+// reasons. A hiring freeze or a layoff in the last 12 months is high risk; an older
+// layoff (up to 24 months) or stalled/slowing hiring is medium.
+func assessEmployerRisk(l *LayoffSignal, v *HiringVelocity, now time.Time) (string, []string) {
+	risk := EmployerRiskLow
+	var signals []string
+	raise := func(level string) {
+		if level == EmployerRiskHigh || risk == EmployerRiskLow {
+			risk = level
+		}
+	}
+	if l != nil {
+		if l.HiringFreeze {
+			raise(EmployerRiskHigh)
+			signals = append(signals, "hiring freeze reported")
+		}
+		for _, ev := range l.Events {
+			months, ok := monthsSince(ev.Date, now)
+			if !ok || months > layoffWatchMonths {
+				continue
+			}
+			if months <= layoffRecentMonths {
+				raise(EmployerRiskHigh)
+			} else {
+				raise(EmployerRiskMedium)
+			}
+			signals = append(signals, describeLayoff(ev))
+		}
+	}
+	if v != nil && (v.Trend == "stalled" || v.Trend == "slowing") {
+		raise(EmployerRiskMedium)
+		signals = append(signals, fmt.Sprintf("hiring %s: %d new postings in the last 30 days vs %d before", v.Trend, v.Last30, v.Prev30))
+	}
+	return risk, signals
+}
+
+// monthsSince returns whole months from a YYYY-MM or YYYY date to now. A bare year
+// counts from its middle.
+func monthsSince(date string, now time.Time) (int, bool) {
+	t, err := time.Parse("2006-01", date)
+	if err != nil {
+		y, yErr := strconv.Atoi(date)
+		if yErr != nil {
+			return 0, false
+		}
+		t = time.Date(y, time.July, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return (now.Year()-t.Year())*12 + int(now.Month()) - int(t.Month()), true
+}
+
+func describeLayoff(ev LayoffEvent) string {
+	s := "layoffs " + ev.Date
+	switch {
+	case ev.Count > 0 && ev.Percent > 0:
+		s += fmt.Sprintf(": %d employees (%g%%)", ev.Count, ev.Percent)
+	case ev.Count > 0:
+		s += fmt.Sprintf(": %d employees", ev.Count)
+	case ev.Percent > 0:
+		s += fmt.Sprintf(": %g%% of staff", ev.Percent)
+	}
+	return s
+}
@@ -0,0 +1,223 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Company tech stack from the postings corpus: the skills of every posting recorded in
+// the seen-jobs store are aggregated per company, which shows what a company actually
+// hires for better than its marketing pages do.
+
+const (
+	companyStackMax = 20
+	// stackFitPromote is the stack fit from which job_search moves a listing ahead of
+	// companies whose stack the user does not share.
+	stackFitPromote = 0.5
+)
+
+// StackSkill is a skill's frequency across a company's postings.
+type StackSkill struct {
+	Skill    string  `json:"skill"`
+	Postings int     `json:"postings"`
+	Share    float64 `json:"share"` // fraction of the company's analyzed postings naming it
+}
+
+// CompanyPostingStack aggregates the skills of a company's recorded postings (under any
+// of its known names), most frequent first, and returns how many postings it analyzed.
+func CompanyPostingStack(ctx context.Context, company string) ([]StackSkill, int, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, 0, err
+	}
+	return companyPostingStack(ctx, db, company)
+}
+
+func companyPostingStack(ctx context.Context, db *sql.DB, company string) ([]StackSkill, int, error) {
+	keys, err := companyAliasKeys(ctx, db, company)
+	if err != nil || len(keys) == 0 {
+		return nil, 0, err
+	}
+	in := strings.TrimSuffix(strings.Repeat("?,", len(keys)), ",")
+	args := make([]any, len(keys))
+	for i, k := range keys {
+		args[i] = k
+	}
+
+	var postings int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(DISTINCT key) FROM seen_job_skills WHERE company_key IN (`+in+`)`, //nolint:gosec // placeholders only
+		args...).Scan(&postings); err != nil {
+		return nil, 0, fmt.Errorf("company stack: count: %w", err)
+	}
+	if postings == 0 {
+		return nil, 0, nil
+	}
+	rows, err := db.QueryContext(ctx, `SELECT MIN(skill), COUNT(DISTINCT key) AS n FROM seen_job_skills
+		WHERE company_key IN (`+in+`) GROUP BY LOWER(skill) ORDER BY n DESC, LOWER(skill) LIMIT ?`, //nolint:gosec // placeholders only
+		append(args, companyStackMax)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("company stack: aggregate: %w", err)
+	}
+	defer rows.Close()
+	var stack []StackSkill
+	for rows.Next() {
+		var s StackSkill
+		if err := rows.Scan(&s.Skill, &s.Postings); err != nil {
+			return nil, 0, fmt.Errorf("company stack: scan: %w", err)
+		}
+		s.Share = math.Round(float64(s.Postings)/float64(postings)*100) / 100
+		stack = append(stack, s)
+	}
+	return stack, postings, rows.Err()
+}
+
+// companyAliasKeys returns the normalized names a company is recorded under: its
+// company-store aliases when known, else just its own key.
+func companyAliasKeys(ctx context.Context, db *sql.DB, company string) ([]string, error) {
+	own := CompanyKey(company)
+	if own == "" {
+		return nil, nil
+	}
+	key, err := resolveCompanyKey(ctx, db, company)
+	if err != nil || key == "" {
+		return []string{own}, err
+	}
+	rows, err := db.QueryContext(ctx, `SELECT alias FROM company_aliases WHERE key = ?`, key)
+	if err != nil {
+		return nil, fmt.Errorf("company stack: aliases: %w", err)
+	}
+	defer rows.Close()
+	keys := []string{own}
+	for rows.Next() {
+		var a string
+		if err := rows.Scan(&a); err != nil {
+			return nil, err
+		}
+		if a != own {
+			keys = append(keys, a)
+		}
+	}
+	return keys, rows.Err()
+}
+
+// attachCorpusSignals adds what the seen-jobs store knows about a company to a
+// company_research result — the postings stack and hiring velocity — and rates the
+// employer risk from them and the reported layoffs.
+func attachCorpusSignals(ctx context.Context, company string, res *CompanyResearchResult) {
+	name := company
+	stack, n, err := CompanyPostingStack(ctx, company)
+	if err != nil {
+		slog.Debug("company_research: posting stack failed", slog.Any("error", err))
+	}
+	if n == 0 && res.Name != "" && !strings.EqualFold(res.Name, company) {
+		name = res.Name
+		stack, n, _ = CompanyPostingStack(ctx, name)
+	}
+	res.PostingStack, res.PostingsAnalyzed = stack, n
+
+	now := time.Now()
+	velocity, err := CompanyHiringVelocity(ctx, name, now)
+	if err != nil {
+		slog.Debug("company_research: hiring velocity failed", slog.Any("error", err))
+	}
+	res.HiringVelocity = velocity
+	res.EmployerRisk, res.RiskSignals = assessEmployerRisk(res.Layoffs, velocity, now)
+}
+
+// MasterStackWeights maps the master resume's skill names (lowercased) and their match
+// keywords to recency weights, or nil without a master resume.
+func MasterStackWeights(ctx context.Context) map[string]float64 {
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return nil
+	}
+	personID := db.GetLatestPersonID(ctx)
+	if personID == 0 {
+		return nil
+	}
+	skills, err := db.GetAllSkills(ctx, personID)
+	if err != nil || len(skills) == 0 {
+		return nil
+	}
+	now := time.Now()
+	weights := skillKeywordWeights(skills, now)
+	for _, s := range skills {
+		weights[strings.ToLower(s.Name)] = max(weights[strings.ToLower(s.Name)], SkillRecencyWeight(s.LastUsed, now))
+	}
+	return weights
+}
+
+// stackFit scores 0-1 how much of a company's stack, weighted by share, the user has.
+func stackFit(stack []StackSkill, weights map[string]float64) float64 {
+	var total, have float64
+	for _, s := range stack {
+		total += s.Share
+		w, ok := weights[strings.ToLower(s.Skill)]
+		if !ok {
+			for kw := range extractMatchKW(s.Skill) {
+				w = max(w, weights[kw])
+			}
+		}
+		have += s.Share * w
+	}
+	if total == 0 {
+		return 0
+	}
+	return math.Round(have/total*100) / 100
+}
+
+// RankByCompanyStack sets scores.stack_fit from each employer's posting stack and
+// moves listings at companies whose stack the user shares (fit >= 0.5) ahead of the
+// rest, keeping the existing order within each group. Listings at companies with no
+// recorded postings are left in place with the non-matching group.
+func RankByCompanyStack(ctx context.Context, listings []engine.JobListing, weights map[string]float64) {
+	if len(weights) == 0 {
+		return
+	}
+	db, err := openTrackerDB()
+	if err != nil {
+		return
+	}
+	fits := make(map[string]float64)
+	fit := make([]float64, len(listings))
+	for i := range listings {
+		j := &listings[i]
+		key := CompanyKey(j.Company)
+		if key == "" {
+			continue
+		}
+		f, ok := fits[key]
+		if !ok {
+			stack, _, err := companyPostingStack(ctx, db, j.Company)
+			if err != nil {
+				slog.Debug("job_search: company stack failed", slog.Any("error", err))
+			}
+			f = stackFit(stack, weights)
+			fits[key] = f
+		}
+		fit[i] = f
+		if f > 0 && j.Scores != nil {
+			j.Scores.StackFit = f
+		}
+	}
+	idx := make([]int, len(listings))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return fit[idx[a]] >= stackFitPromote && fit[idx[b]] < stackFitPromote
+	})
+	sorted := make([]engine.JobListing, len(listings))
+	for i, k := range idx {
+		sorted[i] = listings[k]
+	}
+	copy(listings, sorted)
+}
@@ -0,0 +1,217 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Compensation preferences: listings paying below the profile's salary floor (or,
+// with equity_preference=required, not mentioning equity) are dropped from
+// job_search and remote_work_search; the rest are annotated with how their pay
+// compares to the floor and target. Salaries are compared annually, in the
+// profile currency only.
+
+// Comp fit ratings.
+const (
+	CompBelowFloor  = "below_floor"
+	CompBelowTarget = "below_target"
+	CompMeetsTarget = "meets_target"
+)
+
+// EquityRequired is the equity_preference that drops listings not mentioning equity.
+const EquityRequired = "required"
+
+var (
+	equityRe     = regexp.MustCompile(`(?i)\b(equity|stock options?|rsus?|esop|share options)\b`)
+	salaryTextRe = regexp.MustCompile(`(\d[\d,]*(?:\.\d+)?)\s*([kK])?`)
+	salaryCurRes = []struct {
+		cur string
+		re  *regexp.Regexp
+	}{
+		{"USD", regexp.MustCompile(`(?i)\$|\busd\b`)},
+		{"EUR", regexp.MustCompile(`(?i)€|\beur\b`)},
+		{"GBP", regexp.MustCompile(`(?i)£|\bgbp\b`)},
+		{"RUB", regexp.MustCompile(`(?i)₽|\brub\b|\bруб`)},
+	}
+	salaryIntervalRes = []struct {
+		interval string
+		re       *regexp.Regexp
+	}{
+		{"hour", regexp.MustCompile(`(?i)/\s*h(ou)?r\b|per hour|hourly`)},
+		{"month", regexp.MustCompile(`(?i)/\s*mo(nth)?\b|per month|monthly`)},
+	}
+)
+
+// hasCompPreferences reports whether the profile sets any compensation preference.
+func (p *UserProfile) hasCompPreferences() bool {
+	return p.SalaryFloor > 0 || p.TargetComp > 0 || p.EquityPreference == EquityRequired
+}
+
+// compCurrency is the currency of SalaryFloor and TargetComp (default USD).
+func (p *UserProfile) compCurrency() string {
+	if p.SalaryCurrency == "" {
+		return "USD"
+	}
+	return strings.ToUpper(p.SalaryCurrency)
+}
+
+// CompFit rates an annual salary in the profile currency against the floor and target.
+// Returns "" when the profile sets neither.
+func (p *UserProfile) CompFit(annual int) string {
+	switch {
+	case p.SalaryFloor > 0 && annual < p.SalaryFloor:
+		return CompBelowFloor
+	case p.TargetComp > 0 && annual < p.TargetComp:
+		return CompBelowTarget
+	case p.SalaryFloor > 0 || p.TargetComp > 0:
+		return CompMeetsTarget
+	}
+	return ""
+}
+
+// parseSalaryRange reads a free-text salary ("$80k - $120k", "€50-60k/yr", "$50/hour")
+// into its range, currency and pay interval ("year", "month" or "hour"). A trailing
+// "k" also applies to bare numbers before it, so "50-60k" is 50,000-60,000. ok is
+// false when no amount is found.
+func parseSalaryRange(s string) (lo, hi int, currency, interval string, ok bool) {
+	var nums []float64
+	thousands := false
+	for _, m := range salaryTextRe.FindAllStringSubmatch(s, -1) {
+		v, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+		if err != nil || v <= 0 {
+			continue
+		}
+		if m[2] != "" {
+			v *= 1000
+			thousands = true
+		}
+		nums = append(nums, v)
+	}
+	if len(nums) == 0 {
+		return 0, 0, "", "", false
+	}
+	for i, v := range nums {
+		if thousands && v < 1000 {
+			nums[i] = v * 1000
+		}
+	}
+	low, top := nums[0], nums[0]
+	for _, v := range nums[1:] {
+		low, top = min(low, v), max(top, v)
+	}
+	for _, c := range salaryCurRes {
+		if c.re.MatchString(s) {
+			currency = c.cur
+			break
+		}
+	}
+	interval = "year"
+	for _, iv := range salaryIntervalRes {
+		if iv.re.MatchString(s) {
+			interval = iv.interval
+			break
+		}
+	}
+	return int(low), int(top), currency, interval, true
+}
+
+// parseSalaryText reads the top of a free-text salary as an annual amount with its
+// currency. ok is false when no amount is found.
+func parseSalaryText(s string) (annual int, currency string, ok bool) {
+	_, top, currency, interval, ok := parseSalaryRange(s)
+	if !ok {
+		return 0, "", false
+	}
+	return *annualize(&top, interval), currency, true
+}
+
+// normalizeSalaryText converts a free-text salary to annual figures, or nil when it
+// has no amount.
+func normalizeSalaryText(s string) *engine.NormalizedSalary {
+	lo, hi, currency, interval, ok := parseSalaryRange(s)
+	if !ok {
+		return nil
+	}
+	n := &engine.NormalizedSalary{AnnualMax: annualize(&hi, interval), Currency: currency}
+	if lo < hi {
+		n.AnnualMin = annualize(&lo, interval)
+	}
+	return n
+}
+
+// listingCompFit rates one listing; "" when its pay is unknown or in another currency.
+func listingCompFit(j engine.JobListing, p *UserProfile) string {
+	annual, currency := annualSalaryMax(j), j.SalaryCurrency
+	if annual == 0 {
+		var ok bool
+		if annual, currency, ok = parseSalaryText(j.Salary); !ok {
+			return ""
+		}
+	}
+	if !strings.EqualFold(currency, p.compCurrency()) {
+		return ""
+	}
+	return p.CompFit(annual)
+}
+
+// ApplyCompPreferences annotates listings with scores.comp_fit and scores.equity and,
+// unless keepBelowFloor, drops those below the salary floor or lacking required equity.
+func ApplyCompPreferences(ctx context.Context, listings []engine.JobListing, p *UserProfile, keepBelowFloor bool) (kept []engine.JobListing, dropped int) {
+	if !p.hasCompPreferences() {
+		return listings, 0
+	}
+	kept = listings[:0:0]
+	for _, j := range listings {
+		fit := listingCompFit(j, compProfileFor(ctx, j, p))
+		equity := equityRe.MatchString(j.Title + "\n" + j.Salary + "\n" + j.Description)
+		if !keepBelowFloor && (fit == CompBelowFloor || (p.EquityPreference == EquityRequired && !equity)) {
+			dropped++
+			continue
+		}
+		if j.Scores == nil {
+			j.Scores = &engine.ListingScores{}
+		}
+		j.Scores.CompFit, j.Scores.Equity = fit, equity
+		kept = append(kept, j)
+	}
+	return kept, dropped
+}
+
+// ApplyRemoteCompPreferences is ApplyCompPreferences for remote_work_search listings,
+// which only carry a salary string. Equity is not checked: there is no description.
+func ApplyRemoteCompPreferences(listings []engine.RemoteJobListing, p *UserProfile, keepBelowFloor bool) (kept []engine.RemoteJobListing, dropped int) {
+	if p.SalaryFloor <= 0 && p.TargetComp <= 0 {
+		return listings, 0
+	}
+	kept = listings[:0:0]
+	for _, j := range listings {
+		fit := ""
+		if annual, currency, ok := parseSalaryText(j.Salary); ok && strings.EqualFold(currency, p.compCurrency()) {
+			fit = p.CompFit(annual)
+		}
+		if fit == CompBelowFloor && !keepBelowFloor {
+			dropped++
+			continue
+		}
+		j.CompFit = fit
+		kept = append(kept, j)
+	}
+	return kept, dropped
+}
+
+// CompDroppedNote is the summary note for listings dropped by compensation preferences.
+func CompDroppedNote(dropped int, p *UserProfile) string {
+	if dropped == 0 {
+		return ""
+	}
+	reason := fmt.Sprintf("below your salary floor (%d %s/yr)", p.SalaryFloor, p.compCurrency())
+	if p.EquityPreference == EquityRequired {
+		reason += " or without equity"
+	}
+	return fmt.Sprintf(" Hid %d listing(s) %s; set keep_below_floor=true to see them.", dropped, reason)
+}
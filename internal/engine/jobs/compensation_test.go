@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestApplyCompPreferences(t *testing.T) {
+	lo, hi := 90000, 110000
+	listings := []engine.JobListing{
+		{Title: "Junior Go", SalaryMax: &lo, SalaryInterval: "year", SalaryCurrency: "USD"},
+		{Title: "Go Engineer", SalaryMax: &hi, SalaryInterval: "year", SalaryCurrency: "USD", Description: "Generous stock options."},
+		{Title: "Contract Go", Salary: "$80/hour"},
+		{Title: "Berlin Go", Salary: "€50k", SalaryCurrency: ""},
+	}
+	p := &UserProfile{SalaryFloor: 100000, TargetComp: 150000}
+
+	kept, dropped := ApplyCompPreferences(context.Background(), listings, p, false)
+	if dropped != 1 || len(kept) != 3 {
+		t.Fatalf("kept %d, dropped %d; want 3, 1", len(kept), dropped)
+	}
+	if kept[0].Scores.CompFit != CompBelowTarget || !kept[0].Scores.Equity {
+		t.Errorf("Go Engineer scores = %+v", kept[0].Scores)
+	}
+	if kept[1].Scores.CompFit != CompMeetsTarget {
+		t.Errorf("$80/hour should annualize above target, got %q", kept[1].Scores.CompFit)
+	}
+	if kept[2].Scores.CompFit != "" {
+		t.Errorf("EUR salary should not be compared to a USD floor, got %q", kept[2].Scores.CompFit)
+	}
+
+	kept, _ = ApplyCompPreferences(context.Background(), listings, p, true)
+	if len(kept) != 4 || kept[0].Scores.CompFit != CompBelowFloor {
+		t.Errorf("keep_below_floor should keep and mark the listing, got %+v", kept[0].Scores)
+	}
+
+	remote, dropped := ApplyRemoteCompPreferences([]engine.RemoteJobListing{{Title: "A", Salary: "$60k - $80k"}, {Title: "B", Salary: "not specified"}}, p, false)
+	if dropped != 1 || len(remote) != 1 || remote[0].Title != "B" {
+		t.Errorf("remote = %+v, dropped %d", remote, dropped)
+	}
+}
@@ -0,0 +1,214 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Behavioral competency coverage ---
+
+// Maps the STAR story bank against a competency framework: the standard one below
+// (the story bank's own tags) or one extracted from a company's values page, and
+// suggests enrichment questions for competencies no story supports.
+
+// standardCompetencies describes each of StarCompetencies, with questions that surface
+// a missing story.
+var standardCompetencies = map[string]struct {
+	description string
+	questions   []string
+}{
+	"leadership":     {"Setting direction and getting others to follow it", []string{"When did you lead a team or initiative without being asked to?", "What decision did you make that the team disagreed with at first, and how did it play out?"}},
+	"conflict":       {"Resolving disagreements constructively", []string{"Describe a technical disagreement with a peer or manager and how you resolved it.", "When did you have to push back on a stakeholder's request?"}},
+	"scale":          {"Growing systems, teams or impact by an order of magnitude", []string{"What system did you scale, and what broke first?", "Which project of yours had the largest reach (users, traffic, revenue)?"}},
+	"failure":        {"Owning a setback and learning from it", []string{"Tell me about a project that failed or missed its goal. What would you do differently?", "What mistake of yours reached production, and what did you change afterwards?"}},
+	"ownership":      {"Taking responsibility end to end, beyond the assigned scope", []string{"When did you fix something outside your area because nobody else would?", "What did you own from idea to production?"}},
+	"collaboration":  {"Working across teams and functions", []string{"Describe a project that depended on another team. How did you keep it on track?", "When did you help a colleague succeed at the expense of your own work?"}},
+	"influence":      {"Persuading without authority", []string{"How did you get buy-in for a change you proposed?", "When did you change someone's mind with data?"}},
+	"ambiguity":      {"Making progress when goals or requirements are unclear", []string{"Describe a project that started with vague requirements. How did you scope it?", "When did you have to make a call without enough information?"}},
+	"customer_focus": {"Starting from the user's problem", []string{"When did customer feedback change what you built?", "What did you do to understand a user problem first-hand?"}},
+	"innovation":     {"Introducing new approaches that stuck", []string{"What new tool, process or technique did you introduce, and what changed?", "What problem did you solve in an unconventional way?"}},
+	"mentoring":      {"Growing other people", []string{"Who did you mentor, and how did they grow?", "How did you onboard a new team member?"}},
+	"delivery":       {"Shipping on time under constraints", []string{"Tell me about a tight deadline you met. What did you cut?", "When did you have to deliver with fewer people or less time than planned?"}},
+}
+
+// CompetencyRow is one competency of the coverage matrix.
+type CompetencyRow struct {
+	Competency  string   `json:"competency"`
+	Description string   `json:"description,omitempty"`
+	StoryIDs    []int    `json:"story_ids"`
+	Stories     []string `json:"stories"`  // story titles
+	Strength    string   `json:"strength"` // "none", "thin" (one story), "good"
+	// EnrichmentQuestions help recall experience for a story, when none supports it.
+	EnrichmentQuestions []string `json:"enrichment_questions,omitempty"`
+}
+
+// CompetencyCoverageResult is the structured output of competency_coverage.
+type CompetencyCoverageResult struct {
+	Framework string          `json:"framework"` // "standard" or the company name
+	Source    string          `json:"source,omitempty"`
+	Matrix    []CompetencyRow `json:"matrix"`
+	Gaps      []string        `json:"gaps"`
+	Coverage  float64         `json:"coverage"` // share of competencies with at least one story
+	Stories   int             `json:"stories"`
+}
+
+const companyCompetenciesPrompt = `You are an interview coach. Below is a company's values or leadership principles page, and a candidate's STAR story bank.
+
+COMPANY: %s
+
+VALUES PAGE:
+%s
+
+STORY BANK:
+%s
+
+1. Extract the 4-12 behavioral competencies or values the company interviews for, named as the company names them.
+2. For each, list the IDs of stories that genuinely demonstrate it (a story may support several; do not stretch).
+3. For each competency with no supporting story, write 2 questions that would help the candidate recall a real experience for it.
+
+Return a JSON object with this exact structure:
+{
+  "competencies": [
+    {"competency": "<name>", "description": "<one sentence>", "story_ids": [<ids>], "enrichment_questions": ["<question>"]}
+  ]
+}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+// AnalyzeCompetencyCoverage maps the story bank against the standard framework, or
+// against the competencies on company's values page (valuesURL, else found by search).
+func AnalyzeCompetencyCoverage(ctx context.Context, company, valuesURL string) (*CompetencyCoverageResult, error) {
+	bank, err := StarStories(ctx, false, "")
+	if err != nil {
+		return nil, err
+	}
+	if company == "" && valuesURL == "" {
+		return standardCoverage(bank.Stories), nil
+	}
+
+	source, values, err := companyValuesText(ctx, company, valuesURL)
+	if err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	for _, s := range bank.Stories {
+		fmt.Fprintf(&sb, "[%d] %s [%s]: %s %s\n", s.ID, s.Title, strings.Join(s.Competencies, ", "),
+			engine.TruncateRunes(s.Action, 200, "..."), engine.TruncateRunes(s.Result, 150, "..."))
+	}
+	if company == "" {
+		company = source
+	}
+	prompt := fmt.Sprintf(companyCompetenciesPrompt, company, engine.TruncateRunes(values, 5000, ""), sb.String())
+	raw, err := engine.CallLLM(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("competency_coverage LLM: %w", err)
+	}
+	var gen struct {
+		Competencies []CompetencyRow `json:"competencies"`
+	}
+	if err := json.Unmarshal([]byte(StripMarkdownFences(raw)), &gen); err != nil {
+		return nil, fmt.Errorf("competency_coverage parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+	}
+	if len(gen.Competencies) == 0 {
+		return nil, fmt.Errorf("competency_coverage: no competencies found on %s", source)
+	}
+	result := buildCoverage(company, gen.Competencies, bank.Stories)
+	result.Source = source
+	return result, nil
+}
+
+// companyValuesText fetches the values page, or searches for one.
+func companyValuesText(ctx context.Context, company, valuesURL string) (source, text string, err error) {
+	if valuesURL != "" {
+		_, text, err = engine.FetchURLContent(ctx, valuesURL)
+		if err != nil {
+			return "", "", fmt.Errorf("fetch values page: %w", err)
+		}
+		return valuesURL, text, nil
+	}
+	results, err := engine.SearchSearXNG(ctx, company+" company values OR leadership principles OR culture", "all", "", engine.DefaultSearchEngine)
+	if err != nil {
+		return "", "", fmt.Errorf("competency_coverage: search values: %w", err)
+	}
+	if len(results) == 0 {
+		return "", "", fmt.Errorf("competency_coverage: no values page found for %q", company)
+	}
+	var snippets []string
+	for _, r := range results {
+		snippets = append(snippets, r.Title+"\n"+r.Content)
+	}
+	text = strings.Join(snippets, "\n\n")
+	if _, page, err := engine.FetchURLContent(ctx, results[0].URL); err != nil {
+		slog.Debug("competency_coverage: values page fetch failed, using snippets", slog.Any("error", err))
+	} else if page != "" {
+		text = page + "\n\n" + text
+	}
+	return results[0].URL, text, nil
+}
+
+// standardCoverage builds the matrix for the standard framework from the stories'
+// competency tags.
+func standardCoverage(stories []StarStoryRecord) *CompetencyCoverageResult {
+	rows := make([]CompetencyRow, 0, len(StarCompetencies))
+	for _, c := range StarCompetencies {
+		row := CompetencyRow{Competency: c, Description: standardCompetencies[c].description}
+		for _, s := range stories {
+			if containsString(s.Competencies, c) {
+				row.StoryIDs = append(row.StoryIDs, s.ID)
+			}
+		}
+		rows = append(rows, row)
+	}
+	result := buildCoverage("standard", rows, stories)
+	for i := range result.Matrix {
+		if row := &result.Matrix[i]; row.Strength == "none" {
+			row.EnrichmentQuestions = standardCompetencies[row.Competency].questions
+		}
+	}
+	return result
+}
+
+// buildCoverage fills story titles and strength for rows whose story IDs are set,
+// dropping IDs not in the bank, and collects the gaps.
+func buildCoverage(framework string, rows []CompetencyRow, stories []StarStoryRecord) *CompetencyCoverageResult {
+	titles := make(map[int]string, len(stories))
+	for _, s := range stories {
+		titles[s.ID] = s.Title
+	}
+	result := &CompetencyCoverageResult{Framework: framework, Gaps: []string{}, Stories: len(stories)}
+	covered := 0
+	for _, row := range rows {
+		ids := []int{}
+		row.Stories = []string{}
+		for _, id := range row.StoryIDs {
+			if t, ok := titles[id]; ok {
+				ids = append(ids, id)
+				row.Stories = append(row.Stories, t)
+			}
+		}
+		row.StoryIDs = ids
+		switch len(ids) {
+		case 0:
+			row.Strength = "none"
+			result.Gaps = append(result.Gaps, row.Competency)
+		case 1:
+			row.Strength = "thin"
+			row.EnrichmentQuestions = nil
+			covered++
+		default:
+			row.Strength = "good"
+			row.EnrichmentQuestions = nil
+			covered++
+		}
+		result.Matrix = append(result.Matrix, row)
+	}
+	if len(rows) > 0 {
+		result.Coverage = float64(covered*100/len(rows)) / 100
+	}
+	return result
+}
@@ -0,0 +1,142 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Company-specific cover letters ---
+
+// With a company, cover_letter_generate lists the facts of its cached company_research
+// (news, culture, stack, overview) in the prompt and requires the letter to reference
+// one. The LLM returns the fact it used and the sentence that uses it; the sentence must
+// appear in the letter, else the letter is generated once more.
+
+// CompanyReference is the company-specific fact a cover letter references.
+type CompanyReference struct {
+	Fact         string `json:"fact"`
+	Source       string `json:"source"` // company_research field: recent_news, culture_notes, tech_stack, summary
+	Sentence     string `json:"sentence"`
+	Website      string `json:"website,omitempty"`
+	ResearchedAt string `json:"researched_at"` // YYYY-MM-DD
+}
+
+// coverLetterFact is one numbered company fact offered to the LLM.
+type coverLetterFact struct {
+	ID     string
+	Source string
+	Text   string
+}
+
+const coverLetterCompanyFormat = `
+COMPANY FACTS (from research on %s):
+%s
+Reference at least one of these facts concretely in the body of the letter — why it matters
+to you or how your experience connects to it. Do not invent company facts beyond this list.%s
+
+Return a JSON object with this exact structure:
+{
+  "cover_letter": "<the full cover letter text, paragraphs separated by \n\n>",
+  "fact_id": "<ID of the fact you referenced, e.g. F1>",
+  "sentence": "<the sentence of the letter that references it, copied verbatim>"
+}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+const coverLetterRetryNote = `
+Your previous letter did not reference a company fact verbatim. This time one sentence
+must clearly use a listed fact, and "sentence" must be copied exactly from the letter.`
+
+// coverLetterCompanyFacts numbers the usable facts of a company_research result.
+func coverLetterCompanyFacts(res *CompanyResearchResult) []coverLetterFact {
+	var facts []coverLetterFact
+	add := func(source, text string) {
+		if text = strings.TrimSpace(text); text != "" {
+			facts = append(facts, coverLetterFact{ID: fmt.Sprintf("F%d", len(facts)+1), Source: source, Text: text})
+		}
+	}
+	for _, n := range res.RecentNews {
+		add("recent_news", n)
+	}
+	add("culture_notes", res.CultureNotes)
+	if len(res.TechStack) > 0 {
+		add("tech_stack", "Uses "+strings.Join(res.TechStack, ", "))
+	}
+	add("summary", res.Summary)
+	return facts
+}
+
+// generateCompanyCoverLetter writes a cover letter that references a cached company
+// fact. Without cached research, or when the letter still lacks a verified reference
+// after a retry, the letter is returned with a company_note saying so.
+func generateCompanyCoverLetter(ctx context.Context, resume, jd, tone, availability, company string) (*CoverLetterResult, error) {
+	res, researchedAt := cachedCompanyResearch(ctx, company)
+	var facts []coverLetterFact
+	if res != nil {
+		facts = coverLetterCompanyFacts(res)
+	}
+	if len(facts) == 0 {
+		result, err := GenerateCoverLetter(ctx, resume, jd, tone, "")
+		if err != nil {
+			return nil, err
+		}
+		result.CompanyNote = fmt.Sprintf("No cached company_research for %s — run company_research first for a company-specific letter.", company)
+		return result, nil
+	}
+
+	var factList strings.Builder
+	for _, f := range facts {
+		fmt.Fprintf(&factList, "%s [%s]: %s\n", f.ID, f.Source, engine.TruncateRunes(f.Text, 400, "..."))
+	}
+	var result *CoverLetterResult
+	for attempt := 0; attempt < 2; attempt++ {
+		retry := ""
+		if attempt > 0 {
+			retry = coverLetterRetryNote
+		}
+		format := fmt.Sprintf(coverLetterCompanyFormat, company, factList.String(), retry)
+		raw, err := engine.CallLLM(ctx, fmt.Sprintf(coverLetterPrompt, tone, resume, jd, availability, format))
+		if err != nil {
+			return nil, fmt.Errorf("cover_letter_generate LLM: %w", err)
+		}
+		var out struct {
+			CoverLetter string `json:"cover_letter"`
+			FactID      string `json:"fact_id"`
+			Sentence    string `json:"sentence"`
+		}
+		if err := json.Unmarshal([]byte(StripMarkdownFences(raw)), &out); err != nil {
+			return nil, fmt.Errorf("cover_letter_generate parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+		}
+		letter := strings.TrimSpace(out.CoverLetter)
+		result = &CoverLetterResult{CoverLetter: letter, WordCount: len(strings.Fields(letter)), Tone: tone}
+		if ref := verifyCompanyReference(facts, letter, out.FactID, out.Sentence); ref != nil {
+			ref.Website = res.Website
+			ref.ResearchedAt = researchedAt.Format(time.DateOnly)
+			result.CompanyReference = ref
+			return result, nil
+		}
+	}
+	result.CompanyNote = "The letter has no verified reference to the company research; add one before sending."
+	return result, nil
+}
+
+// verifyCompanyReference returns the reference when the fact ID is one of the facts and
+// the sentence appears in the letter, ignoring case and whitespace.
+func verifyCompanyReference(facts []coverLetterFact, letter, factID, sentence string) *CompanyReference {
+	norm := func(s string) string { return strings.ToLower(strings.Join(strings.Fields(s), " ")) }
+	sentence = strings.TrimSpace(sentence)
+	if sentence == "" || !strings.Contains(norm(letter), norm(sentence)) {
+		return nil
+	}
+	for _, f := range facts {
+		if strings.EqualFold(strings.TrimSpace(factID), f.ID) {
+			return &CompanyReference{Fact: f.Text, Source: f.Source, Sentence: sentence}
+		}
+	}
+	return nil
+}
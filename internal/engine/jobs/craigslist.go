@@ -95,14 +95,14 @@ func fetchCraigslistRSS(ctx context.Context, query, location string, limit int)
 	feedURL := fmt.Sprintf("https://%s.craigslist.org/search/jjj?query=%s&format=rss",
 		region, url.QueryEscape(query))
 
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	headers := engine.ChromeHeaders()
 	headers["accept"] = "application/rss+xml, application/xml, text/xml"
 
 	data, err := engine.RetryDo(ctx, engine.DefaultRetryConfig, func() ([]byte, error) {
-		d, _, status, e := engine.Cfg.BrowserClient.Do("GET", feedURL, headers, nil)
+		d, _, status, e := engine.ConfigFrom(ctx).BrowserClient.Do("GET", feedURL, headers, nil)
 		if e != nil {
 			return nil, e
 		}
@@ -164,9 +164,9 @@ func parseCraigslistRSS(body []byte, limit int) ([]engine.SearxngResult, error)
 // Primary: RSS feed via BrowserClient (structured data, more results).
 // Fallback: SearXNG site: search when BrowserClient is unavailable or RSS fails.
 func SearchCraigslistJobs(ctx context.Context, query, location string, limit int) ([]engine.SearxngResult, error) {
-	engine.IncrCraigslistRequests()
+	engine.IncrCraigslistRequests(ctx)
 
-	if engine.Cfg.BrowserClient != nil {
+	if engine.ConfigFrom(ctx).BrowserClient != nil {
 		results, err := fetchCraigslistRSS(ctx, query, location, limit)
 		if err != nil {
 			slog.Warn("craigslist: RSS fetch failed, falling back to SearXNG",
@@ -0,0 +1,138 @@
+package jobs
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// urgentWindow is how close a deadline must be to flag a listing as urgent.
+const urgentWindow = 7 * 24 * time.Hour
+
+// followUpLead is how long before a deadline the tracker schedules a follow-up.
+const followUpLead = 3 * 24 * time.Hour
+
+// Deadline kinds.
+const (
+	DeadlineApplication = "application"
+	DeadlineVisaLottery = "visa_lottery"
+)
+
+// Deadline is an explicit date extracted from a job description.
+type Deadline struct {
+	Date     time.Time `json:"date"`
+	Kind     string    `json:"kind"`     // "application" or "visa_lottery"
+	Evidence string    `json:"evidence"` // the matched phrase
+}
+
+const monthNames = `jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?`
+
+var (
+	deadlineTriggerRe = regexp.MustCompile(`(?i)(apply (by|before|until)|application deadline|deadline|applications? (close|closes|due|accepted until)|closing date|no later than|submit (your )?application by|registration (period )?(ends|closes)|(h-?1b|visa) (lottery|registration))`)
+	visaLotteryRe     = regexp.MustCompile(`(?i)(h-?1b|visa|lottery|registration)`)
+	deadlineDateRe    = regexp.MustCompile(`(?i)\b(\d{4}-\d{2}-\d{2}|\d{1,2}/\d{1,2}/\d{4}|(?:` + monthNames + `)\.? \d{1,2}(?:st|nd|rd|th)?(?:,? \d{4})?|\d{1,2}(?:st|nd|rd|th)? (?:` + monthNames + `)\.?(?:,? \d{4})?)\b`)
+	ordinalRe         = regexp.MustCompile(`(?i)(\d)(st|nd|rd|th)\b`)
+)
+
+// ExtractDeadline finds the first explicit deadline ("apply by March 15", "deadline: 2025-04-01")
+// in text. Dates without a year resolve to their next occurrence after now.
+func ExtractDeadline(text string, now time.Time) (Deadline, bool) {
+	for _, loc := range deadlineTriggerRe.FindAllStringIndex(text, -1) {
+		// The date must follow the trigger closely, within the same sentence.
+		window := text[loc[1]:min(len(text), loc[1]+60)]
+		if i := strings.IndexAny(window, "\n"); i >= 0 {
+			window = window[:i]
+		}
+		m := deadlineDateRe.FindStringIndex(window)
+		if m == nil {
+			continue
+		}
+		date, ok := parseDeadlineDate(window[m[0]:m[1]], now)
+		if !ok {
+			continue
+		}
+		kind := DeadlineApplication
+		if visaLotteryRe.MatchString(text[loc[0]:loc[1]]) {
+			kind = DeadlineVisaLottery
+		}
+		return Deadline{
+			Date:     date,
+			Kind:     kind,
+			Evidence: strings.TrimSpace(text[loc[0] : loc[1]+m[1]]),
+		}, true
+	}
+	return Deadline{}, false
+}
+
+func parseDeadlineDate(s string, now time.Time) (time.Time, bool) {
+	s = ordinalRe.ReplaceAllString(strings.TrimSpace(s), "$1")
+	s = strings.ReplaceAll(strings.ReplaceAll(s, ",", ""), ".", "")
+	// Shorten month names to the 3-letter form time.Parse expects ("September" → "Sep").
+	fields := strings.Fields(s)
+	for i, f := range fields {
+		if len(f) > 3 && unicode.IsLetter(rune(f[0])) {
+			fields[i] = f[:3]
+		}
+	}
+	s = strings.Join(fields, " ")
+
+	for _, layout := range []string{time.DateOnly, "1/2/2006", "Jan 2 2006", "2 Jan 2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	for _, layout := range []string{"Jan 2", "2 Jan"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			t = t.AddDate(now.Year(), 0, 0)
+			if t.Before(now.AddDate(0, 0, -1)) {
+				t = t.AddDate(1, 0, 0)
+			}
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// AnnotateDeadlines extracts deadlines from listing descriptions and flags those due within a week.
+func AnnotateDeadlines(listings []engine.JobListing, now time.Time) {
+	for i := range listings {
+		j := &listings[i]
+		d, ok := ExtractDeadline(j.Description, now)
+		if !ok {
+			continue
+		}
+		j.Deadline = d.Date.Format(time.DateOnly)
+		j.DeadlineKind = d.Kind
+		until := d.Date.Add(24 * time.Hour).Sub(now)
+		j.DeadlineUrgent = until > 0 && until <= urgentWindow
+	}
+}
+
+// SortByDeadline orders listings by soonest deadline; listings without one keep their order at the end.
+func SortByDeadline(listings []engine.JobListing) {
+	sort.SliceStable(listings, func(a, b int) bool {
+		da, db := listings[a].Deadline, listings[b].Deadline
+		switch {
+		case da == "":
+			return false
+		case db == "":
+			return true
+		default:
+			return da < db
+		}
+	})
+}
+
+// FollowUpForDeadline returns when the tracker should remind about a deadline:
+// followUpLead before it, but never earlier than now.
+func FollowUpForDeadline(deadline, now time.Time) time.Time {
+	at := deadline.Add(-followUpLead)
+	if at.Before(now) {
+		return now
+	}
+	return at
+}
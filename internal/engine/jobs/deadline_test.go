@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestExtractDeadline(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		text string
+		want string
+		kind string
+	}{
+		{"Please apply by March 15th, 2025 to be considered.", "2025-03-15", DeadlineApplication},
+		{"Application deadline: 2025-04-01", "2025-04-01", DeadlineApplication},
+		{"Applications close 20 April.", "2025-04-20", DeadlineApplication},
+		{"Closing date: Jan 10", "2026-01-10", DeadlineApplication},
+		{"We sponsor: H-1B registration by March 20.", "2025-03-20", DeadlineVisaLottery},
+	}
+	for _, tt := range tests {
+		d, ok := ExtractDeadline(tt.text, now)
+		if !ok {
+			t.Errorf("ExtractDeadline(%q) found nothing", tt.text)
+			continue
+		}
+		if got := d.Date.Format(time.DateOnly); got != tt.want || d.Kind != tt.kind {
+			t.Errorf("ExtractDeadline(%q) = %s/%s, want %s/%s", tt.text, got, d.Kind, tt.want, tt.kind)
+		}
+	}
+
+	if _, ok := ExtractDeadline("We move fast and ship daily. Founded March 2019.", now); ok {
+		t.Error("expected no deadline without a trigger phrase")
+	}
+}
+
+func TestAnnotateAndSortDeadlines(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	listings := []engine.JobListing{
+		{Title: "none", Description: "Great team."},
+		{Title: "later", Description: "Apply by 2025-05-01."},
+		{Title: "soon", Description: "Apply by March 5."},
+	}
+	AnnotateDeadlines(listings, now)
+	SortByDeadline(listings)
+
+	if listings[0].Title != "soon" || listings[1].Title != "later" || listings[2].Title != "none" {
+		t.Fatalf("unexpected order: %s, %s, %s", listings[0].Title, listings[1].Title, listings[2].Title)
+	}
+	if !listings[0].DeadlineUrgent || listings[1].DeadlineUrgent {
+		t.Errorf("urgent flags = %v/%v, want true/false", listings[0].DeadlineUrgent, listings[1].DeadlineUrgent)
+	}
+}
@@ -140,12 +140,3 @@ func CosineSimilarity(a, b []float32) float32 {
 	}
 	return float32(dot / denom)
 }
-
-// Package-level embed client, set by SetEmbedClient.
-var embedClient *EmbedClient
-
-// SetEmbedClient sets the package-level embed client.
-func SetEmbedClient(c *EmbedClient) { embedClient = c }
-
-// GetEmbedClient returns the package-level embed client (nil if not configured).
-func GetEmbedClient() *EmbedClient { return embedClient }
@@ -0,0 +1,105 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Executive search: job_search executive=true targets director+ roles on LinkedIn
+// (f_E director/executive) and the executive boards ExecThread, BlueSteps and The
+// Ladders, and drops listings below director. resume_generate switches to an executive
+// summary format for such roles; the tracker records retained-recruiter contacts and
+// keeps confidential searches out of exports.
+
+const (
+	executiveLevel       = "director"
+	executiveSiteSearch  = "site:execthread.com OR site:bluesteps.com OR site:theladders.com"
+	confidentialEmployer = "Confidential"
+)
+
+var (
+	executiveTitleRe = regexp.MustCompile(`(?i)\b(director|head of|[se]?vp|vice[- ]president|chief|c[efiotmpr]o|president|managing partner|general manager|managing director|geschäftsführer(?:in)?)\b`)
+	executiveBoardRe = regexp.MustCompile(`(?i)(execthread\.com|bluesteps\.com|theladders\.com)/`)
+)
+
+// executiveBoards names the executive boards by host.
+var executiveBoards = map[string]string{
+	"execthread.com": "ExecThread",
+	"bluesteps.com":  "BlueSteps",
+	"theladders.com": "The Ladders",
+}
+
+// ApplyExecutiveMode narrows a job_search input to director+ roles, searching the
+// executive platform unless another one was chosen.
+func ApplyExecutiveMode(input *engine.JobSearchInput) {
+	if input.Experience != "director" && input.Experience != "executive" {
+		input.Experience = executiveLevel
+	}
+	if input.Platform == "" {
+		input.Platform = "executive"
+	}
+}
+
+// IsExecutiveRole reports whether a role title or seniority is director level or above.
+// "Executive" counts only as a seniority: as a title it is usually a sales role.
+func IsExecutiveRole(seniority, title string) bool {
+	return strings.Contains(strings.ToLower(seniority), "executive") || executiveTitleRe.MatchString(seniority+"\n"+title)
+}
+
+// FilterExecutive keeps director+ listings, returning how many were dropped.
+func FilterExecutive(listings []engine.JobListing) ([]engine.JobListing, int) {
+	out := listings[:0:0]
+	for _, j := range listings {
+		if IsExecutiveRole(j.Experience, j.Title) {
+			out = append(out, j)
+		}
+	}
+	return out, len(listings) - len(out)
+}
+
+// ExecutiveDroppedNote is the summary suffix for FilterExecutive.
+func ExecutiveDroppedNote(dropped int) string {
+	if dropped == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" Hid %d listing(s) below director level; set executive=false to see them.", dropped)
+}
+
+// SearchExecutiveBoards finds ExecThread, BlueSteps and The Ladders job pages via
+// SearXNG; the boards are members-only and have no open API.
+func SearchExecutiveBoards(ctx context.Context, query, location string, limit int) ([]engine.SearxngResult, error) {
+	engine.IncrExecutiveRequests(ctx)
+	searxQuery := strings.Join(strings.Fields(query+" "+location+" "+executiveSiteSearch), " ")
+	searxResults, err := engine.SearchSearXNG(ctx, searxQuery, "all", "", engine.DefaultSearchEngine)
+	if err != nil {
+		return nil, fmt.Errorf("executive boards: %w", err)
+	}
+	var results []engine.SearxngResult
+	for _, r := range searxResults {
+		m := executiveBoardRe.FindStringSubmatch(r.URL)
+		if m == nil {
+			continue
+		}
+		r.Content = "**Source:** " + executiveBoards[strings.ToLower(m[1])] + "\n\n" + r.Content
+		r.Score = 0.9
+		results = append(results, r)
+		if len(results) >= limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// executiveGuidelines are the resume_generate formatting rules for director+ roles.
+const executiveGuidelines = `EXECUTIVE ROLE (director level or above) — use the executive format:
+- Open with an EXECUTIVE SUMMARY of 3-4 lines: leadership scope, industries, and the two or three largest business outcomes
+- Follow with 3-5 "Selected Achievements" leading with P&L, revenue, cost, headcount and transformation results
+- Under each role state scope first (team size, budget, reporting line), then strategic outcomes; leave out hands-on task detail
+- Summarize roles older than 15 years in one "Earlier career" line
+- Two pages are acceptable
+
+`
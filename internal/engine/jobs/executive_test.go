@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestIsExecutiveRole(t *testing.T) {
+	for _, title := range []string{"VP of Engineering", "Director, Platform", "CTO", "Head of Data", "SVP Sales", "Chief Product Officer", "Managing Director"} {
+		if !IsExecutiveRole("", title) {
+			t.Errorf("IsExecutiveRole(%q) = false", title)
+		}
+	}
+	for _, title := range []string{"Senior Backend Engineer", "Partner Engineer", "Engineering Manager", "Product Owner", "Account Executive"} {
+		if IsExecutiveRole("", title) {
+			t.Errorf("IsExecutiveRole(%q) = true", title)
+		}
+	}
+	if !IsExecutiveRole("Executive", "Technology Lead") {
+		t.Error("executive seniority not detected")
+	}
+}
+
+func TestFilterExecutive(t *testing.T) {
+	kept, dropped := FilterExecutive([]engine.JobListing{
+		{Title: "VP Engineering"},
+		{Title: "Staff Engineer"},
+		{Title: "Technology Lead", Experience: "director"},
+	})
+	if dropped != 1 || len(kept) != 2 {
+		t.Fatalf("kept %+v, dropped %d", kept, dropped)
+	}
+	if note := ExecutiveDroppedNote(dropped); !strings.Contains(note, "executive=false") {
+		t.Errorf("note = %q", note)
+	}
+}
+
+func TestApplyExecutiveMode(t *testing.T) {
+	input := engine.JobSearchInput{Experience: "mid-senior"}
+	ApplyExecutiveMode(&input)
+	if input.Experience != "director" || input.Platform != "executive" {
+		t.Errorf("input = %+v", input)
+	}
+	input = engine.JobSearchInput{Experience: "executive", Platform: "linkedin"}
+	ApplyExecutiveMode(&input)
+	if input.Experience != "executive" || input.Platform != "linkedin" {
+		t.Errorf("explicit choices overridden: %+v", input)
+	}
+}
@@ -0,0 +1,179 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Years of experience: the total is the union of the experience date ranges of the
+// master resume, per skill the union of the experiences the skill was used in (the
+// graph's USED_SKILL edges, directly or through a sub-project). Overlapping roles
+// count once. Figures are rounded to half years.
+
+// SkillYears is the years of experience with one skill.
+type SkillYears struct {
+	Skill    string  `json:"skill"`
+	Years    float64 `json:"years"`
+	LastUsed string  `json:"last_used,omitempty"` // YYYY-MM or "present"
+}
+
+// ExperienceYears is the computed years-of-experience view of the master resume.
+type ExperienceYears struct {
+	TotalYears float64         `json:"total_years"`
+	Skills     []SkillYears    `json:"skills"`                // most years first
+	Gaps       []EmploymentGap `json:"gaps,omitempty"`        // breaks of minGapMonths or more between roles
+	DateIssues []string        `json:"date_issues,omitempty"` // experiences left out: unreadable or inconsistent dates
+	Summary    string          `json:"summary"`
+}
+
+// minGapMonths is the shortest break between roles reported as a gap.
+const minGapMonths = 3
+
+// experienceMonths returns the months an experience covers as monthIndex values,
+// end month included; an ongoing role runs to now.
+func experienceMonths(e ExperienceRecord, now time.Time) (from, to int, ok bool) {
+	start, end, err := parseExperienceDates(e, now)
+	if err != nil {
+		return 0, 0, false
+	}
+	return monthIndex(start.Date), monthIndex(experienceLast(end, now)), true
+}
+
+// monthsToYears rounds a month count to half years.
+func monthsToYears(months int) float64 {
+	return math.Round(float64(months)/12*2) / 2
+}
+
+// computeExperienceYears builds the view from the experiences, skills and the skill
+// ID → experience IDs edges.
+func computeExperienceYears(exps []ExperienceRecord, skills []SkillRecord, skillExps map[int][]int, now time.Time) *ExperienceYears {
+	months := make(map[int][2]int, len(exps))
+	all := make(map[int]bool)
+	for _, e := range exps {
+		from, to, ok := experienceMonths(e, now)
+		if !ok {
+			continue
+		}
+		months[e.ID] = [2]int{from, to}
+		for m := from; m <= to; m++ {
+			all[m] = true
+		}
+	}
+	y := &ExperienceYears{TotalYears: monthsToYears(len(all))}
+	for _, s := range skills {
+		covered := make(map[int]bool)
+		for _, id := range skillExps[s.ID] {
+			if r, ok := months[id]; ok {
+				for m := r[0]; m <= r[1]; m++ {
+					covered[m] = true
+				}
+			}
+		}
+		if years := monthsToYears(len(covered)); years > 0 {
+			y.Skills = append(y.Skills, SkillYears{Skill: s.Name, Years: years, LastUsed: s.LastUsed})
+		}
+	}
+	sort.SliceStable(y.Skills, func(i, j int) bool {
+		if y.Skills[i].Years != y.Skills[j].Years {
+			return y.Skills[i].Years > y.Skills[j].Years
+		}
+		return y.Skills[i].Skill < y.Skills[j].Skill
+	})
+	y.Gaps = experienceGaps(exps, now, minGapMonths)
+	y.DateIssues = experienceDateIssues(exps, now)
+	y.Summary = y.Text(10)
+	if len(y.Gaps) > 0 {
+		y.Summary += fmt.Sprintf(" %d gap(s) of %d+ months between roles.", len(y.Gaps), minGapMonths)
+	}
+	return y
+}
+
+// Skill returns the years with a skill, matched by name or alias.
+func (y *ExperienceYears) Skill(name string) (SkillYears, bool) {
+	names := expandQueryAliases(strings.ToLower(strings.TrimSpace(name)))
+	for _, s := range y.Skills {
+		for _, n := range names {
+			if strings.EqualFold(s.Skill, n) {
+				return s, true
+			}
+		}
+	}
+	return SkillYears{}, false
+}
+
+// Years returns the number of years with a skill, matched by name or alias.
+func (y *ExperienceYears) Years(skill string) (float64, bool) {
+	s, ok := y.Skill(skill)
+	return s.Years, ok
+}
+
+// Text formats the total and the top skills, e.g. "Total: 9.5 years. Go: 6.5 years, ...".
+func (y *ExperienceYears) Text(limit int) string {
+	parts := make([]string, 0, min(limit, len(y.Skills)))
+	for i, s := range y.Skills {
+		if i == limit {
+			break
+		}
+		parts = append(parts, s.String())
+	}
+	text := "Total: " + formatYears(y.TotalYears) + "."
+	if len(parts) > 0 {
+		text += " " + strings.Join(parts, ", ") + "."
+	}
+	return text
+}
+
+// String formats the skill's years, e.g. "Go: 6.5 years".
+func (s SkillYears) String() string {
+	return s.Skill + ": " + formatYears(s.Years)
+}
+
+func formatYears(y float64) string {
+	if y == 1 {
+		return "1 year"
+	}
+	return fmt.Sprintf("%g years", y)
+}
+
+// MasterExperienceYears computes total and per-skill years of experience from the
+// master resume.
+func MasterExperienceYears(ctx context.Context) (*ExperienceYears, error) {
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return nil, errors.New("resume database not configured (set DATABASE_URL)")
+	}
+	personID := db.GetLatestPersonID(ctx)
+	if personID == 0 {
+		return nil, errors.New("no master resume found — run master_resume_build first")
+	}
+	exps, err := db.GetAllExperiences(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("experience_years: experiences: %w", err)
+	}
+	skills, err := db.GetAllSkills(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("experience_years: skills: %w", err)
+	}
+	skillExps, err := db.QuerySkillExperiences(ctx)
+	if err != nil {
+		// Totals still work without the graph.
+		slog.Debug("experience_years: graph query failed", slog.Any("error", err))
+	}
+	return computeExperienceYears(exps, skills, skillExps, time.Now()), nil
+}
+
+// experienceYearsContext is the years-of-experience block for screening-style
+// answers, or "" without a master resume.
+func experienceYearsContext(ctx context.Context) string {
+	y, err := MasterExperienceYears(ctx)
+	if err != nil || y.TotalYears == 0 {
+		return ""
+	}
+	return "\nCANDIDATE YEARS OF EXPERIENCE (computed from the master resume; use these figures when a question asks how many years):\n" + y.Text(20) + "\n"
+}
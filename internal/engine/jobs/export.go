@@ -0,0 +1,220 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// JobExportInput is the input for job_export.
+type JobExportInput struct {
+	Source           string `json:"source,omitempty" jsonschema:"What to export: tracker (default) or last_search (the most recent job_search results)"`
+	Status           string `json:"status,omitempty" jsonschema:"Tracker only: export just this status (saved, applied, interview, offer, rejected)"`
+	Format           string `json:"format,omitempty" jsonschema:"csv (default), xlsx, or notion (append rows to a Notion database; needs NOTION_TOKEN)"`
+	NotionDatabaseID string `json:"notion_database_id,omitempty" jsonschema:"Target Notion database ID when format=notion"`
+	// IncludeConfidential exports confidential tracker entries unmasked.
+	IncludeConfidential bool `json:"include_confidential,omitempty" jsonschema:"Tracker only: export the employer, URL and notes of confidential entries instead of masking them"`
+}
+
+// JobExportResult is the output of job_export.
+type JobExportResult struct {
+	Format   string   `json:"format"`
+	Rows     int      `json:"rows"`
+	Path     string   `json:"path,omitempty"`     // file written under ~/.go_job/exports
+	Data     string   `json:"data,omitempty"`     // CSV text, or base64 XLSX
+	Skipped  []string `json:"skipped,omitempty"`  // notion: columns with no matching database property
+	Failures []string `json:"failures,omitempty"` // notion: rows that could not be created
+}
+
+// exportColumns is the column order of every export format.
+var exportColumns = []string{"Title", "Company", "Status", "Location", "Salary", "URL", "Source", "Posted", "Deadline", "Follow up", "Notes", "Created", "Updated"}
+
+// exportRow is one job in export column order.
+type exportRow [13]string
+
+// --- last search snapshot ---
+
+var (
+	lastSearchMu    sync.Mutex
+	lastSearchQuery string
+	lastSearchJobs  []engine.JobListing
+)
+
+// SetLastSearch records the results of the most recent job_search for job_export.
+func SetLastSearch(query string, listings []engine.JobListing) {
+	lastSearchMu.Lock()
+	defer lastSearchMu.Unlock()
+	lastSearchQuery = query
+	lastSearchJobs = append([]engine.JobListing(nil), listings...)
+}
+
+// lastSearch returns the recorded job_search snapshot.
+func lastSearch() (string, []engine.JobListing) {
+	lastSearchMu.Lock()
+	defer lastSearchMu.Unlock()
+	return lastSearchQuery, lastSearchJobs
+}
+
+// ExportJobs exports tracked jobs or the last search snapshot as CSV, XLSX or Notion rows.
+func ExportJobs(ctx context.Context, input JobExportInput) (*JobExportResult, error) {
+	rows, name, err := exportRows(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("job_export: nothing to export from %s", name)
+	}
+
+	format := strings.ToLower(strings.TrimSpace(input.Format))
+	if format == "" {
+		format = "csv"
+	}
+	result := &JobExportResult{Format: format, Rows: len(rows)}
+
+	switch format {
+	case "csv":
+		data, err := encodeCSV(rows)
+		if err != nil {
+			return nil, fmt.Errorf("job_export: csv: %w", err)
+		}
+		result.Data = string(data)
+		result.Path, err = writeExportFile(name+".csv", data)
+		if err != nil {
+			return nil, err
+		}
+	case "xlsx":
+		data, err := encodeXLSX(rows)
+		if err != nil {
+			return nil, fmt.Errorf("job_export: xlsx: %w", err)
+		}
+		result.Data = base64.StdEncoding.EncodeToString(data)
+		result.Path, err = writeExportFile(name+".xlsx", data)
+		if err != nil {
+			return nil, err
+		}
+	case "notion":
+		if input.NotionDatabaseID == "" {
+			return nil, errors.New("job_export: notion_database_id is required for format=notion")
+		}
+		created, skipped, failures, err := pushNotionRows(ctx, input.NotionDatabaseID, rows)
+		if err != nil {
+			return nil, fmt.Errorf("job_export: notion: %w", err)
+		}
+		result.Rows, result.Skipped, result.Failures = created, skipped, failures
+	default:
+		return nil, fmt.Errorf("job_export: invalid format %q (valid: csv, xlsx, notion)", format)
+	}
+	return result, nil
+}
+
+// exportRows loads the rows to export and a base file name for them.
+func exportRows(input JobExportInput) ([]exportRow, string, error) {
+	switch strings.ToLower(strings.TrimSpace(input.Source)) {
+	case "", "tracker":
+		tracked, err := allTrackedJobs(input.Status)
+		if err != nil {
+			return nil, "", err
+		}
+		rows := make([]exportRow, 0, len(tracked))
+		for _, j := range tracked {
+			if j.Confidential && !input.IncludeConfidential {
+				j.Company, j.URL, j.Notes = confidentialEmployer, "", ""
+			}
+			rows = append(rows, exportRow{j.Title, j.Company, string(j.Status), j.Location, j.Salary, j.URL,
+				"tracker", "", j.Deadline, j.FollowUp, j.Notes, j.CreatedAt, j.UpdatedAt})
+		}
+		return rows, "tracker", nil
+	case "last_search":
+		query, listings := lastSearch()
+		rows := make([]exportRow, 0, len(listings))
+		for _, j := range listings {
+			rows = append(rows, exportRow{j.Title, j.Company, "", j.Location, j.Salary, j.URL,
+				j.Source, j.Posted, j.Deadline, "", "", "", ""})
+		}
+		return rows, "search_" + exportSlug(query), nil
+	default:
+		return nil, "", fmt.Errorf("job_export: invalid source %q (valid: tracker, last_search)", input.Source)
+	}
+}
+
+// allTrackedJobs returns every tracked job, optionally filtered by status.
+func allTrackedJobs(status string) ([]TrackedJob, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + trackedJobColumns + ` FROM jobs`
+	var args []any
+	if status != "" {
+		status = strings.ToLower(status)
+		if !validStatus(status) {
+			return nil, fmt.Errorf("job_export: invalid status %q", status)
+		}
+		query += ` WHERE status = ?`
+		args = append(args, status)
+	}
+	rows, err := db.Query(query+` ORDER BY updated_at DESC`, args...) //nolint:noctx // SQLite file-based tracker
+	if err != nil {
+		return nil, fmt.Errorf("job_export: query: %w", err)
+	}
+	defer rows.Close()
+	return scanTrackedJobs(rows), nil
+}
+
+func encodeCSV(rows []exportRow) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(exportColumns); err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		if err := w.Write(r[:]); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// writeExportFile saves data under ~/.go_job/exports with a timestamped name.
+func writeExportFile(name string, data []byte) (string, error) {
+	dir := filepath.Join(os.Getenv("HOME"), ".go_job", "exports")
+	if err := os.MkdirAll(dir, 0o750); err != nil { //nolint:gosec // path derived from HOME env var, not user input
+		return "", fmt.Errorf("job_export: mkdir %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, time.Now().UTC().Format("20060102-150405")+"_"+name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("job_export: write: %w", err)
+	}
+	return path, nil
+}
+
+// exportSlug turns a query into a short file-name-safe slug.
+func exportSlug(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+		if b.Len() >= 40 {
+			break
+		}
+	}
+	if out := strings.Trim(b.String(), "-"); out != "" {
+		return out
+	}
+	return "results"
+}
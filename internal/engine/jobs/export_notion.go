@@ -0,0 +1,133 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+const (
+	notionAPIBase = "https://api.notion.com/v1"
+	notionVersion = "2022-06-28"
+)
+
+// pushNotionRows appends rows to a Notion database. Columns are matched to database
+// properties by name (case-insensitive); Title always goes to the title property.
+// Returns the number of pages created, columns with no matching property, and
+// per-row failures.
+func pushNotionRows(ctx context.Context, databaseID string, rows []exportRow) (created int, skipped, failures []string, err error) {
+	if engine.ConfigFrom(ctx).NotionToken == "" {
+		return 0, nil, nil, errors.New("Notion token not configured (set NOTION_TOKEN)")
+	}
+
+	var db struct {
+		Properties map[string]struct {
+			Type string `json:"type"`
+		} `json:"properties"`
+	}
+	if err := notionRequest(ctx, http.MethodGet, "/databases/"+url.PathEscape(databaseID), nil, &db); err != nil {
+		return 0, nil, nil, fmt.Errorf("read database: %w", err)
+	}
+
+	// column index → (property name, property type)
+	type target struct{ name, typ string }
+	targets := make(map[int]target)
+	for col, colName := range exportColumns {
+		for propName, prop := range db.Properties {
+			if col == 0 && prop.Type == "title" || col > 0 && strings.EqualFold(propName, colName) && prop.Type != "title" {
+				targets[col] = target{propName, prop.Type}
+				break
+			}
+		}
+		if _, ok := targets[col]; !ok {
+			skipped = append(skipped, colName)
+		}
+	}
+
+	for _, r := range rows {
+		props := make(map[string]any)
+		for col, t := range targets {
+			if v := notionPropertyValue(t.typ, r[col]); v != nil {
+				props[t.name] = v
+			}
+		}
+		page := map[string]any{"parent": map[string]string{"database_id": databaseID}, "properties": props}
+		if err := notionRequest(ctx, http.MethodPost, "/pages", page, nil); err != nil {
+			failures = append(failures, fmt.Sprintf("%s at %s: %v", r[0], r[1], err))
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		created++
+	}
+	return created, skipped, failures, nil
+}
+
+// notionPropertyValue builds a property value of the given Notion type. Returns nil
+// for empty values and property types that cannot hold plain text.
+func notionPropertyValue(typ, v string) any {
+	if v == "" {
+		return nil
+	}
+	text := []map[string]any{{"text": map[string]string{"content": engine.TruncateRunes(v, 2000, "")}}}
+	switch typ {
+	case "title":
+		return map[string]any{"title": text}
+	case "rich_text":
+		return map[string]any{"rich_text": text}
+	case "url":
+		return map[string]any{"url": v}
+	case "select":
+		return map[string]any{"select": map[string]string{"name": v}}
+	case "status":
+		return map[string]any{"status": map[string]string{"name": v}}
+	case "date":
+		if len(v) < 10 {
+			return nil
+		}
+		return map[string]any{"date": map[string]string{"start": v[:10]}}
+	}
+	return nil
+}
+
+// notionRequest sends a JSON request to the Notion API and decodes the response into out.
+func notionRequest(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, notionAPIBase+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).NotionToken)
+	req.Header.Set("Notion-Version", notionVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // fixed Notion API URL
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,159 @@
+package jobs
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestEncodeCSV(t *testing.T) {
+	rows := []exportRow{{"Go Dev", "Acme, Inc.", "applied"}}
+	data, err := encodeCSV(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected header + 1 row, got %d", len(records))
+	}
+	if records[0][0] != "Title" || len(records[0]) != len(exportColumns) {
+		t.Errorf("unexpected header: %v", records[0])
+	}
+	if records[1][1] != "Acme, Inc." {
+		t.Errorf("company = %q", records[1][1])
+	}
+}
+
+func TestEncodeXLSX(t *testing.T) {
+	data, err := encodeXLSX([]exportRow{{"Go <Dev>", "Acme"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("not a zip: %v", err)
+	}
+	var sheet string
+	for _, f := range zr.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		sheet = string(b)
+	}
+	if sheet == "" {
+		t.Fatal("sheet1.xml missing")
+	}
+	for _, want := range []string{`r="A1"`, `r="M1"`, `r="B2"`, "Go &lt;Dev&gt;"} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %q", want)
+		}
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 12: "M", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestExportSlug(t *testing.T) {
+	cases := map[string]string{
+		"Senior Go Developer": "senior-go-developer",
+		"  C++ / Rust!  ":     "c-rust",
+		"Разработчик":         "results",
+	}
+	for in, want := range cases {
+		if got := exportSlug(in); got != want {
+			t.Errorf("exportSlug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestExportJobs_Tracker(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	for _, in := range []JobTrackerAddInput{
+		{Title: "Go Dev", Company: "Acme", Status: "applied"},
+		{Title: "Rust Dev", Company: "Initech"},
+	} {
+		if _, err := AddTrackedJob(ctx, in); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := ExportJobs(ctx, JobExportInput{Status: "applied"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Format != "csv" || result.Rows != 1 {
+		t.Errorf("got format=%s rows=%d, want csv/1", result.Format, result.Rows)
+	}
+	if !strings.Contains(result.Data, "Go Dev,Acme,applied") {
+		t.Errorf("unexpected CSV: %s", result.Data)
+	}
+	if _, err := os.Stat(result.Path); err != nil {
+		t.Errorf("export file not written: %v", err)
+	}
+
+	if _, err := ExportJobs(ctx, JobExportInput{Format: "pdf"}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestExportJobs_LastSearch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	SetLastSearch("go remote", []engine.JobListing{{Title: "Go Dev", Company: "Acme", Source: "linkedin"}})
+	t.Cleanup(func() { SetLastSearch("", nil) })
+
+	result, err := ExportJobs(context.Background(), JobExportInput{Source: "last_search", Format: "xlsx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Rows != 1 || !strings.Contains(result.Path, "search_go-remote.xlsx") {
+		t.Errorf("unexpected result: rows=%d path=%s", result.Rows, result.Path)
+	}
+}
+
+func TestRenderBookmarks(t *testing.T) {
+	rows := []exportRow{
+		{"Go Dev", "Acme", "", "Remote", "", "https://acme.com/1"},
+		{"SRE", "Initech", "", "", "", "https://initech.com/2?a=1&b=2"},
+		{"No URL", "Acme"},
+		{"Rust <Dev>", "Acme", "", "", "", "https://acme.com/3"},
+	}
+	page, jobs, companies := renderBookmarks("go_job: search", rows, time.Unix(1700000000, 0))
+	if jobs != 3 || companies != 2 {
+		t.Errorf("jobs=%d companies=%d, want 3/2", jobs, companies)
+	}
+	s := string(page)
+	if !strings.HasPrefix(s, "<!DOCTYPE NETSCAPE-Bookmark-file-1>") {
+		t.Error("missing Netscape doctype")
+	}
+	for _, want := range []string{`<H3 ADD_DATE="1700000000">Acme</H3>`, "Go Dev — Remote", "Rust &lt;Dev&gt;", `HREF="https://initech.com/2?a=1&amp;b=2"`} {
+		if !strings.Contains(s, want) {
+			t.Errorf("bookmarks missing %q", want)
+		}
+	}
+	if strings.Index(s, ">Acme<") > strings.Index(s, ">Initech<") {
+		t.Error("companies should keep first-seen order")
+	}
+}
@@ -0,0 +1,217 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Follow-up emails ---
+
+// Post-interview thank-you notes and status-inquiry emails for a tracked job, written
+// from its stage, the interviewers and discussion notes of its logged events, and the
+// elapsed time since the last contact.
+
+// Follow-up email types.
+const (
+	FollowupThankYou      = "thank_you"
+	FollowupStatusInquiry = "status_inquiry"
+)
+
+const (
+	thankYouWindowDays = 3 // a thank-you note is due only this soon after an interview
+	inquiryWaitDays    = 7 // wait this long after the last contact before asking for a status
+)
+
+// FollowupEmailInput is the input for followup_email_generate.
+type FollowupEmailInput struct {
+	ID           int64    `json:"id" jsonschema:"Tracker ID of the job (from job_tracker_list)"`
+	Type         string   `json:"type,omitempty" jsonschema:"thank_you or status_inquiry; default: thank_you within 3 days of a logged interview, else status_inquiry"`
+	Tone         string   `json:"tone,omitempty" jsonschema:"Email tone: professional (default), friendly, concise"`
+	Interviewers []string `json:"interviewers,omitempty" jsonschema:"Recipient names; default: the interviewers of the last logged interview"`
+	Notes        string   `json:"notes,omitempty" jsonschema:"Discussion points to reference, in addition to the last interview's event notes"`
+}
+
+// FollowupEmailResult is the structured output of followup_email_generate.
+type FollowupEmailResult struct {
+	ID         int64    `json:"id"`
+	Type       string   `json:"type"`
+	Tone       string   `json:"tone"`
+	Stage      string   `json:"stage"`
+	Recipients []string `json:"recipients,omitempty"`
+	Subject    string   `json:"subject"`
+	Body       string   `json:"body"`
+	SendOn     string   `json:"send_on"` // suggested send date, YYYY-MM-DD
+}
+
+const followupEmailPrompt = `You are a career coach writing a short email for a job candidate.
+
+Email type: %s
+Tone: %s
+Role: %s at %s
+Application stage: %s
+%s
+Guidelines:
+%s
+- Address the recipients by first name when known; otherwise address the hiring team
+- Under 150 words; no flattery, no restating the whole resume
+- Use ONLY the facts above; never invent names, dates or discussion topics
+- Sign off with "[Your Name]"
+
+Return a JSON object with this exact structure:
+{"subject": "<email subject>", "body": "<email body with line breaks as \n>"}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+// GenerateFollowupEmail writes a thank-you or status-inquiry email for a tracked job.
+func GenerateFollowupEmail(ctx context.Context, input FollowupEmailInput) (*FollowupEmailResult, error) {
+	if input.ID <= 0 {
+		return nil, errors.New("followup_email_generate: id is required")
+	}
+	tone := strings.ToLower(strings.TrimSpace(input.Tone))
+	if tone != "friendly" && tone != "concise" {
+		tone = ToneProfessional
+	}
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	job, err := getTrackedJob(db, "followup_email_generate", input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == StatusSaved {
+		return nil, fmt.Errorf("followup_email_generate: job #%d is still saved — apply first", job.ID)
+	}
+
+	now := time.Now()
+	emailType, sendOn, err := followupPlan(job, strings.ToLower(strings.TrimSpace(input.Type)), now)
+	if err != nil {
+		return nil, err
+	}
+	recipients := cleanNames(input.Interviewers)
+	interview := lastJobEvent(job.Events, EventInterview)
+	if len(recipients) == 0 && interview != nil {
+		recipients = interview.Interviewers
+	}
+
+	var facts strings.Builder
+	if len(recipients) > 0 {
+		fmt.Fprintf(&facts, "Recipients: %s\n", strings.Join(recipients, ", "))
+	}
+	if interview != nil {
+		fmt.Fprintf(&facts, "Last interview: %s\n", interview.Date)
+		if interview.Notes != "" {
+			fmt.Fprintf(&facts, "Interview notes: %s\n", engine.TruncateRunes(interview.Notes, 800, "..."))
+		}
+	}
+	if last := lastJobEvent(job.Events, ""); last != nil {
+		fmt.Fprintf(&facts, "Last contact: %s (%s)\n", last.Date, last.Kind)
+	}
+	if input.Notes != "" {
+		fmt.Fprintf(&facts, "Discussion points to reference: %s\n", engine.TruncateRunes(input.Notes, 800, "..."))
+	}
+
+	prompt := fmt.Sprintf(followupEmailPrompt, emailType, tone, job.Title, job.Company, job.Status,
+		facts.String(), followupGuidelines(emailType, job.Status))
+	raw, err := engine.CallLLM(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("followup_email_generate LLM: %w", err)
+	}
+	var email struct {
+		Subject string `json:"subject"`
+		Body    string `json:"body"`
+	}
+	if err := json.Unmarshal([]byte(StripMarkdownFences(raw)), &email); err != nil {
+		return nil, fmt.Errorf("followup_email_generate parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+	}
+	return &FollowupEmailResult{
+		ID:         job.ID,
+		Type:       emailType,
+		Tone:       tone,
+		Stage:      string(job.Status),
+		Recipients: recipients,
+		Subject:    strings.TrimSpace(email.Subject),
+		Body:       strings.TrimSpace(email.Body),
+		SendOn:     sendOn,
+	}, nil
+}
+
+// followupPlan picks the email type, when not given, and the suggested send date: a
+// thank-you the day after the interview, a status inquiry a week after the last
+// contact (the last event, else the last tracker update). Dates in the past become
+// today.
+func followupPlan(job *TrackedJob, emailType string, now time.Time) (string, string, error) {
+	today := now.Format(time.DateOnly)
+	interview := lastJobEvent(job.Events, EventInterview)
+	switch emailType {
+	case "":
+		emailType = FollowupStatusInquiry
+		if interview != nil {
+			if d, err := time.Parse(time.DateOnly, interview.Date); err == nil && now.Sub(d) < thankYouWindowDays*24*time.Hour {
+				emailType = FollowupThankYou
+			}
+		}
+	case FollowupThankYou, FollowupStatusInquiry:
+	default:
+		return "", "", fmt.Errorf("followup_email_generate: invalid type %q (valid: thank_you, status_inquiry)", emailType)
+	}
+
+	var sendOn string
+	if emailType == FollowupThankYou {
+		if interview != nil {
+			if d, err := time.Parse(time.DateOnly, interview.Date); err == nil {
+				sendOn = d.AddDate(0, 0, 1).Format(time.DateOnly)
+			}
+		}
+	} else {
+		lastContact := job.UpdatedAt
+		if last := lastJobEvent(job.Events, ""); last != nil {
+			lastContact = last.Date
+		}
+		if len(lastContact) >= len(time.DateOnly) {
+			if d, err := time.Parse(time.DateOnly, lastContact[:len(time.DateOnly)]); err == nil {
+				sendOn = d.AddDate(0, 0, inquiryWaitDays).Format(time.DateOnly)
+			}
+		}
+	}
+	if sendOn == "" || sendOn < today {
+		sendOn = today
+	}
+	return emailType, sendOn, nil
+}
+
+// followupGuidelines tailors the prompt to the email type and application stage.
+func followupGuidelines(emailType string, status JobStatus) string {
+	if emailType == FollowupThankYou {
+		if status == StatusRejected {
+			return "- Thank them for their time and consideration, ask for brief feedback, and keep the door open for future roles"
+		}
+		return "- Thank them for the conversation, reference one or two specific discussion points, and restate interest in the role"
+	}
+	switch status {
+	case StatusApplied:
+		return "- Politely ask whether the application is under review and restate fit in one sentence"
+	case StatusInterview:
+		return "- Thank them again for the interview and politely ask about the timeline for next steps"
+	case StatusOffer:
+		return "- Confirm enthusiasm for the offer and ask about the timeline or open questions for the decision"
+	default:
+		return "- Politely ask for an update on the application"
+	}
+}
+
+// lastJobEvent returns the most recent event of kind, or of any kind when kind is "".
+func lastJobEvent(events []JobEvent, kind string) *JobEvent {
+	for i := len(events) - 1; i >= 0; i-- {
+		if kind == "" || events[i].Kind == kind {
+			return &events[i]
+		}
+	}
+	return nil
+}
@@ -19,7 +19,7 @@ const (
 // StartFreelanceMonitor launches a background goroutine that polls RemoteOK
 // and Himalayas for new freelance/remote jobs and sends Telegram notifications.
 func StartFreelanceMonitor(ctx context.Context) {
-	if engine.Cfg.VaelorNotifyURL == "" {
+	if engine.ConfigFrom(ctx).VaelorNotifyURL == "" {
 		slog.Info("freelance_monitor: disabled (VAELOR_NOTIFY_URL not set)")
 		return
 	}
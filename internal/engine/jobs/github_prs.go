@@ -15,7 +15,7 @@ import (
 // FetchLinkedPRs finds pull requests that reference the given issue.
 // Uses GitHub's search API: "type:pr repo:owner/repo <number> in:body,comments".
 func FetchLinkedPRs(ctx context.Context, owner, repo string, number int) ([]engine.CompetingPR, error) {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	// Search for PRs mentioning this issue number in the same repo.
@@ -29,11 +29,11 @@ func FetchLinkedPRs(ctx context.Context, owner, repo string, number int) ([]engi
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	if engine.Cfg.GithubToken != "" {
-		req.Header.Set("Authorization", "Bearer "+engine.Cfg.GithubToken)
+	if engine.ConfigFrom(ctx).GithubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
 	}
 
-	resp, err := engine.Cfg.HTTPClient.Do(req)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("github search: %w", err)
 	}
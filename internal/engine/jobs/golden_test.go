@@ -0,0 +1,166 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Golden-file tests for the scraper parsers. Each source has representative
+// responses under testdata/golden/<source>/; the parsed output of every fixture is
+// compared with <fixture>.golden.json next to it.
+//
+//	go test ./internal/engine/jobs -run TestSourceGolden -update   rewrite the goldens
+//	go test ./internal/engine/jobs -run TestSourceGolden -refresh  fetch live.<ext> fixtures, then rewrite
+//
+// Review the golden diff before committing: a changed golden is a parser or site change.
+var (
+	updateGolden  = flag.Bool("update", false, "rewrite golden files from the current parser output")
+	refreshGolden = flag.Bool("refresh", false, "fetch live responses into testdata/golden and rewrite golden files (network)")
+)
+
+// goldenSource is one parser under golden test.
+type goldenSource struct {
+	name  string
+	ext   string                         // fixture extension, e.g. ".json"
+	parse func(body []byte) (any, error) // parses a raw response
+	live  string                         // URL fetched by -refresh; empty when the source needs auth or POST
+}
+
+var goldenSources = []goldenSource{
+	{name: "linkedin", ext: ".html", live: linkedInGuestAPI + "?keywords=golang&location=Remote",
+		parse: func(b []byte) (any, error) { return parseLinkedInHTML(string(b)), nil }},
+	// Indeed is a GraphQL POST with an API key: its fixtures are maintained by hand.
+	{name: "indeed", ext: ".json", parse: func(b []byte) (any, error) {
+		resp, err := parseIndeedGraphQL(b)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]engine.SearxngResult, 0, len(resp.Data.JobSearch.Results))
+		for _, r := range resp.Data.JobSearch.Results {
+			out = append(out, indeedGQLJobToResult(r.Job))
+		}
+		return out, nil
+	}},
+	{name: "remoteok", ext: ".json", live: remoteOKAPI + "?tag=golang",
+		parse: func(b []byte) (any, error) { return parseRemoteOKResponse(b) }},
+	{name: "wwr", ext: ".xml", live: wwrRSSURL,
+		parse: func(b []byte) (any, error) { return parseWWRResponse(b) }},
+	{name: "remotive", ext: ".json", live: remotiveAPI + "?search=golang",
+		parse: func(b []byte) (any, error) { return parseRemotiveResponse(b, nil) }},
+	{name: "habr", ext: ".json", live: habrCareerAPIBase + "?q=golang&per_page=15&page=1",
+		parse: func(b []byte) (any, error) { return parseHabrVacancies(b) }},
+	{name: "craigslist", ext: ".xml", live: "https://sfbay.craigslist.org/search/jjj?query=golang&format=rss",
+		parse: func(b []byte) (any, error) { return parseCraigslistRSS(b, 100) }},
+	{name: "himalayas", ext: ".json", live: himalayasAPIURL + "?limit=20",
+		parse: func(b []byte) (any, error) { return parseHimalayasResponse(b) }},
+	// takehome_research fixtures are searches for "Stripe": the parsers keep only
+	// results that mention the company.
+	{name: "takehome_github", ext: ".json",
+		live:  "https://api.github.com/search/repositories?per_page=10&sort=stars&q=" + url.QueryEscape(`"Stripe" take-home OR "coding challenge"`),
+		parse: func(b []byte) (any, error) { return parseTakeHomeRepos(bytes.NewReader(b), "Stripe") }},
+	// SearXNG needs a configured instance: its fixtures are recorded JSON responses.
+	{name: "takehome_writeups", ext: ".json", parse: func(b []byte) (any, error) {
+		var resp struct {
+			Results []engine.SearxngResult `json:"results"`
+		}
+		if err := json.Unmarshal(b, &resp); err != nil {
+			return nil, err
+		}
+		return filterTakeHomeWriteups(resp.Results, "Stripe", make(map[string]bool)), nil
+	}},
+}
+
+func TestSourceGolden(t *testing.T) {
+	for _, src := range goldenSources {
+		t.Run(src.name, func(t *testing.T) {
+			dir := filepath.Join("testdata", "golden", src.name)
+			if *refreshGolden && src.live != "" {
+				refreshFixture(t, src, dir)
+			}
+			fixtures, err := filepath.Glob(filepath.Join(dir, "*"+src.ext))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, fixture := range fixtures {
+				if strings.HasSuffix(fixture, ".golden.json") {
+					continue
+				}
+				t.Run(filepath.Base(fixture), func(t *testing.T) {
+					checkGolden(t, src, fixture)
+				})
+			}
+		})
+	}
+}
+
+// checkGolden parses fixture and compares the output with its golden file.
+func checkGolden(t *testing.T, src goldenSource, fixture string) {
+	t.Helper()
+	body, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := src.parse(body)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got, err := json.MarshalIndent(parsed, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	golden := strings.TrimSuffix(fixture, src.ext) + ".golden.json"
+	if *updateGolden || *refreshGolden {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s output differs from %s (run with -update if the change is intended)\n--- got\n%s", src.name, golden, got)
+	}
+}
+
+// refreshFixture saves the live response of src as live<ext> in dir.
+func refreshFixture(t *testing.T, src goldenSource, dir string) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.live, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("User-Agent", engine.UserAgentChrome)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("refresh %s: %v", src.name, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 5*1024*1024))
+	if err != nil {
+		t.Fatalf("refresh %s: %v", src.name, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("refresh %s: status %d", src.name, resp.StatusCode)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "live"+src.ext), body, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -19,6 +18,27 @@ import (
 
 const habrCareerAPIBase = "https://career.habr.com/api/frontend/vacancies"
 
+// habrRUBRates are approximate RUB exchange rates used to normalize salaries
+// posted in other currencies. Precise enough for ranking and filtering.
+var habrRUBRates = map[string]float64{
+	"RUB": 1,
+	"USD": 90,
+	"EUR": 98,
+	"KZT": 0.18,
+	"BYN": 28,
+	"UAH": 2.2,
+}
+
+// habrQualification maps job_search experience levels to Habr qualification IDs.
+var habrQualification = map[string]string{
+	"internship": "1",
+	"entry":      "3",
+	"associate":  "4",
+	"mid-senior": "5",
+	"director":   "6",
+	"executive":  "6",
+}
+
 // habrVacanciesResponse is the top-level API response.
 type habrVacanciesResponse struct {
 	List []habrVacancy `json:"list"`
@@ -29,12 +49,13 @@ type habrVacanciesResponse struct {
 
 // habrVacancy is a single vacancy from the Habr Career API.
 type habrVacancy struct {
-	ID    int    `json:"id"`
-	Title string `json:"title"`
-	Href  string `json:"href"`
+	ID      int    `json:"id"`
+	Title   string `json:"title"`
+	Href    string `json:"href"`
 	Company struct {
-		Title string `json:"title"`
-		Href  string `json:"href"`
+		Title  string          `json:"title"`
+		Href   string          `json:"href"`
+		Rating json.RawMessage `json:"rating"`
 	} `json:"company"`
 	Salary struct {
 		From     *int   `json:"from"`
@@ -47,16 +68,37 @@ type habrVacancy struct {
 	Locations []struct {
 		Title string `json:"title"`
 	} `json:"locations"`
-	RemoteWork bool   `json:"remoteWork"`
-	PublishedAt string `json:"publishedAt"`
+	RemoteWork    bool   `json:"remoteWork"`
+	PublishedAt   string `json:"publishedAt"`
+	Qualification struct {
+		Title string `json:"title"`
+	} `json:"salaryQualification"`
 	Employment struct {
 		Title string `json:"title"`
 	} `json:"employment"`
 }
 
+// HabrFilters narrows a Habr Career search. Zero value = no filtering.
+type HabrFilters struct {
+	Remote     bool   // remote-friendly vacancies only
+	WithSalary bool   // only vacancies that state a salary
+	Experience string // job_search experience level (internship, entry, ..., executive)
+}
+
 // SearchHabrJobs searches Habr Career for IT job listings.
 func SearchHabrJobs(ctx context.Context, query, location string, limit int) ([]engine.SearxngResult, error) {
-	engine.IncrHabrRequests()
+	listings, err := SearchHabrVacancies(ctx, query, location, HabrFilters{}, limit)
+	if err != nil {
+		return nil, err
+	}
+	return HabrListingsToSearxngResults(listings), nil
+}
+
+// SearchHabrVacancies queries the Habr Career API and returns structured listings.
+// Salaries are normalized to monthly RUB in SalaryMin/SalaryMax; the posted
+// range and currency are kept in Salary.
+func SearchHabrVacancies(ctx context.Context, query, location string, f HabrFilters, limit int) ([]engine.JobListing, error) {
+	engine.IncrHabrRequests(ctx)
 
 	if limit <= 0 || limit > 30 {
 		limit = 15
@@ -74,6 +116,15 @@ func SearchHabrJobs(ctx context.Context, query, location string, limit int) ([]e
 	if location != "" {
 		q.Set("locations[]", location)
 	}
+	if f.Remote {
+		q.Set("remote", "true")
+	}
+	if f.WithSalary {
+		q.Set("with_salary", "true")
+	}
+	if qid := habrQualification[f.Experience]; qid != "" {
+		q.Set("qid", qid)
+	}
 	u.RawQuery = q.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
@@ -85,7 +136,7 @@ func SearchHabrJobs(ctx context.Context, query, location string, limit int) ([]e
 	req.Header.Set("X-Requested-With", "XMLHttpRequest")
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // Habr API URL, intentional outbound request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // Habr API URL, intentional outbound request
 	})
 	if err != nil {
 		return nil, fmt.Errorf("habr career API: %w", err)
@@ -96,17 +147,26 @@ func SearchHabrJobs(ctx context.Context, query, location string, limit int) ([]e
 		return nil, fmt.Errorf("habr career API status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	var listings []engine.JobListing
+	err = engine.ReadBody(resp.Body, 1024*1024, func(body []byte) (err error) {
+		listings, err = parseHabrVacancies(body)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	slog.Debug("habr: search complete", slog.Int("results", len(listings)))
+	return listings, nil
+}
 
+// parseHabrVacancies converts an API response body into structured listings.
+func parseHabrVacancies(body []byte) ([]engine.JobListing, error) {
 	var apiResp habrVacanciesResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
 		return nil, fmt.Errorf("habr career parse: %w", err)
 	}
 
-	results := make([]engine.SearxngResult, 0, len(apiResp.List))
+	listings := make([]engine.JobListing, 0, len(apiResp.List))
 	for _, v := range apiResp.List {
 		if v.Title == "" {
 			continue
@@ -117,67 +177,178 @@ func SearchHabrJobs(ctx context.Context, query, location string, limit int) ([]e
 			jobURL = "https://career.habr.com" + jobURL
 		}
 
-		var contentParts []string
-		contentParts = append(contentParts, "**Source:** Хабр Карьера")
-
-		if v.Company.Title != "" {
-			contentParts = append(contentParts, "**Company:** "+v.Company.Title)
-		}
-
-		// Locations
 		var locs []string
 		for _, l := range v.Locations {
 			if l.Title != "" {
 				locs = append(locs, l.Title)
 			}
 		}
-		if v.RemoteWork {
-			locs = append(locs, "Remote")
-		}
-		if len(locs) > 0 {
-			contentParts = append(contentParts, "**Location:** "+strings.Join(locs, ", "))
-		}
 
-		// Salary
-		if v.Salary.From != nil || v.Salary.To != nil {
-			salary := formatHabrSalary(v.Salary.From, v.Salary.To, v.Salary.Currency)
-			contentParts = append(contentParts, "**Salary:** "+salary)
-		}
-
-		// Skills
 		var skills []string
 		for _, s := range v.Skills {
 			if s.Title != "" {
 				skills = append(skills, s.Title)
 			}
 		}
-		if len(skills) > 0 {
-			contentParts = append(contentParts, "**Skills:** "+strings.Join(skills, ", "))
+
+		j := engine.JobListing{
+			Title:         v.Title,
+			Company:       v.Company.Title,
+			URL:           jobURL,
+			JobID:         strconv.Itoa(v.ID),
+			Source:        "habr",
+			Location:      strings.Join(locs, ", "),
+			JobType:       v.Employment.Title,
+			Experience:    v.Qualification.Title,
+			Skills:        skills,
+			CompanyRating: habrRating(v.Company.Rating),
+		}
+		if v.RemoteWork {
+			j.Remote = "remote"
+		}
+		if len(v.PublishedAt) >= 10 {
+			j.Posted = v.PublishedAt[:10]
+		}
+		if v.Salary.From != nil || v.Salary.To != nil {
+			cur := habrCurrency(v.Salary.Currency)
+			j.Salary = formatHabrSalary(v.Salary.From, v.Salary.To, cur)
+			j.SalaryMin, j.SalaryMax, j.SalaryCurrency = habrToRUB(v.Salary.From, v.Salary.To, cur)
+			j.SalaryInterval = "month"
 		}
+		listings = append(listings, j)
+	}
+	return listings, nil
+}
 
-		if v.Employment.Title != "" {
-			contentParts = append(contentParts, "**Type:** "+v.Employment.Title)
+// HabrListingsToSearxngResults renders Habr listings in the "**Field:** value" form
+// the job summarizer reads.
+func HabrListingsToSearxngResults(listings []engine.JobListing) []engine.SearxngResult {
+	results := make([]engine.SearxngResult, 0, len(listings))
+	for _, j := range listings {
+		contentParts := []string{"**Source:** Хабр Карьера"}
+		if j.Company != "" {
+			contentParts = append(contentParts, "**Company:** "+j.Company)
+		}
+		if j.CompanyRating > 0 {
+			contentParts = append(contentParts, fmt.Sprintf("**Company rating:** %.1f", j.CompanyRating))
 		}
 
-		if v.PublishedAt != "" && len(v.PublishedAt) >= 10 {
-			contentParts = append(contentParts, "**Posted:** "+v.PublishedAt[:10])
+		loc := j.Location
+		if j.Remote != "" {
+			loc = strings.TrimPrefix(loc+", Remote", ", ")
+		}
+		if loc != "" {
+			contentParts = append(contentParts, "**Location:** "+loc)
 		}
 
-		title := v.Title
-		if v.Company.Title != "" {
-			title = v.Title + " at " + v.Company.Title
+		if j.Salary != "" {
+			salary := j.Salary + "/month"
+			if j.SalaryCurrency == "RUB" && !strings.HasSuffix(j.Salary, "RUB") {
+				salary += " (≈ " + formatHabrSalary(j.SalaryMin, j.SalaryMax, "RUB") + "/month)"
+			}
+			contentParts = append(contentParts, "**Salary:** "+salary)
+		}
+		if len(j.Skills) > 0 {
+			contentParts = append(contentParts, "**Skills:** "+strings.Join(j.Skills, ", "))
+		}
+		if j.Experience != "" {
+			contentParts = append(contentParts, "**Level:** "+j.Experience)
+		}
+		if j.JobType != "" {
+			contentParts = append(contentParts, "**Type:** "+j.JobType)
+		}
+		if j.Posted != "" {
+			contentParts = append(contentParts, "**Posted:** "+j.Posted)
+		}
+
+		title := j.Title
+		if j.Company != "" {
+			title = j.Title + " at " + j.Company
 		}
 
 		results = append(results, engine.SearxngResult{
 			Title:   title,
 			Content: strings.Join(contentParts, " | "),
-			URL:     jobURL,
+			URL:     j.URL,
 			Score:   0.9,
 		})
 	}
+	return results
+}
+
+// MergeHabrListing fills fields the LLM summary left empty (or only stated as text)
+// from the structured Habr listing for the same URL.
+func MergeHabrListing(dst *engine.JobListing, src engine.JobListing) {
+	if dst.Company == "" {
+		dst.Company = src.Company
+	}
+	if dst.Location == "" {
+		dst.Location = src.Location
+	}
+	if dst.Remote == "" {
+		dst.Remote = src.Remote
+	}
+	if len(dst.Skills) == 0 {
+		dst.Skills = src.Skills
+	}
+	if dst.Posted == "" || dst.Posted == "not specified" {
+		dst.Posted = src.Posted
+	}
+	if src.SalaryMin != nil || src.SalaryMax != nil {
+		dst.SalaryMin, dst.SalaryMax = src.SalaryMin, src.SalaryMax
+		dst.SalaryCurrency, dst.SalaryInterval = src.SalaryCurrency, src.SalaryInterval
+		if dst.Salary == "" || dst.Salary == "not specified" {
+			dst.Salary = src.Salary
+		}
+	}
+	dst.CompanyRating = src.CompanyRating
+	dst.Source = src.Source
+}
 
-	slog.Debug("habr: search complete", slog.Int("results", len(results)))
-	return results, nil
+// habrCurrency normalizes Habr currency codes ("rur", "usd") to ISO codes.
+func habrCurrency(c string) string {
+	c = strings.ToUpper(strings.TrimSpace(c))
+	switch c {
+	case "", "RUR":
+		return "RUB"
+	default:
+		return c
+	}
+}
+
+// habrToRUB converts a salary range to RUB. Unknown currencies are returned unchanged.
+func habrToRUB(from, to *int, currency string) (lo, hi *int, cur string) {
+	rate, ok := habrRUBRates[currency]
+	if !ok {
+		return from, to, currency
+	}
+	conv := func(v *int) *int {
+		if v == nil {
+			return nil
+		}
+		r := int(float64(*v) * rate)
+		return &r
+	}
+	return conv(from), conv(to), "RUB"
+}
+
+// habrRating reads the company rating, which the API sends either as a number or
+// as an object with a value field. Returns 0 when absent.
+func habrRating(raw json.RawMessage) float64 {
+	if len(raw) == 0 {
+		return 0
+	}
+	var f float64
+	if err := json.Unmarshal(raw, &f); err == nil {
+		return f
+	}
+	var obj struct {
+		Value json.Number `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		f, _ = obj.Value.Float64()
+	}
+	return f
 }
 
 // formatHabrSalary formats salary range from Habr Career API.
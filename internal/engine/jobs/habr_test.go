@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleHabrResponse = `{"list":[
+  {"id":1001,"title":"Go Developer","href":"/vacancies/1001",
+   "company":{"title":"Ozon","href":"/companies/ozon","rating":4.3},
+   "salary":{"from":300000,"to":450000,"currency":"rur"},
+   "skills":[{"title":"Go"},{"title":"PostgreSQL"}],
+   "locations":[{"title":"Москва"}],"remoteWork":true,
+   "publishedAt":"2026-10-01T10:00:00+03:00",
+   "salaryQualification":{"title":"Senior"},
+   "employment":{"title":"Полный рабочий день"}},
+  {"id":1002,"title":"Backend Engineer","href":"https://career.habr.com/vacancies/1002",
+   "company":{"title":"Acme","rating":{"value":"3.9"}},
+   "salary":{"from":4000,"to":null,"currency":"usd"}},
+  {"id":1003,"title":""}
+],"meta":{"totalCount":3}}`
+
+func TestParseHabrVacancies(t *testing.T) {
+	listings, err := parseHabrVacancies([]byte(sampleHabrResponse))
+	if err != nil {
+		t.Fatalf("parseHabrVacancies: %v", err)
+	}
+	if len(listings) != 2 {
+		t.Fatalf("got %d listings, want 2 (empty title skipped)", len(listings))
+	}
+
+	j := listings[0]
+	if j.URL != "https://career.habr.com/vacancies/1001" || j.JobID != "1001" {
+		t.Errorf("URL/JobID = %q/%q", j.URL, j.JobID)
+	}
+	if j.Remote != "remote" || j.Experience != "Senior" || j.Posted != "2026-10-01" {
+		t.Errorf("remote/level/posted = %q/%q/%q", j.Remote, j.Experience, j.Posted)
+	}
+	if j.CompanyRating != 4.3 {
+		t.Errorf("rating = %v, want 4.3", j.CompanyRating)
+	}
+	if j.SalaryMin == nil || *j.SalaryMin != 300000 || j.SalaryCurrency != "RUB" || j.SalaryInterval != "month" {
+		t.Errorf("salary = %v %q %q", j.SalaryMin, j.SalaryCurrency, j.SalaryInterval)
+	}
+
+	usd := listings[1]
+	if usd.SalaryMin == nil || *usd.SalaryMin != 4000*90 || usd.SalaryMax != nil || usd.SalaryCurrency != "RUB" {
+		t.Errorf("USD salary not normalized to RUB: %v %v %q", usd.SalaryMin, usd.SalaryMax, usd.SalaryCurrency)
+	}
+	if usd.Salary != "от 4000 USD" {
+		t.Errorf("Salary = %q, want the posted currency kept", usd.Salary)
+	}
+	if usd.CompanyRating != 3.9 {
+		t.Errorf("object rating = %v, want 3.9", usd.CompanyRating)
+	}
+}
+
+func TestHabrListingsToSearxngResults(t *testing.T) {
+	listings, err := parseHabrVacancies([]byte(sampleHabrResponse))
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := HabrListingsToSearxngResults(listings)
+	if len(results) != 2 {
+		t.Fatalf("got %d results", len(results))
+	}
+	if results[0].Title != "Go Developer at Ozon" {
+		t.Errorf("title = %q", results[0].Title)
+	}
+	for _, want := range []string{"**Company rating:** 4.3", "Москва, Remote", "300000 – 450000 RUB/month", "**Skills:** Go, PostgreSQL"} {
+		if !strings.Contains(results[0].Content, want) {
+			t.Errorf("content missing %q: %s", want, results[0].Content)
+		}
+	}
+	if !strings.Contains(results[1].Content, "(≈ от 360000 RUB/month)") {
+		t.Errorf("content missing RUB estimate: %s", results[1].Content)
+	}
+}
@@ -0,0 +1,456 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// hh.ru (HeadHunter) resume publishing. Maps the master resume onto the hh.ru
+// resume schema and creates or updates it with the user's OAuth token.
+
+const hhAPIBase = "https://api.hh.ru"
+
+// hhDefaultUserAgent is sent when HH_USER_AGENT is unset; hh.ru rejects requests
+// without an "App/Version (contact)" user agent.
+const hhDefaultUserAgent = "go_job/1.0 (resume-sync)"
+
+// HHFieldGap is a master resume field that could not be mapped to hh.ru.
+type HHFieldGap struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// HHResumeSyncResult is the output of hh_resume_sync.
+type HHResumeSyncResult struct {
+	Action   string         `json:"action"` // "created", "updated" or "dry_run"
+	ResumeID string         `json:"resume_id,omitempty"`
+	URL      string         `json:"url,omitempty"`
+	Mapped   []string       `json:"mapped"`
+	Gaps     []HHFieldGap   `json:"gaps"`
+	Payload  map[string]any `json:"payload,omitempty"` // dry_run only
+}
+
+// hhDateRe accepts YYYY, YYYY-MM and YYYY-MM-DD (also with "/" or "." separators).
+var hhDateRe = regexp.MustCompile(`^(\d{4})(?:[-/.](\d{1,2}))?(?:[-/.](\d{1,2}))?$`)
+
+// hhEducationLevels maps degree keywords to hh.ru education level IDs, most specific first.
+var hhEducationLevels = []struct {
+	keyword, level string
+}{
+	{"phd", "candidate"},
+	{"candidate", "candidate"},
+	{"doctor", "doctor"},
+	{"master", "master"},
+	{"msc", "master"},
+	{"bachelor", "bachelor"},
+	{"bsc", "bachelor"},
+	{"specialist", "higher"},
+}
+
+// hhSiteTypes maps master resume link keys to hh.ru site type IDs.
+var hhSiteTypes = map[string]string{
+	"linkedin":  "linkedin",
+	"facebook":  "facebook",
+	"website":   "personal",
+	"personal":  "personal",
+	"portfolio": "personal",
+	"freelance": "freelance",
+}
+
+// SyncHHResume publishes the master resume to hh.ru. An empty resumeID creates a
+// new resume; otherwise the existing one is updated. With dryRun the mapped payload
+// and gaps are returned without calling hh.ru.
+func SyncHHResume(ctx context.Context, resumeID, title string, salaryRUB int, dryRun bool) (*HHResumeSyncResult, error) {
+	token := engine.ConfigFrom(ctx).HHAccessToken
+	if token == "" && !dryRun {
+		return nil, errors.New("hh.ru access token not configured (set HH_ACCESS_TOKEN)")
+	}
+	feed, err := BuildResumeFeed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, result := BuildHHResume(feed, title, salaryRUB)
+	if areaID, err := hhLookupArea(ctx, feed.Person.Location); err != nil {
+		result.Gaps = append(result.Gaps, HHFieldGap{Field: "area", Reason: err.Error()})
+	} else {
+		payload["area"] = map[string]string{"id": areaID}
+		result.Mapped = append(result.Mapped, "area")
+	}
+
+	if dryRun {
+		result.Action = "dry_run"
+		result.ResumeID = resumeID
+		result.Payload = payload
+		return result, nil
+	}
+
+	if resumeID == "" {
+		resp, err := hhRequest(ctx, http.MethodPost, "/resumes", payload)
+		if err != nil {
+			return nil, fmt.Errorf("hh.ru create resume: %w", err)
+		}
+		// The new resume ID is only returned in the Location header.
+		result.ResumeID = path.Base(resp.Header.Get("Location"))
+		result.Action = "created"
+	} else {
+		if _, err := hhRequest(ctx, http.MethodPut, "/resumes/"+url.PathEscape(resumeID), payload); err != nil {
+			return nil, fmt.Errorf("hh.ru update resume %s: %w", resumeID, err)
+		}
+		result.ResumeID = resumeID
+		result.Action = "updated"
+	}
+	if result.ResumeID != "" && result.ResumeID != "." {
+		result.URL = "https://hh.ru/resume/" + result.ResumeID
+	}
+	return result, nil
+}
+
+// BuildHHResume maps the resume feed onto the hh.ru resume schema. The returned
+// result lists mapped fields and every gap; area is left to the caller since it
+// needs an API lookup.
+func BuildHHResume(feed *ResumeFeed, title string, salaryRUB int) (map[string]any, *HHResumeSyncResult) {
+	r := &HHResumeSyncResult{}
+	p := make(map[string]any)
+	mapped := func(field string) { r.Mapped = append(r.Mapped, field) }
+	gap := func(field, reason string) { r.Gaps = append(r.Gaps, HHFieldGap{Field: field, Reason: reason}) }
+
+	person := feed.Person
+	names := strings.Fields(person.Name)
+	switch len(names) {
+	case 0:
+		gap("first_name", "master resume has no name")
+	case 1:
+		p["first_name"] = names[0]
+		gap("last_name", "name has a single word; hh.ru requires first and last name")
+	default:
+		p["first_name"] = names[0]
+		p["last_name"] = strings.Join(names[1:], " ")
+		mapped("first_name")
+		mapped("last_name")
+	}
+
+	if title == "" && len(feed.Experiences) > 0 {
+		title = feed.Experiences[0].Title
+	}
+	if title != "" {
+		p["title"] = title
+		mapped("title")
+	} else {
+		gap("title", "no desired position given and no experience to derive it from")
+	}
+
+	var contacts []map[string]any
+	if person.Email != "" {
+		contacts = append(contacts, map[string]any{"type": map[string]string{"id": "email"}, "value": person.Email, "preferred": true})
+	}
+	if person.Phone != "" {
+		contacts = append(contacts, map[string]any{"type": map[string]string{"id": "cell"}, "value": map[string]string{"formatted": person.Phone}})
+	}
+	if len(contacts) > 0 {
+		p["contact"] = contacts
+		mapped("contact")
+	} else {
+		gap("contact", "master resume has no email or phone")
+	}
+
+	keys := make([]string, 0, len(person.Links))
+	for key := range person.Links {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var sites []map[string]any
+	for _, key := range keys {
+		link := person.Links[key]
+		typ, ok := hhSiteTypes[strings.ToLower(key)]
+		if !ok {
+			gap("site."+key, "hh.ru has no matching site type; add it to the about text manually")
+			continue
+		}
+		sites = append(sites, map[string]any{"type": map[string]string{"id": typ}, "url": link})
+	}
+	if len(sites) > 0 {
+		p["site"] = sites
+		mapped("site")
+	}
+
+	if about := hhAboutText(feed); about != "" {
+		p["skills"] = about
+		mapped("skills")
+	}
+
+	if len(feed.Skills) > 0 {
+		skillSet := make([]string, 0, len(feed.Skills))
+		for _, s := range feed.Skills {
+			if !s.IsImplicit {
+				skillSet = append(skillSet, s.Name)
+			}
+		}
+		p["skill_set"] = skillSet
+		mapped("skill_set")
+	}
+
+	var experience []map[string]any
+	for _, e := range feed.Experiences {
+		start, ok := hhDate(e.StartDate)
+		if !ok {
+			gap("experience."+e.Company, fmt.Sprintf("start date %q is not YYYY[-MM[-DD]]; entry skipped", e.StartDate))
+			continue
+		}
+		item := map[string]any{
+			"company":     e.Company,
+			"position":    e.Title,
+			"start":       start,
+			"description": hhExperienceDescription(e),
+		}
+		if end, ok := hhDate(e.EndDate); ok {
+			item["end"] = end
+		}
+		experience = append(experience, item)
+	}
+	if len(experience) > 0 {
+		p["experience"] = experience
+		mapped("experience")
+	}
+
+	if edu := hhEducation(feed.Educations, gap); edu != nil {
+		p["education"] = edu
+		mapped("education")
+	}
+
+	var certs []map[string]any
+	for _, c := range feed.Certifications {
+		year := leadingYear(c.Year)
+		if year == "" {
+			gap("certificate."+c.Name, "no year; hh.ru requires achieved_at")
+			continue
+		}
+		cert := map[string]any{"title": c.Name, "achieved_at": year + "-01-01", "type": "custom"}
+		if c.URL != "" {
+			cert["url"] = c.URL
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) > 0 {
+		p["certificate"] = certs
+		mapped("certificate")
+	}
+
+	if salaryRUB > 0 {
+		p["salary"] = map[string]any{"amount": salaryRUB, "currency": "RUR"}
+		mapped("salary")
+	}
+
+	// Required by hh.ru for publication, but not mapped to hh.ru dictionaries.
+	gap("language", "hh.ru needs its own language and level codes; set them on hh.ru from the master resume languages")
+	gap("citizenship", "hh.ru needs area codes; set it on hh.ru from the master resume work_authorization")
+	gap("work_ticket", "hh.ru needs area codes; set it on hh.ru from the master resume work_authorization")
+	return p, r
+}
+
+// hhAboutText builds the "about me" section from the summary, achievements and projects,
+// which have no dedicated hh.ru fields.
+func hhAboutText(feed *ResumeFeed) string {
+	var parts []string
+	if feed.Person.Summary != "" {
+		parts = append(parts, feed.Person.Summary)
+	}
+	if len(feed.Achievements) > 0 {
+		var sb strings.Builder
+		sb.WriteString("Achievements:")
+		for _, a := range feed.Achievements {
+			sb.WriteString("\n• " + a.Text)
+		}
+		parts = append(parts, sb.String())
+	}
+	if len(feed.Projects) > 0 {
+		var sb strings.Builder
+		sb.WriteString("Projects:")
+		for _, pr := range feed.Projects {
+			line := "\n• " + pr.Name
+			if pr.Description != "" {
+				line += " — " + pr.Description
+			}
+			if pr.URL != "" {
+				line += " (" + pr.URL + ")"
+			}
+			sb.WriteString(line)
+		}
+		parts = append(parts, sb.String())
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func hhExperienceDescription(e ExperienceRecord) string {
+	desc := e.Description
+	for _, h := range e.Highlights {
+		desc += "\n• " + h
+	}
+	return strings.TrimSpace(desc)
+}
+
+// hhEducation maps educations to hh.ru's {level, primary[]} block. The level is
+// taken from the highest recognised degree.
+func hhEducation(edus []EducationRecord, gap func(field, reason string)) map[string]any {
+	if len(edus) == 0 {
+		return nil
+	}
+	level := ""
+	var primary []map[string]any
+	for _, e := range edus {
+		year := leadingYear(e.EndDate)
+		if year == "" {
+			gap("education."+e.School, "no graduation year; entry skipped")
+			continue
+		}
+		y, _ := strconv.Atoi(year)
+		primary = append(primary, map[string]any{
+			"name":         e.School,
+			"organization": e.Field,
+			"result":       e.Degree,
+			"year":         y,
+		})
+		degree := strings.ToLower(e.Degree)
+		for _, l := range hhEducationLevels {
+			if strings.Contains(degree, l.keyword) {
+				if level == "" || hhLevelRank(l.level) > hhLevelRank(level) {
+					level = l.level
+				}
+				break
+			}
+		}
+	}
+	if len(primary) == 0 {
+		return nil
+	}
+	if level == "" {
+		level = "higher"
+		gap("education.level", "degree not recognised; defaulted to \"higher\"")
+	}
+	return map[string]any{"level": map[string]string{"id": level}, "primary": primary}
+}
+
+func hhLevelRank(level string) int {
+	switch level {
+	case "doctor":
+		return 4
+	case "candidate":
+		return 3
+	case "master":
+		return 2
+	case "bachelor", "higher":
+		return 1
+	}
+	return 0
+}
+
+// hhDate converts YYYY, YYYY-MM or YYYY-MM-DD into the YYYY-MM-DD form hh.ru expects.
+func hhDate(s string) (string, bool) {
+	m := hhDateRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return "", false
+	}
+	month, day := 1, 1
+	if m[2] != "" {
+		month, _ = strconv.Atoi(m[2])
+	}
+	if m[3] != "" {
+		day, _ = strconv.Atoi(m[3])
+	}
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return "", false
+	}
+	return fmt.Sprintf("%s-%02d-%02d", m[1], month, day), true
+}
+
+// leadingYear returns the first four characters when they form a year.
+func leadingYear(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) < 4 {
+		return ""
+	}
+	if _, err := strconv.Atoi(s[:4]); err != nil {
+		return ""
+	}
+	return s[:4]
+}
+
+// hhLookupArea resolves a free-text location to an hh.ru area ID via the suggest API.
+func hhLookupArea(ctx context.Context, location string) (string, error) {
+	city, _, _ := strings.Cut(location, ",")
+	city = strings.TrimSpace(city)
+	if city == "" {
+		return "", errors.New("master resume has no location")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hhAPIBase+"/suggests/areas?text="+url.QueryEscape(city), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("HH-User-Agent", hhUserAgent(req.Context()))
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // fixed hh.ru API URL
+	if err != nil {
+		return "", fmt.Errorf("area lookup: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("area lookup: status %d", resp.StatusCode)
+	}
+	var out struct {
+		Items []struct {
+			ID string `json:"id"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("area lookup: %w", err)
+	}
+	if len(out.Items) == 0 {
+		return "", fmt.Errorf("no hh.ru area matches %q; set it on hh.ru", city)
+	}
+	return out.Items[0].ID, nil
+}
+
+// hhRequest sends an authenticated JSON request to the hh.ru API.
+func hhRequest(ctx context.Context, method, apiPath string, payload any) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, hhAPIBase+apiPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).HHAccessToken)
+	req.Header.Set("HH-User-Agent", hhUserAgent(req.Context()))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // fixed hh.ru API URL
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+func hhUserAgent(ctx context.Context) string {
+	if engine.ConfigFrom(ctx).HHUserAgent != "" {
+		return engine.ConfigFrom(ctx).HHUserAgent
+	}
+	return hhDefaultUserAgent
+}
@@ -0,0 +1,73 @@
+package jobs
+
+import "testing"
+
+func TestHHDate(t *testing.T) {
+	tests := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"2021", "2021-01-01", true},
+		{"2021-03", "2021-03-01", true},
+		{"2021/3/15", "2021-03-15", true},
+		{"2021-13", "", false},
+		{"present", "", false},
+		{"Mar 2021", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := hhDate(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("hhDate(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBuildHHResume(t *testing.T) {
+	feed := &ResumeFeed{
+		Person: PersonRecord{
+			Name:    "Anna Ivanova",
+			Email:   "anna@example.com",
+			Summary: "Backend engineer.",
+			Links:   map[string]string{"linkedin": "https://linkedin.com/in/anna", "github": "https://github.com/anna"},
+		},
+		Experiences: []ExperienceRecord{
+			{Title: "Senior Go Engineer", Company: "Ozon", StartDate: "2021-03", EndDate: "present", Highlights: []string{"Cut p99 latency 40%"}},
+			{Title: "Go Engineer", Company: "Avito", StartDate: "sometime"},
+		},
+		Skills:     []SkillRecord{{Name: "Go"}, {Name: "Kubernetes", IsImplicit: true}},
+		Educations: []EducationRecord{{School: "MSU", Degree: "Master of Science", Field: "CS", EndDate: "2015-06"}},
+	}
+
+	p, r := BuildHHResume(feed, "", 350000)
+
+	if p["first_name"] != "Anna" || p["last_name"] != "Ivanova" {
+		t.Errorf("name = %v %v", p["first_name"], p["last_name"])
+	}
+	if p["title"] != "Senior Go Engineer" {
+		t.Errorf("title = %v, want the latest experience title", p["title"])
+	}
+	exp, _ := p["experience"].([]map[string]any)
+	if len(exp) != 1 || exp[0]["start"] != "2021-03-01" || exp[0]["end"] != nil {
+		t.Errorf("experience = %v", exp)
+	}
+	if skills, _ := p["skill_set"].([]string); len(skills) != 1 || skills[0] != "Go" {
+		t.Errorf("skill_set = %v, want implicit skills dropped", p["skill_set"])
+	}
+	edu, _ := p["education"].(map[string]any)
+	if lvl, _ := edu["level"].(map[string]string); lvl["id"] != "master" {
+		t.Errorf("education level = %v", edu["level"])
+	}
+
+	gaps := make(map[string]bool)
+	for _, g := range r.Gaps {
+		gaps[g.Field] = true
+	}
+	for _, want := range []string{"experience.Avito", "site.github", "language", "citizenship"} {
+		if !gaps[want] {
+			t.Errorf("missing gap %q in %+v", want, r.Gaps)
+		}
+	}
+	if gaps["site.linkedin"] {
+		t.Error("linkedin link should map to an hh.ru site type")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -26,15 +25,17 @@ type himalayasResponse struct {
 }
 
 type himalayasJob struct {
-	Title          string          `json:"title"`
-	CompanyName    string          `json:"companyName"`
-	ApplicationURL string          `json:"applicationUrl"`
-	Categories     []string        `json:"categories"`
-	Seniority      []string        `json:"seniority"`
-	MinSalary      int             `json:"minSalary"`
-	MaxSalary      int             `json:"maxSalary"`
-	PubDate        json.RawMessage `json:"pubDate"`
-	Excerpt        string          `json:"excerpt"`
+	Title                string          `json:"title"`
+	CompanyName          string          `json:"companyName"`
+	ApplicationURL       string          `json:"applicationUrl"`
+	Categories           []string        `json:"categories"`
+	Seniority            []string        `json:"seniority"`
+	MinSalary            int             `json:"minSalary"`
+	MaxSalary            int             `json:"maxSalary"`
+	Currency             string          `json:"currency"`
+	LocationRestrictions []string        `json:"locationRestrictions"`
+	PubDate              json.RawMessage `json:"pubDate"`
+	Excerpt              string          `json:"excerpt"`
 }
 
 // SearchHimalayas fetches jobs from Himalayas. Results are cached.
@@ -71,7 +72,7 @@ func SearchHimalayas(ctx context.Context, query string, limit int) ([]engine.Fre
 }
 
 func fetchHimalayas(ctx context.Context, query string, limit int) ([]engine.FreelanceJob, error) {
-	fetchCtx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	params := url.Values{}
@@ -88,7 +89,7 @@ func fetchHimalayas(ctx context.Context, query string, limit int) ([]engine.Free
 	}
 	req.Header.Set("User-Agent", engine.UserAgentChrome)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("himalayas request failed: %w", err)
 	}
@@ -98,12 +99,12 @@ func fetchHimalayas(ctx context.Context, query string, limit int) ([]engine.Free
 		return nil, fmt.Errorf("himalayas returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 5*1024*1024))
-	if err != nil {
-		return nil, err
-	}
-
-	return parseHimalayasResponse(body)
+	var jobs []engine.FreelanceJob
+	err = engine.ReadBody(resp.Body, 5*1024*1024, func(body []byte) (err error) {
+		jobs, err = parseHimalayasResponse(body)
+		return err
+	})
+	return jobs, err
 }
 
 func parseHimalayasResponse(data []byte) ([]engine.FreelanceJob, error) {
@@ -132,8 +133,10 @@ func parseHimalayasResponse(data []byte) ([]engine.FreelanceJob, error) {
 			Tags:      tags,
 			SalaryMin: hj.MinSalary,
 			SalaryMax: hj.MaxSalary,
+			Currency:  hj.Currency,
 			Source:    "himalayas",
 			Posted:    parsePubDate(hj.PubDate),
+			Location:  strings.Join(hj.LocationRestrictions, ", "),
 		})
 	}
 
@@ -68,7 +68,7 @@ func FindWhoIsHiringThread(ctx context.Context) (int64, error) {
 	req.Header.Set("User-Agent", engine.UserAgentBot)
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return 0, err
@@ -108,7 +108,7 @@ func fetchHNItem(ctx context.Context, id int64) (*hnItemResponse, error) {
 	req.Header.Set("User-Agent", engine.UserAgentBot)
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return nil, err
@@ -149,24 +149,10 @@ func FetchHNJobComments(ctx context.Context, threadID int64, limit int) ([]strin
 		text string
 	}
 	ch := make(chan result, fetch)
-	sem := make(chan struct{}, 10) // max 10 concurrent requests
 
 	for i := 0; i < fetch; i++ {
-		go func(i int, id int64) {
-			sem <- struct{}{}
-			defer func() { <-sem }()
-
-			// Stagger requests slightly to avoid hammering Firebase.
-			delay := time.Duration(i/10) * 200 * time.Millisecond
-			if delay > 0 {
-				select {
-				case <-time.After(delay):
-				case <-ctx.Done():
-					ch <- result{i, ""}
-					return
-				}
-			}
-
+		id := thread.Kids[i]
+		get := func() {
 			item, err := fetchHNItem(ctx, id)
 			if err != nil || item == nil || item.Dead || item.Deleted || item.Text == "" {
 				ch <- result{i, ""}
@@ -177,7 +163,23 @@ func FetchHNJobComments(ctx context.Context, threadID int64, limit int) ([]strin
 				text = text[:1200] + "..."
 			}
 			ch <- result{i, text}
-		}(i, thread.Kids[i])
+		}
+		// Stagger requests slightly to avoid hammering Firebase. The delay is waited
+		// out before queuing, so it does not hold an outbound worker.
+		delay := time.Duration(i/10) * 200 * time.Millisecond
+		if delay == 0 {
+			engine.GoOutbound(ctx, get)
+			continue
+		}
+		go func() {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				ch <- result{i, ""}
+				return
+			}
+			engine.GoOutbound(ctx, get)
+		}()
 	}
 
 	// Collect in order.
@@ -223,7 +225,7 @@ func FilterHNJobComments(comments []string, query string) []string {
 // Uses Algolia search within the thread for keyword matching (efficient, handles large threads).
 // Falls back to sequential Firebase fetch if Algolia returns nothing.
 func SearchHNJobs(ctx context.Context, query string, limit int) ([]engine.SearxngResult, error) {
-	engine.IncrHNJobsRequests()
+	engine.IncrHNJobsRequests(ctx)
 
 	threadID, err := FindWhoIsHiringThread(ctx)
 	if err != nil {
@@ -294,7 +296,7 @@ func searchHNThreadComments(ctx context.Context, threadID int64, query string, l
 	}
 	req.Header.Set("User-Agent", engine.UserAgentBot)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	if err != nil {
 		return nil, err
 	}
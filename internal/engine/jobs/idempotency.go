@@ -0,0 +1,127 @@
+package jobs
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// idempotencyTTL is how long an idempotency key replays its original result.
+const idempotencyTTL = 24 * time.Hour
+
+// idempotencyLocks serializes concurrent calls that share a key, so a retry that
+// races the original waits for it instead of running twice. An entry lives only while
+// calls hold or wait for it; later retries are answered from the stored result.
+var (
+	idempotencyLocksMu sync.Mutex
+	idempotencyLocks   = make(map[string]*idempotencyLock) // tool + "\x00" + key
+)
+
+type idempotencyLock struct {
+	mu   sync.Mutex
+	refs int // calls holding or waiting for mu
+}
+
+// lockIdempotencyKey locks id and returns the func that unlocks it, dropping the
+// entry when no other call waits.
+func lockIdempotencyKey(id string) (unlock func()) {
+	idempotencyLocksMu.Lock()
+	l := idempotencyLocks[id]
+	if l == nil {
+		l = &idempotencyLock{}
+		idempotencyLocks[id] = l
+	}
+	l.refs++
+	idempotencyLocksMu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		idempotencyLocksMu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(idempotencyLocks, id)
+		}
+		idempotencyLocksMu.Unlock()
+	}
+}
+
+// initIdempotencySchema creates the idempotency_keys table in the tracker database.
+func initIdempotencySchema(db *sql.DB) error {
+	schema := `CREATE TABLE IF NOT EXISTS idempotency_keys (
+		tool        TEXT NOT NULL,
+		key         TEXT NOT NULL,
+		fingerprint TEXT NOT NULL,
+		result      TEXT NOT NULL,
+		created_at  TEXT NOT NULL,
+		PRIMARY KEY (tool, key)
+	)`
+	_, err := db.Exec(schema) //nolint:noctx // schema init, no user context available
+	return err
+}
+
+// Idempotent runs fn at most once per (tool, key) within idempotencyTTL and replays the
+// stored result on retries. input is fingerprinted: reusing a key with different input
+// is an error. Failed calls are not stored, so they can be retried with the same key.
+// An empty key runs fn directly.
+func Idempotent[T any](ctx context.Context, tool, key string, input any, fn func() (*T, error)) (*T, error) {
+	if key == "" {
+		return fn()
+	}
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+
+	defer lockIdempotencyKey(tool + "\x00" + key)()
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: idempotency fingerprint: %w", tool, err)
+	}
+	sum := sha256.Sum256(data)
+	fingerprint := hex.EncodeToString(sum[:])
+
+	now := time.Now().UTC()
+	cutoff := now.Add(-idempotencyTTL).Format(time.RFC3339)
+	if _, err := db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE created_at < ?`, cutoff); err != nil {
+		slog.Warn("idempotency: purge failed", slog.Any("error", err))
+	}
+
+	var storedFP, stored string
+	err = db.QueryRowContext(ctx, `SELECT fingerprint, result FROM idempotency_keys WHERE tool = ? AND key = ?`, tool, key).
+		Scan(&storedFP, &stored)
+	switch {
+	case err == nil:
+		if storedFP != fingerprint {
+			return nil, fmt.Errorf("%s: idempotency_key %q was already used with different input", tool, key)
+		}
+		var out T
+		if err := json.Unmarshal([]byte(stored), &out); err != nil {
+			return nil, fmt.Errorf("%s: idempotency replay: %w", tool, err)
+		}
+		slog.Info("idempotency: replayed result", slog.String("tool", tool), slog.String("key", key))
+		return &out, nil
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("%s: idempotency lookup: %w", tool, err)
+	}
+
+	result, err := fn()
+	if err != nil {
+		return nil, err
+	}
+	if encoded, err := json.Marshal(result); err == nil {
+		_, err = db.ExecContext(ctx, `INSERT OR REPLACE INTO idempotency_keys (tool, key, fingerprint, result, created_at) VALUES (?, ?, ?, ?, ?)`,
+			tool, key, fingerprint, string(encoded), now.Format(time.RFC3339))
+		if err != nil {
+			slog.Warn("idempotency: store failed", slog.String("tool", tool), slog.Any("error", err))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestIdempotent(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	var runs atomic.Int32
+	call := func(input string) (*string, error) {
+		return Idempotent(ctx, "job_tracker_add", "key-1", input, func() (*string, error) {
+			runs.Add(1)
+			out := "id-1"
+			return &out, nil
+		})
+	}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got, err := call("Go Dev"); err != nil || *got != "id-1" {
+				t.Errorf("call = %v, %v", got, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := runs.Load(); n != 1 {
+		t.Errorf("fn ran %d times, want 1", n)
+	}
+	if _, err := call("SRE"); err == nil {
+		t.Error("reusing the key with different input should fail")
+	}
+
+	idempotencyLocksMu.Lock()
+	defer idempotencyLocksMu.Unlock()
+	if len(idempotencyLocks) != 0 {
+		t.Errorf("%d idempotency locks left after the calls returned", len(idempotencyLocks))
+	}
+}
@@ -55,7 +55,7 @@ func SearchImmunefi(ctx context.Context, limit int) ([]engine.SecurityProgram, e
 }
 
 func fetchImmunefi(ctx context.Context) ([]engine.SecurityProgram, error) {
-	fetchCtx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, immunefiAPIURL, nil)
@@ -64,7 +64,7 @@ func fetchImmunefi(ctx context.Context) ([]engine.SecurityProgram, error) {
 	}
 	req.Header.Set("User-Agent", engine.UserAgentBot)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("immunefi request failed: %w", err)
 	}
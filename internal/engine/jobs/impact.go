@@ -0,0 +1,214 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Impact-sector boards: Idealist (nonprofits), the 80,000 Hours job board (high-impact
+// roles) and ReliefWeb (humanitarian and NGO jobs). job_search queries them with
+// platform=impact. Nonprofit pay runs below the private sector, so their listings are
+// rated against a salary floor and target lowered by GO_JOB_IMPACT_SALARY_DISCOUNT.
+
+const (
+	reliefWebAPIURL      = "https://api.reliefweb.int/v1/jobs"
+	idealistSiteSearch   = "site:idealist.org"
+	eightyKSiteSearch    = "site:jobs.80000hours.org"
+	impactSourceIdealist = "Idealist"
+	impactSource80k      = "80,000 Hours"
+	impactSourceRW       = "ReliefWeb"
+)
+
+var (
+	idealistJobRe = regexp.MustCompile(`idealist\.org/[a-z]{2}/[a-z-]*job/`)
+	eightyKJobRe  = regexp.MustCompile(`jobs\.80000hours\.org/`)
+	// impactHosts are the boards whose listings get the impact salary discount.
+	impactHosts = []string{"idealist.org", "80000hours.org", "reliefweb.int"}
+)
+
+type reliefWebResponse struct {
+	Data []struct {
+		ID     string `json:"id"`
+		Fields struct {
+			Title    string `json:"title"`
+			URLAlias string `json:"url_alias"`
+			URL      string `json:"url"`
+			Body     string `json:"body"`
+			Source   []struct {
+				Name string `json:"name"`
+			} `json:"source"`
+			Country []struct {
+				Name string `json:"name"`
+			} `json:"country"`
+			Date struct {
+				Created string `json:"created"`
+				Closing string `json:"closing"`
+			} `json:"date"`
+		} `json:"fields"`
+	} `json:"data"`
+}
+
+// SearchReliefWebJobs queries the ReliefWeb jobs API. Results are cached.
+func SearchReliefWebJobs(ctx context.Context, query, location string, limit int) ([]engine.SearxngResult, error) {
+	if limit <= 0 || limit > 50 {
+		limit = 15
+	}
+	cacheKey := engine.CacheKey("reliefweb_jobs", query, location, strconv.Itoa(limit))
+	if cached, ok := engine.CacheLoadJSON[[]engine.SearxngResult](ctx, cacheKey); ok {
+		return cached, nil
+	}
+	engine.IncrImpactRequests(ctx)
+
+	params := url.Values{}
+	params.Set("appname", engine.ConfigFrom(ctx).ReliefWebAppName)
+	params.Set("query[value]", strings.TrimSpace(query+" "+location))
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("sort[]", "date.created:desc")
+	for _, f := range []string{"title", "url_alias", "url", "body", "source.name", "country.name", "date.created", "date.closing"} {
+		params.Add("fields[include][]", f)
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, reliefWebAPIURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", engine.UserAgentChrome)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("reliefweb request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reliefweb returned status %d", resp.StatusCode)
+	}
+
+	var results []engine.SearxngResult
+	err = engine.ReadBody(resp.Body, 5*1024*1024, func(body []byte) (err error) {
+		results, err = parseReliefWebResponse(body)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	engine.CacheStoreJSON(ctx, cacheKey, query, results)
+	slog.Debug("reliefweb: search complete", slog.Int("results", len(results)))
+	return results, nil
+}
+
+func parseReliefWebResponse(data []byte) ([]engine.SearxngResult, error) {
+	var resp reliefWebResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("reliefweb: JSON parse failed: %w", err)
+	}
+	results := make([]engine.SearxngResult, 0, len(resp.Data))
+	for _, d := range resp.Data {
+		f := d.Fields
+		link := f.URLAlias
+		if link == "" {
+			link = f.URL
+		}
+		if link == "" || f.Title == "" {
+			continue
+		}
+		var orgs, countries []string
+		for _, s := range f.Source {
+			orgs = append(orgs, s.Name)
+		}
+		for _, c := range f.Country {
+			countries = append(countries, c.Name)
+		}
+		var content strings.Builder
+		fmt.Fprintf(&content, "**Source:** %s | **Company:** %s | **Location:** %s", impactSourceRW, strings.Join(orgs, ", "), strings.Join(countries, ", "))
+		if created := dateOnly(f.Date.Created); created != "" {
+			content.WriteString(" | **Posted:** " + created)
+		}
+		if closing := dateOnly(f.Date.Closing); closing != "" {
+			content.WriteString(" | **Apply by:** " + closing)
+		}
+		if f.Body != "" {
+			content.WriteString("\n\n" + engine.TrimContent(f.Body, 3000))
+		}
+		results = append(results, engine.SearxngResult{Title: f.Title, URL: link, Content: content.String(), Score: 0.8})
+	}
+	return results, nil
+}
+
+// dateOnly trims an ISO timestamp to its date.
+func dateOnly(ts string) string {
+	if len(ts) >= 10 {
+		return ts[:10]
+	}
+	return ts
+}
+
+// SearchIdealistJobs finds Idealist nonprofit job pages via SearXNG; Idealist has no
+// open API.
+func SearchIdealistJobs(ctx context.Context, query, location string, limit int) ([]engine.SearxngResult, error) {
+	return searchImpactSite(ctx, query, location, idealistSiteSearch, impactSourceIdealist, idealistJobRe, limit)
+}
+
+// Search80000HoursJobs finds 80,000 Hours job board listings via SearXNG.
+func Search80000HoursJobs(ctx context.Context, query, location string, limit int) ([]engine.SearxngResult, error) {
+	return searchImpactSite(ctx, query, location, eightyKSiteSearch, impactSource80k, eightyKJobRe, limit)
+}
+
+func searchImpactSite(ctx context.Context, query, location, site, source string, jobRe *regexp.Regexp, limit int) ([]engine.SearxngResult, error) {
+	engine.IncrImpactRequests(ctx)
+	searxQuery := strings.Join(strings.Fields(query+" "+location+" "+site), " ")
+	searxResults, err := engine.SearchSearXNG(ctx, searxQuery, "all", "", engine.DefaultSearchEngine)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	var results []engine.SearxngResult
+	for _, r := range searxResults {
+		if !jobRe.MatchString(r.URL) {
+			continue
+		}
+		r.Content = "**Source:** " + source + "\n\n" + r.Content
+		r.Score = 0.8
+		results = append(results, r)
+		if len(results) >= limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// IsImpactListing reports whether a listing comes from an impact-sector board.
+func IsImpactListing(j engine.JobListing) bool {
+	u, err := url.Parse(j.URL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, h := range impactHosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+// compProfileFor returns the profile a listing's pay is rated against: for impact-sector
+// listings, the salary floor and target lowered by GO_JOB_IMPACT_SALARY_DISCOUNT percent.
+func compProfileFor(ctx context.Context, j engine.JobListing, p *UserProfile) *UserProfile {
+	discount := engine.ConfigFrom(ctx).ImpactSalaryDiscount
+	if discount <= 0 || discount >= 100 || !IsImpactListing(j) {
+		return p
+	}
+	adjusted := *p
+	adjusted.SalaryFloor = p.SalaryFloor * (100 - discount) / 100
+	adjusted.TargetComp = p.TargetComp * (100 - discount) / 100
+	return &adjusted
+}
@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestParseReliefWebResponse(t *testing.T) {
+	data := []byte(`{"data":[
+		{"id":"1","fields":{"title":"Program Officer","url_alias":"https://reliefweb.int/job/1/program-officer","body":"Coordinate cash assistance.","source":[{"name":"UNICEF"}],"country":[{"name":"Kenya"}],"date":{"created":"2026-10-01T00:00:00+00:00","closing":"2026-10-30T00:00:00+00:00"}}},
+		{"id":"2","fields":{"title":""}}
+	]}`)
+	results, err := parseReliefWebResponse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].URL != "https://reliefweb.int/job/1/program-officer" {
+		t.Fatalf("results = %+v", results)
+	}
+	for _, want := range []string{"**Company:** UNICEF", "**Location:** Kenya", "**Posted:** 2026-10-01", "**Apply by:** 2026-10-30", "cash assistance"} {
+		if !strings.Contains(results[0].Content, want) {
+			t.Errorf("content %q missing %q", results[0].Content, want)
+		}
+	}
+}
+
+func TestImpactCompAdjustment(t *testing.T) {
+	ctx := engine.WithEngine(context.Background(), engine.New(engine.Config{ImpactSalaryDiscount: 20}))
+
+	pay := 85000
+	listings := []engine.JobListing{
+		{Title: "Startup Go", URL: "https://jobs.lever.co/acme/1", SalaryMax: &pay, SalaryCurrency: "USD"},
+		{Title: "Nonprofit Go", URL: "https://www.idealist.org/en/nonprofit-job/abc", SalaryMax: &pay, SalaryCurrency: "USD"},
+	}
+	p := &UserProfile{SalaryFloor: 100000, TargetComp: 120000}
+	kept, dropped := ApplyCompPreferences(ctx, listings, p, false)
+	// 85k is below the 100k floor, but above the 80k impact floor and below the 96k impact target.
+	if dropped != 1 || len(kept) != 1 || kept[0].Title != "Nonprofit Go" || kept[0].Scores.CompFit != CompBelowTarget {
+		t.Fatalf("kept %+v, dropped %d", kept, dropped)
+	}
+	if !IsImpactListing(engine.JobListing{URL: "https://reliefweb.int/job/1"}) || IsImpactListing(engine.JobListing{URL: "https://example.org/idealist.org"}) {
+		t.Error("IsImpactListing host check is wrong")
+	}
+}
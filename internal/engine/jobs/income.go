@@ -0,0 +1,194 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Contract vs permanent income comparison ---
+
+// Defaults for income_compare assumptions.
+const (
+	incomeHoursPerWeek   = 40
+	incomeUnpaidWeeks    = 6    // vacation, public holidays, sick days and gaps between contracts
+	incomeBenefitsRate   = 0.20 // employer-paid health, pension and other benefits, as a share of salary
+	incomeContractTax    = 0.30 // effective tax and social contributions on contract income
+	incomeSalaryTax      = 0.25 // effective tax and social contributions on a salary
+	incomeContractCosts  = 0.05 // insurance, accounting, equipment, as a share of contract revenue
+	incomeWorkDaysInWeek = 5
+)
+
+// IncomeCompareResult is the structured output of income_compare.
+type IncomeCompareResult struct {
+	From        IncomeFigure     `json:"from"`
+	Equivalent  IncomeFigure     `json:"equivalent"`
+	Assumptions IncomeAssumption `json:"assumptions"`
+	Summary     string           `json:"summary"`
+}
+
+// IncomeFigure is one side of the comparison in every unit.
+type IncomeFigure struct {
+	Type      string  `json:"type"` // "contract" or "salary"
+	Currency  string  `json:"currency"`
+	Hourly    float64 `json:"hourly"`
+	Daily     float64 `json:"daily"`
+	Monthly   float64 `json:"monthly"`
+	Annual    float64 `json:"annual"`     // gross per year
+	NetAnnual float64 `json:"net_annual"` // take-home plus benefits value, per year
+}
+
+// IncomeAssumption records the rates used.
+type IncomeAssumption struct {
+	HoursPerWeek  float64 `json:"hours_per_week"`
+	PaidWeeks     float64 `json:"paid_weeks"` // contract weeks billed per year
+	BenefitsRate  float64 `json:"benefits_rate"`
+	ContractTax   float64 `json:"contract_tax_rate"`
+	SalaryTax     float64 `json:"salary_tax_rate"`
+	ContractCosts float64 `json:"contract_costs_rate"`
+	ExchangeRate  float64 `json:"exchange_rate,omitempty"` // target currency per input currency
+}
+
+// CompareIncome converts a contract rate to the salary with the same net value, or a
+// salary to the equivalent contract rate. A contractor bills only paid_weeks a year and
+// covers their own costs and benefits; an employee is paid all 52 weeks plus benefits.
+func CompareIncome(input engine.IncomeCompareInput) (*IncomeCompareResult, error) {
+	if input.Amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	kind := strings.ToLower(input.Type)
+	if kind == "" {
+		kind = "contract"
+	}
+	if kind != "contract" && kind != "salary" {
+		return nil, fmt.Errorf("type must be contract or salary, got %q", input.Type)
+	}
+
+	a := IncomeAssumption{
+		HoursPerWeek:  orDefault(input.HoursPerWeek, incomeHoursPerWeek),
+		PaidWeeks:     52 - orDefault(input.UnpaidWeeks, incomeUnpaidWeeks),
+		BenefitsRate:  orDefault(input.BenefitsRate, incomeBenefitsRate),
+		ContractTax:   orDefault(input.ContractTaxRate, incomeContractTax),
+		SalaryTax:     orDefault(input.SalaryTaxRate, incomeSalaryTax),
+		ContractCosts: orDefault(input.ContractCosts, incomeContractCosts),
+	}
+	if input.UnpaidWeeks < 0 || a.PaidWeeks <= 0 || a.HoursPerWeek <= 0 || a.HoursPerWeek > 100 {
+		return nil, errors.New("unpaid_weeks must be 0-51 and hours_per_week 1-100")
+	}
+	for _, r := range []float64{a.BenefitsRate, a.ContractTax, a.SalaryTax, a.ContractCosts} {
+		if r < 0 || r >= 1 {
+			return nil, errors.New("rates are fractions between 0 and 1 (e.g. 0.3 for 30%)")
+		}
+	}
+
+	currency := strings.ToUpper(input.Currency)
+	if currency == "" {
+		currency = "USD"
+	}
+	target := strings.ToUpper(input.TargetCurrency)
+	if target == "" {
+		target = currency
+	}
+	rate := 1.0
+	if target != currency {
+		from, okFrom := habrRUBRates[currency]
+		to, okTo := habrRUBRates[target]
+		if !okFrom || !okTo {
+			return nil, fmt.Errorf("no exchange rate for %s→%s", currency, target)
+		}
+		rate = from / to
+		a.ExchangeRate = math.Round(rate*10000) / 10000
+	}
+
+	unit := strings.ToLower(input.Unit)
+	if unit == "" {
+		unit = "hour"
+		if kind == "salary" {
+			unit = "year"
+		}
+	}
+
+	contractHours := a.HoursPerWeek * a.PaidWeeks
+	salaryHours := a.HoursPerWeek * 52
+	contractNet := func(annual float64) float64 { return annual * (1 - a.ContractCosts) * (1 - a.ContractTax) }
+	salaryNet := func(annual float64) float64 { return annual * (1 - a.SalaryTax + a.BenefitsRate) }
+
+	result := &IncomeCompareResult{Assumptions: a}
+	if kind == "contract" {
+		annual, err := annualIncome(input.Amount, unit, contractHours, a)
+		if err != nil {
+			return nil, err
+		}
+		net := contractNet(annual)
+		salary := net / (1 - a.SalaryTax + a.BenefitsRate)
+		result.From = incomeFigure("contract", currency, annual, net, contractHours, a.PaidWeeks, 1)
+		result.Equivalent = incomeFigure("salary", target, salary, net, salaryHours, 52, rate)
+		result.Summary = fmt.Sprintf("A %s %s/%s contract (%s/yr gross over %.0f paid weeks) nets about the same as a %s %s/yr salary with %.0f%% benefits.",
+			incomeAmount(input.Amount), currency, unit, incomeAmount(annual), a.PaidWeeks, incomeAmount(result.Equivalent.Annual), target, a.BenefitsRate*100)
+	} else {
+		annual, err := annualIncome(input.Amount, unit, salaryHours, IncomeAssumption{PaidWeeks: 52, HoursPerWeek: a.HoursPerWeek})
+		if err != nil {
+			return nil, err
+		}
+		net := salaryNet(annual)
+		contract := net / ((1 - a.ContractCosts) * (1 - a.ContractTax))
+		result.From = incomeFigure("salary", currency, annual, net, salaryHours, 52, 1)
+		result.Equivalent = incomeFigure("contract", target, contract, net, contractHours, a.PaidWeeks, rate)
+		result.Summary = fmt.Sprintf("A %s %s/yr salary is worth a contract rate of about %s %s/hour (%s/day) over %.0f paid weeks.",
+			incomeAmount(annual), currency, incomeAmount(result.Equivalent.Hourly), target, incomeAmount(result.Equivalent.Daily), a.PaidWeeks)
+	}
+	return result, nil
+}
+
+// annualIncome converts an amount per unit to a yearly gross. Months count only the
+// paid weeks, so a monthly contract rate is not billed during unpaid time.
+func annualIncome(amount float64, unit string, hours float64, a IncomeAssumption) (float64, error) {
+	switch unit {
+	case "hour":
+		return amount * hours, nil
+	case "day":
+		return amount * incomeWorkDaysInWeek * a.PaidWeeks, nil
+	case "month":
+		return amount * 12 * a.PaidWeeks / 52, nil
+	case "year":
+		return amount, nil
+	}
+	return 0, fmt.Errorf("unit must be hour, day, month or year, got %q", unit)
+}
+
+// incomeFigure expresses an annual gross in every unit, converted at rate.
+func incomeFigure(kind, currency string, annual, net, hours, weeks, rate float64) IncomeFigure {
+	round := func(v float64) float64 { return math.Round(v*rate*100) / 100 }
+	return IncomeFigure{
+		Type:      kind,
+		Currency:  currency,
+		Hourly:    round(annual / hours),
+		Daily:     round(annual / (weeks * incomeWorkDaysInWeek)),
+		Monthly:   round(annual / 12),
+		Annual:    round(annual),
+		NetAnnual: round(net),
+	}
+}
+
+func orDefault(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// incomeAmount formats an amount with thousands separators and no cents.
+func incomeAmount(v float64) string {
+	s := fmt.Sprintf("%.0f", v)
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 && s[i-1] != '-' {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
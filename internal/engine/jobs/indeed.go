@@ -28,6 +28,13 @@ const (
 	indeedSiteSearch = "site:indeed.com/viewjob"
 )
 
+const (
+	// indeedPageSize is the number of jobs requested per GraphQL page.
+	indeedPageSize = 25
+	// indeedMaxPages caps cursor-following so one search costs at most this many calls.
+	indeedMaxPages = 4
+)
+
 // indeedDateRanges maps human-readable time ranges to Indeed GraphQL filter values.
 var indeedDateRanges = map[string]string{
 	"day":   "24h",
@@ -127,7 +134,7 @@ func buildIndeedGraphQLQuery(what, where, timeRange string, limit int, cursor st
 
 // doIndeedGraphQL executes a GraphQL request against the Indeed internal API.
 func doIndeedGraphQL(ctx context.Context, gqlQuery string) (*indeedGraphQLResponse, error) {
-	apiKey := engine.Cfg.IndeedAPIKey
+	apiKey := engine.ConfigFrom(ctx).IndeedAPIKey
 	if apiKey == "" {
 		return nil, errors.New("indeed: no API key configured")
 	}
@@ -148,8 +155,8 @@ func doIndeedGraphQL(ctx context.Context, gqlQuery string) (*indeedGraphQLRespon
 	}
 
 	respBytes, err := engine.RetryDo(ctx, engine.DefaultRetryConfig, func() ([]byte, error) {
-		if engine.Cfg.BrowserClient != nil {
-			data, _, status, e := engine.Cfg.BrowserClient.Do("POST", indeedGraphQLEndpoint, headers, bytes.NewReader(bodyBytes))
+		if engine.ConfigFrom(ctx).BrowserClient != nil {
+			data, _, status, e := engine.ConfigFrom(ctx).BrowserClient.Do("POST", indeedGraphQLEndpoint, headers, bytes.NewReader(bodyBytes))
 			if e != nil {
 				return nil, e
 			}
@@ -166,7 +173,7 @@ func doIndeedGraphQL(ctx context.Context, gqlQuery string) (*indeedGraphQLRespon
 		for k, v := range headers {
 			req.Header.Set(k, v)
 		}
-		resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 		if err != nil {
 			return nil, err
 		}
@@ -180,8 +187,13 @@ func doIndeedGraphQL(ctx context.Context, gqlQuery string) (*indeedGraphQLRespon
 		return nil, err
 	}
 
+	return parseIndeedGraphQL(respBytes)
+}
+
+// parseIndeedGraphQL decodes a GraphQL response, failing on the first GraphQL error.
+func parseIndeedGraphQL(body []byte) (*indeedGraphQLResponse, error) {
 	var gqlResp indeedGraphQLResponse
-	if err := json.Unmarshal(respBytes, &gqlResp); err != nil {
+	if err := json.Unmarshal(body, &gqlResp); err != nil {
 		return nil, fmt.Errorf("indeed: parse response: %w", err)
 	}
 	if len(gqlResp.Errors) > 0 {
@@ -263,29 +275,57 @@ func indeedGQLJobToResult(job indeedGQLJob) engine.SearxngResult {
 // searchIndeedGraphQL fetches jobs from Indeed's internal GraphQL API.
 // Returns up to limit results, fetching multiple pages if needed.
 func searchIndeedGraphQL(ctx context.Context, query, location, timeRange string, limit int) ([]engine.SearxngResult, error) {
-	pageLimit := limit
-	if pageLimit > 100 {
-		pageLimit = 100
-	}
-	if pageLimit <= 0 {
-		pageLimit = 15
-	}
-
-	gqlQuery := buildIndeedGraphQLQuery(query, location, timeRange, pageLimit, "")
-	resp, err := doIndeedGraphQL(ctx, gqlQuery)
+	results, err := paginateIndeed(ctx, limit, func(cursor string, n int) (*indeedGraphQLResponse, error) {
+		return doIndeedGraphQL(ctx, buildIndeedGraphQLQuery(query, location, timeRange, n, cursor))
+	})
 	if err != nil {
 		return nil, err
 	}
+	slog.Debug("indeed: graphql search complete", slog.Int("results", len(results)))
+	return results, nil
+}
+
+// paginateIndeed follows pageInfo.nextCursor until limit jobs are collected, the
+// cursor runs out, a page comes back empty, or indeedMaxPages is reached. An error
+// on the first page is returned; later errors keep the jobs already collected.
+func paginateIndeed(ctx context.Context, limit int, fetch func(cursor string, n int) (*indeedGraphQLResponse, error)) ([]engine.SearxngResult, error) {
+	if limit <= 0 {
+		limit = 15
+	}
+	if limit > indeedMaxPages*indeedPageSize {
+		limit = indeedMaxPages * indeedPageSize
+	}
 
 	var results []engine.SearxngResult
-	for _, r := range resp.Data.JobSearch.Results {
-		if r.Job.Key == "" {
-			continue
+	seen := make(map[string]bool)
+	cursor := ""
+	for page := 0; page < indeedMaxPages && len(results) < limit; page++ {
+		resp, err := fetch(cursor, min(indeedPageSize, limit-len(results)))
+		if err != nil {
+			if page == 0 {
+				return nil, err
+			}
+			slog.Warn("indeed: pagination stopped", slog.Int("page", page+1), slog.Any("error", err))
+			break
 		}
-		results = append(results, indeedGQLJobToResult(r.Job))
-	}
 
-	slog.Debug("indeed: graphql search complete", slog.Int("results", len(results)))
+		added := 0
+		for _, r := range resp.Data.JobSearch.Results {
+			if r.Job.Key == "" || seen[r.Job.Key] {
+				continue
+			}
+			seen[r.Job.Key] = true
+			results = append(results, indeedGQLJobToResult(r.Job))
+			added++
+		}
+		cursor = resp.Data.JobSearch.PageInfo.NextCursor
+		if added == 0 || cursor == "" || ctx.Err() != nil {
+			break
+		}
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
 	return results, nil
 }
 
@@ -297,7 +337,7 @@ func SearchIndeedJobs(ctx context.Context, query, location string, limit int) ([
 
 // SearchIndeedJobsFiltered searches Indeed with optional jobType and timeRange filters.
 func SearchIndeedJobsFiltered(ctx context.Context, query, location, jobType, timeRange string, limit int) ([]engine.SearxngResult, error) {
-	engine.IncrIndeedRequests()
+	engine.IncrIndeedRequests(ctx)
 
 	// Try GraphQL API first (direct, no SearXNG dependency)
 	results, err := searchIndeedGraphQL(ctx, query, location, timeRange, limit)
@@ -326,14 +366,14 @@ func searchIndeedViaSearxng(ctx context.Context, query, location string, limit i
 	gCh := make(chan searchRes, 1)
 	bCh := make(chan searchRes, 1)
 
-	go func() {
+	engine.GoOutbound(ctx, func() {
 		r, err := engine.SearchSearXNG(ctx, searxQuery, "all", "", engine.DefaultSearchEngine)
 		gCh <- searchRes{r, err}
-	}()
-	go func() {
+	})
+	engine.GoOutbound(ctx, func() {
 		r, err := engine.SearchSearXNG(ctx, searxQuery, "all", "", engine.DefaultSearchEngine)
 		bCh <- searchRes{r, err}
-	}()
+	})
 
 	gr := <-gCh
 	br := <-bCh
@@ -376,10 +416,10 @@ func searchIndeedViaSearxng(ctx context.Context, query, location string, limit i
 	}
 
 	for i := 0; i < fetchCount; i++ {
-		go func(idx int, r engine.SearxngResult) {
-			content := fetchIndeedJobContent(ctx, r)
-			enrichCh <- enrichResult{idx, content}
-		}(i, merged[i])
+		idx, r := i, merged[i]
+		engine.GoOutbound(ctx, func() {
+			enrichCh <- enrichResult{idx, fetchIndeedJobContent(ctx, r)}
+		})
 	}
 
 	for i := 0; i < fetchCount; i++ {
@@ -397,16 +437,16 @@ func searchIndeedViaSearxng(ctx context.Context, query, location string, limit i
 // when available, falling back to engine.FetchURLContent.
 // Indeed blocks non-browser TLS fingerprints similarly to LinkedIn.
 func indeedRequest(ctx context.Context, targetURL string) (string, error) {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
-	if engine.Cfg.BrowserClient != nil {
+	if engine.ConfigFrom(ctx).BrowserClient != nil {
 		headers := engine.ChromeHeaders()
 		headers["referer"] = "https://www.indeed.com/"
 		headers["accept"] = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
 
 		data, err := engine.RetryDo(ctx, engine.DefaultRetryConfig, func() ([]byte, error) {
-			d, _, s, e := engine.Cfg.BrowserClient.Do("GET", targetURL, headers, nil)
+			d, _, s, e := engine.ConfigFrom(ctx).BrowserClient.Do("GET", targetURL, headers, nil)
 			if e != nil {
 				return nil, e
 			}
@@ -425,32 +465,21 @@ func indeedRequest(ctx context.Context, targetURL string) (string, error) {
 	return text, err
 }
 
-// fetchIndeedJobContent fetches an Indeed job page and extracts structured content.
+// fetchIndeedJobContent fetches an Indeed job page and extracts structured content,
+// falling back to the SearXNG snippet.
 func fetchIndeedJobContent(ctx context.Context, r engine.SearxngResult) string {
-	bodyText, err := indeedRequest(ctx, r.URL)
-	if err != nil || bodyText == "" {
-		if r.Content != "" {
-			return "**Source:** Indeed\n\n" + r.Content
-		}
-		return ""
+	if text, err := fetchIndeedPosting(ctx, r.URL); err == nil {
+		return text
 	}
-
-	// Try to parse JSON-LD JobPosting from the page.
-	if structured := extractIndeedStructured(bodyText); structured != "" {
-		return structured
-	}
-
-	// Fall back to snippet from SearXNG.
-	content := "**Source:** Indeed"
-	if r.Content != "" {
-		content += "\n\n" + engine.TruncateRunes(r.Content, 800, "...")
+	if r.Content == "" {
+		return ""
 	}
-	return content
+	return "**Source:** Indeed\n\n" + engine.TruncateRunes(r.Content, 800, "...")
 }
 
 // extractIndeedStructured tries to extract job info from Indeed page HTML/text.
 func extractIndeedStructured(body string) string {
-	// Indeed embeds JSON-LD with schema.org/JobPosting — reuse LinkedIn's extractor logic.
+	// Indeed embeds JSON-LD with schema.org/JobPosting.
 	if jsonLD := extractJSONLD(body); jsonLD != "" {
 		return "**Source:** Indeed\n\n" + jsonLD
 	}
@@ -465,8 +494,8 @@ func extractIndeedStructured(body string) string {
 
 	// Indeed uses data-testid attributes for key fields.
 	testIDs := map[string]string{
-		"jobsearch-JobInfoHeader-title":          "**Title:**",
-		"inlineHeader-companyName":               "**Company:**",
+		"jobsearch-JobInfoHeader-title":           "**Title:**",
+		"inlineHeader-companyName":                "**Company:**",
 		"jobsearch-JobInfoHeader-companyLocation": "**Location:**",
 	}
 	for testID, label := range testIDs {
@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// fakeIndeedPage returns a response with n jobs keyed from start and the given cursor.
+func fakeIndeedPage(start, n int, next string) *indeedGraphQLResponse {
+	var resp indeedGraphQLResponse
+	for i := start; i < start+n; i++ {
+		var r struct {
+			Job indeedGQLJob `json:"job"`
+		}
+		r.Job.Key = fmt.Sprintf("k%d", i)
+		r.Job.Title = "Go Engineer"
+		resp.Data.JobSearch.Results = append(resp.Data.JobSearch.Results, r)
+	}
+	resp.Data.JobSearch.PageInfo.NextCursor = next
+	return &resp
+}
+
+func TestPaginateIndeed_FollowsCursor(t *testing.T) {
+	var cursors []string
+	results, err := paginateIndeed(context.Background(), 50, func(cursor string, n int) (*indeedGraphQLResponse, error) {
+		cursors = append(cursors, cursor)
+		start := (len(cursors) - 1) * indeedPageSize
+		return fakeIndeedPage(start, n, fmt.Sprintf("c%d", len(cursors))), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 50 {
+		t.Errorf("got %d results, want 50", len(results))
+	}
+	if len(cursors) != 2 || cursors[0] != "" || cursors[1] != "c1" {
+		t.Errorf("cursors = %v, want [\"\" c1]", cursors)
+	}
+}
+
+func TestPaginateIndeed_StopsEarly(t *testing.T) {
+	calls := 0
+	results, err := paginateIndeed(context.Background(), 50, func(cursor string, n int) (*indeedGraphQLResponse, error) {
+		calls++
+		switch calls {
+		case 1:
+			return fakeIndeedPage(0, n, "c1"), nil
+		case 2:
+			return fakeIndeedPage(0, 3, "c2"), nil // all duplicates → empty page
+		}
+		return nil, errors.New("should not be called")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 || len(results) != indeedPageSize {
+		t.Errorf("calls = %d, results = %d; want 2, %d", calls, len(results), indeedPageSize)
+	}
+
+	// A failure after the first page keeps what was collected.
+	calls = 0
+	results, err = paginateIndeed(context.Background(), 50, func(cursor string, n int) (*indeedGraphQLResponse, error) {
+		calls++
+		if calls == 2 {
+			return nil, errors.New("rate limited")
+		}
+		return fakeIndeedPage(0, n, "c1"), nil
+	})
+	if err != nil || len(results) != indeedPageSize {
+		t.Errorf("got %d results, err %v; want the first page kept", len(results), err)
+	}
+
+	if _, err := paginateIndeed(context.Background(), 10, func(string, int) (*indeedGraphQLResponse, error) {
+		return nil, errors.New("down")
+	}); err == nil {
+		t.Error("want error when the first page fails")
+	}
+}
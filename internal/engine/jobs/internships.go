@@ -0,0 +1,131 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Internship mode: job_search internships=true sets the internship experience level and
+// job type (LinkedIn f_E=1, f_JT=I), adds "internship" to free-text queries (Indeed,
+// SearXNG), drops listings that are not internships and checks the master resume for
+// the education and projects internship applications lean on. resume_generate formats
+// resumes of candidates with little experience education-first.
+
+// internshipLevel is the experience level and job type internship mode searches for.
+const internshipLevel = "internship"
+
+// earlyCareerYears is the total experience below which resume_generate treats the
+// candidate as early career.
+const earlyCareerYears = 2.0
+
+var (
+	internshipRe  = regexp.MustCompile(`(?i)\b(intern(?:ship)?s?|co-?op|working student|werkstudent(?:in)?|trainee(?:ship)?|placement year|industrial placement|summer (?:analyst|associate)|praktikum|praktikant(?:in)?|stagiaire|alternance|стаж[её]р|стажировка)\b`)
+	juniorLevelRe = regexp.MustCompile(`(?i)\b(intern(?:ship)?|junior|entry|graduate|trainee|student)\b`)
+)
+
+// ApplyInternshipMode narrows a job_search input to internships.
+func ApplyInternshipMode(input *engine.JobSearchInput) {
+	input.Experience = internshipLevel
+	input.JobType = internshipLevel
+}
+
+// InternshipQuery adds "internship" to a free-text query that does not already ask for one.
+func InternshipQuery(query string) string {
+	if internshipRe.MatchString(query) {
+		return query
+	}
+	return strings.TrimSpace(query + " " + internshipLevel)
+}
+
+// isInternship reports whether a listing is an internship, trainee or working-student
+// post. The description is not checked: full-time posts often mention interns.
+func isInternship(j engine.JobListing) bool {
+	return internshipRe.MatchString(j.Title + "\n" + j.JobType + "\n" + j.Experience)
+}
+
+// FilterInternships keeps internship listings, returning how many were dropped.
+func FilterInternships(listings []engine.JobListing) ([]engine.JobListing, int) {
+	out := listings[:0:0]
+	for _, j := range listings {
+		if isInternship(j) {
+			out = append(out, j)
+		}
+	}
+	return out, len(listings) - len(out)
+}
+
+// InternshipDroppedNote is the summary suffix for FilterInternships.
+func InternshipDroppedNote(dropped int) string {
+	if dropped == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" Hid %d listing(s) that are not internships; set internships=false to see them.", dropped)
+}
+
+// StudentProfileWarnings checks the master resume for what internship applications are
+// judged on: education with a (expected) graduation date, and projects. It returns nil
+// without a resume database or master resume.
+func StudentProfileWarnings(ctx context.Context) []string {
+	db := resumeStore(ctx)
+	if db == nil {
+		return nil
+	}
+	personID := db.GetLatestPersonID(ctx)
+	if personID == 0 {
+		return nil
+	}
+	var warnings []string
+	edus, _ := db.GetAllEducations(ctx, personID)
+	switch {
+	case len(edus) == 0:
+		warnings = append(warnings, "the master resume has no education; internship applications are judged on it first, so add your degree program to the resume and rebuild it")
+	case !hasGraduationDate(edus):
+		warnings = append(warnings, "no education entry has a graduation date; add the expected one (e.g. \"expected May 2027\"), recruiters filter internships by it")
+	}
+	if projects, _ := db.GetAllProjects(ctx, personID); len(projects) == 0 {
+		warnings = append(warnings, "the master resume has no projects; with little work experience, course, personal or hackathon projects carry the resume")
+	}
+	return warnings
+}
+
+func hasGraduationDate(edus []EducationRecord) bool {
+	for _, e := range edus {
+		if strings.TrimSpace(e.EndDate) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// StudentProfileNote is the summary suffix for StudentProfileWarnings.
+func StudentProfileNote(warnings []string) string {
+	if len(warnings) == 0 {
+		return ""
+	}
+	return " Student profile: " + strings.Join(warnings, "; ") + "."
+}
+
+// isEarlyCareer reports whether a resume for the JD seniority should be formatted for a
+// candidate with little experience: the role is an internship or junior one, or the
+// candidate has under earlyCareerYears of experience.
+func isEarlyCareer(seniority string, exps []ExperienceRecord, now time.Time) bool {
+	if juniorLevelRe.MatchString(seniority) {
+		return true
+	}
+	return computeExperienceYears(exps, nil, nil, now).TotalYears < earlyCareerYears
+}
+
+// earlyCareerGuidelines are the resume_generate formatting rules for early-career candidates.
+const earlyCareerGuidelines = `EARLY-CAREER CANDIDATE (little professional experience) — adapt the format:
+- Put Education directly after the summary: degree, school, (expected) graduation date, GPA if 3.0+ or equivalent, relevant coursework and honors from the highlights
+- Put a Projects section before Experience; course, personal, research and hackathon projects count
+- Present internships, part-time jobs, teaching/research assistant roles and volunteering as experience
+- Lead the summary with the degree program and the strongest project, not years of experience
+- Keep it to one page
+
+`
@@ -0,0 +1,79 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestInternshipQuery(t *testing.T) {
+	if got := InternshipQuery("data analyst"); got != "data analyst internship" {
+		t.Errorf("InternshipQuery = %q", got)
+	}
+	for _, q := range []string{"software intern", "werkstudent backend", "summer internship ML"} {
+		if got := InternshipQuery(q); got != q {
+			t.Errorf("InternshipQuery(%q) = %q, want unchanged", q, got)
+		}
+	}
+}
+
+func TestFilterInternships(t *testing.T) {
+	listings := []engine.JobListing{
+		{Title: "Software Engineering Intern"},
+		{Title: "Backend Developer", JobType: "Internship"},
+		{Title: "Werkstudent Data Engineering"},
+		{Title: "Senior Backend Engineer", Description: "Mentor our interns"},
+		{Title: "Staff Engineer"},
+	}
+	kept, dropped := FilterInternships(listings)
+	if dropped != 2 || len(kept) != 3 {
+		t.Fatalf("kept %d, dropped %d: %+v", len(kept), dropped, kept)
+	}
+	if note := InternshipDroppedNote(dropped); !strings.Contains(note, "internships=false") {
+		t.Errorf("note = %q", note)
+	}
+}
+
+func TestApplyInternshipMode(t *testing.T) {
+	input := engine.JobSearchInput{Query: "go", Experience: "senior", Internships: true}
+	ApplyInternshipMode(&input)
+	if input.Experience != "internship" || input.JobType != "internship" {
+		t.Errorf("input = %+v", input)
+	}
+}
+
+func TestStudentProfileWarnings(t *testing.T) {
+	ctx, rs, _ := withMemoryStores(t)
+	if w := StudentProfileWarnings(ctx); w != nil {
+		t.Fatalf("warnings without a master resume: %v", w)
+	}
+	personID, _ := rs.InsertPerson(ctx, PersonRecord{Name: "Sam Student"})
+	if w := StudentProfileWarnings(ctx); len(w) != 2 || !strings.Contains(w[0], "no education") {
+		t.Fatalf("empty profile warnings = %v", w)
+	}
+	_, _ = rs.InsertEducation(ctx, personID, EducationRecord{School: "TU Berlin", Degree: "BSc"})
+	if w := StudentProfileWarnings(ctx); len(w) != 2 || !strings.Contains(w[0], "graduation date") {
+		t.Fatalf("warnings without graduation date = %v", w)
+	}
+	_, _ = rs.InsertEducation(ctx, personID, EducationRecord{School: "TU Berlin", Degree: "MSc", EndDate: "2027-07"})
+	_, _ = rs.InsertProject(ctx, personID, ProjectRecord{Name: "Compiler"})
+	if w := StudentProfileWarnings(ctx); len(w) != 0 {
+		t.Errorf("complete profile warnings = %v", w)
+	}
+}
+
+func TestIsEarlyCareer(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	veteran := []ExperienceRecord{{Title: "Engineer", StartDate: "2018-01", EndDate: "2026-01"}}
+	if !isEarlyCareer("Internship", veteran, now) {
+		t.Error("internship role not early career")
+	}
+	if isEarlyCareer("Senior", veteran, now) {
+		t.Error("eight years of experience treated as early career")
+	}
+	if !isEarlyCareer("Mid", []ExperienceRecord{{Title: "Intern", StartDate: "2025-06", EndDate: "2025-09"}}, now) {
+		t.Error("three months of experience not early career")
+	}
+}
@@ -14,11 +14,12 @@ import (
 
 // InterviewQuestion is a single Q&A item for interview prep.
 type InterviewQuestion struct {
-	Category    string `json:"category"`     // behavioral, technical, system_design
+	Category    string `json:"category"` // behavioral, technical, system_design
 	Question    string `json:"question"`
-	WhyAsked    string `json:"why_asked"`    // why this is relevant for the role
-	ModelAnswer string `json:"model_answer"` // answer using candidate's actual projects/experience
-	Tips        string `json:"tips"`         // delivery tips
+	WhyAsked    string `json:"why_asked"`       // why this is relevant for the role
+	ModelAnswer string `json:"model_answer"`    // answer using candidate's actual projects/experience
+	Tips        string `json:"tips"`            // delivery tips
+	Story       string `json:"story,omitempty"` // STAR story bank title the answer uses
 }
 
 // InterviewPrepResult is the structured output of interview_prep.
@@ -28,6 +29,9 @@ type InterviewPrepResult struct {
 	Questions []InterviewQuestion `json:"questions"`
 	Pitch     string              `json:"pitch"`   // 30-sec elevator pitch for this role
 	Summary   string              `json:"summary"` // overall prep advice
+	// ReportedExperience is what candidates report about the company's interviews,
+	// with source links.
+	ReportedExperience *InterviewExperiences `json:"reported_experience,omitempty"`
 }
 
 const interviewPrepPrompt = `You are an expert interview coach who prepares candidates with personalized questions and model answers grounded in their actual experience.
@@ -64,7 +68,8 @@ Return a JSON object with this exact structure:
       "question": "<the interview question>",
       "why_asked": "<why this question is relevant for this specific role>",
       "model_answer": "<detailed answer using candidate's actual experience, 3-5 sentences>",
-      "tips": "<delivery tip: tone, what to emphasize, common pitfalls>"
+      "tips": "<delivery tip: tone, what to emphasize, common pitfalls>",
+      "story": "<title of the candidate STAR story the answer uses, or empty string>"
     }
   ],
   "pitch": "<30-second elevator pitch tailored to this role, referencing top achievements>",
@@ -74,7 +79,8 @@ Return a JSON object with this exact structure:
 Return ONLY the JSON object, no markdown, no explanation.`
 
 // PrepareInterview generates personalized interview Q&A from resume and job description.
-// If company is provided, enriches questions with company research context.
+// If company is provided, enriches questions with company research, news and the
+// interview experiences candidates reported.
 func PrepareInterview(ctx context.Context, resume, jobDescription, company, focus string) (*InterviewPrepResult, error) {
 	resumeTrunc := engine.TruncateRunes(resume, 4000, "")
 	jdTrunc := engine.TruncateRunes(jobDescription, 3000, "")
@@ -89,16 +95,32 @@ func PrepareInterview(ctx context.Context, resume, jobDescription, company, focu
 
 	// Optional company enrichment
 	var companyContext string
+	var experiences *InterviewExperiences
 	if company != "" {
+		expCh := make(chan *InterviewExperiences, 1)
+		go func() {
+			exp, err := MineInterviewExperiences(ctx, company, jdRoleHint(jobDescription))
+			if err != nil {
+				slog.Debug("interview_prep: interview experiences failed", slog.Any("error", err))
+			}
+			expCh <- exp
+		}()
 		res, err := ResearchCompany(ctx, company)
 		if err != nil {
 			slog.Warn("interview_prep: company research failed, proceeding without", slog.Any("error", err))
 		} else {
 			companyContext = BuildCompanyContext(company, res)
 		}
+		if news, err := CompanyNews(ctx, company); err != nil {
+			slog.Debug("interview_prep: company news failed", slog.Any("error", err))
+		} else {
+			companyContext += BuildNewsContext(news)
+		}
+		experiences = <-expCh
+		companyContext += BuildInterviewExperienceContext(experiences)
 	}
 
-	prompt := fmt.Sprintf(interviewPrepPrompt, resumeTrunc, jdTrunc, companyContext, focus)
+	prompt := fmt.Sprintf(interviewPrepPrompt, resumeTrunc, jdTrunc, companyContext+storedStarStoriesContext(ctx)+experienceYearsContext(ctx), focus)
 	raw, err := engine.CallLLM(ctx, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("interview_prep LLM: %w", err)
@@ -114,5 +136,8 @@ func PrepareInterview(ctx context.Context, resume, jobDescription, company, focu
 	if err := json.Unmarshal([]byte(raw), &result); err != nil {
 		return nil, fmt.Errorf("interview_prep parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
 	}
+	if experiences != nil && len(experiences.Reports) > 0 {
+		result.ReportedExperience = experiences
+	}
 	return &result, nil
 }
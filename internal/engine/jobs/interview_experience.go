@@ -0,0 +1,259 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Interview experiences ---
+
+// Candidates' reports of a company's interview process and the questions they were
+// asked, mined from Glassdoor, Blind and levels.fyi. Pages are fetched with the stealth
+// browser client (these sites block non-browser TLS fingerprints); when a fetch fails
+// the search snippet is used instead.
+
+const (
+	interviewPagesMax     = 6
+	interviewQuestionsMax = 12
+	interviewProcessMax   = 6
+)
+
+// interviewSites are the sources searched, with their labels.
+var interviewSites = []struct{ label, site string }{
+	{"glassdoor", "glassdoor.com/Interview"},
+	{"blind", "teamblind.com"},
+	{"levels.fyi", "levels.fyi"},
+}
+
+// InterviewReport is what one source page says about the interview.
+type InterviewReport struct {
+	Source     string   `json:"source"` // "glassdoor", "blind", "levels.fyi"
+	URL        string   `json:"url"`
+	Title      string   `json:"title"`
+	Difficulty string   `json:"difficulty,omitempty"` // "easy", "average", "difficult" when stated
+	Process    []string `json:"process,omitempty"`
+	Questions  []string `json:"questions,omitempty"`
+}
+
+// InterviewExperiences aggregates the reports for a company and role.
+type InterviewExperiences struct {
+	Company   string            `json:"company"`
+	Role      string            `json:"role,omitempty"`
+	Process   []string          `json:"process,omitempty"`   // reported process steps, deduplicated
+	Questions []string          `json:"questions,omitempty"` // reported questions, deduplicated
+	Reports   []InterviewReport `json:"reports"`
+}
+
+var (
+	interviewProcessRe    = regexp.MustCompile(`(?i)\b(phone screens?|recruiter (call|screen)|hr (call|screen)|onsites?|on-site|take[- ]home|coding (round|challenge|test|exercise)|technical (round|interview|screen)|system design|behavioral (round|interview)|hiring manager|final round|panel|\d+ rounds?|(two|three|four|five) rounds?|live coding|pair programming|hackerrank|codesignal|leetcode)\b`)
+	interviewDifficultyRe = regexp.MustCompile(`(?i)\b(easy|average|difficult) interview\b`)
+	sentenceSplitRe       = regexp.MustCompile(`(?:[.!?]["')\]]?\s+|\n+)`)
+	questionSplitRe       = regexp.MustCompile(`[^.!?\n]{12,220}\?`)
+	// interviewNoiseRe matches site chrome that reads like a question.
+	interviewNoiseRe = regexp.MustCompile(`(?i)(was this (review |interview )?helpful|is this your company|sign (in|up)|log ?in|cookies?|are you (a|an) |want to|looking for|did you|have you been|helpful\?|see more|read more|join (the )?conversation|download the app)`)
+)
+
+// MineInterviewExperiences searches the interview-experience sites for company (and
+// role, when known) and extracts the process and reported questions. Cached per
+// company and role.
+func MineInterviewExperiences(ctx context.Context, company, role string) (*InterviewExperiences, error) {
+	company = strings.TrimSpace(company)
+	role = strings.TrimSpace(role)
+	cacheKey := engine.CacheKey("interview_experiences", strings.ToLower(company), strings.ToLower(role))
+	if cached, ok := engine.CacheLoadJSON[InterviewExperiences](ctx, cacheKey); ok {
+		return &cached, nil
+	}
+
+	type hit struct {
+		label string
+		res   engine.SearxngResult
+	}
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		hits    []hit
+		lastErr error
+	)
+	for _, s := range interviewSites {
+		wg.Add(1)
+		engine.GoOutbound(ctx, func() {
+			defer wg.Done()
+			q := fmt.Sprintf("%s %s interview questions site:%s", company, role, s.site)
+			results, err := engine.SearchSearXNG(ctx, q, "all", "", engine.DefaultSearchEngine)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				slog.Debug("interview_experiences: search failed", slog.String("site", s.label), slog.Any("error", err))
+				lastErr = err
+				return
+			}
+			for _, r := range results {
+				if strings.Contains(strings.ToLower(r.Title+" "+r.Content), strings.ToLower(company)) {
+					hits = append(hits, hit{s.label, r})
+				}
+			}
+		})
+	}
+	wg.Wait()
+	if len(hits) == 0 && lastErr != nil {
+		return nil, fmt.Errorf("interview_experiences: %w", lastErr)
+	}
+	if len(hits) > interviewPagesMax {
+		hits = hits[:interviewPagesMax]
+	}
+
+	reports := make([]InterviewReport, len(hits))
+	for i, h := range hits {
+		wg.Add(1)
+		engine.GoOutbound(ctx, func() {
+			defer wg.Done()
+			text := h.res.Content
+			if page, err := fetchInterviewPage(ctx, h.res.URL); err != nil {
+				slog.Debug("interview_experiences: fetch failed, using snippet", slog.String("url", h.res.URL), slog.Any("error", err))
+			} else if page != "" {
+				text = page
+			}
+			reports[i] = extractInterviewReport(h.label, h.res, text)
+		})
+	}
+	wg.Wait()
+
+	result := aggregateInterviewReports(company, role, reports)
+	engine.CacheStoreJSON(ctx, cacheKey, company+" "+role, *result)
+	return result, nil
+}
+
+// fetchInterviewPage fetches a page as text, through the stealth browser client when
+// it is configured.
+func fetchInterviewPage(ctx context.Context, pageURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
+	defer cancel()
+
+	if engine.ConfigFrom(ctx).BrowserClient != nil {
+		headers := engine.ChromeHeaders()
+		headers["accept"] = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+		data, err := engine.RetryDo(ctx, engine.DefaultRetryConfig, func() ([]byte, error) {
+			d, _, s, e := engine.ConfigFrom(ctx).BrowserClient.Do("GET", pageURL, headers, nil)
+			if e != nil {
+				return nil, e
+			}
+			if s != http.StatusOK {
+				return nil, fmt.Errorf("interview page status %d", s)
+			}
+			return d, nil
+		})
+		if err != nil {
+			return "", err
+		}
+		return engine.CleanHTML(string(data)), nil
+	}
+
+	_, text, err := engine.FetchURLContent(ctx, pageURL)
+	return text, err
+}
+
+// extractInterviewReport reads the process sentences, reported questions and stated
+// difficulty from a page's text.
+func extractInterviewReport(label string, r engine.SearxngResult, text string) InterviewReport {
+	rep := InterviewReport{Source: label, URL: r.URL, Title: strings.TrimSpace(r.Title)}
+	if m := interviewDifficultyRe.FindStringSubmatch(text); m != nil {
+		rep.Difficulty = strings.ToLower(m[1])
+	}
+	for _, s := range sentenceSplitRe.Split(text, -1) {
+		s = strings.Join(strings.Fields(s), " ")
+		if len(s) < 20 || len(s) > 300 || interviewNoiseRe.MatchString(s) {
+			continue
+		}
+		if interviewProcessRe.MatchString(s) && len(rep.Process) < interviewProcessMax {
+			rep.Process = appendUnique(rep.Process, s)
+		}
+	}
+	for _, q := range questionSplitRe.FindAllString(text, -1) {
+		q = strings.Join(strings.Fields(q), " ")
+		if len(q) < 15 || interviewNoiseRe.MatchString(q) || len(strings.Fields(q)) < 4 {
+			continue
+		}
+		if len(rep.Questions) < interviewQuestionsMax {
+			rep.Questions = appendUnique(rep.Questions, q)
+		}
+	}
+	return rep
+}
+
+// aggregateInterviewReports merges the reports' process steps and questions, keeping
+// only reports that yielded something.
+func aggregateInterviewReports(company, role string, reports []InterviewReport) *InterviewExperiences {
+	out := &InterviewExperiences{Company: company, Role: role, Reports: []InterviewReport{}}
+	for _, rep := range reports {
+		if len(rep.Process) == 0 && len(rep.Questions) == 0 {
+			continue
+		}
+		out.Reports = append(out.Reports, rep)
+		for _, p := range rep.Process {
+			if len(out.Process) < interviewProcessMax {
+				out.Process = appendUnique(out.Process, p)
+			}
+		}
+		for _, q := range rep.Questions {
+			if len(out.Questions) < interviewQuestionsMax {
+				out.Questions = appendUnique(out.Questions, q)
+			}
+		}
+	}
+	return out
+}
+
+// appendUnique appends s unless list already holds it, ignoring case.
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// BuildInterviewExperienceContext formats mined reports as a prompt context block, or
+// "" when there are none.
+func BuildInterviewExperienceContext(exp *InterviewExperiences) string {
+	if exp == nil || len(exp.Reports) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\nREPORTED INTERVIEW EXPERIENCES (candidates on Glassdoor/Blind/levels.fyi; include relevant reported questions and prepare for the reported process):\n")
+	if len(exp.Process) > 0 {
+		b.WriteString("Process:\n")
+		for _, p := range exp.Process {
+			b.WriteString("- " + p + "\n")
+		}
+	}
+	if len(exp.Questions) > 0 {
+		b.WriteString("Reported questions:\n")
+		for _, q := range exp.Questions {
+			b.WriteString("- " + q + "\n")
+		}
+	}
+	return b.String()
+}
+
+// jdRoleHint returns the JD's first line when it looks like a job title.
+func jdRoleHint(jd string) string {
+	for _, line := range strings.Split(jd, "\n") {
+		line = strings.TrimSpace(strings.Trim(line, "#*- "))
+		if line == "" {
+			continue
+		}
+		if n := len(strings.Fields(line)); n <= 8 && !strings.ContainsAny(line, ".:") {
+			return line
+		}
+		return ""
+	}
+	return ""
+}
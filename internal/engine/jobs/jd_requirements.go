@@ -0,0 +1,220 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- JD requirements ---
+
+// jdRequirements is what the extraction prompt pulls out of a JD. Must-have and
+// nice-to-have demands are kept apart so resume_analyze can score hard blockers
+// separately from soft gaps.
+type jdRequirements struct {
+	RequiredSkills          []string        `json:"required_skills"`
+	NiceToHave              []string        `json:"nice_to_have"`
+	MinQualifications       []string        `json:"min_qualifications"`
+	PreferredQualifications []string        `json:"preferred_qualifications"`
+	YearsRequired           []JDYearsDemand `json:"years_required"`
+	KeyRequirements         []string        `json:"key_requirements"`
+	RoleTitle               string          `json:"role_title"`
+	Seniority               string          `json:"seniority"`
+}
+
+// JDYearsDemand is a years-of-experience demand of a JD.
+type JDYearsDemand struct {
+	Skill    string  `json:"skill,omitempty"` // empty for total experience
+	Years    float64 `json:"years"`
+	Required bool    `json:"required"` // must-have rather than preferred
+}
+
+const jdExtractPrompt = `Analyze the following job description and extract requirements.
+
+Return a JSON object with this exact structure:
+{
+  "required_skills": ["skill1", "skill2"],
+  "nice_to_have": ["skill1", "skill2"],
+  "min_qualifications": ["qualification1"],
+  "preferred_qualifications": ["qualification1"],
+  "years_required": [{"skill": "Go", "years": 3, "required": true}],
+  "key_requirements": ["requirement1", "requirement2"],
+  "role_title": "normalized role title",
+  "seniority": "junior/mid/senior/lead/staff/principal"
+}
+
+Rules:
+- required_skills and min_qualifications are must-haves: listed under "requirements", "minimum/basic qualifications", "you must" or "you have"
+- nice_to_have and preferred_qualifications are listed under "preferred", "bonus", "nice to have", "plus" or "ideally"
+- Qualifications are short non-skill demands (degree, domain, clearance, language), without years
+- Put every years-of-experience demand in years_required; leave "skill" empty for total experience
+- Use [] for anything the JD does not state
+
+JOB DESCRIPTION:
+%s
+
+Return ONLY the JSON object.`
+
+// extractJDRequirements runs the JD extraction prompt. tool prefixes errors.
+func extractJDRequirements(ctx context.Context, jobDescription, tool string) (*jdRequirements, error) {
+	jdTrunc := engine.TruncateRunes(jobDescription, 3000, "")
+	raw, err := engine.CallLLM(ctx, fmt.Sprintf(jdExtractPrompt, jdTrunc))
+	if err != nil {
+		return nil, fmt.Errorf("%s extract JD: %w", tool, err)
+	}
+	raw = StripMarkdownFences(raw)
+	var jd jdRequirements
+	if err := json.Unmarshal([]byte(raw), &jd); err != nil {
+		return nil, fmt.Errorf("%s parse JD: %w (raw: %s)", tool, err, engine.TruncateRunes(raw, 200, "..."))
+	}
+	return &jd, nil
+}
+
+// RequirementsFit scores a resume against the must-have and nice-to-have demands
+// of a JD separately: an unmet must-have is a hard blocker, an unmet nice-to-have
+// a soft gap. Matching is deterministic — skill normalizer and keyword overlap.
+type RequirementsFit struct {
+	MustHaveScore   int             `json:"must_have_score"`    // 0-100, share of must-haves met
+	NiceToHaveScore int             `json:"nice_to_have_score"` // 0-100, share of nice-to-haves met
+	HardBlockers    []string        `json:"hard_blockers,omitempty"`
+	SoftGaps        []string        `json:"soft_gaps,omitempty"`
+	YearsRequired   []JDYearsDemand `json:"years_required,omitempty"`
+	ResumeYears     float64         `json:"resume_years,omitempty"` // from the master resume, else the resume's date ranges
+}
+
+// scoreRequirements checks each JD demand against the resume text. Years demands
+// compare against the master resume's years of experience when given (per skill
+// where known), else against the total span of the resume's date ranges, a per-skill
+// demand then also needing the skill. They are skipped when neither has dates.
+func scoreRequirements(jd *jdRequirements, resumeText string, years *ExperienceYears, now time.Time) *RequirementsFit {
+	fit := &RequirementsFit{YearsRequired: jd.YearsRequired, ResumeYears: resumeYears(resumeText, now)}
+	if years != nil && years.TotalYears > 0 {
+		fit.ResumeYears = years.TotalYears
+	}
+	resumeKW := extractMatchKW(resumeText)
+	resumeSkills := make(map[string]bool)
+	for _, s := range ExtractSkillsFromText(resumeText) {
+		resumeSkills[s] = true
+	}
+	skillMet := func(skill string) bool {
+		if canon := ExtractSkillsFromText(skill); len(canon) > 0 {
+			for _, s := range canon {
+				if !resumeSkills[s] {
+					return false
+				}
+			}
+			return true
+		}
+		return keywordCoverage(skill, resumeKW) == 1
+	}
+	qualMet := func(q string) bool { return keywordCoverage(q, resumeKW) >= 0.5 }
+
+	var must, nice, mustMet, niceMet int
+	check := func(label string, met, required bool) {
+		if required {
+			must++
+			if met {
+				mustMet++
+			} else {
+				fit.HardBlockers = append(fit.HardBlockers, label)
+			}
+			return
+		}
+		nice++
+		if met {
+			niceMet++
+		} else {
+			fit.SoftGaps = append(fit.SoftGaps, label)
+		}
+	}
+	for _, s := range jd.RequiredSkills {
+		check(s, skillMet(s), true)
+	}
+	for _, q := range jd.MinQualifications {
+		check(q, qualMet(q), true)
+	}
+	for _, s := range jd.NiceToHave {
+		check(s, skillMet(s), false)
+	}
+	for _, q := range jd.PreferredQualifications {
+		check(q, qualMet(q), false)
+	}
+	for _, y := range jd.YearsRequired {
+		if fit.ResumeYears == 0 || y.Years <= 0 {
+			continue
+		}
+		met := fit.ResumeYears >= y.Years && (y.Skill == "" || skillMet(y.Skill))
+		if y.Skill != "" && years != nil {
+			if have, ok := years.Years(y.Skill); ok {
+				met = have >= y.Years
+			}
+		}
+		check(y.label(), met, y.Required)
+	}
+	fit.MustHaveScore, fit.NiceToHaveScore = percentMet(mustMet, must), percentMet(niceMet, nice)
+	return fit
+}
+
+func (y JDYearsDemand) label() string {
+	years := strconv.FormatFloat(y.Years, 'f', -1, 64)
+	if y.Skill == "" {
+		return years + "+ years of experience"
+	}
+	return years + "+ years of " + y.Skill
+}
+
+// percentMet is met/total as 0-100; nothing to meet counts as fully met.
+func percentMet(met, total int) int {
+	if total == 0 {
+		return 100
+	}
+	return int(math.Round(float64(met) / float64(total) * 100))
+}
+
+// keywordCoverage is the share of the keywords of s found in kw, ignoring numbers.
+func keywordCoverage(s string, kw map[string]bool) float64 {
+	var total, found int
+	for w := range extractMatchKW(s) {
+		if _, err := strconv.ParseFloat(strings.TrimRight(w, "+"), 64); err == nil {
+			continue
+		}
+		total++
+		if kw[w] {
+			found++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(found) / float64(total)
+}
+
+// resumeDateRangeRe matches "2019 – 2023", "Mar 2020 - Present" and similar ranges.
+var resumeDateRangeRe = regexp.MustCompile(`(?i)\b((?:19|20)\d{2})\s*(?:-|–|—|to)\s*(?:[a-z]{3,9}\.?\s+)?((?:19|20)\d{2}|present|current|now|today)\b`)
+
+// resumeYears is the number of years covered by the date ranges of a resume, with
+// overlapping ranges counted once.
+func resumeYears(text string, now time.Time) float64 {
+	covered := make(map[int]bool)
+	for _, m := range resumeDateRangeRe.FindAllStringSubmatch(text, -1) {
+		from, _ := strconv.Atoi(m[1])
+		to, err := strconv.Atoi(m[2])
+		if err != nil {
+			to = now.Year()
+		}
+		if to < from || to > now.Year() {
+			continue
+		}
+		for y := from; y < max(to, from+1); y++ {
+			covered[y] = true
+		}
+	}
+	return float64(len(covered))
+}
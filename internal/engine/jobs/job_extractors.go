@@ -0,0 +1,194 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	htmltomarkdown "github.com/JohannesKaufmann/html-to-markdown/v2"
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Job posting extractors ---
+
+// FetchJobPosting routes a posting URL to the extractor for its site — ATS JSON APIs,
+// LinkedIn and Indeed pages through their browser clients, the hh.ru API — and falls
+// back to the generic JSON-LD/readability extraction of engine.FetchJobPage for every
+// other site or when the site extractor fails.
+
+// jobExtractor extracts the postings of one site.
+type jobExtractor struct {
+	name  string
+	hosts []string // host or parent domain, without "www."
+	fetch func(ctx context.Context, jobURL string) (string, error)
+}
+
+// jobExtractors is the registry of site extractors, matched in order.
+var jobExtractors = []jobExtractor{
+	{"greenhouse", []string{"greenhouse.io"}, FetchATSPosting},
+	{"lever", []string{"jobs.lever.co"}, FetchATSPosting},
+	{"linkedin", []string{"linkedin.com"}, FetchJobDetails},
+	{"indeed", []string{"indeed.com"}, fetchIndeedPosting},
+	{"habr", []string{"career.habr.com"}, fetchHabrVacancy},
+	{"hh", []string{"hh.ru"}, fetchHHVacancy},
+}
+
+// jobExtractorFor returns the extractor registered for the URL's host, or nil.
+func jobExtractorFor(jobURL string) *jobExtractor {
+	u, err := url.Parse(jobURL)
+	if err != nil {
+		return nil
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	for i, x := range jobExtractors {
+		for _, h := range x.hosts {
+			if host == h || strings.HasSuffix(host, "."+h) {
+				return &jobExtractors[i]
+			}
+		}
+	}
+	return nil
+}
+
+// FetchJobPosting returns the JD text of a job posting URL. Results are cached for
+// engine.JobDetailsTTL under the canonical URL and indexed for jobs_local_search.
+func FetchJobPosting(ctx context.Context, jobURL string) (string, error) {
+	jobURL = engine.CanonicalJobURL(jobURL)
+	if cached, ok := engine.CacheGetJobDetails(ctx, jobURL); ok {
+		return cached, nil
+	}
+	if x := jobExtractorFor(jobURL); x != nil {
+		text, err := x.fetch(ctx, jobURL)
+		if err == nil && strings.TrimSpace(text) != "" {
+			engine.CacheSetJobDetails(ctx, jobURL, text)
+			indexJobPosting(ctx, jobURL, text)
+			return text, nil
+		}
+		slog.Debug("job extractor failed, using generic extraction",
+			slog.String("extractor", x.name), slog.String("url", jobURL), slog.Any("error", err))
+	}
+	_, text, err := engine.FetchJobPage(ctx, jobURL)
+	if err != nil {
+		return "", err
+	}
+	engine.CacheSetJobDetails(ctx, jobURL, text)
+	indexJobPosting(ctx, jobURL, text)
+	return text, nil
+}
+
+// fetchIndeedPosting fetches an Indeed job page through the browser client.
+func fetchIndeedPosting(ctx context.Context, jobURL string) (string, error) {
+	body, err := indeedRequest(ctx, jobURL)
+	if err != nil {
+		return "", err
+	}
+	if text := extractIndeedStructured(body); text != "" {
+		return text, nil
+	}
+	return "", errors.New("indeed: no job data on page")
+}
+
+// fetchHabrVacancy extracts a Habr Career vacancy page, which embeds JobPosting JSON-LD.
+func fetchHabrVacancy(ctx context.Context, jobURL string) (string, error) {
+	_, text, err := engine.FetchJobPage(ctx, jobURL)
+	if err != nil {
+		return "", err
+	}
+	return "**Source:** Habr Career\n\n" + text, nil
+}
+
+// hhVacancyIDRe matches hh.ru/vacancy/<id> on any regional hh domain.
+var hhVacancyIDRe = regexp.MustCompile(`/vacancy/(\d+)`)
+
+// hhVacancy is the part of the hh.ru vacancy API response used for the JD.
+type hhVacancy struct {
+	Name     string `json:"name"`
+	Employer struct {
+		Name string `json:"name"`
+	} `json:"employer"`
+	Area struct {
+		Name string `json:"name"`
+	} `json:"area"`
+	Salary *struct {
+		From     *int   `json:"from"`
+		To       *int   `json:"to"`
+		Currency string `json:"currency"`
+	} `json:"salary"`
+	Experience struct {
+		Name string `json:"name"`
+	} `json:"experience"`
+	Schedule struct {
+		Name string `json:"name"`
+	} `json:"schedule"`
+	Employment struct {
+		Name string `json:"name"`
+	} `json:"employment"`
+	KeySkills []struct {
+		Name string `json:"name"`
+	} `json:"key_skills"`
+	Description string `json:"description"`
+}
+
+// fetchHHVacancy reads a vacancy from the public hh.ru API.
+func fetchHHVacancy(ctx context.Context, jobURL string) (string, error) {
+	m := hhVacancyIDRe.FindStringSubmatch(jobURL)
+	if m == nil {
+		return "", fmt.Errorf("hh: not a vacancy URL: %s", jobURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hhAPIBase+"/vacancies/"+m[1], nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("HH-User-Agent", hhUserAgent(req.Context()))
+	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // fixed hh.ru API URL
+	})
+	if err != nil {
+		return "", fmt.Errorf("hh vacancy: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("hh vacancy: status %d", resp.StatusCode)
+	}
+	var v hhVacancy
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 2*1024*1024)).Decode(&v); err != nil {
+		return "", fmt.Errorf("hh vacancy: %w", err)
+	}
+	return formatHHVacancy(&v), nil
+}
+
+func formatHHVacancy(v *hhVacancy) string {
+	parts := []string{"**Source:** hh.ru", "**Title:** " + v.Name}
+	field := func(label, value string) {
+		if value != "" {
+			parts = append(parts, "**"+label+":** "+value)
+		}
+	}
+	field("Company", v.Employer.Name)
+	field("Location", v.Area.Name)
+	if v.Salary != nil {
+		field("Salary", formatHabrSalary(v.Salary.From, v.Salary.To, v.Salary.Currency))
+	}
+	field("Experience", v.Experience.Name)
+	field("Type", strings.Trim(v.Employment.Name+", "+v.Schedule.Name, ", "))
+	var skills []string
+	for _, s := range v.KeySkills {
+		skills = append(skills, s.Name)
+	}
+	field("Skills", strings.Join(skills, ", "))
+	desc := v.Description
+	if md, err := htmltomarkdown.ConvertString(desc); err == nil {
+		desc = md
+	}
+	if desc = strings.TrimSpace(desc); desc != "" {
+		parts = append(parts, "**Description:**\n"+desc)
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -61,7 +61,7 @@ func SearchLightning(ctx context.Context, limit int) ([]engine.BountyListing, er
 }
 
 func fetchLightning(ctx context.Context) ([]engine.BountyListing, error) {
-	fetchCtx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, lightningAPIURL, nil)
@@ -70,7 +70,7 @@ func fetchLightning(ctx context.Context) ([]engine.BountyListing, error) {
 	}
 	req.Header.Set("User-Agent", engine.UserAgentChrome)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("lightning request failed: %w", err)
 	}
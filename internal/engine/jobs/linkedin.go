@@ -2,7 +2,6 @@ package jobs
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -95,6 +94,7 @@ var salaryMap = map[string]string{
 
 // linkedInGeoIDs maps common location strings (lowercase) to LinkedIn geoId values.
 // Using geoId provides more precise geographic filtering than text-based location.
+// Anything not listed is resolved through the typeahead (see ResolveLinkedInGeoID).
 var linkedInGeoIDs = map[string]string{
 	"united states":  "103644278",
 	"us":             "103644278",
@@ -142,7 +142,10 @@ var linkedInGeoIDs = map[string]string{
 // SearchLinkedInJobs queries the LinkedIn Guest API and returns parsed job cards.
 // maxResults controls how many jobs to fetch (rounds up to nearest 25). 0 means 25.
 // easyApply=true filters to Easy Apply jobs only (f_JIYN=true param).
-func SearchLinkedInJobs(ctx context.Context, query, location, experience, jobType, remote, timeRange, salary string, maxResults int, easyApply bool) ([]LinkedInJob, error) {
+// company limits results to one employer (f_C); if its ID cannot be resolved the
+// name is added to the keywords instead. industry is mapped to f_I codes when known
+// (see LinkedInIndustryCodes) and ignored otherwise.
+func SearchLinkedInJobs(ctx context.Context, query, location, experience, jobType, remote, timeRange, salary, company, industry string, maxResults int, easyApply bool) ([]LinkedInJob, error) {
 	if maxResults <= 0 {
 		maxResults = 25
 	}
@@ -155,11 +158,18 @@ func SearchLinkedInJobs(ctx context.Context, query, location, experience, jobTyp
 	// Build base query params (filters, no start offset yet).
 	baseQ := u.Query()
 	baseQ.Set("keywords", query)
+	if company != "" {
+		if companyID := ResolveLinkedInCompanyID(ctx, company); companyID != "" {
+			baseQ.Set("f_C", companyID)
+		} else {
+			baseQ.Set("keywords", query+" "+company)
+		}
+	}
 	baseQ.Set("sortBy", "DD") // sort by date
 	if location != "" {
 		baseQ.Set("location", location)
-		// Add geoId for precise geographic filtering when location is known.
-		if geoID, ok := linkedInGeoIDs[strings.ToLower(strings.TrimSpace(location))]; ok {
+		// Add geoId for precise geographic filtering when location resolves.
+		if geoID := ResolveLinkedInGeoID(ctx, location); geoID != "" {
 			baseQ.Set("geoId", geoID)
 		}
 	}
@@ -178,6 +188,9 @@ func SearchLinkedInJobs(ctx context.Context, query, location, experience, jobTyp
 	if v, ok := salaryMap[strings.ToLower(strings.TrimSpace(salary))]; ok {
 		baseQ.Set("f_SB2", v)
 	}
+	if codes := LinkedInIndustryCodes(industry); codes != "" {
+		baseQ.Set("f_I", codes)
+	}
 	if easyApply {
 		baseQ.Set("f_JIYN", "true")
 	}
@@ -219,17 +232,17 @@ func SearchLinkedInJobs(ctx context.Context, query, location, experience, jobTyp
 // when available, falling back to standard net/http client.
 // LinkedIn blocks non-browser TLS fingerprints, so BrowserClient is strongly preferred.
 func linkedInRequest(ctx context.Context, targetURL string) ([]byte, error) {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	// Prefer BrowserClient - LinkedIn detects non-browser TLS fingerprints
-	if engine.Cfg.BrowserClient != nil {
+	if engine.ConfigFrom(ctx).BrowserClient != nil {
 		headers := engine.ChromeHeaders()
 		headers["accept"] = "text/html,application/xhtml+xml,application/xml;q=0.9"
 		headers["referer"] = "https://www.linkedin.com/"
 
 		data, err := engine.RetryDo(ctx, engine.DefaultRetryConfig, func() ([]byte, error) {
-			d, _, s, e := engine.Cfg.BrowserClient.Do("GET", targetURL, headers, nil)
+			d, _, s, e := engine.ConfigFrom(ctx).BrowserClient.Do("GET", targetURL, headers, nil)
 			if e != nil {
 				return nil, e
 			}
@@ -253,7 +266,7 @@ func linkedInRequest(ctx context.Context, targetURL string) ([]byte, error) {
 		req.Header.Set("User-Agent", engine.UserAgentChrome)
 		req.Header.Set("Accept", "text/html,application/xhtml+xml")
 		req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return nil, err
@@ -419,93 +432,16 @@ func fetchJobDetailsUncached(ctx context.Context, jobURL string) (string, error)
 	return "", errors.New("no job details found")
 }
 
-// extractJSONLD extracts and formats the schema.org/JobPosting JSON-LD block.
+// extractJSONLD extracts and formats the schema.org/JobPosting JSON-LD block,
+// truncated to jsonLDMaxRunes.
 func extractJSONLD(html string) string {
-	marker := `"@type":"JobPosting"`
-	markerAlt := `"@type": "JobPosting"`
-
-	idx := strings.Index(html, marker)
-	if idx == -1 {
-		idx = strings.Index(html, markerAlt)
-	}
-	if idx == -1 {
-		return ""
-	}
-
-	// Find the enclosing <script> tag
-	scriptStart := strings.LastIndex(html[:idx], "<script")
-	if scriptStart == -1 {
-		return ""
-	}
-	scriptEnd := strings.Index(html[scriptStart:], "</script>")
-	if scriptEnd == -1 {
-		return ""
-	}
-
-	scriptContent := html[scriptStart : scriptStart+scriptEnd]
-	// Extract JSON content between > and </script>
-	jsonStart := strings.Index(scriptContent, ">")
-	if jsonStart == -1 {
-		return ""
-	}
-	jsonStr := strings.TrimSpace(scriptContent[jsonStart+1:])
-
-	// Parse and extract key fields
-	var data map[string]interface{}
-	if err := json.Unmarshal([]byte(jsonStr), &data); err != nil {
-		return ""
-	}
-
-	var parts []string
-
-	if title, ok := data["title"].(string); ok {
-		parts = append(parts, "**Title:** "+title)
-	}
-	if desc, ok := data["description"].(string); ok {
-		// Convert HTML description to markdown
-		md, err := htmltomarkdown.ConvertString(desc)
-		if err == nil {
-			desc = md
-		}
-		desc = engine.TruncateRunes(desc, 3000, "...")
-		parts = append(parts, "**Description:**\n"+desc)
-	}
-	if org, ok := data["hiringOrganization"].(map[string]interface{}); ok {
-		if name, ok := org["name"].(string); ok {
-			parts = append(parts, "**Company:** "+name)
-		}
-	}
-	if loc, ok := data["jobLocation"].(map[string]interface{}); ok {
-		if addr, ok := loc["address"].(map[string]interface{}); ok {
-			locParts := []string{}
-			if city, ok := addr["addressLocality"].(string); ok {
-				locParts = append(locParts, city)
-			}
-			if country, ok := addr["addressCountry"].(string); ok {
-				locParts = append(locParts, country)
-			}
-			if len(locParts) > 0 {
-				parts = append(parts, "**Location:** "+strings.Join(locParts, ", "))
-			}
-		}
-	}
-	if empType, ok := data["employmentType"].(string); ok {
-		parts = append(parts, "**Type:** "+empType)
-	}
-	if salary, ok := data["baseSalary"].(map[string]interface{}); ok {
-		if val, ok := salary["value"].(map[string]interface{}); ok {
-			min, _ := val["minValue"].(float64)
-			max, _ := val["maxValue"].(float64)
-			currency, _ := salary["currency"].(string)
-			if min > 0 || max > 0 {
-				parts = append(parts, fmt.Sprintf("**Salary:** %.0f-%.0f %s", min, max, currency))
-			}
-		}
-	}
-
-	return strings.Join(parts, "\n\n")
+	_, text, _ := engine.JobPostingFromHTML(html)
+	return engine.TruncateRunes(text, jsonLDMaxRunes, "...")
 }
 
+// jsonLDMaxRunes caps a formatted JSON-LD posting: the fields plus a long description.
+const jsonLDMaxRunes = 3500
+
 // extractJobDescription extracts the job description HTML section using tree parsing.
 func extractJobDescription(body string) string {
 	doc, err := html.Parse(strings.NewReader(body))
@@ -558,15 +494,8 @@ func LinkedInJobsToSearxngResults(ctx context.Context, jobs []LinkedInJob, fetch
 	}
 	detailCh := make(chan detailResult, fetchDetailCount)
 	for i := 0; i < fetchDetailCount && i < len(jobs); i++ {
-		go func(idx int, jobURL string) {
-			if idx > 0 {
-				select {
-				case <-time.After(time.Duration(idx) * time.Second):
-				case <-ctx.Done():
-					detailCh <- detailResult{idx, ""}
-					return
-				}
-			}
+		idx, jobURL := i, jobs[i].URL
+		fetch := func() {
 			details, err := FetchJobDetails(ctx, jobURL)
 			if err != nil {
 				slog.Debug("linkedin: failed to fetch job details", slog.String("url", jobURL), slog.Any("error", err))
@@ -574,7 +503,20 @@ func LinkedInJobsToSearxngResults(ctx context.Context, jobs []LinkedInJob, fetch
 				return
 			}
 			detailCh <- detailResult{idx, details}
-		}(i, jobs[i].URL)
+		}
+		if idx == 0 {
+			engine.GoOutbound(ctx, fetch)
+			continue
+		}
+		// Wait out the stagger before queuing, so the delay does not hold an outbound
+		// worker; on a done ctx the fetch fails fast and still reports.
+		go func() {
+			select {
+			case <-time.After(time.Duration(idx) * time.Second):
+			case <-ctx.Done():
+			}
+			engine.GoOutbound(ctx, fetch)
+		}()
 	}
 
 	// Collect results
@@ -0,0 +1,184 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// KeywordCoverage reports how many target keywords appear in a text.
+type KeywordCoverage struct {
+	Score   int      `json:"score"` // 0-100, share of target keywords present
+	Covered []string `json:"covered"`
+	Missing []string `json:"missing"`
+}
+
+// LinkedInOptimizeResult is the structured output of linkedin_profile_optimize.
+type LinkedInOptimizeResult struct {
+	Headline             string          `json:"headline"`
+	AlternativeHeadlines []string        `json:"alternative_headlines,omitempty"`
+	About                string          `json:"about"`
+	TargetKeywords       []string        `json:"target_keywords"`
+	CoverageBefore       KeywordCoverage `json:"coverage_before"`
+	CoverageAfter        KeywordCoverage `json:"coverage_after"`
+	GroundedAchievements []string        `json:"grounded_achievements,omitempty"`
+	Summary              string          `json:"summary"`
+}
+
+const linkedInOptimizePrompt = `You are a LinkedIn profile strategist and ATS keyword expert.
+
+Rewrite the candidate's LinkedIn headline and About section so recruiters searching for the target roles find them.
+
+TARGET ROLES: %s
+
+CURRENT HEADLINE:
+%s
+
+CURRENT ABOUT:
+%s
+
+CANDIDATE EVIDENCE (skills and achievements from their master resume — use ONLY these facts):
+%s
+
+Rules:
+- Headline: max 220 characters, lead with the target role title, then 2-4 differentiating keywords or a proof point
+- About: 150-300 words, first person, hook in the first two lines (shown before "see more"), 2-3 concrete achievements with numbers from the evidence, a skills line, and a call to action
+- Every claim and number MUST come from the evidence above — never invent metrics, employers, or titles
+- Naturally include the keywords recruiters search for these roles
+- target_keywords: 10-20 keywords recruiters use when sourcing for the target roles (titles, skills, tools)
+- grounded_achievements: the evidence lines you actually used
+
+Return a JSON object with this exact structure:
+{
+  "headline": "<optimized headline>",
+  "alternative_headlines": ["<variant 1>", "<variant 2>"],
+  "about": "<optimized About section>",
+  "target_keywords": ["<keyword>"],
+  "grounded_achievements": ["<evidence line used>"],
+  "summary": "<2-3 sentences on what changed and why>"
+}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+// OptimizeLinkedInProfile rewrites a LinkedIn headline/About for target roles, grounded in ResumeDB data.
+// resumeFallback is used as evidence when the resume database is not configured.
+func OptimizeLinkedInProfile(ctx context.Context, headline, about string, targetRoles []string, resumeFallback string) (*LinkedInOptimizeResult, error) {
+	if len(targetRoles) == 0 {
+		return nil, errors.New("linkedin_profile_optimize: at least one target role is required")
+	}
+
+	evidence, skills := loadProfileEvidence(ctx)
+	if evidence == "" {
+		evidence = engine.TruncateRunes(resumeFallback, 4000, "")
+	}
+	if evidence == "" {
+		return nil, errors.New("linkedin_profile_optimize: no master resume found — run master_resume_build or pass resume")
+	}
+
+	prompt := fmt.Sprintf(linkedInOptimizePrompt,
+		strings.Join(targetRoles, ", "),
+		engine.TruncateRunes(headline, 500, ""),
+		engine.TruncateRunes(about, 3000, ""),
+		evidence,
+	)
+	raw, err := engine.CallLLM(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("linkedin_profile_optimize LLM: %w", err)
+	}
+	raw = StripMarkdownFences(raw)
+
+	var result LinkedInOptimizeResult
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		return nil, fmt.Errorf("linkedin_profile_optimize parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+	}
+
+	// Target keywords: LLM recruiter keywords + the candidate's own skills that the roles mention.
+	roleText := strings.Join(targetRoles, " ") + " " + strings.Join(result.TargetKeywords, " ")
+	for _, s := range skills {
+		if strings.Contains(strings.ToLower(roleText), strings.ToLower(s)) {
+			result.TargetKeywords = append(result.TargetKeywords, s)
+		}
+	}
+	result.TargetKeywords = MergeSkills(result.TargetKeywords)
+
+	// Coverage is computed deterministically — don't trust the LLM for it.
+	result.CoverageBefore = ComputeKeywordCoverage(headline+"\n"+about, result.TargetKeywords)
+	result.CoverageAfter = ComputeKeywordCoverage(result.Headline+"\n"+result.About, result.TargetKeywords)
+	if result.Summary == "" {
+		result.Summary = fmt.Sprintf("Keyword coverage %d → %d/100 for %s.",
+			result.CoverageBefore.Score, result.CoverageAfter.Score, strings.Join(targetRoles, ", "))
+	}
+	return &result, nil
+}
+
+// loadProfileEvidence returns skills + achievements from ResumeDB formatted for prompts,
+// along with the raw skill names. Returns empty values when no master resume exists.
+func loadProfileEvidence(ctx context.Context) (string, []string) {
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return "", nil
+	}
+	personID := db.GetLatestPersonID(ctx)
+	if personID == 0 {
+		return "", nil
+	}
+
+	var b strings.Builder
+	var skillNames []string
+	if skills, err := db.GetAllSkills(ctx, personID); err == nil && len(skills) > 0 {
+		for _, s := range skills {
+			skillNames = append(skillNames, s.Name)
+		}
+		fmt.Fprintf(&b, "Skills: %s\n", strings.Join(skillNames, ", "))
+	}
+	if exps, err := db.GetAllExperiences(ctx, personID); err == nil {
+		for _, e := range exps {
+			fmt.Fprintf(&b, "- Role: %s at %s (%s–%s)\n", e.Title, e.Company, e.StartDate, e.EndDate)
+		}
+	}
+	if achvs, err := db.GetAllAchievements(ctx, personID); err == nil {
+		for _, a := range achvs {
+			fmt.Fprintf(&b, "- Achievement: %s\n", a.Text)
+		}
+	}
+	return engine.TruncateRunes(b.String(), 5000, ""), skillNames
+}
+
+// ComputeKeywordCoverage returns the share of keywords found in text. It tokenizes
+// like job_match_score (extractMatchKW), keeping short words such as "Go": a keyword
+// is covered when all its words are in text.
+func ComputeKeywordCoverage(text string, keywords []string) KeywordCoverage {
+	words := matchWords(text, 1)
+	cov := KeywordCoverage{Covered: []string{}, Missing: []string{}}
+	for _, kw := range keywords {
+		kwWords := matchWords(kw, 1)
+		if len(kwWords) == 0 {
+			continue
+		}
+		if containsAllWords(words, kwWords) {
+			cov.Covered = append(cov.Covered, kw)
+		} else {
+			cov.Missing = append(cov.Missing, kw)
+		}
+	}
+	sort.Strings(cov.Covered)
+	sort.Strings(cov.Missing)
+	if total := len(cov.Covered) + len(cov.Missing); total > 0 {
+		cov.Score = len(cov.Covered) * 100 / total
+	}
+	return cov
+}
+
+func containsAllWords(words, want map[string]bool) bool {
+	for w := range want {
+		if !words[w] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComputeKeywordCoverage(t *testing.T) {
+	cov := ComputeKeywordCoverage("Senior Go engineer building Kubernetes platforms", []string{"Go", "kubernetes", "Terraform", " "})
+	if cov.Score != 66 {
+		t.Errorf("Score = %d, want 66", cov.Score)
+	}
+	if len(cov.Covered) != 2 || len(cov.Missing) != 1 || cov.Missing[0] != "Terraform" {
+		t.Errorf("Covered=%v Missing=%v", cov.Covered, cov.Missing)
+	}
+
+	empty := ComputeKeywordCoverage("anything", nil)
+	if empty.Score != 0 || empty.Covered == nil || empty.Missing == nil {
+		t.Errorf("empty keywords: %+v", empty)
+	}
+}
+
+func TestComputeKeywordCoverage_Words(t *testing.T) {
+	cov := ComputeKeywordCoverage("Engineer at Google shipping Node.js services and CI/CD pipelines", []string{"Go", "Node.js", "CI/CD", "machine learning"})
+	if strings.Join(cov.Covered, ",") != "CI/CD,Node.js" || strings.Join(cov.Missing, ",") != "Go,machine learning" {
+		t.Errorf("Covered=%v Missing=%v", cov.Covered, cov.Missing)
+	}
+}
@@ -29,12 +29,12 @@ type liPool struct {
 }
 
 // getLinkedInClient returns a cached LinkedIn client, refreshing from go-social if expired.
-// Falls back to engine.Cfg.LinkedInClient if go-social is unavailable.
+// Falls back to engine.ConfigFrom(ctx).LinkedInClient if go-social is unavailable.
 func getLinkedInClient(ctx context.Context) (*linkedin.Client, error) {
 	// Fast path: static client without go-social.
-	sc := engine.Cfg.SocialClient
+	sc := engine.ConfigFrom(ctx).SocialClient
 	if sc == nil {
-		client := engine.Cfg.LinkedInClient
+		client := engine.ConfigFrom(ctx).LinkedInClient
 		if client == nil {
 			return nil, errLinkedInNotConfigured
 		}
@@ -103,7 +103,7 @@ func withRetry[T any](ctx context.Context, fn func(*linkedin.Client) (T, error))
 // reportLinkedInAuthError notifies go-social that LinkedIn credentials are failing.
 // Best-effort: logs warning on failure, does not block the error return.
 func reportLinkedInAuthError(ctx context.Context) {
-	sc := engine.Cfg.SocialClient
+	sc := engine.ConfigFrom(ctx).SocialClient
 	if sc == nil {
 		return
 	}
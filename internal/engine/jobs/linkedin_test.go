@@ -1,6 +1,8 @@
 package jobs
 
 import (
+	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -211,5 +213,59 @@ func TestExtractJSONLD(t *testing.T) {
 }
 
 func containsStr(s, sub string) bool {
-return strings.Contains(s, sub)
+	return strings.Contains(s, sub)
+}
+
+func TestResolveLinkedInGeoID_Static(t *testing.T) {
+	ctx := context.Background()
+	if got := ResolveLinkedInGeoID(ctx, "  Berlin "); got != "103035651" {
+		t.Errorf("ResolveLinkedInGeoID(Berlin) = %q, want the static geoId", got)
+	}
+	if got := ResolveLinkedInGeoID(ctx, ""); got != "" {
+		t.Errorf("ResolveLinkedInGeoID(\"\") = %q, want empty", got)
+	}
+}
+
+func TestJobExtractorFor(t *testing.T) {
+	cases := map[string]string{
+		"https://boards.greenhouse.io/acme/jobs/123":         "greenhouse",
+		"https://jobs.lever.co/acme/abc":                     "lever",
+		"https://www.linkedin.com/jobs/view/4012345678":      "linkedin",
+		"https://uk.indeed.com/viewjob?jk=abc":               "indeed",
+		"https://career.habr.com/vacancies/1000123456":       "habr",
+		"https://spb.hh.ru/vacancy/98765":                    "hh",
+		"https://careers.example.com/jobs/42":                "",
+		"https://notlinkedin.com/jobs/view/4012345678":       "",
+		"https://boards.greenhouse.io.evil.com/acme/jobs/12": "",
+	}
+	for u, want := range cases {
+		got := ""
+		if x := jobExtractorFor(u); x != nil {
+			got = x.name
+		}
+		if got != want {
+			t.Errorf("jobExtractorFor(%q) = %q, want %q", u, got, want)
+		}
+	}
+}
+
+func TestFormatHHVacancy(t *testing.T) {
+	var v hhVacancy
+	raw := `{"name":"Go-разработчик","employer":{"name":"Acme"},"area":{"name":"Москва"},
+		"salary":{"from":300000,"to":null,"currency":"RUR"},"experience":{"name":"От 3 до 6 лет"},
+		"schedule":{"name":"Удаленная работа"},"employment":{"name":"Полная занятость"},
+		"key_skills":[{"name":"Go"},{"name":"PostgreSQL"}],"description":"<p>Пишем <strong>API</strong></p>"}`
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		t.Fatal(err)
+	}
+	got := formatHHVacancy(&v)
+	for _, want := range []string{
+		"**Title:** Go-разработчик", "**Company:** Acme", "**Location:** Москва",
+		"**Salary:** от 300000 RUR", "**Type:** Полная занятость, Удаленная работа",
+		"**Skills:** Go, PostgreSQL", "Пишем **API**",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatHHVacancy missing %q in:\n%s", want, got)
+		}
+	}
 }
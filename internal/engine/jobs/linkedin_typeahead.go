@@ -0,0 +1,101 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// linkedInTypeaheadAPI is the public (guest) typeahead used by the LinkedIn jobs search box.
+const linkedInTypeaheadAPI = "https://www.linkedin.com/jobs-guest/api/typeaheadHits"
+
+// linkedInGeoTypes restricts geo typeahead hits to places a job search can filter on.
+const linkedInGeoTypes = "POPULATED_PLACE,ADMIN_DIVISION_1,ADMIN_DIVISION_2,MARKET_AREA,COUNTRY_REGION"
+
+// linkedInTypeaheadHit is one typeahead suggestion.
+type linkedInTypeaheadHit struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+// ResolveLinkedInGeoID returns the LinkedIn geoId for a free-text location. The static
+// linkedInGeoIDs table is checked first, then the guest typeahead; lookups are cached
+// for engine.LookupTTL. Returns "" when the location cannot be resolved.
+func ResolveLinkedInGeoID(ctx context.Context, location string) string {
+	key := strings.ToLower(strings.TrimSpace(location))
+	if key == "" {
+		return ""
+	}
+	if id, ok := linkedInGeoIDs[key]; ok {
+		return id
+	}
+	if id, ok := engine.CacheGetLookup(ctx, "li_geo", key); ok {
+		return id
+	}
+
+	hits, err := linkedInTypeahead(ctx, "GEO", location, url.Values{"geoTypes": {linkedInGeoTypes}})
+	if err != nil {
+		// Not cached: the next search retries the lookup.
+		slog.Debug("linkedin: geo typeahead failed", slog.String("location", location), slog.Any("error", err))
+		return ""
+	}
+	id := ""
+	if len(hits) > 0 {
+		id = hits[0].ID
+	}
+	engine.CacheSetLookup(ctx, "li_geo", key, id)
+	return id
+}
+
+// ResolveLinkedInCompanyID returns the LinkedIn company ID (the f_C filter value) for
+// a company name via the guest typeahead, cached for engine.LookupTTL. An exact
+// display-name match wins over LinkedIn's first suggestion. Returns "" when unresolved.
+func ResolveLinkedInCompanyID(ctx context.Context, company string) string {
+	key := strings.ToLower(strings.TrimSpace(company))
+	if key == "" {
+		return ""
+	}
+	if id, ok := engine.CacheGetLookup(ctx, "li_company", key); ok {
+		return id
+	}
+
+	hits, err := linkedInTypeahead(ctx, "COMPANY", company, nil)
+	if err != nil {
+		slog.Debug("linkedin: company typeahead failed", slog.String("company", company), slog.Any("error", err))
+		return ""
+	}
+	id := ""
+	for _, h := range hits {
+		if strings.EqualFold(strings.TrimSpace(h.DisplayName), strings.TrimSpace(company)) {
+			id = h.ID
+			break
+		}
+	}
+	if id == "" && len(hits) > 0 {
+		id = hits[0].ID
+	}
+	engine.CacheSetLookup(ctx, "li_company", key, id)
+	return id
+}
+
+// linkedInTypeahead queries the guest typeahead for the given type (GEO, COMPANY, ...).
+func linkedInTypeahead(ctx context.Context, typ, query string, extra url.Values) ([]linkedInTypeaheadHit, error) {
+	q := url.Values{"typeaheadType": {typ}, "query": {query}}
+	for k, v := range extra {
+		q[k] = v
+	}
+	body, err := linkedInRequest(ctx, linkedInTypeaheadAPI+"?"+q.Encode())
+	if err != nil {
+		return nil, err
+	}
+	var hits []linkedInTypeaheadHit
+	if err := json.Unmarshal(body, &hits); err != nil {
+		return nil, fmt.Errorf("linkedin typeahead: %w", err)
+	}
+	return hits, nil
+}
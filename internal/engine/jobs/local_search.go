@@ -0,0 +1,310 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Local full-text search ---
+
+// jobs_local_search queries everything collected so far without hitting a source: the
+// listings recorded in the seen-jobs store, the job descriptions fetched by
+// FetchJobPosting, and tracked jobs with their notes. All three go into one SQLite FTS5
+// table in the tracker database; tracked jobs are kept in sync by triggers on jobs.
+
+// Local search sources.
+const (
+	LocalSourceSeen    = "seen"    // listing returned by a search
+	LocalSourcePosting = "posting" // fetched job description
+	LocalSourceTracked = "tracked" // job tracker entry
+)
+
+const (
+	localSearchDefaultLimit = 20
+	localSearchMaxLimit     = 100
+)
+
+// LocalSearchInput is the input for jobs_local_search.
+type LocalSearchInput struct {
+	Query  string `json:"query"`            // keywords; time phrases like "last week" or "past 3 days" become since
+	Source string `json:"source,omitempty"` // "seen", "posting" or "tracked"; empty searches all
+	Since  string `json:"since,omitempty"`  // YYYY-MM-DD or a phrase like "last week"
+	Limit  int    `json:"limit,omitempty"`  // default 20, max 100
+}
+
+// LocalSearchHit is one matching document.
+type LocalSearchHit struct {
+	Source    string `json:"source"`
+	TrackerID int64  `json:"tracker_id,omitempty"` // set for tracked jobs
+	Title     string `json:"title"`
+	Company   string `json:"company,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Snippet   string `json:"snippet"` // matched text with terms in **bold**
+	UpdatedAt string `json:"updated_at"`
+}
+
+// LocalSearchResult is the output of jobs_local_search.
+type LocalSearchResult struct {
+	Terms []string         `json:"terms"`
+	Since string           `json:"since,omitempty"` // YYYY-MM-DD
+	Match string           `json:"match"`           // "all" terms, or "any" when no document has all
+	Hits  []LocalSearchHit `json:"hits"`
+	Total int              `json:"total"`
+}
+
+// localDoc is a row of the local_search index.
+type localDoc struct {
+	Source, Ref, Title, Company, URL, Body string
+	UpdatedAt                              time.Time
+}
+
+// initLocalSearchSchema creates the FTS5 index and the triggers mirroring tracked jobs
+// into it. Existing tracked jobs are indexed when the table is first created.
+func initLocalSearchSchema(db *sql.DB) error {
+	var exists int
+	_ = db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'local_search'`).Scan(&exists) //nolint:noctx // schema init
+	schema := `CREATE VIRTUAL TABLE IF NOT EXISTS local_search USING fts5(
+		source UNINDEXED, ref UNINDEXED, title, company, url UNINDEXED, body, updated_at UNINDEXED
+	);
+	CREATE TRIGGER IF NOT EXISTS local_search_jobs_ai AFTER INSERT ON jobs BEGIN
+		INSERT INTO local_search (source, ref, title, company, url, body, updated_at)
+		VALUES ('tracked', new.id, new.title, new.company, new.url,
+		        COALESCE(new.notes, '') || char(10) || COALESCE(new.location, ''), new.updated_at);
+	END;
+	CREATE TRIGGER IF NOT EXISTS local_search_jobs_au AFTER UPDATE ON jobs BEGIN
+		DELETE FROM local_search WHERE source = 'tracked' AND ref = old.id;
+		INSERT INTO local_search (source, ref, title, company, url, body, updated_at)
+		VALUES ('tracked', new.id, new.title, new.company, new.url,
+		        COALESCE(new.notes, '') || char(10) || COALESCE(new.location, ''), new.updated_at);
+	END;
+	CREATE TRIGGER IF NOT EXISTS local_search_jobs_ad AFTER DELETE ON jobs BEGIN
+		DELETE FROM local_search WHERE source = 'tracked' AND ref = old.id;
+	END`
+	if _, err := db.Exec(schema); err != nil { //nolint:noctx // schema init, no user context available
+		return err
+	}
+	if exists > 0 {
+		return nil
+	}
+	_, err := db.Exec(`INSERT INTO local_search (source, ref, title, company, url, body, updated_at)
+		SELECT 'tracked', id, title, company, url, COALESCE(notes, '') || char(10) || COALESCE(location, ''), updated_at
+		FROM jobs`) //nolint:noctx // schema init, no user context available
+	return err
+}
+
+// indexLocalDoc replaces the index entry of a document. An empty body keeps the
+// previously indexed body, so a re-sighting without a description keeps the old one.
+func indexLocalDoc(ctx context.Context, db *sql.DB, d localDoc) error {
+	if strings.TrimSpace(d.Body) == "" {
+		_ = db.QueryRowContext(ctx, `SELECT body FROM local_search WHERE source = ? AND ref = ?`, d.Source, d.Ref).Scan(&d.Body)
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM local_search WHERE source = ? AND ref = ?`, d.Source, d.Ref); err != nil {
+		return fmt.Errorf("local_search: %w", err)
+	}
+	_, err := db.ExecContext(ctx, `INSERT INTO local_search (source, ref, title, company, url, body, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		d.Source, d.Ref, d.Title, d.Company, d.URL, d.Body, d.UpdatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("local_search: %w", err)
+	}
+	return nil
+}
+
+var postingFieldRe = regexp.MustCompile(`(?m)^\*\*(Title|Company):\*\*\s*(.+)$`)
+
+// indexJobPosting adds a fetched job description to the local index. Errors are only
+// logged: indexing never fails a fetch.
+func indexJobPosting(ctx context.Context, jobURL, text string) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return
+	}
+	d := localDoc{Source: LocalSourcePosting, Ref: jobURL, URL: jobURL, Body: text, UpdatedAt: time.Now()}
+	for _, m := range postingFieldRe.FindAllStringSubmatch(text, 2) {
+		if m[1] == "Title" {
+			d.Title = strings.TrimSpace(m[2])
+		} else {
+			d.Company = strings.TrimSpace(m[2])
+		}
+	}
+	if err := indexLocalDoc(ctx, db, d); err != nil {
+		slog.Debug("local_search: index posting failed", slog.String("url", jobURL), slog.Any("error", err))
+	}
+}
+
+var (
+	localSinceRe = regexp.MustCompile(`(?i)\b(?:(today)|(yesterday)|(?:this|last|past) (week|month|year)|(?:last|past) (\d+) (day|week|month)s?|(\d+) (day|week|month)s? ago)\b`)
+	localTermRe  = regexp.MustCompile(`[\p{L}\p{N}][\p{L}\p{N}+#.]*`)
+)
+
+// localSearchStopwords are the filler words of a natural-language query.
+var localSearchStopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "that": true, "this": true, "which": true, "and": true, "or": true,
+	"of": true, "on": true, "in": true, "at": true, "to": true, "for": true, "from": true, "with": true,
+	"about": true, "job": true, "jobs": true, "role": true, "roles": true, "position": true, "posting": true,
+	"vacancy": true, "mentioning": true, "mentions": true, "mentioned": true, "i": true, "me": true,
+	"my": true, "saw": true, "seen": true, "was": true, "were": true, "one": true, "some": true,
+}
+
+// parseLocalSince resolves a time phrase to the start of its period and returns the
+// text with the phrase removed. ok is false when there is no phrase.
+func parseLocalSince(s string, now time.Time) (since time.Time, rest string, ok bool) {
+	loc := localSinceRe.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return time.Time{}, s, false
+	}
+	m := localSinceRe.FindStringSubmatch(s)
+	day := now.UTC().Truncate(24 * time.Hour)
+	unitAgo := func(n int, unit string) time.Time {
+		switch strings.ToLower(unit) {
+		case "week":
+			return day.AddDate(0, 0, -7*n)
+		case "month":
+			return day.AddDate(0, -n, 0)
+		case "year":
+			return day.AddDate(-n, 0, 0)
+		}
+		return day.AddDate(0, 0, -n)
+	}
+	switch {
+	case m[1] != "":
+		since = day
+	case m[2] != "":
+		since = day.AddDate(0, 0, -1)
+	case m[3] != "":
+		since = unitAgo(1, m[3])
+	case m[4] != "":
+		n, _ := strconv.Atoi(m[4])
+		since = unitAgo(n, m[5])
+	default:
+		n, _ := strconv.Atoi(m[6])
+		since = unitAgo(n, m[7])
+	}
+	return since, s[:loc[0]] + " " + s[loc[1]:], true
+}
+
+// localSearchTerms extracts the search terms of a query, lowercased and deduplicated.
+func localSearchTerms(q string) []string {
+	var terms []string
+	seen := map[string]bool{}
+	for _, t := range localTermRe.FindAllString(strings.ToLower(q), -1) {
+		t = strings.TrimRight(t, ".")
+		if t == "" || localSearchStopwords[t] || seen[t] {
+			continue
+		}
+		seen[t] = true
+		terms = append(terms, t)
+	}
+	return terms
+}
+
+// ftsQuery quotes each term as an FTS5 string and joins them with op.
+func ftsQuery(terms []string, op string) string {
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = `"` + strings.ReplaceAll(t, `"`, `""`) + `"`
+	}
+	return strings.Join(quoted, " "+op+" ")
+}
+
+// SearchLocalJobs runs a full-text query over the local index. Documents matching all
+// terms are returned ranked by BM25 (title and company weigh more than the body); when
+// none match all terms, documents matching any term are returned instead.
+func SearchLocalJobs(ctx context.Context, input LocalSearchInput) (*LocalSearchResult, error) {
+	now := time.Now()
+	var since time.Time
+	query := input.Query
+	if s, rest, ok := parseLocalSince(query, now); ok {
+		since, query = s, rest
+	}
+	if input.Since != "" {
+		if t, err := time.Parse(time.DateOnly, strings.TrimSpace(input.Since)); err == nil {
+			since = t
+		} else if s, _, ok := parseLocalSince(input.Since, now); ok {
+			since = s
+		} else {
+			return nil, fmt.Errorf("jobs_local_search: invalid since %q (YYYY-MM-DD or e.g. \"last week\")", input.Since)
+		}
+	}
+	terms := localSearchTerms(query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("jobs_local_search: no search terms in %q", input.Query)
+	}
+	switch input.Source {
+	case "", LocalSourceSeen, LocalSourcePosting, LocalSourceTracked:
+	default:
+		return nil, fmt.Errorf("jobs_local_search: invalid source %q (valid: seen, posting, tracked)", input.Source)
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = localSearchDefaultLimit
+	}
+	limit = min(limit, localSearchMaxLimit)
+
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	result := &LocalSearchResult{Terms: terms, Match: "all", Hits: []LocalSearchHit{}}
+	if !since.IsZero() {
+		result.Since = since.Format(time.DateOnly)
+	}
+	for _, op := range []string{"AND", "OR"} {
+		hits, err := queryLocalSearch(ctx, db, ftsQuery(terms, op), input.Source, since, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(hits) > 0 || len(terms) == 1 {
+			result.Hits = hits
+			break
+		}
+		result.Match = "any"
+	}
+	result.Total = len(result.Hits)
+	return result, nil
+}
+
+func queryLocalSearch(ctx context.Context, db *sql.DB, match, source string, since time.Time, limit int) ([]LocalSearchHit, error) {
+	q := `SELECT source, ref, title, company, url, updated_at,
+		snippet(local_search, 5, '**', '**', '…', 16)
+		FROM local_search WHERE local_search MATCH ?`
+	args := []any{match}
+	if source != "" {
+		q += ` AND source = ?`
+		args = append(args, source)
+	}
+	if !since.IsZero() {
+		q += ` AND updated_at >= ?`
+		args = append(args, since.UTC().Format(time.RFC3339))
+	}
+	q += ` ORDER BY bm25(local_search, 0, 0, 10.0, 5.0, 0, 1.0, 0) LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("jobs_local_search: %w", err)
+	}
+	defer rows.Close()
+	hits := []LocalSearchHit{}
+	for rows.Next() {
+		var h LocalSearchHit
+		var ref string
+		var company, url sql.NullString
+		if err := rows.Scan(&h.Source, &ref, &h.Title, &company, &url, &h.UpdatedAt, &h.Snippet); err != nil {
+			return nil, fmt.Errorf("jobs_local_search: %w", err)
+		}
+		h.Company, h.URL = company.String, url.String
+		if h.Source == LocalSourceTracked {
+			h.TrackerID, _ = strconv.ParseInt(ref, 10, 64)
+		}
+		h.Snippet = strings.Join(strings.Fields(h.Snippet), " ")
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
@@ -7,27 +7,34 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/anatolykoptev/go_job/internal/engine"
 )
 
 // MasterResumeBuildResult is the structured output of master_resume_build.
 type MasterResumeBuildResult struct {
-	PersonID       int    `json:"person_id"`
-	Experiences    int    `json:"experiences"`
-	Skills         int    `json:"skills"`
-	Projects       int    `json:"projects"`
-	Achievements   int    `json:"achievements"`
-	Educations     int    `json:"educations"`
-	Certifications int    `json:"certifications"`
-	Domains        int    `json:"domains"`
-	Methodologies  int    `json:"methodologies"`
-	ImplicitSkills int    `json:"implicit_skills"`
-	SubProjects    int    `json:"sub_projects"`
-	GraphNodes     int    `json:"graph_nodes"`
-	GraphEdges     int    `json:"graph_edges"`
-	VectorsStored  int    `json:"vectors_stored"`
-	Summary        string `json:"summary"`
+	JobID          string   `json:"job_id,omitempty"` // set when started with async=true
+	PersonID       int      `json:"person_id"`
+	Experiences    int      `json:"experiences"`
+	Skills         int      `json:"skills"`
+	Projects       int      `json:"projects"`
+	Achievements   int      `json:"achievements"`
+	Educations     int      `json:"educations"`
+	Certifications int      `json:"certifications"`
+	Domains        int      `json:"domains"`
+	Methodologies  int      `json:"methodologies"`
+	Publications   int      `json:"publications,omitempty"`
+	Talks          int      `json:"talks,omitempty"`
+	Patents        int      `json:"patents,omitempty"`
+	OpenSource     int      `json:"open_source,omitempty"`
+	ImplicitSkills int      `json:"implicit_skills"`
+	SubProjects    int      `json:"sub_projects"`
+	GraphNodes     int      `json:"graph_nodes"`
+	GraphEdges     int      `json:"graph_edges"`
+	VectorsStored  int      `json:"vectors_stored"`
+	Warnings       []string `json:"warnings,omitempty"` // unreadable experience dates, derived-store sync failures; SQL data is intact
+	Summary        string   `json:"summary"`
 }
 
 type parsedResume struct {
@@ -38,6 +45,9 @@ type parsedResume struct {
 		Location string            `json:"location"`
 		Links    map[string]string `json:"links"`
 		Summary  string            `json:"summary"`
+		// Spoken languages and right to work, when the resume states them.
+		Languages         []LanguageSkill     `json:"languages"`
+		WorkAuthorization []WorkAuthorization `json:"work_authorization"`
 	} `json:"person"`
 	Experiences []struct {
 		Title       string   `json:"title"`
@@ -100,6 +110,10 @@ type parsedResume struct {
 		Name        string `json:"name"`
 		Description string `json:"description,omitempty"`
 	} `json:"methodologies,omitempty"`
+	Publications []PublicationRecord `json:"publications,omitempty"`
+	Talks        []TalkRecord        `json:"talks,omitempty"`
+	Patents      []PatentRecord      `json:"patents,omitempty"`
+	OpenSource   []OpenSourceRecord  `json:"open_source,omitempty"`
 }
 
 type enrichmentResult struct {
@@ -149,9 +163,17 @@ For skills:
 - Mark explicitly listed skills with is_implicit: false, source: "resume"
 - If you can clearly infer skills from context (e.g., "sold 16K tickets with zero budget" implies Guerrilla Marketing), add them with is_implicit: true, source: "inferred"
 
+For the person:
+- "languages": spoken (human) languages with proficiency — not programming languages
+- "work_authorization": only what the resume states (citizenship, green card, visa, "no sponsorship needed"); never guess it from the location or name
+
 Also extract:
 - "domains": array of professional domains the person operates in (e.g., ["Event Production", "Digital Marketing", "Media"])
 - "methodologies": array of approaches/frameworks the person uses (e.g., [{"name": "Zero-Budget Growth", "description": "..."}])
+- "publications": papers, articles, books and chapters, with "topics" naming the skills/fields they cover
+- "talks": conference talks, meetup presentations and workshops, with "topics"
+- "patents": granted or pending patents, with "topics"
+- "open_source": open-source projects the person created, maintains or is a core contributor to, with "tech"
 
 Return a JSON object with this exact structure:
 {
@@ -161,7 +183,9 @@ Return a JSON object with this exact structure:
     "phone": "...",
     "location": "...",
     "links": {"linkedin": "url", "github": "url", ...},
-    "summary": "professional summary if present"
+    "summary": "professional summary if present",
+    "languages": [{"language": "English", "proficiency": "native|fluent|professional|conversational|basic"}],
+    "work_authorization": [{"country": "US", "status": "citizen|permanent_resident|work_visa|needs_sponsorship", "note": "visa type or restrictions, if stated"}]
   },
   "experiences": [
     {
@@ -200,13 +224,25 @@ Return a JSON object with this exact structure:
   "domains": ["Event Production", "Digital Marketing"],
   "methodologies": [
     {"name": "Zero-Budget Growth", "description": "Driving massive outcomes without paid advertising through viral mechanics and psychological triggers"}
+  ],
+  "publications": [
+    {"title": "...", "venue": "journal, conference or publisher", "date": "YYYY-MM or YYYY", "url": "...", "authors": ["..."], "summary": "...", "topics": ["Distributed Systems"]}
+  ],
+  "talks": [
+    {"title": "...", "event": "conference or meetup", "date": "...", "location": "...", "url": "...", "summary": "...", "topics": ["Go"]}
+  ],
+  "patents": [
+    {"title": "...", "number": "US 10,123,456 B2", "status": "granted|pending|filed", "date": "...", "url": "...", "summary": "...", "topics": []}
+  ],
+  "open_source": [
+    {"project": "...", "role": "creator|maintainer|core contributor", "url": "...", "since": "YYYY", "stars": null, "summary": "...", "tech": ["Go"]}
   ]
 }
 
 Skill categories: programming_language, framework, database, cloud, devops, tool, methodology, soft_skill, other.
 Skill levels: expert, advanced, intermediate, beginner (infer from context — primary stack = expert, mentioned once = intermediate).
 
-IMPORTANT: Do NOT skip any section. The "educations" array MUST be populated if the resume contains education info (look at the bottom of the resume). Same for certifications.
+IMPORTANT: Do NOT skip any section. The "educations" array MUST be populated if the resume contains education info (look at the bottom of the resume). Same for certifications, publications, talks, patents and open source.
 Be aggressive about extracting sub_projects from experiences — if an experience mentions multiple distinct initiatives, events, products, or campaigns, each one is a sub_project.
 Be aggressive about inferring implicit skills — read between the lines of achievements and responsibilities.
 
@@ -259,12 +295,13 @@ Return ONLY the JSON object, no markdown, no explanation.`
 
 // BuildMasterResume parses resume text into SQL tables + AGE graph + MemDB vectors.
 func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBuildResult, error) { //nolint:funlen
-	db := GetResumeDB()
+	db := resumeStore(ctx)
 	if db == nil {
 		return nil, errors.New("resume database not configured (set DATABASE_URL)")
 	}
 
 	// 1. Parse resume via LLM (call #1)
+	reportProgress(ctx, 5, "parsing")
 	resumeTrunc := engine.TruncateRunes(resumeText, 12000, "")
 	prompt := fmt.Sprintf(masterResumeParsePrompt, resumeTrunc)
 
@@ -281,6 +318,7 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 	}
 
 	// 2. Enrichment pass (LLM call #2)
+	reportProgress(ctx, 30, "enriching")
 	parsedJSON, _ := json.Marshal(parsed)
 	enrichPrompt := fmt.Sprintf(enrichmentPrompt,
 		engine.TruncateRunes(string(parsedJSON), 8000, ""),
@@ -300,37 +338,91 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 		}
 	}
 
-	// 3. Clear existing data (single-user, rebuild from scratch)
-	if err := db.ClearAllPersons(ctx); err != nil {
-		slog.Debug("clear persons failed", slog.Any("error", err))
+	// 3. Write all rows in one transaction, replacing the previous resume. Graph and
+	// vector writes are only planned here: they live outside the transaction and are
+	// replayed once the rows are committed, so a failed build keeps the previous resume.
+	reportProgress(ctx, 55, "writing rows")
+	result := &MasterResumeBuildResult{}
+	plan := &buildPlan{}
+	if err := db.WithTx(ctx, func(tx ResumeStore) error {
+		return writeMasterResume(ctx, tx, &parsed, &enrichment, result, plan)
+	}); err != nil {
+		return nil, fmt.Errorf("master_resume_build: %w (previous resume kept)", err)
+	}
+	personID := result.PersonID
+
+	// 4. Rebuild the graph from the plan
+	reportProgress(ctx, 70, "building graph")
+	if failed := syncGraph(ctx, db, personID, plan.Graph); failed > 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("graph: %d of %d writes failed; run master_resume_status with repair=true", failed, len(plan.Graph)))
+	}
+	if nodes, err := db.CountGraphNodes(ctx); err == nil {
+		result.GraphNodes = nodes
 	}
-	if err := db.ClearGraph(ctx); err != nil {
-		slog.Debug("clear graph failed", slog.Any("error", err))
+	if edges, err := db.CountGraphEdges(ctx); err == nil {
+		result.GraphEdges = edges
 	}
 
-	// Clear MemDB vectors
-	mdb := GetMemDB()
-	if mdb != nil {
-		if err := mdb.ClearAllBySearch(ctx); err != nil {
-			slog.Debug("memdb clear failed", slog.Any("error", err))
+	// 5. Sync to MemDB
+	reportProgress(ctx, 85, "storing vectors")
+	if mdb := vectorStore(ctx); mdb != nil {
+		stored, failed := syncVectors(ctx, db, mdb, personID, plan.Vectors)
+		result.VectorsStored = stored
+		if failed > 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("vectors: %d of %d writes failed; run master_resume_status with repair=true", failed, len(plan.Vectors)))
 		}
 	}
 
-	// 4. Insert person
-	personID, err := db.InsertPerson(ctx, PersonRecord{
+	result.Summary = fmt.Sprintf("Master resume built for %s: %d experiences, %d skills (%d implicit), %d projects (%d sub-projects), %d achievements, %d educations, %d certifications, %d domains, %d methodologies, %d publications, %d talks, %d patents, %d open-source projects. Graph: %d nodes, %d edges. Vectors: %d stored.",
+		parsed.Person.Name,
+		result.Experiences, result.Skills, result.ImplicitSkills,
+		result.Projects, result.SubProjects,
+		result.Achievements, result.Educations, result.Certifications,
+		result.Domains, result.Methodologies,
+		result.Publications, result.Talks, result.Patents, result.OpenSource,
+		result.GraphNodes, result.GraphEdges, result.VectorsStored,
+	)
+
+	slog.Info("master resume built",
+		slog.Int("person_id", personID),
+		slog.Int("experiences", result.Experiences),
+		slog.Int("skills", result.Skills),
+		slog.Int("implicit_skills", result.ImplicitSkills),
+		slog.Int("sub_projects", result.SubProjects),
+		slog.Int("domains", result.Domains),
+		slog.Int("methodologies", result.Methodologies),
+		slog.Int("graph_nodes", result.GraphNodes),
+		slog.Int("vectors", result.VectorsStored),
+	)
+
+	return result, nil
+}
+
+// writeMasterResume replaces all resume rows inside tx and records the graph and
+// vector writes that belong to them in plan. Any SQL error aborts the build.
+func writeMasterResume(ctx context.Context, tx ResumeStore, parsed *parsedResume, enrichment *enrichmentResult, result *MasterResumeBuildResult, plan *buildPlan) error { //nolint:funlen
+	// Archive the previous build (single-user, rebuild from scratch); master_resume_rollback
+	// can bring it back.
+	if err := tx.ArchiveCurrentPersons(ctx, resumeArchiveKeep); err != nil {
+		return err
+	}
+
+	// Insert person
+	personID, err := tx.InsertPerson(ctx, PersonRecord{
 		Name:     parsed.Person.Name,
 		Email:    parsed.Person.Email,
 		Phone:    parsed.Person.Phone,
 		Location: parsed.Person.Location,
 		Links:    parsed.Person.Links,
 		Summary:  parsed.Person.Summary,
+
+		Languages:         parsed.Person.Languages,
+		WorkAuthorization: parsed.Person.WorkAuthorization,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("insert person: %w", err)
+		return fmt.Errorf("insert person: %w", err)
 	}
-
-	result := &MasterResumeBuildResult{PersonID: personID}
-	var vectorTexts []vectorEntry
+	result.PersonID = personID
 
 	// Track skill name → skill ID for graph edges
 	skillIDs := make(map[string]int)
@@ -338,13 +430,13 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 	// Track experience company → expID for enrichment linking
 	expByCompany := make(map[string]int)
 
-	// 5. Insert standalone skills (both explicit and implicit from parse)
+	// Insert standalone skills (both explicit and implicit from parse)
 	for _, s := range parsed.Skills {
 		source := s.Source
 		if source == "" {
 			source = "resume"
 		}
-		sid, err := db.InsertSkillExtended(ctx, personID, SkillRecord{
+		sid, err := tx.InsertSkillExtended(ctx, personID, SkillRecord{
 			Name:       s.Name,
 			Category:   s.Category,
 			Level:      s.Level,
@@ -352,8 +444,7 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 			Source:     source,
 		})
 		if err != nil {
-			slog.Debug("insert skill failed", slog.String("name", s.Name), slog.Any("error", err))
-			continue
+			return fmt.Errorf("insert skill %q: %w", s.Name, err)
 		}
 		skillIDs[strings.ToLower(s.Name)] = sid
 		result.Skills++
@@ -362,9 +453,9 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 		}
 	}
 
-	// 6. Insert experiences + graph nodes/edges
+	// Insert experiences + graph nodes/edges
 	for _, exp := range parsed.Experiences {
-		expID, err := db.InsertExperience(ctx, personID, ExperienceRecord{
+		expID, err := tx.InsertExperience(ctx, personID, ExperienceRecord{
 			Title:       exp.Title,
 			Company:     exp.Company,
 			Location:    exp.Location,
@@ -374,107 +465,80 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 			Highlights:  exp.Highlights,
 		})
 		if err != nil {
-			slog.Debug("insert experience failed", slog.String("title", exp.Title), slog.Any("error", err))
-			continue
+			return fmt.Errorf("insert experience %q: %w", exp.Title, err)
 		}
 		result.Experiences++
 		expByCompany[strings.ToLower(exp.Company)] = expID
 
 		// Update extended metadata
 		if exp.Domain != "" || exp.TeamSize != nil || exp.BudgetUSD != nil || exp.IsVolunteer {
-			if err := db.UpdateExperienceMeta(ctx, expID, exp.TeamSize, exp.BudgetUSD, exp.Domain, exp.IsVolunteer); err != nil {
-				slog.Debug("update experience meta failed", slog.Int("exp_id", expID), slog.Any("error", err))
+			if err := tx.UpdateExperienceMeta(ctx, expID, exp.TeamSize, exp.BudgetUSD, exp.Domain, exp.IsVolunteer); err != nil {
+				return fmt.Errorf("update experience meta %d: %w", expID, err)
 			}
 		}
 
 		// Graph: Exp node
-		if err := db.UpsertGraphNode(ctx, "Exp", expID, map[string]string{
+		plan.node("Exp", expID, map[string]string{
 			"title":   exp.Title,
 			"company": exp.Company,
-		}); err != nil {
-			slog.Debug("graph node upsert failed", slog.Any("error", err))
-		}
+		})
 
 		// Graph: skill edges
 		for _, skillName := range exp.Skills {
-			sid := ensureSkill(ctx, db, personID, skillName, "other", "intermediate", false, "resume", skillIDs, result)
-			if sid > 0 {
-				if err := db.UpsertGraphNode(ctx, "Skill", sid, map[string]string{"name": skillName}); err != nil {
-					slog.Debug("graph node upsert failed", slog.Any("error", err))
-				}
-				if err := db.UpsertGraphEdge(ctx, "Exp", expID, "USED_SKILL", "Skill", sid); err != nil {
-					slog.Debug("graph edge upsert failed", slog.Any("error", err))
-				}
+			sid, err := ensureSkill(ctx, tx, personID, skillName, "other", "intermediate", false, "resume", skillIDs, result)
+			if err != nil {
+				return err
 			}
+			plan.node("Skill", sid, map[string]string{"name": skillName})
+			plan.edge("Exp", expID, "USED_SKILL", "Skill", sid)
 		}
 
 		// Graph: domain edge
 		if exp.Domain != "" {
-			domID, err := db.InsertDomain(ctx, personID, exp.Domain)
+			domID, err := tx.InsertDomain(ctx, personID, exp.Domain)
 			if err != nil {
-				slog.Warn("insert exp domain failed", slog.String("domain", exp.Domain), slog.Any("error", err))
-			} else {
-				if err := db.UpsertGraphNode(ctx, "Domain", domID, map[string]string{"name": exp.Domain}); err != nil {
-					slog.Debug("graph node upsert failed", slog.Any("error", err))
-				}
-				if err := db.UpsertGraphEdge(ctx, "Exp", expID, "IN_DOMAIN", "Domain", domID); err != nil {
-					slog.Debug("graph edge upsert failed", slog.Any("error", err))
-				}
+				return fmt.Errorf("insert exp domain %q: %w", exp.Domain, err)
 			}
+			plan.node("Domain", domID, map[string]string{"name": exp.Domain})
+			plan.edge("Exp", expID, "IN_DOMAIN", "Domain", domID)
 		}
 
 		// Insert sub-projects from parse
 		for _, sp := range exp.SubProjects {
-			spID, err := db.InsertProjectWithParent(ctx, personID, &expID, ProjectRecord{
+			spID, err := tx.InsertProjectWithParent(ctx, personID, &expID, ProjectRecord{
 				Name:        sp.Name,
 				Description: sp.Description,
 				Tech:        sp.Tech,
 				Highlights:  sp.Highlights,
 			})
 			if err != nil {
-				slog.Debug("insert sub-project failed", slog.String("name", sp.Name), slog.Any("error", err))
-				continue
+				return fmt.Errorf("insert sub-project %q: %w", sp.Name, err)
 			}
 			result.Projects++
 			result.SubProjects++
 
-			if err := db.UpsertGraphNode(ctx, "Proj", spID, map[string]string{"name": sp.Name}); err != nil {
-				slog.Debug("graph node upsert failed", slog.Any("error", err))
-			}
-			if err := db.UpsertGraphEdge(ctx, "Proj", spID, "PART_OF", "Exp", expID); err != nil {
-				slog.Debug("graph edge upsert failed", slog.Any("error", err))
-			}
+			plan.node("Proj", spID, map[string]string{"name": sp.Name})
+			plan.edge("Proj", spID, "PART_OF", "Exp", expID)
 
 			for _, techName := range sp.Tech {
-				sid := ensureSkill(ctx, db, personID, techName, "other", "intermediate", false, "resume", skillIDs, result)
-				if sid > 0 {
-					if err := db.UpsertGraphNode(ctx, "Skill", sid, map[string]string{"name": techName}); err != nil {
-						slog.Debug("graph node upsert failed", slog.Any("error", err))
-					}
-					if err := db.UpsertGraphEdge(ctx, "Proj", spID, "USED_SKILL", "Skill", sid); err != nil {
-						slog.Debug("graph edge upsert failed", slog.Any("error", err))
-					}
+				sid, err := ensureSkill(ctx, tx, personID, techName, "other", "intermediate", false, "resume", skillIDs, result)
+				if err != nil {
+					return err
 				}
+				plan.node("Skill", sid, map[string]string{"name": techName})
+				plan.edge("Proj", spID, "USED_SKILL", "Skill", sid)
 			}
 
-			text := formatProjectText(sp.Name, sp.Description, sp.Tech, sp.Highlights)
-			vectorTexts = append(vectorTexts, vectorEntry{
-				content: text,
-				info:    map[string]any{"type": "project", "id": float64(spID)},
-			})
+			plan.vector(formatProjectText(sp.Name, sp.Description, sp.Tech, sp.Highlights), "project", spID)
 		}
 
 		// Vector: experience text (with domain context)
-		text := formatExperienceTextExtended(exp.Title, exp.Company, exp.StartDate, exp.EndDate, exp.Description, exp.Highlights, exp.Domain)
-		vectorTexts = append(vectorTexts, vectorEntry{
-			content: text,
-			info:    map[string]any{"type": "experience", "id": float64(expID)},
-		})
+		plan.vector(formatExperienceTextExtended(exp.Title, exp.Company, exp.StartDate, exp.EndDate, exp.Description, exp.Highlights, exp.Domain), "experience", expID)
 	}
 
-	// 7. Insert standalone projects + graph
+	// Insert standalone projects + graph
 	for _, proj := range parsed.Projects {
-		projID, err := db.InsertProject(ctx, personID, ProjectRecord{
+		projID, err := tx.InsertProject(ctx, personID, ProjectRecord{
 			Name:        proj.Name,
 			Description: proj.Description,
 			URL:         proj.URL,
@@ -482,37 +546,27 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 			Highlights:  proj.Highlights,
 		})
 		if err != nil {
-			slog.Debug("insert project failed", slog.String("name", proj.Name), slog.Any("error", err))
-			continue
+			return fmt.Errorf("insert project %q: %w", proj.Name, err)
 		}
 		result.Projects++
 
-		if err := db.UpsertGraphNode(ctx, "Proj", projID, map[string]string{"name": proj.Name}); err != nil {
-			slog.Debug("graph node upsert failed", slog.Any("error", err))
-		}
+		plan.node("Proj", projID, map[string]string{"name": proj.Name})
 
 		for _, techName := range proj.Tech {
-			sid := ensureSkill(ctx, db, personID, techName, "other", "intermediate", false, "resume", skillIDs, result)
-			if sid > 0 {
-				if err := db.UpsertGraphNode(ctx, "Skill", sid, map[string]string{"name": techName}); err != nil {
-					slog.Debug("graph node upsert failed", slog.Any("error", err))
-				}
-				if err := db.UpsertGraphEdge(ctx, "Proj", projID, "USED_SKILL", "Skill", sid); err != nil {
-					slog.Debug("graph edge upsert failed", slog.Any("error", err))
-				}
+			sid, err := ensureSkill(ctx, tx, personID, techName, "other", "intermediate", false, "resume", skillIDs, result)
+			if err != nil {
+				return err
 			}
+			plan.node("Skill", sid, map[string]string{"name": techName})
+			plan.edge("Proj", projID, "USED_SKILL", "Skill", sid)
 		}
 
-		text := formatProjectText(proj.Name, proj.Description, proj.Tech, proj.Highlights)
-		vectorTexts = append(vectorTexts, vectorEntry{
-			content: text,
-			info:    map[string]any{"type": "project", "id": float64(projID)},
-		})
+		plan.vector(formatProjectText(proj.Name, proj.Description, proj.Tech, proj.Highlights), "project", projID)
 	}
 
-	// 8. Insert achievements + graph
+	// Insert achievements + graph
 	for i, achv := range parsed.Achievements {
-		achvID, err := db.InsertAchievementExtended(ctx, personID, AchievementRecord{
+		achvID, err := tx.InsertAchievementExtended(ctx, personID, AchievementRecord{
 			Text:          achv.Text,
 			Metric:        achv.Metric,
 			Value:         achv.Value,
@@ -521,29 +575,23 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 			MetricUnit:    achv.MetricUnit,
 		})
 		if err != nil {
-			slog.Debug("insert achievement failed", slog.Int("index", i), slog.Any("error", err))
-			continue
+			return fmt.Errorf("insert achievement %d: %w", i, err)
 		}
 		result.Achievements++
 
-		if err := db.UpsertGraphNode(ctx, "Achv", achvID, map[string]string{"text": achv.Text}); err != nil {
-			slog.Debug("graph node upsert failed", slog.Any("error", err))
-		}
+		plan.node("Achv", achvID, map[string]string{"text": achv.Text})
 
 		// Link to parent experience/project by context match
 		if achv.Context != "" {
-			linkAchievementToParent(ctx, db, achv.Context, achvID, personID)
+			linkAchievementToParent(ctx, tx, plan, achv.Context, achvID, personID)
 		}
 
-		vectorTexts = append(vectorTexts, vectorEntry{
-			content: achv.Text,
-			info:    map[string]any{"type": "achievement", "id": float64(achvID)},
-		})
+		plan.vector(achv.Text, "achievement", achvID)
 	}
 
-	// 9. Insert educations
+	// Insert educations
 	for _, edu := range parsed.Educations {
-		_, err := db.InsertEducation(ctx, personID, EducationRecord{
+		_, err := tx.InsertEducation(ctx, personID, EducationRecord{
 			School:     edu.School,
 			Degree:     edu.Degree,
 			Field:      edu.Field,
@@ -553,27 +601,29 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 			Highlights: edu.Highlights,
 		})
 		if err != nil {
-			slog.Debug("insert education failed", slog.String("school", edu.School), slog.Any("error", err))
-			continue
+			return fmt.Errorf("insert education %q: %w", edu.School, err)
 		}
 		result.Educations++
 	}
 
-	// 10. Insert certifications
+	// Insert certifications
 	for _, cert := range parsed.Certifications {
-		_, err := db.InsertCertification(ctx, personID, CertificationRecord{
+		_, err := tx.InsertCertification(ctx, personID, CertificationRecord{
 			Name:   cert.Name,
 			Issuer: cert.Issuer,
 			Year:   cert.Year,
 		})
 		if err != nil {
-			slog.Debug("insert certification failed", slog.String("name", cert.Name), slog.Any("error", err))
-			continue
+			return fmt.Errorf("insert certification %q: %w", cert.Name, err)
 		}
 		result.Certifications++
 	}
 
-	// 11. Insert domains (from parse + enrichment)
+	if err := writeResumeWorks(ctx, tx, personID, parsed, skillIDs, result, plan); err != nil {
+		return err
+	}
+
+	// Insert domains (from parse + enrichment)
 	allDomains := make(map[string]bool)
 	for _, d := range parsed.Domains {
 		allDomains[d] = true
@@ -582,18 +632,15 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 		allDomains[d] = true
 	}
 	for d := range allDomains {
-		domID, err := db.InsertDomain(ctx, personID, d)
+		domID, err := tx.InsertDomain(ctx, personID, d)
 		if err != nil {
-			slog.Warn("insert domain failed", slog.String("name", d), slog.Any("error", err))
-			continue
-		}
-		if err := db.UpsertGraphNode(ctx, "Domain", domID, map[string]string{"name": d}); err != nil {
-			slog.Debug("graph node upsert failed", slog.Any("error", err))
+			return fmt.Errorf("insert domain %q: %w", d, err)
 		}
+		plan.node("Domain", domID, map[string]string{"name": d})
 		result.Domains++
 	}
 
-	// 12. Insert methodologies (from parse + enrichment)
+	// Insert methodologies (from parse + enrichment)
 	allMethods := make(map[string]string) // name → description
 	for _, m := range parsed.Methodologies {
 		allMethods[m.Name] = m.Description
@@ -604,37 +651,33 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 		}
 	}
 	for name, desc := range allMethods {
-		methID, err := db.InsertMethodology(ctx, personID, name, desc)
+		methID, err := tx.InsertMethodology(ctx, personID, name, desc)
 		if err != nil {
-			slog.Warn("insert methodology failed", slog.String("name", name), slog.Any("error", err))
-			continue
-		}
-		if err := db.UpsertGraphNode(ctx, "Method", methID, map[string]string{"name": name}); err != nil {
-			slog.Debug("graph node upsert failed", slog.Any("error", err))
+			return fmt.Errorf("insert methodology %q: %w", name, err)
 		}
+		plan.node("Method", methID, map[string]string{"name": name})
 		result.Methodologies++
 	}
 
-	// 13. Apply enrichment: implicit skills
+	// Apply enrichment: implicit skills
 	for _, is := range enrichment.ImplicitSkills {
 		if _, exists := skillIDs[strings.ToLower(is.Name)]; exists {
 			continue // already have this skill
 		}
-		sid := ensureSkill(ctx, db, personID, is.Name, is.Category, is.Level, true, "inferred", skillIDs, result)
-		if sid > 0 {
-			result.ImplicitSkills++
-			if err := db.UpsertGraphNode(ctx, "Skill", sid, map[string]string{"name": is.Name}); err != nil {
-				slog.Debug("graph node upsert failed", slog.Any("error", err))
-			}
+		sid, err := ensureSkill(ctx, tx, personID, is.Name, is.Category, is.Level, true, "inferred", skillIDs, result)
+		if err != nil {
+			return err
+		}
+		result.ImplicitSkills++
+		plan.node("Skill", sid, map[string]string{"name": is.Name})
 
-			// DERIVED_SKILL: link from achievement context if possible
-			if is.Source != "" {
-				linkImplicitSkillToSource(ctx, db, is.Source, sid, personID)
-			}
+		// DERIVED_SKILL: link from achievement context if possible
+		if is.Source != "" {
+			linkImplicitSkillToSource(ctx, tx, plan, is.Source, sid, personID)
 		}
 	}
 
-	// 14. Apply enrichment: sub-projects
+	// Apply enrichment: sub-projects
 	for _, sp := range enrichment.SubProjects {
 		parentExpID := findExperienceByHint(expByCompany, sp.ParentExperience)
 		var parentPtr *int
@@ -642,141 +685,170 @@ func BuildMasterResume(ctx context.Context, resumeText string) (*MasterResumeBui
 			parentPtr = &parentExpID
 		}
 
-		spID, err := db.InsertProjectWithParent(ctx, personID, parentPtr, ProjectRecord{
+		spID, err := tx.InsertProjectWithParent(ctx, personID, parentPtr, ProjectRecord{
 			Name:        sp.Name,
 			Description: sp.Description,
 			Tech:        sp.Tech,
 			Highlights:  sp.Highlights,
 		})
 		if err != nil {
-			continue
+			return fmt.Errorf("insert enriched sub-project %q: %w", sp.Name, err)
 		}
 		result.Projects++
 		result.SubProjects++
 
-		if err := db.UpsertGraphNode(ctx, "Proj", spID, map[string]string{"name": sp.Name}); err != nil {
-			slog.Debug("graph node upsert failed", slog.Any("error", err))
-		}
+		plan.node("Proj", spID, map[string]string{"name": sp.Name})
 		if parentExpID > 0 {
-			if err := db.UpsertGraphEdge(ctx, "Proj", spID, "PART_OF", "Exp", parentExpID); err != nil {
-				slog.Debug("graph edge upsert failed", slog.Any("error", err))
-			}
+			plan.edge("Proj", spID, "PART_OF", "Exp", parentExpID)
 		}
 
 		for _, techName := range sp.Tech {
-			sid := ensureSkill(ctx, db, personID, techName, "other", "intermediate", false, "resume", skillIDs, result)
-			if sid > 0 {
-				if err := db.UpsertGraphNode(ctx, "Skill", sid, map[string]string{"name": techName}); err != nil {
-					slog.Debug("graph node upsert failed", slog.Any("error", err))
-				}
-				if err := db.UpsertGraphEdge(ctx, "Proj", spID, "USED_SKILL", "Skill", sid); err != nil {
-					slog.Debug("graph edge upsert failed", slog.Any("error", err))
-				}
+			sid, err := ensureSkill(ctx, tx, personID, techName, "other", "intermediate", false, "resume", skillIDs, result)
+			if err != nil {
+				return err
 			}
+			plan.node("Skill", sid, map[string]string{"name": techName})
+			plan.edge("Proj", spID, "USED_SKILL", "Skill", sid)
 		}
 
-		text := formatProjectText(sp.Name, sp.Description, sp.Tech, sp.Highlights)
-		vectorTexts = append(vectorTexts, vectorEntry{
-			content: text,
-			info:    map[string]any{"type": "project", "id": float64(spID)},
-		})
+		plan.vector(formatProjectText(sp.Name, sp.Description, sp.Tech, sp.Highlights), "project", spID)
 	}
 
-	// 15. Apply enrichment: skill adjacencies (IMPLIES_SKILL edges)
+	// Apply enrichment: skill adjacencies (IMPLIES_SKILL edges)
 	for _, adj := range enrichment.SkillAdjacencies {
 		fromID, ok := skillIDs[strings.ToLower(adj.From)]
 		if !ok {
 			continue
 		}
-		toID := ensureSkill(ctx, db, personID, adj.To, "other", "intermediate", true, "inferred", skillIDs, result)
-		if toID > 0 {
-			if err := db.UpsertGraphNode(ctx, "Skill", toID, map[string]string{"name": adj.To}); err != nil {
-				slog.Debug("graph node upsert failed", slog.Any("error", err))
-			}
-			if err := db.UpsertGraphEdge(ctx, "Skill", fromID, "IMPLIES_SKILL", "Skill", toID); err != nil {
-				slog.Debug("graph edge upsert failed", slog.Any("error", err))
-			}
+		toID, err := ensureSkill(ctx, tx, personID, adj.To, "other", "intermediate", true, "inferred", skillIDs, result)
+		if err != nil {
+			return err
 		}
+		plan.node("Skill", toID, map[string]string{"name": adj.To})
+		plan.edge("Skill", fromID, "IMPLIES_SKILL", "Skill", toID)
 	}
 
-	// 16. Apply enrichment: career trajectory (EVOLVED_TO edges)
+	// Apply enrichment: career trajectory (EVOLVED_TO edges)
 	for _, ct := range enrichment.CareerTrajectory {
 		fromExpID := findExperienceByHint(expByCompany, ct.From)
 		toExpID := findExperienceByHint(expByCompany, ct.To)
 		if fromExpID > 0 && toExpID > 0 {
-			if err := db.UpsertGraphEdge(ctx, "Exp", fromExpID, "EVOLVED_TO", "Exp", toExpID); err != nil {
-				slog.Debug("graph edge upsert failed", slog.Any("error", err))
-			}
+			plan.edge("Exp", fromExpID, "EVOLVED_TO", "Exp", toExpID)
 		}
 	}
 
-	// 17. Link methodologies to experiences via USED_METHOD
-	exps, _ := db.GetAllExperiences(ctx, personID)
-	methods, _ := db.GetAllMethodologies(ctx, personID)
+	// Link methodologies to experiences via USED_METHOD
+	exps, _ := tx.GetAllExperiences(ctx, personID)
+	methods, _ := tx.GetAllMethodologies(ctx, personID)
 	for _, exp := range exps {
 		expText := strings.ToLower(exp.Description + " " + strings.Join(exp.Highlights, " "))
 		for _, m := range methods {
 			if strings.Contains(expText, strings.ToLower(m.Name)) {
-				if err := db.UpsertGraphEdge(ctx, "Exp", exp.ID, "USED_METHOD", "Method", m.ID); err != nil {
-					slog.Debug("graph edge upsert failed", slog.Any("error", err))
-				}
+				plan.edge("Exp", exp.ID, "USED_METHOD", "Method", m.ID)
 			}
 		}
 	}
 
-	// 18. Graph counts
-	if nodes, err := db.CountGraphNodes(ctx); err == nil {
-		result.GraphNodes = nodes
+	// Experience dates that cannot be read or contradict each other
+	result.Warnings = append(result.Warnings, experienceDateIssues(exps, time.Now())...)
+
+	// Skill recency from the end dates of the experiences each skill was used in
+	for sid, lastUsed := range skillLastUsed(plan, exps) {
+		if err := tx.UpdateSkillLastUsed(ctx, sid, lastUsed); err != nil {
+			return fmt.Errorf("update skill %d last_used: %w", sid, err)
+		}
 	}
-	if edges, err := db.CountGraphEdges(ctx); err == nil {
-		result.GraphEdges = edges
+
+	// Persist the plan so the graph and vectors can be repaired from SQL alone.
+	planJSON, err := json.Marshal(plan)
+	if err != nil {
+		return fmt.Errorf("encode build plan: %w", err)
+	}
+	if err := tx.SaveBuildPlan(ctx, personID, planJSON); err != nil {
+		return fmt.Errorf("save build plan: %w", err)
+	}
+
+	// Mark person as enriched
+	if err := tx.MarkPersonEnriched(ctx, personID); err != nil {
+		return fmt.Errorf("mark person enriched: %w", err)
 	}
+	return nil
+}
 
-	// 19. Sync to MemDB
-	if mdb != nil {
-		for _, ve := range vectorTexts {
-			if _, err := mdb.Add(ctx, ve.content, ve.info); err != nil {
-				slog.Debug("memdb add failed", slog.Any("error", err))
+// writeResumeWorks inserts publications, talks, patents and open-source projects with
+// their graph nodes (Pub, Talk, Patent, OSS), skill edges and vectors.
+func writeResumeWorks(ctx context.Context, tx ResumeStore, personID int, parsed *parsedResume, skillIDs map[string]int, result *MasterResumeBuildResult, plan *buildPlan) error {
+	linkSkills := func(label string, id int, edge string, names []string) error {
+		for _, name := range names {
+			if strings.TrimSpace(name) == "" {
 				continue
 			}
-			result.VectorsStored++
+			sid, err := ensureSkill(ctx, tx, personID, name, "other", "intermediate", false, "resume", skillIDs, result)
+			if err != nil {
+				return err
+			}
+			plan.node("Skill", sid, map[string]string{"name": name})
+			plan.edge(label, id, edge, "Skill", sid)
 		}
+		return nil
 	}
 
-	// 20. Mark person as enriched
-	if err := db.MarkPersonEnriched(ctx, personID); err != nil {
-		slog.Debug("mark person enriched failed", slog.Int("person_id", personID), slog.Any("error", err))
+	for _, p := range parsed.Publications {
+		id, err := tx.InsertPublication(ctx, personID, p)
+		if err != nil {
+			return fmt.Errorf("insert publication %q: %w", p.Title, err)
+		}
+		result.Publications++
+		plan.node("Pub", id, map[string]string{"title": p.Title})
+		if err := linkSkills("Pub", id, "ABOUT_SKILL", p.Topics); err != nil {
+			return err
+		}
+		plan.vector(formatPublicationText(p), "publication", id)
 	}
-
-	result.Summary = fmt.Sprintf("Master resume built for %s: %d experiences, %d skills (%d implicit), %d projects (%d sub-projects), %d achievements, %d educations, %d certifications, %d domains, %d methodologies. Graph: %d nodes, %d edges. Vectors: %d stored.",
-		parsed.Person.Name,
-		result.Experiences, result.Skills, result.ImplicitSkills,
-		result.Projects, result.SubProjects,
-		result.Achievements, result.Educations, result.Certifications,
-		result.Domains, result.Methodologies,
-		result.GraphNodes, result.GraphEdges, result.VectorsStored,
-	)
-
-	slog.Info("master resume built",
-		slog.Int("person_id", personID),
-		slog.Int("experiences", result.Experiences),
-		slog.Int("skills", result.Skills),
-		slog.Int("implicit_skills", result.ImplicitSkills),
-		slog.Int("sub_projects", result.SubProjects),
-		slog.Int("domains", result.Domains),
-		slog.Int("methodologies", result.Methodologies),
-		slog.Int("graph_nodes", result.GraphNodes),
-		slog.Int("vectors", result.VectorsStored),
-	)
-
-	return result, nil
+	for _, t := range parsed.Talks {
+		id, err := tx.InsertTalk(ctx, personID, t)
+		if err != nil {
+			return fmt.Errorf("insert talk %q: %w", t.Title, err)
+		}
+		result.Talks++
+		plan.node("Talk", id, map[string]string{"title": t.Title})
+		if err := linkSkills("Talk", id, "ABOUT_SKILL", t.Topics); err != nil {
+			return err
+		}
+		plan.vector(formatTalkText(t), "talk", id)
+	}
+	for _, p := range parsed.Patents {
+		id, err := tx.InsertPatent(ctx, personID, p)
+		if err != nil {
+			return fmt.Errorf("insert patent %q: %w", p.Title, err)
+		}
+		result.Patents++
+		plan.node("Patent", id, map[string]string{"title": p.Title})
+		if err := linkSkills("Patent", id, "ABOUT_SKILL", p.Topics); err != nil {
+			return err
+		}
+		plan.vector(formatPatentText(p), "patent", id)
+	}
+	for _, o := range parsed.OpenSource {
+		id, err := tx.InsertOpenSource(ctx, personID, o)
+		if err != nil {
+			return fmt.Errorf("insert open source %q: %w", o.Project, err)
+		}
+		result.OpenSource++
+		plan.node("OSS", id, map[string]string{"name": o.Project})
+		if err := linkSkills("OSS", id, "USED_SKILL", o.Tech); err != nil {
+			return err
+		}
+		plan.vector(formatOpenSourceText(o), "open_source", id)
+	}
+	return nil
 }
 
 // ensureSkill inserts or retrieves a skill, updating the tracking map and result counter.
-func ensureSkill(ctx context.Context, db *ResumeDB, personID int, name, category, level string, isImplicit bool, source string, skillIDs map[string]int, result *MasterResumeBuildResult) int {
+func ensureSkill(ctx context.Context, db ResumeStore, personID int, name, category, level string, isImplicit bool, source string, skillIDs map[string]int, result *MasterResumeBuildResult) (int, error) {
 	key := strings.ToLower(name)
 	if sid, ok := skillIDs[key]; ok {
-		return sid
+		return sid, nil
 	}
 	sid, err := db.InsertSkillExtended(ctx, personID, SkillRecord{
 		Name:       name,
@@ -786,11 +858,11 @@ func ensureSkill(ctx context.Context, db *ResumeDB, personID int, name, category
 		Source:     source,
 	})
 	if err != nil {
-		return 0
+		return 0, fmt.Errorf("insert skill %q: %w", name, err)
 	}
 	skillIDs[key] = sid
 	result.Skills++
-	return sid
+	return sid, nil
 }
 
 // findExperienceByHint looks up an experience ID by matching company name (case-insensitive).
@@ -809,24 +881,17 @@ func findExperienceByHint(expByCompany map[string]int, hint string) int {
 }
 
 // linkImplicitSkillToSource creates a DERIVED_SKILL edge from the matching achievement to the skill.
-func linkImplicitSkillToSource(ctx context.Context, db *ResumeDB, sourceHint string, skillID int, personID int) {
+func linkImplicitSkillToSource(ctx context.Context, db ResumeStore, plan *buildPlan, sourceHint string, skillID int, personID int) {
 	hint := strings.ToLower(sourceHint)
 	achvs, _ := db.GetAllAchievements(ctx, personID)
 	for _, a := range achvs {
 		if strings.Contains(strings.ToLower(a.Text), hint) || strings.Contains(strings.ToLower(a.Context), hint) {
-			if err := db.UpsertGraphEdge(ctx, "Achv", a.ID, "DERIVED_SKILL", "Skill", skillID); err != nil {
-				slog.Debug("graph edge upsert failed", slog.Any("error", err))
-			}
+			plan.edge("Achv", a.ID, "DERIVED_SKILL", "Skill", skillID)
 			return
 		}
 	}
 }
 
-type vectorEntry struct {
-	content string
-	info    map[string]any
-}
-
 func formatExperienceTextExtended(title, company, startDate, endDate, description string, highlights []string, domain string) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "%s at %s (%s–%s)", title, company, startDate, endDate)
@@ -858,16 +923,14 @@ func formatProjectText(name, description string, tech []string, highlights []str
 }
 
 // linkAchievementToParent creates a PRODUCED edge from the matching experience/project to the achievement.
-func linkAchievementToParent(ctx context.Context, db *ResumeDB, contextHint string, achvID int, personID int) {
+func linkAchievementToParent(ctx context.Context, db ResumeStore, plan *buildPlan, contextHint string, achvID int, personID int) {
 	hint := strings.ToLower(contextHint)
 
 	// Try experiences
 	exps, _ := db.GetAllExperiences(ctx, personID)
 	for _, exp := range exps {
 		if strings.Contains(hint, strings.ToLower(exp.Company)) || strings.Contains(hint, strings.ToLower(exp.Title)) {
-			if err := db.UpsertGraphEdge(ctx, "Exp", exp.ID, "PRODUCED", "Achv", achvID); err != nil {
-				slog.Debug("graph edge upsert failed", slog.Any("error", err))
-			}
+			plan.edge("Exp", exp.ID, "PRODUCED", "Achv", achvID)
 			return
 		}
 	}
@@ -876,9 +939,7 @@ func linkAchievementToParent(ctx context.Context, db *ResumeDB, contextHint stri
 	projs, _ := db.GetAllProjects(ctx, personID)
 	for _, proj := range projs {
 		if strings.Contains(hint, strings.ToLower(proj.Name)) {
-			if err := db.UpsertGraphEdge(ctx, "Proj", proj.ID, "PRODUCED", "Achv", achvID); err != nil {
-				slog.Debug("graph edge upsert failed", slog.Any("error", err))
-			}
+			plan.edge("Proj", proj.ID, "PRODUCED", "Achv", achvID)
 			return
 		}
 	}
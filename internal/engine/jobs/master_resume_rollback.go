@@ -0,0 +1,109 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// MasterResumeRollbackResult is the structured output of master_resume_rollback.
+type MasterResumeRollbackResult struct {
+	DryRun        bool           `json:"dry_run,omitempty"`
+	Restored      *ArchivedBuild `json:"restored"`           // the build brought back
+	ReplacedID    int            `json:"replaced_person_id"` // the build archived in its place
+	Current       map[string]int `json:"current_rows"`       // rows of the build that was current
+	Previous      map[string]int `json:"previous_rows"`      // rows of the restored build
+	Changes       []string       `json:"changes,omitempty"`  // per table, previous vs current
+	GraphFailed   int            `json:"graph_failed,omitempty"`
+	VectorsStored int            `json:"vectors_stored,omitempty"`
+	Warnings      []string       `json:"warnings,omitempty"`
+	Summary       string         `json:"summary"`
+}
+
+// MasterResumeRollback restores the build that the last master_resume_build replaced:
+// the archived person becomes current again and the replaced one is archived, so a
+// second rollback undoes the first. The graph and vectors are rebuilt from the
+// restored build's plan. With dryRun it only compares the two builds.
+func MasterResumeRollback(ctx context.Context, dryRun bool) (*MasterResumeRollbackResult, error) {
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return nil, errors.New("resume database not configured (set DATABASE_URL)")
+	}
+	prev, err := db.LatestArchivedBuild(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("master_resume_rollback: %w", err)
+	}
+	if prev == nil {
+		return nil, errors.New("master_resume_rollback: no previous build archived (builds made before rollback support were deleted)")
+	}
+	res := &MasterResumeRollbackResult{DryRun: dryRun, Restored: prev, ReplacedID: db.GetLatestPersonID(ctx)}
+	if res.Previous, err = db.PersonRowCounts(ctx, prev.PersonID); err != nil {
+		return nil, fmt.Errorf("master_resume_rollback: %w", err)
+	}
+	if res.ReplacedID > 0 {
+		if res.Current, err = db.PersonRowCounts(ctx, res.ReplacedID); err != nil {
+			return nil, fmt.Errorf("master_resume_rollback: %w", err)
+		}
+	}
+	res.Changes = compareBuildRows(res.Previous, res.Current)
+	if dryRun {
+		res.Summary = fmt.Sprintf("Dry run: would restore build %d (built %s, replaced %s).", prev.PersonID, prev.BuiltAt, prev.ArchivedAt)
+		if len(res.Changes) > 0 {
+			res.Summary += " Previous vs current: " + strings.Join(res.Changes, ", ") + "."
+		}
+		return res, nil
+	}
+
+	if err := db.SwapCurrentPerson(ctx, prev.PersonID); err != nil {
+		return nil, fmt.Errorf("master_resume_rollback: %w", err)
+	}
+	raw, err := db.LoadBuildPlan(ctx, prev.PersonID)
+	var plan buildPlan
+	if err == nil && len(raw) > 0 {
+		err = json.Unmarshal(raw, &plan)
+	}
+	switch {
+	case err != nil || len(raw) == 0:
+		res.Warnings = append(res.Warnings, "restored build has no build plan; graph and vectors were left as they are, re-run master_resume_build to refresh them")
+	default:
+		if res.GraphFailed = syncGraph(ctx, db, prev.PersonID, plan.Graph); res.GraphFailed > 0 {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("graph: %d of %d writes failed; run master_resume_status with repair=true", res.GraphFailed, len(plan.Graph)))
+		}
+		if mdb := StoresFrom(ctx).MemDB; mdb != nil {
+			stored, failed := syncVectors(ctx, db, mdb, prev.PersonID, plan.Vectors)
+			res.VectorsStored = stored
+			if failed > 0 {
+				res.Warnings = append(res.Warnings, fmt.Sprintf("vectors: %d of %d writes failed; run master_resume_status with repair=true", failed, len(plan.Vectors)))
+			}
+		}
+	}
+
+	res.Summary = fmt.Sprintf("Restored master resume build %d (built %s); build %d is archived and can be restored with another rollback.",
+		prev.PersonID, prev.BuiltAt, res.ReplacedID)
+	if len(res.Changes) > 0 {
+		res.Summary += " Restored vs replaced: " + strings.Join(res.Changes, ", ") + "."
+	}
+	slog.Info("master resume rolled back", slog.Int("person_id", prev.PersonID), slog.Int("replaced", res.ReplacedID))
+	return res, nil
+}
+
+// compareBuildRows lists the tables whose row counts differ, e.g. "resume_experiences 6 vs 4".
+func compareBuildRows(prev, cur map[string]int) []string {
+	var out []string
+	for table, n := range prev {
+		if n != cur[table] {
+			out = append(out, fmt.Sprintf("%s %d vs %d", table, n, cur[table]))
+		}
+	}
+	for table, n := range cur {
+		if _, ok := prev[table]; !ok && n > 0 {
+			out = append(out, fmt.Sprintf("%s 0 vs %d", table, n))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
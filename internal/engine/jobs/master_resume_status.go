@@ -0,0 +1,223 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// buildPlan is the derived-store half of a master resume build: every graph
+// write and vector text, in order. It is stored with the person row so the
+// graph and MemDB can be rebuilt from SQL alone.
+type buildPlan struct {
+	Graph   []graphOp   `json:"graph"`
+	Vectors []MemDBItem `json:"vectors"`
+}
+
+// graphOp is a node upsert, or an edge upsert when Edge is set.
+type graphOp struct {
+	Label   string            `json:"label"`
+	ID      int               `json:"id"`
+	Props   map[string]string `json:"props,omitempty"`
+	Edge    string            `json:"edge,omitempty"`
+	ToLabel string            `json:"to_label,omitempty"`
+	ToID    int               `json:"to_id,omitempty"`
+}
+
+func (p *buildPlan) node(label string, id int, props map[string]string) {
+	p.Graph = append(p.Graph, graphOp{Label: label, ID: id, Props: props})
+}
+
+func (p *buildPlan) edge(fromLabel string, fromID int, edgeLabel, toLabel string, toID int) {
+	p.Graph = append(p.Graph, graphOp{Label: fromLabel, ID: fromID, Edge: edgeLabel, ToLabel: toLabel, ToID: toID})
+}
+
+func (p *buildPlan) vector(content, typ string, id int) {
+	p.Vectors = append(p.Vectors, MemDBItem{
+		Content: content,
+		Info:    map[string]any{"type": typ, "id": float64(id)},
+	})
+}
+
+// nodeCount returns the number of distinct graph nodes the plan creates.
+func (p *buildPlan) nodeCount() int {
+	seen := make(map[string]bool)
+	for _, op := range p.Graph {
+		if op.Edge == "" {
+			seen[fmt.Sprintf("%s:%d", op.Label, op.ID)] = true
+		}
+	}
+	return len(seen)
+}
+
+// syncGraph rebuilds the AGE graph from ops and returns the number of failed writes.
+// The person is marked graph-synced only when every write succeeded.
+func syncGraph(ctx context.Context, db ResumeStore, personID int, ops []graphOp) int {
+	if err := db.ClearGraph(ctx); err != nil {
+		slog.Warn("master_resume: clear graph failed", slog.Any("error", err))
+		return len(ops)
+	}
+	failed, err := db.ApplyGraphOps(ctx, ops)
+	if err != nil {
+		slog.Warn("master_resume: graph write failed", slog.Any("error", err))
+	}
+	if failed == 0 {
+		if err := db.MarkGraphSynced(ctx, personID); err != nil {
+			slog.Debug("mark graph synced failed", slog.Int("person_id", personID), slog.Any("error", err))
+		}
+	}
+	return failed
+}
+
+// syncVectors replaces all MemDB resume vectors with vectors and returns how many
+// were stored and how many failed. The person is marked vectors-synced only on full success.
+func syncVectors(ctx context.Context, db ResumeStore, mdb VectorStore, personID int, vectors []MemDBItem) (stored, failed int) {
+	if err := mdb.ClearAllBySearch(ctx); err != nil {
+		slog.Warn("master_resume: memdb clear failed", slog.Any("error", err))
+		return 0, len(vectors)
+	}
+	for _, err := range mdb.AddBatch(ctx, vectors) {
+		if err != nil {
+			slog.Debug("memdb add failed", slog.Any("error", err))
+			failed++
+			continue
+		}
+		stored++
+	}
+	if failed == 0 {
+		if err := db.MarkVectorsSynced(ctx, personID); err != nil {
+			slog.Debug("mark vectors synced failed", slog.Int("person_id", personID), slog.Any("error", err))
+		}
+	}
+	return stored, failed
+}
+
+// MasterResumeStatusResult is the structured output of master_resume_status.
+type MasterResumeStatusResult struct {
+	Healthy            bool             `json:"healthy"`
+	Integrity          *ResumeIntegrity `json:"integrity"`
+	GraphNodes         int              `json:"graph_nodes"`
+	ExpectedGraphNodes int              `json:"expected_graph_nodes"`
+	Issues             []string         `json:"issues,omitempty"`
+	Repairs            []string         `json:"repairs,omitempty"`
+	Summary            string           `json:"summary"`
+}
+
+// MasterResumeStatus checks that the SQL rows, AGE graph and MemDB vectors of the
+// master resume agree. With repair set it removes stray persons and orphan rows,
+// then replays the stored build plan into whichever derived store is out of sync.
+func MasterResumeStatus(ctx context.Context, repair bool) (*MasterResumeStatusResult, error) {
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return nil, errors.New("resume database not configured (set DATABASE_URL)")
+	}
+
+	res, plan, err := checkMasterResume(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if !repair || res.Healthy {
+		return res, nil
+	}
+
+	repairs := repairMasterResume(ctx, db, res, plan)
+	if res, _, err = checkMasterResume(ctx, db); err != nil {
+		return nil, err
+	}
+	res.Repairs = repairs
+	return res, nil
+}
+
+// checkMasterResume builds the status report and returns the stored plan (nil if absent).
+func checkMasterResume(ctx context.Context, db *ResumeDB) (*MasterResumeStatusResult, *buildPlan, error) {
+	integ, err := db.CheckIntegrity(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("master_resume_status: %w", err)
+	}
+	res := &MasterResumeStatusResult{Integrity: integ}
+	if nodes, err := db.CountGraphNodes(ctx); err == nil {
+		res.GraphNodes = nodes
+	}
+
+	var plan *buildPlan
+	if integ.PersonID > 0 && integ.HasBuildPlan {
+		raw, err := db.LoadBuildPlan(ctx, integ.PersonID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("master_resume_status: load build plan: %w", err)
+		}
+		plan = &buildPlan{}
+		if err := json.Unmarshal(raw, plan); err != nil {
+			return nil, nil, fmt.Errorf("master_resume_status: decode build plan: %w", err)
+		}
+		res.ExpectedGraphNodes = plan.nodeCount()
+	}
+
+	switch {
+	case integ.PersonID == 0:
+		res.Issues = append(res.Issues, "no master resume; run master_resume_build")
+	default:
+		if integ.Persons > 1 {
+			res.Issues = append(res.Issues, fmt.Sprintf("%d persons stored; expected 1 (leftover from an interrupted build)", integ.Persons))
+		}
+		if integ.EnrichedAt == "" {
+			res.Issues = append(res.Issues, "latest build did not complete")
+		}
+		if plan == nil {
+			res.Issues = append(res.Issues, "no build plan stored (built before build tracking); re-run master_resume_build to enable repair")
+			break
+		}
+		if integ.GraphSyncedAt == "" || res.GraphNodes < res.ExpectedGraphNodes {
+			res.Issues = append(res.Issues, fmt.Sprintf("graph out of sync: %d of %d nodes", res.GraphNodes, res.ExpectedGraphNodes))
+		}
+		if StoresFrom(ctx).MemDB != nil && integ.VectorsSyncedAt == "" {
+			res.Issues = append(res.Issues, fmt.Sprintf("vectors out of sync (%d expected)", len(plan.Vectors)))
+		}
+	}
+	if integ.Orphans > 0 {
+		res.Issues = append(res.Issues, fmt.Sprintf("%d orphan rows without a person", integ.Orphans))
+	}
+
+	res.Healthy = len(res.Issues) == 0
+	if res.Healthy {
+		res.Summary = fmt.Sprintf("Master resume %d is consistent: %d graph nodes, built %s.", integ.PersonID, res.GraphNodes, integ.EnrichedAt)
+	} else {
+		res.Summary = fmt.Sprintf("Master resume has %d issue(s): %s.", len(res.Issues), strings.Join(res.Issues, "; "))
+	}
+	return res, plan, nil
+}
+
+// repairMasterResume fixes what checkMasterResume found and returns a line per action taken.
+func repairMasterResume(ctx context.Context, db *ResumeDB, res *MasterResumeStatusResult, plan *buildPlan) []string {
+	var repairs []string
+	integ := res.Integrity
+
+	if integ.PersonID > 0 && integ.Persons > 1 {
+		if n, err := db.DeleteOtherPersons(ctx, integ.PersonID); err != nil {
+			slog.Warn("master_resume: delete stray persons failed", slog.Any("error", err))
+		} else {
+			repairs = append(repairs, fmt.Sprintf("deleted %d stray person(s)", n))
+		}
+	}
+	if integ.Orphans > 0 {
+		if n, err := db.DeleteOrphans(ctx); err != nil {
+			slog.Warn("master_resume: delete orphans failed", slog.Any("error", err))
+		} else {
+			repairs = append(repairs, fmt.Sprintf("deleted %d orphan row(s)", n))
+		}
+	}
+	if plan == nil {
+		return repairs
+	}
+	if integ.GraphSyncedAt == "" || res.GraphNodes < res.ExpectedGraphNodes {
+		failed := syncGraph(ctx, db, integ.PersonID, plan.Graph)
+		repairs = append(repairs, fmt.Sprintf("replayed graph: %d writes, %d failed", len(plan.Graph), failed))
+	}
+	if mdb := StoresFrom(ctx).MemDB; mdb != nil && integ.VectorsSyncedAt == "" {
+		stored, failed := syncVectors(ctx, db, mdb, integ.PersonID, plan.Vectors)
+		repairs = append(repairs, fmt.Sprintf("replayed vectors: %d stored, %d failed", stored, failed))
+	}
+	return repairs
+}
@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBuildPlanRoundTrip(t *testing.T) {
+	plan := &buildPlan{}
+	plan.node("Exp", 1, map[string]string{"title": "Engineer"})
+	plan.node("Skill", 7, map[string]string{"name": "Go"})
+	plan.node("Skill", 7, map[string]string{"name": "Go"})
+	plan.edge("Exp", 1, "USED_SKILL", "Skill", 7)
+	plan.vector("Engineer at Acme", "experience", 1)
+
+	if got := plan.nodeCount(); got != 2 {
+		t.Errorf("nodeCount = %d, want 2", got)
+	}
+
+	raw, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back buildPlan
+	if err := json.Unmarshal(raw, &back); err != nil {
+		t.Fatal(err)
+	}
+	if len(back.Graph) != 4 || back.Graph[3].Edge != "USED_SKILL" || back.Graph[3].ToID != 7 {
+		t.Errorf("graph ops not preserved: %+v", back.Graph)
+	}
+	if len(back.Vectors) != 1 || back.Vectors[0].Info["id"] != float64(1) || back.Vectors[0].Info["type"] != "experience" {
+		t.Errorf("vectors not preserved: %+v", back.Vectors)
+	}
+}
+
+func TestCompareBuildRows(t *testing.T) {
+	prev := map[string]int{"resume_experiences": 6, "resume_skills": 40, "resume_talks": 0}
+	cur := map[string]int{"resume_experiences": 4, "resume_skills": 40, "resume_talks": 0, "resume_patents": 1}
+	got := compareBuildRows(prev, cur)
+	want := []string{"resume_experiences 6 vs 4", "resume_patents 0 vs 1"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("compareBuildRows = %v, want %v", got, want)
+	}
+}
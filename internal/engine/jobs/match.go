@@ -4,6 +4,8 @@ import (
 	"sort"
 	"strings"
 	"unicode"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
 )
 
 // matchStopWords filters common English words that add noise to keyword matching.
@@ -29,13 +31,18 @@ func ExtractResumeKeywords(text string) map[string]bool {
 // extractMatchKW tokenizes text into lowercase keywords, skipping stop words.
 // Preserves tech suffixes like "c++", "c#", "node.js" by treating + # . as word chars.
 func extractMatchKW(text string) map[string]bool {
+	return matchWords(text, 3)
+}
+
+// matchWords is extractMatchKW keeping words of at least minRunes runes.
+func matchWords(text string, minRunes int) map[string]bool {
 	kw := make(map[string]bool)
 	var word strings.Builder
 	flush := func() {
 		w := word.String()
 		word.Reset()
 		w = strings.TrimRight(w, ".") // drop trailing dots
-		if len([]rune(w)) >= 3 && !matchStopWords[w] {
+		if len([]rune(w)) >= minRunes && !matchStopWords[w] {
 			kw[w] = true
 		}
 	}
@@ -58,12 +65,28 @@ func extractMatchKW(text string) map[string]bool {
 //   - matching: keywords present in both resume and job (candidate's strengths)
 //   - missing: important job keywords absent from resume (skills gap, top 20 max)
 func ScoreJobMatch(resumeKW map[string]bool, jobText string) (score float64, matching, missing []string) {
+	return ScoreJobMatchWeighted(resumeKW, nil, jobText)
+}
+
+// ScoreJobMatchWeighted is ScoreJobMatch with per-keyword resume weights (see
+// MasterSkillWeights): a resume keyword counts with its weight in both the overlap
+// and the union, so stale skills pull the score less. Keywords without a weight count 1.
+func ScoreJobMatchWeighted(resumeKW map[string]bool, weights map[string]float64, jobText string) (score float64, matching, missing []string) {
 	jobKW := extractMatchKW(jobText)
 
-	inter := 0
+	weight := func(kw string) float64 {
+		if w, ok := weights[kw]; ok {
+			return w
+		}
+		return 1
+	}
+
+	var inter, resumeTotal float64
 	for kw := range resumeKW {
+		w := weight(kw)
+		resumeTotal += w
 		if jobKW[kw] {
-			inter++
+			inter += w
 			matching = append(matching, kw)
 		}
 	}
@@ -73,9 +96,9 @@ func ScoreJobMatch(resumeKW map[string]bool, jobText string) (score float64, mat
 		}
 	}
 
-	union := len(resumeKW) + len(jobKW) - inter
+	union := resumeTotal + float64(len(jobKW)-len(matching))
 	if union > 0 {
-		raw := float64(inter) / float64(union) * 100
+		raw := inter / union * 100
 		score = float64(int(raw*10+0.5)) / 10 // round to 1 decimal
 	}
 
@@ -86,3 +109,53 @@ func ScoreJobMatch(resumeKW map[string]bool, jobText string) (score float64, mat
 	}
 	return score, matching, missing
 }
+
+// ExplainMatches sets match_explanation on each listing: the user's skills found in
+// the title and in the description or skill tags, and the listing's skills the user
+// lacks. Skills come from the skill normalizer (ExtractSkillsFromText), so the result
+// is deterministic. weights are the resume skill keywords (see MasterStackWeights);
+// nothing is set without them. match_explanation is an output_version 2 field.
+func ExplainMatches(listings []engine.JobListing, weights map[string]float64) {
+	if len(weights) == 0 {
+		return
+	}
+	for i := range listings {
+		j := &listings[i]
+		var e engine.MatchExplanation
+		title := ExtractSkillsFromText(j.Title)
+		for _, s := range title {
+			if hasSkill(s, weights) {
+				e.TitleMatches = append(e.TitleMatches, s)
+			}
+		}
+		for _, s := range MergeSkills(ExtractSkillsFromText(j.Description), j.Skills) {
+			if hasSkill(s, weights) {
+				e.DescriptionMatches = append(e.DescriptionMatches, s)
+			}
+		}
+		for _, s := range MergeSkills(title, ExtractSkillsFromText(j.Description), j.Skills) {
+			if !hasSkill(s, weights) {
+				e.Unmet = append(e.Unmet, s)
+			}
+		}
+		if e.TitleMatches != nil || e.DescriptionMatches != nil || e.Unmet != nil {
+			j.MatchExplanation = &e
+		}
+	}
+}
+
+// hasSkill reports whether a skill, by name, alias or keyword, is among the resume weights.
+func hasSkill(skill string, weights map[string]float64) bool {
+	for _, name := range expandQueryAliases(strings.ToLower(skill)) {
+		if _, ok := weights[name]; ok {
+			return true
+		}
+	}
+	kws := extractMatchKW(skill)
+	for kw := range kws {
+		if _, ok := weights[kw]; !ok {
+			return false
+		}
+	}
+	return len(kws) > 0
+}
@@ -3,13 +3,69 @@ package jobs
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"sync"
 	"time"
+
+	"github.com/anatolykoptev/go-kit/retry"
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Per-operation MemDB timeouts. "fine" mode adds run LLM extraction server-side, so they get the longest budget.
+const (
+	memdbAddTimeout    = 90 * time.Second
+	memdbSearchTimeout = 20 * time.Second
+	memdbDeleteTimeout = 20 * time.Second
+	memdbPingTimeout   = 3 * time.Second
 )
 
+// memdbRetry retries transport errors, 429 and 5xx with exponential backoff.
+var memdbRetry = retry.Options{
+	MaxAttempts:  3,
+	InitialDelay: 500 * time.Millisecond,
+	MaxDelay:     5 * time.Second,
+	Jitter:       true,
+}
+
+// memdbAddRetry retries an add only when MemDB cannot have stored it, since an add
+// is not idempotent: see memdbAddRetryable.
+var memdbAddRetry = func() retry.Options {
+	o := memdbRetry
+	o.RetryIf = memdbAddRetryable
+	return o
+}()
+
+// memdbAddRetryable reports whether a failed add is safe to resend: the connection
+// was refused, or MemDB answered 429 or 503 without processing the request. A timeout
+// or a 500/502/504 may follow a stored memory, so it is returned instead.
+func memdbAddRetryable(err error) bool {
+	var httpErr *retry.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode == http.StatusServiceUnavailable
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// memdbIdempotencyKey derives the Idempotency-Key of an add from its content and
+// info, so a resend of the same memory carries the same key.
+func memdbIdempotencyKey(content string, info map[string]any) string {
+	data, _ := json.Marshal(struct {
+		Content string         `json:"content"`
+		Info    map[string]any `json:"info,omitempty"`
+	}{content, info})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
 // MemDBClient talks to the memdb-go HTTP API.
 type MemDBClient struct {
 	baseURL       string
@@ -17,21 +73,65 @@ type MemDBClient struct {
 	http          *http.Client
 }
 
-// NewMemDBClient creates a MemDB client.
+// NewMemDBClient creates a MemDB client. It has its own HTTP client; deadlines come from
+// the per-operation timeouts above rather than a client-wide limit.
 func NewMemDBClient(baseURL, serviceSecret string) *MemDBClient {
 	return &MemDBClient{
 		baseURL:       baseURL,
 		serviceSecret: serviceSecret,
-		http:          &http.Client{Timeout: 60 * time.Second},
+		http:          &http.Client{Transport: http.DefaultTransport.(*http.Transport).Clone()},
 	}
 }
 
+// MemDBItem is a memory to store: text plus info metadata (type, id, source).
+type MemDBItem struct {
+	Content string         `json:"content"`
+	Info    map[string]any `json:"info"`
+}
+
+// Ping checks that MemDB is reachable and healthy.
+func (c *MemDBClient) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, memdbPingTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/health", nil)
+	if err != nil {
+		return fmt.Errorf("memdb ping: %w", err)
+	}
+	resp, err := c.http.Do(req) //nolint:gosec // MemDB internal API URL, intentional outbound request
+	if err != nil {
+		return fmt.Errorf("memdb ping: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("memdb ping: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// AddBatch stores items concurrently on the outbound pool. The returned slice holds
+// one error per item (nil on success), in item order.
+func (c *MemDBClient) AddBatch(ctx context.Context, items []MemDBItem) []error {
+	errs := make([]error, len(items))
+	var wg sync.WaitGroup
+	for i, it := range items {
+		wg.Add(1)
+		engine.GoOutbound(ctx, func() {
+			defer wg.Done()
+			_, errs[i] = c.Add(ctx, it.Content, it.Info)
+		})
+	}
+	wg.Wait()
+	return errs
+}
+
 // AddResult holds the response from a MemDB add operation.
 type AddResult struct {
 	MemoryID string
 }
 
-// Add sends a memory to MemDB for enrichment and returns the new memory ID.
+// Add sends a memory to MemDB for enrichment and returns the new memory ID. The
+// request carries an Idempotency-Key and is resent only on the failures
+// memdbAddRetryable allows.
 func (c *MemDBClient) Add(ctx context.Context, content string, info map[string]any) (*AddResult, error) {
 	body := map[string]any{
 		"user_id":           "gojob",
@@ -44,7 +144,9 @@ func (c *MemDBClient) Add(ctx context.Context, content string, info map[string]a
 		body["info"] = info
 	}
 
-	resp, err := c.post(ctx, "/product/add", body)
+	ctx, cancel := context.WithTimeout(ctx, memdbAddTimeout)
+	defer cancel()
+	resp, err := c.send(ctx, "/product/add", body, memdbAddRetry, memdbIdempotencyKey(content, info))
 	if err != nil {
 		return nil, fmt.Errorf("memdb add: %w", err)
 	}
@@ -89,6 +191,8 @@ func (c *MemDBClient) Search(ctx context.Context, query string, topK int, relati
 		"relativity":        relativity,
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, memdbSearchTimeout)
+	defer cancel()
 	resp, err := c.post(ctx, "/product/search", body)
 	if err != nil {
 		return nil, fmt.Errorf("memdb search: %w", err)
@@ -132,6 +236,25 @@ func (c *MemDBClient) Search(ctx context.Context, query string, topK int, relati
 	return results, nil
 }
 
+// ListAll returns up to limit gojob memories, deduplicated by memory ID.
+// MemDB has no list endpoint, so this is a zero-relativity search over resume vocabulary.
+func (c *MemDBClient) ListAll(ctx context.Context, limit int) ([]MemDBSearchResult, error) {
+	results, err := c.Search(ctx, "resume experience project skill achievement note goal", limit, 0.0)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(results))
+	out := results[:0]
+	for _, r := range results {
+		if r.MemoryID == "" || seen[r.MemoryID] {
+			continue
+		}
+		seen[r.MemoryID] = true
+		out = append(out, r)
+	}
+	return out, nil
+}
+
 // DeleteByUser deletes all memories for the gojob user/cube.
 func (c *MemDBClient) DeleteByUser(ctx context.Context, memoryIDs []string) error {
 	if len(memoryIDs) == 0 {
@@ -142,6 +265,8 @@ func (c *MemDBClient) DeleteByUser(ctx context.Context, memoryIDs []string) erro
 		"memory_ids": memoryIDs,
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, memdbDeleteTimeout)
+	defer cancel()
 	resp, err := c.post(ctx, "/product/delete_memory", body)
 	if err != nil {
 		return fmt.Errorf("memdb delete: %w", err)
@@ -180,20 +305,35 @@ func (c *MemDBClient) ClearAllBySearch(ctx context.Context) error {
 	}
 }
 
+// post sends a JSON request, retrying transport errors, 429 and 5xx per memdbRetry.
+// Only idempotent operations (search, list, delete) use it.
 func (c *MemDBClient) post(ctx context.Context, path string, body any) (*http.Response, error) {
+	return c.send(ctx, path, body, memdbRetry, "")
+}
+
+// send posts a JSON request with the given retry options and, when set, an
+// Idempotency-Key header.
+func (c *MemDBClient) send(ctx context.Context, path string, body any, opts retry.Options, idempotencyKey string) (*http.Response, error) {
 	data, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(data))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	if c.serviceSecret != "" {
-		req.Header.Set("X-Internal-Service", c.serviceSecret)
+	opts.OnRetry = func(attempt int, err error) {
+		slog.Debug("memdb retry", slog.String("path", path), slog.Int("attempt", attempt+1), slog.Any("error", err))
 	}
-
-	return c.http.Do(req) //nolint:gosec // MemDB internal API URL, intentional outbound request
+	return retry.HTTP(ctx, opts, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(data))
+		if err != nil {
+			return nil, retry.Permanent(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if c.serviceSecret != "" {
+			req.Header.Set("X-Internal-Service", c.serviceSecret)
+		}
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		return c.http.Do(req) //nolint:gosec // MemDB internal API URL, intentional outbound request
+	})
 }
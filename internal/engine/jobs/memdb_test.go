@@ -0,0 +1,117 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMemDBAddRetriesOn503(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	keys := make(chan string, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys <- r.Header.Get("Idempotency-Key")
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"memory_id":"m1"}]}`))
+	}))
+	defer srv.Close()
+
+	res, err := NewMemDBClient(srv.URL, "secret").Add(context.Background(), "text", nil)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if res.MemoryID != "m1" {
+		t.Errorf("MemoryID = %q, want m1", res.MemoryID)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("calls = %d, want 2", n)
+	}
+	if first, second := <-keys, <-keys; first == "" || first != second {
+		t.Errorf("Idempotency-Key = %q then %q, want the same non-empty key", first, second)
+	}
+}
+
+// A 500 or 504 may come after MemDB stored the memory, so the add is not resent.
+func TestMemDBAddDoesNotRetryUnsafeFailures(t *testing.T) {
+	t.Parallel()
+
+	for _, status := range []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout} {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(status)
+		}))
+		if _, err := NewMemDBClient(srv.URL, "").Add(context.Background(), "text", nil); err == nil {
+			t.Errorf("status %d: want error", status)
+		}
+		if n := calls.Load(); n != 1 {
+			t.Errorf("status %d: calls = %d, want 1", status, n)
+		}
+		srv.Close()
+	}
+}
+
+func TestMemDBIdempotencyKey(t *testing.T) {
+	a := memdbIdempotencyKey("text", map[string]any{"type": "skill", "id": 1})
+	if b := memdbIdempotencyKey("text", map[string]any{"id": 1, "type": "skill"}); a != b {
+		t.Errorf("same memory, different keys: %s, %s", a, b)
+	}
+	if c := memdbIdempotencyKey("text", map[string]any{"type": "skill", "id": 2}); a == c {
+		t.Error("different info, same key")
+	}
+}
+
+func TestMemDBAddBatch(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Content string `json:"memory_content"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Content == "bad" {
+			http.Error(w, "rejected", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"memory_id":"x"}]}`))
+	}))
+	defer srv.Close()
+
+	items := []MemDBItem{{Content: "a"}, {Content: "bad"}, {Content: "b"}, {Content: "c"}, {Content: "d"}}
+	errs := NewMemDBClient(srv.URL, "").AddBatch(context.Background(), items)
+	if len(errs) != len(items) {
+		t.Fatalf("got %d errors, want %d", len(errs), len(items))
+	}
+	for i, err := range errs {
+		if (err != nil) != (i == 1) {
+			t.Errorf("item %d: err = %v", i, err)
+		}
+	}
+}
+
+func TestMemDBPing(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	if err := NewMemDBClient(srv.URL, "").Ping(context.Background()); err != nil {
+		t.Errorf("Ping: %v", err)
+	}
+	srv.Close()
+	if err := NewMemDBClient(srv.URL, "").Ping(context.Background()); err == nil {
+		t.Error("Ping on closed server: want error")
+	}
+}
@@ -0,0 +1,406 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Mock interview ---
+
+// A mock interview is a session stored in the tracker database: the server generates
+// the questions up front, asks them one at a time, evaluates each answer against the
+// candidate's resume (default: master resume) and ends with a scored debrief.
+
+const (
+	mockDefaultQuestions = 5
+	mockMaxQuestions     = 10
+)
+
+// Mock interview session states.
+const (
+	MockInProgress = "in_progress"
+	MockCompleted  = "completed"
+)
+
+// MockQuestion is one question of a mock interview.
+type MockQuestion struct {
+	Number   int    `json:"number"`
+	Category string `json:"category"` // behavioral, technical, system_design
+	Question string `json:"question"`
+	// LookingFor is what a strong answer covers; kept server-side until the answer is in.
+	LookingFor string `json:"-"`
+}
+
+// AnswerEvaluation is the feedback on one answer.
+type AnswerEvaluation struct {
+	Number       int      `json:"number"`
+	Score        int      `json:"score"`    // 1-10
+	Grounded     bool     `json:"grounded"` // answer draws on the candidate's real experience
+	Strengths    []string `json:"strengths"`
+	Improvements []string `json:"improvements"`
+	// BetterExample names resume experience the answer could have used.
+	BetterExample string `json:"better_example,omitempty"`
+	LookingFor    string `json:"looking_for,omitempty"`
+}
+
+// MockDebrief is the scored summary of a finished session.
+type MockDebrief struct {
+	OverallScore   int            `json:"overall_score"` // 0-100
+	Answered       int            `json:"answered"`
+	Skipped        int            `json:"skipped"`
+	CategoryScores map[string]int `json:"category_scores"` // 0-100 per category answered
+	GroundedShare  float64        `json:"grounded_share"`  // fraction of answers using real experience
+	TopStrengths   []string       `json:"top_strengths"`
+	FocusAreas     []string       `json:"focus_areas"`
+	Verdict        string         `json:"verdict"` // "ready", "almost_ready", "needs_practice"
+}
+
+// MockInterviewResult is the structured output of mock_interview.
+type MockInterviewResult struct {
+	SessionID      string            `json:"session_id"`
+	Status         string            `json:"status"`
+	Role           string            `json:"role,omitempty"`
+	Company        string            `json:"company,omitempty"`
+	TotalQuestions int               `json:"total_questions"`
+	Feedback       *AnswerEvaluation `json:"feedback,omitempty"` // on the answer just given
+	Question       *MockQuestion     `json:"question,omitempty"` // next question to answer
+	Debrief        *MockDebrief      `json:"debrief,omitempty"`
+}
+
+// mockSession is the persisted state of a mock interview.
+type mockSession struct {
+	ID          string             `json:"id"`
+	Status      string             `json:"status"`
+	Role        string             `json:"role"`
+	Company     string             `json:"company"`
+	Resume      string             `json:"resume"`
+	Questions   []mockStoredQ      `json:"questions"`
+	Current     int                `json:"current"` // index of the next question to ask
+	Evaluations []AnswerEvaluation `json:"evaluations"`
+	Debrief     *MockDebrief       `json:"debrief,omitempty"`
+}
+
+// mockStoredQ is a MockQuestion with its rubric, as persisted.
+type mockStoredQ struct {
+	Category   string `json:"category"`
+	Question   string `json:"question"`
+	LookingFor string `json:"looking_for"`
+}
+
+// initMockInterviewSchema creates the mock_interviews table in the tracker database.
+func initMockInterviewSchema(db *sql.DB) error {
+	schema := `CREATE TABLE IF NOT EXISTS mock_interviews (
+		id         TEXT PRIMARY KEY,
+		status     TEXT NOT NULL,
+		state      TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL
+	)`
+	_, err := db.Exec(schema) //nolint:noctx // schema init, no user context available
+	return err
+}
+
+const mockQuestionsPrompt = `You are an interviewer running a realistic mock interview for the role below.
+
+JOB DESCRIPTION:
+%s
+%s
+CANDIDATE RESUME:
+%s
+
+FOCUS: %s
+
+Write exactly %d interview questions in the order you would ask them, as a real interviewer for this role would: start with a warm-up, then probe the JD's core requirements and the candidate's claimed experience. Mix behavioral, technical and (for senior roles) system design questions unless the focus says otherwise. For each question state what a strong answer covers.
+
+Return a JSON object with this exact structure:
+{
+  "role": "<role title from the JD>",
+  "questions": [
+    {"category": "<behavioral|technical|system_design>", "question": "<question>", "looking_for": "<what a strong answer covers, 1-2 sentences>"}
+  ]
+}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+const mockEvaluatePrompt = `You are an interviewer evaluating a candidate's answer in a mock interview.
+
+CANDIDATE RESUME (their real experience):
+%s
+
+QUESTION (%s): %s
+A STRONG ANSWER COVERS: %s
+
+CANDIDATE ANSWER:
+%s
+
+Evaluate the answer honestly. Score 1-10 (7+ would pass a real interview). "grounded" is true only if the answer uses concrete experience that is consistent with the resume; claims the resume does not support are a weakness. If the resume holds a better example than the one used, name it.
+
+Return a JSON object with this exact structure:
+{
+  "score": <1-10>,
+  "grounded": <true|false>,
+  "strengths": [<up to 3 short points>],
+  "improvements": [<up to 3 short, actionable points>],
+  "better_example": "<resume experience that would answer this better, or empty string>"
+}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+// MockInterview starts a session (no session_id) or continues one: an answer is
+// evaluated and the next question returned; after the last question, or with end set,
+// the session completes with a debrief.
+func MockInterview(ctx context.Context, input engine.MockInterviewInput) (*MockInterviewResult, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	if input.SessionID == "" {
+		return startMockInterview(ctx, db, input)
+	}
+
+	s, err := loadMockSession(ctx, db, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if s.Status == MockCompleted {
+		return s.result(nil), nil
+	}
+
+	var feedback *AnswerEvaluation
+	if answer := strings.TrimSpace(input.Answer); answer != "" {
+		ev, err := evaluateMockAnswer(ctx, s, answer)
+		if err != nil {
+			return nil, err
+		}
+		s.Evaluations = append(s.Evaluations, *ev)
+		s.Current++
+		feedback = ev
+	} else if !input.End {
+		return nil, errors.New("answer is required to continue a session (or end=true to finish)")
+	}
+	if input.End || s.Current >= len(s.Questions) {
+		s.Status = MockCompleted
+		s.Debrief = buildMockDebrief(s)
+	}
+	if err := saveMockSession(ctx, db, s); err != nil {
+		return nil, err
+	}
+	return s.result(feedback), nil
+}
+
+func startMockInterview(ctx context.Context, db *sql.DB, input engine.MockInterviewInput) (*MockInterviewResult, error) {
+	if input.JobDescription == "" {
+		return nil, errors.New("job_description is required to start a session")
+	}
+	resume := input.Resume
+	if resume == "" {
+		feed, err := BuildResumeFeed(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("resume is required without a master resume: %w", err)
+		}
+		resume = ResumeFeedText(feed)
+	}
+	n := input.Questions
+	if n <= 0 {
+		n = mockDefaultQuestions
+	}
+	n = min(n, mockMaxQuestions)
+	focus := strings.ToLower(input.Focus)
+	if focus == "" {
+		focus = "all"
+	}
+
+	var companyContext string
+	if input.Company != "" {
+		if res, err := ResearchCompany(ctx, input.Company); err != nil {
+			slog.Debug("mock_interview: company research failed", slog.Any("error", err))
+		} else {
+			companyContext = BuildCompanyContext(input.Company, res)
+		}
+		if exp, err := MineInterviewExperiences(ctx, input.Company, jdRoleHint(input.JobDescription)); err != nil {
+			slog.Debug("mock_interview: interview experiences failed", slog.Any("error", err))
+		} else {
+			companyContext += BuildInterviewExperienceContext(exp)
+		}
+	}
+
+	prompt := fmt.Sprintf(mockQuestionsPrompt, engine.TruncateRunes(input.JobDescription, 3000, ""),
+		companyContext, engine.TruncateRunes(resume, 4000, ""), focus, n)
+	raw, err := engine.CallLLM(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("mock_interview LLM: %w", err)
+	}
+	var gen struct {
+		Role      string        `json:"role"`
+		Questions []mockStoredQ `json:"questions"`
+	}
+	if err := json.Unmarshal([]byte(StripMarkdownFences(raw)), &gen); err != nil {
+		return nil, fmt.Errorf("mock_interview parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+	}
+	if len(gen.Questions) == 0 {
+		return nil, errors.New("mock_interview: no questions generated")
+	}
+	if len(gen.Questions) > n {
+		gen.Questions = gen.Questions[:n]
+	}
+
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, fmt.Errorf("mock_interview session id: %w", err)
+	}
+	s := &mockSession{
+		ID:          "mock_" + hex.EncodeToString(b[:]),
+		Status:      MockInProgress,
+		Role:        gen.Role,
+		Company:     input.Company,
+		Resume:      engine.TruncateRunes(resume, 6000, ""),
+		Questions:   gen.Questions,
+		Evaluations: []AnswerEvaluation{},
+	}
+	if err := saveMockSession(ctx, db, s); err != nil {
+		return nil, err
+	}
+	return s.result(nil), nil
+}
+
+// evaluateMockAnswer scores the answer to the session's current question.
+func evaluateMockAnswer(ctx context.Context, s *mockSession, answer string) (*AnswerEvaluation, error) {
+	q := s.Questions[s.Current]
+	prompt := fmt.Sprintf(mockEvaluatePrompt, engine.TruncateRunes(s.Resume, 4000, ""),
+		q.Category, q.Question, q.LookingFor, engine.TruncateRunes(answer, 3000, ""))
+	raw, err := engine.CallLLM(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("mock_interview evaluate LLM: %w", err)
+	}
+	var ev AnswerEvaluation
+	if err := json.Unmarshal([]byte(StripMarkdownFences(raw)), &ev); err != nil {
+		return nil, fmt.Errorf("mock_interview evaluate parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+	}
+	ev.Number = s.Current + 1
+	ev.Score = max(1, min(10, ev.Score))
+	ev.LookingFor = q.LookingFor
+	return &ev, nil
+}
+
+// buildMockDebrief scores a session from its evaluations: the overall and per-category
+// scores are mean answer scores on 0-100; unanswered questions count as skipped.
+func buildMockDebrief(s *mockSession) *MockDebrief {
+	d := &MockDebrief{
+		Answered:       len(s.Evaluations),
+		Skipped:        len(s.Questions) - len(s.Evaluations),
+		CategoryScores: map[string]int{},
+		TopStrengths:   []string{},
+		FocusAreas:     []string{},
+	}
+	if d.Answered == 0 {
+		d.Verdict = "needs_practice"
+		return d
+	}
+	var total, grounded int
+	catSum := map[string]int{}
+	catN := map[string]int{}
+	for _, ev := range s.Evaluations {
+		total += ev.Score
+		if ev.Grounded {
+			grounded++
+		}
+		cat := s.Questions[ev.Number-1].Category
+		catSum[cat] += ev.Score
+		catN[cat]++
+	}
+	d.OverallScore = int(math.Round(float64(total) / float64(d.Answered) * 10))
+	for cat, sum := range catSum {
+		d.CategoryScores[cat] = int(math.Round(float64(sum) / float64(catN[cat]) * 10))
+	}
+	d.GroundedShare = math.Round(float64(grounded)/float64(d.Answered)*100) / 100
+
+	// Strengths from the best answers, focus areas from the weakest.
+	byScore := append([]AnswerEvaluation(nil), s.Evaluations...)
+	sort.SliceStable(byScore, func(i, j int) bool { return byScore[i].Score > byScore[j].Score })
+	for _, ev := range byScore {
+		if ev.Score >= 7 && len(ev.Strengths) > 0 && len(d.TopStrengths) < 3 {
+			d.TopStrengths = appendUnique(d.TopStrengths, ev.Strengths[0])
+		}
+	}
+	for i := len(byScore) - 1; i >= 0; i-- {
+		ev := byScore[i]
+		if ev.Score < 7 && len(ev.Improvements) > 0 && len(d.FocusAreas) < 3 {
+			d.FocusAreas = appendUnique(d.FocusAreas, ev.Improvements[0])
+		}
+	}
+	if d.GroundedShare < 0.5 {
+		d.FocusAreas = append(d.FocusAreas, "Anchor more answers in concrete projects and metrics from your own experience.")
+	}
+
+	switch {
+	case d.OverallScore >= 75 && d.Skipped == 0:
+		d.Verdict = "ready"
+	case d.OverallScore >= 60:
+		d.Verdict = "almost_ready"
+	default:
+		d.Verdict = "needs_practice"
+	}
+	return d
+}
+
+// result builds the tool output for the session's current state.
+func (s *mockSession) result(feedback *AnswerEvaluation) *MockInterviewResult {
+	r := &MockInterviewResult{
+		SessionID:      s.ID,
+		Status:         s.Status,
+		Role:           s.Role,
+		Company:        s.Company,
+		TotalQuestions: len(s.Questions),
+		Feedback:       feedback,
+		Debrief:        s.Debrief,
+	}
+	if s.Status == MockInProgress && s.Current < len(s.Questions) {
+		q := s.Questions[s.Current]
+		r.Question = &MockQuestion{Number: s.Current + 1, Category: q.Category, Question: q.Question}
+	}
+	return r
+}
+
+func loadMockSession(ctx context.Context, db *sql.DB, id string) (*mockSession, error) {
+	var state string
+	err := db.QueryRowContext(ctx, `SELECT state FROM mock_interviews WHERE id = ?`, id).Scan(&state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("mock interview session %q not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mock interview load: %w", err)
+	}
+	var s mockSession
+	if err := json.Unmarshal([]byte(state), &s); err != nil {
+		return nil, fmt.Errorf("mock interview decode: %w", err)
+	}
+	return &s, nil
+}
+
+func saveMockSession(ctx context.Context, db *sql.DB, s *mockSession) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("mock interview encode: %w", err)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err = db.ExecContext(ctx, `INSERT INTO mock_interviews (id, status, state, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET status = excluded.status, state = excluded.state, updated_at = excluded.updated_at`,
+		s.ID, s.Status, string(data), now, now)
+	if err != nil {
+		return fmt.Errorf("mock interview save: %w", err)
+	}
+	return nil
+}
@@ -67,7 +67,7 @@ func SearchOpire(ctx context.Context, limit int) ([]engine.BountyListing, error)
 }
 
 func scrapeOpireBounties(ctx context.Context) ([]engine.BountyListing, error) {
-	fetchCtx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, opireHomeURL, nil)
@@ -78,7 +78,7 @@ func scrapeOpireBounties(ctx context.Context) ([]engine.BountyListing, error) {
 	req.Header.Set("RSC", "1")
 	req.Header.Set("User-Agent", engine.UserAgentChrome)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	if err != nil {
 		return nil, fmt.Errorf("opire request failed: %w", err)
 	}
@@ -0,0 +1,149 @@
+package jobs
+
+import (
+	"regexp"
+	"strings"
+
+	linkedin "github.com/anatolykoptev/go-linkedin"
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+var (
+	noSponsorshipRe = regexp.MustCompile(`(?i)(no|not|unable to|cannot|can't|won't|will not) (offer |provide )?(visa )?sponsor|without (visa )?sponsorship|must be (legally )?authori[sz]ed to work`)
+	sponsorshipRe   = regexp.MustCompile(`(?i)(visa sponsorship (is )?(available|provided|offered)|(we|will) sponsor|sponsorship available|relocation and visa)`)
+)
+
+// BuildListingV2 fills the output_version 2 blocks (salary_normalized, eligibility, scores)
+// from the flat fields already set on each listing.
+func BuildListingV2(listings []engine.JobListing) {
+	for i := range listings {
+		j := &listings[i]
+		if lo, hi := annualize(j.SalaryMin, j.SalaryInterval), annualize(j.SalaryMax, j.SalaryInterval); lo != nil || hi != nil {
+			j.SalaryNorm = &engine.NormalizedSalary{AnnualMin: lo, AnnualMax: hi, Currency: j.SalaryCurrency}
+		}
+		j.Eligibility = detectEligibility(*j)
+		j.Scores = &engine.ListingScores{
+			ScamRisk:       j.ScamRisk,
+			ScamSignals:    j.ScamSignals,
+			Evergreen:      j.Evergreen,
+			DaysOpen:       j.DaysOpen,
+			DeadlineUrgent: j.DeadlineUrgent,
+		}
+	}
+}
+
+// ApplyOutputVersion translates fully-enriched listings to the requested output shape.
+// v1 drops the v2 blocks and fields (company_info, company_rating, match_explanation, ...);
+// v2 moves flat score fields into the scores block.
+// Unknown versions fall back to v1 so old clients never see an unexpected shape.
+func ApplyOutputVersion(listings []engine.JobListing, version int) {
+	for i := range listings {
+		j := &listings[i]
+		if version == engine.OutputV2 {
+			j.ScamRisk, j.ScamSignals = "", nil
+			j.Evergreen, j.DaysOpen, j.DeadlineUrgent = false, 0, false
+			continue
+		}
+		j.SalaryNorm, j.Eligibility, j.Scores, j.CompanyInfo, j.PriorApp = nil, nil, nil, nil, nil
+		j.MatchExplanation, j.CompanyRating = nil, 0
+		j.Equity, j.Benefits, j.PTOPolicy, j.Match401k = "", nil, "", ""
+		j.WorkStyle = nil
+		j.Institution, j.TenureTrack = "", false
+	}
+}
+
+// ApplyRemoteOutputVersion translates remote_work_search listings: v1 drops the fields
+// added after its shape was frozen (salary_normalized, comp_fit, overlap_hours,
+// eligible_from); v2 keeps them.
+func ApplyRemoteOutputVersion(listings []engine.RemoteJobListing, version int) {
+	if version == engine.OutputV2 {
+		return
+	}
+	for i := range listings {
+		j := &listings[i]
+		j.SalaryNorm, j.CompFit, j.OverlapHours, j.EligibleFrom = nil, "", nil, nil
+	}
+}
+
+// ApplyFreelanceOutputVersion adds the v2 scores block (scam risk and signals) to
+// freelance_search projects; v1 projects are left as they are.
+func ApplyFreelanceOutputVersion(projects []engine.FreelanceProject, version int) {
+	if version != engine.OutputV2 {
+		return
+	}
+	listings := make([]engine.JobListing, len(projects))
+	for i, p := range projects {
+		listings[i] = engine.JobListing{Title: p.Title, URL: p.URL, Salary: p.Budget, Description: p.Description}
+	}
+	AnnotateScamRisk(listings)
+	BuildListingV2(listings)
+	for i := range projects {
+		projects[i].Scores = listings[i].Scores
+	}
+}
+
+// VoyagerJob is a linkedin_jobs listing: the Voyager job plus the output_version 2
+// eligibility and scores blocks.
+type VoyagerJob struct {
+	linkedin.Job
+	Eligibility *engine.Eligibility   `json:"eligibility,omitempty"`
+	Scores      *engine.ListingScores `json:"scores,omitempty"`
+}
+
+// ApplyLinkedInOutputVersion wraps Voyager jobs for linkedin_jobs, filling the
+// eligibility and scores blocks for v2.
+func ApplyLinkedInOutputVersion(voyagerJobs []linkedin.Job, version int) []VoyagerJob {
+	out := make([]VoyagerJob, len(voyagerJobs))
+	listings := make([]engine.JobListing, len(voyagerJobs))
+	for i, j := range voyagerJobs {
+		out[i].Job = j
+		listings[i] = engine.JobListing{
+			Title: j.Title, Company: j.Company, URL: j.ApplyURL,
+			Location: j.Location, Remote: j.Remote, Description: j.Description,
+		}
+	}
+	if version != engine.OutputV2 {
+		return out
+	}
+	AnnotateScamRisk(listings)
+	BuildListingV2(listings)
+	for i := range out {
+		out[i].Eligibility, out[i].Scores = listings[i].Eligibility, listings[i].Scores
+	}
+	return out
+}
+
+func detectEligibility(j engine.JobListing) *engine.Eligibility {
+	text := j.Title + "\n" + j.Location + "\n" + j.Remote + "\n" + j.Description
+	var e engine.Eligibility
+	e.RemoteScope = strings.Join(listingEligibility(j), ",")
+	switch {
+	case noSponsorshipRe.MatchString(text):
+		e.VisaSponsorship = "no"
+	case sponsorshipRe.MatchString(text):
+		e.VisaSponsorship = "yes"
+	}
+	e.CitizenshipRequired = detectCitizenship(text)
+	e.ClearanceRequired = detectClearance(text)
+	e.ImmediateStart = immediateStartRe.MatchString(text)
+	e.Deadline = j.Deadline
+	if e == (engine.Eligibility{}) {
+		return nil
+	}
+	return &e
+}
+
+// annualize converts a salary amount in the given interval to an annual figure.
+func annualize(v *int, interval string) *int {
+	if v == nil || *v <= 0 {
+		return nil
+	}
+	a := *v
+	switch interval {
+	case "hour":
+		a *= 2080
+	case "month":
+		a *= 12
+	}
+	return &a
+}
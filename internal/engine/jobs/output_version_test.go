@@ -0,0 +1,95 @@
+package jobs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	linkedin "github.com/anatolykoptev/go-linkedin"
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestApplyOutputVersion(t *testing.T) {
+	hourly := 60
+	build := func() []engine.JobListing {
+		l := []engine.JobListing{{
+			Title: "Go Engineer", Company: "Acme", Description: "Remote (US). We will sponsor visas.",
+			SalaryMax: &hourly, SalaryInterval: "hour", SalaryCurrency: "USD",
+			ScamRisk: ScamRiskLow, DaysOpen: 3, CompanyRating: 4.3,
+			MatchExplanation: &engine.MatchExplanation{TitleMatches: []string{"Go"}},
+		}}
+		BuildListingV2(l)
+		return l
+	}
+
+	v1 := build()
+	ApplyOutputVersion(v1, 0)
+	raw, _ := json.Marshal(v1[0])
+	for _, key := range []string{"salary_normalized", "eligibility", `"scores"`, "match_explanation", "company_rating"} {
+		if strings.Contains(string(raw), key) {
+			t.Errorf("v1 output contains v2 field %s: %s", key, raw)
+		}
+	}
+	if v1[0].ScamRisk != ScamRiskLow || v1[0].DaysOpen != 3 {
+		t.Errorf("v1 lost flat fields: %+v", v1[0])
+	}
+
+	v2 := build()
+	ApplyOutputVersion(v2, engine.OutputV2)
+	j := v2[0]
+	if j.SalaryNorm == nil || *j.SalaryNorm.AnnualMax != 60*2080 {
+		t.Errorf("salary_normalized = %+v, want annual max %d", j.SalaryNorm, 60*2080)
+	}
+	if j.Eligibility == nil || j.Eligibility.RemoteScope != "us" || j.Eligibility.VisaSponsorship != "yes" {
+		t.Errorf("eligibility = %+v", j.Eligibility)
+	}
+	if j.Scores == nil || j.Scores.ScamRisk != ScamRiskLow || j.Scores.DaysOpen != 3 {
+		t.Errorf("scores = %+v", j.Scores)
+	}
+	if j.MatchExplanation == nil || j.CompanyRating != 4.3 {
+		t.Error("v2 lost match_explanation or company_rating")
+	}
+	if j.ScamRisk != "" || j.DaysOpen != 0 {
+		t.Error("v2 should move flat score fields into scores")
+	}
+}
+
+func TestSearchToolOutputVersions(t *testing.T) {
+	fit := 4.0
+	remote := func() []engine.RemoteJobListing {
+		return []engine.RemoteJobListing{{
+			Title: "Go Dev", Salary: "$120k", SalaryNorm: normalizeSalaryText("$120k"),
+			CompFit: CompMeetsTarget, OverlapHours: &fit, EligibleFrom: []string{"eu"},
+		}}
+	}
+	v1 := remote()
+	ApplyRemoteOutputVersion(v1, 0)
+	if j := v1[0]; j.SalaryNorm != nil || j.CompFit != "" || j.OverlapHours != nil || j.EligibleFrom != nil {
+		t.Errorf("remote v1 kept v2 fields: %+v", j)
+	}
+	v2 := remote()
+	ApplyRemoteOutputVersion(v2, engine.OutputV2)
+	if v2[0].CompFit != CompMeetsTarget || v2[0].EligibleFrom == nil {
+		t.Errorf("remote v2 lost fields: %+v", v2[0])
+	}
+
+	projects := []engine.FreelanceProject{{Title: "Bot", Description: "Pay a $50 registration fee to start."}}
+	ApplyFreelanceOutputVersion(projects, 0)
+	if projects[0].Scores != nil {
+		t.Error("freelance v1 got a scores block")
+	}
+	ApplyFreelanceOutputVersion(projects, engine.OutputV2)
+	if s := projects[0].Scores; s == nil || s.ScamRisk == "" {
+		t.Errorf("freelance v2 scores = %+v", s)
+	}
+
+	voyager := []linkedin.Job{{Title: "Go Engineer", Location: "Remote (US)", Description: "We will sponsor visas."}}
+	raw, _ := json.Marshal(ApplyLinkedInOutputVersion(voyager, 0))
+	if strings.Contains(string(raw), "eligibility") || strings.Contains(string(raw), "scores") || !strings.Contains(string(raw), `"title":"Go Engineer"`) {
+		t.Errorf("linkedin v1 = %s", raw)
+	}
+	lv2 := ApplyLinkedInOutputVersion(voyager, engine.OutputV2)
+	if e := lv2[0].Eligibility; e == nil || e.VisaSponsorship != "yes" || lv2[0].Scores == nil {
+		t.Errorf("linkedin v2 = %+v", lv2[0])
+	}
+}
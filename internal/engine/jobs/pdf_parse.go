@@ -0,0 +1,735 @@
+package jobs
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Minimal PDF reader for resume_pdf_check: objects (including object streams),
+// FlateDecode streams, the page tree, fonts with their ToUnicode CMaps, and the text
+// show operators of page content streams with their positions. Enough for the PDFs
+// resume builders and word processors produce; not a general PDF parser.
+
+// pdfMaxInflated caps the bytes all FlateDecode streams of one PDF may inflate to,
+// so a small file cannot expand into a zlib bomb.
+const pdfMaxInflated = 64 << 20
+
+var errPDFInflateLimit = errors.New("decompressed streams exceed the size limit")
+
+// pdfDoc is a parsed PDF: object bodies (dictionary part) and decoded streams.
+type pdfDoc struct {
+	objs    map[int]string
+	streams map[int][]byte
+}
+
+// pdfTextRun is one shown string with its device-space position.
+type pdfTextRun struct {
+	page  int
+	x, y  float64
+	size  float64 // effective font size
+	width float64 // approximate: no font metrics are read
+	text  string
+}
+
+// pdfFontInfo describes a font resource.
+type pdfFontInfo struct {
+	name     string
+	embedded bool
+	type3    bool
+	cmap     map[string]string // code bytes → text, from ToUnicode
+	codeLen  int               // code width in bytes
+}
+
+var (
+	pdfObjRe       = regexp.MustCompile(`(?s)(\d+)\s+\d+\s+obj\b(.*?)\bendobj`)
+	pdfStreamRe    = regexp.MustCompile(`>>\s*stream\r?\n`)
+	pdfRefRe       = regexp.MustCompile(`(\d+)\s+\d+\s+R\b`)
+	pdfNamedRefRe  = regexp.MustCompile(`/([^\s/<>\[\]()]+)\s+(\d+)\s+\d+\s+R\b`)
+	pdfBaseFontRe  = regexp.MustCompile(`/BaseFont\s*/([^\s/<>\[\]()]+)`)
+	pdfFontFileRe  = regexp.MustCompile(`/FontFile[23]?\b`)
+	pdfCMapHexRe   = regexp.MustCompile(`<([0-9A-Fa-f]*)>`)
+	pdfBfCharRe    = regexp.MustCompile(`(?s)beginbfchar(.*?)endbfchar`)
+	pdfBfRangeRe   = regexp.MustCompile(`(?s)beginbfrange(.*?)endbfrange`)
+	pdfBfRangeLine = regexp.MustCompile(`<([0-9A-Fa-f]+)>\s*<([0-9A-Fa-f]+)>\s*(<[0-9A-Fa-f]+>|\[[^\]]*\])`)
+
+	// Per-key patterns for the dictionary keys the reader looks up.
+	pdfKeyRes  = pdfKeyPatterns(`/%s\b\s*`, "Pages", "Kids", "Parent", "Contents", "Resources", "Font", "FontDescriptor", "DescendantFonts", "ToUnicode")
+	pdfNameRes = pdfKeyPatterns(`/%s\s*/([^\s/<>\[\]()]+)`, "Type", "Subtype")
+	pdfIntRes  = pdfKeyPatterns(`/%s\s+(\d+)`, "N", "First")
+)
+
+// pdfKeyPatterns compiles format, with %s replaced by each key, once per key.
+func pdfKeyPatterns(format string, keys ...string) map[string]*regexp.Regexp {
+	m := make(map[string]*regexp.Regexp, len(keys))
+	for _, k := range keys {
+		m[k] = regexp.MustCompile(fmt.Sprintf(format, regexp.QuoteMeta(k)))
+	}
+	return m
+}
+
+// pdfKeyPattern returns the pattern precompiled for key; a missing key is a bug.
+func pdfKeyPattern(m map[string]*regexp.Regexp, key string) *regexp.Regexp {
+	re, ok := m[key]
+	if !ok {
+		panic("pdf: no pattern precompiled for /" + key)
+	}
+	return re
+}
+
+// parsePDF reads the objects of a PDF file. It fails once the decoded streams pass
+// pdfMaxInflated.
+func parsePDF(data []byte) (*pdfDoc, error) {
+	doc := &pdfDoc{objs: make(map[int]string), streams: make(map[int][]byte)}
+	budget := int64(pdfMaxInflated)
+	for _, m := range pdfObjRe.FindAllSubmatchIndex(data, -1) {
+		num, _ := strconv.Atoi(string(data[m[2]:m[3]]))
+		body := data[m[4]:m[5]]
+		if loc := pdfStreamRe.FindIndex(body); loc != nil {
+			dict := string(body[:loc[0]+2])
+			raw := body[loc[1]:]
+			if i := bytes.LastIndex(raw, []byte("endstream")); i >= 0 {
+				raw = bytes.TrimRight(raw[:i], "\r\n")
+			}
+			stream, err := pdfDecodeStream(dict, raw, budget)
+			if err != nil {
+				return nil, err
+			}
+			budget -= int64(len(stream))
+			doc.objs[num] = dict
+			doc.streams[num] = stream
+			continue
+		}
+		doc.objs[num] = string(body)
+	}
+	// Objects packed in object streams (PDF 1.5+).
+	for num, dict := range doc.objs {
+		if pdfHasName(dict, "Type", "ObjStm") {
+			doc.unpackObjStm(dict, doc.streams[num])
+		}
+	}
+	return doc, nil
+}
+
+// pdfDecodeStream inflates FlateDecode streams, failing with errPDFInflateLimit past
+// limit bytes; other filters are returned as is.
+func pdfDecodeStream(dict string, raw []byte, limit int64) ([]byte, error) {
+	if !strings.Contains(dict, "/FlateDecode") {
+		return raw, nil
+	}
+	r, err := zlib.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, nil
+	}
+	out, _ := io.ReadAll(io.LimitReader(r, limit+1)) // keep what inflated before a truncated tail
+	if int64(len(out)) > limit {
+		return nil, errPDFInflateLimit
+	}
+	return out, nil
+}
+
+func (doc *pdfDoc) unpackObjStm(dict string, data []byte) {
+	n, first := pdfInt(dict, "N"), pdfInt(dict, "First")
+	if n <= 0 || first <= 0 || first > len(data) {
+		return
+	}
+	fields := strings.Fields(string(data[:first]))
+	for k := 0; k < n && 2*k+1 < len(fields); k++ {
+		num, _ := strconv.Atoi(fields[2*k])
+		off, _ := strconv.Atoi(fields[2*k+1])
+		end := len(data)
+		if 2*k+3 < len(fields) {
+			next, _ := strconv.Atoi(fields[2*k+3])
+			end = first + next
+		}
+		if start := first + off; start < end && end <= len(data) {
+			if _, ok := doc.objs[num]; !ok {
+				doc.objs[num] = string(data[start:end])
+			}
+		}
+	}
+}
+
+// pdfHasName reports whether dict has /key /name.
+func pdfHasName(dict, key, name string) bool {
+	for _, m := range pdfKeyPattern(pdfNameRes, key).FindAllStringSubmatch(dict, -1) {
+		if m[1] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func pdfInt(dict, key string) int {
+	m := pdfKeyPattern(pdfIntRes, key).FindStringSubmatch(dict)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n
+}
+
+// pdfValue returns the raw value of /key: a balanced << >> or [ ] group, or the text up
+// to the next key.
+func pdfValue(dict, key string) string {
+	loc := pdfKeyPattern(pdfKeyRes, key).FindStringIndex(dict)
+	if loc == nil {
+		return ""
+	}
+	rest := dict[loc[1]:]
+	for _, pair := range [][2]string{{"<<", ">>"}, {"[", "]"}} {
+		if strings.HasPrefix(rest, pair[0]) {
+			depth := 0
+			for i := 0; i < len(rest); i++ {
+				switch {
+				case strings.HasPrefix(rest[i:], pair[0]):
+					depth++
+					i += len(pair[0]) - 1
+				case strings.HasPrefix(rest[i:], pair[1]):
+					depth--
+					if depth == 0 {
+						return rest[:i+len(pair[1])]
+					}
+					i += len(pair[1]) - 1
+				}
+			}
+			return rest
+		}
+	}
+	if i := strings.IndexAny(rest, "/>"); i >= 0 {
+		rest = rest[:i]
+	}
+	return strings.TrimSpace(rest)
+}
+
+// pdfRefs returns the object numbers referenced by /key (a single reference or an array).
+func pdfRefs(dict, key string) []int {
+	var out []int
+	for _, m := range pdfRefRe.FindAllStringSubmatch(pdfValue(dict, key), -1) {
+		n, _ := strconv.Atoi(m[1])
+		out = append(out, n)
+	}
+	return out
+}
+
+// dict resolves /key to a dictionary: inline, or the body of the referenced object.
+func (doc *pdfDoc) dict(dict, key string) string {
+	v := pdfValue(dict, key)
+	if strings.HasPrefix(v, "<<") {
+		return v
+	}
+	if m := pdfRefRe.FindStringSubmatch(v); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return doc.objs[n]
+	}
+	return ""
+}
+
+// pages returns the page dictionaries in page-tree order.
+func (doc *pdfDoc) pages() []string {
+	var root int
+	for _, body := range doc.objs {
+		if pdfHasName(body, "Type", "Catalog") {
+			if refs := pdfRefs(body, "Pages"); len(refs) > 0 {
+				root = refs[0]
+			}
+			break
+		}
+	}
+	var out []string
+	seen := make(map[int]bool)
+	var walk func(n int)
+	walk = func(n int) {
+		body, ok := doc.objs[n]
+		if !ok || seen[n] {
+			return
+		}
+		seen[n] = true
+		if pdfHasName(body, "Type", "Pages") {
+			for _, kid := range pdfRefs(body, "Kids") {
+				walk(kid)
+			}
+			return
+		}
+		out = append(out, body)
+	}
+	if root > 0 {
+		walk(root)
+	}
+	if len(out) == 0 { // no usable page tree: pages in object order
+		var nums []int
+		for n, body := range doc.objs {
+			if pdfHasName(body, "Type", "Page") {
+				nums = append(nums, n)
+			}
+		}
+		sort.Ints(nums)
+		for _, n := range nums {
+			out = append(out, doc.objs[n])
+		}
+	}
+	return out
+}
+
+// fonts lists every font dictionary in the document.
+func (doc *pdfDoc) fonts() []pdfFontInfo {
+	var out []pdfFontInfo
+	seen := make(map[string]bool)
+	for _, body := range doc.objs {
+		if !pdfHasName(body, "Type", "Font") {
+			continue
+		}
+		f := doc.font(body)
+		if f.name == "" || seen[f.name] {
+			continue
+		}
+		seen[f.name] = true
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out
+}
+
+// font reads a font dictionary: name, embedding and ToUnicode map.
+func (doc *pdfDoc) font(body string) pdfFontInfo {
+	f := pdfFontInfo{codeLen: 1, type3: pdfHasName(body, "Subtype", "Type3")}
+	if m := pdfBaseFontRe.FindStringSubmatch(body); m != nil {
+		f.name = m[1]
+		if i := strings.IndexByte(f.name, '+'); i == 6 { // subset prefix "ABCDEF+"
+			f.name = f.name[i+1:]
+		}
+	}
+	descriptors := pdfRefs(body, "FontDescriptor")
+	if pdfHasName(body, "Subtype", "Type0") {
+		f.codeLen = 2
+		for _, d := range pdfRefs(body, "DescendantFonts") {
+			descriptors = append(descriptors, pdfRefs(doc.objs[d], "FontDescriptor")...)
+		}
+	}
+	for _, d := range descriptors {
+		if pdfFontFileRe.MatchString(doc.objs[d]) {
+			f.embedded = true
+		}
+	}
+	if refs := pdfRefs(body, "ToUnicode"); len(refs) > 0 {
+		f.cmap, f.codeLen = parseToUnicode(doc.streams[refs[0]], f.codeLen)
+	}
+	return f
+}
+
+// parseToUnicode reads bfchar and bfrange mappings of a ToUnicode CMap.
+func parseToUnicode(data []byte, codeLen int) (map[string]string, int) {
+	cmap := make(map[string]string)
+	text := string(data)
+	for _, sec := range pdfBfCharRe.FindAllStringSubmatch(text, -1) {
+		hexes := pdfCMapHexRe.FindAllStringSubmatch(sec[1], -1)
+		for i := 0; i+1 < len(hexes); i += 2 {
+			src, _ := hex.DecodeString(hexes[i][1])
+			cmap[string(src)] = utf16BEHex(hexes[i+1][1])
+			codeLen = len(src)
+		}
+	}
+	for _, sec := range pdfBfRangeRe.FindAllStringSubmatch(text, -1) {
+		for _, m := range pdfBfRangeLine.FindAllStringSubmatch(sec[1], -1) {
+			lo, _ := hex.DecodeString(m[1])
+			hi, _ := hex.DecodeString(m[2])
+			if len(lo) == 0 || len(lo) != len(hi) {
+				continue
+			}
+			codeLen = len(lo)
+			start, end := pdfCodeInt(lo), pdfCodeInt(hi)
+			if end < start || end-start > 0xFFFF {
+				continue
+			}
+			var dsts []string
+			if strings.HasPrefix(m[3], "[") {
+				for _, h := range pdfCMapHexRe.FindAllStringSubmatch(m[3], -1) {
+					dsts = append(dsts, utf16BEHex(h[1]))
+				}
+			}
+			base := []rune(utf16BEHex(strings.Trim(m[3], "<>")))
+			for c := start; c <= end; c++ {
+				code := pdfIntCode(c, len(lo))
+				switch {
+				case dsts != nil:
+					if c-start < len(dsts) {
+						cmap[code] = dsts[c-start]
+					}
+				case len(base) > 0:
+					r := append([]rune{}, base...)
+					r[len(r)-1] += rune(c - start)
+					cmap[code] = string(r)
+				}
+			}
+		}
+	}
+	return cmap, codeLen
+}
+
+func pdfCodeInt(b []byte) int {
+	n := 0
+	for _, c := range b {
+		n = n<<8 | int(c)
+	}
+	return n
+}
+
+func pdfIntCode(n, width int) string {
+	b := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		b[i] = byte(n)
+		n >>= 8
+	}
+	return string(b)
+}
+
+// utf16BEHex decodes a hex UTF-16BE string.
+func utf16BEHex(h string) string {
+	b, err := hex.DecodeString(h)
+	if err != nil || len(b) < 2 {
+		return ""
+	}
+	u := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		u = append(u, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(u))
+}
+
+// decode maps shown bytes to text through the font's ToUnicode map, else Latin-1.
+func (f *pdfFontInfo) decode(s string) string {
+	if f == nil || f.cmap == nil {
+		if f != nil && f.codeLen == 2 {
+			return "" // CID font without ToUnicode: glyph IDs, not text
+		}
+		var b strings.Builder
+		for i := 0; i < len(s); i++ {
+			b.WriteRune(rune(s[i]))
+		}
+		return b.String()
+	}
+	var b strings.Builder
+	for i := 0; i+f.codeLen <= len(s); i += f.codeLen {
+		b.WriteString(f.cmap[s[i:i+f.codeLen]])
+	}
+	return b.String()
+}
+
+// pageFonts maps the font resource names of a page (inherited from parents) to fonts.
+func (doc *pdfDoc) pageFonts(page string, cache map[int]*pdfFontInfo) map[string]*pdfFontInfo {
+	res := doc.dict(page, "Resources")
+	for parent := page; res == "" && parent != ""; {
+		refs := pdfRefs(parent, "Parent")
+		if len(refs) == 0 {
+			break
+		}
+		parent = doc.objs[refs[0]]
+		res = doc.dict(parent, "Resources")
+	}
+	out := make(map[string]*pdfFontInfo)
+	for _, m := range pdfNamedRefRe.FindAllStringSubmatch(doc.dict(res, "Font"), -1) {
+		n, _ := strconv.Atoi(m[2])
+		if cache[n] == nil {
+			f := doc.font(doc.objs[n])
+			cache[n] = &f
+		}
+		out[m[1]] = cache[n]
+	}
+	return out
+}
+
+// textRuns interprets the content streams of every page.
+func (doc *pdfDoc) textRuns() (runs []pdfTextRun, pages int) {
+	cache := make(map[int]*pdfFontInfo)
+	for i, page := range doc.pages() {
+		var content []byte
+		for _, ref := range pdfRefs(page, "Contents") {
+			content = append(content, doc.streams[ref]...)
+			content = append(content, '\n')
+		}
+		runs = append(runs, interpretContent(content, doc.pageFonts(page, cache), i+1)...)
+		pages++
+	}
+	return runs, pages
+}
+
+type pdfMatrix [6]float64
+
+var pdfIdentity = pdfMatrix{1, 0, 0, 1, 0, 0}
+
+func (m pdfMatrix) mul(n pdfMatrix) pdfMatrix {
+	return pdfMatrix{
+		m[0]*n[0] + m[1]*n[2], m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2], m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4], m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+type pdfToken struct {
+	kind byte // 'n' number, '/' name, 's' string, 'o' operator, '[' and ']'
+	s    string
+	n    float64
+}
+
+// interpretContent runs the text operators of a content stream.
+func interpretContent(data []byte, fonts map[string]*pdfFontInfo, page int) []pdfTextRun {
+	var runs []pdfTextRun
+	ctm, tm, tlm := pdfIdentity, pdfIdentity, pdfIdentity
+	var stack []pdfMatrix
+	var font *pdfFontInfo
+	var size, leading float64
+	var operands []pdfToken
+	inArray := false
+	var array []pdfToken
+
+	nums := func(k int) []float64 {
+		if len(operands) < k {
+			return nil
+		}
+		out := make([]float64, k)
+		for i, t := range operands[len(operands)-k:] {
+			out[i] = t.n
+		}
+		return out
+	}
+	moveLine := func(tx, ty float64) {
+		tlm = pdfMatrix{1, 0, 0, 1, tx, ty}.mul(tlm)
+		tm = tlm
+	}
+	show := func(s string) {
+		text := font.decode(s)
+		if text == "" {
+			return
+		}
+		m := tm.mul(ctm)
+		eff := size * math.Hypot(m[2], m[3])
+		if eff == 0 {
+			eff = size
+		}
+		w := float64(len([]rune(text))) * eff * 0.5
+		runs = append(runs, pdfTextRun{page: page, x: m[4], y: m[5], size: eff, width: w, text: text})
+		tm = pdfMatrix{1, 0, 0, 1, float64(len([]rune(text))) * size * 0.5, 0}.mul(tm)
+	}
+
+	for _, t := range tokenizeContent(data) {
+		switch t.kind {
+		case '[':
+			inArray, array = true, nil
+			continue
+		case ']':
+			inArray = false
+			operands = append(operands, pdfToken{kind: 'a'})
+			continue
+		}
+		if inArray {
+			array = append(array, t)
+			continue
+		}
+		if t.kind != 'o' {
+			operands = append(operands, t)
+			continue
+		}
+		switch t.s {
+		case "q":
+			stack = append(stack, ctm)
+		case "Q":
+			if len(stack) > 0 {
+				ctm, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			}
+		case "cm":
+			if v := nums(6); v != nil {
+				ctm = pdfMatrix{v[0], v[1], v[2], v[3], v[4], v[5]}.mul(ctm)
+			}
+		case "BT":
+			tm, tlm = pdfIdentity, pdfIdentity
+		case "Tf":
+			if len(operands) >= 2 {
+				font = fonts[operands[len(operands)-2].s]
+				size = operands[len(operands)-1].n
+			}
+		case "TL":
+			if v := nums(1); v != nil {
+				leading = v[0]
+			}
+		case "Td":
+			if v := nums(2); v != nil {
+				moveLine(v[0], v[1])
+			}
+		case "TD":
+			if v := nums(2); v != nil {
+				leading = -v[1]
+				moveLine(v[0], v[1])
+			}
+		case "Tm":
+			if v := nums(6); v != nil {
+				tlm = pdfMatrix{v[0], v[1], v[2], v[3], v[4], v[5]}
+				tm = tlm
+			}
+		case "T*":
+			moveLine(0, -leading)
+		case "Tj", "'", "\"":
+			if t.s != "Tj" {
+				moveLine(0, -leading)
+			}
+			if len(operands) > 0 && operands[len(operands)-1].kind == 's' {
+				show(operands[len(operands)-1].s)
+			}
+		case "TJ":
+			var b strings.Builder
+			for _, e := range array {
+				switch {
+				case e.kind == 's':
+					b.WriteString(e.s)
+				case e.kind == 'n' && e.n < -180 && b.Len() > 0:
+					show(b.String())
+					b.Reset()
+					tm = pdfMatrix{1, 0, 0, 1, -e.n / 1000 * size, 0}.mul(tm)
+				}
+			}
+			if b.Len() > 0 {
+				show(b.String())
+			}
+		}
+		operands = operands[:0]
+	}
+	return runs
+}
+
+// tokenizeContent splits a content stream into operands and operators.
+func tokenizeContent(data []byte) []pdfToken {
+	var out []pdfToken
+	isDelim := func(c byte) bool { return strings.IndexByte("()<>[]{}/% \t\r\n\f\x00", c) >= 0 }
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0:
+			i++
+		case c == '%':
+			for i < len(data) && data[i] != '\n' && data[i] != '\r' {
+				i++
+			}
+		case c == '[' || c == ']':
+			out = append(out, pdfToken{kind: c})
+			i++
+		case c == '/':
+			j := i + 1
+			for j < len(data) && !isDelim(data[j]) {
+				j++
+			}
+			out = append(out, pdfToken{kind: '/', s: string(data[i+1 : j])})
+			i = j
+		case c == '(':
+			s, j := readLiteralString(data, i)
+			out = append(out, pdfToken{kind: 's', s: s})
+			i = j
+		case c == '<' && i+1 < len(data) && data[i+1] == '<':
+			out = append(out, pdfToken{kind: 'o', s: "<<"})
+			i += 2
+		case c == '>' && i+1 < len(data) && data[i+1] == '>':
+			out = append(out, pdfToken{kind: 'o', s: ">>"})
+			i += 2
+		case c == '<':
+			j := bytes.IndexByte(data[i:], '>')
+			if j < 0 {
+				return out
+			}
+			h := strings.Map(func(r rune) rune {
+				if strings.ContainsRune("0123456789abcdefABCDEF", r) {
+					return r
+				}
+				return -1
+			}, string(data[i+1:i+j]))
+			if len(h)%2 == 1 {
+				h += "0"
+			}
+			b, _ := hex.DecodeString(h)
+			out = append(out, pdfToken{kind: 's', s: string(b)})
+			i += j + 1
+		default:
+			j := i
+			for j < len(data) && !isDelim(data[j]) {
+				j++
+			}
+			if j == i {
+				i++
+				continue
+			}
+			word := string(data[i:j])
+			if n, err := strconv.ParseFloat(word, 64); err == nil {
+				out = append(out, pdfToken{kind: 'n', n: n})
+			} else {
+				out = append(out, pdfToken{kind: 'o', s: word})
+			}
+			if word == "BI" { // inline image: skip its data up to EI
+				if k := bytes.Index(data[j:], []byte("EI")); k >= 0 {
+					j += k + 2
+				}
+			}
+			i = j
+		}
+	}
+	return out
+}
+
+// readLiteralString reads a (...) string starting at data[i], returning its bytes and
+// the index after it.
+func readLiteralString(data []byte, i int) (string, int) {
+	var b []byte
+	depth := 0
+	for i < len(data) {
+		c := data[i]
+		switch {
+		case c == '\\' && i+1 < len(data):
+			i++
+			switch e := data[i]; e {
+			case 'n':
+				b = append(b, '\n')
+			case 'r':
+				b = append(b, '\r')
+			case 't':
+				b = append(b, '\t')
+			case 'b':
+				b = append(b, '\b')
+			case 'f':
+				b = append(b, '\f')
+			case '\r', '\n': // line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					n, k := 0, 0
+					for k < 3 && i < len(data) && data[i] >= '0' && data[i] <= '7' {
+						n = n*8 + int(data[i]-'0')
+						i++
+						k++
+					}
+					b = append(b, byte(n))
+					continue
+				}
+				b = append(b, e)
+			}
+		case c == '(':
+			if depth > 0 {
+				b = append(b, c)
+			}
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return string(b), i + 1
+			}
+			b = append(b, c)
+		default:
+			b = append(b, c)
+		}
+		i++
+	}
+	return string(b), i
+}
@@ -167,7 +167,7 @@ func ResearchPerson(ctx context.Context, name, company, jobTitle string) (*Perso
 
 	// Source 1: LinkedIn
 	wg.Add(1)
-	go func() {
+	engine.GoOutbound(ctx, func() {
 		defer wg.Done()
 		results, err := engine.SearchSearXNG(ctx, subject+" site:linkedin.com/in", "all", "", engine.DefaultSearchEngine)
 		if err != nil {
@@ -184,11 +184,11 @@ func ResearchPerson(ctx context.Context, name, company, jobTitle string) (*Perso
 			}
 		}
 		ch <- sourceData{name: "linkedin", text: strings.Join(snippets, "\n\n")}
-	}()
+	})
 
 	// Source 2: GitHub
 	wg.Add(1)
-	go func() {
+	engine.GoOutbound(ctx, func() {
 		defer wg.Done()
 		results, err := engine.SearchSearXNG(ctx, subject+" site:github.com", "all", "", engine.DefaultSearchEngine)
 		if err != nil {
@@ -205,11 +205,11 @@ func ResearchPerson(ctx context.Context, name, company, jobTitle string) (*Perso
 			}
 		}
 		ch <- sourceData{name: "github", text: strings.Join(snippets, "\n\n")}
-	}()
+	})
 
 	// Source 3: General web
 	wg.Add(1)
-	go func() {
+	engine.GoOutbound(ctx, func() {
 		defer wg.Done()
 		query := subject + " developer engineer"
 		if company != "" {
@@ -228,11 +228,11 @@ func ResearchPerson(ctx context.Context, name, company, jobTitle string) (*Perso
 			snippets = append(snippets, fmt.Sprintf("[Web] %s\n%s\n%s", r.Title, r.URL, engine.TruncateRunes(r.Content, 300, "...")))
 		}
 		ch <- sourceData{name: "web", text: strings.Join(snippets, "\n\n")}
-	}()
+	})
 
 	// Source 4: Habr (Russian tech community)
 	wg.Add(1)
-	go func() {
+	engine.GoOutbound(ctx, func() {
 		defer wg.Done()
 		results, err := engine.SearchSearXNG(ctx, name+" site:habr.com", "ru", "", engine.DefaultSearchEngine)
 		if err != nil {
@@ -247,11 +247,11 @@ func ResearchPerson(ctx context.Context, name, company, jobTitle string) (*Perso
 			snippets = append(snippets, fmt.Sprintf("[Habr] %s\n%s\n%s", r.Title, r.URL, engine.TruncateRunes(r.Content, 300, "...")))
 		}
 		ch <- sourceData{name: "habr", text: strings.Join(snippets, "\n\n")}
-	}()
+	})
 
 	// Source 5: Twitter via go-hully
 	wg.Add(1)
-	go func() {
+	engine.GoOutbound(ctx, func() {
 		defer wg.Done()
 		// First find their Twitter handle via web search
 		twResults, _ := engine.SearchSearXNG(ctx, subject+" twitter OR x.com", "all", "", engine.DefaultSearchEngine)
@@ -273,7 +273,7 @@ func ResearchPerson(ctx context.Context, name, company, jobTitle string) (*Perso
 			return
 		}
 		ch <- sourceData{name: "twitter", text: fmt.Sprintf("[Twitter @%s]\n%s", handle, engine.TruncateRunes(text, 1000, "..."))}
-	}()
+	})
 
 	go func() {
 		wg.Wait()
@@ -0,0 +1,167 @@
+package jobs
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+var (
+	relativePostedRe   = regexp.MustCompile(`(?i)(\d+|an?)\+?\s*(minute|min|hour|hr|day|week|month|year)s?\s+ago`)
+	relativePostedRuRe = regexp.MustCompile(`(\d+)\s*(минут|час|день|дн|недел|месяц|мес|год|лет)\S*\s+назад`)
+)
+
+// postedLayouts are the absolute date formats sources use: ISO, RSS pubDate,
+// Remotive's zone-less timestamps, US and European day-month forms.
+var postedLayouts = []string{
+	time.RFC3339, time.DateOnly, time.RFC1123Z, time.RFC1123, "2006-01-02T15:04:05", time.DateTime,
+	"Jan 2, 2006", "January 2, 2006", "Jan 2 2006", "2 Jan 2006", "2 January 2006", "02.01.2006",
+}
+
+// ParsePostedDate parses the free-form Posted field ("2024-05-01", "3 days ago",
+// "yesterday", "an hour ago", "Fri, 10 Oct 2026 12:00:00 +0000", "3 дня назад").
+func ParsePostedDate(posted string, now time.Time) (time.Time, bool) {
+	raw := strings.TrimSpace(posted)
+	if strings.HasPrefix(strings.ToLower(raw), "posted") {
+		raw = strings.TrimSpace(raw[len("posted"):])
+	}
+	p := strings.ToLower(raw)
+	if p == "" || p == "not specified" {
+		return time.Time{}, false
+	}
+	for _, layout := range postedLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.UTC().Truncate(24 * time.Hour), true
+		}
+	}
+	switch {
+	case strings.Contains(p, "just now"), strings.Contains(p, "today"), strings.Contains(p, "сегодня"):
+		return now.UTC().Truncate(24 * time.Hour), true
+	case strings.Contains(p, "yesterday"), strings.Contains(p, "вчера"):
+		return now.UTC().AddDate(0, 0, -1).Truncate(24 * time.Hour), true
+	}
+	var n int
+	var unit string
+	if m := relativePostedRe.FindStringSubmatch(p); m != nil {
+		n, unit = relativeCount(m[1]), m[2]
+	} else if m := relativePostedRuRe.FindStringSubmatch(p); m != nil {
+		n, unit = relativeCount(m[1]), ruPostedUnits[m[2]]
+	} else {
+		return time.Time{}, false
+	}
+	t := now.UTC()
+	switch unit {
+	case "minute", "min":
+		t = t.Add(-time.Duration(n) * time.Minute)
+	case "hour", "hr":
+		t = t.Add(-time.Duration(n) * time.Hour)
+	case "day":
+		t = t.AddDate(0, 0, -n)
+	case "week":
+		t = t.AddDate(0, 0, -7*n)
+	case "month":
+		t = t.AddDate(0, -n, 0)
+	case "year":
+		t = t.AddDate(-n, 0, 0)
+	}
+	return t.Truncate(24 * time.Hour), true
+}
+
+// ruPostedUnits maps the Russian unit stems of relativePostedRuRe to English units.
+var ruPostedUnits = map[string]string{
+	"минут": "minute", "час": "hour", "день": "day", "дн": "day", "недел": "week",
+	"месяц": "month", "мес": "month", "год": "year", "лет": "year",
+}
+
+// relativeCount reads the count of a relative date; "a"/"an" mean one.
+func relativeCount(s string) int {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	return 1
+}
+
+// NormalizePostedDates rewrites each parseable Posted value as YYYY-MM-DD, so
+// "2 days ago" does not go stale in the cache. Unparseable values are kept.
+func NormalizePostedDates(listings []engine.JobListing, now time.Time) {
+	for i := range listings {
+		if t, ok := ParsePostedDate(listings[i].Posted, now); ok {
+			listings[i].Posted = t.Format(time.DateOnly)
+		}
+	}
+}
+
+// timeRangeWindows are the job_search time_range values and how far back they reach.
+var timeRangeWindows = map[string]int{"day": 1, "week": 7, "month": 31, "year": 366}
+
+// FilterByTimeRange drops listings posted before the time_range window ("day",
+// "week", "month", "year"). Sources without a native date filter return older
+// postings; listings with no parseable date are kept.
+func FilterByTimeRange(listings []engine.JobListing, timeRange string, now time.Time) []engine.JobListing {
+	days, ok := timeRangeWindows[strings.ToLower(strings.TrimSpace(timeRange))]
+	if !ok {
+		return listings
+	}
+	cutoff := now.UTC().AddDate(0, 0, -days).Truncate(24 * time.Hour)
+	kept := listings[:0]
+	for _, j := range listings {
+		if t, ok := ParsePostedDate(j.Posted, now); ok && t.Before(cutoff) {
+			continue
+		}
+		kept = append(kept, j)
+	}
+	return kept
+}
+
+// freshnessHalfLife is the listing age at which Freshness drops to 0.5.
+const freshnessHalfLife = 14 * 24 * time.Hour
+
+// staleFreshness is the Freshness below which RankByFreshness demotes a listing
+// (older than about a month).
+const staleFreshness = 0.2
+
+// Freshness scores a Posted value from 1 (today) halving every two weeks; -1 when
+// the date is unknown.
+func Freshness(posted string, now time.Time) float64 {
+	t, ok := ParsePostedDate(posted, now)
+	if !ok {
+		return -1
+	}
+	age := now.UTC().Truncate(24 * time.Hour).Sub(t)
+	if age <= 0 {
+		return 1
+	}
+	return math.Exp2(-float64(age) / float64(freshnessHalfLife))
+}
+
+// RankByFreshness moves stale listings (older than about a month) after the rest,
+// keeping relevance order within each group, and records the freshness score in the
+// v2 scores block. Listings without a date stay in place.
+func RankByFreshness(listings []engine.JobListing, now time.Time) {
+	fresh := make([]float64, len(listings))
+	for i := range listings {
+		fresh[i] = Freshness(listings[i].Posted, now)
+		if fresh[i] >= 0 && listings[i].Scores != nil {
+			listings[i].Scores.Freshness = fresh[i]
+		}
+	}
+	idx := make([]int, len(listings))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return !isStale(fresh[idx[a]]) && isStale(fresh[idx[b]])
+	})
+	sorted := make([]engine.JobListing, len(listings))
+	for i, k := range idx {
+		sorted[i] = listings[k]
+	}
+	copy(listings, sorted)
+}
+
+func isStale(f float64) bool { return f >= 0 && f < staleFreshness }
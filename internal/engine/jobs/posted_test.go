@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestNormalizeAndFilterByTimeRange(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	listings := []engine.JobListing{
+		{Title: "new", Posted: "2 days ago"},
+		{Title: "old", Posted: "2026-08-01"},
+		{Title: "undated", Posted: "not specified"},
+	}
+	NormalizePostedDates(listings, now)
+	if listings[0].Posted != "2026-10-13" || listings[2].Posted != "not specified" {
+		t.Errorf("normalized = %q, %q", listings[0].Posted, listings[2].Posted)
+	}
+
+	week := FilterByTimeRange(append([]engine.JobListing(nil), listings...), "week", now)
+	if len(week) != 2 || week[0].Title != "new" || week[1].Title != "undated" {
+		t.Errorf("week filter kept %+v", week)
+	}
+	if all := FilterByTimeRange(append([]engine.JobListing(nil), listings...), "", now); len(all) != 3 {
+		t.Errorf("no time range should keep all, got %d", len(all))
+	}
+}
+
+func TestRankByFreshness(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	if f := Freshness("2026-10-01", now); f < 0.49 || f > 0.51 {
+		t.Errorf("two-week-old freshness = %v, want 0.5", f)
+	}
+	if f := Freshness("soon", now); f != -1 {
+		t.Errorf("unknown date freshness = %v, want -1", f)
+	}
+	listings := []engine.JobListing{
+		{Title: "stale", Posted: "2026-08-01", Scores: &engine.ListingScores{}},
+		{Title: "undated"},
+		{Title: "fresh", Posted: "2026-10-14"},
+	}
+	RankByFreshness(listings, now)
+	if listings[0].Title != "undated" || listings[1].Title != "fresh" || listings[2].Title != "stale" {
+		t.Errorf("order = %s, %s, %s", listings[0].Title, listings[1].Title, listings[2].Title)
+	}
+	if listings[2].Scores.Freshness == 0 {
+		t.Error("freshness not recorded in scores")
+	}
+}
@@ -0,0 +1,378 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Posting change monitor ---
+
+// The posting monitor re-fetches the postings of active tracked jobs (saved, applied,
+// interview) and compares them with the last version seen, starting from the snapshot
+// archived by job_tracker_add. Changes — salary added or changed, requirements added or
+// dropped, description rewritten, posting closed — are logged as posting_change events
+// on the job and, with VAELOR_NOTIFY_URL set, sent as a Telegram notification. A
+// posting that returns 404/410 or says it no longer accepts applications flags the job
+// closed_by_employer, and job_tracker_list prompts for a status update.
+
+const (
+	postingMonitorInitialDelay = 5 * time.Minute
+	// postingMinLineChanges is the number of changed description lines reported as a
+	// rewrite; single-line edits are usually view counters or dates.
+	postingMinLineChanges = 2
+	postingSampleLines    = 3
+)
+
+var (
+	postingClosedRe = regexp.MustCompile(`(?i)no longer (accepting applications|available|open)|position (has been|is) filled|(job|posting|vacancy) (has )?(expired|closed|been removed)|вакансия (закрыта|в архиве)`)
+	postingSalaryRe = regexp.MustCompile(`(?i)(?:^|\n)\*\*(?:Salary|Compensation):\*\*\s*(.+)|[$€£]\s?\d[\d,.]*\s?[kK]?(?:\s?(?:-|–|to)\s?[$€£]?\s?\d[\d,.]*\s?[kK]?)?`)
+	postingValidRe  = regexp.MustCompile(`\*\*Valid through:\*\*\s*(\d{4}-\d{2}-\d{2})`)
+	// postingVolatileRe matches lines that change on every fetch.
+	postingVolatileRe = regexp.MustCompile(`(?i)\b(ago|applicants?|views?|reposted|posted (on|today|yesterday))\b`)
+)
+
+// PostingChange is what changed in a tracked job's posting since it was last checked.
+type PostingChange struct {
+	JobID         int64    `json:"job_id"`
+	Title         string   `json:"title"`
+	Company       string   `json:"company"`
+	URL           string   `json:"url"`
+	Closed        bool     `json:"closed,omitempty"`
+	ClosedReason  string   `json:"closed_reason,omitempty"`
+	SalaryBefore  string   `json:"salary_before,omitempty"`
+	SalaryAfter   string   `json:"salary_after,omitempty"`
+	SkillsAdded   []string `json:"skills_added,omitempty"`
+	SkillsRemoved []string `json:"skills_removed,omitempty"`
+	LinesAdded    []string `json:"lines_added,omitempty"` // a sample
+	LinesRemoved  []string `json:"lines_removed,omitempty"`
+	AddedCount    int      `json:"added_count,omitempty"`
+	RemovedCount  int      `json:"removed_count,omitempty"`
+}
+
+// Empty reports whether nothing worth reporting changed.
+func (c *PostingChange) Empty() bool {
+	return !c.Closed && c.SalaryBefore == c.SalaryAfter && len(c.SkillsAdded) == 0 && len(c.SkillsRemoved) == 0 &&
+		c.AddedCount+c.RemovedCount < postingMinLineChanges
+}
+
+// Summary describes the change in a few lines.
+func (c *PostingChange) Summary() string {
+	var lines []string
+	if c.Closed {
+		lines = append(lines, "Posting closed: "+c.ClosedReason)
+	}
+	switch {
+	case c.SalaryBefore == c.SalaryAfter:
+	case c.SalaryBefore == "":
+		lines = append(lines, "Salary added: "+c.SalaryAfter)
+	case c.SalaryAfter == "":
+		lines = append(lines, "Salary removed (was "+c.SalaryBefore+")")
+	default:
+		lines = append(lines, "Salary changed: "+c.SalaryBefore+" → "+c.SalaryAfter)
+	}
+	if len(c.SkillsAdded) > 0 {
+		lines = append(lines, "Requirements added: "+strings.Join(c.SkillsAdded, ", "))
+	}
+	if len(c.SkillsRemoved) > 0 {
+		lines = append(lines, "Requirements removed: "+strings.Join(c.SkillsRemoved, ", "))
+	}
+	if c.AddedCount+c.RemovedCount >= postingMinLineChanges {
+		lines = append(lines, fmt.Sprintf("Description changed: %d lines added, %d removed", c.AddedCount, c.RemovedCount))
+		for _, l := range c.LinesAdded {
+			lines = append(lines, "+ "+engine.TruncateRunes(l, 160, "..."))
+		}
+		for _, l := range c.LinesRemoved {
+			lines = append(lines, "- "+engine.TruncateRunes(l, 160, "..."))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// StartPostingMonitor launches a background goroutine that re-checks tracked postings
+// every engine.ConfigFrom(ctx).PostingCheckInterval.
+func StartPostingMonitor(ctx context.Context) {
+	interval := engine.ConfigFrom(ctx).PostingCheckInterval
+	if interval <= 0 {
+		slog.Info("posting_monitor: disabled (POSTING_CHECK_INTERVAL is 0)")
+		return
+	}
+	slog.Info("posting_monitor: starting", slog.Duration("interval", interval))
+
+	time.AfterFunc(postingMonitorInitialDelay, func() {
+		checkTrackedPostings(ctx)
+	})
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				slog.Info("posting_monitor: stopped")
+				return
+			case <-ticker.C:
+				checkTrackedPostings(ctx)
+			}
+		}
+	}()
+}
+
+// trackedPosting is an active tracked job with a URL and its last seen posting text.
+type trackedPosting struct {
+	id                  int64
+	title, company, url string
+	last                string // empty when not archived yet
+}
+
+// checkTrackedPostings re-fetches the postings of active tracked jobs and records changes.
+func checkTrackedPostings(ctx context.Context) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return
+	}
+	postings, err := activeTrackedPostings(ctx, db)
+	if err != nil {
+		slog.Warn("posting_monitor: list tracked jobs failed", slog.Any("error", err))
+		return
+	}
+	// Fetch on the outbound pool; changes are recorded and notified in order below.
+	type checked struct {
+		change *PostingChange
+		text   string
+	}
+	results := make([]checked, len(postings))
+	var wg sync.WaitGroup
+	for i, p := range postings {
+		wg.Add(1)
+		engine.GoOutbound(ctx, func() {
+			defer wg.Done()
+			if p.last == "" {
+				if _, err := archiveJobSnapshot(ctx, db, p.id, p.url); err != nil {
+					slog.Debug("posting_monitor: archive failed", slog.Int64("job_id", p.id), slog.Any("error", err))
+				}
+				return
+			}
+			results[i].change, results[i].text = checkTrackedPosting(ctx, p)
+		})
+	}
+	wg.Wait()
+
+	changed := 0
+	for i, p := range postings {
+		change, text := results[i].change, results[i].text
+		if change == nil {
+			continue
+		}
+		if err := recordPostingChange(ctx, db, change, text, time.Now()); err != nil {
+			slog.Warn("posting_monitor: record change failed", slog.Int64("job_id", p.id), slog.Any("error", err))
+			continue
+		}
+		if change.Empty() {
+			continue
+		}
+		changed++
+		if engine.ConfigFrom(ctx).VaelorNotifyURL != "" {
+			msg := fmt.Sprintf("📝 Posting changed: %s at %s\n%s\n%s", change.Title, change.Company, change.Summary(), change.URL)
+			if change.Closed {
+				msg = fmt.Sprintf("🔒 Posting closed by the employer: %s at %s\n%s\n%s\nUpdate its status with job_tracker_update (id=%d).",
+					change.Title, change.Company, change.Summary(), change.URL, change.JobID)
+			}
+			if err := SendTelegramNotification(ctx, msg); err != nil {
+				slog.Warn("posting_monitor: notify failed", slog.Any("error", err))
+			}
+		}
+	}
+	slog.Info("posting_monitor: checked", slog.Int("postings", len(postings)), slog.Int("changed", changed))
+}
+
+func activeTrackedPostings(ctx context.Context, db *sql.DB) ([]trackedPosting, error) {
+	rows, err := db.QueryContext(ctx, `SELECT j.id, j.title, j.company, j.url, COALESCE(s.current_markdown, s.markdown, '')
+		FROM jobs j LEFT JOIN job_snapshots s ON s.job_id = j.id
+		WHERE j.url != '' AND j.status IN (?, ?, ?) AND s.closed_at IS NULL
+		ORDER BY j.id`, StatusSaved, StatusApplied, StatusInterview)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []trackedPosting
+	for rows.Next() {
+		var p trackedPosting
+		if err := rows.Scan(&p.id, &p.title, &p.company, &p.url, &p.last); err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// checkTrackedPosting re-fetches one posting. It returns the change and the fetched
+// text, the change being empty when nothing notable changed, or nil when the fetch
+// failed for a reason other than the posting being gone.
+func checkTrackedPosting(ctx context.Context, p trackedPosting) (*PostingChange, string) {
+	text, err := FetchJobPosting(engine.WithFreshJobDetails(ctx), p.url)
+	var change *PostingChange
+	switch {
+	case err == nil:
+		change = diffPostings(p.last, text, time.Now())
+	case postingGone(err):
+		change = &PostingChange{Closed: true, ClosedReason: "the posting URL returns " + err.Error()}
+	default:
+		slog.Debug("posting_monitor: fetch failed", slog.Int64("job_id", p.id), slog.Any("error", err))
+		return nil, ""
+	}
+	change.JobID, change.Title, change.Company, change.URL = p.id, p.title, p.company, p.url
+	return change, text
+}
+
+// postingGone reports whether a fetch error means the posting was taken down.
+func postingGone(err error) bool {
+	var statusErr *engine.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusGone
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "status 404") || strings.Contains(msg, "status 410")
+}
+
+// diffPostings compares two versions of a posting's text.
+func diffPostings(before, after string, now time.Time) *PostingChange {
+	c := &PostingChange{SalaryBefore: postingSalary(before), SalaryAfter: postingSalary(after)}
+	if postingClosedRe.MatchString(after) && !postingClosedRe.MatchString(before) {
+		c.Closed = true
+		c.ClosedReason = "the posting says " + strings.ToLower(postingClosedRe.FindString(after))
+	} else if m := postingValidRe.FindStringSubmatch(after); m != nil {
+		if d, err := time.Parse(time.DateOnly, m[1]); err == nil && d.AddDate(0, 0, 1).Before(now) {
+			c.Closed = true
+			c.ClosedReason = "valid through " + m[1]
+		}
+	}
+
+	oldSkills, newSkills := ExtractSkillsFromText(before), ExtractSkillsFromText(after)
+	c.SkillsAdded = missingSkills(newSkills, oldSkills)
+	c.SkillsRemoved = missingSkills(oldSkills, newSkills)
+
+	oldLines, newLines := postingLines(before), postingLines(after)
+	for _, l := range newLines.order {
+		if !oldLines.set[l] {
+			c.AddedCount++
+			if len(c.LinesAdded) < postingSampleLines {
+				c.LinesAdded = append(c.LinesAdded, newLines.text[l])
+			}
+		}
+	}
+	for _, l := range oldLines.order {
+		if !newLines.set[l] {
+			c.RemovedCount++
+			if len(c.LinesRemoved) < postingSampleLines {
+				c.LinesRemoved = append(c.LinesRemoved, oldLines.text[l])
+			}
+		}
+	}
+	return c
+}
+
+// postingSalary returns the salary stated in a posting: a Salary field, else the first
+// currency amount or range.
+func postingSalary(text string) string {
+	m := postingSalaryRe.FindStringSubmatch(text)
+	if m == nil {
+		return ""
+	}
+	if m[1] != "" {
+		return strings.TrimSpace(m[1])
+	}
+	return strings.TrimSpace(m[0])
+}
+
+// missingSkills returns the skills of a that b lacks.
+func missingSkills(a, b []string) []string {
+	var out []string
+	for _, s := range a {
+		if !containsString(b, s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// postingLineSet is the normalized non-volatile lines of a posting.
+type postingLineSet struct {
+	order []string
+	set   map[string]bool
+	text  map[string]string // normalized → original
+}
+
+func postingLines(text string) postingLineSet {
+	ls := postingLineSet{set: map[string]bool{}, text: map[string]string{}}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		norm := strings.ToLower(strings.Join(strings.Fields(strings.Trim(line, "-*#> ")), " "))
+		if len(norm) < 3 || postingVolatileRe.MatchString(norm) || ls.set[norm] {
+			continue
+		}
+		ls.set[norm] = true
+		ls.text[norm] = line
+		ls.order = append(ls.order, norm)
+	}
+	return ls
+}
+
+// recordPostingChange stores the fetched version and, when something changed, logs a
+// posting_change event on the job. A closed posting flags the job closed_by_employer.
+func recordPostingChange(ctx context.Context, db *sql.DB, c *PostingChange, text string, now time.Time) error {
+	ts := now.UTC().Format(time.RFC3339)
+	var closedAt *string
+	if c.Closed {
+		closedAt = &ts
+	}
+	if text == "" {
+		_, err := db.ExecContext(ctx, `UPDATE job_snapshots SET checked_at = ?, closed_at = ? WHERE job_id = ?`, ts, closedAt, c.JobID)
+		if err != nil {
+			return err
+		}
+	} else {
+		_, err := db.ExecContext(ctx, `UPDATE job_snapshots SET current_markdown = ?, checked_at = ?, closed_at = ? WHERE job_id = ?`,
+			text, ts, closedAt, c.JobID)
+		if err != nil {
+			return err
+		}
+	}
+	if c.Empty() {
+		return nil
+	}
+	if c.Closed {
+		_, err := db.ExecContext(ctx, `UPDATE jobs SET closed_by_employer = ? WHERE id = ? AND closed_by_employer IS NULL`, ts, c.JobID)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := db.ExecContext(ctx, `INSERT INTO job_events (job_id, kind, date, notes, created_at) VALUES (?, ?, ?, ?, ?)`,
+		c.JobID, EventPostingChange, now.Format(time.DateOnly), c.Summary(), ts)
+	return err
+}
+
+// closedStatusPrompt asks to update the status of a job whose posting the employer
+// closed while the application is still open.
+func closedStatusPrompt(j TrackedJob) string {
+	if j.ClosedByEmployer == "" {
+		return ""
+	}
+	switch j.Status {
+	case StatusSaved:
+		return "The employer closed this posting; it can no longer be applied to. Set status rejected with job_tracker_update to archive it."
+	case StatusApplied, StatusInterview:
+		return fmt.Sprintf("The employer closed this posting on %s. Update the status with job_tracker_update: still in process, or rejected if you have not heard back.",
+			strings.SplitN(j.ClosedByEmployer, "T", 2)[0])
+	}
+	return ""
+}
@@ -14,6 +14,20 @@ type UserProfile struct {
 	DefaultLimit    int    `json:"default_limit,omitempty"`
 	DefaultLocation string `json:"default_location,omitempty"`
 	DefaultRemote   string `json:"default_remote,omitempty"`
+
+	// Availability, for cover letters and immediate-start checks.
+	AvailableFrom    string `json:"available_from,omitempty"`     // earliest start date, YYYY-MM-DD
+	NoticePeriodDays int    `json:"notice_period_days,omitempty"` // notice owed to the current employer
+
+	// Compensation preferences; amounts are annual, in SalaryCurrency (default USD).
+	SalaryFloor      int    `json:"salary_floor,omitempty"`      // listings paying less are hidden
+	TargetComp       int    `json:"target_comp,omitempty"`       // listings paying less are marked below_target
+	SalaryCurrency   string `json:"salary_currency,omitempty"`   // e.g. "USD", "EUR"
+	EquityPreference string `json:"equity_preference,omitempty"` // "required" hides listings not mentioning equity; "preferred", "none"
+
+	// Working day, for time-zone overlap with remote teams.
+	Timezone  string `json:"timezone,omitempty"`   // IANA name ("Europe/Berlin") or UTC offset ("UTC+3")
+	WorkHours string `json:"work_hours,omitempty"` // local working hours, default "09:00-17:00"
 }
 
 var (
@@ -39,7 +53,7 @@ func LoadProfile() *UserProfile {
 	return cachedProfile
 }
 
-// SaveProfile writes user profile to ~/.go_job/profile.json.
+// SaveProfile writes user profile to ~/.go_job/profile.json and replaces the cached one.
 func SaveProfile(p *UserProfile) error {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -53,5 +67,10 @@ func SaveProfile(p *UserProfile) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filepath.Join(dir, "profile.json"), data, 0o600)
+	if err := os.WriteFile(filepath.Join(dir, "profile.json"), data, 0o600); err != nil {
+		return err
+	}
+	profileOnce.Do(func() {})
+	cachedProfile = p
+	return nil
 }
@@ -0,0 +1,300 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Freelance Proposal Generation ---
+
+// Proposal limits per platform: Upwork cover letters allow 5000 characters (clients see
+// the first two lines in the list), Freelancer.com bids 1500.
+var proposalMaxChars = map[string]int{
+	"upwork":     5000,
+	"freelancer": 1500,
+}
+
+// proposalWords is the target word range per length setting.
+var proposalWords = map[string]string{
+	"short":  "80-120",
+	"medium": "150-220",
+	"long":   "250-350",
+}
+
+const (
+	proposalMaxProjects     = 4
+	proposalMaxAchievements = 5
+)
+
+// ProposalResult is the structured output of proposal_generate.
+type ProposalResult struct {
+	Proposal       string          `json:"proposal"`
+	OpeningLine    string          `json:"opening_line"` // what the client sees in the proposal list
+	Rate           *RateSuggestion `json:"rate_suggestion,omitempty"`
+	Questions      []string        `json:"questions,omitempty"` // clarifying questions to ask the client
+	PortfolioLinks []string        `json:"portfolio_links,omitempty"`
+	UsedProjects   []string        `json:"used_projects"`
+	Platform       string          `json:"platform"`
+	Chars          int             `json:"chars"`
+}
+
+// RateSuggestion is the suggested bid for a project.
+type RateSuggestion struct {
+	Amount    float64 `json:"amount"`
+	Unit      string  `json:"unit"` // "hour" or "fixed"
+	Currency  string  `json:"currency"`
+	Rationale string  `json:"rationale"`
+}
+
+const proposalPrompt = `You are an expert freelancer who writes winning %s proposals.
+
+Write a proposal for the project below using ONLY the candidate's real projects and achievements. Every claim must come from the candidate data.
+
+PROJECT:
+%s
+
+CANDIDATE PROJECTS AND ACHIEVEMENTS:
+%s
+%s
+Requirements:
+- Length: %s words, at most %d characters.
+- Open with a line that shows you understood the client's problem — no "Dear Sir/Madam", no "I am writing to apply". The opening line is all the client sees before expanding.
+- Connect 1-2 of the most relevant candidate projects or achievements to the client's needs, with concrete results.
+- Outline a short plan for the first steps of the work.
+- End with a clear call to action.
+- Reference portfolio links naturally where they support a claim; never invent links.
+- Plain text, no markdown headings.
+
+RATE:
+%s
+
+Return a JSON object with this exact structure:
+{
+  "proposal": "<full proposal text>",
+  "opening_line": "<first sentence of the proposal>",
+  "rate_suggestion": {"amount": <number>, "unit": "hour|fixed", "currency": "<ISO code>", "rationale": "<one sentence>"},
+  "questions": ["<clarifying question for the client>"],
+  "used_projects": ["<names of candidate projects used>"]
+}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+// GenerateProposal writes a freelance proposal for a project description from the
+// master resume's most relevant projects and achievements.
+func GenerateProposal(ctx context.Context, input engine.ProposalGenerateInput, project string) (*ProposalResult, error) {
+	platform := strings.ToLower(input.Platform)
+	if platform == "" {
+		platform = "upwork"
+	}
+	maxChars, ok := proposalMaxChars[platform]
+	if !ok {
+		return nil, fmt.Errorf("proposal_generate: unknown platform %q (valid: upwork, freelancer)", input.Platform)
+	}
+	length := strings.ToLower(input.Length)
+	if length == "" {
+		length = "medium"
+	}
+	words, ok := proposalWords[length]
+	if !ok {
+		return nil, fmt.Errorf("proposal_generate: unknown length %q (valid: short, medium, long)", input.Length)
+	}
+
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return nil, errors.New("resume database not configured (set DATABASE_URL)")
+	}
+	personID := db.GetLatestPersonID(ctx)
+	if personID == 0 {
+		return nil, errors.New("no master resume found — run master_resume_build first")
+	}
+
+	project = engine.TruncateRunes(project, 4000, "")
+	projects, achievements := proposalEvidence(ctx, db, personID, project)
+	if len(projects) == 0 && len(achievements) == 0 {
+		return nil, errors.New("master resume has no projects or achievements to reference")
+	}
+
+	links := proposalLinks(input.PortfolioLinks, projects)
+	var linkText string
+	if len(links) > 0 {
+		linkText = "\nPORTFOLIO LINKS:\n" + strings.Join(links, "\n") + "\n"
+	}
+
+	prompt := fmt.Sprintf(proposalPrompt, proposalPlatformName(platform), project,
+		formatProposalEvidence(projects, achievements), linkText, words, maxChars, proposalRateGuidance(input))
+	raw, err := engine.CallLLM(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("proposal_generate LLM: %w", err)
+	}
+	raw = StripMarkdownFences(raw)
+
+	var result ProposalResult
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		return nil, fmt.Errorf("proposal_generate parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+	}
+	if r := []rune(result.Proposal); len(r) > maxChars {
+		slog.Debug("proposal_generate: trimming proposal to platform limit", slog.Int("chars", len(r)), slog.Int("max", maxChars))
+		result.Proposal = string(r[:maxChars])
+	}
+	if result.Rate != nil && result.Rate.Amount <= 0 {
+		result.Rate = nil
+	}
+	if result.UsedProjects == nil {
+		result.UsedProjects = []string{}
+	}
+	result.PortfolioLinks = links
+	result.Platform = platform
+	result.Chars = len([]rune(result.Proposal))
+	return &result, nil
+}
+
+// proposalEvidence picks the projects and achievements most relevant to a project
+// description: MemDB semantic matches when available, else tech overlap.
+func proposalEvidence(ctx context.Context, db *ResumeDB, personID int, project string) ([]ProjectRecord, []AchievementRecord) {
+	var projIDs, achvIDs []int
+	if mdb := StoresFrom(ctx).MemDB; mdb != nil {
+		results, err := mdb.Search(ctx, project, 15, 0.5)
+		if err != nil {
+			slog.Debug("proposal_generate: memdb search failed", slog.Any("error", err))
+		}
+		for _, r := range results {
+			itemType, _ := r.Info["type"].(string)
+			itemID, _ := r.Info["id"].(float64)
+			switch {
+			case itemID == 0:
+			case itemType == "project":
+				projIDs = append(projIDs, int(itemID))
+			case itemType == "achievement":
+				achvIDs = append(achvIDs, int(itemID))
+			}
+		}
+	}
+
+	var projects []ProjectRecord
+	var achievements []AchievementRecord
+	if len(projIDs) > 0 {
+		projects, _ = db.GetProjectsByIDs(ctx, projIDs)
+	}
+	if len(projects) == 0 {
+		projects, _ = db.GetAllProjects(ctx, personID)
+		rankProjectsByText(projects, project)
+	}
+	if len(achvIDs) > 0 {
+		achievements, _ = db.GetAchievementsByIDs(ctx, achvIDs)
+	}
+	if len(achievements) == 0 {
+		achievements, _ = db.GetAllAchievements(ctx, personID)
+	}
+	if len(projects) > proposalMaxProjects {
+		projects = projects[:proposalMaxProjects]
+	}
+	if len(achievements) > proposalMaxAchievements {
+		achievements = achievements[:proposalMaxAchievements]
+	}
+	return projects, achievements
+}
+
+// rankProjectsByText orders projects by how many of their technologies the text
+// mentions as whole words, keeping the original order among ties.
+func rankProjectsByText(projects []ProjectRecord, text string) {
+	lower := strings.ToLower(text)
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(lower, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("+#.", r)
+	}) {
+		words[strings.TrimRight(w, ".")] = true
+	}
+	score := func(p ProjectRecord) int {
+		n := 0
+		for _, t := range p.Tech {
+			t = strings.ToLower(t)
+			if words[t] || (strings.Contains(t, " ") && strings.Contains(lower, t)) {
+				n++
+			}
+		}
+		return n
+	}
+	sort.SliceStable(projects, func(i, j int) bool { return score(projects[i]) > score(projects[j]) })
+}
+
+// proposalLinks merges the given portfolio links with project URLs, without duplicates.
+func proposalLinks(given []string, projects []ProjectRecord) []string {
+	seen := make(map[string]bool)
+	var links []string
+	add := func(u string) {
+		u = strings.TrimSpace(u)
+		if u != "" && !seen[u] {
+			seen[u] = true
+			links = append(links, u)
+		}
+	}
+	for _, u := range given {
+		add(u)
+	}
+	for _, p := range projects {
+		add(p.URL)
+	}
+	return links
+}
+
+func formatProposalEvidence(projects []ProjectRecord, achievements []AchievementRecord) string {
+	var b strings.Builder
+	for _, p := range projects {
+		fmt.Fprintf(&b, "• Project: %s", p.Name)
+		if p.URL != "" {
+			fmt.Fprintf(&b, " (%s)", p.URL)
+		}
+		b.WriteString("\n")
+		if p.Description != "" {
+			fmt.Fprintf(&b, "  %s\n", p.Description)
+		}
+		if len(p.Tech) > 0 {
+			fmt.Fprintf(&b, "  Tech: %s\n", strings.Join(p.Tech, ", "))
+		}
+		for _, h := range p.Highlights {
+			fmt.Fprintf(&b, "  - %s\n", h)
+		}
+	}
+	for _, a := range achievements {
+		fmt.Fprintf(&b, "• Achievement: %s", a.Text)
+		if a.Context != "" {
+			fmt.Fprintf(&b, " (%s)", a.Context)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// proposalRateGuidance tells the LLM how to price the bid: the user's own rate when
+// given, else the hourly rate equivalent to the profile's target compensation.
+func proposalRateGuidance(input engine.ProposalGenerateInput) string {
+	if input.Rate > 0 {
+		currency := strings.ToUpper(input.Currency)
+		if currency == "" {
+			currency = "USD"
+		}
+		return fmt.Sprintf("The freelancer's usual rate is %.0f %s/hour. Suggest a bid (hourly, or fixed if the project is fixed-price) based on this rate, the project's budget and scope.", input.Rate, currency)
+	}
+	p := LoadProfile()
+	if target := max(p.TargetComp, p.SalaryFloor); target > 0 {
+		if eq, err := CompareIncome(engine.IncomeCompareInput{Amount: float64(target), Type: "salary", Currency: p.compCurrency()}); err == nil {
+			return fmt.Sprintf("Matching the freelancer's salary target needs about %.0f %s/hour. Suggest a bid (hourly, or fixed if the project is fixed-price) based on this rate, the project's budget and scope.", eq.Equivalent.Hourly, eq.Equivalent.Currency)
+		}
+	}
+	return "No rate given. Suggest a bid that fits the project's stated budget and the candidate's seniority; say in the rationale that it is an estimate."
+}
+
+func proposalPlatformName(platform string) string {
+	if platform == "freelancer" {
+		return "Freelancer.com"
+	}
+	return "Upwork"
+}
@@ -0,0 +1,248 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// RedFlag is a single warning sign found in a job description.
+type RedFlag struct {
+	Category    string `json:"category"`
+	Severity    string `json:"severity"` // "low", "medium", "high"
+	Evidence    string `json:"evidence"` // verbatim quote from the JD
+	Explanation string `json:"explanation"`
+	Source      string `json:"source"` // "rule", "llm" or "news"
+}
+
+// JDRedFlagsResult is the structured output of jd_red_flags.
+type JDRedFlagsResult struct {
+	RiskScore int       `json:"risk_score"` // 0-100
+	RiskLevel string    `json:"risk_level"` // "low", "medium", "high"
+	Flags     []RedFlag `json:"flags"`
+	Summary   string    `json:"summary"`
+}
+
+// textRule is a regex heuristic that maps a phrase to a flag category.
+type textRule struct {
+	category    string
+	severity    string
+	explanation string
+	re          *regexp.Regexp
+}
+
+var jdRedFlagRules = []textRule{
+	{"unpaid_work", "high", "Unpaid trial work or test projects are a common way to extract free labor.",
+		regexp.MustCompile(`(?i)\b(unpaid (trial|test|internship|project)|trial (period|project|task) (is )?(unpaid|without pay)|free (trial|test) (project|task|work))\b`)},
+	{"crunch_culture", "medium", "Phrases that normalize long hours or burnout.",
+		regexp.MustCompile(`(?i)\b(work hard,? play hard|fast[- ]paced,? high[- ]pressure|willing to work (long hours|weekends|nights)|(available|on call) 24/7|hustle|grind|whatever it takes|no 9[- ]to[- ]5)\b`)},
+	{"family_culture", "low", "\"We're a family\" often precedes blurred work/life boundaries.",
+		regexp.MustCompile(`(?i)\b(we('| a)re (like )?a family|work family)\b`)},
+	{"vague_equity", "medium", "Equity is promised without concrete terms (percentage, vesting, strike price).",
+		regexp.MustCompile(`(?i)\b(equity (potential|opportunity|upside|to be discussed)|potential equity|generous equity|equity instead of (salary|pay)|sweat equity|significant equity)\b`)},
+	{"vague_compensation", "low", "Compensation is not stated.",
+		regexp.MustCompile(`(?i)\b(competitive (salary|pay|compensation)|salary (is )?(DOE|negotiable)|depending on experience)\b`)},
+	{"rockstar_language", "low", "Hyperbolic titles often signal unrealistic expectations.",
+		regexp.MustCompile(`(?i)\b(rock ?star|ninja|guru|10x (engineer|developer))\b`)},
+	{"commission_only", "high", "Pay depends entirely on commission.",
+		regexp.MustCompile(`(?i)\b(commission[- ]only|100% commission|uncapped commission,? no base)\b`)},
+}
+
+var (
+	manyHatsRe = regexp.MustCompile(`(?i)\b(wear (many|multiple|lots of) hats|jack of all trades|do (it all|everything)|full ownership of everything)\b`)
+	payBandRe  = regexp.MustCompile(`(?i)\$\s?(\d{2,3})(?:,(\d{3})|(k))`)
+)
+
+// lowPayThreshold is the annual USD ceiling under which a "many hats" role is flagged.
+const lowPayThreshold = 70000
+
+var severityWeight = map[string]int{"low": 8, "medium": 18, "high": 35}
+
+// DetectRedFlagsHeuristic runs the rule set over a JD and returns matching flags with evidence quotes.
+func DetectRedFlagsHeuristic(jd string) []RedFlag {
+	var flags []RedFlag
+	for _, rule := range jdRedFlagRules {
+		if loc := rule.re.FindStringIndex(jd); loc != nil {
+			flags = append(flags, RedFlag{
+				Category:    rule.category,
+				Severity:    rule.severity,
+				Evidence:    quoteAround(jd, loc[0], loc[1]),
+				Explanation: rule.explanation,
+				Source:      "rule",
+			})
+		}
+	}
+
+	// "Wear many hats" is only a red flag when paired with a low pay band.
+	if loc := manyHatsRe.FindStringIndex(jd); loc != nil {
+		if maxPay := maxAnnualPay(jd); maxPay > 0 && maxPay < lowPayThreshold {
+			flags = append(flags, RedFlag{
+				Category:    "scope_vs_pay",
+				Severity:    "high",
+				Evidence:    quoteAround(jd, loc[0], loc[1]),
+				Explanation: fmt.Sprintf("Broad multi-role scope with a low pay band (max ~$%dk).", maxPay/1000),
+				Source:      "rule",
+			})
+		}
+	}
+	return flags
+}
+
+// maxAnnualPay returns the largest dollar amount in text, in USD (e.g. "$55k" → 55000). 0 if none.
+func maxAnnualPay(text string) int {
+	var best int
+	for _, m := range payBandRe.FindAllStringSubmatch(text, -1) {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		n *= 1000
+		if m[2] != "" {
+			rest, _ := strconv.Atoi(m[2])
+			n += rest
+		}
+		if n > best {
+			best = n
+		}
+	}
+	return best
+}
+
+// quoteAround returns the sentence containing text[start:end], trimmed to a readable length.
+func quoteAround(text string, start, end int) string {
+	from := strings.LastIndexAny(text[:start], ".!?\n")
+	from++
+	to := strings.IndexAny(text[end:], ".!?\n")
+	if to < 0 {
+		to = len(text)
+	} else {
+		to += end + 1
+	}
+	return engine.TruncateRunes(strings.TrimSpace(text[from:to]), 240, "...")
+}
+
+const jdRedFlagsPrompt = `You are an experienced recruiter who helps candidates spot problematic job postings.
+
+Analyze the job description below for warning signs, such as:
+- unpaid trial projects or take-home work that looks like real production work
+- "wear many hats" / several roles in one combined with low or missing pay
+- crunch culture (long hours, always-on, "hustle")
+- vague or unrealistic equity promises instead of salary
+- contradictory requirements (e.g. "entry level" + "5+ years")
+- excessive responsibilities for the stated seniority
+- high turnover hints ("immediate start", "urgent", constant reposting language)
+
+JOB DESCRIPTION:
+%s
+
+Rules:
+- "evidence" MUST be a verbatim quote copied from the job description
+- Only report real issues — an empty list is a valid answer
+- severity: "low", "medium", or "high"
+
+Return a JSON object with this exact structure:
+{
+  "flags": [
+    {"category": "<snake_case category>", "severity": "<low|medium|high>", "evidence": "<verbatim quote>", "explanation": "<why this is a concern>"}
+  ],
+  "summary": "<2-3 sentences: overall assessment for the candidate>"
+}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+// AnalyzeJDRedFlags combines heuristic rules with an LLM pass and returns a risk score with evidence.
+// When company is set, recent layoffs and lawsuits in its news are flagged too.
+// If the LLM call fails the heuristic result is still returned.
+func AnalyzeJDRedFlags(ctx context.Context, jd, company string) (*JDRedFlagsResult, error) {
+	flags := DetectRedFlagsHeuristic(jd)
+
+	var summary string
+	prompt := fmt.Sprintf(jdRedFlagsPrompt, engine.TruncateRunes(jd, 6000, ""))
+	raw, err := engine.CallLLM(ctx, prompt)
+	if err != nil {
+		slog.Warn("jd_red_flags: LLM pass failed, using heuristics only", slog.Any("error", err))
+	} else {
+		var llm struct {
+			Flags   []RedFlag `json:"flags"`
+			Summary string    `json:"summary"`
+		}
+		if err := json.Unmarshal([]byte(StripMarkdownFences(raw)), &llm); err != nil {
+			slog.Warn("jd_red_flags: LLM parse failed", slog.Any("error", err))
+		} else {
+			summary = llm.Summary
+			flags = mergeRedFlags(flags, llm.Flags, jd)
+		}
+	}
+	if company != "" {
+		if news, err := CompanyNews(ctx, company); err != nil {
+			slog.Debug("jd_red_flags: company news failed", slog.Any("error", err))
+		} else {
+			flags = append(flags, newsRedFlags(news, time.Now())...)
+		}
+	}
+
+	result := &JDRedFlagsResult{Flags: flags, Summary: summary}
+	result.RiskScore, result.RiskLevel = scoreRedFlags(flags)
+	if result.Flags == nil {
+		result.Flags = []RedFlag{}
+	}
+	if result.Summary == "" {
+		result.Summary = fmt.Sprintf("%d red flag(s) found, risk %s.", len(flags), result.RiskLevel)
+	}
+	return result, nil
+}
+
+// mergeRedFlags appends LLM flags whose evidence actually appears in the JD,
+// skipping categories already covered by a rule.
+func mergeRedFlags(rules, llm []RedFlag, jd string) []RedFlag {
+	seen := make(map[string]bool, len(rules))
+	for _, f := range rules {
+		seen[f.Category] = true
+	}
+	lowerJD := strings.ToLower(jd)
+	for _, f := range llm {
+		ev := strings.Trim(strings.TrimSpace(f.Evidence), `"'…`)
+		if ev == "" || !strings.Contains(lowerJD, strings.ToLower(ev)) {
+			continue // ungrounded — drop hallucinated quotes
+		}
+		if seen[f.Category] {
+			continue
+		}
+		if _, ok := severityWeight[f.Severity]; !ok {
+			f.Severity = "low"
+		}
+		f.Evidence = ev
+		f.Source = "llm"
+		seen[f.Category] = true
+		rules = append(rules, f)
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return severityWeight[rules[i].Severity] > severityWeight[rules[j].Severity]
+	})
+	return rules
+}
+
+// scoreRedFlags converts flags to a 0-100 risk score and level.
+func scoreRedFlags(flags []RedFlag) (int, string) {
+	score := 0
+	for _, f := range flags {
+		score += severityWeight[f.Severity]
+	}
+	score = min(score, 100)
+	switch {
+	case score >= 50:
+		return score, "high"
+	case score >= 20:
+		return score, "medium"
+	default:
+		return score, "low"
+	}
+}
@@ -0,0 +1,53 @@
+package jobs
+
+import "testing"
+
+func TestDetectRedFlagsHeuristic(t *testing.T) {
+	jd := "We're a family here. Candidates complete an unpaid trial project before the offer. " +
+		"You'll wear many hats across product and ops. Salary: $45k-$55k plus potential equity."
+
+	got := make(map[string]RedFlag)
+	for _, f := range DetectRedFlagsHeuristic(jd) {
+		got[f.Category] = f
+	}
+	for _, want := range []string{"unpaid_work", "family_culture", "vague_equity", "scope_vs_pay"} {
+		if _, ok := got[want]; !ok {
+			t.Errorf("missing flag %q (got %v)", want, got)
+		}
+	}
+	if ev := got["unpaid_work"].Evidence; ev != "Candidates complete an unpaid trial project before the offer." {
+		t.Errorf("unpaid_work evidence = %q", ev)
+	}
+
+	// Many hats with a healthy pay band is not flagged.
+	for _, f := range DetectRedFlagsHeuristic("You'll wear many hats. Base $150,000-$180,000.") {
+		if f.Category == "scope_vs_pay" {
+			t.Error("scope_vs_pay flagged despite high pay band")
+		}
+	}
+}
+
+func TestMergeRedFlagsDropsUngroundedEvidence(t *testing.T) {
+	jd := "Must be available 24/7. Entry level role, 5+ years required."
+	llm := []RedFlag{
+		{Category: "contradictory_requirements", Severity: "medium", Evidence: "Entry level role, 5+ years required"},
+		{Category: "made_up", Severity: "high", Evidence: "this text is not in the posting"},
+	}
+	flags := mergeRedFlags(DetectRedFlagsHeuristic(jd), llm, jd)
+
+	cats := make(map[string]bool)
+	for _, f := range flags {
+		cats[f.Category] = true
+	}
+	if !cats["crunch_culture"] || !cats["contradictory_requirements"] {
+		t.Errorf("expected rule and grounded LLM flags, got %+v", flags)
+	}
+	if cats["made_up"] {
+		t.Error("ungrounded LLM flag was not dropped")
+	}
+
+	score, level := scoreRedFlags(flags)
+	if score != 36 || level != "medium" {
+		t.Errorf("score=%d level=%s, want 36 medium", score, level)
+	}
+}
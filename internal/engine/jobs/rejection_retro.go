@@ -0,0 +1,297 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Rejection retrospective ---
+
+// A retro compares the resume version sent for a rejected application against the
+// JD: keyword gaps, seniority mismatch, and an LLM read of the likely reasons. Its
+// learnings are stored in the tracker (rejection_retros) so weekly_review can show
+// gaps that recur across rejections.
+
+// RejectionRetroInput is the input for rejection_retro.
+type RejectionRetroInput struct {
+	ID             int64  `json:"id" jsonschema:"Tracker ID of the rejected job (from job_tracker_list)"`
+	Resume         string `json:"resume,omitempty" jsonschema:"The resume version you sent; default: the master resume"`
+	JobDescription string `json:"job_description,omitempty" jsonschema:"Job description; default: fetched from the tracked job URL"`
+}
+
+// RejectionRetroResult is the structured output of rejection_retro.
+type RejectionRetroResult struct {
+	ID                int64    `json:"id"`
+	Title             string   `json:"title"`
+	Company           string   `json:"company"`
+	MatchScore        float64  `json:"match_score"` // keyword overlap 0-100
+	MissingKeywords   []string `json:"missing_keywords"`
+	JDSeniority       string   `json:"jd_seniority,omitempty"`
+	ResumeSeniority   string   `json:"resume_seniority,omitempty"`
+	SeniorityMismatch string   `json:"seniority_mismatch,omitempty"` // "under" (JD asks for more) or "over"
+	LikelyGaps        []string `json:"likely_gaps"`
+	Learnings         []string `json:"learnings"` // stored for weekly_review
+	Summary           string   `json:"summary"`
+}
+
+// seniorityLevels ranks seniority words, lowest first.
+var seniorityLevels = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"intern", regexp.MustCompile(`(?i)\b(intern(ship)?|trainee)\b`)},
+	{"junior", regexp.MustCompile(`(?i)\b(junior|jr|entry[- ]level|graduate)\b`)},
+	{"mid", regexp.MustCompile(`(?i)\b(mid[- ]level|middle|intermediate)\b`)},
+	{"senior", regexp.MustCompile(`(?i)\b(senior|sr)\b`)},
+	{"staff", regexp.MustCompile(`(?i)\b(staff|tech(nical)? lead|team lead|lead (engineer|developer))\b`)},
+	{"principal", regexp.MustCompile(`(?i)\b(principal|distinguished|director|head of|vp)\b`)},
+}
+
+// seniorityOf returns the seniority rank (index in seniorityLevels) of the earliest
+// seniority word in text, or -1 when there is none.
+func seniorityOf(text string) int {
+	rank, pos := -1, len(text)
+	for i, l := range seniorityLevels {
+		if loc := l.re.FindStringIndex(text); loc != nil && loc[0] < pos {
+			rank, pos = i, loc[0]
+		}
+	}
+	return rank
+}
+
+// seniorityMismatch compares the JD's seniority (its title first) with the resume's
+// (its most recent title, which comes first). "under" means the JD asks for at least
+// one level more, "over" at least two levels less.
+func seniorityMismatch(title, jd, resume string) (jdLevel, resumeLevel, mismatch string) {
+	jdRank := seniorityOf(title)
+	if jdRank < 0 {
+		jdRank = seniorityOf(jd)
+	}
+	resumeRank := seniorityOf(resume)
+	if jdRank >= 0 {
+		jdLevel = seniorityLevels[jdRank].name
+	}
+	if resumeRank >= 0 {
+		resumeLevel = seniorityLevels[resumeRank].name
+	}
+	switch {
+	case jdRank < 0 || resumeRank < 0:
+	case jdRank-resumeRank >= 1:
+		mismatch = "under"
+	case resumeRank-jdRank >= 2:
+		mismatch = "over"
+	}
+	return jdLevel, resumeLevel, mismatch
+}
+
+const rejectionRetroPrompt = `You are a career coach reviewing a rejected job application.
+
+Role: %s at %s
+Keyword overlap between resume and JD: %.0f/100
+JD keywords absent from the resume: %s
+Seniority: JD %s, resume %s%s
+
+RESUME SENT:
+%s
+
+JOB DESCRIPTION:
+%s
+
+Identify the most likely reasons the resume was screened out, based only on the resume and JD.
+
+Return a JSON object with this exact structure:
+{
+  "missing_keywords": [<from the absent keywords above, only real requirements of the JD — skills, tools, domains; drop generic words>],
+  "likely_gaps": [<2-5 concrete gaps: missing must-haves, seniority or scope mismatch, weak evidence>],
+  "learnings": [<2-4 short, reusable lessons for future applications, e.g. "Lead with Kubernetes experience for platform roles">],
+  "summary": "<2 sentences>"
+}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+// RejectionRetro analyzes a rejected tracked job and stores its learnings.
+func RejectionRetro(ctx context.Context, input RejectionRetroInput) (*RejectionRetroResult, error) {
+	if input.ID <= 0 {
+		return nil, errors.New("rejection_retro: id is required")
+	}
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	job, err := getTrackedJob(db, "rejection_retro", input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != StatusRejected {
+		return nil, fmt.Errorf("rejection_retro: job #%d is %s, not rejected", job.ID, job.Status)
+	}
+
+	resume := input.Resume
+	if resume == "" {
+		feed, err := BuildResumeFeed(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("rejection_retro: no resume given and master resume unavailable: %w", err)
+		}
+		resume = ResumeFeedText(feed)
+	}
+	jd := input.JobDescription
+	if jd == "" {
+		if job.URL == "" {
+			return nil, errors.New("rejection_retro: job has no URL — pass job_description")
+		}
+		if jd, err = FetchJobPosting(ctx, job.URL); err != nil {
+			return nil, fmt.Errorf("rejection_retro: fetch job description: %w", err)
+		}
+	}
+
+	result := &RejectionRetroResult{ID: job.ID, Title: job.Title, Company: job.Company}
+	var missing []string
+	result.MatchScore, _, missing = ScoreJobMatch(ExtractResumeKeywords(resume), jd)
+	result.JDSeniority, result.ResumeSeniority, result.SeniorityMismatch = seniorityMismatch(job.Title, jd, resume)
+
+	mismatchNote := ""
+	if result.SeniorityMismatch != "" {
+		mismatchNote = " (mismatch: resume reads " + result.SeniorityMismatch + "-leveled for this role)"
+	}
+	prompt := fmt.Sprintf(rejectionRetroPrompt, job.Title, job.Company, result.MatchScore, strings.Join(missing, ", "),
+		orUnknown(result.JDSeniority), orUnknown(result.ResumeSeniority), mismatchNote,
+		engine.TruncateRunes(resume, 3000, ""), engine.TruncateRunes(jd, 3000, ""))
+	raw, err := engine.CallLLM(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("rejection_retro LLM: %w", err)
+	}
+	if err := json.Unmarshal([]byte(StripMarkdownFences(raw)), result); err != nil {
+		return nil, fmt.Errorf("rejection_retro parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+	}
+	result.MissingKeywords = normalizeKeywords(result.MissingKeywords)
+
+	if err := saveRejectionRetro(db, result, time.Now()); err != nil {
+		return nil, fmt.Errorf("rejection_retro: save: %w", err)
+	}
+	return result, nil
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// normalizeKeywords lowercases and dedupes keywords.
+func normalizeKeywords(kws []string) []string {
+	out := []string{}
+	for _, k := range kws {
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k != "" && !containsString(out, k) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// initRejectionRetrosSchema creates the rejection_retros table in the tracker database.
+func initRejectionRetrosSchema(db *sql.DB) error {
+	schema := `CREATE TABLE IF NOT EXISTS rejection_retros (
+		job_id             INTEGER PRIMARY KEY REFERENCES jobs(id) ON DELETE CASCADE,
+		missing_keywords   TEXT NOT NULL,
+		seniority_mismatch TEXT,
+		learnings          TEXT NOT NULL,
+		created_at         TEXT NOT NULL
+	)`
+	_, err := db.Exec(schema) //nolint:noctx // schema init, no user context available
+	return err
+}
+
+// saveRejectionRetro stores the retro's learnings, replacing an earlier retro of the job.
+func saveRejectionRetro(db *sql.DB, r *RejectionRetroResult, now time.Time) error {
+	missing, _ := json.Marshal(r.MissingKeywords)
+	learnings, _ := json.Marshal(r.Learnings)
+	_, err := db.Exec( //nolint:noctx // SQLite file-based tracker
+		`INSERT OR REPLACE INTO rejection_retros (job_id, missing_keywords, seniority_mismatch, learnings, created_at)
+		 VALUES (?, ?, ?, ?, ?)`,
+		r.ID, string(missing), r.SeniorityMismatch, string(learnings), now.UTC().Format(time.RFC3339))
+	return err
+}
+
+// ReviewRejections aggregates the stored rejection retros for weekly_review.
+type ReviewRejections struct {
+	Retros              int                  `json:"retros"`
+	RecurringGaps       []ReviewRejectionGap `json:"recurring_gaps,omitempty"`
+	SeniorityMismatches map[string]int       `json:"seniority_mismatches,omitempty"` // "under"/"over" → count
+	RecentLearnings     []string             `json:"recent_learnings,omitempty"`
+
+	jobIDs map[int64]bool // jobs with a retro
+}
+
+// ReviewRejectionGap is a keyword missing from the resume in several rejections.
+type ReviewRejectionGap struct {
+	Keyword    string `json:"keyword"`
+	Rejections int    `json:"rejections"`
+}
+
+// reviewRejections reads all retros: keywords missing in two or more rejections, the
+// seniority mismatch counts and the learnings of the five latest retros. Nil when no
+// retro was run.
+func reviewRejections(db *sql.DB) (*ReviewRejections, error) {
+	rows, err := db.Query( //nolint:noctx // SQLite file-based tracker
+		`SELECT job_id, missing_keywords, seniority_mismatch, learnings FROM rejection_retros ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("weekly_review: rejections: %w", err)
+	}
+	defer rows.Close()
+	r := &ReviewRejections{jobIDs: make(map[int64]bool)}
+	counts := make(map[string]int)
+	for rows.Next() {
+		var jobID int64
+		var missingRaw, learningsRaw string
+		var mismatch sql.NullString
+		if err := rows.Scan(&jobID, &missingRaw, &mismatch, &learningsRaw); err != nil {
+			return nil, fmt.Errorf("weekly_review: rejections scan: %w", err)
+		}
+		r.Retros++
+		r.jobIDs[jobID] = true
+		var missing, learnings []string
+		_ = json.Unmarshal([]byte(missingRaw), &missing)
+		_ = json.Unmarshal([]byte(learningsRaw), &learnings)
+		for _, k := range missing {
+			counts[k]++
+		}
+		if mismatch.String != "" {
+			if r.SeniorityMismatches == nil {
+				r.SeniorityMismatches = make(map[string]int)
+			}
+			r.SeniorityMismatches[mismatch.String]++
+		}
+		if r.Retros <= 5 {
+			r.RecentLearnings = append(r.RecentLearnings, learnings...)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if r.Retros == 0 {
+		return nil, nil
+	}
+	for k, n := range counts {
+		if n >= 2 {
+			r.RecurringGaps = append(r.RecurringGaps, ReviewRejectionGap{Keyword: k, Rejections: n})
+		}
+	}
+	sort.Slice(r.RecurringGaps, func(a, b int) bool {
+		if r.RecurringGaps[a].Rejections != r.RecurringGaps[b].Rejections {
+			return r.RecurringGaps[a].Rejections > r.RecurringGaps[b].Rejections
+		}
+		return r.RecurringGaps[a].Keyword < r.RecurringGaps[b].Keyword
+	})
+	return r, nil
+}
@@ -0,0 +1,224 @@
+package jobs
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Remote eligibility: many "remote" listings only hire in one country or region. The
+// location field (Remotive's candidate_required_location, WWR's region, Jobicy's
+// jobGeo) and restrictions stated in the text are mapped to eligibility buckets —
+// "worldwide", a region ("us", "eu", "uk", "canada", "latam", "apac") or a specific
+// country ("germany") — and matched against where the user can work from.
+
+const bucketWorldwide = "worldwide"
+
+// locationBuckets maps a normalized location token to its buckets. Countries not
+// listed here but present in the country sets below map to themselves.
+var locationBuckets = map[string][]string{
+	"anywhere": {bucketWorldwide}, "worldwide": {bucketWorldwide}, "global": {bucketWorldwide},
+	"world": {bucketWorldwide}, "international": {bucketWorldwide},
+	"us": {"us"}, "usa": {"us"}, "u.s.": {"us"}, "u.s.a.": {"us"}, "united states": {"us"},
+	"united states of america": {"us"}, "north america": {"us", "canada"}, "americas": {"us", "canada", "latam"},
+	"eu": {"eu"}, "european union": {"eu"}, "europe": {"eu", "uk"}, "emea": {"eu", "uk"},
+	"uk": {"uk"}, "u.k.": {"uk"}, "united kingdom": {"uk"}, "great britain": {"uk"}, "england": {"uk"},
+	"canada": {"canada"}, "latam": {"latam"}, "latin america": {"latam"}, "south america": {"latam"},
+	"apac": {"apac"}, "asia": {"apac"}, "asia pacific": {"apac"}, "asia-pacific": {"apac"},
+	"czech republic": {"czechia"}, "holland": {"netherlands"},
+}
+
+var (
+	latamCountries = map[string]bool{
+		"mexico": true, "brazil": true, "argentina": true, "colombia": true, "chile": true, "peru": true,
+		"uruguay": true, "costa rica": true, "ecuador": true, "bolivia": true, "paraguay": true, "venezuela": true,
+	}
+	apacCountries = map[string]bool{
+		"australia": true, "new zealand": true, "japan": true, "singapore": true, "india": true,
+		"philippines": true, "indonesia": true, "vietnam": true, "south korea": true, "malaysia": true,
+		"thailand": true, "taiwan": true, "hong kong": true, "pakistan": true, "bangladesh": true,
+	}
+	otherCountries = map[string]bool{
+		"switzerland": true, "norway": true, "iceland": true, "serbia": true, "ukraine": true, "turkey": true,
+		"israel": true, "georgia": true, "armenia": true, "kazakhstan": true, "south africa": true,
+		"nigeria": true, "kenya": true, "egypt": true, "united arab emirates": true, "uae": true,
+	}
+)
+
+// remoteEligibilityRules are restrictions stated in free text. Unlike the location
+// field, text only counts when it qualifies the region ("US only", "EU-based").
+var remoteEligibilityRules = []struct {
+	re      *regexp.Regexp
+	buckets []string
+}{
+	{regexp.MustCompile(`(?i)\b(worldwide|anywhere in the world|work from anywhere|global(ly)? remote)\b`), []string{bucketWorldwide}},
+	{regexp.MustCompile(`(?i)\b(us|usa|u\.s\.|united states)[- ](only|based|remote)\b|remote \((us|usa)\)`), []string{"us"}},
+	{regexp.MustCompile(`(?i)\bnorth america(n)?[- ](only|based|remote)\b|remote \(north america\)`), []string{"us", "canada"}},
+	{regexp.MustCompile(`(?i)\b(eu|europe|emea)[- ](only|based|remote)\b|remote \((eu|europe)\)`), []string{"eu"}},
+	{regexp.MustCompile(`(?i)\b(uk|united kingdom)[- ](only|based|remote)\b`), []string{"uk"}},
+	{regexp.MustCompile(`(?i)\bcanada[- ](only|based|remote)\b`), []string{"canada"}},
+	{regexp.MustCompile(`(?i)\b(latam|latin america)\b`), []string{"latam"}},
+	{regexp.MustCompile(`(?i)\b(apac|asia[- ]pacific)\b`), []string{"apac"}},
+}
+
+var (
+	// residencyRe captures the place in "must be based in Germany or Poland".
+	residencyRe = regexp.MustCompile(`(?i)\b(?:must|should|need to|required to) (?:be )?(?:located|based|residing|reside|live|living) (?:in|within) (?:the )?([a-z .,&/-]+?)(?:[.;:!\n)]|$| to | and (?:have|be|hold))`)
+	// locationSepRe splits a location field into places.
+	locationSepRe = regexp.MustCompile(`(?i)\s*(?:[,;/|()+]|\bor\b|\band\b|&)\s*`)
+	// locationNoiseRe removes words that qualify a place rather than name it.
+	locationNoiseRe = regexp.MustCompile(`(?i)\b(100% )?(remote|only|based|residents?|timezones?|time zones?|hybrid|fully)\b|[-–—:]`)
+)
+
+// RemoteEligibility returns the eligibility buckets of a remote listing from its
+// location field and text, or nil when neither restricts it. Worldwide is reported only
+// when nothing narrower is stated.
+func RemoteEligibility(location, text string) []string {
+	var buckets []string
+	add := func(bs ...string) {
+		for _, b := range bs {
+			if !containsString(buckets, b) {
+				buckets = append(buckets, b)
+			}
+		}
+	}
+	add(locationToBuckets(location)...)
+	for _, r := range remoteEligibilityRules {
+		if r.re.MatchString(text) {
+			add(r.buckets...)
+		}
+	}
+	for _, m := range residencyRe.FindAllStringSubmatch(text, -1) {
+		add(locationToBuckets(m[1])...)
+	}
+	if len(buckets) > 1 {
+		narrow := buckets[:0:0]
+		for _, b := range buckets {
+			if b != bucketWorldwide {
+				narrow = append(narrow, b)
+			}
+		}
+		buckets = narrow
+	}
+	return buckets
+}
+
+// locationToBuckets maps each recognized place in a location string to its buckets.
+func locationToBuckets(location string) []string {
+	var out []string
+	for _, part := range locationSepRe.Split(strings.ToLower(location), -1) {
+		place := strings.Join(strings.Fields(locationNoiseRe.ReplaceAllString(part, " ")), " ")
+		if place == "" {
+			continue
+		}
+		bs, ok := locationBuckets[place]
+		if !ok && isKnownCountry(place) {
+			bs = []string{place}
+		}
+		for _, b := range bs {
+			if !containsString(out, b) {
+				out = append(out, b)
+			}
+		}
+	}
+	return out
+}
+
+func isKnownCountry(place string) bool {
+	return euCountries[place] || latamCountries[place] || apacCountries[place] || otherCountries[place]
+}
+
+// eligibleFromSet expands where the user can work from to every bucket that admits
+// them: "Germany" → germany, eu, worldwide. Returns nil when from names no known place.
+func eligibleFromSet(from string) map[string]bool {
+	buckets := locationToBuckets(from)
+	if len(buckets) == 0 {
+		return nil
+	}
+	set := map[string]bool{bucketWorldwide: true}
+	for _, b := range buckets {
+		set[b] = true
+		switch {
+		case euCountries[b]:
+			set["eu"] = true
+		case latamCountries[b]:
+			set["latam"] = true
+		case apacCountries[b]:
+			set["apac"] = true
+		}
+	}
+	return set
+}
+
+// eligibleFor reports whether a listing with the given buckets is open to someone in
+// the set; listings stating no restriction are.
+func eligibleFor(buckets []string, set map[string]bool) bool {
+	if len(buckets) == 0 {
+		return true
+	}
+	for _, b := range buckets {
+		if set[b] {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyRemoteEligibility tags remote_work_search listings with eligible_from buckets
+// (from location, title and tags) and, when eligibleFrom is set, drops listings
+// restricted to places that exclude it.
+func ApplyRemoteEligibility(listings []engine.RemoteJobListing, eligibleFrom string) []engine.RemoteJobListing {
+	set := eligibleFromSet(eligibleFrom)
+	out := listings[:0:0]
+	for _, j := range listings {
+		j.EligibleFrom = RemoteEligibility(j.Location, j.Title+"\n"+strings.Join(j.Tags, ", "))
+		if set != nil && !eligibleFor(j.EligibleFrom, set) {
+			continue
+		}
+		out = append(out, j)
+	}
+	return out
+}
+
+// FilterEligibleFrom drops job_search remote listings restricted to places that
+// exclude eligibleFrom. On-site and hybrid listings are kept.
+func FilterEligibleFrom(listings []engine.JobListing, eligibleFrom string) []engine.JobListing {
+	set := eligibleFromSet(eligibleFrom)
+	if set == nil {
+		return listings
+	}
+	out := listings[:0:0]
+	for _, j := range listings {
+		if isRemoteListing(j) && !eligibleFor(listingEligibility(j), set) {
+			continue
+		}
+		out = append(out, j)
+	}
+	return out
+}
+
+// listingEligibility returns the remote eligibility buckets of a job_search listing.
+// The location field only counts for remote listings, where it names who may apply
+// rather than where the office is.
+func listingEligibility(j engine.JobListing) []string {
+	text := j.Title + "\n" + j.Remote + "\n" + j.Description
+	if isRemoteListing(j) {
+		return RemoteEligibility(j.Location, text)
+	}
+	return RemoteEligibility("", j.Location+"\n"+text)
+}
+
+func isRemoteListing(j engine.JobListing) bool {
+	loc := strings.ToLower(j.Location)
+	return strings.EqualFold(j.Remote, "remote") || strings.Contains(loc, "remote") || strings.Contains(loc, "anywhere")
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
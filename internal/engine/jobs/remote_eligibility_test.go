@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestRemoteEligibility(t *testing.T) {
+	tests := []struct {
+		location, text string
+		want           string
+	}{
+		{"Anywhere", "", "worldwide"},
+		{"USA Only", "", "us"},
+		{"Remote - Europe", "", "eu,uk"},
+		{"Germany, Poland", "", "germany,poland"},
+		{"Worldwide", "Candidates must be based in the United States.", "us"},
+		{"", "We are a fully remote, EU-based team", "eu"},
+		{"Remote", "Great team, great perks", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(RemoteEligibility(tt.location, tt.text), ","); got != tt.want {
+			t.Errorf("RemoteEligibility(%q, %q) = %q, want %q", tt.location, tt.text, got, tt.want)
+		}
+	}
+
+	listings := []engine.RemoteJobListing{
+		{Title: "Go Dev", Location: "USA only"},
+		{Title: "Rust Dev", Location: "Europe"},
+		{Title: "SRE", Location: "Worldwide"},
+		{Title: "PM", Location: "Remote"},
+		{Title: "QA", Location: "Brazil"},
+	}
+	got := ApplyRemoteEligibility(listings, "Germany")
+	var titles []string
+	for _, j := range got {
+		titles = append(titles, j.Title)
+	}
+	if strings.Join(titles, ",") != "Rust Dev,SRE,PM" {
+		t.Errorf("eligible from Germany = %v", titles)
+	}
+	if all := ApplyRemoteEligibility(listings, ""); len(all) != 5 || all[0].EligibleFrom[0] != "us" {
+		t.Errorf("tagging without filter = %+v", all)
+	}
+
+	jl := []engine.JobListing{
+		{Title: "Backend", Location: "Remote (US)", Remote: "remote"},
+		{Title: "Onsite", Location: "Austin, United States", Remote: "onsite"},
+	}
+	if f := FilterEligibleFrom(jl, "EU"); len(f) != 1 || f[0].Title != "Onsite" {
+		t.Errorf("FilterEligibleFrom(EU) = %+v", f)
+	}
+}
@@ -0,0 +1,316 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Additional remote-first boards for remote_work_search and job_search platform=remote:
+// Jobicy (JSON API), Himalayas (JSON API, see himalayas.go) and JustRemote (RSS).
+
+const (
+	jobicyAPIURL      = "https://jobicy.com/api/v2/remote-jobs"
+	jobicyCacheKey    = "jobicy_jobs"
+	justRemoteFeedURL = "https://justremote.co/feed"
+)
+
+// --- Jobicy API ---
+
+type jobicyResponse struct {
+	Jobs []jobicyJob `json:"jobs"`
+}
+
+type jobicyJob struct {
+	URL             string          `json:"url"`
+	JobTitle        string          `json:"jobTitle"`
+	CompanyName     string          `json:"companyName"`
+	JobIndustry     []string        `json:"jobIndustry"`
+	JobType         []string        `json:"jobType"`
+	JobGeo          string          `json:"jobGeo"`
+	JobLevel        string          `json:"jobLevel"`
+	PubDate         string          `json:"pubDate"`
+	AnnualSalaryMin json.RawMessage `json:"annualSalaryMin"`
+	AnnualSalaryMax json.RawMessage `json:"annualSalaryMax"`
+	SalaryCurrency  string          `json:"salaryCurrency"`
+}
+
+// SearchJobicy queries the Jobicy remote jobs API. Jobicy asks clients not to poll
+// often, so results are cached per query.
+func SearchJobicy(ctx context.Context, query string, limit int) ([]engine.RemoteJobListing, error) {
+	if limit <= 0 || limit > 50 {
+		limit = 20
+	}
+	cacheKey := jobicyCacheKey + "_" + strings.ToLower(strings.TrimSpace(query))
+	if cached, ok := engine.CacheLoadJSON[[]engine.RemoteJobListing](ctx, cacheKey); ok {
+		if len(cached) > limit {
+			cached = cached[:limit]
+		}
+		return cached, nil
+	}
+	engine.IncrJobicyRequests(ctx)
+
+	params := url.Values{}
+	params.Set("count", "50")
+	if tag := jobicyTag(query); tag != "" {
+		params.Set("tag", tag)
+	}
+	body, err := fetchRemoteBoard(ctx, jobicyAPIURL+"?"+params.Encode(), "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("jobicy: %w", err)
+	}
+	jobs, err := parseJobicyResponse(body)
+	if err != nil {
+		return nil, err
+	}
+	engine.CacheStoreJSON(ctx, cacheKey, "", jobs)
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	slog.Debug("jobicy: search complete", slog.Int("results", len(jobs)))
+	return jobs, nil
+}
+
+// jobicyTag turns a query into Jobicy's single-keyword tag filter: the longest word,
+// which is usually the most specific ("senior golang developer" → golang).
+func jobicyTag(query string) string {
+	best := ""
+	for _, w := range strings.Fields(strings.ToLower(query)) {
+		if len(w) > len(best) && !genericJobWords[w] {
+			best = w
+		}
+	}
+	return best
+}
+
+// genericJobWords are query words too broad to filter a board by.
+var genericJobWords = map[string]bool{
+	"senior": true, "junior": true, "remote": true, "developer": true, "engineer": true,
+	"software": true, "lead": true, "principal": true, "staff": true, "manager": true,
+}
+
+func parseJobicyResponse(body []byte) ([]engine.RemoteJobListing, error) {
+	var resp jobicyResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("jobicy parse error: %w", err)
+	}
+	jobs := make([]engine.RemoteJobListing, 0, len(resp.Jobs))
+	for _, j := range resp.Jobs {
+		if j.JobTitle == "" || j.URL == "" {
+			continue
+		}
+		posted := j.PubDate
+		if len(posted) >= 10 {
+			posted = posted[:10]
+		}
+		location := j.JobGeo
+		if location == "" {
+			location = "Anywhere"
+		}
+		tags := append([]string(nil), j.JobIndustry...)
+		if j.JobLevel != "" {
+			tags = append(tags, j.JobLevel)
+		}
+		lo, hi := jsonInt(j.AnnualSalaryMin), jsonInt(j.AnnualSalaryMax)
+		jobs = append(jobs, engine.RemoteJobListing{
+			Title:      j.JobTitle,
+			Company:    j.CompanyName,
+			URL:        j.URL,
+			Source:     "jobicy",
+			Salary:     formatBoardSalary(lo, hi, j.SalaryCurrency),
+			SalaryNorm: annualSalaryNorm(lo, hi, j.SalaryCurrency),
+			Location:   location,
+			Tags:       tags,
+			Posted:     posted,
+			JobType:    strings.Join(j.JobType, ", "),
+		})
+	}
+	return jobs, nil
+}
+
+// --- Himalayas ---
+
+// SearchHimalayasRemote returns Himalayas jobs as remote job listings.
+func SearchHimalayasRemote(ctx context.Context, query string, limit int) ([]engine.RemoteJobListing, error) {
+	hjobs, err := SearchHimalayas(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]engine.RemoteJobListing, 0, len(hjobs))
+	for _, h := range hjobs {
+		location := h.Location
+		if location == "" {
+			location = "Anywhere"
+		}
+		jobs = append(jobs, engine.RemoteJobListing{
+			Title:      h.Title,
+			Company:    h.Company,
+			URL:        h.URL,
+			Source:     "himalayas",
+			Salary:     formatBoardSalary(h.SalaryMin, h.SalaryMax, h.Currency),
+			SalaryNorm: annualSalaryNorm(h.SalaryMin, h.SalaryMax, h.Currency),
+			Location:   location,
+			Tags:       h.Tags,
+			Posted:     h.Posted,
+			JobType:    "remote",
+		})
+	}
+	return jobs, nil
+}
+
+// --- JustRemote RSS ---
+
+type justRemoteRSS struct {
+	Channel struct {
+		Items []justRemoteItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type justRemoteItem struct {
+	Title      string   `xml:"title"`
+	Link       string   `xml:"link"`
+	PubDate    string   `xml:"pubDate"`
+	Categories []string `xml:"category"`
+}
+
+// SearchJustRemote fetches the JustRemote RSS feed and filters it by the query.
+func SearchJustRemote(ctx context.Context, query string, limit int) ([]engine.RemoteJobListing, error) {
+	engine.IncrJustRemoteRequests(ctx)
+	if limit <= 0 || limit > 30 {
+		limit = 20
+	}
+	body, err := fetchRemoteBoard(ctx, justRemoteFeedURL, "application/rss+xml, application/xml")
+	if err != nil {
+		return nil, fmt.Errorf("justremote: %w", err)
+	}
+	jobs, err := parseJustRemoteFeed(body)
+	if err != nil {
+		return nil, err
+	}
+	filtered := filterRemoteJobs(jobs, query)
+	if len(filtered) > limit {
+		filtered = filtered[:limit]
+	}
+	slog.Debug("justremote: search complete", slog.Int("raw", len(jobs)), slog.Int("filtered", len(filtered)))
+	return filtered, nil
+}
+
+func parseJustRemoteFeed(body []byte) ([]engine.RemoteJobListing, error) {
+	var rss justRemoteRSS
+	if err := xml.Unmarshal(body, &rss); err != nil {
+		return nil, fmt.Errorf("justremote rss parse error: %w", err)
+	}
+	jobs := make([]engine.RemoteJobListing, 0, len(rss.Channel.Items))
+	for _, item := range rss.Channel.Items {
+		if item.Title == "" || item.Link == "" {
+			continue
+		}
+		title, company := parseWWRTitle(item.Title)
+		if company == "" {
+			if idx := strings.LastIndex(title, " at "); idx > 0 {
+				title, company = strings.TrimSpace(title[:idx]), strings.TrimSpace(title[idx+4:])
+			}
+		}
+		posted := ""
+		if t, err := time.Parse(time.RFC1123Z, item.PubDate); err == nil {
+			posted = t.UTC().Format(time.DateOnly)
+		} else if t, err := time.Parse(time.RFC1123, item.PubDate); err == nil {
+			posted = t.UTC().Format(time.DateOnly)
+		}
+		jobs = append(jobs, engine.RemoteJobListing{
+			Title:    title,
+			Company:  company,
+			URL:      strings.TrimSpace(item.Link),
+			Source:   "justremote",
+			Salary:   "not specified",
+			Location: "Anywhere",
+			Tags:     item.Categories,
+			Posted:   posted,
+			JobType:  "remote",
+		})
+	}
+	return jobs, nil
+}
+
+// --- shared helpers ---
+
+// fetchRemoteBoard GETs a board API or feed with retries and returns the body.
+func fetchRemoteBoard(ctx context.Context, boardURL, accept string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, boardURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", engine.UserAgentBot)
+	req.Header.Set("Accept", accept)
+
+	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 5*1024*1024))
+}
+
+// jsonInt reads a JSON number or numeric string; 0 when absent or not a number.
+func jsonInt(raw json.RawMessage) int {
+	s := strings.Trim(strings.TrimSpace(string(raw)), `"`)
+	v, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	return int(v)
+}
+
+// formatBoardSalary formats an annual salary range in the given currency (default USD).
+func formatBoardSalary(lo, hi int, currency string) string {
+	currency = strings.ToUpper(currency)
+	if currency == "" || currency == "USD" {
+		return formatRemoteSalary(lo, hi)
+	}
+	switch {
+	case lo == 0 && hi == 0:
+		return "not specified"
+	case lo == 0 || lo == hi:
+		return fmt.Sprintf("%d %s", max(lo, hi), currency)
+	case hi == 0:
+		return fmt.Sprintf("from %d %s", lo, currency)
+	}
+	return fmt.Sprintf("%d - %d %s", lo, hi, currency)
+}
+
+// annualSalaryNorm builds a normalized salary from annual amounts, or nil when both are 0.
+func annualSalaryNorm(lo, hi int, currency string) *engine.NormalizedSalary {
+	if lo <= 0 && hi <= 0 {
+		return nil
+	}
+	if currency == "" {
+		currency = "USD"
+	}
+	n := &engine.NormalizedSalary{Currency: strings.ToUpper(currency)}
+	if lo > 0 {
+		n.AnnualMin = &lo
+	}
+	if hi > 0 {
+		n.AnnualMax = &hi
+	}
+	return n
+}
@@ -6,11 +6,12 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/anatolykoptev/go_job/internal/engine"
@@ -19,6 +20,45 @@ import (
 const remoteOKAPI = "https://remoteok.com/api"
 const wwrRSSURL = "https://weworkremotely.com/remote-jobs.rss"
 
+// wwrCategoryFeeds are WWR's per-category RSS feeds with the query words that select
+// them. The global feed only carries the latest listings, so matching category feeds
+// are fetched alongside it for recall.
+var wwrCategoryFeeds = []struct {
+	slug     string
+	keywords []string
+}{
+	{"remote-back-end-programming-jobs", []string{"backend", "back-end", "golang", "go", "python", "java", "ruby", "rails", "php", "node", "api", "rust", "scala", "elixir", "django"}},
+	{"remote-front-end-programming-jobs", []string{"frontend", "front-end", "react", "vue", "angular", "javascript", "typescript", "css", "svelte"}},
+	{"remote-full-stack-programming-jobs", []string{"fullstack", "full-stack", "full", "stack", "javascript", "typescript", "node", "react", "rails"}},
+	{"remote-programming-jobs", []string{"developer", "engineer", "programmer", "software", "programming", "mobile", "ios", "android", "data", "ml", "ai"}},
+	{"remote-devops-sysadmin-jobs", []string{"devops", "sre", "sysadmin", "infrastructure", "kubernetes", "k8s", "cloud", "aws", "platform", "reliability", "terraform"}},
+	{"remote-design-jobs", []string{"design", "designer", "ux", "ui", "product designer", "figma", "graphic"}},
+	{"remote-product-jobs", []string{"product", "pm", "owner"}},
+	{"remote-customer-support-jobs", []string{"support", "customer", "success", "helpdesk"}},
+	{"remote-sales-and-marketing-jobs", []string{"sales", "marketing", "seo", "growth", "content", "copywriter", "account"}},
+	{"remote-management-and-finance-jobs", []string{"manager", "management", "finance", "accounting", "operations", "hr", "recruiter"}},
+}
+
+// wwrFeedsForQuery returns the global WWR feed plus the category feeds whose
+// keywords appear in the query.
+func wwrFeedsForQuery(query string) []string {
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(strings.ToLower(query)) {
+		words[w] = true
+	}
+	lower := " " + strings.ToLower(query) + " "
+	feeds := []string{wwrRSSURL}
+	for _, c := range wwrCategoryFeeds {
+		for _, k := range c.keywords {
+			if words[k] || (strings.Contains(k, " ") && strings.Contains(lower, " "+k+" ")) {
+				feeds = append(feeds, "https://weworkremotely.com/categories/"+c.slug+".rss")
+				break
+			}
+		}
+	}
+	return feeds
+}
+
 // --- RemoteOK API types ---
 
 type remoteOKJob struct {
@@ -57,7 +97,7 @@ type wwrItem struct {
 
 // SearchRemoteOK queries the RemoteOK JSON API for remote job listings.
 func SearchRemoteOK(ctx context.Context, query string, limit int) ([]engine.RemoteJobListing, error) {
-	engine.IncrRemoteOKRequests()
+	engine.IncrRemoteOKRequests(ctx)
 
 	if limit <= 0 || limit > 30 {
 		limit = 20
@@ -79,7 +119,7 @@ func SearchRemoteOK(ctx context.Context, query string, limit int) ([]engine.Remo
 	u.RawQuery = q.Encode()
 	apiURL := u.String()
 
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
@@ -90,7 +130,7 @@ func SearchRemoteOK(ctx context.Context, query string, limit int) ([]engine.Remo
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return nil, err
@@ -101,12 +141,11 @@ func SearchRemoteOK(ctx context.Context, query string, limit int) ([]engine.Remo
 		return nil, fmt.Errorf("RemoteOK API returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
-	if err != nil {
-		return nil, err
-	}
-
-	jobs, err := parseRemoteOKResponse(body)
+	var jobs []engine.RemoteJobListing
+	err = engine.ReadBody(resp.Body, 1024*1024, func(body []byte) (err error) {
+		jobs, err = parseRemoteOKResponse(body)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -190,18 +229,67 @@ func formatRemoteSalary(min, max int) string {
 	return fmt.Sprintf("$%d - $%d", min, max)
 }
 
-// SearchWeWorkRemotely fetches and parses the WWR RSS feed.
+// SearchWeWorkRemotely fetches the global WWR RSS feed and the category feeds matching
+// the query in parallel, and filters the merged listings by the query.
 func SearchWeWorkRemotely(ctx context.Context, query string, limit int) ([]engine.RemoteJobListing, error) {
-	engine.IncrWWRRequests()
+	engine.IncrWWRRequests(ctx)
 
 	if limit <= 0 || limit > 30 {
 		limit = 20
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wwrRSSURL, nil)
+	feeds := wwrFeedsForQuery(query)
+	results := make([][]engine.RemoteJobListing, len(feeds))
+	errs := make([]error, len(feeds))
+	var wg sync.WaitGroup
+	for i, feed := range feeds {
+		wg.Add(1)
+		engine.GoOutbound(ctx, func() {
+			defer wg.Done()
+			results[i], errs[i] = fetchWWRFeed(ctx, feed)
+			if errs[i] != nil {
+				slog.Debug("wwr: feed failed", slog.String("feed", feed), slog.Any("error", errs[i]))
+			}
+		})
+	}
+	wg.Wait()
+
+	var jobs []engine.RemoteJobListing
+	seen := make(map[string]bool)
+	var lastErr error
+	for i, feedJobs := range results {
+		if errs[i] != nil {
+			lastErr = errs[i]
+			continue
+		}
+		for _, j := range feedJobs {
+			if !seen[j.URL] {
+				seen[j.URL] = true
+				jobs = append(jobs, j)
+			}
+		}
+	}
+	if len(jobs) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	// Filter by keyword match since RSS returns all listings.
+	filtered := filterRemoteJobs(jobs, query)
+
+	if len(filtered) > limit {
+		filtered = filtered[:limit]
+	}
+
+	slog.Debug("wwr: search complete", slog.Int("feeds", len(feeds)), slog.Int("raw", len(jobs)), slog.Int("filtered", len(filtered)))
+	return filtered, nil
+}
+
+// fetchWWRFeed fetches and parses one WWR RSS feed.
+func fetchWWRFeed(ctx context.Context, feedURL string) ([]engine.RemoteJobListing, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +297,7 @@ func SearchWeWorkRemotely(ctx context.Context, query string, limit int) ([]engin
 	req.Header.Set("Accept", "application/xml, application/rss+xml")
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return nil, err
@@ -220,25 +308,12 @@ func SearchWeWorkRemotely(ctx context.Context, query string, limit int) ([]engin
 		return nil, fmt.Errorf("WWR RSS returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
-	if err != nil {
-		return nil, err
-	}
-
-	jobs, err := parseWWRResponse(body)
-	if err != nil {
-		return nil, err
-	}
-
-	// Filter by keyword match since RSS returns all listings.
-	filtered := filterRemoteJobs(jobs, query)
-
-	if len(filtered) > limit {
-		filtered = filtered[:limit]
-	}
-
-	slog.Debug("wwr: search complete", slog.Int("raw", len(jobs)), slog.Int("filtered", len(filtered)))
-	return filtered, nil
+	var jobs []engine.RemoteJobListing
+	err = engine.ReadBody(resp.Body, 1024*1024, func(body []byte) (err error) {
+		jobs, err = parseWWRResponse(body)
+		return err
+	})
+	return jobs, err
 }
 
 // parseWWRResponse parses the WeWorkRemotely RSS XML feed.
@@ -381,6 +456,13 @@ func RemoteJobsToSearxngResults(jobs []engine.RemoteJobListing) []engine.Searxng
 		content.WriteString("**Source:** " + j.Source)
 		if j.Salary != "not specified" {
 			content.WriteString(" | **Salary:** " + j.Salary)
+			if n := j.SalaryNorm; n != nil && n.AnnualMax != nil {
+				content.WriteString(" (annual")
+				if n.AnnualMin != nil {
+					fmt.Fprintf(&content, " %d -", *n.AnnualMin)
+				}
+				fmt.Fprintf(&content, " %d %s)", *n.AnnualMax, n.Currency)
+			}
 		}
 		if len(j.Tags) > 0 {
 			content.WriteString(" | **Tags:** " + strings.Join(j.Tags, ", "))
@@ -431,12 +513,73 @@ type remotiveJob struct {
 	Salary                    string   `json:"salary"`
 }
 
+// RemotiveOptions carries job_search filters to Remotive.
+type RemotiveOptions struct {
+	JobType    string // full-time, part-time, contract, temporary
+	Experience string // job_search experience level; only "internship" maps to Remotive
+}
+
+// remotiveCategories maps query words to Remotive category slugs.
+var remotiveCategories = []struct {
+	slug     string
+	keywords []string
+}{
+	{"devops", []string{"devops", "sre", "sysadmin", "infrastructure", "kubernetes", "k8s", "terraform"}},
+	{"data", []string{"data", "analyst", "analytics", "scientist", "ml", "bi"}},
+	{"qa", []string{"qa", "tester", "testing", "sdet"}},
+	{"design", []string{"designer", "design", "ux", "ui", "figma"}},
+	{"product", []string{"product"}},
+	{"customer-support", []string{"support", "helpdesk", "customer"}},
+	{"marketing", []string{"marketing", "seo", "growth"}},
+	{"sales-business", []string{"sales", "account", "bdr", "sdr"}},
+	{"writing", []string{"writer", "copywriter", "editor", "content"}},
+	{"finance-legal", []string{"finance", "accountant", "accounting", "legal", "lawyer"}},
+	{"human-resources", []string{"recruiter", "hr", "talent"}},
+	{"project-management", []string{"project", "scrum", "agile"}},
+	{"software-dev", []string{"developer", "engineer", "programmer", "software", "backend", "frontend", "fullstack", "golang", "go", "python", "java", "javascript", "typescript", "react", "rust", "ruby", "php", "ios", "android"}},
+}
+
+// remotiveCategory returns the Remotive category for a query, or "" when no
+// category clearly matches. Earlier categories win: "data engineer" is data.
+func remotiveCategory(query string) string {
+	words := strings.Fields(strings.ToLower(query))
+	for _, c := range remotiveCategories {
+		for _, k := range c.keywords {
+			for _, w := range words {
+				if w == k {
+					return c.slug
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// remotiveJobTypes maps job_search job_type and experience to the Remotive job_type
+// values to keep; nil keeps all.
+func remotiveJobTypes(opts RemotiveOptions) map[string]bool {
+	if strings.EqualFold(opts.Experience, "internship") {
+		return map[string]bool{"internship": true}
+	}
+	switch strings.ToLower(opts.JobType) {
+	case "full-time":
+		return map[string]bool{"full_time": true}
+	case "part-time":
+		return map[string]bool{"part_time": true}
+	case "contract", "temporary":
+		return map[string]bool{"contract": true, "freelance": true}
+	}
+	return nil
+}
+
 // SearchRemotive queries the Remotive public JSON API for remote job listings.
-// No auth required. Search results are filtered by the `search` param server-side.
-func SearchRemotive(ctx context.Context, query string, limit int) ([]engine.RemoteJobListing, error) {
+// No auth required. Search results are filtered by the `search` param server-side,
+// narrowed to the category matching the query, and filtered by job type locally.
+func SearchRemotive(ctx context.Context, query string, opts RemotiveOptions, limit int) ([]engine.RemoteJobListing, error) {
 	if limit <= 0 || limit > 30 {
 		limit = 15
 	}
+	jobTypes := remotiveJobTypes(opts)
 
 	u, err := url.Parse(remotiveAPI)
 	if err != nil {
@@ -444,9 +587,17 @@ func SearchRemotive(ctx context.Context, query string, limit int) ([]engine.Remo
 	}
 	q := u.Query()
 	q.Set("search", query)
+	if cat := remotiveCategory(query); cat != "" {
+		q.Set("category", cat)
+	}
+	fetch := limit
+	if jobTypes != nil {
+		fetch = limit * 4 // job type is filtered locally
+	}
+	q.Set("limit", strconv.Itoa(fetch))
 	u.RawQuery = q.Encode()
 
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
@@ -457,7 +608,7 @@ func SearchRemotive(ctx context.Context, query string, limit int) ([]engine.Remo
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return nil, err
@@ -468,11 +619,24 @@ func SearchRemotive(ctx context.Context, query string, limit int) ([]engine.Remo
 		return nil, fmt.Errorf("remotive API returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 2*1024*1024))
+	var jobs []engine.RemoteJobListing
+	err = engine.ReadBody(resp.Body, 2*1024*1024, func(body []byte) (err error) {
+		jobs, err = parseRemotiveResponse(body, jobTypes)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
 
+	slog.Debug("remotive: search complete", slog.Int("results", len(jobs)))
+	return jobs, nil
+}
+
+// parseRemotiveResponse parses the Remotive API response, keeping only jobTypes when non-nil.
+func parseRemotiveResponse(body []byte, jobTypes map[string]bool) ([]engine.RemoteJobListing, error) {
 	var rr remotiveResponse
 	if err := json.Unmarshal(body, &rr); err != nil {
 		return nil, fmt.Errorf("remotive parse error: %w", err)
@@ -483,6 +647,9 @@ func SearchRemotive(ctx context.Context, query string, limit int) ([]engine.Remo
 		if j.Title == "" || j.URL == "" {
 			continue
 		}
+		if jobTypes != nil && !jobTypes[j.JobType] {
+			continue
+		}
 
 		// Parse date: Remotive uses "2024-01-15T10:00:00" format, take YYYY-MM-DD prefix.
 		posted := ""
@@ -497,29 +664,24 @@ func SearchRemotive(ctx context.Context, query string, limit int) ([]engine.Remo
 
 		jobType := strings.ReplaceAll(j.JobType, "_", " ")
 
-		salary := j.Salary
+		salary := strings.TrimSpace(j.Salary)
 		if salary == "" {
 			salary = "not specified"
 		}
 
 		jobs = append(jobs, engine.RemoteJobListing{
-			Title:    j.Title,
-			Company:  j.CompanyName,
-			URL:      j.URL,
-			Source:   "remotive",
-			Salary:   salary,
-			Location: location,
-			Tags:     j.Tags,
-			Posted:   posted,
-			JobType:  jobType,
+			Title:      j.Title,
+			Company:    j.CompanyName,
+			URL:        j.URL,
+			Source:     "remotive",
+			Salary:     salary,
+			SalaryNorm: normalizeSalaryText(j.Salary),
+			Location:   location,
+			Tags:       j.Tags,
+			Posted:     posted,
+			JobType:    jobType,
 		})
 	}
-
-	if len(jobs) > limit {
-		jobs = jobs[:limit]
-	}
-
-	slog.Debug("remotive: search complete", slog.Int("results", len(jobs)))
 	return jobs, nil
 }
 
@@ -251,6 +251,31 @@ func TestParseWWRTitle(t *testing.T) {
 	}
 }
 
+func TestWWRFeedsForQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string // category slugs besides the global feed
+	}{
+		{"senior golang engineer", []string{"remote-back-end-programming-jobs", "remote-programming-jobs"}},
+		{"DevOps Kubernetes", []string{"remote-devops-sysadmin-jobs"}},
+		{"product designer", []string{"remote-design-jobs", "remote-product-jobs"}},
+		{"underwater basket weaving", nil},
+	}
+	for _, tt := range tests {
+		feeds := wwrFeedsForQuery(tt.query)
+		if feeds[0] != wwrRSSURL {
+			t.Errorf("wwrFeedsForQuery(%q)[0] = %q, want the global feed", tt.query, feeds[0])
+		}
+		var got []string
+		for _, f := range feeds[1:] {
+			got = append(got, strings.TrimSuffix(strings.TrimPrefix(f, "https://weworkremotely.com/categories/"), ".rss"))
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("wwrFeedsForQuery(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
 func TestFilterRemoteJobs(t *testing.T) {
 	jobs := []engine.RemoteJobListing{
 		{Title: "Senior Go Developer", Company: "Acme", Tags: []string{"golang", "kubernetes"}},
@@ -335,3 +360,115 @@ func TestRemoteJobsToSearxngResults(t *testing.T) {
 		t.Errorf("content should contain source, got: %s", r.Content)
 	}
 }
+
+func TestRemotiveCategoryAndJobTypes(t *testing.T) {
+	for query, want := range map[string]string{
+		"senior golang developer": "software-dev",
+		"data engineer":           "data",
+		"DevOps Engineer":         "devops",
+		"UX designer":             "design",
+		"chief of staff":          "",
+	} {
+		if got := remotiveCategory(query); got != want {
+			t.Errorf("remotiveCategory(%q) = %q, want %q", query, got, want)
+		}
+	}
+
+	if got := remotiveJobTypes(RemotiveOptions{JobType: "contract"}); !got["contract"] || !got["freelance"] || got["full_time"] {
+		t.Errorf("contract job types = %v", got)
+	}
+	if got := remotiveJobTypes(RemotiveOptions{JobType: "full-time", Experience: "internship"}); len(got) != 1 || !got["internship"] {
+		t.Errorf("internship job types = %v", got)
+	}
+	if got := remotiveJobTypes(RemotiveOptions{}); got != nil {
+		t.Errorf("no filters should keep all job types, got %v", got)
+	}
+}
+
+func TestNormalizeSalaryText(t *testing.T) {
+	tests := []struct {
+		in       string
+		min, max int
+		currency string
+	}{
+		{"$80k - $120k", 80000, 120000, "USD"},
+		{"€50-60k/yr", 50000, 60000, "EUR"},
+		{"$120,000 USD", 0, 120000, "USD"},
+		{"$40 - $60 per hour", 83200, 124800, "USD"},
+	}
+	for _, tt := range tests {
+		n := normalizeSalaryText(tt.in)
+		if n == nil || n.AnnualMax == nil {
+			t.Errorf("normalizeSalaryText(%q) = nil", tt.in)
+			continue
+		}
+		gotMin := 0
+		if n.AnnualMin != nil {
+			gotMin = *n.AnnualMin
+		}
+		if gotMin != tt.min || *n.AnnualMax != tt.max || n.Currency != tt.currency {
+			t.Errorf("normalizeSalaryText(%q) = %d-%d %s, want %d-%d %s", tt.in, gotMin, *n.AnnualMax, n.Currency, tt.min, tt.max, tt.currency)
+		}
+	}
+	if n := normalizeSalaryText("competitive"); n != nil {
+		t.Errorf("normalizeSalaryText(competitive) = %+v, want nil", n)
+	}
+}
+
+func TestParseJobicyResponse(t *testing.T) {
+	body := []byte(`{"apiVersion":"2","jobs":[
+		{"id":1,"url":"https://jobicy.com/jobs/1-go-dev","jobTitle":"Go Developer","companyName":"Acme",
+		 "jobIndustry":["Programming"],"jobType":["full-time"],"jobGeo":"EMEA","jobLevel":"Senior",
+		 "pubDate":"2026-03-01 10:00:00","annualSalaryMin":"90000","annualSalaryMax":120000,"salaryCurrency":"EUR"},
+		{"id":2,"url":"https://jobicy.com/jobs/2","jobTitle":"Designer","companyName":"Beta","pubDate":"2026-03-02 09:00:00"},
+		{"id":3,"url":"","jobTitle":"No URL"}
+	]}`)
+	jobs, err := parseJobicyResponse(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+	j := jobs[0]
+	if j.Source != "jobicy" || j.Posted != "2026-03-01" || j.Location != "EMEA" || j.JobType != "full-time" {
+		t.Errorf("unexpected listing: %+v", j)
+	}
+	if j.Salary != "90000 - 120000 EUR" {
+		t.Errorf("salary = %q", j.Salary)
+	}
+	if j.SalaryNorm == nil || *j.SalaryNorm.AnnualMin != 90000 || *j.SalaryNorm.AnnualMax != 120000 || j.SalaryNorm.Currency != "EUR" {
+		t.Errorf("salary_normalized = %+v", j.SalaryNorm)
+	}
+	if jobs[1].Location != "Anywhere" || jobs[1].SalaryNorm != nil || jobs[1].Salary != "not specified" {
+		t.Errorf("defaults not applied: %+v", jobs[1])
+	}
+	if _, err := parseJobicyResponse([]byte("not json")); err == nil {
+		t.Error("expected parse error")
+	}
+	if got := jobicyTag("Senior Golang Developer"); got != "golang" {
+		t.Errorf("jobicyTag = %q, want golang", got)
+	}
+}
+
+func TestParseJustRemoteFeed(t *testing.T) {
+	body := []byte(`<?xml version="1.0"?><rss><channel>
+		<item><title>Acme: Backend Engineer</title><link>https://justremote.co/remote-developer-jobs/backend-engineer-acme</link>
+		<pubDate>Mon, 02 Mar 2026 10:00:00 +0000</pubDate><category>Developer</category></item>
+		<item><title>Support Lead at Beta Inc</title><link>https://justremote.co/remote-customer-service-jobs/support-lead</link></item>
+		<item><title></title><link>https://justremote.co/x</link></item>
+	</channel></rss>`)
+	jobs, err := parseJustRemoteFeed(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+	if jobs[0].Title != "Backend Engineer" || jobs[0].Company != "Acme" || jobs[0].Posted != "2026-03-02" {
+		t.Errorf("unexpected first listing: %+v", jobs[0])
+	}
+	if jobs[1].Title != "Support Lead" || jobs[1].Company != "Beta Inc" {
+		t.Errorf("unexpected second listing: %+v", jobs[1])
+	}
+}
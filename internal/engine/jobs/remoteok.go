@@ -52,7 +52,7 @@ func SearchRemoteOKFreelance(ctx context.Context, tag string, limit int) ([]engi
 }
 
 func fetchRemoteOKFreelance(ctx context.Context, tag string) ([]engine.FreelanceJob, error) {
-	fetchCtx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	apiURL := remoteOKAPI
@@ -66,7 +66,7 @@ func fetchRemoteOKFreelance(ctx context.Context, tag string) ([]engine.Freelance
 	}
 	req.Header.Set("User-Agent", engine.UserAgentChrome)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("remoteok freelance request failed: %w", err)
 	}
@@ -13,15 +13,17 @@ import (
 
 // SalaryResearchResult is the structured output of salary_research.
 type SalaryResearchResult struct {
-	Role       string   `json:"role"`
-	Location   string   `json:"location"`
-	Currency   string   `json:"currency"`
-	P25        int      `json:"p25"`
-	Median     int      `json:"median"`
-	P75        int      `json:"p75"`
-	Sources    []string `json:"sources"`
-	Notes      string   `json:"notes"`
-	UpdatedAt  string   `json:"updated_at"`
+	Role      string   `json:"role"`
+	Location  string   `json:"location"`
+	Currency  string   `json:"currency"`
+	P25       int      `json:"p25"`
+	Median    int      `json:"median"`
+	P75       int      `json:"p75"`
+	Sources   []string `json:"sources"`
+	Notes     string   `json:"notes"`
+	UpdatedAt string   `json:"updated_at"`
+	// Anecdotal holds comp datapoints from Blind posts, kept apart from the percentiles.
+	Anecdotal *BlindSignals `json:"anecdotal,omitempty"`
 }
 
 const salaryResearchPrompt = `You are a compensation research expert. Based on the search results below, provide salary data for the role.
@@ -52,6 +54,8 @@ Return ONLY the JSON object, no markdown, no explanation.`
 // ResearchSalary aggregates salary data for a role+location via SearXNG + LLM synthesis.
 func ResearchSalary(ctx context.Context, role, location, experience string) (*SalaryResearchResult, error) {
 	queries := buildSalaryQueries(role, location, experience)
+	blindCh := make(chan *BlindSignals, 1)
+	go func() { blindCh <- BlindCompSignals(ctx, role, location) }()
 
 	type searchRes struct {
 		results []engine.SearxngResult
@@ -59,10 +63,10 @@ func ResearchSalary(ctx context.Context, role, location, experience string) (*Sa
 	}
 	ch := make(chan searchRes, len(queries))
 	for _, q := range queries {
-		go func(query string) {
-			r, err := engine.SearchSearXNG(ctx, query, "all", "", engine.DefaultSearchEngine)
+		engine.GoOutbound(ctx, func() {
+			r, err := engine.SearchSearXNG(ctx, q, "all", "", engine.DefaultSearchEngine)
 			ch <- searchRes{r, err}
-		}(q)
+		})
 	}
 
 	var allSnippets []string
@@ -101,6 +105,7 @@ func ResearchSalary(ctx context.Context, role, location, experience string) (*Sa
 	if err := json.Unmarshal([]byte(raw), &result); err != nil {
 		return nil, fmt.Errorf("salary_research parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
 	}
+	result.Anecdotal = <-blindCh
 	return &result, nil
 }
 
@@ -145,17 +150,32 @@ func isRussianLocation(location string) bool {
 
 // CompanyResearchResult is the structured output of company_research.
 type CompanyResearchResult struct {
-	Name        string   `json:"name"`
-	Size        string   `json:"size"`
-	Founded     string   `json:"founded"`
-	Industry    string   `json:"industry"`
-	Funding     string   `json:"funding"`
-	TechStack   []string `json:"tech_stack"`
-	CultureNotes string  `json:"culture_notes"`
-	RecentNews  []string `json:"recent_news"`
-	GlassdoorRating float64 `json:"glassdoor_rating"`
-	Website     string   `json:"website"`
-	Summary     string   `json:"summary"`
+	Name            string   `json:"name"`
+	Size            string   `json:"size"`
+	Founded         string   `json:"founded"`
+	Headquarters    string   `json:"headquarters"`
+	Industry        string   `json:"industry"`
+	Funding         string   `json:"funding"`
+	TechStack       []string `json:"tech_stack"`
+	CultureNotes    string   `json:"culture_notes"`
+	RecentNews      []string `json:"recent_news"`
+	GlassdoorRating float64  `json:"glassdoor_rating"`
+	Website         string   `json:"website"`
+	Summary         string   `json:"summary"`
+
+	// From the seen-jobs corpus: skills across the company's recorded postings.
+	PostingStack     []StackSkill `json:"posting_stack,omitempty"`
+	PostingsAnalyzed int          `json:"postings_analyzed,omitempty"`
+
+	// Employer risk: reported layoffs and hiring freezes, and hiring velocity from the
+	// seen-jobs corpus.
+	Layoffs        *LayoffSignal   `json:"layoffs,omitempty"`
+	HiringVelocity *HiringVelocity `json:"hiring_velocity,omitempty"`
+	EmployerRisk   string          `json:"employer_risk,omitempty"` // "low", "medium", "high"
+	RiskSignals    []string        `json:"risk_signals,omitempty"`
+
+	// Blind holds anonymous employee sentiment and comp datapoints (anecdotal).
+	Blind *BlindSignals `json:"blind,omitempty"`
 }
 
 const companyResearchPrompt = `You are a company research analyst. Based on the search results below, provide a comprehensive company overview.
@@ -170,6 +190,7 @@ Return a JSON object with this exact structure:
   "name": "<official company name>",
   "size": "<employee count or range, e.g. '1000-5000', '50-200', 'startup <50'>",
   "founded": "<founding year or decade>",
+  "headquarters": "<headquarters city and country>",
   "industry": "<primary industry>",
   "funding": "<funding stage and amount if known, e.g. 'Series B, $50M' or 'Public (NASDAQ: XYZ)'>",
   "tech_stack": [<technologies the company uses, from job postings or engineering blog>],
@@ -182,13 +203,28 @@ Return a JSON object with this exact structure:
 
 Return ONLY the JSON object, no markdown, no explanation.`
 
-// ResearchCompany fetches company overview from multiple sources via SearXNG + LLM.
+// ResearchCompany returns the company overview from the company store when it was
+// researched within the last 30 days, otherwise researches it afresh.
 func ResearchCompany(ctx context.Context, companyName string) (*CompanyResearchResult, error) {
+	if res := storedCompanyResearch(ctx, companyName); res != nil {
+		attachCorpusSignals(ctx, companyName, res)
+		return res, nil
+	}
+	return RefreshCompanyResearch(ctx, companyName)
+}
+
+// RefreshCompanyResearch fetches company overview from multiple sources via SearXNG + LLM
+// and records it in the company store.
+func RefreshCompanyResearch(ctx context.Context, companyName string) (*CompanyResearchResult, error) {
 	queries := []string{
 		companyName + " company overview employees funding tech stack",
 		companyName + " reviews culture glassdoor work life balance",
 		companyName + " news 2024 2025 site:techcrunch.com OR site:crunchbase.com OR site:linkedin.com",
 	}
+	layoffsCh := make(chan *LayoffSignal, 1)
+	go func() { layoffsCh <- ResearchLayoffs(ctx, companyName) }()
+	blindCh := make(chan *BlindSignals, 1)
+	go func() { blindCh <- BlindCompanySignals(ctx, companyName) }()
 
 	type searchRes struct {
 		results []engine.SearxngResult
@@ -196,10 +232,10 @@ func ResearchCompany(ctx context.Context, companyName string) (*CompanyResearchR
 	}
 	ch := make(chan searchRes, len(queries))
 	for _, q := range queries {
-		go func(query string) {
-			r, err := engine.SearchSearXNG(ctx, query, "all", "", engine.DefaultSearchEngine)
+		engine.GoOutbound(ctx, func() {
+			r, err := engine.SearchSearXNG(ctx, q, "all", "", engine.DefaultSearchEngine)
 			ch <- searchRes{r, err}
-		}(q)
+		})
 	}
 
 	var allSnippets []string
@@ -238,6 +274,10 @@ func ResearchCompany(ctx context.Context, companyName string) (*CompanyResearchR
 	if err := json.Unmarshal([]byte(raw), &result); err != nil {
 		return nil, fmt.Errorf("company_research parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
 	}
+	result.Layoffs = <-layoffsCh
+	result.Blind = <-blindCh
+	attachCorpusSignals(ctx, companyName, &result)
+	recordCompanyResearch(ctx, companyName, &result)
 	return &result, nil
 }
 
@@ -251,9 +291,19 @@ func BuildCompanyContext(company string, res *CompanyResearchResult) string {
 	if len(res.TechStack) > 0 {
 		parts = append(parts, "Tech stack: "+strings.Join(res.TechStack, ", "))
 	}
+	if len(res.PostingStack) > 0 {
+		var skills []string
+		for _, s := range res.PostingStack {
+			skills = append(skills, fmt.Sprintf("%s (%.0f%%)", s.Skill, s.Share*100))
+		}
+		parts = append(parts, fmt.Sprintf("Skills across %d job postings: %s", res.PostingsAnalyzed, strings.Join(skills, ", ")))
+	}
 	if res.CultureNotes != "" {
 		parts = append(parts, "Culture: "+res.CultureNotes)
 	}
+	if b := res.Blind; b != nil && (b.Positive > 0 || b.Negative > 0) {
+		parts = append(parts, fmt.Sprintf("Blind employee sentiment (anecdotal): %s, %d positive / %d negative posts", b.Sentiment, b.Positive, b.Negative))
+	}
 	if len(res.RecentNews) > 0 {
 		parts = append(parts, "Recent news: "+strings.Join(res.RecentNews, "; "))
 	}
@@ -1,8 +1,12 @@
 package jobs
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
 )
 
 // --- isRussianLocation ---
@@ -116,3 +120,249 @@ func TestBuildSalaryQueries_RussianLocation_Variants(t *testing.T) {
 		}
 	}
 }
+
+// --- cert_recommend ---
+
+func TestCertTargetJobs(t *testing.T) {
+	listings := []engine.JobListing{
+		{Title: "Platform Engineer", Company: "Acme", Skills: []string{"Kubernetes", "Go"}},
+		{Title: "Kubernetes Admin", Company: "Globex"},
+		{Title: "Frontend Engineer", Company: "Initech", Skills: []string{"React"}},
+	}
+	got := certTargetJobs("kubernetes", "We run everything on Kubernetes.", listings)
+	want := []string{"target job description", "Platform Engineer at Acme", "Kubernetes Admin at Globex"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("certTargetJobs = %v, want %v", got, want)
+	}
+	if got := certTargetJobs("Terraform", "", listings); len(got) != 0 {
+		t.Errorf("unexpected targets %v", got)
+	}
+}
+
+func TestCertGapSkills_Explicit(t *testing.T) {
+	gaps, err := certGapSkills(context.Background(), engine.CertRecommendInput{Skills: []string{" AWS ", "", "Terraform", "Kafka"}}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gaps) != 2 || gaps[0].Skill != "AWS" || gaps[1].Skill != "Terraform" {
+		t.Errorf("gaps = %+v", gaps)
+	}
+	if certPriority("critical") >= certPriority("medium") || certPriority("") <= certPriority("medium") {
+		t.Error("priority order wrong")
+	}
+}
+
+// --- CompareIncome ---
+
+func TestCompareIncome(t *testing.T) {
+	// $100/h over 46 paid weeks = 184,000; net 184,000 × 0.95 × 0.7 = 122,360;
+	// salary with the same net value = 122,360 / (1 − 0.25 + 0.2) = 128,800.
+	r, err := CompareIncome(engine.IncomeCompareInput{Amount: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.From.Annual != 184000 || r.Equivalent.Annual != 128800 || r.Equivalent.Type != "salary" {
+		t.Errorf("contract→salary = %+v → %+v", r.From, r.Equivalent)
+	}
+
+	back, err := CompareIncome(engine.IncomeCompareInput{Amount: 128800, Type: "salary"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Equivalent.Hourly != 100 {
+		t.Errorf("salary→contract hourly = %v, want 100", back.Equivalent.Hourly)
+	}
+
+	rub, err := CompareIncome(engine.IncomeCompareInput{Amount: 100, TargetCurrency: "rub"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rub.Equivalent.Currency != "RUB" || rub.Equivalent.Annual != 128800*90 {
+		t.Errorf("converted = %+v", rub.Equivalent)
+	}
+
+	for _, bad := range []engine.IncomeCompareInput{
+		{Amount: 0},
+		{Amount: 100, Unit: "week"},
+		{Amount: 100, Type: "equity"},
+		{Amount: 100, ContractTaxRate: 30},
+		{Amount: 100, Currency: "XYZ", TargetCurrency: "USD"},
+	} {
+		if _, err := CompareIncome(bad); err == nil {
+			t.Errorf("CompareIncome(%+v) should fail", bad)
+		}
+	}
+}
+
+func TestExtractLayoffSignalAndRisk(t *testing.T) {
+	results := []engine.SearxngResult{
+		{Title: "Acme lays off 1,200 employees", URL: "https://news.example/a",
+			Content: "In March 2026 Acme cut 12% of its workforce amid restructuring."},
+		{Title: "Acme layoffs tracker", URL: "https://layoffs.fyi/acme",
+			Content: "Acme laid off 300 people in Nov 2023."},
+		{Title: "Globex announces layoffs", URL: "https://news.example/b", Content: "Globex cut 500 jobs."},
+		{Title: "Acme pauses hiring", URL: "https://news.example/c", Content: "Acme pauses hiring for the rest of the year."},
+	}
+	sig := extractLayoffSignal("Acme", results)
+	if sig == nil || !sig.HiringFreeze || len(sig.Events) != 2 {
+		t.Fatalf("signal = %+v", sig)
+	}
+	if ev := sig.Events[0]; ev.Date != "2026-03" || ev.Count != 1200 || ev.Percent != 12 {
+		t.Errorf("latest event = %+v", ev)
+	}
+	if extractLayoffSignal("Initech", results) != nil {
+		t.Error("expected no signal for a company not mentioned")
+	}
+
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	if risk, signals := assessEmployerRisk(sig, nil, now); risk != EmployerRiskHigh || len(signals) != 2 {
+		t.Errorf("risk = %s %v", risk, signals)
+	}
+	old := &LayoffSignal{Events: []LayoffEvent{{Date: "2025-01", Count: 50}}}
+	if risk, _ := assessEmployerRisk(old, nil, now); risk != EmployerRiskMedium {
+		t.Errorf("older layoff risk = %s", risk)
+	}
+	if risk, signals := assessEmployerRisk(nil, &HiringVelocity{Last30: 1, Prev30: 6, Trend: "slowing"}, now); risk != EmployerRiskMedium || len(signals) != 1 {
+		t.Errorf("slowing hiring risk = %s %v", risk, signals)
+	}
+	if risk, _ := assessEmployerRisk(nil, &HiringVelocity{Last30: 5, Prev30: 4, Trend: "steady"}, now); risk != EmployerRiskLow {
+		t.Errorf("steady hiring risk = %s", risk)
+	}
+}
+
+func TestBuildCompanyNews(t *testing.T) {
+	results := []engine.SearxngResult{
+		{Title: "Acme raises $50M Series B", URL: "https://www.techcrunch.com/acme-b", Content: "Published Feb 3, 2026. Acme closed a funding round."},
+		{Title: "Acme sued over data practices", URL: "https://news.example/suit", Content: "2026-08-14 — a class action was filed against Acme."},
+		{Title: "Acme launches new analytics product", URL: "https://news.example/launch", Content: "Acme unveils Insights."},
+		{Title: "Globex news", URL: "https://news.example/globex", Content: "Nothing about the other company."},
+		{Title: "Acme raises $50M Series B", URL: "https://www.techcrunch.com/acme-b", Content: "duplicate"},
+	}
+	news := buildCompanyNews("Acme", results)
+	if len(news.Items) != 3 {
+		t.Fatalf("items = %+v", news.Items)
+	}
+	first := news.Items[0]
+	if first.Date != "2026-08-14" || first.Category != NewsLawsuit || first.Sentiment != SentimentNegative {
+		t.Errorf("newest item = %+v", first)
+	}
+	if second := news.Items[1]; second.Date != "2026-02" || second.Category != NewsFunding || second.Source != "techcrunch.com" {
+		t.Errorf("second item = %+v", second)
+	}
+	if last := news.Items[2]; last.Date != "" || last.Category != NewsProduct {
+		t.Errorf("undated item = %+v", last)
+	}
+	if news.Sentiment != "mixed" || news.Counts[NewsFunding] != 1 {
+		t.Errorf("sentiment = %s, counts = %v", news.Sentiment, news.Counts)
+	}
+
+	flags := newsRedFlags(news, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	if len(flags) != 1 || flags[0].Category != "company_lawsuit" || flags[0].Source != "news" {
+		t.Errorf("flags = %+v", flags)
+	}
+}
+
+func TestExtractInterviewReport(t *testing.T) {
+	text := `Acme Software Engineer Interview Questions
+Difficult Interview
+I applied online. The process took 4 weeks. There was a recruiter call, then a take-home assignment and a final round with the hiring manager.
+Interview Questions
+How would you design a rate limiter for a public API?
+Tell me about a time you disagreed with your manager?
+Was this interview helpful?
+Sign in to see more?`
+	r := engine.SearxngResult{Title: "Acme interview", URL: "https://www.glassdoor.com/Interview/acme"}
+	rep := extractInterviewReport("glassdoor", r, text)
+	if rep.Difficulty != "difficult" {
+		t.Errorf("difficulty = %q", rep.Difficulty)
+	}
+	if len(rep.Process) != 1 || !strings.Contains(rep.Process[0], "take-home") {
+		t.Errorf("process = %q", rep.Process)
+	}
+	if len(rep.Questions) != 2 || !strings.HasPrefix(rep.Questions[0], "How would you design") {
+		t.Errorf("questions = %q", rep.Questions)
+	}
+
+	exp := aggregateInterviewReports("Acme", "", []InterviewReport{rep, {Source: "blind", URL: "https://teamblind.com/x"}})
+	if len(exp.Reports) != 1 || len(exp.Questions) != 2 {
+		t.Errorf("aggregate = %+v", exp)
+	}
+	if ctx := BuildInterviewExperienceContext(exp); !strings.Contains(ctx, "rate limiter") {
+		t.Errorf("context = %q", ctx)
+	}
+	if got := jdRoleHint("## Senior Go Engineer\nWe are hiring."); got != "Senior Go Engineer" {
+		t.Errorf("role hint = %q", got)
+	}
+}
+
+func TestParseBlindResults(t *testing.T) {
+	results := []engine.SearxngResult{
+		{Title: "Acme offer", URL: "https://www.teamblind.com/post/acme-offer-1", Content: "Got an offer from Acme, L5, TC: 320k, base 190k. Great WLB so far."},
+		{Title: "Acme layoffs again", URL: "https://www.teamblind.com/post/acme-2", Content: "Toxic management, people burned out after the layoffs."},
+		{Title: "Acme careers", URL: "https://acme.example/careers", Content: "Not Blind."},
+	}
+	posts := parseBlindResults(results)
+	if len(posts) != 2 {
+		t.Fatalf("posts = %+v", posts)
+	}
+	c := posts[0].Comp
+	if c == nil || c.TotalComp != 320000 || c.Base != 190000 || c.Level != "L5" {
+		t.Errorf("comp = %+v", c)
+	}
+	if posts[0].Sentiment != SentimentPositive || posts[1].Sentiment != SentimentNegative {
+		t.Errorf("sentiments = %s, %s", posts[0].Sentiment, posts[1].Sentiment)
+	}
+	s := summarizeBlind(posts)
+	if !s.Anecdotal || s.Sentiment != "mixed" || s.MedianTC != 320000 || len(s.CompDatapoints) != 1 {
+		t.Errorf("signals = %+v", s)
+	}
+}
+
+func TestStarStoryHelpers(t *testing.T) {
+	if got := normalizeCompetencies([]string{"Leadership", "customer focus", "heroics", "leadership"}); strings.Join(got, ",") != "leadership,customer_focus" {
+		t.Errorf("normalizeCompetencies = %q", got)
+	}
+	exps := []ExperienceRecord{{ID: 1, Title: "Backend Engineer", Company: "Acme"}, {ID: 2, Title: "Tech Lead", Company: "Globex"}}
+	if exp, ok := matchAchievementExperience(AchievementRecord{Context: "Tech Lead at Globex, 2022"}, exps); !ok || exp.ID != 2 {
+		t.Errorf("match = %+v %v", exp, ok)
+	}
+	if _, ok := matchAchievementExperience(AchievementRecord{Context: "side project"}, exps); ok {
+		t.Error("expected no match")
+	}
+	cov := starCoverage([]StarStoryRecord{{Competencies: []string{"leadership", "scale"}}, {Competencies: []string{"leadership"}}})
+	if cov["leadership"] != 2 || cov["scale"] != 1 || cov["failure"] != 0 || len(cov) != len(StarCompetencies) {
+		t.Errorf("coverage = %v", cov)
+	}
+}
+
+func TestStandardCompetencyCoverage(t *testing.T) {
+	stories := []StarStoryRecord{
+		{ID: 1, Title: "Migration", Competencies: []string{"leadership", "scale"}},
+		{ID: 2, Title: "Outage", Competencies: []string{"failure", "leadership"}},
+	}
+	res := standardCoverage(stories)
+	if len(res.Matrix) != len(StarCompetencies) || res.Stories != 2 {
+		t.Fatalf("matrix = %+v", res.Matrix)
+	}
+	byName := map[string]CompetencyRow{}
+	for _, r := range res.Matrix {
+		byName[r.Competency] = r
+	}
+	if r := byName["leadership"]; r.Strength != "good" || len(r.Stories) != 2 || r.EnrichmentQuestions != nil {
+		t.Errorf("leadership = %+v", r)
+	}
+	if r := byName["scale"]; r.Strength != "thin" {
+		t.Errorf("scale = %+v", r)
+	}
+	if r := byName["conflict"]; r.Strength != "none" || len(r.EnrichmentQuestions) == 0 {
+		t.Errorf("conflict = %+v", r)
+	}
+	if len(res.Gaps) != len(StarCompetencies)-3 || res.Coverage != 0.25 {
+		t.Errorf("gaps = %v, coverage = %v", res.Gaps, res.Coverage)
+	}
+
+	company := buildCoverage("Acme", []CompetencyRow{{Competency: "Bias for Action", StoryIDs: []int{2, 99}, EnrichmentQuestions: []string{"x"}}}, stories)
+	if r := company.Matrix[0]; len(r.StoryIDs) != 1 || r.Stories[0] != "Outage" || r.EnrichmentQuestions != nil {
+		t.Errorf("company row = %+v", r)
+	}
+}
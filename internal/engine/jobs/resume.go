@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/anatolykoptev/go_job/internal/engine"
 )
@@ -16,12 +18,15 @@ const ToneProfessional = "professional"
 
 // ResumeAnalysisResult is the structured output of resume_analyze.
 type ResumeAnalysisResult struct {
-	ATSScore         int      `json:"ats_score"`
-	MatchingKeywords []string `json:"matching_keywords"`
-	MissingKeywords  []string `json:"missing_keywords"`
-	Gaps             []string `json:"gaps"`
-	Recommendations  []string `json:"recommendations"`
-	Summary          string   `json:"summary"`
+	ATSScore         int         `json:"ats_score"`
+	MatchingKeywords []string    `json:"matching_keywords"`
+	MissingKeywords  []string    `json:"missing_keywords"`
+	Gaps             []string    `json:"gaps"`
+	Recommendations  []string    `json:"recommendations"`
+	Summary          string      `json:"summary"`
+	Lint             *ResumeLint `json:"lint"` // deterministic readability checks of the resume
+	// Must-have vs nice-to-have scoring; nil when the JD extraction failed.
+	Requirements *RequirementsFit `json:"requirements,omitempty"`
 }
 
 const resumeAnalyzePrompt = `You are an expert ATS (Applicant Tracking System) resume analyst.
@@ -45,7 +50,18 @@ JOB DESCRIPTION:
 Return ONLY the JSON object, no markdown, no explanation.`
 
 // AnalyzeResume compares a resume against a job description and returns ATS analysis.
+// The JD requirements are extracted alongside and scored deterministically, hard
+// blockers apart from soft gaps.
 func AnalyzeResume(ctx context.Context, resumeText, jobDescription string) (*ResumeAnalysisResult, error) {
+	jdCh := make(chan *jdRequirements, 1)
+	go func() {
+		jd, err := extractJDRequirements(ctx, jobDescription, "resume_analyze")
+		if err != nil {
+			slog.Debug("resume_analyze: JD requirements", slog.Any("error", err))
+		}
+		jdCh <- jd
+	}()
+
 	resumeTrunc := engine.TruncateRunes(resumeText, 4000, "")
 	jdTrunc := engine.TruncateRunes(jobDescription, 3000, "")
 
@@ -65,6 +81,14 @@ func AnalyzeResume(ctx context.Context, resumeText, jobDescription string) (*Res
 	if err := json.Unmarshal([]byte(raw), &result); err != nil {
 		return nil, fmt.Errorf("resume_analyze parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
 	}
+	result.Lint = LintResume(resumeText)
+	if jd := <-jdCh; jd != nil {
+		years, _ := MasterExperienceYears(ctx) // nil without a master resume
+		result.Requirements = scoreRequirements(jd, resumeText, years, time.Now())
+		if n := len(result.Requirements.HardBlockers); n > 0 {
+			result.Summary += fmt.Sprintf(" Hard blockers (%d unmet must-haves): %s.", n, strings.Join(result.Requirements.HardBlockers, "; "))
+		}
+	}
 	return &result, nil
 }
 
@@ -72,9 +96,11 @@ func AnalyzeResume(ctx context.Context, resumeText, jobDescription string) (*Res
 
 // CoverLetterResult is the structured output of cover_letter_generate.
 type CoverLetterResult struct {
-	CoverLetter string `json:"cover_letter"`
-	WordCount   int    `json:"word_count"`
-	Tone        string `json:"tone"`
+	CoverLetter      string            `json:"cover_letter"`
+	WordCount        int               `json:"word_count"`
+	Tone             string            `json:"tone"`
+	CompanyReference *CompanyReference `json:"company_reference,omitempty"`
+	CompanyNote      string            `json:"company_note,omitempty"` // why the letter has no company reference
 }
 
 const coverLetterPrompt = `You are an expert career coach and professional writer.
@@ -95,12 +121,15 @@ RESUME:
 
 JOB DESCRIPTION:
 %s
+%s%s`
 
+const coverLetterTextFormat = `
 Return ONLY the cover letter text, no JSON, no markdown headers.`
 
 // GenerateCoverLetter creates a tailored cover letter from resume and job description.
-// tone: "professional" (default), "friendly", "concise"
-func GenerateCoverLetter(ctx context.Context, resumeText, jobDescription, tone string) (*CoverLetterResult, error) {
+// tone: "professional" (default), "friendly", "concise". With a company, the letter must
+// reference a fact from its cached company_research (see coverLetterCompanyFacts).
+func GenerateCoverLetter(ctx context.Context, resumeText, jobDescription, tone, company string) (*CoverLetterResult, error) {
 	if tone == "" {
 		tone = ToneProfessional
 	}
@@ -112,7 +141,15 @@ func GenerateCoverLetter(ctx context.Context, resumeText, jobDescription, tone s
 	resumeTrunc := engine.TruncateRunes(resumeText, 3000, "")
 	jdTrunc := engine.TruncateRunes(jobDescription, 2000, "")
 
-	prompt := fmt.Sprintf(coverLetterPrompt, tone, resumeTrunc, jdTrunc)
+	availability := ""
+	if a := LoadProfile().AvailabilityText(time.Now()); a != "" {
+		availability = "\nAVAILABILITY: " + a + "\nState it in one sentence of the closing paragraph, especially if the JD asks for a start date.\n"
+	}
+
+	if company != "" {
+		return generateCompanyCoverLetter(ctx, resumeTrunc, jdTrunc, tone, availability, company)
+	}
+	prompt := fmt.Sprintf(coverLetterPrompt, tone, resumeTrunc, jdTrunc, availability, coverLetterTextFormat)
 	raw, err := engine.CallLLM(ctx, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("cover_letter_generate LLM: %w", err)
@@ -137,6 +174,7 @@ type ResumeTailorResult struct {
 	RemovedKeywords  []string          `json:"removed_keywords"`
 	DiffSummary      string            `json:"diff_summary"`
 	TailoredResume   string            `json:"tailored_resume"`
+	Lint             *ResumeLint       `json:"lint"` // deterministic readability checks of the tailored resume
 }
 
 const resumeTailorPrompt = `You are an expert resume writer and ATS optimization specialist.
@@ -187,5 +225,6 @@ func TailorResume(ctx context.Context, resumeText, jobDescription string) (*Resu
 	if err := json.Unmarshal([]byte(raw), &result); err != nil {
 		return nil, fmt.Errorf("resume_tailor parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
 	}
+	result.Lint = LintResume(result.TailoredResume)
 	return &result, nil
 }
@@ -0,0 +1,241 @@
+package jobs
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Experience dates ---
+
+// Experience start_date/end_date are free strings as the resume wrote them: "2019",
+// "2019-03", "03/2019", "Mar 2019", "весна 2019", "Present", "по настоящее время".
+// ParseResumeDate reads them into a date with a precision; the build stores the
+// parsed dates (start_on/end_on) next to the originals for sorting, and durations,
+// gaps and skill recency are computed from them.
+
+// Precisions of a ResumeDate.
+const (
+	DatePrecisionDay     = "day"
+	DatePrecisionMonth   = "month"
+	DatePrecisionSeason  = "season"
+	DatePrecisionYear    = "year"
+	DatePrecisionPresent = "present" // an ongoing role
+)
+
+// ResumeDate is a parsed resume date: the first day of the period it names.
+type ResumeDate struct {
+	Date      time.Time // zero for present
+	Precision string
+}
+
+// IsPresent reports whether the date means "until now".
+func (d ResumeDate) IsPresent() bool { return d.Precision == DatePrecisionPresent }
+
+// Last returns the last day of the period the date names: the end of the year for
+// "2019", of the month for "2019-03", now for present.
+func (d ResumeDate) Last(now time.Time) time.Time {
+	switch d.Precision {
+	case DatePrecisionPresent:
+		return now
+	case DatePrecisionYear:
+		return d.Date.AddDate(1, 0, -1)
+	case DatePrecisionSeason:
+		return d.Date.AddDate(0, 3, -1)
+	case DatePrecisionMonth:
+		return d.Date.AddDate(0, 1, -1)
+	}
+	return d.Date
+}
+
+// String formats the date at its precision: "2019", "2019-03", "2019-03-05", "present".
+// A season formats as its first month.
+func (d ResumeDate) String() string {
+	switch d.Precision {
+	case DatePrecisionPresent:
+		return DatePrecisionPresent
+	case DatePrecisionYear:
+		return d.Date.Format("2006")
+	case DatePrecisionDay:
+		return d.Date.Format(time.DateOnly)
+	}
+	return d.Date.Format("2006-01")
+}
+
+var (
+	resumeDateNumericRe = regexp.MustCompile(`^(\d{4})(?:[-/.](\d{1,2}))?(?:[-/.](\d{1,2}))?$`)
+	resumeDateMonthYrRe = regexp.MustCompile(`^(?:(\d{1,2})[./])?(\d{1,2})[-/.](\d{4})$`) // 03/2019, 05.03.2019
+	resumeDateNamedRe   = regexp.MustCompile(`^([\p{L}]+)\.?,?\s+(\d{4})$`)
+	resumeDatePresentRe = regexp.MustCompile(`^(?:present|current(?:ly)?|now|today|ongoing|to date|till now|сейчас|(?:по )?(?:настоящее время|наст\. время|н\. ?в\.?))$`)
+)
+
+// resumeMonths maps month-name prefixes (English and Russian, three letters) to months.
+var resumeMonths = map[string]time.Month{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	"янв": 1, "фев": 2, "мар": 3, "апр": 4, "май": 5, "мая": 5, "июн": 6,
+	"июл": 7, "авг": 8, "сен": 9, "окт": 10, "ноя": 11, "дек": 12,
+}
+
+// resumeSeasons maps season names to their first month; winter 2019 starts in
+// December 2018.
+var resumeSeasons = map[string]time.Month{
+	"spring": 3, "summer": 6, "autumn": 9, "fall": 9, "winter": 12,
+	"весна": 3, "весной": 3, "лето": 6, "летом": 6, "осень": 9, "осенью": 9, "зима": 12, "зимой": 12,
+}
+
+// ParseResumeDate reads a free-form resume date. An empty string is not a date;
+// callers decide whether a missing end date means present.
+func ParseResumeDate(s string) (ResumeDate, bool) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), " "))
+	if s == "" {
+		return ResumeDate{}, false
+	}
+	if resumeDatePresentRe.MatchString(s) {
+		return ResumeDate{Precision: DatePrecisionPresent}, true
+	}
+	date := func(year, month, day int, precision string) (ResumeDate, bool) {
+		if month < 1 || month > 12 || day < 1 || day > 31 || year < 1950 || year > 2100 {
+			return ResumeDate{}, false
+		}
+		t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if t.Day() != day {
+			return ResumeDate{}, false // e.g. February 30
+		}
+		return ResumeDate{Date: t, Precision: precision}, true
+	}
+	if m := resumeDateNumericRe.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		switch {
+		case m[3] != "":
+			month, _ := strconv.Atoi(m[2])
+			day, _ := strconv.Atoi(m[3])
+			return date(year, month, day, DatePrecisionDay)
+		case m[2] != "":
+			month, _ := strconv.Atoi(m[2])
+			return date(year, month, 1, DatePrecisionMonth)
+		}
+		return date(year, 1, 1, DatePrecisionYear)
+	}
+	if m := resumeDateMonthYrRe.FindStringSubmatch(s); m != nil {
+		month, _ := strconv.Atoi(m[2])
+		year, _ := strconv.Atoi(m[3])
+		if m[1] != "" {
+			day, _ := strconv.Atoi(m[1])
+			return date(year, month, day, DatePrecisionDay)
+		}
+		return date(year, month, 1, DatePrecisionMonth)
+	}
+	if m := resumeDateNamedRe.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[2])
+		if month, ok := resumeSeasons[m[1]]; ok {
+			if month == 12 {
+				year-- // "winter 2019" is Dec 2018 – Feb 2019
+			}
+			return date(year, int(month), 1, DatePrecisionSeason)
+		}
+		if r := []rune(m[1]); len(r) >= 3 {
+			if month, ok := resumeMonths[string(r[:3])]; ok {
+				return date(year, int(month), 1, DatePrecisionMonth)
+			}
+		}
+	}
+	return ResumeDate{}, false
+}
+
+// parseExperienceDates parses and validates the dates of an experience. A missing
+// end date means the role is ongoing.
+func parseExperienceDates(e ExperienceRecord, now time.Time) (start, end ResumeDate, err error) {
+	start, ok := ParseResumeDate(e.StartDate)
+	if !ok || start.IsPresent() {
+		return start, end, fmt.Errorf("unreadable start date %q", e.StartDate)
+	}
+	if strings.TrimSpace(e.EndDate) == "" {
+		end = ResumeDate{Precision: DatePrecisionPresent}
+	} else if end, ok = ParseResumeDate(e.EndDate); !ok {
+		return start, end, fmt.Errorf("unreadable end date %q", e.EndDate)
+	}
+	switch {
+	case start.Date.After(now):
+		return start, end, fmt.Errorf("starts in the future (%s)", start)
+	case !end.IsPresent() && end.Last(now).Before(start.Date):
+		return start, end, fmt.Errorf("ends (%s) before it starts (%s)", end, start)
+	}
+	return start, end, nil
+}
+
+// experienceDateIssues lists the experiences whose dates cannot be read or do not
+// make sense, for the build warnings.
+func experienceDateIssues(exps []ExperienceRecord, now time.Time) []string {
+	var issues []string
+	for _, e := range exps {
+		if _, _, err := parseExperienceDates(e, now); err != nil {
+			issues = append(issues, fmt.Sprintf("experience %q at %s: %v", e.Title, e.Company, err))
+		}
+	}
+	return issues
+}
+
+// EmploymentGap is a period between roles.
+type EmploymentGap struct {
+	From   string `json:"from"` // YYYY-MM, first month without a role
+	To     string `json:"to"`   // YYYY-MM, last month without a role, or "present"
+	Months int    `json:"months"`
+}
+
+// experienceGaps finds the periods of at least minMonths months not covered by any
+// experience with valid dates, including the time since the last role ended.
+func experienceGaps(exps []ExperienceRecord, now time.Time, minMonths int) []EmploymentGap {
+	type span struct{ from, to int } // month indexes, inclusive
+	var spans []span
+	for _, e := range exps {
+		start, end, err := parseExperienceDates(e, now)
+		if err != nil {
+			continue
+		}
+		spans = append(spans, span{monthIndex(start.Date), monthIndex(experienceLast(end, now))})
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].from < spans[j].from })
+
+	var gaps []EmploymentGap
+	addGap := func(from, to int, present bool) {
+		if n := to - from + 1; n >= minMonths {
+			g := EmploymentGap{From: monthString(from), To: monthString(to), Months: n}
+			if present {
+				g.To = DatePrecisionPresent
+			}
+			gaps = append(gaps, g)
+		}
+	}
+	covered := spans[0].to
+	for _, s := range spans[1:] {
+		if s.from > covered+1 {
+			addGap(covered+1, s.from-1, false)
+		}
+		covered = max(covered, s.to)
+	}
+	if nowIdx := monthIndex(now); covered < nowIdx {
+		addGap(covered+1, nowIdx, true)
+	}
+	return gaps
+}
+
+// experienceLast is the last day of a role: the end of its end date's period, but
+// not later than now.
+func experienceLast(end ResumeDate, now time.Time) time.Time {
+	if last := end.Last(now); last.Before(now) {
+		return last
+	}
+	return now
+}
+
+// monthIndex numbers months as year*12+month-1.
+func monthIndex(t time.Time) int { return t.Year()*12 + int(t.Month()) - 1 }
+
+func monthString(idx int) string { return fmt.Sprintf("%04d-%02d", idx/12, idx%12+1) }
@@ -13,7 +13,8 @@ import (
 
 // ResumeEnrichResult is the structured output of resume_enrich.
 type ResumeEnrichResult struct {
-	Status    string           `json:"status"` // "questions", "complete"
+	JobID     string           `json:"job_id,omitempty"` // set when started with async=true
+	Status    string           `json:"status"`           // "questions", "complete", "started"
 	Questions []EnrichQuestion `json:"questions,omitempty"`
 	Applied   int              `json:"applied,omitempty"`
 	Summary   string           `json:"summary"`
@@ -103,7 +104,7 @@ Return ONLY the JSON object, no markdown, no explanation.`
 
 // EnrichResume handles the interactive enrichment flow.
 func EnrichResume(ctx context.Context, action string, answers []AnswerPair) (*ResumeEnrichResult, error) {
-	db := GetResumeDB()
+	db := resumeStore(ctx)
 	if db == nil {
 		return nil, errors.New("resume database not configured (set DATABASE_URL)")
 	}
@@ -123,13 +124,16 @@ func EnrichResume(ctx context.Context, action string, answers []AnswerPair) (*Re
 	}
 }
 
-// AnswerPair holds a question ID and its answer.
+// AnswerPair holds a question ID and its answer. Question and Context are set for
+// questions raised outside action=start (resume_generate metric questions).
 type AnswerPair struct {
 	QuestionID string `json:"question_id"`
 	Answer     string `json:"answer"`
+	Question   string `json:"question,omitempty"`
+	Context    string `json:"context,omitempty"`
 }
 
-func enrichStart(ctx context.Context, db *ResumeDB, personID int) (*ResumeEnrichResult, error) {
+func enrichStart(ctx context.Context, db ResumeStore, personID int) (*ResumeEnrichResult, error) {
 	// Load current data
 	dataStr := buildCurrentDataString(ctx, db, personID)
 
@@ -155,7 +159,7 @@ func enrichStart(ctx context.Context, db *ResumeDB, personID int) (*ResumeEnrich
 	}, nil
 }
 
-func enrichAnswer(ctx context.Context, db *ResumeDB, personID int, answers []AnswerPair) (*ResumeEnrichResult, error) {
+func enrichAnswer(ctx context.Context, db ResumeStore, personID int, answers []AnswerPair) (*ResumeEnrichResult, error) {
 	if len(answers) == 0 {
 		return nil, errors.New("no answers provided")
 	}
@@ -166,7 +170,15 @@ func enrichAnswer(ctx context.Context, db *ResumeDB, personID int, answers []Ans
 	// Format Q&A
 	var qaStr strings.Builder
 	for _, a := range answers {
-		fmt.Fprintf(&qaStr, "Question %s: %s\n", a.QuestionID, a.Answer)
+		if a.Question == "" {
+			fmt.Fprintf(&qaStr, "Question %s: %s\n", a.QuestionID, a.Answer)
+			continue
+		}
+		fmt.Fprintf(&qaStr, "Question %s: %s\n", a.QuestionID, a.Question)
+		if a.Context != "" {
+			fmt.Fprintf(&qaStr, "Context: %s\n", a.Context)
+		}
+		fmt.Fprintf(&qaStr, "Answer: %s\n", a.Answer)
 	}
 
 	prompt := fmt.Sprintf(enrichApplyPrompt,
@@ -188,7 +200,7 @@ func enrichAnswer(ctx context.Context, db *ResumeDB, personID int, answers []Ans
 	}
 
 	applied := 0
-	mdb := GetMemDB()
+	mdb := vectorStore(ctx)
 
 	for _, updateRaw := range parsed.Updates {
 		var base struct {
@@ -338,24 +350,21 @@ func enrichAnswer(ctx context.Context, db *ResumeDB, personID int, answers []Ans
 }
 
 // updateAchievementMetrics updates metric fields on an achievement.
-func updateAchievementMetrics(ctx context.Context, db *ResumeDB, achvID int, metricNumeric *float64, metricUnit, newText string) {
+func updateAchievementMetrics(ctx context.Context, db ResumeStore, achvID int, metricNumeric *float64, metricUnit, newText string) {
 	if newText != "" {
-		if _, err := db.pool.Exec(ctx,
-			`UPDATE resume_achievements SET text = $2 WHERE id = $1`, achvID, newText); err != nil {
+		if err := db.UpdateAchievementText(ctx, achvID, newText); err != nil {
 			slog.Debug("update achievement text failed", slog.Any("error", err))
 		}
 	}
 	if metricNumeric != nil || metricUnit != "" {
-		if _, err := db.pool.Exec(ctx,
-			`UPDATE resume_achievements SET metric_numeric = $2, metric_unit = $3 WHERE id = $1`,
-			achvID, metricNumeric, metricUnit); err != nil {
+		if err := db.UpdateAchievementMetric(ctx, achvID, metricNumeric, metricUnit); err != nil {
 			slog.Debug("update achievement metrics failed", slog.Any("error", err))
 		}
 	}
 }
 
 // buildCurrentDataString assembles current resume data for LLM consumption.
-func buildCurrentDataString(ctx context.Context, db *ResumeDB, personID int) string {
+func buildCurrentDataString(ctx context.Context, db ResumeStore, personID int) string {
 	var b strings.Builder
 
 	exps, _ := db.GetAllExperiences(ctx, personID)
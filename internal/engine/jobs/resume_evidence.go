@@ -0,0 +1,108 @@
+package jobs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Evidence tags mark each ResumeDB record in the candidate data sent to the LLM
+// ([E12] experience 12, [P3] project 3, [A7] achievement 7, [PUB2] publication 2,
+// [TALK1], [PAT4], [OSS5]). The LLM cites them per claim; citations are resolved back
+// to record IDs and checked against what was sent.
+
+// EvidenceRef points at the ResumeDB record backing a claim.
+type EvidenceRef struct {
+	Type string `json:"type"` // experience, project, achievement, publication, talk, patent or open_source
+	ID   int    `json:"id"`
+}
+
+// ResumeClaim is one bullet or statement of a generated resume with its evidence.
+type ResumeClaim struct {
+	Claim     string        `json:"claim"`
+	Evidence  []EvidenceRef `json:"evidence"`
+	Supported bool          `json:"supported"` // false when no cited record exists in the candidate data
+}
+
+var (
+	evidenceTagRe   = regexp.MustCompile(`\[(E|P|A|PUB|TALK|PAT|OSS)(\d+)\]`)
+	evidenceStripRe = regexp.MustCompile(`[ \t]*\[(?:E|P|A|PUB|TALK|PAT|OSS)\d+\]`)
+)
+
+// evidenceTag returns the tag for a record, e.g. evidenceTag("E", 12) = "[E12]".
+func evidenceTag(kind string, id int) string {
+	return fmt.Sprintf("[%s%d]", kind, id)
+}
+
+// knownEvidence indexes the tags present in the candidate data.
+func knownEvidence(exps []ExperienceRecord, projs []ProjectRecord, achvs []AchievementRecord) map[string]EvidenceRef {
+	known := make(map[string]EvidenceRef, len(exps)+len(projs)+len(achvs))
+	for _, e := range exps {
+		known[evidenceTag("E", e.ID)] = EvidenceRef{Type: "experience", ID: e.ID}
+	}
+	for _, p := range projs {
+		known[evidenceTag("P", p.ID)] = EvidenceRef{Type: "project", ID: p.ID}
+	}
+	for _, a := range achvs {
+		known[evidenceTag("A", a.ID)] = EvidenceRef{Type: "achievement", ID: a.ID}
+	}
+	return known
+}
+
+// resolveResumeEvidence maps the LLM's cited tags to record IDs. Tags for records
+// that were not in the candidate data are dropped; a claim left without evidence is
+// unsupported. Resume bullets the LLM did not cite at all are added as unsupported.
+func resolveResumeEvidence(raw []rawResumeClaim, known map[string]EvidenceRef, resume string) []ResumeClaim {
+	claims := make([]ResumeClaim, 0, len(raw))
+	cited := make(map[string]bool)
+	for _, rc := range raw {
+		text := strings.TrimSpace(stripEvidenceTags(rc.Claim))
+		if text == "" {
+			continue
+		}
+		c := ResumeClaim{Claim: text, Evidence: []EvidenceRef{}}
+		seen := make(map[EvidenceRef]bool)
+		for _, src := range rc.Sources {
+			for _, m := range evidenceTagRe.FindAllStringSubmatch("["+strings.Trim(src, "[] ")+"]", -1) {
+				id, _ := strconv.Atoi(m[2])
+				ref, ok := known[evidenceTag(m[1], id)]
+				if ok && !seen[ref] {
+					seen[ref] = true
+					c.Evidence = append(c.Evidence, ref)
+				}
+			}
+		}
+		c.Supported = len(c.Evidence) > 0
+		cited[normalizeClaim(text)] = true
+		claims = append(claims, c)
+	}
+
+	for _, line := range strings.Split(resume, "\n") {
+		t := strings.TrimSpace(line)
+		if !strings.HasPrefix(t, "-") && !strings.HasPrefix(t, "•") && !strings.HasPrefix(t, "*") {
+			continue
+		}
+		t = strings.TrimSpace(strings.TrimLeft(t, "-•* "))
+		if t == "" || cited[normalizeClaim(t)] {
+			continue
+		}
+		claims = append(claims, ResumeClaim{Claim: t, Evidence: []EvidenceRef{}})
+	}
+	return claims
+}
+
+// rawResumeClaim is one entry of the "evidence" array returned by the LLM.
+type rawResumeClaim struct {
+	Claim   string   `json:"claim"`
+	Sources []string `json:"sources"`
+}
+
+// stripEvidenceTags removes any [E12]-style tags the LLM copied into the text.
+func stripEvidenceTags(s string) string {
+	return evidenceStripRe.ReplaceAllString(s, "")
+}
+
+func normalizeClaim(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(strings.TrimLeft(s, "-•* "))), " ")
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/anatolykoptev/go_job/internal/engine"
 )
@@ -18,38 +19,27 @@ type ResumeGenerateResult struct {
 	MatchedKeywords []string `json:"matched_keywords"`
 	AddedKeywords   []string `json:"added_keywords"`
 	MissingKeywords []string `json:"missing_keywords"`
+	StaleSkills     []string `json:"stale_skills,omitempty"` // JD skills the candidate last used over 2 years ago
 	SelectedItems   struct {
 		Experiences  int `json:"experiences"`
 		Projects     int `json:"projects"`
 		Achievements int `json:"achievements"`
+		Publications int `json:"publications,omitempty"`
+		Talks        int `json:"talks,omitempty"`
+		Patents      int `json:"patents,omitempty"`
+		OpenSource   int `json:"open_source,omitempty"`
 	} `json:"selected_items"`
-	Summary string `json:"summary"`
+	Evidence    []ResumeClaim `json:"evidence,omitempty"`    // per-claim ResumeDB records backing the resume
+	Unsupported int           `json:"unsupported,omitempty"` // claims with no evidence — review for hallucinations
+	// Figures no achievement metric backs, and the resume_enrich questions to confirm them.
+	UnverifiedMetrics []UnverifiedMetric `json:"unverified_metrics,omitempty"`
+	MetricQuestions   []EnrichQuestion   `json:"metric_questions,omitempty"`
+	Lint              *ResumeLint        `json:"lint,omitempty"`         // readability findings; not run for format=json
+	EarlyCareer       bool               `json:"early_career,omitempty"` // formatted education- and projects-first
+	Executive         bool               `json:"executive,omitempty"`    // executive summary format for director+ roles
+	Summary           string             `json:"summary"`
 }
 
-type jdRequirements struct {
-	RequiredSkills  []string `json:"required_skills"`
-	NiceToHave      []string `json:"nice_to_have"`
-	KeyRequirements []string `json:"key_requirements"`
-	RoleTitle       string   `json:"role_title"`
-	Seniority       string   `json:"seniority"`
-}
-
-const jdExtractPrompt = `Analyze the following job description and extract requirements.
-
-Return a JSON object with this exact structure:
-{
-  "required_skills": ["skill1", "skill2"],
-  "nice_to_have": ["skill1", "skill2"],
-  "key_requirements": ["requirement1", "requirement2"],
-  "role_title": "normalized role title",
-  "seniority": "junior/mid/senior/lead/staff/principal"
-}
-
-JOB DESCRIPTION:
-%s
-
-Return ONLY the JSON object.`
-
 const resumeAssemblePrompt = `You are an expert ATS resume writer. Create an ATS-optimized resume tailored to the target job.
 
 TARGET ROLE: %s (%s level)
@@ -69,6 +59,11 @@ CANDIDATE DATA:
 - Quantify achievements with numbers wherever possible
 - Include a skills section grouped by category
 - Keep it to 1-2 pages (for senior roles, 2 pages is fine)
+- Candidate data items are tagged [E#] (experience), [P#] (project), [A#] (achievement), [PUB#] (publication), [TALK#] (talk), [PAT#] (patent), [OSS#] (open source). Do NOT put the tags in the resume text; cite them in "evidence" instead
+- Publications, talks, patents and open source were pre-selected for relevance to this role: give them their own sections when present
+- Every claim must be backed by the candidate data; do not invent employers, numbers or technologies
+- List spoken languages in a short Languages section when present; mention work authorization only where it answers a location or visa requirement of the role
+- Skills marked "(last used YEAR)" have not been used recently: do not lead with them or list them as core skills; prefer recent experience for required skills
 
 FORMAT: %s
 
@@ -78,14 +73,18 @@ Return a JSON object with this exact structure:
   "ats_score": <estimated ATS match score 0-100>,
   "matched_keywords": [<keywords from JD that are in the resume>],
   "added_keywords": [<keywords you added to improve match>],
-  "missing_keywords": [<JD keywords that could not be naturally incorporated>]
+  "missing_keywords": [<JD keywords that could not be naturally incorporated>],
+  "evidence": [{"claim": "<a bullet or summary sentence, verbatim from the resume>", "sources": ["E12", "A7"]}]
 }
 
+Include one "evidence" entry for EVERY bullet point and summary sentence, citing the tags of the items it is based on.
+
 Return ONLY the JSON object, no markdown, no explanation.`
 
 // GenerateResume queries the master resume graph + vectors against a JD and assembles an ATS-optimized resume.
-func GenerateResume(ctx context.Context, jobDescription, company, format string) (*ResumeGenerateResult, error) {
-	db := GetResumeDB()
+// metricPolicy decides what happens to figures no record backs (MetricPolicyAsk or MetricPolicyRemove).
+func GenerateResume(ctx context.Context, jobDescription, company, format, metricPolicy string) (*ResumeGenerateResult, error) {
+	db := resumeStore(ctx)
 	if db == nil {
 		return nil, errors.New("resume database not configured (set DATABASE_URL)")
 	}
@@ -101,23 +100,22 @@ func GenerateResume(ctx context.Context, jobDescription, company, format string)
 
 	// 1. Extract JD requirements (LLM call #1)
 	jdTrunc := engine.TruncateRunes(jobDescription, 3000, "")
-	jdPrompt := fmt.Sprintf(jdExtractPrompt, jdTrunc)
-	jdRaw, err := engine.CallLLM(ctx, jdPrompt)
+	jd, err := extractJDRequirements(ctx, jobDescription, "resume_generate")
 	if err != nil {
-		return nil, fmt.Errorf("resume_generate extract JD: %w", err)
-	}
-
-	jdRaw = StripMarkdownFences(jdRaw)
-
-	var jd jdRequirements
-	if err := json.Unmarshal([]byte(jdRaw), &jd); err != nil {
-		return nil, fmt.Errorf("resume_generate parse JD: %w (raw: %s)", err, engine.TruncateRunes(jdRaw, 200, "..."))
+		return nil, err
 	}
 
 	// 2. Query graph for matching experiences & projects by skill
 	expIDSet := make(map[int]bool)
 	projIDSet := make(map[int]bool)
 	achvIDSet := make(map[int]bool)
+	workIDs := make(map[string]map[int]bool) // vector type → IDs of relevant publications, talks, patents, open source
+	markWork := func(typ string, id int) {
+		if workIDs[typ] == nil {
+			workIDs[typ] = make(map[int]bool)
+		}
+		workIDs[typ][id] = true
+	}
 
 	allSkills := make([]string, 0, len(jd.RequiredSkills)+len(jd.NiceToHave))
 	allSkills = append(allSkills, jd.RequiredSkills...)
@@ -151,6 +149,17 @@ func GenerateResume(ctx context.Context, jobDescription, company, format string)
 			projIDSet[id] = true
 		}
 
+		// Publications, talks, patents and open source about the skill
+		for typ, label := range workLabels {
+			ids, err := db.QueryWorkIDsBySkill(ctx, label, skill)
+			if err != nil {
+				slog.Debug("graph query works by skill failed", slog.String("label", label), slog.Any("error", err))
+			}
+			for _, id := range ids {
+				markWork(typ, id)
+			}
+		}
+
 		// Traverse IMPLIES_SKILL: find experiences/projects via adjacent skills
 		skillID := db.QuerySkillIDByName(ctx, personID, skill)
 		if skillID > 0 {
@@ -160,7 +169,7 @@ func GenerateResume(ctx context.Context, jobDescription, company, format string)
 				iExpIDs, _ := db.QueryExperienceIDsBySkill(ctx, skill)
 				_ = iExpIDs // implied skills don't have name here, query by ID not possible directly
 				// Use a Cypher query to find experiences via implied skill ID
-				iExpIDs2, _ := queryExperienceIDsBySkillID(ctx, db, impliedID)
+				iExpIDs2, _ := db.QueryExperienceIDsBySkillID(ctx, impliedID)
 				for _, id := range iExpIDs2 {
 					expIDSet[id] = true
 				}
@@ -169,7 +178,7 @@ func GenerateResume(ctx context.Context, jobDescription, company, format string)
 	}
 
 	// 3. Vector search for semantic matches (MemDB)
-	mdb := GetMemDB()
+	mdb := vectorStore(ctx)
 	if mdb != nil {
 		results, err := mdb.Search(ctx, jdTrunc, 15, 0.6)
 		if err != nil {
@@ -189,6 +198,8 @@ func GenerateResume(ctx context.Context, jobDescription, company, format string)
 					projIDSet[id] = true
 				case "achievement":
 					achvIDSet[id] = true
+				case "publication", "talk", "patent", "open_source":
+					markWork(itemType, id)
 				}
 			}
 		}
@@ -221,8 +232,21 @@ func GenerateResume(ctx context.Context, jobDescription, company, format string)
 	domains, _ := db.GetAllDomains(ctx, personID)
 	methodologies, _ := db.GetAllMethodologies(ctx, personID)
 
+	// Publications, talks, patents and open source only when relevant to the JD
+	works, err := loadResumeWorks(ctx, db, personID)
+	if err != nil {
+		slog.Debug("load resume works failed", slog.Any("error", err))
+	}
+	works = works.relevant(workIDs, allSkills)
+
+	// Required skills the candidate only has from older roles
+	staleSkills := jdStaleSkills(allSkills, skills, time.Now())
+
 	// 5. Format candidate data for LLM
-	candidateData := formatCandidateData(experiences, projects, achievements, educations, skills, certifications, domains, methodologies)
+	candidateData := formatCandidateData(experiences, projects, achievements, educations, skills, certifications, domains, methodologies, works)
+	if person, err := db.GetPerson(ctx, personID); err == nil {
+		candidateData += formatPersonFacts(person)
+	}
 
 	// 6. Optional company enrichment
 	companyContext := ""
@@ -245,6 +269,18 @@ func GenerateResume(ctx context.Context, jobDescription, company, format string)
 		}
 	}
 
+	// Director+ roles get an executive summary format; internship and junior roles, or
+	// under two years of experience, put education and projects first
+	executive := IsExecutiveRole(jd.Seniority, jd.RoleTitle)
+	allExperiences, _ := db.GetAllExperiences(ctx, personID)
+	earlyCareer := !executive && isEarlyCareer(jd.Seniority, allExperiences, time.Now())
+	switch {
+	case executive:
+		companyContext += executiveGuidelines
+	case earlyCareer:
+		companyContext += earlyCareerGuidelines
+	}
+
 	// 7. Assemble resume (LLM call #2)
 	assemblePrompt := fmt.Sprintf(resumeAssemblePrompt,
 		jd.RoleTitle,
@@ -264,11 +300,12 @@ func GenerateResume(ctx context.Context, jobDescription, company, format string)
 	assembleRaw = StripMarkdownFences(assembleRaw)
 
 	var assembled struct {
-		Resume          string   `json:"resume"`
-		ATSScore        int      `json:"ats_score"`
-		MatchedKeywords []string `json:"matched_keywords"`
-		AddedKeywords   []string `json:"added_keywords"`
-		MissingKeywords []string `json:"missing_keywords"`
+		Resume          string           `json:"resume"`
+		ATSScore        int              `json:"ats_score"`
+		MatchedKeywords []string         `json:"matched_keywords"`
+		AddedKeywords   []string         `json:"added_keywords"`
+		MissingKeywords []string         `json:"missing_keywords"`
+		Evidence        []rawResumeClaim `json:"evidence"`
 	}
 	if err := json.Unmarshal([]byte(assembleRaw), &assembled); err != nil {
 		// Fallback: if JSON parse fails, treat the raw output as the resume
@@ -278,16 +315,50 @@ func GenerateResume(ctx context.Context, jobDescription, company, format string)
 		}, nil
 	}
 
+	assembled.Resume = stripEvidenceTags(assembled.Resume)
 	result := &ResumeGenerateResult{
 		Resume:          assembled.Resume,
 		ATSScore:        assembled.ATSScore,
 		MatchedKeywords: assembled.MatchedKeywords,
 		AddedKeywords:   assembled.AddedKeywords,
 		MissingKeywords: assembled.MissingKeywords,
+		StaleSkills:     staleSkills,
+		EarlyCareer:     earlyCareer,
+		Executive:       executive,
 	}
 	result.SelectedItems.Experiences = len(experiences)
 	result.SelectedItems.Projects = len(projects)
 	result.SelectedItems.Achievements = len(achievements)
+	result.SelectedItems.Publications = len(works.Publications)
+	result.SelectedItems.Talks = len(works.Talks)
+	result.SelectedItems.Patents = len(works.Patents)
+	result.SelectedItems.OpenSource = len(works.OpenSource)
+	known := knownEvidence(experiences, projects, achievements)
+	works.addEvidence(known)
+	result.Evidence = resolveResumeEvidence(assembled.Evidence, known, assembled.Resume)
+
+	// 8. Validate figures against achievement metrics
+	metrics := checkResumeMetrics(result.Resume, metricPolicy, knownMetrics(achievements, candidateData), result.Evidence, achievements)
+	result.Resume = metrics.Resume
+	result.UnverifiedMetrics = metrics.Unverified
+	result.MetricQuestions = metrics.Questions
+	if len(metrics.Removed) > 0 {
+		claims := result.Evidence[:0]
+		for _, c := range result.Evidence {
+			if !metrics.Removed[normalizeClaim(c.Claim)] {
+				claims = append(claims, c)
+			}
+		}
+		result.Evidence = claims
+	}
+	for _, c := range result.Evidence {
+		if !c.Supported {
+			result.Unsupported++
+		}
+	}
+	if format != "json" {
+		result.Lint = LintResume(result.Resume)
+	}
 
 	result.Summary = fmt.Sprintf("Generated ATS resume for %s (%s). Used %d experiences, %d projects, %d achievements. ATS score: %d/100. Matched %d/%d keywords.",
 		jd.RoleTitle, jd.Seniority,
@@ -296,34 +367,32 @@ func GenerateResume(ctx context.Context, jobDescription, company, format string)
 		len(result.MatchedKeywords),
 		len(jd.RequiredSkills)+len(jd.NiceToHave),
 	)
-
-	return result, nil
-}
-
-// queryExperienceIDsBySkillID finds experience IDs linked to a skill by its ID.
-func queryExperienceIDsBySkillID(ctx context.Context, db *ResumeDB, skillID int) ([]int, error) {
-	conn, err := db.pool.Acquire(ctx)
-	if err != nil {
-		return nil, err
+	switch {
+	case executive:
+		result.Summary += " Used the executive format: executive summary and selected achievements first."
+	case earlyCareer:
+		result.Summary += " Formatted for an early-career candidate: education and projects before experience."
 	}
-	defer conn.Release()
-
-	if _, err := conn.Exec(ctx, ageSetup); err != nil {
-		return nil, err
+	if n := works.count(); n > 0 {
+		result.Summary += fmt.Sprintf(" Included %d relevant publications/talks/patents/open-source projects.", n)
 	}
-
-	cypher := fmt.Sprintf(`
-		SELECT * FROM ag_catalog.cypher('resume_graph', $$
-			MATCH (e:Exp)-[:USED_SKILL]->(s:Skill {id: %d})
-			RETURN e.id
-		$$) AS (id ag_catalog.agtype)`, skillID)
-
-	rows, err := conn.Query(ctx, cypher)
-	if err != nil {
-		return nil, err
+	if result.Unsupported > 0 {
+		result.Summary += fmt.Sprintf(" %d claim(s) have no supporting record — review them.", result.Unsupported)
 	}
-	defer rows.Close()
-	return scanAGEIntIDs(rows)
+	if len(staleSkills) > 0 {
+		result.Summary += fmt.Sprintf(" Stale JD skills (last used over %d years ago): %s.", skillFreshYears, strings.Join(staleSkills, ", "))
+	}
+	if result.Lint != nil && len(result.Lint.Findings) > 0 {
+		result.Summary += fmt.Sprintf(" Lint score %d/100 with %d finding(s).", result.Lint.Score, len(result.Lint.Findings))
+	}
+	switch {
+	case len(metrics.Removed) > 0:
+		result.Summary += fmt.Sprintf(" Removed %d claim(s) with figures not recorded in your achievements.", len(metrics.Removed))
+	case len(result.MetricQuestions) > 0:
+		result.Summary += fmt.Sprintf(" %d figure(s) are not recorded in your achievements — answer metric_questions with resume_enrich (action=answer) to confirm them.", len(result.UnverifiedMetrics))
+	}
+
+	return result, nil
 }
 
 func formatCandidateData(
@@ -335,12 +404,14 @@ func formatCandidateData(
 	certs []CertificationRecord,
 	domains []DomainRecord,
 	methodologies []MethodologyRecord,
+	works resumeWorks,
 ) string {
 	var b strings.Builder
+	now := time.Now()
 
 	b.WriteString("=== EXPERIENCES ===\n")
 	for _, e := range exps {
-		fmt.Fprintf(&b, "\u2022 %s at %s (%s\u2013%s)\n", e.Title, e.Company, e.StartDate, e.EndDate)
+		fmt.Fprintf(&b, "\u2022 %s %s at %s (%s\u2013%s)\n", evidenceTag("E", e.ID), e.Title, e.Company, e.StartDate, e.EndDate)
 		if e.Location != "" {
 			fmt.Fprintf(&b, "  Location: %s\n", e.Location)
 		}
@@ -358,7 +429,7 @@ func formatCandidateData(
 	if len(projs) > 0 {
 		b.WriteString("\n=== PROJECTS ===\n")
 		for _, p := range projs {
-			fmt.Fprintf(&b, "\u2022 %s", p.Name)
+			fmt.Fprintf(&b, "\u2022 %s %s", evidenceTag("P", p.ID), p.Name)
 			if p.URL != "" {
 				fmt.Fprintf(&b, " (%s)", p.URL)
 			}
@@ -381,10 +452,12 @@ func formatCandidateData(
 	if len(achvs) > 0 {
 		b.WriteString("\n=== KEY ACHIEVEMENTS ===\n")
 		for _, a := range achvs {
-			fmt.Fprintf(&b, "\u2022 %s\n", a.Text)
+			fmt.Fprintf(&b, "\u2022 %s %s\n", evidenceTag("A", a.ID), a.Text)
 		}
 	}
 
+	works.writeCandidateData(&b)
+
 	if len(edus) > 0 {
 		b.WriteString("\n=== EDUCATION ===\n")
 		for _, e := range edus {
@@ -401,7 +474,7 @@ func formatCandidateData(
 			if cat == "" {
 				cat = "other"
 			}
-			label := s.Name
+			label := staleSkillLabel(s, now)
 			if s.IsImplicit {
 				label += " (inferred)"
 			}
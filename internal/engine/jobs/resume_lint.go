@@ -0,0 +1,159 @@
+package jobs
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// --- Resume lint ---
+
+// Deterministic readability checks for generated and tailored resumes, returned next to
+// the ATS score: bullet length, passive voice, first-person pronouns, buzzword density,
+// date-format consistency and an estimated page count.
+
+// Lint rules.
+const (
+	LintBulletLength = "bullet_length"
+	LintPassiveVoice = "passive_voice"
+	LintFirstPerson  = "first_person"
+	LintBuzzwords    = "buzzwords"
+	LintDateFormat   = "date_format"
+	LintPageLength   = "page_length"
+)
+
+const (
+	lintBulletMaxWords = 30
+	lintBulletMinWords = 5
+	lintWordsPerPage   = 500
+	lintLinesPerPage   = 55
+	lintMaxPages       = 2
+	lintBuzzwordMax    = 1.0 // buzzwords per 100 words
+)
+
+// LintFinding is one lint problem.
+type LintFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"` // "warning" or "info"
+	Line     int    `json:"line,omitempty"`
+	Text     string `json:"text,omitempty"` // offending excerpt
+	Message  string `json:"message"`
+}
+
+// ResumeLint is the lint report of a resume.
+type ResumeLint struct {
+	Score           int           `json:"score"` // 100 minus 5 per warning and 2 per info, floored at 0
+	Words           int           `json:"words"`
+	Bullets         int           `json:"bullets"`
+	EstimatedPages  float64       `json:"estimated_pages"`
+	BuzzwordDensity float64       `json:"buzzword_density"` // per 100 words
+	DateFormats     []string      `json:"date_formats,omitempty"`
+	Findings        []LintFinding `json:"findings"`
+}
+
+var (
+	lintBulletRe  = regexp.MustCompile(`^\s*(?:[-*•▪◦‣]|\d{1,2}[.)])\s+(.+)$`)
+	lintPassiveRe = regexp.MustCompile(`(?i)\b(?:am|is|are|was|were|be|been|being)\s+(?:\w+ly\s+)?(?:\w+ed|built|done|made|led|given|taken|written|run|shown|chosen|driven|grown|won|brought|sent|kept|held|taught)\b`)
+	lintPronounRe = regexp.MustCompile(`\bI\b|(?i:\b(?:me|my|mine|myself|we|our|ours)\b)`)
+	lintBuzzRe    = regexp.MustCompile(`(?i)\b(synerg(?:y|ies|istic)|go-getter|team player|hard[- ]?working|detail[- ]oriented|results[- ](?:driven|oriented)|self[- ]starter|think(?:ing)? outside the box|dynamic|passionate|highly motivated|proactive|best[- ]of[- ]breed|rock ?star|ninja|guru|thought leader|value[- ]add(?:ed)?|proven track record|strategic thinker|go-to person|wear many hats)\b`)
+)
+
+// lintDateFormats are the date styles checked for consistency.
+var lintDateFormats = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"Mon YYYY", regexp.MustCompile(`\b(?:Jan|Feb|Mar|Apr|Jun|Jul|Aug|Sep|Sept|Oct|Nov|Dec)\.?\s+(?:19|20)\d{2}\b`)},
+	{"Month YYYY", regexp.MustCompile(`\b(?:January|February|March|April|June|July|August|September|October|November|December)\s+(?:19|20)\d{2}\b`)},
+	{"MM/YYYY", regexp.MustCompile(`\b(?:0?[1-9]|1[0-2])/(?:19|20)\d{2}\b`)},
+	{"YYYY-MM", regexp.MustCompile(`\b(?:19|20)\d{2}-(?:0[1-9]|1[0-2])\b`)},
+	{"MM.YYYY", regexp.MustCompile(`\b(?:0[1-9]|1[0-2])\.(?:19|20)\d{2}\b`)},
+}
+
+// LintResume runs the readability checks on resume text.
+func LintResume(text string) *ResumeLint {
+	lint := &ResumeLint{Findings: []LintFinding{}}
+	lines := strings.Split(text, "\n")
+	lint.Words = len(strings.Fields(text))
+
+	for i, line := range lines {
+		n := i + 1
+		if m := lintBulletRe.FindStringSubmatch(line); m != nil {
+			lint.Bullets++
+			words := len(strings.Fields(m[1]))
+			switch {
+			case words > lintBulletMaxWords:
+				lint.add(LintBulletLength, "warning", n, m[1], fmt.Sprintf("Bullet has %d words; keep bullets under %d so they scan in one glance.", words, lintBulletMaxWords))
+			case words < lintBulletMinWords:
+				lint.add(LintBulletLength, "info", n, m[1], fmt.Sprintf("Bullet has only %d words; add the action and its result.", words))
+			}
+		}
+		for _, p := range lintPassiveRe.FindAllString(line, -1) {
+			lint.add(LintPassiveVoice, "warning", n, p, "Passive voice; start with the action verb instead (\"Built ...\", not \"was built\").")
+		}
+		if p := lintPronounRe.FindAllString(line, -1); len(p) > 0 {
+			lint.add(LintFirstPerson, "info", n, strings.Join(p, ", "), "First-person pronouns; resumes conventionally drop them (\"Led ...\", not \"I led ...\").")
+		}
+	}
+
+	buzz := make(map[string]int)
+	total := 0
+	for _, b := range lintBuzzRe.FindAllString(text, -1) {
+		buzz[strings.ToLower(b)]++
+		total++
+	}
+	if lint.Words > 0 {
+		lint.BuzzwordDensity = math.Round(float64(total)*10000/float64(lint.Words)) / 100
+	}
+	if total > 0 {
+		var names []string
+		for b, c := range buzz {
+			names = append(names, fmt.Sprintf("%s (%d)", b, c))
+		}
+		sort.Strings(names)
+		severity, msg := "info", "Buzzwords; replace them with concrete evidence."
+		if lint.BuzzwordDensity > lintBuzzwordMax {
+			severity = "warning"
+			msg = fmt.Sprintf("%.1f buzzwords per 100 words; replace them with concrete evidence.", lint.BuzzwordDensity)
+		}
+		lint.add(LintBuzzwords, severity, 0, strings.Join(names, ", "), msg)
+	}
+
+	for _, f := range lintDateFormats {
+		if f.re.MatchString(text) {
+			lint.DateFormats = append(lint.DateFormats, f.name)
+		}
+	}
+	if len(lint.DateFormats) > 1 {
+		lint.add(LintDateFormat, "warning", 0, strings.Join(lint.DateFormats, ", "), "Dates use several formats; pick one and use it everywhere.")
+	}
+
+	nonEmpty := 0
+	for _, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			nonEmpty++
+		}
+	}
+	pages := math.Max(float64(lint.Words)/lintWordsPerPage, float64(nonEmpty)/lintLinesPerPage)
+	lint.EstimatedPages = math.Round(pages*10) / 10
+	if lint.EstimatedPages > lintMaxPages {
+		lint.add(LintPageLength, "warning", 0, "", fmt.Sprintf("About %.1f pages; trim to %d pages or fewer.", lint.EstimatedPages, lintMaxPages))
+	}
+
+	lint.Score = 100
+	for _, f := range lint.Findings {
+		if f.Severity == "warning" {
+			lint.Score -= 5
+		} else {
+			lint.Score -= 2
+		}
+	}
+	lint.Score = max(lint.Score, 0)
+	return lint
+}
+
+func (l *ResumeLint) add(rule, severity string, line int, text, msg string) {
+	l.Findings = append(l.Findings, LintFinding{Rule: rule, Severity: severity, Line: line, Text: strings.TrimSpace(text), Message: msg})
+}
@@ -33,7 +33,7 @@ type ResumeMemorySearchResult struct {
 
 // SearchResumeMemory queries MemDB for resume-related vectors.
 func SearchResumeMemory(ctx context.Context, query string, topK int) (*ResumeMemorySearchResult, error) {
-	mdb := GetMemDB()
+	mdb := StoresFrom(ctx).MemDB
 	if mdb == nil {
 		return nil, errors.New("MemDB not configured (set MEMDB_URL)")
 	}
@@ -57,21 +57,7 @@ func SearchResumeMemory(ctx context.Context, query string, topK int) (*ResumeMem
 			Score:    r.Score,
 			MemoryID: r.MemoryID,
 		}
-		if t, ok := r.Info["type"].(string); ok {
-			item.Type = t
-		}
-		if idVal, ok := r.Info["id"]; ok {
-			switch v := idVal.(type) {
-			case float64:
-				item.ID = int(v)
-			case int:
-				item.ID = v
-			case int64:
-				item.ID = int(v)
-			case string:
-				item.ID, _ = strconv.Atoi(v)
-			}
-		}
+		item.Type, item.ID = memoryInfoRef(r.Info)
 		items = append(items, item)
 	}
 
@@ -82,6 +68,23 @@ func SearchResumeMemory(ctx context.Context, query string, topK int) (*ResumeMem
 	}, nil
 }
 
+// memoryInfoRef returns the resume record a memory points at via its info type and id.
+// id is 0 for memories not tied to a record (e.g. agent notes).
+func memoryInfoRef(info map[string]any) (typ string, id int) {
+	typ, _ = info["type"].(string)
+	switch v := info["id"].(type) {
+	case float64:
+		id = int(v)
+	case int:
+		id = v
+	case int64:
+		id = int(v)
+	case string:
+		id, _ = strconv.Atoi(v)
+	}
+	return typ, id
+}
+
 // --- Add ---
 
 // ResumeMemoryAddResult is the output of resume_memory_add.
@@ -92,7 +95,7 @@ type ResumeMemoryAddResult struct {
 
 // AddResumeMemory stores a new memory in MemDB.
 func AddResumeMemory(ctx context.Context, content, memType string) (*ResumeMemoryAddResult, error) {
-	mdb := GetMemDB()
+	mdb := StoresFrom(ctx).MemDB
 	if mdb == nil {
 		return nil, errors.New("MemDB not configured (set MEMDB_URL)")
 	}
@@ -126,7 +129,7 @@ type ResumeMemoryUpdateResult struct {
 
 // UpdateResumeMemory replaces an existing memory by deleting and re-adding.
 func UpdateResumeMemory(ctx context.Context, memoryID, content string) (*ResumeMemoryUpdateResult, error) {
-	mdb := GetMemDB()
+	mdb := StoresFrom(ctx).MemDB
 	if mdb == nil {
 		return nil, errors.New("MemDB not configured (set MEMDB_URL)")
 	}
@@ -0,0 +1,223 @@
+package jobs
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Post-generation metric check for resume_generate: every figure in a resume claim
+// must match an achievement's metric_numeric (or appear in the candidate data the
+// LLM was given). Unmatched figures are either removed or turned into resume_enrich
+// questions so the real number can be recorded in the master resume.
+
+// Metric policies for resume_generate.
+const (
+	MetricPolicyAsk    = "ask"    // keep the claim and ask for the real figure (default)
+	MetricPolicyRemove = "remove" // drop the bullet or sentence carrying the figure
+)
+
+// metricTolerance lets rounded figures match: "16K" for a recorded 16,230.
+const metricTolerance = 0.05
+
+// UnverifiedMetric is a figure in a generated resume that no record backs.
+type UnverifiedMetric struct {
+	Number string `json:"number"`
+	Claim  string `json:"claim"`
+	Action string `json:"action"` // "removed" or "question"
+}
+
+// resumeNumber is a figure found in text, with K/M/B suffixes applied.
+type resumeNumber struct {
+	Text  string
+	Value float64
+}
+
+var (
+	resumeNumberRe   = regexp.MustCompile(`\d[\d,]*(?:\.\d+)?`)
+	sentenceBreakRe  = regexp.MustCompile(`[.!?]+\s+`)
+	numberMultiplier = []struct {
+		suffix string
+		mult   float64
+	}{{"bn", 1e9}, {"mm", 1e6}, {"k", 1e3}, {"m", 1e6}, {"b", 1e9}, {"x", 1}}
+)
+
+// resumeNumbers extracts the figures in s. Years, dates, versions and numbers glued
+// to words (S3, K8s, 2nd) are skipped.
+func resumeNumbers(s string) []resumeNumber {
+	var out []resumeNumber
+	for _, loc := range resumeNumberRe.FindAllStringIndex(s, -1) {
+		start := loc[0]
+		raw := strings.TrimRight(s[start:loc[1]], ",")
+		if start > 0 {
+			if r, _ := utf8.DecodeLastRuneInString(s[:start]); unicode.IsLetter(r) || r == '/' || r == '.' {
+				continue
+			}
+		}
+
+		rest := s[start+len(raw):]
+		lower := strings.ToLower(rest)
+		mult, suffix := 1.0, ""
+		for _, m := range numberMultiplier {
+			if strings.HasPrefix(lower, m.suffix) && !startsWithLetter(lower[len(m.suffix):]) {
+				mult, suffix = m.mult, rest[:len(m.suffix)]
+				break
+			}
+		}
+		if next, _ := utf8.DecodeRuneInString(rest[len(suffix):]); suffix == "" && (unicode.IsLetter(next) || next == '/') {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(strings.ReplaceAll(raw, ",", ""), 64)
+		if err != nil {
+			continue
+		}
+		if suffix == "" && !strings.ContainsAny(raw, ",.") && v >= 1950 && v <= 2100 {
+			continue // a year
+		}
+		out = append(out, resumeNumber{Text: raw + suffix, Value: v * mult})
+	}
+	return out
+}
+
+func startsWithLetter(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsLetter(r)
+}
+
+// knownMetrics collects the figures a resume may cite: achievement metric_numeric
+// values plus every figure in the candidate data sent to the LLM.
+func knownMetrics(achvs []AchievementRecord, candidateData string) []float64 {
+	var known []float64
+	for _, a := range achvs {
+		if a.MetricNumeric != nil {
+			known = append(known, *a.MetricNumeric)
+		}
+	}
+	for _, n := range resumeNumbers(candidateData) {
+		known = append(known, n.Value)
+	}
+	return known
+}
+
+func metricKnown(v float64, known []float64) bool {
+	for _, k := range known {
+		if math.Abs(v-k) <= metricTolerance*math.Max(math.Abs(k), 1) {
+			return true
+		}
+	}
+	return false
+}
+
+// resumeMetricCheck is the outcome of checkResumeMetrics.
+type resumeMetricCheck struct {
+	Resume     string
+	Unverified []UnverifiedMetric
+	Questions  []EnrichQuestion
+	Removed    map[string]bool // normalized claims dropped from the resume
+}
+
+// checkResumeMetrics validates the figures in the resume's claims — bullet points and
+// prose lines; headers, contact and date lines are left alone. With MetricPolicyRemove
+// the offending bullet or sentence is dropped; otherwise a missing_metric question is
+// raised for resume_enrich, with the cited achievement as context when there is one.
+func checkResumeMetrics(resume, policy string, known []float64, claims []ResumeClaim, achvs []AchievementRecord) resumeMetricCheck {
+	check := resumeMetricCheck{Removed: make(map[string]bool)}
+	remove := policy == MetricPolicyRemove
+
+	achvText := make(map[int]string, len(achvs))
+	for _, a := range achvs {
+		achvText[a.ID] = a.Text
+	}
+	claimAchv := make(map[string]string)
+	for _, c := range claims {
+		for _, ref := range c.Evidence {
+			if ref.Type == "achievement" && achvText[ref.ID] != "" {
+				claimAchv[normalizeClaim(c.Claim)] = achvText[ref.ID]
+				break
+			}
+		}
+	}
+
+	lines := strings.Split(resume, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		t := strings.TrimSpace(line)
+		bullet := strings.HasPrefix(t, "-") || strings.HasPrefix(t, "•") || strings.HasPrefix(t, "*")
+		if !bullet && len(strings.Fields(t)) < 8 {
+			kept = append(kept, line)
+			continue
+		}
+
+		parts := []string{line}
+		if !bullet {
+			parts = splitSentences(line)
+		}
+		var out []string
+		for _, part := range parts {
+			claim := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(part), "-•* "))
+			var bad []string
+			for _, n := range resumeNumbers(claim) {
+				if !metricKnown(n.Value, known) {
+					bad = append(bad, n.Text)
+				}
+			}
+			if len(bad) == 0 {
+				out = append(out, part)
+				continue
+			}
+			action := "question"
+			if remove {
+				action = "removed"
+				check.Removed[normalizeClaim(claim)] = true
+			} else {
+				out = append(out, part)
+			}
+			for _, num := range bad {
+				check.Unverified = append(check.Unverified, UnverifiedMetric{Number: num, Claim: claim, Action: action})
+			}
+			if !remove {
+				check.Questions = append(check.Questions, metricQuestion(len(check.Questions)+1, bad, claim, claimAchv[normalizeClaim(claim)]))
+			}
+		}
+		if len(out) > 0 {
+			kept = append(kept, strings.Join(out, " "))
+		}
+	}
+	check.Resume = strings.Join(kept, "\n")
+	return check
+}
+
+// metricQuestion builds the resume_enrich question for unverified figures in a claim.
+func metricQuestion(n int, numbers []string, claim, achievement string) EnrichQuestion {
+	context := "Resume claim: " + claim
+	if achievement != "" {
+		context = "Achievement: " + achievement
+	}
+	return EnrichQuestion{
+		ID:       fmt.Sprintf("metric%d", n),
+		Category: "missing_metric",
+		Question: fmt.Sprintf("The tailored resume says %q, but %s is not recorded in your master resume. What is the real figure and what does it measure?",
+			claim, strings.Join(numbers, ", ")),
+		Context: context,
+	}
+}
+
+// splitSentences splits a prose line after sentence-ending punctuation.
+func splitSentences(line string) []string {
+	var parts []string
+	start := 0
+	for _, loc := range sentenceBreakRe.FindAllStringIndex(line, -1) {
+		end := loc[0] + len(strings.TrimRightFunc(line[loc[0]:loc[1]], unicode.IsSpace))
+		parts = append(parts, line[start:end])
+		start = loc[1]
+	}
+	if start < len(line) {
+		parts = append(parts, line[start:])
+	}
+	return parts
+}
@@ -0,0 +1,274 @@
+package jobs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Resume PDF ATS check ---
+
+// resume_pdf_check reads a rendered resume PDF the way an ATS parser does and checks
+// it is machine readable: a text layer that decodes to real text, embedded fonts, a
+// single-column layout without tables, and content emitted in reading order. The
+// extracted text is returned so the user can see what the ATS will see.
+
+// PDF check names.
+const (
+	PDFCheckTextLayer     = "text_layer"
+	PDFCheckFontsEmbedded = "fonts_embedded"
+	PDFCheckLayout        = "single_column"
+	PDFCheckReadingOrder  = "reading_order"
+)
+
+const (
+	pdfMaxBytes       = 10 << 20
+	pdfMinTextChars   = 200 // a one-page resume has well over this
+	pdfMinLetterShare = 0.6 // of non-space characters; lower means undecodable glyphs
+	pdfColumnGap      = 3.0 // horizontal gap, in font sizes, that splits a line into columns
+	pdfSplitLineShare = 0.3 // share of split lines above which the layout is multi-column
+	pdfMinLayoutLines = 8
+)
+
+// PDFCheck is one pass/fail check.
+type PDFCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+// PDFFont is a font used by the PDF.
+type PDFFont struct {
+	Name     string `json:"name"`
+	Embedded bool   `json:"embedded"`
+}
+
+// ResumePDFCheckResult is the structured output of resume_pdf_check.
+type ResumePDFCheckResult struct {
+	Passed  bool       `json:"passed"`
+	Pages   int        `json:"pages"`
+	Checks  []PDFCheck `json:"checks"`
+	Fonts   []PDFFont  `json:"fonts"`
+	Text    string     `json:"text"` // extracted in content order, as an ATS reads it
+	Summary string     `json:"summary"`
+}
+
+// CheckResumePDF runs the ATS readability checks on a resume PDF.
+func CheckResumePDF(input engine.ResumePDFCheckInput) (*ResumePDFCheckResult, error) {
+	var data []byte
+	switch {
+	case input.ContentBase64 != "":
+		content := strings.TrimSpace(input.ContentBase64)
+		if base64.StdEncoding.DecodedLen(len(content)) > pdfMaxBytes {
+			return nil, fmt.Errorf("resume_pdf_check: content_base64 is larger than %d MB", pdfMaxBytes>>20)
+		}
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(content); err != nil {
+			return nil, fmt.Errorf("resume_pdf_check: content_base64: %w", err)
+		}
+	case input.Path != "":
+		path, err := goJobFile("resumes", input.Path)
+		if err != nil {
+			return nil, fmt.Errorf("resume_pdf_check: %w", err)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("resume_pdf_check: %w", err)
+		}
+		if info.Size() > pdfMaxBytes {
+			return nil, fmt.Errorf("resume_pdf_check: %s is larger than %d MB", input.Path, pdfMaxBytes>>20)
+		}
+		if data, err = os.ReadFile(path); err != nil { //nolint:gosec // confined to the resumes directory
+			return nil, fmt.Errorf("resume_pdf_check: %w", err)
+		}
+	default:
+		return nil, errors.New("resume_pdf_check: path or content_base64 is required")
+	}
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("%PDF-")) {
+		return nil, errors.New("resume_pdf_check: not a PDF file")
+	}
+	doc, err := parsePDF(data)
+	if err != nil {
+		return nil, fmt.Errorf("resume_pdf_check: %w", err)
+	}
+	return checkPDF(doc), nil
+}
+
+// checkPDF runs the checks on a parsed PDF.
+func checkPDF(doc *pdfDoc) *ResumePDFCheckResult {
+	runs, pages := doc.textRuns()
+	r := &ResumePDFCheckResult{Pages: pages, Fonts: []PDFFont{}, Text: pdfRunsText(runs)}
+	for _, f := range doc.fonts() {
+		r.Fonts = append(r.Fonts, PDFFont{Name: f.name, Embedded: f.embedded || f.type3})
+	}
+	r.Checks = []PDFCheck{
+		checkTextLayer(r.Text),
+		checkFontsEmbedded(r.Fonts),
+		checkLayout(runs),
+		checkReadingOrder(runs),
+	}
+
+	var failed []string
+	for _, c := range r.Checks {
+		if !c.Passed {
+			failed = append(failed, c.Name)
+		}
+	}
+	r.Passed = len(failed) == 0
+	if r.Passed {
+		r.Summary = fmt.Sprintf("All %d ATS checks passed (%d pages). Review the extracted text for anything missing or garbled.", len(r.Checks), pages)
+	} else {
+		r.Summary = fmt.Sprintf("%d of %d ATS checks failed: %s.", len(failed), len(r.Checks), strings.Join(failed, ", "))
+	}
+	return r
+}
+
+func checkTextLayer(text string) PDFCheck {
+	c := PDFCheck{Name: PDFCheckTextLayer}
+	chars, letters := 0, 0
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		chars++
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			letters++
+		}
+	}
+	switch {
+	case chars == 0:
+		c.Detail = "No extractable text: the PDF is likely a scanned image or text converted to outlines, and an ATS will read nothing. Export from the editor as text, not as an image."
+	case float64(letters)/float64(chars) < pdfMinLetterShare:
+		c.Detail = fmt.Sprintf("Text layer does not decode to readable text (%d%% letters and digits): fonts lack a Unicode mapping. Re-export with standard fonts.", letters*100/chars)
+	case chars < pdfMinTextChars:
+		c.Detail = fmt.Sprintf("Only %d characters extractable; most of the content is likely images or outlined text.", chars)
+	default:
+		c.Passed = true
+		c.Detail = fmt.Sprintf("%d characters extractable.", chars)
+	}
+	return c
+}
+
+func checkFontsEmbedded(fonts []PDFFont) PDFCheck {
+	c := PDFCheck{Name: PDFCheckFontsEmbedded}
+	var missing []string
+	for _, f := range fonts {
+		if !f.Embedded {
+			missing = append(missing, f.Name)
+		}
+	}
+	if len(missing) > 0 {
+		c.Detail = fmt.Sprintf("Not embedded: %s. The parser substitutes fonts and may misread glyphs; enable font embedding on export.", strings.Join(missing, ", "))
+		return c
+	}
+	c.Passed = true
+	c.Detail = fmt.Sprintf("All %d fonts embedded.", len(fonts))
+	return c
+}
+
+// checkLayout flags multi-column layouts and tables: lines whose text is split by a gap
+// of several font sizes. A right-aligned date is one such line; a side column or a
+// table makes them the norm.
+func checkLayout(runs []pdfTextRun) PDFCheck {
+	c := PDFCheck{Name: PDFCheckLayout}
+	lines := pdfLines(runs)
+	split := 0
+	for _, line := range lines {
+		for i := 1; i < len(line); i++ {
+			prev := line[i-1]
+			if line[i].x-(prev.x+prev.width) > pdfColumnGap*math.Max(prev.size, line[i].size) {
+				split++
+				break
+			}
+		}
+	}
+	if len(lines) >= pdfMinLayoutLines && float64(split)/float64(len(lines)) > pdfSplitLineShare {
+		c.Detail = fmt.Sprintf("%d of %d lines are split into side-by-side blocks: a multi-column layout or table. ATS parsers read across columns and merge unrelated text; use a single column.", split, len(lines))
+		return c
+	}
+	c.Passed = true
+	c.Detail = fmt.Sprintf("Single column (%d of %d lines split, e.g. right-aligned dates).", split, len(lines))
+	return c
+}
+
+// checkReadingOrder compares the order text is stored in with its visual order: on each
+// page, text should run top to bottom. One jump back up per page is allowed for a
+// header or footer written last.
+func checkReadingOrder(runs []pdfTextRun) PDFCheck {
+	c := PDFCheck{Name: PDFCheckReadingOrder}
+	jumps, pages := 0, map[int]bool{}
+	for i := 1; i < len(runs); i++ {
+		prev, cur := runs[i-1], runs[i]
+		pages[cur.page] = true
+		if prev.page == cur.page && cur.y-prev.y > 2*math.Max(prev.size, cur.size) {
+			jumps++
+		}
+	}
+	if jumps > max(len(pages), 1) {
+		c.Detail = fmt.Sprintf("Text is stored out of visual order (%d jumps back up the page); an ATS will read sections in the wrong order. Compare the extracted text with the PDF.", jumps)
+		return c
+	}
+	c.Passed = true
+	c.Detail = "Text is stored top to bottom."
+	return c
+}
+
+// pdfLines groups runs into visual lines by page and baseline, each sorted left to right.
+func pdfLines(runs []pdfTextRun) [][]pdfTextRun {
+	sorted := append([]pdfTextRun{}, runs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].page != sorted[j].page {
+			return sorted[i].page < sorted[j].page
+		}
+		return sorted[i].y > sorted[j].y
+	})
+	var lines [][]pdfTextRun
+	for _, r := range sorted {
+		if strings.TrimSpace(r.text) == "" {
+			continue
+		}
+		n := len(lines)
+		if n > 0 && lines[n-1][0].page == r.page && math.Abs(lines[n-1][0].y-r.y) < r.size*0.5 {
+			lines[n-1] = append(lines[n-1], r)
+			continue
+		}
+		lines = append(lines, []pdfTextRun{r})
+	}
+	for _, line := range lines {
+		sort.Slice(line, func(i, j int) bool { return line[i].x < line[j].x })
+	}
+	return lines
+}
+
+// pdfRunsText joins runs in content order, starting a new line when the baseline moves
+// and inserting a space across horizontal gaps.
+func pdfRunsText(runs []pdfTextRun) string {
+	var b strings.Builder
+	for i, r := range runs {
+		if i > 0 {
+			prev := runs[i-1]
+			switch {
+			case r.page != prev.page:
+				b.WriteString("\n\n")
+			case math.Abs(r.y-prev.y) >= r.size*0.5:
+				b.WriteString("\n")
+			case r.x-(prev.x+prev.width) > r.size*0.2 && !strings.HasSuffix(prev.text, " ") && !strings.HasPrefix(r.text, " "):
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString(r.text)
+	}
+	lines := strings.Split(b.String(), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -26,6 +26,7 @@ type SkillSummary struct {
 	Name     string `json:"name"`
 	Category string `json:"category"`
 	Level    string `json:"level"`
+	LastUsed string `json:"last_used,omitempty"`
 }
 
 // ProjectSummary is a compact view of a project for profile output.
@@ -65,13 +66,15 @@ type CertificationSummary struct {
 
 // ResumeProfileResult is the structured output of resume_profile.
 type ResumeProfileResult struct {
-	PersonID   int               `json:"person_id"`
-	Name       string            `json:"name"`
-	Email      string            `json:"email,omitempty"`
-	Location   string            `json:"location,omitempty"`
-	Links      map[string]string `json:"links,omitempty"`
-	Summary    string            `json:"summary,omitempty"`
-	EnrichedAt string            `json:"enriched_at,omitempty"`
+	PersonID          int                 `json:"person_id"`
+	Name              string              `json:"name"`
+	Email             string              `json:"email,omitempty"`
+	Location          string              `json:"location,omitempty"`
+	Links             map[string]string   `json:"links,omitempty"`
+	Summary           string              `json:"summary,omitempty"`
+	Languages         []LanguageSkill     `json:"languages,omitempty"`
+	WorkAuthorization []WorkAuthorization `json:"work_authorization,omitempty"`
+	EnrichedAt        string              `json:"enriched_at,omitempty"`
 
 	Experiences    []ExperienceSummary    `json:"experiences,omitempty"`
 	Skills         []SkillSummary         `json:"skills,omitempty"`
@@ -81,6 +84,10 @@ type ResumeProfileResult struct {
 	Certifications []CertificationSummary `json:"certifications,omitempty"`
 	Domains        []string               `json:"domains,omitempty"`
 	Methodologies  []string               `json:"methodologies,omitempty"`
+	Publications   []PublicationRecord    `json:"publications,omitempty"`
+	Talks          []TalkRecord           `json:"talks,omitempty"`
+	Patents        []PatentRecord         `json:"patents,omitempty"`
+	OpenSource     []OpenSourceRecord     `json:"open_source,omitempty"`
 
 	Stats struct {
 		TotalExperiences int `json:"total_experiences"`
@@ -93,7 +100,7 @@ type ResumeProfileResult struct {
 // GetResumeProfile reads the full resume profile from PostgreSQL.
 // If section is non-empty, only that section is loaded.
 func GetResumeProfile(ctx context.Context, section string) (*ResumeProfileResult, error) {
-	db := GetResumeDB()
+	db := StoresFrom(ctx).ResumeDB
 	if db == nil {
 		return nil, errors.New("resume database not configured (set DATABASE_URL)")
 	}
@@ -109,13 +116,15 @@ func GetResumeProfile(ctx context.Context, section string) (*ResumeProfileResult
 	}
 
 	result := &ResumeProfileResult{
-		PersonID:   person.ID,
-		Name:       person.Name,
-		Email:      person.Email,
-		Location:   person.Location,
-		Links:      person.Links,
-		Summary:    person.Summary,
-		EnrichedAt: db.GetPersonEnrichedAt(ctx, personID),
+		PersonID:          person.ID,
+		Name:              person.Name,
+		Email:             person.Email,
+		Location:          person.Location,
+		Links:             person.Links,
+		Summary:           person.Summary,
+		Languages:         person.Languages,
+		WorkAuthorization: person.WorkAuthorization,
+		EnrichedAt:        db.GetPersonEnrichedAt(ctx, personID),
 	}
 
 	sec := strings.ToLower(strings.TrimSpace(section))
@@ -147,10 +156,22 @@ func GetResumeProfile(ctx context.Context, section string) (*ResumeProfileResult
 	if sec == "" || sec == "methodologies" {
 		result.Methodologies = loadMethodologies(ctx, db, personID)
 	}
+	if sec == "" || sec == "publications" {
+		result.Publications, _ = db.GetAllPublications(ctx, personID)
+	}
+	if sec == "" || sec == "talks" {
+		result.Talks, _ = db.GetAllTalks(ctx, personID)
+	}
+	if sec == "" || sec == "patents" {
+		result.Patents, _ = db.GetAllPatents(ctx, personID)
+	}
+	if sec == "" || sec == "open_source" {
+		result.OpenSource, _ = db.GetAllOpenSource(ctx, personID)
+	}
 
 	// Approximate vector count via MemDB search (no dedicated count API).
 	if sec == "" {
-		if mdb := GetMemDB(); mdb != nil {
+		if mdb := StoresFrom(ctx).MemDB; mdb != nil {
 			all, err := mdb.Search(ctx, "resume experience project skill achievement", 100, 0.0)
 			if err == nil {
 				result.Stats.VectorsStored = len(all)
@@ -194,6 +215,7 @@ func loadSkills(ctx context.Context, db *ResumeDB, personID int) []SkillSummary
 			Name:     r.Name,
 			Category: r.Category,
 			Level:    r.Level,
+			LastUsed: r.LastUsed,
 		})
 	}
 	return out
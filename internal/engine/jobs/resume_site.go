@@ -0,0 +1,167 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResumeFeed is the public JSON representation of the master resume, served by /resume.json.
+type ResumeFeed struct {
+	Person         PersonRecord          `json:"person"`
+	Experiences    []ExperienceRecord    `json:"experiences"`
+	Projects       []ProjectRecord       `json:"projects"`
+	Achievements   []AchievementRecord   `json:"achievements"`
+	Skills         []SkillRecord         `json:"skills"`
+	Educations     []EducationRecord     `json:"educations"`
+	Certifications []CertificationRecord `json:"certifications"`
+	Version        string                `json:"version"`
+	GeneratedAt    string                `json:"generated_at"`
+}
+
+// renderedResume caches the last rendered site keyed by the feed version.
+type renderedResume struct {
+	version string
+	feed    []byte
+	html    []byte
+}
+
+var (
+	resumeSiteMu    sync.Mutex
+	resumeSiteCache renderedResume
+)
+
+// BuildResumeFeed assembles the resume feed for the latest person in ResumeDB.
+// Version is a content hash, so it only changes when the underlying data changes.
+func BuildResumeFeed(ctx context.Context) (*ResumeFeed, error) {
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return nil, errors.New("resume database not configured (set DATABASE_URL)")
+	}
+	personID := db.GetLatestPersonID(ctx)
+	if personID == 0 {
+		return nil, errors.New("no master resume found — run master_resume_build first")
+	}
+
+	person, err := db.GetPerson(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("resume feed: get person: %w", err)
+	}
+
+	feed := &ResumeFeed{Person: *person}
+	feed.Experiences, _ = db.GetAllExperiences(ctx, personID)
+	feed.Projects, _ = db.GetAllProjects(ctx, personID)
+	feed.Achievements, _ = db.GetAllAchievements(ctx, personID)
+	feed.Skills, _ = db.GetAllSkills(ctx, personID)
+	feed.Educations, _ = db.GetAllEducations(ctx, personID)
+	feed.Certifications, _ = db.GetAllCertifications(ctx, personID)
+
+	data, err := json.Marshal(feed)
+	if err != nil {
+		return nil, fmt.Errorf("resume feed: marshal: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	feed.Version = hex.EncodeToString(sum[:8])
+	feed.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+	return feed, nil
+}
+
+// ResumeFeedText flattens the feed into plain text for keyword matching.
+func ResumeFeedText(feed *ResumeFeed) string {
+	parts := []string{feed.Person.Summary}
+	for _, e := range feed.Experiences {
+		parts = append(parts, e.Title, e.Description)
+		parts = append(parts, e.Highlights...)
+	}
+	for _, p := range feed.Projects {
+		parts = append(parts, p.Name, p.Description)
+		parts = append(parts, p.Tech...)
+	}
+	for _, s := range feed.Skills {
+		parts = append(parts, s.Name)
+	}
+	for _, a := range feed.Achievements {
+		parts = append(parts, a.Text)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// RenderedResumeSite returns the JSON feed and HTML page for the master resume.
+// Rendering is skipped when the data version matches the cached one.
+func RenderedResumeSite(ctx context.Context) (version string, feedJSON, page []byte, err error) {
+	feed, err := BuildResumeFeed(ctx)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	resumeSiteMu.Lock()
+	defer resumeSiteMu.Unlock()
+
+	if resumeSiteCache.version == feed.Version {
+		return resumeSiteCache.version, resumeSiteCache.feed, resumeSiteCache.html, nil
+	}
+
+	feedJSON, err = json.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("resume feed: marshal: %w", err)
+	}
+	page, err = RenderResumeHTML(feed)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	resumeSiteCache = renderedResume{version: feed.Version, feed: feedJSON, html: page}
+	return feed.Version, feedJSON, page, nil
+}
+
+var resumeHTMLTemplate = template.Must(template.New("resume").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Person.Name}} — Resume</title>
+<style>
+body{font-family:system-ui,sans-serif;max-width:760px;margin:2rem auto;padding:0 1rem;line-height:1.5;color:#222}
+h1{margin-bottom:0}h2{border-bottom:1px solid #ddd;margin-top:2rem}
+.meta{color:#666}.item{margin-bottom:1rem}.skills span{display:inline-block;margin:0 .4rem .4rem 0;padding:.1rem .5rem;background:#f0f0f0;border-radius:4px}
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Person.Name}}</h1>
+<p class="meta">{{.Person.Location}}{{if .Person.Email}} · <a href="mailto:{{.Person.Email}}">{{.Person.Email}}</a>{{end}}{{range $k, $v := .Person.Links}} · <a href="{{$v}}">{{$k}}</a>{{end}}</p>
+{{if .Person.Summary}}<p>{{.Person.Summary}}</p>{{end}}
+</header>
+{{if .Experiences}}<h2>Experience</h2>
+{{range .Experiences}}<div class="item"><strong>{{.Title}}</strong> — {{.Company}} <span class="meta">({{.StartDate}} – {{.EndDate}})</span>
+{{if .Description}}<p>{{.Description}}</p>{{end}}{{if .Highlights}}<ul>{{range .Highlights}}<li>{{.}}</li>{{end}}</ul>{{end}}</div>
+{{end}}{{end}}
+{{if .Projects}}<h2>Projects</h2>
+{{range .Projects}}<div class="item"><strong>{{if .URL}}<a href="{{.URL}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</strong>{{if .Description}} — {{.Description}}{{end}}</div>
+{{end}}{{end}}
+{{if .Achievements}}<h2>Achievements</h2><ul>{{range .Achievements}}<li>{{.Text}}</li>{{end}}</ul>{{end}}
+{{if .Skills}}<h2>Skills</h2><p class="skills">{{range .Skills}}<span>{{.Name}}</span>{{end}}</p>{{end}}
+{{if .Educations}}<h2>Education</h2>
+{{range .Educations}}<div class="item"><strong>{{.Degree}}{{if .Field}}, {{.Field}}{{end}}</strong> — {{.School}} <span class="meta">({{.StartDate}} – {{.EndDate}})</span></div>
+{{end}}{{end}}
+{{if .Certifications}}<h2>Certifications</h2><ul>{{range .Certifications}}<li>{{.Name}}{{if .Issuer}} ({{.Issuer}}){{end}}{{if .Year}} {{.Year}}{{end}}</li>{{end}}</ul>{{end}}
+<footer class="meta"><small>Version {{.Version}} · generated {{.GeneratedAt}}</small></footer>
+</body>
+</html>
+`))
+
+// RenderResumeHTML renders the resume feed as a standalone HTML page.
+func RenderResumeHTML(feed *ResumeFeed) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := resumeHTMLTemplate.Execute(&buf, feed); err != nil {
+		return nil, fmt.Errorf("resume html: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderResumeHTML(t *testing.T) {
+	feed := &ResumeFeed{
+		Person: PersonRecord{
+			Name:    "Jane Doe",
+			Email:   "jane@example.com",
+			Summary: "Backend engineer <script>alert(1)</script>",
+			Links:   map[string]string{"github": "https://github.com/jane"},
+		},
+		Experiences: []ExperienceRecord{{
+			Title: "Senior Go Engineer", Company: "Acme", StartDate: "2021-01", EndDate: "Present",
+			Highlights: []string{"Cut p99 latency by 40%"},
+		}},
+		Skills:  []SkillRecord{{Name: "Go"}, {Name: "PostgreSQL"}},
+		Version: "abc123",
+	}
+
+	page, err := RenderResumeHTML(feed)
+	if err != nil {
+		t.Fatalf("RenderResumeHTML error: %v", err)
+	}
+	html := string(page)
+
+	for _, want := range []string{"Jane Doe", "Senior Go Engineer", "Cut p99 latency by 40%", "PostgreSQL", "https://github.com/jane", "abc123"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("rendered HTML missing %q", want)
+		}
+	}
+	if strings.Contains(html, "<script>") {
+		t.Error("summary was not HTML-escaped")
+	}
+}
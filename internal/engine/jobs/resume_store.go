@@ -0,0 +1,122 @@
+package jobs
+
+import "context"
+
+// --- Storage interfaces ---
+
+// ResumeStore is the resume storage used by master_resume_build, resume_generate and
+// resume_enrich: the SQL rows plus the skill graph. ResumeDB (Postgres + AGE)
+// implements it in production, MemoryResumeStore in the package tests. Maintenance tools
+// (status, rollback, backup) stay on *ResumeDB.
+type ResumeStore interface {
+	// WithTx runs fn in a transaction; fn's error rolls every row write back.
+	// Graph writes are not transactional.
+	WithTx(ctx context.Context, fn func(tx ResumeStore) error) error
+
+	// Persons and build state.
+	InsertPerson(ctx context.Context, p PersonRecord) (int, error)
+	GetPerson(ctx context.Context, personID int) (*PersonRecord, error)
+	GetLatestPersonID(ctx context.Context) int
+	ArchiveCurrentPersons(ctx context.Context, keep int) error
+	MarkPersonEnriched(ctx context.Context, personID int) error
+	SaveBuildPlan(ctx context.Context, personID int, plan []byte) error
+	MarkGraphSynced(ctx context.Context, personID int) error
+	MarkVectorsSynced(ctx context.Context, personID int) error
+
+	// Entity rows.
+	InsertExperience(ctx context.Context, personID int, e ExperienceRecord) (int, error)
+	UpdateExperienceMeta(ctx context.Context, expID int, teamSize, budgetUSD *int, domain string, isVolunteer bool) error
+	GetAllExperiences(ctx context.Context, personID int) ([]ExperienceRecord, error)
+	GetExperiencesByIDs(ctx context.Context, ids []int) ([]ExperienceRecord, error)
+	InsertSkillExtended(ctx context.Context, personID int, s SkillRecord) (int, error)
+	UpdateSkillLastUsed(ctx context.Context, skillID int, lastUsed string) error
+	GetAllSkills(ctx context.Context, personID int) ([]SkillRecord, error)
+	QuerySkillIDByName(ctx context.Context, personID int, skillName string) int
+	InsertProject(ctx context.Context, personID int, p ProjectRecord) (int, error)
+	InsertProjectWithParent(ctx context.Context, personID int, parentExpID *int, p ProjectRecord) (int, error)
+	GetAllProjects(ctx context.Context, personID int) ([]ProjectRecord, error)
+	GetProjectsByIDs(ctx context.Context, ids []int) ([]ProjectRecord, error)
+	InsertAchievementExtended(ctx context.Context, personID int, a AchievementRecord) (int, error)
+	UpdateAchievementText(ctx context.Context, achvID int, text string) error
+	UpdateAchievementMetric(ctx context.Context, achvID int, metricNumeric *float64, metricUnit string) error
+	GetAllAchievements(ctx context.Context, personID int) ([]AchievementRecord, error)
+	GetAchievementsByIDs(ctx context.Context, ids []int) ([]AchievementRecord, error)
+	InsertEducation(ctx context.Context, personID int, e EducationRecord) (int, error)
+	GetAllEducations(ctx context.Context, personID int) ([]EducationRecord, error)
+	InsertCertification(ctx context.Context, personID int, c CertificationRecord) (int, error)
+	GetAllCertifications(ctx context.Context, personID int) ([]CertificationRecord, error)
+	InsertDomain(ctx context.Context, personID int, name string) (int, error)
+	GetAllDomains(ctx context.Context, personID int) ([]DomainRecord, error)
+	InsertMethodology(ctx context.Context, personID int, name, desc string) (int, error)
+	GetAllMethodologies(ctx context.Context, personID int) ([]MethodologyRecord, error)
+	InsertPublication(ctx context.Context, personID int, p PublicationRecord) (int, error)
+	GetAllPublications(ctx context.Context, personID int) ([]PublicationRecord, error)
+	InsertTalk(ctx context.Context, personID int, t TalkRecord) (int, error)
+	GetAllTalks(ctx context.Context, personID int) ([]TalkRecord, error)
+	InsertPatent(ctx context.Context, personID int, p PatentRecord) (int, error)
+	GetAllPatents(ctx context.Context, personID int) ([]PatentRecord, error)
+	InsertOpenSource(ctx context.Context, personID int, o OpenSourceRecord) (int, error)
+	GetAllOpenSource(ctx context.Context, personID int) ([]OpenSourceRecord, error)
+
+	// Skill graph.
+	UpsertGraphNode(ctx context.Context, label string, id int, props map[string]string) error
+	UpsertGraphEdge(ctx context.Context, fromLabel string, fromID int, edgeLabel string, toLabel string, toID int) error
+	ApplyGraphOps(ctx context.Context, ops []graphOp) (int, error)
+	ClearGraph(ctx context.Context) error
+	CountGraphNodes(ctx context.Context) (int, error)
+	CountGraphEdges(ctx context.Context) (int, error)
+	QueryExperienceIDsBySkill(ctx context.Context, skillName string) ([]int, error)
+	QueryExperienceIDsBySkillID(ctx context.Context, skillID int) ([]int, error)
+	QueryProjectIDsBySkill(ctx context.Context, skillName string) ([]int, error)
+	QueryAchievementIDsByExperience(ctx context.Context, expID int) ([]int, error)
+	QueryImpliedSkillIDs(ctx context.Context, skillID int) ([]int, error)
+	QuerySubProjectIDs(ctx context.Context, expID int) ([]int, error)
+	QueryWorkIDsBySkill(ctx context.Context, label, skillName string) ([]int, error)
+}
+
+// VectorStore is the semantic memory holding resume vectors: MemDBClient in
+// production, MemoryVectorStore in the package tests.
+type VectorStore interface {
+	Add(ctx context.Context, content string, info map[string]any) (*AddResult, error)
+	AddBatch(ctx context.Context, items []MemDBItem) []error
+	Search(ctx context.Context, query string, topK int, relativity float64) ([]MemDBSearchResult, error)
+	ListAll(ctx context.Context, limit int) ([]MemDBSearchResult, error)
+	DeleteByUser(ctx context.Context, memoryIDs []string) error
+	ClearAllBySearch(ctx context.Context) error
+}
+
+var (
+	_ ResumeStore = (*ResumeDB)(nil)
+	_ VectorStore = (*MemDBClient)(nil)
+)
+
+// WithTx is InTx for ResumeStore callers.
+func (db *ResumeDB) WithTx(ctx context.Context, fn func(tx ResumeStore) error) error {
+	return db.InTx(ctx, func(tx *ResumeDB) error { return fn(tx) })
+}
+
+// resumeStore returns the store behind the master resume flows: Stores.Resume when
+// set, else the ResumeDB. Nil when neither is configured.
+func resumeStore(ctx context.Context) ResumeStore {
+	stores := StoresFrom(ctx)
+	if stores.Resume != nil {
+		return stores.Resume
+	}
+	if stores.ResumeDB != nil {
+		return stores.ResumeDB
+	}
+	return nil
+}
+
+// vectorStore returns Stores.Vectors when set, else the MemDB client. Nil when
+// neither is configured.
+func vectorStore(ctx context.Context) VectorStore {
+	stores := StoresFrom(ctx)
+	if stores.Vectors != nil {
+		return stores.Vectors
+	}
+	if stores.MemDB != nil {
+		return stores.MemDB
+	}
+	return nil
+}
@@ -0,0 +1,928 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// --- In-memory stores ---
+
+var (
+	_ ResumeStore = (*MemoryResumeStore)(nil)
+	_ VectorStore = (*MemoryVectorStore)(nil)
+)
+
+// MemoryResumeStore is a ResumeStore held in process memory, for tests of the master
+// resume flows without Postgres and AGE. It keeps the semantics those flows rely on:
+// rows need an existing person, skills, domains and methodologies are unique per
+// person and name, experiences come back most recent first, replaced builds are
+// archived, and graph edges are only merged between existing nodes.
+type MemoryResumeStore struct {
+	// Fail, when set, is called with the method name before every write; a non-nil
+	// error fails that write. Tests use it to exercise rollbacks.
+	Fail func(op string) error
+
+	mu    sync.Mutex
+	data  memResumeData
+	graph memGraph
+}
+
+// NewMemoryResumeStore returns an empty in-memory resume store.
+func NewMemoryResumeStore() *MemoryResumeStore {
+	return &MemoryResumeStore{graph: memGraph{
+		nodes: make(map[memNodeKey]map[string]string),
+		edges: make(map[memEdgeKey]bool),
+	}}
+}
+
+// memRow is one row of a table: the record with its ID and owning person.
+type memRow[T any] struct {
+	id       int
+	personID int
+	rec      T
+}
+
+// memPerson is a resume_persons row.
+type memPerson struct {
+	rec           PersonRecord
+	archived      int // archive order, 0 while current
+	enriched      bool
+	graphSynced   bool
+	vectorsSynced bool
+	plan          []byte
+}
+
+// memResumeData is the row state, copied whole to roll a transaction back.
+type memResumeData struct {
+	lastID         int
+	lastArchive    int
+	persons        []memPerson
+	experiences    []memRow[ExperienceRecord]
+	skills         []memRow[SkillRecord]
+	projects       []memRow[ProjectRecord]
+	achievements   []memRow[AchievementRecord]
+	educations     []memRow[EducationRecord]
+	certifications []memRow[CertificationRecord]
+	domains        []memRow[DomainRecord]
+	methodologies  []memRow[MethodologyRecord]
+	publications   []memRow[PublicationRecord]
+	talks          []memRow[TalkRecord]
+	patents        []memRow[PatentRecord]
+	openSource     []memRow[OpenSourceRecord]
+}
+
+func (d *memResumeData) clone() memResumeData {
+	c := *d
+	c.persons = slices.Clone(d.persons)
+	c.experiences = slices.Clone(d.experiences)
+	c.skills = slices.Clone(d.skills)
+	c.projects = slices.Clone(d.projects)
+	c.achievements = slices.Clone(d.achievements)
+	c.educations = slices.Clone(d.educations)
+	c.certifications = slices.Clone(d.certifications)
+	c.domains = slices.Clone(d.domains)
+	c.methodologies = slices.Clone(d.methodologies)
+	c.publications = slices.Clone(d.publications)
+	c.talks = slices.Clone(d.talks)
+	c.patents = slices.Clone(d.patents)
+	c.openSource = slices.Clone(d.openSource)
+	return c
+}
+
+func (d *memResumeData) person(personID int) *memPerson {
+	for i := range d.persons {
+		if d.persons[i].rec.ID == personID {
+			return &d.persons[i]
+		}
+	}
+	return nil
+}
+
+// newRow checks the owning person exists, as the foreign key does, and allocates an ID.
+func (d *memResumeData) newRow(personID int) (int, error) {
+	if d.person(personID) == nil {
+		return 0, fmt.Errorf("person %d does not exist", personID)
+	}
+	d.lastID++
+	return d.lastID, nil
+}
+
+// dropPersons deletes the persons in gone with all their rows.
+func (d *memResumeData) dropPersons(gone map[int]bool) {
+	d.persons = slices.DeleteFunc(d.persons, func(p memPerson) bool { return gone[p.rec.ID] })
+	d.experiences = dropRows(d.experiences, gone)
+	d.skills = dropRows(d.skills, gone)
+	d.projects = dropRows(d.projects, gone)
+	d.achievements = dropRows(d.achievements, gone)
+	d.educations = dropRows(d.educations, gone)
+	d.certifications = dropRows(d.certifications, gone)
+	d.domains = dropRows(d.domains, gone)
+	d.methodologies = dropRows(d.methodologies, gone)
+	d.publications = dropRows(d.publications, gone)
+	d.talks = dropRows(d.talks, gone)
+	d.patents = dropRows(d.patents, gone)
+	d.openSource = dropRows(d.openSource, gone)
+}
+
+func dropRows[T any](rows []memRow[T], gone map[int]bool) []memRow[T] {
+	return slices.DeleteFunc(rows, func(r memRow[T]) bool { return gone[r.personID] })
+}
+
+// rowsOf returns the records of a person in ID order.
+func rowsOf[T any](rows []memRow[T], personID int) []T {
+	var out []T
+	for _, r := range rows {
+		if r.personID == personID {
+			out = append(out, r.rec)
+		}
+	}
+	return out
+}
+
+// rowsByID returns the records with the given IDs in ID order.
+func rowsByID[T any](rows []memRow[T], ids []int) []T {
+	if len(ids) == 0 {
+		return nil
+	}
+	var out []T
+	for _, r := range rows {
+		if slices.Contains(ids, r.id) {
+			out = append(out, r.rec)
+		}
+	}
+	return out
+}
+
+// rowByID returns the record with the given ID for update, nil if none.
+func rowByID[T any](rows []memRow[T], id int) *T {
+	for i := range rows {
+		if rows[i].id == id {
+			return &rows[i].rec
+		}
+	}
+	return nil
+}
+
+// sortExperiences applies experienceOrder: ongoing roles first, then by end and
+// start date, most recent first, rows without parsed dates last.
+func sortExperiences(exps []ExperienceRecord) {
+	sort.SliceStable(exps, func(i, j int) bool {
+		a, b := exps[i], exps[j]
+		if pa, pb := a.EndPrecision == DatePrecisionPresent, b.EndPrecision == DatePrecisionPresent; pa != pb {
+			return pa
+		}
+		if a.EndOn != b.EndOn {
+			return b.EndOn == "" || (a.EndOn != "" && a.EndOn > b.EndOn)
+		}
+		if a.StartOn != b.StartOn {
+			return b.StartOn == "" || (a.StartOn != "" && a.StartOn > b.StartOn)
+		}
+		return a.ID < b.ID
+	})
+}
+
+// write locks the store and runs the Fail hook for op. Callers unlock.
+func (s *MemoryResumeStore) write(op string) error {
+	s.mu.Lock()
+	if s.Fail != nil {
+		if err := s.Fail(op); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+// WithTx runs fn against the store and restores the rows it held before when fn
+// fails. Graph writes are kept, as with AGE. Concurrent writers are not isolated.
+func (s *MemoryResumeStore) WithTx(_ context.Context, fn func(tx ResumeStore) error) error {
+	s.mu.Lock()
+	saved := s.data.clone()
+	s.mu.Unlock()
+	if err := fn(s); err != nil {
+		s.mu.Lock()
+		s.data = saved
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// --- Persons and build state ---
+
+func (s *MemoryResumeStore) InsertPerson(_ context.Context, p PersonRecord) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertPerson"); err != nil {
+		return 0, err
+	}
+	s.data.lastID++
+	p.ID = s.data.lastID
+	s.data.persons = append(s.data.persons, memPerson{rec: p})
+	return p.ID, nil
+}
+
+func (s *MemoryResumeStore) GetPerson(_ context.Context, personID int) (*PersonRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.data.person(personID)
+	if p == nil {
+		return nil, fmt.Errorf("person %d not found", personID)
+	}
+	rec := p.rec
+	return &rec, nil
+}
+
+func (s *MemoryResumeStore) GetLatestPersonID(_ context.Context) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := 0
+	for _, p := range s.data.persons {
+		if p.archived == 0 && p.rec.ID > id {
+			id = p.rec.ID
+		}
+	}
+	return id
+}
+
+func (s *MemoryResumeStore) ArchiveCurrentPersons(_ context.Context, keep int) error {
+	defer s.mu.Unlock()
+	if err := s.write("ArchiveCurrentPersons"); err != nil {
+		return err
+	}
+	var archived []memPerson
+	for i := range s.data.persons {
+		p := &s.data.persons[i]
+		if p.archived == 0 {
+			s.data.lastArchive++
+			p.archived = s.data.lastArchive
+		}
+		archived = append(archived, *p)
+	}
+	sort.Slice(archived, func(i, j int) bool { return archived[i].archived > archived[j].archived })
+	gone := make(map[int]bool)
+	for _, p := range archived[min(keep, len(archived)):] {
+		gone[p.rec.ID] = true
+	}
+	s.data.dropPersons(gone)
+	return nil
+}
+
+// IsArchived reports whether a person exists and belongs to an archived build.
+func (s *MemoryResumeStore) IsArchived(personID int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.data.person(personID)
+	return p != nil && p.archived > 0
+}
+
+func (s *MemoryResumeStore) setPerson(op string, personID int, set func(p *memPerson)) error {
+	defer s.mu.Unlock()
+	if err := s.write(op); err != nil {
+		return err
+	}
+	if p := s.data.person(personID); p != nil {
+		set(p)
+	}
+	return nil
+}
+
+func (s *MemoryResumeStore) MarkPersonEnriched(_ context.Context, personID int) error {
+	return s.setPerson("MarkPersonEnriched", personID, func(p *memPerson) { p.enriched = true })
+}
+
+func (s *MemoryResumeStore) SaveBuildPlan(_ context.Context, personID int, plan []byte) error {
+	return s.setPerson("SaveBuildPlan", personID, func(p *memPerson) { p.plan = slices.Clone(plan) })
+}
+
+func (s *MemoryResumeStore) MarkGraphSynced(_ context.Context, personID int) error {
+	return s.setPerson("MarkGraphSynced", personID, func(p *memPerson) { p.graphSynced = true })
+}
+
+func (s *MemoryResumeStore) MarkVectorsSynced(_ context.Context, personID int) error {
+	return s.setPerson("MarkVectorsSynced", personID, func(p *memPerson) { p.vectorsSynced = true })
+}
+
+// PersonState reports the enriched and synced markers of a person.
+func (s *MemoryResumeStore) PersonState(personID int) (enriched, graphSynced, vectorsSynced bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p := s.data.person(personID); p != nil {
+		return p.enriched, p.graphSynced, p.vectorsSynced
+	}
+	return false, false, false
+}
+
+// --- Entity rows ---
+
+func (s *MemoryResumeStore) InsertExperience(_ context.Context, personID int, e ExperienceRecord) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertExperience"); err != nil {
+		return 0, err
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	e.ID, e.PersonID = id, personID
+	e.StartOn, e.EndOn, e.StartPrecision, e.EndPrecision = "", "", "", ""
+	startOn, endOn, startPrec, endPrec := experienceDateValues(e)
+	if startOn != nil {
+		e.StartOn, e.StartPrecision = startOn.Format("2006-01-02"), *startPrec
+	}
+	if endPrec != nil {
+		e.EndPrecision = *endPrec
+	}
+	if endOn != nil {
+		e.EndOn = endOn.Format("2006-01-02")
+	}
+	s.data.experiences = append(s.data.experiences, memRow[ExperienceRecord]{id, personID, e})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) UpdateExperienceMeta(_ context.Context, expID int, teamSize, budgetUSD *int, domain string, isVolunteer bool) error {
+	defer s.mu.Unlock()
+	if err := s.write("UpdateExperienceMeta"); err != nil {
+		return err
+	}
+	if e := rowByID(s.data.experiences, expID); e != nil {
+		e.TeamSize, e.BudgetUSD, e.Domain, e.IsVolunteer = teamSize, budgetUSD, domain, isVolunteer
+	}
+	return nil
+}
+
+func (s *MemoryResumeStore) GetAllExperiences(_ context.Context, personID int) ([]ExperienceRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exps := rowsOf(s.data.experiences, personID)
+	sortExperiences(exps)
+	return exps, nil
+}
+
+func (s *MemoryResumeStore) GetExperiencesByIDs(_ context.Context, ids []int) ([]ExperienceRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exps := rowsByID(s.data.experiences, ids)
+	sortExperiences(exps)
+	return exps, nil
+}
+
+func (s *MemoryResumeStore) InsertSkillExtended(_ context.Context, personID int, sk SkillRecord) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertSkillExtended"); err != nil {
+		return 0, err
+	}
+	for i := range s.data.skills {
+		r := &s.data.skills[i]
+		if r.personID == personID && r.rec.Name == sk.Name {
+			r.rec.Category, r.rec.Level, r.rec.IsImplicit, r.rec.Source = sk.Category, sk.Level, sk.IsImplicit, sk.Source
+			return r.id, nil
+		}
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	sk.ID, sk.PersonID, sk.LastUsed = id, personID, ""
+	s.data.skills = append(s.data.skills, memRow[SkillRecord]{id, personID, sk})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) UpdateSkillLastUsed(_ context.Context, skillID int, lastUsed string) error {
+	defer s.mu.Unlock()
+	if err := s.write("UpdateSkillLastUsed"); err != nil {
+		return err
+	}
+	if sk := rowByID(s.data.skills, skillID); sk != nil {
+		sk.LastUsed = lastUsed
+	}
+	return nil
+}
+
+func (s *MemoryResumeStore) GetAllSkills(_ context.Context, personID int) ([]SkillRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsOf(s.data.skills, personID), nil
+}
+
+func (s *MemoryResumeStore) QuerySkillIDByName(_ context.Context, personID int, skillName string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.data.skills {
+		if r.personID == personID && strings.EqualFold(r.rec.Name, skillName) {
+			return r.id
+		}
+	}
+	return 0
+}
+
+func (s *MemoryResumeStore) InsertProject(ctx context.Context, personID int, p ProjectRecord) (int, error) {
+	return s.InsertProjectWithParent(ctx, personID, nil, p)
+}
+
+func (s *MemoryResumeStore) InsertProjectWithParent(_ context.Context, personID int, parentExpID *int, p ProjectRecord) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertProject"); err != nil {
+		return 0, err
+	}
+	if parentExpID != nil && rowByID(s.data.experiences, *parentExpID) == nil {
+		return 0, fmt.Errorf("parent experience %d does not exist", *parentExpID)
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	p.ID, p.PersonID, p.ParentExperienceID = id, personID, parentExpID
+	s.data.projects = append(s.data.projects, memRow[ProjectRecord]{id, personID, p})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) GetAllProjects(_ context.Context, personID int) ([]ProjectRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsOf(s.data.projects, personID), nil
+}
+
+func (s *MemoryResumeStore) GetProjectsByIDs(_ context.Context, ids []int) ([]ProjectRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsByID(s.data.projects, ids), nil
+}
+
+func (s *MemoryResumeStore) InsertAchievementExtended(_ context.Context, personID int, a AchievementRecord) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertAchievementExtended"); err != nil {
+		return 0, err
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	a.ID, a.PersonID = id, personID
+	s.data.achievements = append(s.data.achievements, memRow[AchievementRecord]{id, personID, a})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) UpdateAchievementText(_ context.Context, achvID int, text string) error {
+	defer s.mu.Unlock()
+	if err := s.write("UpdateAchievementText"); err != nil {
+		return err
+	}
+	if a := rowByID(s.data.achievements, achvID); a != nil {
+		a.Text = text
+	}
+	return nil
+}
+
+func (s *MemoryResumeStore) UpdateAchievementMetric(_ context.Context, achvID int, metricNumeric *float64, metricUnit string) error {
+	defer s.mu.Unlock()
+	if err := s.write("UpdateAchievementMetric"); err != nil {
+		return err
+	}
+	if a := rowByID(s.data.achievements, achvID); a != nil {
+		a.MetricNumeric, a.MetricUnit = metricNumeric, metricUnit
+	}
+	return nil
+}
+
+func (s *MemoryResumeStore) GetAllAchievements(_ context.Context, personID int) ([]AchievementRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsOf(s.data.achievements, personID), nil
+}
+
+func (s *MemoryResumeStore) GetAchievementsByIDs(_ context.Context, ids []int) ([]AchievementRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsByID(s.data.achievements, ids), nil
+}
+
+func (s *MemoryResumeStore) InsertEducation(_ context.Context, personID int, e EducationRecord) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertEducation"); err != nil {
+		return 0, err
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	e.ID, e.PersonID = id, personID
+	s.data.educations = append(s.data.educations, memRow[EducationRecord]{id, personID, e})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) GetAllEducations(_ context.Context, personID int) ([]EducationRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsOf(s.data.educations, personID), nil
+}
+
+func (s *MemoryResumeStore) InsertCertification(_ context.Context, personID int, c CertificationRecord) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertCertification"); err != nil {
+		return 0, err
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	c.ID, c.PersonID = id, personID
+	s.data.certifications = append(s.data.certifications, memRow[CertificationRecord]{id, personID, c})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) GetAllCertifications(_ context.Context, personID int) ([]CertificationRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsOf(s.data.certifications, personID), nil
+}
+
+func (s *MemoryResumeStore) InsertDomain(_ context.Context, personID int, name string) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertDomain"); err != nil {
+		return 0, err
+	}
+	for _, r := range s.data.domains {
+		if r.personID == personID && r.rec.Name == name {
+			return r.id, nil
+		}
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	s.data.domains = append(s.data.domains, memRow[DomainRecord]{id, personID, DomainRecord{ID: id, Name: name}})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) GetAllDomains(_ context.Context, personID int) ([]DomainRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsOf(s.data.domains, personID), nil
+}
+
+func (s *MemoryResumeStore) InsertMethodology(_ context.Context, personID int, name, desc string) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertMethodology"); err != nil {
+		return 0, err
+	}
+	for i := range s.data.methodologies {
+		r := &s.data.methodologies[i]
+		if r.personID == personID && r.rec.Name == name {
+			r.rec.Description = desc
+			return r.id, nil
+		}
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	rec := MethodologyRecord{ID: id, Name: name, Description: desc}
+	s.data.methodologies = append(s.data.methodologies, memRow[MethodologyRecord]{id, personID, rec})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) GetAllMethodologies(_ context.Context, personID int) ([]MethodologyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsOf(s.data.methodologies, personID), nil
+}
+
+func (s *MemoryResumeStore) InsertPublication(_ context.Context, personID int, p PublicationRecord) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertPublication"); err != nil {
+		return 0, err
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	p.ID, p.PersonID = id, personID
+	s.data.publications = append(s.data.publications, memRow[PublicationRecord]{id, personID, p})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) GetAllPublications(_ context.Context, personID int) ([]PublicationRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsOf(s.data.publications, personID), nil
+}
+
+func (s *MemoryResumeStore) InsertTalk(_ context.Context, personID int, t TalkRecord) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertTalk"); err != nil {
+		return 0, err
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	t.ID, t.PersonID = id, personID
+	s.data.talks = append(s.data.talks, memRow[TalkRecord]{id, personID, t})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) GetAllTalks(_ context.Context, personID int) ([]TalkRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsOf(s.data.talks, personID), nil
+}
+
+func (s *MemoryResumeStore) InsertPatent(_ context.Context, personID int, p PatentRecord) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertPatent"); err != nil {
+		return 0, err
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	p.ID, p.PersonID = id, personID
+	s.data.patents = append(s.data.patents, memRow[PatentRecord]{id, personID, p})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) GetAllPatents(_ context.Context, personID int) ([]PatentRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsOf(s.data.patents, personID), nil
+}
+
+func (s *MemoryResumeStore) InsertOpenSource(_ context.Context, personID int, o OpenSourceRecord) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("InsertOpenSource"); err != nil {
+		return 0, err
+	}
+	id, err := s.data.newRow(personID)
+	if err != nil {
+		return 0, err
+	}
+	o.ID, o.PersonID = id, personID
+	s.data.openSource = append(s.data.openSource, memRow[OpenSourceRecord]{id, personID, o})
+	return id, nil
+}
+
+func (s *MemoryResumeStore) GetAllOpenSource(_ context.Context, personID int) ([]OpenSourceRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rowsOf(s.data.openSource, personID), nil
+}
+
+// --- Skill graph ---
+
+type memNodeKey struct {
+	label string
+	id    int
+}
+
+type memEdgeKey struct {
+	from memNodeKey
+	edge string
+	to   memNodeKey
+}
+
+// memGraph is the resume graph: nodes by label and id with their properties, and
+// directed, typed edges.
+type memGraph struct {
+	nodes map[memNodeKey]map[string]string
+	edges map[memEdgeKey]bool
+}
+
+func (g *memGraph) upsertNode(label string, id int, props map[string]string) {
+	k := memNodeKey{label, id}
+	if g.nodes[k] == nil {
+		g.nodes[k] = make(map[string]string, len(props))
+	}
+	maps.Copy(g.nodes[k], props)
+}
+
+// upsertEdge merges an edge; like MATCH ... MERGE it does nothing when either node is missing.
+func (g *memGraph) upsertEdge(fromLabel string, fromID int, edge, toLabel string, toID int) {
+	from, to := memNodeKey{fromLabel, fromID}, memNodeKey{toLabel, toID}
+	if g.nodes[from] == nil || g.nodes[to] == nil {
+		return
+	}
+	g.edges[memEdgeKey{from, edge, to}] = true
+}
+
+// match returns the sorted, distinct IDs at one end of the edges that match: the
+// source IDs when the target is given, else the target IDs. An empty edge matches any type.
+func (g *memGraph) match(from func(memNodeKey) bool, edge string, to func(memNodeKey) bool, sources bool) []int {
+	seen := make(map[int]bool)
+	for e := range g.edges {
+		if (edge != "" && e.edge != edge) || !from(e.from) || !to(e.to) {
+			continue
+		}
+		if sources {
+			seen[e.from.id] = true
+		} else {
+			seen[e.to.id] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+func hasLabel(label string) func(memNodeKey) bool {
+	return func(k memNodeKey) bool { return k.label == label }
+}
+
+func isNode(label string, id int) func(memNodeKey) bool {
+	return func(k memNodeKey) bool { return k == memNodeKey{label, id} }
+}
+
+func (g *memGraph) skillNamed(name string) func(memNodeKey) bool {
+	return func(k memNodeKey) bool { return k.label == "Skill" && g.nodes[k]["name"] == name }
+}
+
+func (s *MemoryResumeStore) UpsertGraphNode(_ context.Context, label string, id int, props map[string]string) error {
+	defer s.mu.Unlock()
+	if err := s.write("UpsertGraphNode"); err != nil {
+		return err
+	}
+	s.graph.upsertNode(label, id, props)
+	return nil
+}
+
+func (s *MemoryResumeStore) UpsertGraphEdge(_ context.Context, fromLabel string, fromID int, edgeLabel string, toLabel string, toID int) error {
+	defer s.mu.Unlock()
+	if err := s.write("UpsertGraphEdge"); err != nil {
+		return err
+	}
+	s.graph.upsertEdge(fromLabel, fromID, edgeLabel, toLabel, toID)
+	return nil
+}
+
+// ApplyGraphOps merges nodes before edges, as ResumeDB.ApplyGraphOps does.
+func (s *MemoryResumeStore) ApplyGraphOps(_ context.Context, ops []graphOp) (int, error) {
+	defer s.mu.Unlock()
+	if err := s.write("ApplyGraphOps"); err != nil {
+		return len(ops), err
+	}
+	for _, op := range ops {
+		if op.Edge == "" {
+			s.graph.upsertNode(op.Label, op.ID, op.Props)
+		}
+	}
+	for _, op := range ops {
+		if op.Edge != "" {
+			s.graph.upsertEdge(op.Label, op.ID, op.Edge, op.ToLabel, op.ToID)
+		}
+	}
+	return 0, nil
+}
+
+func (s *MemoryResumeStore) ClearGraph(_ context.Context) error {
+	defer s.mu.Unlock()
+	if err := s.write("ClearGraph"); err != nil {
+		return err
+	}
+	clear(s.graph.nodes)
+	clear(s.graph.edges)
+	return nil
+}
+
+func (s *MemoryResumeStore) CountGraphNodes(_ context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.graph.nodes), nil
+}
+
+func (s *MemoryResumeStore) CountGraphEdges(_ context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.graph.edges), nil
+}
+
+// GraphNode returns the properties of a node, nil if it does not exist.
+func (s *MemoryResumeStore) GraphNode(label string, id int) map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.graph.nodes[memNodeKey{label, id}])
+}
+
+func (s *MemoryResumeStore) QueryExperienceIDsBySkill(_ context.Context, skillName string) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.graph.match(hasLabel("Exp"), "USED_SKILL", s.graph.skillNamed(skillName), true), nil
+}
+
+func (s *MemoryResumeStore) QueryExperienceIDsBySkillID(_ context.Context, skillID int) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.graph.match(hasLabel("Exp"), "USED_SKILL", isNode("Skill", skillID), true), nil
+}
+
+func (s *MemoryResumeStore) QueryProjectIDsBySkill(_ context.Context, skillName string) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.graph.match(hasLabel("Proj"), "USED_SKILL", s.graph.skillNamed(skillName), true), nil
+}
+
+func (s *MemoryResumeStore) QueryAchievementIDsByExperience(_ context.Context, expID int) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.graph.match(isNode("Exp", expID), "PRODUCED", hasLabel("Achv"), false), nil
+}
+
+func (s *MemoryResumeStore) QueryImpliedSkillIDs(_ context.Context, skillID int) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.graph.match(isNode("Skill", skillID), "IMPLIES_SKILL", hasLabel("Skill"), false), nil
+}
+
+func (s *MemoryResumeStore) QuerySubProjectIDs(_ context.Context, expID int) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.graph.match(hasLabel("Proj"), "PART_OF", isNode("Exp", expID), true), nil
+}
+
+func (s *MemoryResumeStore) QueryWorkIDsBySkill(_ context.Context, label, skillName string) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.graph.match(hasLabel(label), "", s.graph.skillNamed(skillName), true), nil
+}
+
+// --- Vector store ---
+
+// MemoryVectorStore is a VectorStore held in process memory. Search ranks memories
+// by the share of their keywords found in the query, a stand-in for embeddings
+// that is enough to tell related resume items from unrelated ones.
+type MemoryVectorStore struct {
+	mu     sync.Mutex
+	lastID int
+	items  []MemDBSearchResult
+}
+
+// NewMemoryVectorStore returns an empty in-memory vector store.
+func NewMemoryVectorStore() *MemoryVectorStore { return &MemoryVectorStore{} }
+
+func (v *MemoryVectorStore) Add(_ context.Context, content string, info map[string]any) (*AddResult, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lastID++
+	id := fmt.Sprintf("mem-%d", v.lastID)
+	v.items = append(v.items, MemDBSearchResult{Content: content, Info: maps.Clone(info), MemoryID: id})
+	return &AddResult{MemoryID: id}, nil
+}
+
+func (v *MemoryVectorStore) AddBatch(ctx context.Context, items []MemDBItem) []error {
+	errs := make([]error, len(items))
+	for i, it := range items {
+		_, errs[i] = v.Add(ctx, it.Content, it.Info)
+	}
+	return errs
+}
+
+func (v *MemoryVectorStore) Search(_ context.Context, query string, topK int, relativity float64) ([]MemDBSearchResult, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	queryKW := extractMatchKW(query)
+	var out []MemDBSearchResult
+	for _, it := range v.items {
+		kw := extractMatchKW(it.Content)
+		hits := 0
+		for w := range kw {
+			if queryKW[w] {
+				hits++
+			}
+		}
+		if len(kw) > 0 {
+			it.Score = float64(hits) / float64(len(kw))
+		}
+		if it.Score >= relativity {
+			out = append(out, it)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	if topK > 0 && len(out) > topK {
+		out = out[:topK]
+	}
+	return out, nil
+}
+
+func (v *MemoryVectorStore) ListAll(_ context.Context, limit int) ([]MemDBSearchResult, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	out := slices.Clone(v.items)
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (v *MemoryVectorStore) DeleteByUser(_ context.Context, memoryIDs []string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.items = slices.DeleteFunc(v.items, func(it MemDBSearchResult) bool { return slices.Contains(memoryIDs, it.MemoryID) })
+	return nil
+}
+
+func (v *MemoryVectorStore) ClearAllBySearch(_ context.Context) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.items = nil
+	return nil
+}
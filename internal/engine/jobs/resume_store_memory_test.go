@@ -0,0 +1,235 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// stubLLM returns ctx bound to an engine whose LLM is a server answering each prompt
+// with reply(prompt), and the prompts it received.
+func stubLLM(ctx context.Context, t *testing.T, reply func(prompt string) string) (context.Context, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var prompts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []struct {
+				Content any `json:"content"`
+			} `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		var prompt strings.Builder
+		for _, m := range req.Messages {
+			if s, ok := m.Content.(string); ok {
+				prompt.WriteString(s)
+			}
+		}
+		mu.Lock()
+		prompts = append(prompts, prompt.String())
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"content": reply(prompt.String())}, "finish_reason": "stop"}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	e := engine.New(engine.Config{LLMAPIBase: srv.URL, LLMAPIKey: "test", LLMModel: "test"})
+	return engine.WithEngine(ctx, e), &prompts
+}
+
+// withMemoryStores returns a context bound to fresh in-memory resume and vector stores.
+func withMemoryStores(t *testing.T) (context.Context, *MemoryResumeStore, *MemoryVectorStore) {
+	t.Helper()
+	rs, vs := NewMemoryResumeStore(), NewMemoryVectorStore()
+	return WithStores(context.Background(), Stores{Resume: rs, Vectors: vs}), rs, vs
+}
+
+const testParsedResume = `{
+  "person": {"name": "Ada Example", "email": "ada@example.com", "summary": "Backend engineer"},
+  "experiences": [
+    {"title": "Senior Engineer", "company": "Acme", "start_date": "2021-03", "end_date": "",
+     "description": "Payments platform in Go", "highlights": ["Cut latency by 40%"], "skills": ["Go", "PostgreSQL"]},
+    {"title": "Engineer", "company": "Initech", "start_date": "2017-01", "end_date": "2021-02",
+     "description": "Reporting frontend", "highlights": ["Built dashboards"], "skills": ["React"]}
+  ],
+  "educations": [{"school": "State University", "degree": "BSc", "field": "Computer Science"}],
+  "skills": [{"name": "Go", "category": "language", "level": "expert"}],
+  "projects": [{"name": "ledger", "description": "Double-entry ledger", "tech": ["Go"]}],
+  "achievements": [{"text": "Cut payment latency by 40%", "metric": "latency", "value": "40%", "context": "Acme",
+    "metric_numeric": 40, "metric_unit": "%"}],
+  "domains": ["fintech"]
+}`
+
+const testEnrichment = `{
+  "implicit_skills": [{"name": "Distributed Systems", "category": "concept", "level": "advanced", "source": "Acme"}],
+  "sub_projects": [{"parent_experience": "Acme", "name": "settlement", "description": "Settlement service", "tech": ["Go"]}],
+  "skill_adjacencies": [{"from": "Go", "to": "Distributed Systems"}],
+  "methodologies": [{"name": "TDD", "description": "Test-first"}]
+}`
+
+// buildReply answers the two master_resume_build prompts.
+func buildReply(prompt string) string {
+	if strings.Contains(prompt, "expert career analyst") {
+		return testEnrichment
+	}
+	return testParsedResume
+}
+
+func TestBuildMasterResumeMemoryStore(t *testing.T) {
+	ctx, rs, vs := withMemoryStores(t)
+	ctx, _ = stubLLM(ctx, t, buildReply)
+
+	res, err := BuildMasterResume(ctx, "resume text")
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if res.Experiences != 2 || res.Educations != 1 || res.Achievements != 1 || res.SubProjects != 1 || res.Methodologies != 1 {
+		t.Errorf("unexpected counts: %+v", res)
+	}
+	if len(res.Warnings) > 0 {
+		t.Errorf("unexpected warnings: %v", res.Warnings)
+	}
+	if res.GraphNodes == 0 || res.GraphEdges == 0 || res.VectorsStored == 0 {
+		t.Errorf("graph or vectors not written: %+v", res)
+	}
+	if all, _ := vs.ListAll(ctx, 0); len(all) != res.VectorsStored {
+		t.Errorf("vector store holds %d items, result says %d", len(all), res.VectorsStored)
+	}
+	if _, graph, vectors := rs.PersonState(res.PersonID); !graph || !vectors {
+		t.Errorf("person not marked synced: graph=%v vectors=%v", graph, vectors)
+	}
+
+	exps, _ := rs.GetAllExperiences(ctx, res.PersonID)
+	if len(exps) != 2 || exps[0].Company != "Acme" {
+		t.Fatalf("ongoing role should come first: %+v", exps)
+	}
+	ids, _ := rs.QueryExperienceIDsBySkill(ctx, "Go")
+	if len(ids) != 1 || ids[0] != exps[0].ID {
+		t.Errorf("Go experiences = %v, want [%d]", ids, exps[0].ID)
+	}
+	if sub, _ := rs.QuerySubProjectIDs(ctx, exps[0].ID); len(sub) != 1 {
+		t.Errorf("sub-projects of Acme = %v, want one", sub)
+	}
+
+	// A rebuild archives the previous build instead of deleting it.
+	again, err := BuildMasterResume(ctx, "resume text")
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if rs.GetLatestPersonID(ctx) != again.PersonID || !rs.IsArchived(res.PersonID) {
+		t.Errorf("rebuild: latest=%d, first build archived=%v", rs.GetLatestPersonID(ctx), rs.IsArchived(res.PersonID))
+	}
+}
+
+func TestBuildMasterResumeMemoryStoreKeepsPreviousOnFailure(t *testing.T) {
+	ctx, rs, _ := withMemoryStores(t)
+	ctx, _ = stubLLM(ctx, t, buildReply)
+
+	first, err := BuildMasterResume(ctx, "resume text")
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	rs.Fail = func(op string) error {
+		if op == "InsertEducation" {
+			return errors.New("disk full")
+		}
+		return nil
+	}
+	if _, err := BuildMasterResume(ctx, "resume text"); err == nil || !strings.Contains(err.Error(), "previous resume kept") {
+		t.Fatalf("expected failed build, got %v", err)
+	}
+	if got := rs.GetLatestPersonID(ctx); got != first.PersonID {
+		t.Errorf("latest person = %d, want the previous build %d", got, first.PersonID)
+	}
+	if exps, _ := rs.GetAllExperiences(ctx, first.PersonID); len(exps) != 2 {
+		t.Errorf("previous build lost rows: %d experiences", len(exps))
+	}
+}
+
+func TestGenerateResumeMemoryStore(t *testing.T) {
+	ctx, _, _ := withMemoryStores(t)
+	ctx, prompts := stubLLM(ctx, t, func(prompt string) string {
+		switch {
+		case strings.Contains(prompt, "Analyze the following job description"):
+			return `{"required_skills": ["Go"], "nice_to_have": [], "role_title": "Backend Engineer", "seniority": "senior"}`
+		case strings.Contains(prompt, "expert ATS resume writer"):
+			return `{"resume": "Ada Example\nSenior Engineer, Acme\n- Cut payment latency by 40%", "ats_score": 80, "matched_keywords": ["Go"]}`
+		}
+		return buildReply(prompt)
+	})
+	if _, err := BuildMasterResume(ctx, "resume text"); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	*prompts = nil
+
+	res, err := GenerateResume(ctx, "We need a senior Go engineer for payments.", "", "text", MetricPolicyAsk)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if res.ATSScore != 80 || len(res.UnverifiedMetrics) != 0 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	// The graph picks the Go role; the unrelated React role is left out.
+	if res.SelectedItems.Experiences != 1 {
+		t.Errorf("selected %d experiences, want 1", res.SelectedItems.Experiences)
+	}
+	if len(*prompts) != 2 {
+		t.Fatalf("expected 2 LLM calls, got %d", len(*prompts))
+	}
+	assemble := (*prompts)[1]
+	if !strings.Contains(assemble, "Acme") || strings.Contains(assemble, "Initech") {
+		t.Errorf("assemble prompt has the wrong candidate data:\n%s", assemble)
+	}
+}
+
+func TestEnrichResumeMemoryStore(t *testing.T) {
+	ctx, rs, vs := withMemoryStores(t)
+	ctx, _ = stubLLM(ctx, t, func(prompt string) string {
+		if strings.Contains(prompt, "resume enrichment engine") {
+			return `{"updates": [
+				{"type": "add_skill", "name": "Kafka", "category": "tool", "level": "advanced"},
+				{"type": "update_achievement", "achievement_text": "Cut payment latency", "metric_numeric": 45, "metric_unit": "%", "new_text": "Cut payment latency by 45%"},
+				{"type": "add_project", "parent_experience": "Acme", "name": "fraud rules", "description": "Rule engine", "tech": ["Go"]},
+				{"type": "add_domain", "name": "payments"}
+			]}`
+		}
+		return buildReply(prompt)
+	})
+	built, err := BuildMasterResume(ctx, "resume text")
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	vectors := built.VectorsStored
+
+	res, err := EnrichResume(ctx, "answer", []AnswerPair{{QuestionID: "q1", Answer: "I also ran Kafka and the fraud rules engine."}})
+	if err != nil {
+		t.Fatalf("enrich: %v", err)
+	}
+	if res.Applied != 4 {
+		t.Errorf("applied %d updates, want 4", res.Applied)
+	}
+	if rs.QuerySkillIDByName(ctx, built.PersonID, "kafka") == 0 {
+		t.Error("Kafka skill not added")
+	}
+	achvs, _ := rs.GetAllAchievements(ctx, built.PersonID)
+	if len(achvs) != 1 || achvs[0].Text != "Cut payment latency by 45%" || achvs[0].MetricNumeric == nil || *achvs[0].MetricNumeric != 45 {
+		t.Errorf("achievement not updated: %+v", achvs)
+	}
+	exps, _ := rs.GetAllExperiences(ctx, built.PersonID)
+	if sub, _ := rs.QuerySubProjectIDs(ctx, exps[0].ID); len(sub) != 2 {
+		t.Errorf("sub-projects of Acme = %v, want the built and the enriched one", sub)
+	}
+	if all, _ := vs.ListAll(ctx, 0); len(all) != vectors+1 {
+		t.Errorf("vector store holds %d items, want %d", len(all), vectors+1)
+	}
+	if enriched, _, _ := rs.PersonState(built.PersonID); !enriched {
+		t.Error("person not marked enriched")
+	}
+}
@@ -1,9 +1,19 @@
 package jobs
 
 import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
 )
 
 // --- GenerateCoverLetter tone normalization ---
@@ -175,8 +185,8 @@ func TestResumeAnalyzePromptFormat(t *testing.T) {
 
 func TestCoverLetterPromptFormat(t *testing.T) {
 	count := strings.Count(coverLetterPrompt, "%s")
-	if count != 3 {
-		t.Errorf("coverLetterPrompt has %d %%s placeholders, want 3 (tone, resume, jd)", count)
+	if count != 5 {
+		t.Errorf("coverLetterPrompt has %d %%s placeholders, want 5 (tone, resume, jd, availability, output format)", count)
 	}
 }
 
@@ -279,3 +289,347 @@ func TestATSScoreBoundaries(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveResumeEvidence(t *testing.T) {
+	known := knownEvidence(
+		[]ExperienceRecord{{ID: 12}},
+		[]ProjectRecord{{ID: 3}},
+		[]AchievementRecord{{ID: 7}},
+	)
+	raw := []rawResumeClaim{
+		{Claim: "Cut p99 latency by 40% [A7]", Sources: []string{"A7", "[E12]", "A7"}},
+		{Claim: "Led a team of 50", Sources: []string{"E99"}},
+		{Claim: "Built the billing service", Sources: []string{"P3"}},
+	}
+	resume := "SUMMARY\nGo engineer.\n- Cut p99 latency by 40%\n- Led a team of 50\n• Built the billing service\n- Won a Turing award\n"
+
+	claims := resolveResumeEvidence(raw, known, resume)
+	if len(claims) != 4 {
+		t.Fatalf("expected 3 cited + 1 uncited claim, got %d: %+v", len(claims), claims)
+	}
+	if claims[0].Claim != "Cut p99 latency by 40%" || len(claims[0].Evidence) != 2 || !claims[0].Supported {
+		t.Errorf("claim 0 = %+v", claims[0])
+	}
+	if claims[0].Evidence[0] != (EvidenceRef{Type: "achievement", ID: 7}) {
+		t.Errorf("evidence = %+v", claims[0].Evidence)
+	}
+	if claims[1].Supported {
+		t.Error("claim citing an unknown record should be unsupported")
+	}
+	if claims[3].Claim != "Won a Turing award" || claims[3].Supported {
+		t.Errorf("uncited bullet should be reported unsupported, got %+v", claims[3])
+	}
+}
+
+func TestStripEvidenceTags(t *testing.T) {
+	if got := stripEvidenceTags("Shipped X [E1] and Y [P22]."); got != "Shipped X and Y." {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestResumeWorksRelevant(t *testing.T) {
+	stars := 1200
+	w := resumeWorks{
+		Publications: []PublicationRecord{{ID: 1, Title: "Consensus in practice", Topics: []string{"Raft"}}, {ID: 2, Title: "Poetry"}},
+		Talks:        []TalkRecord{{ID: 3, Title: "Scaling Go services", Event: "GopherCon"}},
+		Patents:      []PatentRecord{{ID: 4, Title: "Cache eviction method"}},
+		OpenSource:   []OpenSourceRecord{{ID: 5, Project: "fastkv", Stars: &stars, Tech: []string{"Rust"}}},
+	}
+	got := w.relevant(map[string]map[int]bool{"patent": {4: true}}, []string{"raft", " Go "})
+	if len(got.Publications) != 1 || got.Publications[0].ID != 1 {
+		t.Errorf("publications = %+v", got.Publications)
+	}
+	if len(got.Talks) != 1 || len(got.Patents) != 1 || len(got.OpenSource) != 0 {
+		t.Errorf("relevant = %+v", got)
+	}
+
+	known := map[string]EvidenceRef{}
+	got.addEvidence(known)
+	if known["[PUB1]"] != (EvidenceRef{Type: "publication", ID: 1}) || known["[PAT4]"].Type != "patent" {
+		t.Errorf("evidence = %+v", known)
+	}
+	var b strings.Builder
+	w.writeCandidateData(&b)
+	for _, want := range []string{"=== TALKS ===", "• [TALK3] Scaling Go services — GopherCon", "[OSS5] fastkv (1200 GitHub stars). Tech: Rust"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("candidate data missing %q:\n%s", want, b.String())
+		}
+	}
+	if got := stripEvidenceTags("Spoke at GopherCon [TALK3] [OSS5]."); got != "Spoke at GopherCon." {
+		t.Errorf("strip = %q", got)
+	}
+}
+
+func TestResumeNumbers(t *testing.T) {
+	got := resumeNumbers("Sold 16K tickets, grew revenue 40% to $1.2M in 2021, 3x faster on S3 and K8s, 24/7 on-call for 12,500 users")
+	want := map[string]float64{"16K": 16000, "40": 40, "1.2M": 1.2e6, "3x": 3, "12,500": 12500}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v", got)
+	}
+	for _, n := range got {
+		if want[n.Text] != n.Value {
+			t.Errorf("%s = %v, want %v", n.Text, n.Value, want[n.Text])
+		}
+	}
+}
+
+func TestCheckResumeMetrics(t *testing.T) {
+	metric := 16230.0
+	achvs := []AchievementRecord{{ID: 7, Text: "Sold 16,230 festival tickets", MetricNumeric: &metric}}
+	known := knownMetrics(achvs, "• [E1] Lead at Acme\n  - Managed a team of 5")
+	resume := "Jane Doe\n- Sold 16K tickets with zero budget\n- Grew signups by 300% in one quarter\n" +
+		"Engineer who led a team of 5 people. Cut costs by 45% across all products and teams."
+	claims := []ResumeClaim{{Claim: "Grew signups by 300% in one quarter", Evidence: []EvidenceRef{{Type: "achievement", ID: 7}}}}
+
+	ask := checkResumeMetrics(resume, MetricPolicyAsk, known, claims, achvs)
+	if ask.Resume != resume {
+		t.Errorf("ask policy changed the resume:\n%s", ask.Resume)
+	}
+	if len(ask.Unverified) != 2 || ask.Unverified[0].Number != "300" || ask.Unverified[1].Number != "45" {
+		t.Fatalf("unverified = %+v", ask.Unverified)
+	}
+	if len(ask.Questions) != 2 || ask.Questions[0].Category != "missing_metric" || ask.Questions[0].Context != "Achievement: Sold 16,230 festival tickets" {
+		t.Errorf("questions = %+v", ask.Questions)
+	}
+
+	rm := checkResumeMetrics(resume, MetricPolicyRemove, known, claims, achvs)
+	want := "Jane Doe\n- Sold 16K tickets with zero budget\nEngineer who led a team of 5 people."
+	if rm.Resume != want {
+		t.Errorf("remove policy resume =\n%s\nwant\n%s", rm.Resume, want)
+	}
+	if len(rm.Questions) != 0 || len(rm.Removed) != 2 || rm.Unverified[0].Action != "removed" {
+		t.Errorf("remove check = %+v", rm)
+	}
+}
+
+func TestProposalEvidenceOrdering(t *testing.T) {
+	projects := []ProjectRecord{
+		{Name: "Blog", Tech: []string{"Hug"}, URL: "https://blog.example"},
+		{Name: "Scraper", Tech: []string{"Go", "Colly", "Postgres"}, URL: "https://github.com/x/scraper"},
+		{Name: "Bot", Tech: []string{"Python"}},
+	}
+	rankProjectsByText(projects, "Need a Go developer (not Hugo) to build a scraper storing data in Postgres.")
+	if projects[0].Name != "Scraper" || projects[1].Name != "Blog" {
+		t.Errorf("ranked = %v, want Scraper first and ties in original order", []string{projects[0].Name, projects[1].Name, projects[2].Name})
+	}
+
+	links := proposalLinks([]string{"https://dribbble.com/me", " https://blog.example "}, projects)
+	want := []string{"https://dribbble.com/me", "https://blog.example", "https://github.com/x/scraper"}
+	if strings.Join(links, " ") != strings.Join(want, " ") {
+		t.Errorf("links = %v, want %v", links, want)
+	}
+}
+
+func TestLintResume(t *testing.T) {
+	resume := strings.Join([]string{
+		"Jane Doe — results-driven, passionate engineer",
+		"Acme, Jan 2020 – 03/2022",
+		"- I was responsible for the billing service, which was built in Go and handled payments for every customer across more than forty countries worldwide every single day of the year without downtime",
+		"- Fixed bugs",
+		"- Led migration of 12 services to Kubernetes, cutting deploy time by 40%",
+	}, "\n")
+	lint := LintResume(resume)
+
+	rules := make(map[string]int)
+	for _, f := range lint.Findings {
+		rules[f.Rule]++
+	}
+	want := map[string]int{LintBulletLength: 2, LintPassiveVoice: 1, LintFirstPerson: 1, LintBuzzwords: 1, LintDateFormat: 1}
+	for rule, n := range want {
+		if rules[rule] != n {
+			t.Errorf("%s findings = %d, want %d (all: %+v)", rule, rules[rule], n, lint.Findings)
+		}
+	}
+	if rules[LintPageLength] != 0 || lint.Bullets != 3 || lint.EstimatedPages > 1 {
+		t.Errorf("unexpected lint stats: %+v", lint)
+	}
+	if strings.Join(lint.DateFormats, ",") != "Mon YYYY,MM/YYYY" {
+		t.Errorf("date formats = %v", lint.DateFormats)
+	}
+	if lint.Score >= 100 || lint.BuzzwordDensity <= lintBuzzwordMax {
+		t.Errorf("score = %d, density = %.2f", lint.Score, lint.BuzzwordDensity)
+	}
+
+	clean := LintResume("- Led migration of 12 services to Kubernetes, cutting deploy time by 40%\n- Built a billing pipeline processing 2M payments a day")
+	if len(clean.Findings) != 0 || clean.Score != 100 {
+		t.Errorf("clean resume findings = %+v", clean.Findings)
+	}
+}
+
+// --- Resume PDF check ---
+
+// testPDF builds a one-page PDF with a Flate-compressed content stream and an
+// unembedded Helvetica font.
+func testPDF(content string) []byte {
+	var z bytes.Buffer
+	w := zlib.NewWriter(&z)
+	_, _ = w.Write([]byte(content))
+	_ = w.Close()
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	b.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	b.WriteString("2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n")
+	b.WriteString("3 0 obj\n<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>\nendobj\n")
+	fmt.Fprintf(&b, "4 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", z.Len())
+	b.Write(z.Bytes())
+	b.WriteString("\nendstream\nendobj\n")
+	b.WriteString("5 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>\nendobj\n%%EOF\n")
+	return b.Bytes()
+}
+
+func mustParsePDF(t *testing.T, data []byte) *pdfDoc {
+	t.Helper()
+	doc, err := parsePDF(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestPDFDecodeStreamLimit(t *testing.T) {
+	var z bytes.Buffer
+	w := zlib.NewWriter(&z)
+	_, _ = w.Write(make([]byte, 4096))
+	_ = w.Close()
+	dict := "<< /Filter /FlateDecode >>"
+	if out, err := pdfDecodeStream(dict, z.Bytes(), 4096); err != nil || len(out) != 4096 {
+		t.Fatalf("at limit: len = %d, err = %v", len(out), err)
+	}
+	if _, err := pdfDecodeStream(dict, z.Bytes(), 4095); !errors.Is(err, errPDFInflateLimit) {
+		t.Fatalf("past limit: err = %v, want errPDFInflateLimit", err)
+	}
+}
+
+func TestCheckPDF(t *testing.T) {
+	line := "Built payment APIs in Go serving two million requests a day \\(2021-2024\\)"
+	var single strings.Builder
+	single.WriteString("BT /F1 11 Tf 14 TL 72 720 Td (Jane Doe) Tj\n")
+	for range 10 {
+		fmt.Fprintf(&single, "T* [(%s) -300 (again)] TJ\n", line)
+	}
+	single.WriteString("ET")
+
+	r := checkPDF(mustParsePDF(t, testPDF(single.String())))
+	if r.Pages != 1 || !strings.HasPrefix(r.Text, "Jane Doe\nBuilt payment APIs") || !strings.Contains(r.Text, "(2021-2024) again") {
+		t.Fatalf("pages = %d, text = %q", r.Pages, r.Text)
+	}
+	want := map[string]bool{PDFCheckTextLayer: true, PDFCheckFontsEmbedded: false, PDFCheckLayout: true, PDFCheckReadingOrder: true}
+	for _, c := range r.Checks {
+		if c.Passed != want[c.Name] {
+			t.Errorf("%s passed = %v, want %v (%s)", c.Name, c.Passed, want[c.Name], c.Detail)
+		}
+	}
+	if r.Passed || len(r.Fonts) != 1 || r.Fonts[0].Name != "Helvetica" {
+		t.Errorf("passed = %v, fonts = %+v", r.Passed, r.Fonts)
+	}
+
+	// Two columns written column by column: split lines and a jump back up per column.
+	var columns strings.Builder
+	for _, x := range []int{72, 320} {
+		fmt.Fprintf(&columns, "BT /F1 10 Tf 12 TL %d 720 Td", x)
+		for range 12 {
+			columns.WriteString(" (Skills and tools) ' ")
+		}
+		columns.WriteString("ET\n")
+	}
+	r = checkPDF(mustParsePDF(t, testPDF(columns.String())))
+	for _, c := range r.Checks {
+		if c.Name == PDFCheckLayout && c.Passed {
+			t.Errorf("two-column layout passed: %s", c.Detail)
+		}
+	}
+}
+
+func TestCheckResumePDFInputs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".go_job", "resumes")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	pdf := testPDF("BT /F1 11 Tf 72 720 Td (Jane Doe) Tj ET")
+	if err := os.WriteFile(filepath.Join(dir, "cv.pdf"), pdf, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, "secret.pdf"), pdf, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"cv.pdf", filepath.Join(dir, "cv.pdf")} {
+		if _, err := CheckResumePDF(engine.ResumePDFCheckInput{Path: name}); err != nil {
+			t.Errorf("path %q: %v", name, err)
+		}
+	}
+	for _, name := range []string{"../../secret.pdf", filepath.Join(home, "secret.pdf"), "/etc/passwd"} {
+		if _, err := CheckResumePDF(engine.ResumePDFCheckInput{Path: name}); err == nil || !strings.Contains(err.Error(), "outside") {
+			t.Errorf("path %q: err = %v, want outside the resumes directory", name, err)
+		}
+	}
+
+	big := base64.StdEncoding.EncodeToString(make([]byte, pdfMaxBytes+3))
+	if _, err := CheckResumePDF(engine.ResumePDFCheckInput{ContentBase64: big}); err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Errorf("oversized content_base64: err = %v", err)
+	}
+}
+
+// --- Company-specific cover letters ---
+
+func TestCoverLetterCompanyReference(t *testing.T) {
+	facts := coverLetterCompanyFacts(&CompanyResearchResult{
+		RecentNews:   []string{"Launched Acme Pay in Brazil", " "},
+		CultureNotes: "Remote-first, async writing culture.",
+		TechStack:    []string{"Go", "Kafka"},
+	})
+	if len(facts) != 3 || facts[0].ID != "F1" || facts[2].Source != "tech_stack" || facts[2].Text != "Uses Go, Kafka" {
+		t.Fatalf("facts = %+v", facts)
+	}
+
+	letter := "Dear team,\n\nYour launch of Acme Pay in Brazil caught my eye:  I scaled\npayments in LatAm.\n\nBest"
+	ref := verifyCompanyReference(facts, letter, "f1", "Your launch of Acme Pay in Brazil caught my eye: I scaled payments in LatAm.")
+	if ref == nil || ref.Source != "recent_news" || ref.Fact != "Launched Acme Pay in Brazil" {
+		t.Fatalf("ref = %+v", ref)
+	}
+	if verifyCompanyReference(facts, letter, "F1", "I love your async culture.") != nil {
+		t.Error("sentence missing from the letter was accepted")
+	}
+	if verifyCompanyReference(facts, letter, "F9", "I scaled payments in LatAm.") != nil {
+		t.Error("unknown fact ID was accepted")
+	}
+}
+
+func TestScoreRequirements(t *testing.T) {
+	resume := `Senior Backend Engineer, Acme (2019 - Present)
+Built Go services on PostgreSQL and Docker.
+Backend Engineer, Initech (2016 – 2019)
+Python APIs. BSc Computer Science.`
+	jd := &jdRequirements{
+		RequiredSkills:          []string{"Go", "Kubernetes"},
+		NiceToHave:              []string{"Docker", "Rust"},
+		MinQualifications:       []string{"BSc in Computer Science"},
+		PreferredQualifications: []string{"Fintech domain experience"},
+		YearsRequired: []JDYearsDemand{
+			{Years: 5, Required: true},
+			{Skill: "Go", Years: 12, Required: false},
+		},
+	}
+	fit := scoreRequirements(jd, resume, nil, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC))
+
+	if fit.ResumeYears != 10 {
+		t.Errorf("resume_years = %v, want 10", fit.ResumeYears)
+	}
+	wantBlockers := []string{"Kubernetes"}
+	if strings.Join(fit.HardBlockers, "|") != strings.Join(wantBlockers, "|") {
+		t.Errorf("hard_blockers = %v, want %v", fit.HardBlockers, wantBlockers)
+	}
+	wantGaps := []string{"Rust", "Fintech domain experience", "12+ years of Go"}
+	if strings.Join(fit.SoftGaps, "|") != strings.Join(wantGaps, "|") {
+		t.Errorf("soft_gaps = %v, want %v", fit.SoftGaps, wantGaps)
+	}
+	if fit.MustHaveScore != 75 || fit.NiceToHaveScore != 25 {
+		t.Errorf("scores = %d/%d, want 75/25", fit.MustHaveScore, fit.NiceToHaveScore)
+	}
+
+	if got := scoreRequirements(&jdRequirements{YearsRequired: jd.YearsRequired}, "no dates", nil, time.Now()); got.HardBlockers != nil || got.MustHaveScore != 100 {
+		t.Errorf("years without resume dates scored: %+v", got)
+	}
+}
@@ -0,0 +1,271 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Resume A/B variants ---
+
+// resume_variants generates two resumes for one JD: variant A is resume_generate's
+// output, variant B rewrites it with a different summary angle and bullet order from
+// the same facts. Variants are stored in the tracker; applications are tagged with the
+// variant sent (resume_variant_id on job_tracker_add/update) and resume_ab_report
+// compares response rates per variant by month.
+
+// abMinSample is the number of sent applications per variant below which a comparison
+// is reported as inconclusive.
+const abMinSample = 10
+
+// ResumeVariant is one stored resume variant.
+type ResumeVariant struct {
+	ID        int64  `json:"id"`
+	Variant   string `json:"variant"` // "A" or "B"
+	Angle     string `json:"angle"`   // what distinguishes this variant
+	Title     string `json:"title,omitempty"`
+	Company   string `json:"company,omitempty"`
+	Resume    string `json:"resume"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ResumeVariantsResult is the structured output of resume_variants.
+type ResumeVariantsResult struct {
+	Variants []ResumeVariant `json:"variants"`
+	ATSScore int             `json:"ats_score"` // of variant A; B keeps its keywords
+	Summary  string          `json:"summary"`
+}
+
+const resumeVariantPrompt = `You are an expert resume writer preparing an A/B test. Below is variant A of a resume tailored to a job.
+
+Write variant B from exactly the same facts with a clearly different presentation:
+- A different professional summary angle (e.g. impact-led instead of skills-led, or domain-led instead of tool-led)
+- Bullets within each role reordered to lead with a different kind of evidence
+Rules:
+- Never add, drop or change facts, figures, employers, titles or dates
+- Keep every keyword of variant A
+- Keep the same format and section order
+
+VARIANT A:
+%s
+
+Return a JSON object with this exact structure:
+{
+  "angle_a": "<one sentence: how variant A presents the candidate>",
+  "angle_b": "<one sentence: how variant B presents the candidate differently>",
+  "resume_b": "<the full variant B resume>"
+}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+// GenerateResumeVariants generates and stores two resume variants for a JD.
+func GenerateResumeVariants(ctx context.Context, input engine.ResumeVariantsInput) (*ResumeVariantsResult, error) {
+	a, err := GenerateResume(ctx, input.JobDescription, input.Company, input.Format, MetricPolicyRemove)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := engine.CallLLM(ctx, fmt.Sprintf(resumeVariantPrompt, a.Resume))
+	if err != nil {
+		return nil, fmt.Errorf("resume_variants LLM: %w", err)
+	}
+	var gen struct {
+		AngleA  string `json:"angle_a"`
+		AngleB  string `json:"angle_b"`
+		ResumeB string `json:"resume_b"`
+	}
+	if err := json.Unmarshal([]byte(StripMarkdownFences(raw)), &gen); err != nil {
+		return nil, fmt.Errorf("resume_variants parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+	}
+	if strings.TrimSpace(gen.ResumeB) == "" {
+		return nil, errors.New("resume_variants: empty variant B")
+	}
+
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	result := &ResumeVariantsResult{ATSScore: a.ATSScore}
+	for _, v := range []ResumeVariant{
+		{Variant: "A", Angle: gen.AngleA, Resume: a.Resume},
+		{Variant: "B", Angle: gen.AngleB, Resume: strings.TrimSpace(gen.ResumeB)},
+	} {
+		v.Title, v.Company, v.CreatedAt = input.Title, input.Company, now
+		res, err := db.Exec( //nolint:noctx // SQLite file-based tracker
+			`INSERT INTO resume_variants (variant, angle, title, company, resume, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+			v.Variant, v.Angle, v.Title, v.Company, v.Resume, v.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("resume_variants: save: %w", err)
+		}
+		v.ID, _ = res.LastInsertId()
+		result.Variants = append(result.Variants, v)
+	}
+	result.Summary = fmt.Sprintf("Generated variants A (#%d) and B (#%d). Send one and tag the application with resume_variant_id in job_tracker_add or job_tracker_update; compare results with resume_ab_report.",
+		result.Variants[0].ID, result.Variants[1].ID)
+	return result, nil
+}
+
+// initResumeVariantsSchema creates the resume_variants table in the tracker database.
+func initResumeVariantsSchema(db *sql.DB) error {
+	schema := `CREATE TABLE IF NOT EXISTS resume_variants (
+		id         INTEGER PRIMARY KEY AUTOINCREMENT,
+		variant    TEXT NOT NULL,
+		angle      TEXT,
+		title      TEXT,
+		company    TEXT,
+		resume     TEXT NOT NULL,
+		created_at TEXT NOT NULL
+	)`
+	_, err := db.Exec(schema) //nolint:noctx // schema init, no user context available
+	return err
+}
+
+// resumeVariantExists reports whether a stored variant has the ID.
+func resumeVariantExists(db *sql.DB, id int64) bool {
+	var n int
+	err := db.QueryRow(`SELECT COUNT(*) FROM resume_variants WHERE id = ?`, id).Scan(&n) //nolint:noctx // SQLite file-based tracker
+	return err == nil && n > 0
+}
+
+// ABVariantStats is the outcome of applications sent with one variant.
+type ABVariantStats struct {
+	Variant       string  `json:"variant"`
+	Sent          int     `json:"sent"`
+	Responses     int     `json:"responses"`  // moved to interview, offer or rejected
+	Interviews    int     `json:"interviews"` // reached interview or offer
+	Offers        int     `json:"offers"`
+	ResponseRate  float64 `json:"response_rate"`
+	InterviewRate float64 `json:"interview_rate"`
+}
+
+// ABPeriod is the per-variant outcome of applications added in one month.
+type ABPeriod struct {
+	Month    string           `json:"month"` // YYYY-MM
+	Variants []ABVariantStats `json:"variants"`
+}
+
+// ResumeABReport is the structured output of resume_ab_report.
+type ResumeABReport struct {
+	Variants []ABVariantStats `json:"variants"`
+	ByMonth  []ABPeriod       `json:"by_month"`
+	Leader   string           `json:"leader,omitempty"` // variant with the higher interview rate, when conclusive
+	Summary  string           `json:"summary"`
+}
+
+// BuildResumeABReport compares the outcomes of tracked applications added in the last
+// months per resume variant, overall and by the month the application was added.
+func BuildResumeABReport(_ context.Context, months int) (*ResumeABReport, error) {
+	if months <= 0 || months > 24 {
+		months = 6
+	}
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	since := time.Now().UTC().AddDate(0, -months, 0).Format(time.RFC3339)
+	rows, err := db.Query( //nolint:noctx // SQLite file-based tracker
+		`SELECT v.variant, j.status, j.created_at FROM jobs j JOIN resume_variants v ON v.id = j.resume_variant_id
+		 WHERE j.status != ? AND j.created_at >= ?`, string(StatusSaved), since)
+	if err != nil {
+		return nil, fmt.Errorf("resume_ab_report: query: %w", err)
+	}
+	defer rows.Close()
+	var apps []abApplication
+	for rows.Next() {
+		var a abApplication
+		if err := rows.Scan(&a.variant, &a.status, &a.createdAt); err != nil {
+			return nil, fmt.Errorf("resume_ab_report: scan: %w", err)
+		}
+		apps = append(apps, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return abReport(apps), nil
+}
+
+type abApplication struct {
+	variant   string
+	status    JobStatus
+	createdAt string
+}
+
+// abReport aggregates tagged applications into the report.
+func abReport(apps []abApplication) *ResumeABReport {
+	r := &ResumeABReport{Variants: abStats(apps), ByMonth: []ABPeriod{}}
+	byMonth := make(map[string][]abApplication)
+	for _, a := range apps {
+		month := a.createdAt
+		if len(month) >= 7 {
+			month = month[:7]
+		}
+		byMonth[month] = append(byMonth[month], a)
+	}
+	for month, ms := range byMonth {
+		r.ByMonth = append(r.ByMonth, ABPeriod{Month: month, Variants: abStats(ms)})
+	}
+	sort.Slice(r.ByMonth, func(i, j int) bool { return r.ByMonth[i].Month < r.ByMonth[j].Month })
+
+	if len(apps) == 0 {
+		r.Summary = "No applications tagged with a resume variant yet — set resume_variant_id in job_tracker_add or job_tracker_update."
+		return r
+	}
+	var parts []string
+	for _, v := range r.Variants {
+		parts = append(parts, fmt.Sprintf("%s: %d sent, %.0f%% responses, %.0f%% interviews", v.Variant, v.Sent, v.ResponseRate*100, v.InterviewRate*100))
+	}
+	r.Summary = strings.Join(parts, "; ") + "."
+	if len(r.Variants) == 2 && r.Variants[0].Sent >= abMinSample && r.Variants[1].Sent >= abMinSample &&
+		r.Variants[0].InterviewRate != r.Variants[1].InterviewRate {
+		r.Leader = r.Variants[0].Variant
+		if r.Variants[1].InterviewRate > r.Variants[0].InterviewRate {
+			r.Leader = r.Variants[1].Variant
+		}
+		r.Summary += fmt.Sprintf(" Variant %s leads on interview rate.", r.Leader)
+	} else {
+		r.Summary += fmt.Sprintf(" Inconclusive: send at least %d applications per variant.", abMinSample)
+	}
+	return r
+}
+
+// abStats counts outcomes per variant, in variant order.
+func abStats(apps []abApplication) []ABVariantStats {
+	idx := make(map[string]*ABVariantStats)
+	var order []string
+	for _, a := range apps {
+		s := idx[a.variant]
+		if s == nil {
+			s = &ABVariantStats{Variant: a.variant}
+			idx[a.variant] = s
+			order = append(order, a.variant)
+		}
+		s.Sent++
+		switch a.status {
+		case StatusOffer:
+			s.Offers++
+			s.Interviews++
+			s.Responses++
+		case StatusInterview:
+			s.Interviews++
+			s.Responses++
+		case StatusRejected:
+			s.Responses++
+		}
+	}
+	sort.Strings(order)
+	out := make([]ABVariantStats, 0, len(order))
+	for _, v := range order {
+		s := idx[v]
+		s.ResponseRate = float64(s.Responses*100/s.Sent) / 100
+		s.InterviewRate = float64(s.Interviews*100/s.Sent) / 100
+		out = append(out, *s)
+	}
+	return out
+}
@@ -7,12 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/anatolykoptev/go-kit/retry"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -22,31 +24,51 @@ var schemaFS embed.FS
 // ageSetup runs per-connection AGE initialization.
 const ageSetup = `LOAD 'age'; SET search_path TO ag_catalog, "$user", public`
 
-// Package-level singletons, set from main.go.
-var (
-	resumeDB *ResumeDB
-	memDB    *MemDBClient
-)
+// Stores bundles the external data stores used by the jobs package.
+// Any field may be nil when the corresponding backend is not configured.
+type Stores struct {
+	ResumeDB *ResumeDB
+	MemDB    *MemDBClient
+	Embed    *EmbedClient
+
+	// Resume and Vectors replace ResumeDB and MemDB in master_resume_build,
+	// resume_generate and resume_enrich, e.g. with the in-memory stores in tests.
+	Resume  ResumeStore
+	Vectors VectorStore
+}
 
-// SetResumeDB sets the package-level resume DB instance.
-func SetResumeDB(db *ResumeDB) { resumeDB = db }
+type storesKey struct{}
 
-// GetResumeDB returns the package-level resume DB instance (may be nil).
-func GetResumeDB() *ResumeDB { return resumeDB }
+// WithStores returns a context carrying s; the jobs functions read their data stores
+// from it with StoresFrom.
+func WithStores(ctx context.Context, s Stores) context.Context {
+	return context.WithValue(ctx, storesKey{}, s)
+}
 
-// SetMemDB sets the package-level MemDB client instance.
-func SetMemDB(c *MemDBClient) { memDB = c }
+// StoresFrom returns the data stores bound to ctx, the zero Stores when none are.
+func StoresFrom(ctx context.Context) Stores {
+	s, _ := ctx.Value(storesKey{}).(Stores)
+	return s
+}
 
-// GetMemDB returns the package-level MemDB client instance (may be nil).
-func GetMemDB() *MemDBClient { return memDB }
+// querier is the statement API shared by the pool and a transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
 
 // ResumeDB holds the pgx connection pool for resume storage.
+// SQL statements go through q, which is the pool or, inside InTx, the open transaction.
+// AGE graph helpers always acquire their own pool connection.
 type ResumeDB struct {
 	pool *pgxpool.Pool
+	q    querier
 }
 
-// ConnectResumeDB creates a pgx pool and runs schema migrations.
-func ConnectResumeDB(ctx context.Context, databaseURL string) (*ResumeDB, error) {
+// resumePoolConfig parses DATABASE_URL into the pool configuration shared by
+// ConnectResumeDB and OpenResumeDB.
+func resumePoolConfig(databaseURL string) (*pgxpool.Config, error) {
 	if databaseURL == "" {
 		return nil, errors.New("DATABASE_URL is required")
 	}
@@ -66,6 +88,33 @@ func ConnectResumeDB(ctx context.Context, databaseURL string) (*ResumeDB, error)
 		_, err := conn.Exec(ctx, "SET search_path TO public")
 		return err
 	}
+	return config, nil
+}
+
+// OpenResumeDB connects to Postgres without retrying or touching the schema, for
+// read-only checks such as `go_job doctor`.
+func OpenResumeDB(ctx context.Context, databaseURL string) (*ResumeDB, error) {
+	config, err := resumePoolConfig(databaseURL)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("connect postgres: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("connect postgres: %w", err)
+	}
+	return &ResumeDB{pool: pool, q: pool}, nil
+}
+
+// ConnectResumeDB creates a pgx pool and runs schema migrations.
+func ConnectResumeDB(ctx context.Context, databaseURL string) (*ResumeDB, error) {
+	config, err := resumePoolConfig(databaseURL)
+	if err != nil {
+		return nil, err
+	}
 
 	pool, err := retry.Do(ctx, retry.Options{
 		MaxAttempts:  10,
@@ -90,11 +139,14 @@ func ConnectResumeDB(ctx context.Context, databaseURL string) (*ResumeDB, error)
 		return nil, fmt.Errorf("connect postgres: %w", err)
 	}
 
-	db := &ResumeDB{pool: pool}
+	db := &ResumeDB{pool: pool, q: pool}
 	if err := db.runMigrations(ctx); err != nil {
 		pool.Close()
 		return nil, fmt.Errorf("run migrations: %w", err)
 	}
+	if err := db.BackfillExperienceDates(ctx); err != nil {
+		slog.Warn("resume: backfill experience dates failed", slog.Any("error", err))
+	}
 
 	slog.Info("resume postgres connected", slog.String("addr", config.ConnConfig.Host))
 	return db, nil
@@ -104,15 +156,117 @@ func (db *ResumeDB) Close() {
 	db.pool.Close()
 }
 
-func (db *ResumeDB) runMigrations(ctx context.Context) error {
+// Ping checks that Postgres accepts connections.
+func (db *ResumeDB) Ping(ctx context.Context) error {
+	return db.pool.Ping(ctx)
+}
+
+// InTx runs fn with a ResumeDB whose SQL statements share one transaction.
+// The transaction commits when fn returns nil and rolls back otherwise.
+// Graph writes made through tx are not part of the transaction.
+func (db *ResumeDB) InTx(ctx context.Context, fn func(tx *ResumeDB) error) error {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(&ResumeDB{pool: db.pool, q: tx}); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			slog.Warn("resumedb: rollback failed", slog.Any("error", rbErr))
+		}
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// migrationFiles returns the embedded migration files in apply order.
+func migrationFiles() ([]string, error) {
 	entries, err := schemaFS.ReadDir("schema")
 	if err != nil {
-		return fmt.Errorf("read schema dir: %w", err)
+		return nil, fmt.Errorf("read schema dir: %w", err)
 	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".sql") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
 
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].Name() < entries[j].Name()
-	})
+var (
+	migrationTableRe  = regexp.MustCompile(`(?i)CREATE\s+TABLE\s+IF\s+NOT\s+EXISTS\s+(?:public\.)?(\w+)`)
+	migrationIndexRe  = regexp.MustCompile(`(?i)CREATE\s+(?:UNIQUE\s+)?INDEX\s+IF\s+NOT\s+EXISTS\s+(\w+)`)
+	migrationColumnRe = regexp.MustCompile(`(?i)ALTER\s+TABLE\s+(?:public\.)?(\w+)\s+ADD\s+COLUMN\s+IF\s+NOT\s+EXISTS\s+(\w+)`)
+)
+
+// migrationObjects lists the tables and indexes (relations) and the table columns a
+// migration creates. Migrations are idempotent scripts without a version table, so
+// these objects are how an applied migration is recognized.
+func migrationObjects(sql string) (relations []string, columns [][2]string) {
+	for _, m := range migrationTableRe.FindAllStringSubmatch(sql, -1) {
+		relations = append(relations, strings.ToLower(m[1]))
+	}
+	for _, m := range migrationIndexRe.FindAllStringSubmatch(sql, -1) {
+		relations = append(relations, strings.ToLower(m[1]))
+	}
+	for _, m := range migrationColumnRe.FindAllStringSubmatch(sql, -1) {
+		columns = append(columns, [2]string{strings.ToLower(m[1]), strings.ToLower(m[2])})
+	}
+	return relations, columns
+}
+
+// PendingMigrations returns the migration files whose tables, indexes or columns are
+// missing, without changing the schema. Migrations that create none of these (the
+// AGE graph) are not checked.
+func (db *ResumeDB) PendingMigrations(ctx context.Context) ([]string, error) {
+	names, err := migrationFiles()
+	if err != nil {
+		return nil, err
+	}
+	var pending []string
+	for _, name := range names {
+		data, err := schemaFS.ReadFile("schema/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+		relations, columns := migrationObjects(string(data))
+		applied := true
+		for _, rel := range relations {
+			if err := db.q.QueryRow(ctx, `SELECT to_regclass('public.' || $1::text) IS NOT NULL`, rel).Scan(&applied); err != nil {
+				return nil, fmt.Errorf("check %s: %w", rel, err)
+			}
+			if !applied {
+				break
+			}
+		}
+		for _, col := range columns {
+			if !applied {
+				break
+			}
+			err := db.q.QueryRow(ctx,
+				`SELECT EXISTS (SELECT 1 FROM information_schema.columns
+				 WHERE table_schema = 'public' AND table_name = $1 AND column_name = $2)`,
+				col[0], col[1]).Scan(&applied)
+			if err != nil {
+				return nil, fmt.Errorf("check %s.%s: %w", col[0], col[1], err)
+			}
+		}
+		if !applied {
+			pending = append(pending, name)
+		}
+	}
+	return pending, nil
+}
+
+func (db *ResumeDB) runMigrations(ctx context.Context) error {
+	names, err := migrationFiles()
+	if err != nil {
+		return err
+	}
 
 	// Run migrations on a single dedicated connection to avoid search_path issues
 	// across pooled connections. The memos role has ag_catalog first in search_path.
@@ -127,33 +281,30 @@ func (db *ResumeDB) runMigrations(ctx context.Context) error {
 		return fmt.Errorf("set search_path: %w", err)
 	}
 
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
-			continue
-		}
-		data, err := schemaFS.ReadFile("schema/" + entry.Name())
+	for _, name := range names {
+		data, err := schemaFS.ReadFile("schema/" + name)
 		if err != nil {
-			return fmt.Errorf("read %s: %w", entry.Name(), err)
+			return fmt.Errorf("read %s: %w", name, err)
 		}
 
 		if _, err := conn.Exec(ctx, string(data)); err != nil {
-			if strings.Contains(entry.Name(), "002") {
+			if strings.Contains(name, "002") {
 				slog.Warn("AGE migration failed (Apache AGE may not be installed)",
-					slog.String("file", entry.Name()),
+					slog.String("file", name),
 					slog.Any("error", err))
 				// Reset search_path after AGE migration (it sets search_path to ag_catalog)
 				_, _ = conn.Exec(ctx, "SET search_path TO public")
 				continue
 			}
-			return fmt.Errorf("execute %s: %w", entry.Name(), err)
+			return fmt.Errorf("execute %s: %w", name, err)
 		}
 
 		// 002_resume_graph.sql sets search_path to ag_catalog; reset it for subsequent migrations
-		if strings.Contains(entry.Name(), "002") {
+		if strings.Contains(name, "002") {
 			_, _ = conn.Exec(ctx, "SET search_path TO public")
 		}
 
-		slog.Info("migration applied", slog.String("file", entry.Name()))
+		slog.Info("migration applied", slog.String("file", name))
 	}
 	return nil
 }
@@ -161,41 +312,65 @@ func (db *ResumeDB) runMigrations(ctx context.Context) error {
 // --- Person CRUD ---
 
 type PersonRecord struct {
-	ID       int               `json:"id"`
-	Name     string            `json:"name"`
-	Email    string            `json:"email"`
-	Phone    string            `json:"phone"`
-	Location string            `json:"location"`
-	Links    map[string]string `json:"links"`
-	Summary  string            `json:"summary"`
+	ID                int                 `json:"id"`
+	Name              string              `json:"name"`
+	Email             string              `json:"email"`
+	Phone             string              `json:"phone"`
+	Location          string              `json:"location"`
+	Links             map[string]string   `json:"links"`
+	Summary           string              `json:"summary"`
+	Languages         []LanguageSkill     `json:"languages,omitempty"`
+	WorkAuthorization []WorkAuthorization `json:"work_authorization,omitempty"`
+}
+
+// LanguageSkill is a spoken language and how well the person speaks it.
+type LanguageSkill struct {
+	Language    string `json:"language"`
+	Proficiency string `json:"proficiency,omitempty"` // native, fluent, professional, conversational, basic
+}
+
+// WorkAuthorization is the person's right to work in one country or region.
+type WorkAuthorization struct {
+	Country string `json:"country"`        // e.g. "US", "UK", "EU", "Germany"
+	Status  string `json:"status"`         // citizen, permanent_resident, work_visa, needs_sponsorship
+	Note    string `json:"note,omitempty"` // e.g. "H-1B, transfer required"
 }
 
 func (db *ResumeDB) InsertPerson(ctx context.Context, p PersonRecord) (int, error) {
 	linksJSON, _ := json.Marshal(p.Links)
+	if p.Languages == nil {
+		p.Languages = []LanguageSkill{}
+	}
+	if p.WorkAuthorization == nil {
+		p.WorkAuthorization = []WorkAuthorization{}
+	}
+	langsJSON, _ := json.Marshal(p.Languages)
+	authJSON, _ := json.Marshal(p.WorkAuthorization)
 	var id int
-	err := db.pool.QueryRow(ctx,
-		`INSERT INTO resume_persons (name, email, phone, location, links, summary)
-		 VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
-		p.Name, p.Email, p.Phone, p.Location, linksJSON, p.Summary,
+	err := db.q.QueryRow(ctx,
+		`INSERT INTO resume_persons (name, email, phone, location, links, summary, languages, work_authorization)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		p.Name, p.Email, p.Phone, p.Location, linksJSON, p.Summary, langsJSON, authJSON,
 	).Scan(&id)
 	return id, err
 }
 
 func (db *ResumeDB) ClearPerson(ctx context.Context, personID int) error {
-	_, err := db.pool.Exec(ctx, `DELETE FROM resume_persons WHERE id = $1`, personID)
+	_, err := db.q.Exec(ctx, `DELETE FROM resume_persons WHERE id = $1`, personID)
 	return err
 }
 
-// ClearAllPersons deletes all resume data (single-user system, rebuild from scratch).
+// ClearAllPersons deletes all resume data, archived builds included.
 func (db *ResumeDB) ClearAllPersons(ctx context.Context) error {
-	_, err := db.pool.Exec(ctx, `DELETE FROM resume_persons`)
+	_, err := db.q.Exec(ctx, `DELETE FROM resume_persons`)
 	return err
 }
 
-// GetLatestPersonID returns the ID of the most recently created person, or 0 if none.
+// GetLatestPersonID returns the ID of the most recently created person that is not
+// archived, or 0 if none.
 func (db *ResumeDB) GetLatestPersonID(ctx context.Context) int {
 	var id int
-	err := db.pool.QueryRow(ctx, `SELECT id FROM resume_persons ORDER BY id DESC LIMIT 1`).Scan(&id)
+	err := db.q.QueryRow(ctx, `SELECT id FROM resume_persons WHERE archived_at IS NULL ORDER BY id DESC LIMIT 1`).Scan(&id)
 	if err != nil {
 		return 0
 	}
@@ -205,22 +380,25 @@ func (db *ResumeDB) GetLatestPersonID(ctx context.Context) int {
 // GetPerson returns the person record for the given ID.
 func (db *ResumeDB) GetPerson(ctx context.Context, personID int) (*PersonRecord, error) {
 	var p PersonRecord
-	var linksJSON []byte
-	err := db.pool.QueryRow(ctx,
-		`SELECT id, name, COALESCE(email,''), COALESCE(phone,''), COALESCE(location,''), COALESCE(links,'{}'), COALESCE(summary,'')
+	var linksJSON, langsJSON, authJSON []byte
+	err := db.q.QueryRow(ctx,
+		`SELECT id, name, COALESCE(email,''), COALESCE(phone,''), COALESCE(location,''), COALESCE(links,'{}'), COALESCE(summary,''),
+		        COALESCE(languages,'[]'), COALESCE(work_authorization,'[]')
 		 FROM resume_persons WHERE id = $1`, personID,
-	).Scan(&p.ID, &p.Name, &p.Email, &p.Phone, &p.Location, &linksJSON, &p.Summary)
+	).Scan(&p.ID, &p.Name, &p.Email, &p.Phone, &p.Location, &linksJSON, &p.Summary, &langsJSON, &authJSON)
 	if err != nil {
 		return nil, err
 	}
 	_ = json.Unmarshal(linksJSON, &p.Links)
+	_ = json.Unmarshal(langsJSON, &p.Languages)
+	_ = json.Unmarshal(authJSON, &p.WorkAuthorization)
 	return &p, nil
 }
 
 // GetPersonEnrichedAt returns the enriched_at timestamp as a string, or empty if not enriched.
 func (db *ResumeDB) GetPersonEnrichedAt(ctx context.Context, personID int) string {
 	var enrichedAt *string
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`SELECT enriched_at::text FROM resume_persons WHERE id = $1`, personID,
 	).Scan(&enrichedAt)
 	if err != nil || enrichedAt == nil {
@@ -0,0 +1,196 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// --- Resume backup ---
+
+// resumeBackupTables are the resume tables in foreign-key order: persons first, the
+// tables referencing experiences and achievements after them.
+var resumeBackupTables = []string{
+	"resume_persons",
+	"resume_experiences",
+	"resume_educations",
+	"resume_skills",
+	"resume_projects",
+	"resume_achievements",
+	"resume_certifications",
+	"resume_domains",
+	"resume_methodologies",
+	"resume_publications",
+	"resume_talks",
+	"resume_patents",
+	"resume_open_source",
+	"resume_star_stories",
+}
+
+// DumpResumeTables returns every row of the resume tables as a JSON array per table,
+// IDs included so graph and vector references stay valid after a restore.
+func (db *ResumeDB) DumpResumeTables(ctx context.Context) (map[string]json.RawMessage, error) {
+	out := make(map[string]json.RawMessage, len(resumeBackupTables))
+	for _, t := range resumeBackupTables {
+		var raw []byte
+		err := db.q.QueryRow(ctx, `SELECT COALESCE(json_agg(t ORDER BY t.id), '[]'::json) FROM `+t+` t`).Scan(&raw)
+		if err != nil {
+			return nil, fmt.Errorf("dump %s: %w", t, err)
+		}
+		out[t] = raw
+	}
+	return out, nil
+}
+
+// RestoreResumeTables replaces all resume data with the dumped rows in one
+// transaction and moves the ID sequences past the restored IDs. Columns the archive
+// lacks take their defaults; columns this schema lacks are dropped. Returns the rows
+// restored per table.
+func (db *ResumeDB) RestoreResumeTables(ctx context.Context, tables map[string]json.RawMessage) (map[string]int, error) {
+	counts := make(map[string]int)
+	err := db.InTx(ctx, func(tx *ResumeDB) error {
+		if err := tx.ClearAllPersons(ctx); err != nil {
+			return fmt.Errorf("clear resume data: %w", err)
+		}
+		for _, t := range resumeBackupTables {
+			raw, ok := tables[t]
+			if !ok {
+				continue
+			}
+			cols, err := tx.backupColumns(ctx, t, raw)
+			if err != nil {
+				return err
+			}
+			if len(cols) == 0 {
+				continue
+			}
+			list := `"` + strings.Join(cols, `", "`) + `"`
+			tag, err := tx.q.Exec(ctx, `INSERT INTO `+t+` (`+list+`) SELECT `+list+
+				` FROM json_populate_recordset(NULL::`+t+`, $1::json)`, string(raw))
+			if err != nil {
+				return fmt.Errorf("restore %s: %w", t, err)
+			}
+			counts[t] = int(tag.RowsAffected())
+			_, err = tx.q.Exec(ctx, `SELECT setval(pg_get_serial_sequence($1, 'id'), COALESCE((SELECT MAX(id) FROM `+t+`), 0) + 1, false)`, t)
+			if err != nil {
+				return fmt.Errorf("reset %s id sequence: %w", t, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// backupColumns returns the columns of table t that appear in the dumped rows.
+func (db *ResumeDB) backupColumns(ctx context.Context, t string, raw json.RawMessage) ([]string, error) {
+	var rows []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil, fmt.Errorf("restore %s: %w", t, err)
+	}
+	present := make(map[string]bool)
+	for _, r := range rows {
+		for k := range r {
+			present[k] = true
+		}
+	}
+	dbRows, err := db.q.Query(ctx, `SELECT column_name FROM information_schema.columns WHERE table_schema = 'public' AND table_name = $1`, t)
+	if err != nil {
+		return nil, fmt.Errorf("restore %s: columns: %w", t, err)
+	}
+	defer dbRows.Close()
+	var cols []string
+	for dbRows.Next() {
+		var c string
+		if err := dbRows.Scan(&c); err != nil {
+			return nil, fmt.Errorf("restore %s: columns: %w", t, err)
+		}
+		if present[c] {
+			cols = append(cols, c)
+		}
+	}
+	sort.Strings(cols)
+	return cols, dbRows.Err()
+}
+
+// DumpGraph returns the resume graph as the node and edge ops that rebuild it.
+func (db *ResumeDB) DumpGraph(ctx context.Context) ([]graphOp, error) {
+	conn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, ageSetup); err != nil {
+		return nil, fmt.Errorf("age setup: %w", err)
+	}
+
+	var ops []graphOp
+	rows, err := conn.Query(ctx, `SELECT * FROM ag_catalog.cypher('resume_graph', $$
+		MATCH (n) RETURN label(n), properties(n)
+	$$) AS (label ag_catalog.agtype, props ag_catalog.agtype)`)
+	if err != nil {
+		return nil, fmt.Errorf("dump graph nodes: %w", err)
+	}
+	for rows.Next() {
+		var rawLabel, rawProps string
+		if err := rows.Scan(&rawLabel, &rawProps); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("dump graph nodes: %w", err)
+		}
+		var label string
+		var props map[string]any
+		if json.Unmarshal([]byte(rawLabel), &label) != nil || json.Unmarshal([]byte(rawProps), &props) != nil {
+			continue
+		}
+		id, ok := props["id"].(float64)
+		if !ok {
+			continue
+		}
+		op := graphOp{Label: label, ID: int(id)}
+		for k, v := range props {
+			if k == "id" {
+				continue
+			}
+			if op.Props == nil {
+				op.Props = make(map[string]string)
+			}
+			if s, ok := v.(string); ok {
+				op.Props[k] = s
+			} else {
+				op.Props[k] = fmt.Sprint(v)
+			}
+		}
+		ops = append(ops, op)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("dump graph nodes: %w", err)
+	}
+
+	rows, err = conn.Query(ctx, `SELECT * FROM ag_catalog.cypher('resume_graph', $$
+		MATCH (a)-[r]->(b) RETURN label(a), a.id, type(r), label(b), b.id
+	$$) AS (from_label ag_catalog.agtype, from_id ag_catalog.agtype, edge ag_catalog.agtype, to_label ag_catalog.agtype, to_id ag_catalog.agtype)`)
+	if err != nil {
+		return nil, fmt.Errorf("dump graph edges: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var raw [5]string
+		if err := rows.Scan(&raw[0], &raw[1], &raw[2], &raw[3], &raw[4]); err != nil {
+			return nil, fmt.Errorf("dump graph edges: %w", err)
+		}
+		var op graphOp
+		if json.Unmarshal([]byte(raw[0]), &op.Label) != nil || json.Unmarshal([]byte(raw[1]), &op.ID) != nil ||
+			json.Unmarshal([]byte(raw[2]), &op.Edge) != nil || json.Unmarshal([]byte(raw[3]), &op.ToLabel) != nil ||
+			json.Unmarshal([]byte(raw[4]), &op.ToID) != nil {
+			continue
+		}
+		ops = append(ops, op)
+	}
+	return ops, rows.Err()
+}
@@ -0,0 +1,220 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// --- Build state & integrity ---
+
+// resumeChildTables are the per-person tables written by master_resume_build.
+var resumeChildTables = []string{
+	"resume_experiences",
+	"resume_skills",
+	"resume_projects",
+	"resume_achievements",
+	"resume_educations",
+	"resume_certifications",
+	"public.resume_domains",
+	"public.resume_methodologies",
+	"public.resume_publications",
+	"public.resume_talks",
+	"public.resume_patents",
+	"public.resume_open_source",
+}
+
+// ResumeIntegrity is a snapshot of the SQL side of the master resume.
+type ResumeIntegrity struct {
+	Persons         int            `json:"persons"`  // current (not archived)
+	Archived        int            `json:"archived"` // builds kept for master_resume_rollback
+	PersonID        int            `json:"person_id"`
+	EnrichedAt      string         `json:"enriched_at,omitempty"`
+	GraphSyncedAt   string         `json:"graph_synced_at,omitempty"`
+	VectorsSyncedAt string         `json:"vectors_synced_at,omitempty"`
+	HasBuildPlan    bool           `json:"has_build_plan"`
+	Rows            map[string]int `json:"rows"`
+	Orphans         int            `json:"orphans"`
+}
+
+// SaveBuildPlan stores the derived-store plan of a build on the person row.
+func (db *ResumeDB) SaveBuildPlan(ctx context.Context, personID int, plan []byte) error {
+	_, err := db.q.Exec(ctx,
+		`UPDATE resume_persons SET build_plan = $2, graph_synced_at = NULL, vectors_synced_at = NULL WHERE id = $1`,
+		personID, plan)
+	return err
+}
+
+// LoadBuildPlan returns the stored build plan, or nil if the person predates build tracking.
+func (db *ResumeDB) LoadBuildPlan(ctx context.Context, personID int) ([]byte, error) {
+	var plan []byte
+	err := db.q.QueryRow(ctx,
+		`SELECT build_plan FROM resume_persons WHERE id = $1`, personID,
+	).Scan(&plan)
+	return plan, err
+}
+
+// MarkGraphSynced records that the AGE graph matches the person's build plan.
+func (db *ResumeDB) MarkGraphSynced(ctx context.Context, personID int) error {
+	_, err := db.q.Exec(ctx,
+		`UPDATE resume_persons SET graph_synced_at = now() WHERE id = $1`, personID)
+	return err
+}
+
+// MarkVectorsSynced records that MemDB holds the person's build plan vectors.
+func (db *ResumeDB) MarkVectorsSynced(ctx context.Context, personID int) error {
+	_, err := db.q.Exec(ctx,
+		`UPDATE resume_persons SET vectors_synced_at = now() WHERE id = $1`, personID)
+	return err
+}
+
+// CheckIntegrity reports person count, build/sync timestamps, per-table row counts
+// for the latest person and rows not attached to any person.
+func (db *ResumeDB) CheckIntegrity(ctx context.Context) (*ResumeIntegrity, error) {
+	r := &ResumeIntegrity{Rows: make(map[string]int)}
+	err := db.q.QueryRow(ctx,
+		`SELECT count(*) FILTER (WHERE archived_at IS NULL), count(*) FILTER (WHERE archived_at IS NOT NULL) FROM resume_persons`,
+	).Scan(&r.Persons, &r.Archived)
+	if err != nil {
+		return nil, fmt.Errorf("count persons: %w", err)
+	}
+	r.PersonID = db.GetLatestPersonID(ctx)
+	if r.PersonID > 0 {
+		var enrichedAt, graphAt, vectorsAt *string
+		err := db.q.QueryRow(ctx,
+			`SELECT enriched_at::text, graph_synced_at::text, vectors_synced_at::text, build_plan IS NOT NULL
+			 FROM resume_persons WHERE id = $1`, r.PersonID,
+		).Scan(&enrichedAt, &graphAt, &vectorsAt, &r.HasBuildPlan)
+		if err != nil {
+			return nil, fmt.Errorf("read person state: %w", err)
+		}
+		r.EnrichedAt, r.GraphSyncedAt, r.VectorsSyncedAt = derefString(enrichedAt), derefString(graphAt), derefString(vectorsAt)
+	}
+	for _, table := range resumeChildTables {
+		var n, orphans int
+		err := db.q.QueryRow(ctx, fmt.Sprintf(
+			`SELECT count(*) FILTER (WHERE person_id = $1),
+			        count(*) FILTER (WHERE person_id IS NULL OR person_id NOT IN (SELECT id FROM resume_persons))
+			 FROM %s`, table), r.PersonID,
+		).Scan(&n, &orphans)
+		if err != nil {
+			return nil, fmt.Errorf("count %s: %w", table, err)
+		}
+		r.Rows[table] = n
+		r.Orphans += orphans
+	}
+	return r, nil
+}
+
+// DeleteOtherPersons removes every current person except keepID, cascading to their
+// rows. Archived builds are kept.
+func (db *ResumeDB) DeleteOtherPersons(ctx context.Context, keepID int) (int64, error) {
+	tag, err := db.q.Exec(ctx, `DELETE FROM resume_persons WHERE id <> $1 AND archived_at IS NULL`, keepID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DeleteOrphans removes child rows that reference no existing person.
+func (db *ResumeDB) DeleteOrphans(ctx context.Context) (int64, error) {
+	var total int64
+	for _, table := range resumeChildTables {
+		tag, err := db.q.Exec(ctx, fmt.Sprintf(
+			`DELETE FROM %s WHERE person_id IS NULL OR person_id NOT IN (SELECT id FROM resume_persons)`, table))
+		if err != nil {
+			return total, fmt.Errorf("delete orphans from %s: %w", table, err)
+		}
+		total += tag.RowsAffected()
+	}
+	return total, nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// resumeArchiveKeep is how many replaced builds are kept for master_resume_rollback.
+const resumeArchiveKeep = 3
+
+// ArchiveCurrentPersons archives the current build instead of deleting it and drops
+// archived builds beyond the newest keep. master_resume_build calls it in place of
+// a clear, inside its transaction.
+func (db *ResumeDB) ArchiveCurrentPersons(ctx context.Context, keep int) error {
+	if _, err := db.q.Exec(ctx, `UPDATE resume_persons SET archived_at = now() WHERE archived_at IS NULL`); err != nil {
+		return fmt.Errorf("archive persons: %w", err)
+	}
+	_, err := db.q.Exec(ctx,
+		`DELETE FROM resume_persons WHERE id IN (
+			SELECT id FROM resume_persons WHERE archived_at IS NOT NULL
+			ORDER BY archived_at DESC, id DESC OFFSET $1)`, keep)
+	if err != nil {
+		return fmt.Errorf("prune archived persons: %w", err)
+	}
+	return nil
+}
+
+// ArchivedBuild is a master resume build replaced by a later one.
+type ArchivedBuild struct {
+	PersonID   int    `json:"person_id"`
+	Name       string `json:"name"`
+	BuiltAt    string `json:"built_at"`
+	ArchivedAt string `json:"archived_at"`
+}
+
+// LatestArchivedBuild returns the most recently archived build, or nil if none.
+func (db *ResumeDB) LatestArchivedBuild(ctx context.Context) (*ArchivedBuild, error) {
+	var b ArchivedBuild
+	var builtAt *string
+	err := db.q.QueryRow(ctx,
+		`SELECT id, name, created_at::text, archived_at::text FROM resume_persons
+		 WHERE archived_at IS NOT NULL ORDER BY archived_at DESC, id DESC LIMIT 1`,
+	).Scan(&b.PersonID, &b.Name, &builtAt, &b.ArchivedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("latest archived build: %w", err)
+	}
+	b.BuiltAt = derefString(builtAt)
+	return &b, nil
+}
+
+// SwapCurrentPerson makes the archived person personID the current build and
+// archives the one it replaces. The restored build is marked out of sync until its
+// graph and vectors are replayed.
+func (db *ResumeDB) SwapCurrentPerson(ctx context.Context, personID int) error {
+	return db.InTx(ctx, func(tx *ResumeDB) error {
+		if _, err := tx.q.Exec(ctx, `UPDATE resume_persons SET archived_at = now() WHERE archived_at IS NULL`); err != nil {
+			return fmt.Errorf("archive current person: %w", err)
+		}
+		tag, err := tx.q.Exec(ctx, `UPDATE resume_persons SET archived_at = NULL, graph_synced_at = NULL, vectors_synced_at = NULL WHERE id = $1`, personID)
+		if err != nil {
+			return fmt.Errorf("restore person %d: %w", personID, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("person %d not found", personID)
+		}
+		return nil
+	})
+}
+
+// PersonRowCounts returns the rows per resume table of one person.
+func (db *ResumeDB) PersonRowCounts(ctx context.Context, personID int) (map[string]int, error) {
+	rows := make(map[string]int, len(resumeChildTables))
+	for _, table := range resumeChildTables {
+		var n int
+		err := db.q.QueryRow(ctx, fmt.Sprintf(`SELECT count(*) FROM %s WHERE person_id = $1`, table), personID).Scan(&n)
+		if err != nil {
+			return nil, fmt.Errorf("count %s: %w", table, err)
+		}
+		rows[strings.TrimPrefix(table, "public.")] = n
+	}
+	return rows, nil
+}
@@ -2,6 +2,11 @@ package jobs
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
 // --- Experience CRUD ---
@@ -20,22 +25,100 @@ type ExperienceRecord struct {
 	BudgetUSD   *int     `json:"budget_usd,omitempty"`
 	Domain      string   `json:"domain,omitempty"`
 	IsVolunteer bool     `json:"is_volunteer,omitempty"`
+	// Parsed dates (see ParseResumeDate): YYYY-MM-DD and precision, empty when unreadable.
+	StartOn        string `json:"start_on,omitempty"`
+	EndOn          string `json:"end_on,omitempty"` // last day of the end period; empty while ongoing
+	StartPrecision string `json:"start_precision,omitempty"`
+	EndPrecision   string `json:"end_precision,omitempty"`
+}
+
+// experienceColumns are the columns scanned by scanExperience.
+const experienceColumns = `id, person_id, title, company, location, start_date, end_date, description, highlights,
+	COALESCE(to_char(start_on, 'YYYY-MM-DD'), ''), COALESCE(to_char(end_on, 'YYYY-MM-DD'), ''),
+	COALESCE(start_precision, ''), COALESCE(end_precision, '')`
+
+// experienceOrder sorts ongoing roles first, then by end and start date, most recent
+// first; rows without parsed dates keep their insertion order at the end.
+const experienceOrder = `ORDER BY end_precision = 'present' DESC NULLS LAST, end_on DESC NULLS LAST, start_on DESC NULLS LAST, id`
+
+func scanExperience(rows pgx.Rows) (ExperienceRecord, error) {
+	var r ExperienceRecord
+	err := rows.Scan(&r.ID, &r.PersonID, &r.Title, &r.Company, &r.Location,
+		&r.StartDate, &r.EndDate, &r.Description, &r.Highlights,
+		&r.StartOn, &r.EndOn, &r.StartPrecision, &r.EndPrecision)
+	return r, err
+}
+
+// experienceDateValues parses an experience's dates into the start_on, end_on,
+// start_precision and end_precision values; unreadable dates are NULL.
+func experienceDateValues(e ExperienceRecord) (startOn, endOn *time.Time, startPrec, endPrec *string) {
+	if d, ok := ParseResumeDate(e.StartDate); ok && !d.IsPresent() {
+		startOn, startPrec = &d.Date, &d.Precision
+	}
+	end, ok := ParseResumeDate(e.EndDate)
+	if strings.TrimSpace(e.EndDate) == "" {
+		end, ok = ResumeDate{Precision: DatePrecisionPresent}, true
+	}
+	if ok {
+		endPrec = &end.Precision
+		if !end.IsPresent() {
+			last := end.Last(time.Time{})
+			endOn = &last
+		}
+	}
+	return startOn, endOn, startPrec, endPrec
 }
 
 func (db *ResumeDB) InsertExperience(ctx context.Context, personID int, e ExperienceRecord) (int, error) {
+	startOn, endOn, startPrec, endPrec := experienceDateValues(e)
 	var id int
-	err := db.pool.QueryRow(ctx,
-		`INSERT INTO resume_experiences (person_id, title, company, location, start_date, end_date, description, highlights)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+	err := db.q.QueryRow(ctx,
+		`INSERT INTO resume_experiences (person_id, title, company, location, start_date, end_date, description, highlights,
+		 start_on, end_on, start_precision, end_precision)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`,
 		personID, e.Title, e.Company, e.Location, e.StartDate, e.EndDate, e.Description, e.Highlights,
+		startOn, endOn, startPrec, endPrec,
 	).Scan(&id)
 	return id, err
 }
 
+// BackfillExperienceDates parses the dates of experiences stored before the parsed
+// date columns existed.
+func (db *ResumeDB) BackfillExperienceDates(ctx context.Context) error {
+	rows, err := db.q.Query(ctx,
+		`SELECT id, start_date, end_date FROM resume_experiences
+		 WHERE start_precision IS NULL AND end_precision IS NULL`)
+	if err != nil {
+		return err
+	}
+	var exps []ExperienceRecord
+	for rows.Next() {
+		var e ExperienceRecord
+		if err := rows.Scan(&e.ID, &e.StartDate, &e.EndDate); err != nil {
+			rows.Close()
+			return err
+		}
+		exps = append(exps, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, e := range exps {
+		startOn, endOn, startPrec, endPrec := experienceDateValues(e)
+		if _, err := db.q.Exec(ctx,
+			`UPDATE resume_experiences SET start_on = $2, end_on = $3, start_precision = $4, end_precision = $5 WHERE id = $1`,
+			e.ID, startOn, endOn, startPrec, endPrec); err != nil {
+			return fmt.Errorf("experience %d: %w", e.ID, err)
+		}
+	}
+	return nil
+}
+
 func (db *ResumeDB) GetAllExperiences(ctx context.Context, personID int) ([]ExperienceRecord, error) {
-	rows, err := db.pool.Query(ctx,
-		`SELECT id, person_id, title, company, location, start_date, end_date, description, highlights
-		 FROM resume_experiences WHERE person_id = $1 ORDER BY id`, personID)
+	rows, err := db.q.Query(ctx,
+		`SELECT `+experienceColumns+`
+		 FROM resume_experiences WHERE person_id = $1 `+experienceOrder, personID)
 	if err != nil {
 		return nil, err
 	}
@@ -43,9 +126,8 @@ func (db *ResumeDB) GetAllExperiences(ctx context.Context, personID int) ([]Expe
 
 	var results []ExperienceRecord
 	for rows.Next() {
-		var r ExperienceRecord
-		if err := rows.Scan(&r.ID, &r.PersonID, &r.Title, &r.Company, &r.Location,
-			&r.StartDate, &r.EndDate, &r.Description, &r.Highlights); err != nil {
+		r, err := scanExperience(rows)
+		if err != nil {
 			return nil, err
 		}
 		results = append(results, r)
@@ -57,9 +139,9 @@ func (db *ResumeDB) GetExperiencesByIDs(ctx context.Context, ids []int) ([]Exper
 	if len(ids) == 0 {
 		return nil, nil
 	}
-	rows, err := db.pool.Query(ctx,
-		`SELECT id, person_id, title, company, location, start_date, end_date, description, highlights
-		 FROM resume_experiences WHERE id = ANY($1) ORDER BY id`, ids)
+	rows, err := db.q.Query(ctx,
+		`SELECT `+experienceColumns+`
+		 FROM resume_experiences WHERE id = ANY($1) `+experienceOrder, ids)
 	if err != nil {
 		return nil, err
 	}
@@ -67,9 +149,8 @@ func (db *ResumeDB) GetExperiencesByIDs(ctx context.Context, ids []int) ([]Exper
 
 	var results []ExperienceRecord
 	for rows.Next() {
-		var r ExperienceRecord
-		if err := rows.Scan(&r.ID, &r.PersonID, &r.Title, &r.Company, &r.Location,
-			&r.StartDate, &r.EndDate, &r.Description, &r.Highlights); err != nil {
+		r, err := scanExperience(rows)
+		if err != nil {
 			return nil, err
 		}
 		results = append(results, r)
@@ -86,12 +167,13 @@ type SkillRecord struct {
 	Category   string `json:"category"`
 	Level      string `json:"level"`
 	IsImplicit bool   `json:"is_implicit,omitempty"`
-	Source     string `json:"source,omitempty"` // "resume", "inferred", "enrichment"
+	Source     string `json:"source,omitempty"`    // "resume", "inferred", "enrichment"
+	LastUsed   string `json:"last_used,omitempty"` // YYYY-MM or "present", from experience dates
 }
 
 func (db *ResumeDB) InsertSkill(ctx context.Context, personID int, s SkillRecord) (int, error) {
 	var id int
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`INSERT INTO resume_skills (person_id, name, category, level)
 		 VALUES ($1, $2, $3, $4)
 		 ON CONFLICT (person_id, name) DO UPDATE SET category = EXCLUDED.category, level = EXCLUDED.level
@@ -102,8 +184,8 @@ func (db *ResumeDB) InsertSkill(ctx context.Context, personID int, s SkillRecord
 }
 
 func (db *ResumeDB) GetAllSkills(ctx context.Context, personID int) ([]SkillRecord, error) {
-	rows, err := db.pool.Query(ctx,
-		`SELECT id, person_id, name, category, level FROM resume_skills WHERE person_id = $1 ORDER BY id`, personID)
+	rows, err := db.q.Query(ctx,
+		`SELECT id, person_id, name, category, level, COALESCE(last_used, '') FROM resume_skills WHERE person_id = $1 ORDER BY id`, personID)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +194,7 @@ func (db *ResumeDB) GetAllSkills(ctx context.Context, personID int) ([]SkillReco
 	var results []SkillRecord
 	for rows.Next() {
 		var r SkillRecord
-		if err := rows.Scan(&r.ID, &r.PersonID, &r.Name, &r.Category, &r.Level); err != nil {
+		if err := rows.Scan(&r.ID, &r.PersonID, &r.Name, &r.Category, &r.Level, &r.LastUsed); err != nil {
 			return nil, err
 		}
 		results = append(results, r)
@@ -135,7 +217,7 @@ type ProjectRecord struct {
 
 func (db *ResumeDB) InsertProject(ctx context.Context, personID int, p ProjectRecord) (int, error) {
 	var id int
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`INSERT INTO resume_projects (person_id, name, description, url, tech, highlights)
 		 VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
 		personID, p.Name, p.Description, p.URL, p.Tech, p.Highlights,
@@ -144,7 +226,7 @@ func (db *ResumeDB) InsertProject(ctx context.Context, personID int, p ProjectRe
 }
 
 func (db *ResumeDB) GetAllProjects(ctx context.Context, personID int) ([]ProjectRecord, error) {
-	rows, err := db.pool.Query(ctx,
+	rows, err := db.q.Query(ctx,
 		`SELECT id, person_id, name, description, url, tech, highlights
 		 FROM resume_projects WHERE person_id = $1 ORDER BY id`, personID)
 	if err != nil {
@@ -167,7 +249,7 @@ func (db *ResumeDB) GetProjectsByIDs(ctx context.Context, ids []int) ([]ProjectR
 	if len(ids) == 0 {
 		return nil, nil
 	}
-	rows, err := db.pool.Query(ctx,
+	rows, err := db.q.Query(ctx,
 		`SELECT id, person_id, name, description, url, tech, highlights
 		 FROM resume_projects WHERE id = ANY($1) ORDER BY id`, ids)
 	if err != nil {
@@ -201,7 +283,7 @@ type AchievementRecord struct {
 
 func (db *ResumeDB) InsertAchievement(ctx context.Context, personID int, a AchievementRecord) (int, error) {
 	var id int
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`INSERT INTO resume_achievements (person_id, text, metric, value, context)
 		 VALUES ($1, $2, $3, $4, $5) RETURNING id`,
 		personID, a.Text, a.Metric, a.Value, a.Context,
@@ -210,8 +292,8 @@ func (db *ResumeDB) InsertAchievement(ctx context.Context, personID int, a Achie
 }
 
 func (db *ResumeDB) GetAllAchievements(ctx context.Context, personID int) ([]AchievementRecord, error) {
-	rows, err := db.pool.Query(ctx,
-		`SELECT id, person_id, text, metric, value, context
+	rows, err := db.q.Query(ctx,
+		`SELECT id, person_id, text, metric, value, context, metric_numeric, COALESCE(metric_unit, '')
 		 FROM resume_achievements WHERE person_id = $1 ORDER BY id`, personID)
 	if err != nil {
 		return nil, err
@@ -221,7 +303,7 @@ func (db *ResumeDB) GetAllAchievements(ctx context.Context, personID int) ([]Ach
 	var results []AchievementRecord
 	for rows.Next() {
 		var r AchievementRecord
-		if err := rows.Scan(&r.ID, &r.PersonID, &r.Text, &r.Metric, &r.Value, &r.Context); err != nil {
+		if err := rows.Scan(&r.ID, &r.PersonID, &r.Text, &r.Metric, &r.Value, &r.Context, &r.MetricNumeric, &r.MetricUnit); err != nil {
 			return nil, err
 		}
 		results = append(results, r)
@@ -233,8 +315,8 @@ func (db *ResumeDB) GetAchievementsByIDs(ctx context.Context, ids []int) ([]Achi
 	if len(ids) == 0 {
 		return nil, nil
 	}
-	rows, err := db.pool.Query(ctx,
-		`SELECT id, person_id, text, metric, value, context
+	rows, err := db.q.Query(ctx,
+		`SELECT id, person_id, text, metric, value, context, metric_numeric, COALESCE(metric_unit, '')
 		 FROM resume_achievements WHERE id = ANY($1) ORDER BY id`, ids)
 	if err != nil {
 		return nil, err
@@ -244,7 +326,7 @@ func (db *ResumeDB) GetAchievementsByIDs(ctx context.Context, ids []int) ([]Achi
 	var results []AchievementRecord
 	for rows.Next() {
 		var r AchievementRecord
-		if err := rows.Scan(&r.ID, &r.PersonID, &r.Text, &r.Metric, &r.Value, &r.Context); err != nil {
+		if err := rows.Scan(&r.ID, &r.PersonID, &r.Text, &r.Metric, &r.Value, &r.Context, &r.MetricNumeric, &r.MetricUnit); err != nil {
 			return nil, err
 		}
 		results = append(results, r)
@@ -268,7 +350,7 @@ type EducationRecord struct {
 
 func (db *ResumeDB) InsertEducation(ctx context.Context, personID int, e EducationRecord) (int, error) {
 	var id int
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`INSERT INTO resume_educations (person_id, school, degree, field, start_date, end_date, gpa, highlights)
 		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
 		personID, e.School, e.Degree, e.Field, e.StartDate, e.EndDate, e.GPA, e.Highlights,
@@ -277,7 +359,7 @@ func (db *ResumeDB) InsertEducation(ctx context.Context, personID int, e Educati
 }
 
 func (db *ResumeDB) GetAllEducations(ctx context.Context, personID int) ([]EducationRecord, error) {
-	rows, err := db.pool.Query(ctx,
+	rows, err := db.q.Query(ctx,
 		`SELECT id, person_id, school, degree, field, start_date, end_date, gpa, highlights
 		 FROM resume_educations WHERE person_id = $1 ORDER BY id`, personID)
 	if err != nil {
@@ -310,7 +392,7 @@ type CertificationRecord struct {
 
 func (db *ResumeDB) InsertCertification(ctx context.Context, personID int, c CertificationRecord) (int, error) {
 	var id int
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`INSERT INTO resume_certifications (person_id, name, issuer, year, url)
 		 VALUES ($1, $2, $3, $4, $5) RETURNING id`,
 		personID, c.Name, c.Issuer, c.Year, c.URL,
@@ -319,7 +401,7 @@ func (db *ResumeDB) InsertCertification(ctx context.Context, personID int, c Cer
 }
 
 func (db *ResumeDB) GetAllCertifications(ctx context.Context, personID int) ([]CertificationRecord, error) {
-	rows, err := db.pool.Query(ctx,
+	rows, err := db.q.Query(ctx,
 		`SELECT id, person_id, name, issuer, year, url
 		 FROM resume_certifications WHERE person_id = $1 ORDER BY id`, personID)
 	if err != nil {
@@ -347,7 +429,7 @@ type DomainRecord struct {
 
 func (db *ResumeDB) InsertDomain(ctx context.Context, personID int, name string) (int, error) {
 	var id int
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`INSERT INTO public.resume_domains (person_id, name) VALUES ($1, $2)
 		 ON CONFLICT (person_id, name) DO UPDATE SET name = EXCLUDED.name
 		 RETURNING id`,
@@ -357,7 +439,7 @@ func (db *ResumeDB) InsertDomain(ctx context.Context, personID int, name string)
 }
 
 func (db *ResumeDB) GetAllDomains(ctx context.Context, personID int) ([]DomainRecord, error) {
-	rows, err := db.pool.Query(ctx,
+	rows, err := db.q.Query(ctx,
 		`SELECT id, name FROM public.resume_domains WHERE person_id = $1 ORDER BY id`, personID)
 	if err != nil {
 		return nil, err
@@ -384,7 +466,7 @@ type MethodologyRecord struct {
 
 func (db *ResumeDB) InsertMethodology(ctx context.Context, personID int, name, desc string) (int, error) {
 	var id int
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`INSERT INTO public.resume_methodologies (person_id, name, description) VALUES ($1, $2, $3)
 		 ON CONFLICT (person_id, name) DO UPDATE SET description = EXCLUDED.description
 		 RETURNING id`,
@@ -394,7 +476,7 @@ func (db *ResumeDB) InsertMethodology(ctx context.Context, personID int, name, d
 }
 
 func (db *ResumeDB) GetAllMethodologies(ctx context.Context, personID int) ([]MethodologyRecord, error) {
-	rows, err := db.pool.Query(ctx,
+	rows, err := db.q.Query(ctx,
 		`SELECT id, name, COALESCE(description, '') FROM public.resume_methodologies WHERE person_id = $1 ORDER BY id`, personID)
 	if err != nil {
 		return nil, err
@@ -415,17 +497,39 @@ func (db *ResumeDB) GetAllMethodologies(ctx context.Context, personID int) ([]Me
 
 // UpdateExperienceMeta updates the extended metadata on an experience row.
 func (db *ResumeDB) UpdateExperienceMeta(ctx context.Context, expID int, teamSize, budgetUSD *int, domain string, isVolunteer bool) error {
-	_, err := db.pool.Exec(ctx,
+	_, err := db.q.Exec(ctx,
 		`UPDATE resume_experiences SET team_size = $2, budget_usd = $3, domain = $4, is_volunteer = $5 WHERE id = $1`,
 		expID, teamSize, budgetUSD, domain, isVolunteer,
 	)
 	return err
 }
 
+// UpdateSkillLastUsed sets when a skill was last used (YYYY-MM or "present").
+func (db *ResumeDB) UpdateSkillLastUsed(ctx context.Context, skillID int, lastUsed string) error {
+	_, err := db.q.Exec(ctx,
+		`UPDATE resume_skills SET last_used = $2 WHERE id = $1`, skillID, lastUsed)
+	return err
+}
+
+// UpdateAchievementText replaces an achievement's text.
+func (db *ResumeDB) UpdateAchievementText(ctx context.Context, achvID int, text string) error {
+	_, err := db.q.Exec(ctx,
+		`UPDATE resume_achievements SET text = $2 WHERE id = $1`, achvID, text)
+	return err
+}
+
+// UpdateAchievementMetric sets an achievement's parsed metric.
+func (db *ResumeDB) UpdateAchievementMetric(ctx context.Context, achvID int, metricNumeric *float64, metricUnit string) error {
+	_, err := db.q.Exec(ctx,
+		`UPDATE resume_achievements SET metric_numeric = $2, metric_unit = $3 WHERE id = $1`,
+		achvID, metricNumeric, metricUnit)
+	return err
+}
+
 // InsertProjectWithParent inserts a project linked to a parent experience.
 func (db *ResumeDB) InsertProjectWithParent(ctx context.Context, personID int, parentExpID *int, p ProjectRecord) (int, error) {
 	var id int
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`INSERT INTO resume_projects (person_id, name, description, url, tech, highlights, parent_experience_id)
 		 VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
 		personID, p.Name, p.Description, p.URL, p.Tech, p.Highlights, parentExpID,
@@ -435,7 +539,7 @@ func (db *ResumeDB) InsertProjectWithParent(ctx context.Context, personID int, p
 
 // MarkPersonEnriched sets the enriched_at timestamp on a person.
 func (db *ResumeDB) MarkPersonEnriched(ctx context.Context, personID int) error {
-	_, err := db.pool.Exec(ctx,
+	_, err := db.q.Exec(ctx,
 		`UPDATE resume_persons SET enriched_at = now() WHERE id = $1`, personID)
 	return err
 }
@@ -443,7 +547,7 @@ func (db *ResumeDB) MarkPersonEnriched(ctx context.Context, personID int) error
 // InsertSkillExtended inserts a skill with implicit/source tracking.
 func (db *ResumeDB) InsertSkillExtended(ctx context.Context, personID int, s SkillRecord) (int, error) {
 	var id int
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`INSERT INTO resume_skills (person_id, name, category, level, is_implicit, source)
 		 VALUES ($1, $2, $3, $4, $5, $6)
 		 ON CONFLICT (person_id, name) DO UPDATE SET category = EXCLUDED.category, level = EXCLUDED.level, is_implicit = EXCLUDED.is_implicit, source = EXCLUDED.source
@@ -456,11 +560,10 @@ func (db *ResumeDB) InsertSkillExtended(ctx context.Context, personID int, s Ski
 // InsertAchievementExtended inserts an achievement with parsed metric fields.
 func (db *ResumeDB) InsertAchievementExtended(ctx context.Context, personID int, a AchievementRecord) (int, error) {
 	var id int
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`INSERT INTO resume_achievements (person_id, text, metric, value, context, metric_numeric, metric_unit)
 		 VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
 		personID, a.Text, a.Metric, a.Value, a.Context, a.MetricNumeric, a.MetricUnit,
 	).Scan(&id)
 	return id, err
 }
-
@@ -114,6 +114,32 @@ func (db *ResumeDB) QueryExperienceIDsBySkill(ctx context.Context, skillName str
 	return scanAGEIntIDs(rows)
 }
 
+// QueryExperienceIDsBySkillID finds experience IDs linked to a skill by its ID.
+func (db *ResumeDB) QueryExperienceIDsBySkillID(ctx context.Context, skillID int) ([]int, error) {
+	conn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, ageSetup); err != nil {
+		return nil, err
+	}
+
+	cypher := fmt.Sprintf(`
+		SELECT * FROM ag_catalog.cypher('resume_graph', $$
+			MATCH (e:Exp)-[:USED_SKILL]->(s:Skill {id: %d})
+			RETURN e.id
+		$$) AS (id ag_catalog.agtype)`, skillID)
+
+	rows, err := conn.Query(ctx, cypher)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanAGEIntIDs(rows)
+}
+
 // QueryProjectIDsBySkill finds project IDs linked to a skill name via the graph.
 func (db *ResumeDB) QueryProjectIDsBySkill(ctx context.Context, skillName string) ([]int, error) {
 	conn, err := db.pool.Acquire(ctx)
@@ -248,6 +274,53 @@ func (db *ResumeDB) QuerySubProjectIDs(ctx context.Context, expID int) ([]int, e
 	return scanAGEIntIDs(rows)
 }
 
+// QuerySkillExperiences returns the experience IDs each skill ID was used in, directly
+// or through a sub-project of the experience.
+func (db *ResumeDB) QuerySkillExperiences(ctx context.Context) (map[int][]int, error) {
+	conn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, ageSetup); err != nil {
+		return nil, fmt.Errorf("age setup: %w", err)
+	}
+
+	out := make(map[int][]int)
+	for _, match := range []string{
+		"(e:Exp)-[:USED_SKILL]->(s:Skill)",
+		"(p:Proj)-[:PART_OF]->(e:Exp), (p)-[:USED_SKILL]->(s:Skill)",
+	} {
+		cypher := fmt.Sprintf(`
+			SELECT * FROM ag_catalog.cypher('resume_graph', $$
+				MATCH %s
+				RETURN s.id, e.id
+			$$) AS (skill_id ag_catalog.agtype, exp_id ag_catalog.agtype)`, match)
+		rows, err := conn.Query(ctx, cypher)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var rawSkill, rawExp string
+			if err := rows.Scan(&rawSkill, &rawExp); err != nil {
+				continue
+			}
+			var skillID, expID int
+			_, _ = fmt.Sscanf(strings.TrimSpace(rawSkill), "%d", &skillID)
+			_, _ = fmt.Sscanf(strings.TrimSpace(rawExp), "%d", &expID)
+			if skillID > 0 && expID > 0 {
+				out[skillID] = append(out[skillID], expID)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
 // TrajectoryEdge represents a career evolution edge.
 type TrajectoryEdge struct {
 	FromExpID int    `json:"from_exp_id"`
@@ -299,7 +372,7 @@ func (db *ResumeDB) QueryCareerTrajectory(ctx context.Context, personID int) ([]
 // QuerySkillIDByName returns the skill ID for a given name, or 0 if not found.
 func (db *ResumeDB) QuerySkillIDByName(ctx context.Context, personID int, skillName string) int {
 	var id int
-	err := db.pool.QueryRow(ctx,
+	err := db.q.QueryRow(ctx,
 		`SELECT id FROM resume_skills WHERE person_id = $1 AND LOWER(name) = LOWER($2)`,
 		personID, skillName,
 	).Scan(&id)
@@ -0,0 +1,184 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// graphBatchSize caps the rows merged by one UNWIND statement, keeping the Cypher text bounded.
+const graphBatchSize = 200
+
+// graphBatch is one UNWIND statement and the ops it merges.
+type graphBatch struct {
+	cypher string
+	ops    []graphOp
+}
+
+// ApplyGraphOps writes ops to the resume graph over a single connection with one AGE setup.
+// Nodes are merged before edges, grouped by label (or edge type) into UNWIND batches, and all
+// batches are sent as one multi-statement round trip. If that fails, batches are retried one by
+// one and a failing batch falls back to per-op statements, so one bad row cannot sink the rest.
+// Returns the number of ops that could not be written.
+func (db *ResumeDB) ApplyGraphOps(ctx context.Context, ops []graphOp) (int, error) {
+	batches := planGraphBatches(ops)
+	if len(batches) == 0 {
+		return 0, nil
+	}
+
+	conn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return len(ops), fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, ageSetup); err != nil {
+		return len(ops), fmt.Errorf("age setup: %w", err)
+	}
+
+	stmts := make([]string, len(batches))
+	for i, b := range batches {
+		stmts[i] = b.cypher
+	}
+	_, err = conn.Exec(ctx, strings.Join(stmts, ";\n"))
+	if err == nil {
+		return 0, nil
+	}
+	slog.Debug("graph batch write failed, retrying per batch", slog.Any("error", err))
+
+	failed := 0
+	for _, b := range batches {
+		if _, err := conn.Exec(ctx, b.cypher); err == nil {
+			continue
+		}
+		for _, op := range b.ops {
+			if _, err := conn.Exec(ctx, planGraphBatches([]graphOp{op})[0].cypher); err != nil {
+				slog.Debug("graph upsert failed", slog.String("label", op.Label), slog.Int("id", op.ID), slog.String("edge", op.Edge), slog.Any("error", err))
+				failed++
+			}
+		}
+	}
+	return failed, nil
+}
+
+// planGraphBatches groups ops into UNWIND statements: node merges first (by label and
+// property keys, later props for the same node winning), then edge merges (by endpoint
+// labels and edge type, duplicates dropped). Group order follows first appearance in ops.
+func planGraphBatches(ops []graphOp) []graphBatch {
+	type group struct{ ops []graphOp }
+	var nodeGroups, edgeGroups []*group
+	nodeIdx := make(map[string]*group)
+	edgeIdx := make(map[string]*group)
+	nodePos := make(map[string]int) // group key + id → index within its group
+	seenEdge := make(map[string]bool)
+
+	for _, op := range ops {
+		if op.Edge == "" {
+			keys := sortedKeys(op.Props)
+			gk := op.Label + "|" + strings.Join(keys, ",")
+			g := nodeIdx[gk]
+			if g == nil {
+				g = &group{}
+				nodeIdx[gk] = g
+				nodeGroups = append(nodeGroups, g)
+			}
+			nk := gk + "|" + fmt.Sprint(op.ID)
+			if i, ok := nodePos[nk]; ok {
+				g.ops[i] = op
+				continue
+			}
+			nodePos[nk] = len(g.ops)
+			g.ops = append(g.ops, op)
+			continue
+		}
+		gk := op.Label + "|" + op.Edge + "|" + op.ToLabel
+		ek := fmt.Sprintf("%s|%d|%d", gk, op.ID, op.ToID)
+		if seenEdge[ek] {
+			continue
+		}
+		seenEdge[ek] = true
+		g := edgeIdx[gk]
+		if g == nil {
+			g = &group{}
+			edgeIdx[gk] = g
+			edgeGroups = append(edgeGroups, g)
+		}
+		g.ops = append(g.ops, op)
+	}
+
+	var batches []graphBatch
+	for _, g := range nodeGroups {
+		for _, chunk := range chunkGraphOps(g.ops) {
+			batches = append(batches, graphBatch{cypher: nodeBatchCypher(chunk), ops: chunk})
+		}
+	}
+	for _, g := range edgeGroups {
+		for _, chunk := range chunkGraphOps(g.ops) {
+			batches = append(batches, graphBatch{cypher: edgeBatchCypher(chunk), ops: chunk})
+		}
+	}
+	return batches
+}
+
+// nodeBatchCypher merges nodes sharing a label and property keys.
+func nodeBatchCypher(ops []graphOp) string {
+	keys := sortedKeys(ops[0].Props)
+	rows := make([]string, len(ops))
+	for i, op := range ops {
+		fields := []string{fmt.Sprintf("id: %d", op.ID)}
+		for _, k := range keys {
+			fields = append(fields, fmt.Sprintf("%s: '%s'", escapeCypher(k), escapeCypher(op.Props[k])))
+		}
+		rows[i] = "{" + strings.Join(fields, ", ") + "}"
+	}
+	setClause := ""
+	if len(keys) > 0 {
+		sets := make([]string, len(keys))
+		for i, k := range keys {
+			sets[i] = fmt.Sprintf("n.%s = row.%s", escapeCypher(k), escapeCypher(k))
+		}
+		setClause = "SET " + strings.Join(sets, ", ")
+	}
+	return fmt.Sprintf(`SELECT * FROM ag_catalog.cypher('resume_graph', $$
+			UNWIND [%s] AS row
+			MERGE (n:%s {id: row.id})
+			%s
+		$$) AS (result ag_catalog.agtype)`,
+		strings.Join(rows, ", "), ops[0].Label, setClause,
+	)
+}
+
+// edgeBatchCypher merges edges sharing endpoint labels and edge type.
+func edgeBatchCypher(ops []graphOp) string {
+	rows := make([]string, len(ops))
+	for i, op := range ops {
+		rows[i] = fmt.Sprintf("{f: %d, t: %d}", op.ID, op.ToID)
+	}
+	return fmt.Sprintf(`SELECT * FROM ag_catalog.cypher('resume_graph', $$
+			UNWIND [%s] AS row
+			MATCH (a:%s {id: row.f}), (b:%s {id: row.t})
+			MERGE (a)-[:%s]->(b)
+		$$) AS (result ag_catalog.agtype)`,
+		strings.Join(rows, ", "), ops[0].Label, ops[0].ToLabel, ops[0].Edge,
+	)
+}
+
+func chunkGraphOps(ops []graphOp) [][]graphOp {
+	var chunks [][]graphOp
+	for len(ops) > graphBatchSize {
+		chunks = append(chunks, ops[:graphBatchSize])
+		ops = ops[graphBatchSize:]
+	}
+	return append(chunks, ops)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlanGraphBatches(t *testing.T) {
+	plan := &buildPlan{}
+	plan.node("Exp", 1, map[string]string{"title": "Engineer", "company": "Acme"})
+	plan.node("Skill", 7, map[string]string{"name": "go"})
+	plan.edge("Exp", 1, "USED_SKILL", "Skill", 7)
+	plan.node("Skill", 8, map[string]string{"name": "SQL"})
+	plan.node("Skill", 7, map[string]string{"name": "Go"})
+	plan.edge("Exp", 1, "USED_SKILL", "Skill", 8)
+	plan.edge("Exp", 1, "USED_SKILL", "Skill", 7)
+
+	batches := planGraphBatches(plan.Graph)
+	if len(batches) != 3 {
+		t.Fatalf("got %d batches, want 3 (Exp nodes, Skill nodes, USED_SKILL edges)", len(batches))
+	}
+	if len(batches[1].ops) != 2 || batches[1].ops[0].Props["name"] != "Go" {
+		t.Errorf("skill nodes should be deduplicated with the last props winning: %+v", batches[1].ops)
+	}
+	if len(batches[2].ops) != 2 {
+		t.Errorf("duplicate edge should be dropped: %+v", batches[2].ops)
+	}
+	if !strings.Contains(batches[0].cypher, "SET n.company = row.company, n.title = row.title") {
+		t.Errorf("node cypher missing SET clause:\n%s", batches[0].cypher)
+	}
+	if !strings.Contains(batches[2].cypher, "{f: 1, t: 7}, {f: 1, t: 8}") ||
+		!strings.Contains(batches[2].cypher, "MERGE (a)-[:USED_SKILL]->(b)") {
+		t.Errorf("unexpected edge cypher:\n%s", batches[2].cypher)
+	}
+}
+
+func TestPlanGraphBatchesChunks(t *testing.T) {
+	plan := &buildPlan{}
+	for i := 1; i <= graphBatchSize+5; i++ {
+		plan.node("Achv", i, map[string]string{"text": "it's done"})
+	}
+	batches := planGraphBatches(plan.Graph)
+	if len(batches) != 2 || len(batches[0].ops) != graphBatchSize || len(batches[1].ops) != 5 {
+		t.Fatalf("unexpected chunking: %d batches", len(batches))
+	}
+	if !strings.Contains(batches[0].cypher, `text: 'it\'s done'`) {
+		t.Error("property values must be escaped")
+	}
+}
@@ -0,0 +1,423 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// --- Publications, talks, patents and open-source maintainership ---
+
+// PublicationRecord is a paper, article, book or chapter.
+type PublicationRecord struct {
+	ID       int      `json:"id"`
+	PersonID int      `json:"person_id"`
+	Title    string   `json:"title"`
+	Venue    string   `json:"venue,omitempty"`
+	Date     string   `json:"date,omitempty"`
+	URL      string   `json:"url,omitempty"`
+	Authors  []string `json:"authors,omitempty"`
+	Summary  string   `json:"summary,omitempty"`
+	Topics   []string `json:"topics,omitempty"`
+}
+
+// TalkRecord is a conference talk, meetup presentation or workshop.
+type TalkRecord struct {
+	ID       int      `json:"id"`
+	PersonID int      `json:"person_id"`
+	Title    string   `json:"title"`
+	Event    string   `json:"event,omitempty"`
+	Date     string   `json:"date,omitempty"`
+	Location string   `json:"location,omitempty"`
+	URL      string   `json:"url,omitempty"`
+	Summary  string   `json:"summary,omitempty"`
+	Topics   []string `json:"topics,omitempty"`
+}
+
+// PatentRecord is a granted or pending patent.
+type PatentRecord struct {
+	ID       int      `json:"id"`
+	PersonID int      `json:"person_id"`
+	Title    string   `json:"title"`
+	Number   string   `json:"number,omitempty"`
+	Status   string   `json:"status,omitempty"` // granted, pending, filed
+	Date     string   `json:"date,omitempty"`
+	URL      string   `json:"url,omitempty"`
+	Summary  string   `json:"summary,omitempty"`
+	Topics   []string `json:"topics,omitempty"`
+}
+
+// OpenSourceRecord is an open-source project the person created or maintains.
+type OpenSourceRecord struct {
+	ID       int      `json:"id"`
+	PersonID int      `json:"person_id"`
+	Project  string   `json:"project"`
+	Role     string   `json:"role,omitempty"` // creator, maintainer, core contributor
+	URL      string   `json:"url,omitempty"`
+	Since    string   `json:"since,omitempty"`
+	Stars    *int     `json:"stars,omitempty"`
+	Summary  string   `json:"summary,omitempty"`
+	Tech     []string `json:"tech,omitempty"`
+}
+
+func (db *ResumeDB) InsertPublication(ctx context.Context, personID int, p PublicationRecord) (int, error) {
+	var id int
+	err := db.q.QueryRow(ctx,
+		`INSERT INTO public.resume_publications (person_id, title, venue, date, url, authors, summary, topics)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		personID, p.Title, p.Venue, p.Date, p.URL, p.Authors, p.Summary, p.Topics,
+	).Scan(&id)
+	return id, err
+}
+
+func (db *ResumeDB) GetAllPublications(ctx context.Context, personID int) ([]PublicationRecord, error) {
+	rows, err := db.q.Query(ctx,
+		`SELECT id, person_id, title, COALESCE(venue, ''), COALESCE(date, ''), COALESCE(url, ''), authors, COALESCE(summary, ''), topics
+		 FROM public.resume_publications WHERE person_id = $1 ORDER BY id`, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []PublicationRecord
+	for rows.Next() {
+		var r PublicationRecord
+		if err := rows.Scan(&r.ID, &r.PersonID, &r.Title, &r.Venue, &r.Date, &r.URL, &r.Authors, &r.Summary, &r.Topics); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+func (db *ResumeDB) InsertTalk(ctx context.Context, personID int, t TalkRecord) (int, error) {
+	var id int
+	err := db.q.QueryRow(ctx,
+		`INSERT INTO public.resume_talks (person_id, title, event, date, location, url, summary, topics)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		personID, t.Title, t.Event, t.Date, t.Location, t.URL, t.Summary, t.Topics,
+	).Scan(&id)
+	return id, err
+}
+
+func (db *ResumeDB) GetAllTalks(ctx context.Context, personID int) ([]TalkRecord, error) {
+	rows, err := db.q.Query(ctx,
+		`SELECT id, person_id, title, COALESCE(event, ''), COALESCE(date, ''), COALESCE(location, ''), COALESCE(url, ''), COALESCE(summary, ''), topics
+		 FROM public.resume_talks WHERE person_id = $1 ORDER BY id`, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []TalkRecord
+	for rows.Next() {
+		var r TalkRecord
+		if err := rows.Scan(&r.ID, &r.PersonID, &r.Title, &r.Event, &r.Date, &r.Location, &r.URL, &r.Summary, &r.Topics); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+func (db *ResumeDB) InsertPatent(ctx context.Context, personID int, p PatentRecord) (int, error) {
+	var id int
+	err := db.q.QueryRow(ctx,
+		`INSERT INTO public.resume_patents (person_id, title, number, status, date, url, summary, topics)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		personID, p.Title, p.Number, p.Status, p.Date, p.URL, p.Summary, p.Topics,
+	).Scan(&id)
+	return id, err
+}
+
+func (db *ResumeDB) GetAllPatents(ctx context.Context, personID int) ([]PatentRecord, error) {
+	rows, err := db.q.Query(ctx,
+		`SELECT id, person_id, title, COALESCE(number, ''), COALESCE(status, ''), COALESCE(date, ''), COALESCE(url, ''), COALESCE(summary, ''), topics
+		 FROM public.resume_patents WHERE person_id = $1 ORDER BY id`, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []PatentRecord
+	for rows.Next() {
+		var r PatentRecord
+		if err := rows.Scan(&r.ID, &r.PersonID, &r.Title, &r.Number, &r.Status, &r.Date, &r.URL, &r.Summary, &r.Topics); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+func (db *ResumeDB) InsertOpenSource(ctx context.Context, personID int, o OpenSourceRecord) (int, error) {
+	var id int
+	err := db.q.QueryRow(ctx,
+		`INSERT INTO public.resume_open_source (person_id, project, role, url, since, stars, summary, tech)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		personID, o.Project, o.Role, o.URL, o.Since, o.Stars, o.Summary, o.Tech,
+	).Scan(&id)
+	return id, err
+}
+
+func (db *ResumeDB) GetAllOpenSource(ctx context.Context, personID int) ([]OpenSourceRecord, error) {
+	rows, err := db.q.Query(ctx,
+		`SELECT id, person_id, project, COALESCE(role, ''), COALESCE(url, ''), COALESCE(since, ''), stars, COALESCE(summary, ''), tech
+		 FROM public.resume_open_source WHERE person_id = $1 ORDER BY id`, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []OpenSourceRecord
+	for rows.Next() {
+		var r OpenSourceRecord
+		if err := rows.Scan(&r.ID, &r.PersonID, &r.Project, &r.Role, &r.URL, &r.Since, &r.Stars, &r.Summary, &r.Tech); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// QueryWorkIDsBySkill finds IDs of nodes with the given label (Pub, Talk, Patent, OSS)
+// linked to a skill by ABOUT_SKILL or USED_SKILL.
+func (db *ResumeDB) QueryWorkIDsBySkill(ctx context.Context, label, skillName string) ([]int, error) {
+	conn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, ageSetup); err != nil {
+		return nil, fmt.Errorf("age setup: %w", err)
+	}
+
+	cypher := fmt.Sprintf(`
+		SELECT * FROM ag_catalog.cypher('resume_graph', $$
+			MATCH (w:%s)-[]->(s:Skill {name: '%s'})
+			RETURN w.id
+		$$) AS (id ag_catalog.agtype)`, label, escapeCypher(skillName))
+
+	rows, err := conn.Query(ctx, cypher)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAGEIntIDs(rows)
+}
+
+// resumeWorks groups the publications, talks, patents and open-source projects of a resume.
+type resumeWorks struct {
+	Publications []PublicationRecord
+	Talks        []TalkRecord
+	Patents      []PatentRecord
+	OpenSource   []OpenSourceRecord
+}
+
+// workLabels maps each work vector type to its graph label.
+var workLabels = map[string]string{"publication": "Pub", "talk": "Talk", "patent": "Patent", "open_source": "OSS"}
+
+// loadResumeWorks loads all works of a person.
+func loadResumeWorks(ctx context.Context, db ResumeStore, personID int) (resumeWorks, error) {
+	var w resumeWorks
+	var err error
+	if w.Publications, err = db.GetAllPublications(ctx, personID); err != nil {
+		return w, fmt.Errorf("load publications: %w", err)
+	}
+	if w.Talks, err = db.GetAllTalks(ctx, personID); err != nil {
+		return w, fmt.Errorf("load talks: %w", err)
+	}
+	if w.Patents, err = db.GetAllPatents(ctx, personID); err != nil {
+		return w, fmt.Errorf("load patents: %w", err)
+	}
+	if w.OpenSource, err = db.GetAllOpenSource(ctx, personID); err != nil {
+		return w, fmt.Errorf("load open source: %w", err)
+	}
+	return w, nil
+}
+
+// relevant keeps the works picked by graph/vector search (ids, keyed by vector type) or
+// whose text mentions one of the JD skills. Works are only worth resume space when
+// they speak to the role, so nothing falls back to "all".
+func (w resumeWorks) relevant(ids map[string]map[int]bool, jdSkills []string) resumeWorks {
+	var keys []string
+	for _, s := range jdSkills {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			keys = append(keys, s)
+		}
+	}
+	match := func(typ string, id int, text string) bool {
+		if ids[typ][id] {
+			return true
+		}
+		text = strings.ToLower(text)
+		for _, k := range keys {
+			if strings.Contains(text, k) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var out resumeWorks
+	for _, p := range w.Publications {
+		if match("publication", p.ID, formatPublicationText(p)) {
+			out.Publications = append(out.Publications, p)
+		}
+	}
+	for _, t := range w.Talks {
+		if match("talk", t.ID, formatTalkText(t)) {
+			out.Talks = append(out.Talks, t)
+		}
+	}
+	for _, p := range w.Patents {
+		if match("patent", p.ID, formatPatentText(p)) {
+			out.Patents = append(out.Patents, p)
+		}
+	}
+	for _, o := range w.OpenSource {
+		if match("open_source", o.ID, formatOpenSourceText(o)) {
+			out.OpenSource = append(out.OpenSource, o)
+		}
+	}
+	return out
+}
+
+// count returns the number of works.
+func (w resumeWorks) count() int {
+	return len(w.Publications) + len(w.Talks) + len(w.Patents) + len(w.OpenSource)
+}
+
+// addEvidence indexes the evidence tags of the works for resolveResumeEvidence.
+func (w resumeWorks) addEvidence(known map[string]EvidenceRef) {
+	for _, p := range w.Publications {
+		known[evidenceTag("PUB", p.ID)] = EvidenceRef{Type: "publication", ID: p.ID}
+	}
+	for _, t := range w.Talks {
+		known[evidenceTag("TALK", t.ID)] = EvidenceRef{Type: "talk", ID: t.ID}
+	}
+	for _, p := range w.Patents {
+		known[evidenceTag("PAT", p.ID)] = EvidenceRef{Type: "patent", ID: p.ID}
+	}
+	for _, o := range w.OpenSource {
+		known[evidenceTag("OSS", o.ID)] = EvidenceRef{Type: "open_source", ID: o.ID}
+	}
+}
+
+// writeCandidateData appends the works sections to the resume_generate candidate data.
+func (w resumeWorks) writeCandidateData(b *strings.Builder) {
+	if len(w.Publications) > 0 {
+		b.WriteString("\n=== PUBLICATIONS ===\n")
+		for _, p := range w.Publications {
+			fmt.Fprintf(b, "• %s %s\n", evidenceTag("PUB", p.ID), formatPublicationText(p))
+		}
+	}
+	if len(w.Talks) > 0 {
+		b.WriteString("\n=== TALKS ===\n")
+		for _, t := range w.Talks {
+			fmt.Fprintf(b, "• %s %s\n", evidenceTag("TALK", t.ID), formatTalkText(t))
+		}
+	}
+	if len(w.Patents) > 0 {
+		b.WriteString("\n=== PATENTS ===\n")
+		for _, p := range w.Patents {
+			fmt.Fprintf(b, "• %s %s\n", evidenceTag("PAT", p.ID), formatPatentText(p))
+		}
+	}
+	if len(w.OpenSource) > 0 {
+		b.WriteString("\n=== OPEN SOURCE ===\n")
+		for _, o := range w.OpenSource {
+			fmt.Fprintf(b, "• %s %s\n", evidenceTag("OSS", o.ID), formatOpenSourceText(o))
+		}
+	}
+}
+
+// formatPublicationText creates a text representation of a publication for embedding and prompts.
+func formatPublicationText(p PublicationRecord) string {
+	var b strings.Builder
+	b.WriteString(p.Title)
+	if p.Venue != "" {
+		fmt.Fprintf(&b, " — %s", p.Venue)
+	}
+	if p.Date != "" {
+		fmt.Fprintf(&b, " (%s)", p.Date)
+	}
+	if len(p.Authors) > 0 {
+		fmt.Fprintf(&b, ". Authors: %s", strings.Join(p.Authors, ", "))
+	}
+	if p.Summary != "" {
+		fmt.Fprintf(&b, ". %s", p.Summary)
+	}
+	if len(p.Topics) > 0 {
+		fmt.Fprintf(&b, ". Topics: %s", strings.Join(p.Topics, ", "))
+	}
+	return b.String()
+}
+
+// formatTalkText creates a text representation of a talk for embedding and prompts.
+func formatTalkText(t TalkRecord) string {
+	var b strings.Builder
+	b.WriteString(t.Title)
+	if t.Event != "" {
+		fmt.Fprintf(&b, " — %s", t.Event)
+	}
+	if t.Location != "" {
+		fmt.Fprintf(&b, ", %s", t.Location)
+	}
+	if t.Date != "" {
+		fmt.Fprintf(&b, " (%s)", t.Date)
+	}
+	if t.Summary != "" {
+		fmt.Fprintf(&b, ". %s", t.Summary)
+	}
+	if len(t.Topics) > 0 {
+		fmt.Fprintf(&b, ". Topics: %s", strings.Join(t.Topics, ", "))
+	}
+	return b.String()
+}
+
+// formatPatentText creates a text representation of a patent for embedding and prompts.
+func formatPatentText(p PatentRecord) string {
+	var b strings.Builder
+	b.WriteString(p.Title)
+	if p.Number != "" {
+		fmt.Fprintf(&b, " — %s", p.Number)
+	}
+	if p.Status != "" || p.Date != "" {
+		fmt.Fprintf(&b, " (%s)", strings.TrimSpace(p.Status+" "+p.Date))
+	}
+	if p.Summary != "" {
+		fmt.Fprintf(&b, ". %s", p.Summary)
+	}
+	if len(p.Topics) > 0 {
+		fmt.Fprintf(&b, ". Topics: %s", strings.Join(p.Topics, ", "))
+	}
+	return b.String()
+}
+
+// formatOpenSourceText creates a text representation of an open-source project for embedding and prompts.
+func formatOpenSourceText(o OpenSourceRecord) string {
+	var b strings.Builder
+	b.WriteString(o.Project)
+	if o.Role != "" {
+		fmt.Fprintf(&b, " — %s", o.Role)
+	}
+	if o.Since != "" {
+		fmt.Fprintf(&b, " since %s", o.Since)
+	}
+	if o.Stars != nil {
+		fmt.Fprintf(&b, " (%d GitHub stars)", *o.Stars)
+	}
+	if o.URL != "" {
+		fmt.Fprintf(&b, " %s", o.URL)
+	}
+	if o.Summary != "" {
+		fmt.Fprintf(&b, ". %s", o.Summary)
+	}
+	if len(o.Tech) > 0 {
+		fmt.Fprintf(&b, ". Tech: %s", strings.Join(o.Tech, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,198 @@
+package jobs
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Scam risk levels written to JobListing.ScamRisk.
+const (
+	ScamRiskLow    = "low"
+	ScamRiskMedium = "medium"
+	ScamRiskHigh   = "high"
+)
+
+var (
+	payToStartRe = regexp.MustCompile(`(?i)\b(pay (a |the )?(small )?(fee|deposit)|(training|registration|starter kit|onboarding) fee|buy (your )?(own )?(equipment|starter kit|software license)|send (us )?(bitcoin|btc|usdt|crypto)|deposit (in|into) (bitcoin|crypto)|check to (buy|purchase) equipment)\b`)
+	// Messengers count only as the contact or interview channel; a company Telegram
+	// channel or a Telegram-bot job is not a signal.
+	cryptoPayRe = regexp.MustCompile(`(?i)\b(paid (weekly |daily )?in (bitcoin|crypto|usdt)|(contact|message|text|reach|chat with|interview)( us| me| hr| the recruiter)? (via|on|over|through) (telegram|whatsapp)|(telegram|whatsapp) (interview|only))\b`)
+	// Consumer mailbox providers only, by exact domain: company subdomains such as
+	// mail.acme.com and the regional providers small employers use (mail.ru,
+	// yandex.ru) are not a signal.
+	personalMail = regexp.MustCompile(`(?i)[a-z0-9._%+-]+@(gmail\.com|googlemail\.com|yahoo\.com|yahoo\.co\.uk|ymail\.com|hotmail\.com|hotmail\.co\.uk|outlook\.com|live\.com|aol\.com|icloud\.com|proton\.me|protonmail\.com)\b`)
+	noExpRe      = regexp.MustCompile(`(?i)\b(no experience (needed|required|necessary)|entry[- ]level|data entry|work from your phone)\b`)
+)
+
+// bigCompanies are employers that never recruit through personal mailboxes.
+var bigCompanies = []string{
+	"google", "alphabet", "amazon", "meta", "facebook", "microsoft", "apple", "netflix",
+	"nvidia", "tesla", "ibm", "oracle", "salesforce", "adobe", "intel", "uber", "airbnb",
+	"stripe", "shopify", "spotify", "paypal", "linkedin", "deloitte", "accenture",
+}
+
+// aggregatorHosts republish postings scraped from elsewhere, often stale or spammy.
+var aggregatorHosts = []string{
+	"jooble.", "jobrapido.", "talent.com", "neuvoo.", "adzuna.", "jobisjob.", "careerjet.",
+	"jobsora.", "whatjobs.", "jobtome.", "learn4good.", "jobleads.",
+}
+
+// scamWeights maps each signal to its contribution to the risk score.
+var scamWeights = map[string]int{
+	"pay_to_start":        60,
+	"crypto_or_chat_only": 30,
+	"personal_email":      20,
+	"personal_email_big":  60,
+	"salary_above_market": 35,
+	"aggregator_repost":   20,
+	"duplicate_text":      25,
+}
+
+// ScamSignals returns the scam signals for a single listing, independent of other results.
+func ScamSignals(j engine.JobListing) []string {
+	text := j.Title + "\n" + j.Company + "\n" + j.Description + "\n" + j.Salary
+	var signals []string
+	if payToStartRe.MatchString(text) {
+		signals = append(signals, "pay_to_start")
+	}
+	if cryptoPayRe.MatchString(text) {
+		signals = append(signals, "crypto_or_chat_only")
+	}
+	if personalMail.MatchString(text) {
+		if isBigCompany(j.Company) {
+			signals = append(signals, "personal_email_big")
+		} else {
+			signals = append(signals, "personal_email")
+		}
+	}
+	if u, err := url.Parse(j.URL); err == nil {
+		host := strings.ToLower(u.Hostname())
+		for _, agg := range aggregatorHosts {
+			if strings.Contains(host, agg) {
+				signals = append(signals, "aggregator_repost")
+				break
+			}
+		}
+	}
+	return signals
+}
+
+// AnnotateScamRisk sets ScamRisk/ScamSignals on each listing using per-listing rules plus
+// batch-level checks (salary far above the batch median, identical text under different companies).
+func AnnotateScamRisk(listings []engine.JobListing) {
+	median := medianAnnualSalary(listings)
+	dupes := duplicateDescriptions(listings)
+
+	for i := range listings {
+		j := &listings[i]
+		signals := ScamSignals(*j)
+		if annual := annualSalaryMax(*j); median > 0 && annual > 0 {
+			if annual > median*5/2 || (annual > 2*median && noExpRe.MatchString(j.Title+" "+j.Description)) {
+				signals = append(signals, "salary_above_market")
+			}
+		}
+		if dupes[i] {
+			signals = append(signals, "duplicate_text")
+		}
+		j.ScamSignals = signals
+		j.ScamRisk = scamRiskLevel(signals)
+	}
+}
+
+// FilterScams drops listings annotated with high scam risk.
+func FilterScams(listings []engine.JobListing) []engine.JobListing {
+	out := listings[:0:0]
+	for _, j := range listings {
+		if j.ScamRisk != ScamRiskHigh {
+			out = append(out, j)
+		}
+	}
+	return out
+}
+
+func scamRiskLevel(signals []string) string {
+	score := 0
+	for _, s := range signals {
+		score += scamWeights[s]
+	}
+	switch {
+	case score >= 60:
+		return ScamRiskHigh
+	case score >= 25:
+		return ScamRiskMedium
+	default:
+		return ScamRiskLow
+	}
+}
+
+func isBigCompany(company string) bool {
+	c := strings.ToLower(company)
+	for _, big := range bigCompanies {
+		if c == big || strings.HasPrefix(c, big+" ") || strings.HasPrefix(c, big+",") {
+			return true
+		}
+	}
+	return false
+}
+
+// annualSalaryMax returns the listing's annual max salary (falling back to min). 0 if unknown.
+func annualSalaryMax(j engine.JobListing) int {
+	v := annualize(j.SalaryMax, j.SalaryInterval)
+	if v == nil {
+		v = annualize(j.SalaryMin, j.SalaryInterval)
+	}
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// medianAnnualSalary returns the median annual salary across listings sharing the dominant
+// currency. Returns 0 when fewer than 4 comparable salaries exist.
+func medianAnnualSalary(listings []engine.JobListing) int {
+	byCurrency := make(map[string][]int)
+	for _, j := range listings {
+		if a := annualSalaryMax(j); a > 0 {
+			byCurrency[j.SalaryCurrency] = append(byCurrency[j.SalaryCurrency], a)
+		}
+	}
+	var vals []int
+	for _, v := range byCurrency {
+		if len(v) > len(vals) {
+			vals = v
+		}
+	}
+	if len(vals) < 4 {
+		return 0
+	}
+	sort.Ints(vals)
+	return vals[len(vals)/2]
+}
+
+// duplicateDescriptions marks listings whose description is identical to one posted under a different company.
+func duplicateDescriptions(listings []engine.JobListing) map[int]bool {
+	companies := make(map[string]map[string]bool)
+	keys := make([]string, len(listings))
+	for i, j := range listings {
+		d := strings.Join(strings.Fields(strings.ToLower(j.Description)), " ")
+		if len(d) < 80 {
+			continue
+		}
+		keys[i] = d
+		if companies[d] == nil {
+			companies[d] = make(map[string]bool)
+		}
+		companies[d][strings.ToLower(strings.TrimSpace(j.Company))] = true
+	}
+	dupes := make(map[int]bool)
+	for i, k := range keys {
+		if k != "" && len(companies[k]) > 1 {
+			dupes[i] = true
+		}
+	}
+	return dupes
+}
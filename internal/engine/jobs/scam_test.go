@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestAnnotateScamRisk(t *testing.T) {
+	salary := func(v int) *int { return &v }
+	spam := strings.Repeat("Earn money fast from home with flexible hours and great benefits. ", 3)
+
+	listings := []engine.JobListing{
+		{Title: "Backend Engineer", Company: "Acme", URL: "https://acme.com/jobs/1", SalaryMax: salary(150000)},
+		{Title: "Go Developer", Company: "Globex", URL: "https://globex.com/jobs/2", SalaryMax: salary(160000)},
+		{Title: "SRE", Company: "Initech", URL: "https://initech.com/jobs/3", SalaryMax: salary(140000)},
+		{Title: "Remote Assistant", Company: "Google", URL: "https://example.com/4",
+			Description: "Contact hr.google.jobs@gmail.com. Pay a small fee for your starter kit.", SalaryMax: salary(900000)},
+		{Title: "Data Entry", Company: "A", URL: "https://jooble.org/x", Description: spam},
+		{Title: "Data Entry", Company: "B", URL: "https://b.com/y", Description: spam},
+	}
+	AnnotateScamRisk(listings)
+
+	for i := range 3 {
+		if listings[i].ScamRisk != ScamRiskLow || len(listings[i].ScamSignals) != 0 {
+			t.Errorf("listing %d: risk=%s signals=%v, want low/none", i, listings[i].ScamRisk, listings[i].ScamSignals)
+		}
+	}
+
+	scam := listings[3]
+	if scam.ScamRisk != ScamRiskHigh {
+		t.Errorf("scam listing risk = %s, want high", scam.ScamRisk)
+	}
+	for _, want := range []string{"pay_to_start", "personal_email_big", "salary_above_market"} {
+		if !slices.Contains(scam.ScamSignals, want) {
+			t.Errorf("scam listing missing signal %q: %v", want, scam.ScamSignals)
+		}
+	}
+
+	if !slices.Contains(listings[4].ScamSignals, "aggregator_repost") || !slices.Contains(listings[4].ScamSignals, "duplicate_text") {
+		t.Errorf("aggregator listing signals = %v", listings[4].ScamSignals)
+	}
+
+	if got := FilterScams(listings); len(got) != len(listings)-1 {
+		t.Errorf("FilterScams kept %d, want %d", len(got), len(listings)-1)
+	}
+}
+
+func TestScamSignals_ChatAndMail(t *testing.T) {
+	cases := []struct {
+		text string
+		want []string
+	}{
+		{"Interview on Telegram, start today.", []string{"crypto_or_chat_only"}},
+		{"Contact us via WhatsApp to apply.", []string{"crypto_or_chat_only"}},
+		{"Send your CV to recruiter.acme@gmail.com.", []string{"personal_email"}},
+		{"Build our Telegram bot platform in Go. Follow our Telegram channel for news.", nil},
+		{"Send your CV to jobs@mail.acme.com.", nil},
+		{"Резюме присылайте на hr@mail.ru или hr@yandex.ru.", nil},
+	}
+	for _, c := range cases {
+		got := ScamSignals(engine.JobListing{Title: "Backend Engineer", Company: "Acme", Description: c.text})
+		if !slices.Equal(got, c.want) {
+			t.Errorf("ScamSignals(%q) = %v, want %v", c.text, got, c.want)
+		}
+	}
+}
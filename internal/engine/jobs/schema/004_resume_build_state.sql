@@ -0,0 +1,10 @@
+-- 004_resume_build_state.sql: Track master resume builds so derived stores can be checked and repaired.
+
+SET search_path TO public;
+
+-- Graph writes and vector texts of the last build, replayed by the repair path.
+ALTER TABLE resume_persons ADD COLUMN IF NOT EXISTS build_plan JSONB;
+
+-- When the AGE graph and MemDB vectors last matched build_plan.
+ALTER TABLE resume_persons ADD COLUMN IF NOT EXISTS graph_synced_at TIMESTAMPTZ;
+ALTER TABLE resume_persons ADD COLUMN IF NOT EXISTS vectors_synced_at TIMESTAMPTZ;
@@ -0,0 +1,6 @@
+-- 005_skill_recency.sql: Track when each skill was last used, for recency-weighted matching.
+
+SET search_path TO public;
+
+-- YYYY-MM end date of the latest experience using the skill, or 'present'.
+ALTER TABLE resume_skills ADD COLUMN IF NOT EXISTS last_used TEXT;
@@ -0,0 +1,55 @@
+-- 006_resume_works.sql: Publications, conference talks, patents and open-source maintainership.
+
+SET search_path TO public;
+
+CREATE TABLE IF NOT EXISTS public.resume_publications (
+    id          SERIAL PRIMARY KEY,
+    person_id   INT REFERENCES public.resume_persons(id) ON DELETE CASCADE,
+    title       TEXT NOT NULL,
+    venue       TEXT,            -- journal, conference proceedings, book, blog
+    date        TEXT,
+    url         TEXT,
+    authors     TEXT[],
+    summary     TEXT,
+    topics      TEXT[],
+    created_at  TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS public.resume_talks (
+    id          SERIAL PRIMARY KEY,
+    person_id   INT REFERENCES public.resume_persons(id) ON DELETE CASCADE,
+    title       TEXT NOT NULL,
+    event       TEXT,            -- conference or meetup
+    date        TEXT,
+    location    TEXT,
+    url         TEXT,
+    summary     TEXT,
+    topics      TEXT[],
+    created_at  TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS public.resume_patents (
+    id          SERIAL PRIMARY KEY,
+    person_id   INT REFERENCES public.resume_persons(id) ON DELETE CASCADE,
+    title       TEXT NOT NULL,
+    number      TEXT,            -- e.g. US 10,123,456 B2
+    status      TEXT,            -- granted, pending, filed
+    date        TEXT,
+    url         TEXT,
+    summary     TEXT,
+    topics      TEXT[],
+    created_at  TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS public.resume_open_source (
+    id          SERIAL PRIMARY KEY,
+    person_id   INT REFERENCES public.resume_persons(id) ON DELETE CASCADE,
+    project     TEXT NOT NULL,
+    role        TEXT,            -- creator, maintainer, core contributor
+    url         TEXT,
+    since       TEXT,
+    stars       INT,
+    summary     TEXT,
+    tech        TEXT[],
+    created_at  TIMESTAMPTZ DEFAULT now()
+);
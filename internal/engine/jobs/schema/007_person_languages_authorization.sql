@@ -0,0 +1,9 @@
+-- 007_person_languages_authorization.sql: Spoken languages and work authorization on the person record.
+
+SET search_path TO public;
+
+-- [{"language": "English", "proficiency": "native"}, ...]
+ALTER TABLE resume_persons ADD COLUMN IF NOT EXISTS languages JSONB NOT NULL DEFAULT '[]';
+
+-- [{"country": "US", "status": "citizen"}, {"country": "EU", "status": "needs_sponsorship"}, ...]
+ALTER TABLE resume_persons ADD COLUMN IF NOT EXISTS work_authorization JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,19 @@
+-- 008_star_stories.sql: Reusable STAR interview stories generated from achievements.
+
+SET search_path TO public;
+
+CREATE TABLE IF NOT EXISTS public.resume_star_stories (
+    id             SERIAL PRIMARY KEY,
+    person_id      INT REFERENCES public.resume_persons(id) ON DELETE CASCADE,
+    title          TEXT NOT NULL,
+    situation      TEXT NOT NULL,
+    task           TEXT NOT NULL,
+    action         TEXT NOT NULL,
+    result         TEXT NOT NULL,
+    competencies   TEXT[],          -- leadership, conflict, scale, failure, ...
+    achievement_id INT REFERENCES public.resume_achievements(id) ON DELETE SET NULL,
+    experience_id  INT REFERENCES public.resume_experiences(id) ON DELETE SET NULL,
+    created_at     TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_resume_star_stories_person ON public.resume_star_stories(person_id);
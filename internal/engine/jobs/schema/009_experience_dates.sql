@@ -0,0 +1,11 @@
+-- 009_experience_dates.sql: Parsed experience dates for sorting, durations and gaps.
+
+SET search_path TO public;
+
+-- start_date/end_date stay as written; start_on is the first day of the start
+-- period, end_on the last day of the end period (NULL while ongoing).
+-- Precision: 'day', 'month', 'season', 'year', or 'present' for an ongoing role.
+ALTER TABLE resume_experiences ADD COLUMN IF NOT EXISTS start_on DATE;
+ALTER TABLE resume_experiences ADD COLUMN IF NOT EXISTS end_on DATE;
+ALTER TABLE resume_experiences ADD COLUMN IF NOT EXISTS start_precision TEXT;
+ALTER TABLE resume_experiences ADD COLUMN IF NOT EXISTS end_precision TEXT;
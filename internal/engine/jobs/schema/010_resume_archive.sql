@@ -0,0 +1,9 @@
+-- 010_resume_archive.sql: Keep replaced master resume builds for rollback.
+
+SET search_path TO public;
+
+-- master_resume_build archives the previous person (and, through person_id, all its
+-- rows and its build plan) instead of deleting it; master_resume_rollback swaps it
+-- back. The current build is the newest person with archived_at NULL.
+ALTER TABLE resume_persons ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_resume_persons_archived_at ON resume_persons (archived_at);
@@ -0,0 +1,322 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Batch scoring ---
+
+// jobs_score_batch scores job descriptions gathered elsewhere (e.g. by another agent)
+// against the master resume without searching: the job_match_score keyword overlap,
+// weighted by skill recency, the share of the JD's skills the resume covers and, with an
+// embed server, the embedding similarity of the resume and each JD. Jobs come back in
+// recommended priority order with their skill gaps.
+
+const (
+	scoreBatchMaxJobs  = 50
+	scoreBatchSoonDays = 7 // deadlines this close move a job up within its priority tier
+)
+
+// Batch scoring methods.
+const (
+	ScoreByKeywords  = "keywords"
+	ScoreByEmbedding = "keywords+embedding"
+)
+
+// Priority tiers by score.
+const (
+	PriorityHigh   = "high"
+	PriorityMedium = "medium"
+	PriorityLow    = "low"
+)
+
+// JobsScoreBatchInput is the input for jobs_score_batch.
+type JobsScoreBatchInput struct {
+	Jobs []BatchJob `json:"jobs" jsonschema:"Job descriptions to score (max 50); each needs jd text or a url to fetch"`
+}
+
+// BatchJob is one job description to score.
+type BatchJob struct {
+	ID      string `json:"id,omitempty" jsonschema:"Caller's identifier, echoed back in the result"`
+	Title   string `json:"title,omitempty" jsonschema:"Job title (taken from the posting when omitted)"`
+	Company string `json:"company,omitempty" jsonschema:"Company name"`
+	URL     string `json:"url,omitempty" jsonschema:"Posting URL, fetched when jd is empty"`
+	JD      string `json:"jd,omitempty" jsonschema:"Job description text"`
+}
+
+// BatchJobScore is the score of one job description.
+type BatchJobScore struct {
+	ID             string   `json:"id,omitempty"`
+	Title          string   `json:"title,omitempty"`
+	Company        string   `json:"company,omitempty"`
+	URL            string   `json:"url,omitempty"`
+	Rank           int      `json:"rank,omitempty"`     // recommended order, 1 = apply first; 0 when not scored
+	Priority       string   `json:"priority,omitempty"` // high, medium or low
+	Score          float64  `json:"score"`              // 0-100 blend of the scores below
+	KeywordScore   float64  `json:"keyword_score"`      // 0-100 recency-weighted keyword overlap
+	SkillCoverage  float64  `json:"skill_coverage"`     // 0-100 share of the JD's skills on the resume
+	SemanticScore  *float64 `json:"semantic_score,omitempty"`
+	MatchingSkills []string `json:"matching_skills,omitempty"`
+	Gaps           []string `json:"gaps,omitempty"`         // JD skills missing from the resume
+	StaleSkills    []string `json:"stale_skills,omitempty"` // matching skills last used years ago
+	Deadline       string   `json:"deadline,omitempty"`     // YYYY-MM-DD application deadline found in the JD
+	Error          string   `json:"error,omitempty"`        // why the job could not be scored
+}
+
+// JobsScoreBatchResult is the output of jobs_score_batch.
+type JobsScoreBatchResult struct {
+	Method   string          `json:"method"` // "keywords" or "keywords+embedding"
+	Jobs     []BatchJobScore `json:"jobs"`
+	Scored   int             `json:"scored"`
+	Warnings []string        `json:"warnings,omitempty"`
+	Summary  string          `json:"summary"`
+}
+
+// masterResumeProfile returns the master resume as text for keyword scoring and its
+// skill recency weights (skill names and their match keywords).
+func masterResumeProfile(ctx context.Context) (string, map[string]float64, error) {
+	db := resumeStore(ctx)
+	if db == nil {
+		return "", nil, errors.New("resume database not configured")
+	}
+	personID := db.GetLatestPersonID(ctx)
+	if personID == 0 {
+		return "", nil, errors.New("no master resume found — run master_resume_build first")
+	}
+	var b strings.Builder
+	if person, err := db.GetPerson(ctx, personID); err == nil {
+		b.WriteString(person.Summary + "\n")
+	}
+	exps, _ := db.GetAllExperiences(ctx, personID)
+	for _, e := range exps {
+		fmt.Fprintf(&b, "%s\n%s\n%s\n", e.Title, e.Description, strings.Join(e.Highlights, "\n"))
+	}
+	projects, _ := db.GetAllProjects(ctx, personID)
+	for _, p := range projects {
+		fmt.Fprintf(&b, "%s\n%s\n", p.Name, p.Description)
+	}
+	skills, _ := db.GetAllSkills(ctx, personID)
+	now := time.Now()
+	weights := skillKeywordWeights(skills, now)
+	for _, s := range skills {
+		b.WriteString(s.Name + "\n")
+		weights[strings.ToLower(s.Name)] = max(weights[strings.ToLower(s.Name)], SkillRecencyWeight(s.LastUsed, now))
+	}
+	return b.String(), weights, nil
+}
+
+// ScoreJobsBatch scores input.Jobs against the master resume and ranks them.
+func ScoreJobsBatch(ctx context.Context, input JobsScoreBatchInput) (*JobsScoreBatchResult, error) {
+	if len(input.Jobs) == 0 {
+		return nil, errors.New("jobs_score_batch: jobs is required")
+	}
+	if len(input.Jobs) > scoreBatchMaxJobs {
+		return nil, fmt.Errorf("jobs_score_batch: at most %d jobs per call, got %d", scoreBatchMaxJobs, len(input.Jobs))
+	}
+	resume, weights, err := masterResumeProfile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("jobs_score_batch: %w", err)
+	}
+	resumeKW := ExtractResumeKeywords(resume)
+
+	jds := fetchBatchJDs(ctx, input.Jobs)
+	result := &JobsScoreBatchResult{Method: ScoreByKeywords, Jobs: make([]BatchJobScore, len(input.Jobs))}
+	now := time.Now()
+	var scored []int
+	for i, j := range input.Jobs {
+		s := BatchJobScore{ID: j.ID, Title: j.Title, Company: j.Company, URL: j.URL}
+		if jds[i].err != nil {
+			s.Error = jds[i].err.Error()
+			result.Jobs[i] = s
+			continue
+		}
+		jd := jds[i].text
+		if s.Title == "" {
+			s.Title = batchJobTitle(jd)
+		}
+		s.KeywordScore, _, _ = ScoreJobMatchWeighted(resumeKW, weights, jd)
+		for _, skill := range ExtractSkillsFromText(s.Title + "\n" + jd) {
+			if !hasSkill(skill, weights) {
+				s.Gaps = append(s.Gaps, skill)
+				continue
+			}
+			s.MatchingSkills = append(s.MatchingSkills, skill)
+			if w, ok := weights[strings.ToLower(skill)]; ok && w < 1 {
+				s.StaleSkills = append(s.StaleSkills, skill)
+			}
+		}
+		if n := len(s.MatchingSkills) + len(s.Gaps); n > 0 {
+			s.SkillCoverage = roundTenth(float64(len(s.MatchingSkills)) / float64(n) * 100)
+		}
+		if d, ok := ExtractDeadline(jd, now); ok {
+			s.Deadline = d.Date.Format(time.DateOnly)
+		}
+		result.Jobs[i] = s
+		scored = append(scored, i)
+	}
+
+	if embed := StoresFrom(ctx).Embed; embed != nil && len(scored) > 0 {
+		if err := batchSemanticScores(ctx, embed, resume, jds, scored, result.Jobs); err != nil {
+			slog.Warn("jobs_score_batch: embedding failed, using keywords", slog.Any("error", err))
+			result.Warnings = append(result.Warnings, "Embedding server unavailable; scored by keywords and skills only.")
+		} else {
+			result.Method = ScoreByEmbedding
+		}
+	}
+	for _, i := range scored {
+		s := &result.Jobs[i]
+		if s.SemanticScore != nil {
+			s.Score = roundTenth(0.5*s.SkillCoverage + 0.2*s.KeywordScore + 0.3**s.SemanticScore)
+		} else {
+			s.Score = roundTenth(0.7*s.SkillCoverage + 0.3*s.KeywordScore)
+		}
+		s.Priority = batchPriority(s.Score)
+	}
+
+	rankBatchScores(result.Jobs, now)
+	result.Scored = len(scored)
+	result.Summary = batchSummary(result)
+	return result, nil
+}
+
+type batchJD struct {
+	text string
+	err  error
+}
+
+// fetchBatchJDs returns each job's description, fetching the URL of jobs without one
+// on the shared outbound pool.
+func fetchBatchJDs(ctx context.Context, batch []BatchJob) []batchJD {
+	jds := make([]batchJD, len(batch))
+	var wg sync.WaitGroup
+	for i, j := range batch {
+		switch {
+		case strings.TrimSpace(j.JD) != "":
+			jds[i].text = j.JD
+		case j.URL == "":
+			jds[i].err = errors.New("jd or url is required")
+		default:
+			wg.Add(1)
+			engine.GoOutbound(ctx, func() {
+				defer wg.Done()
+				text, err := FetchJobPosting(ctx, j.URL)
+				if err != nil {
+					err = fmt.Errorf("fetch posting: %w", err)
+				}
+				jds[i] = batchJD{text: text, err: err}
+			})
+		}
+	}
+	wg.Wait()
+	return jds
+}
+
+// batchJobTitle takes the title from a fetched posting's **Title:** line, or the first line.
+func batchJobTitle(jd string) string {
+	for _, m := range postingFieldRe.FindAllStringSubmatch(jd, 2) {
+		if m[1] == "Title" {
+			return strings.TrimSpace(m[2])
+		}
+	}
+	return engine.TruncateRunes(firstLine(jd), 120, "...")
+}
+
+// batchSemanticScores sets the semantic score (0-100) of the scored jobs to the cosine
+// similarity of the resume and each JD.
+func batchSemanticScores(ctx context.Context, embed *EmbedClient, resume string, jds []batchJD, scored []int, out []BatchJobScore) error {
+	query, err := embed.EmbedQuery(ctx, engine.TruncateRunes(resume, similarEmbedRunes, ""))
+	if err != nil {
+		return err
+	}
+	texts := make([]string, len(scored))
+	for k, i := range scored {
+		texts[k] = engine.TruncateRunes(out[i].Title+"\n"+jds[i].text, similarEmbedRunes, "")
+	}
+	vecs, err := embed.EmbedPassages(ctx, texts)
+	if err != nil {
+		return err
+	}
+	if len(vecs) != len(texts) {
+		return fmt.Errorf("embed-server returned %d vectors for %d texts", len(vecs), len(texts))
+	}
+	for k, i := range scored {
+		sim := roundTenth(float64(max(CosineSimilarity(query, vecs[k]), 0)) * 100)
+		out[i].SemanticScore = &sim
+	}
+	return nil
+}
+
+func batchPriority(score float64) string {
+	switch {
+	case score >= 60:
+		return PriorityHigh
+	case score >= 35:
+		return PriorityMedium
+	}
+	return PriorityLow
+}
+
+// rankBatchScores orders jobs by priority tier, then jobs whose deadline is within
+// scoreBatchSoonDays, then score; unscored jobs go last. It sets Rank on scored jobs.
+func rankBatchScores(jobs []BatchJobScore, now time.Time) {
+	tier := map[string]int{PriorityHigh: 0, PriorityMedium: 1, PriorityLow: 2, "": 3}
+	soon := func(s BatchJobScore) int {
+		d, err := time.Parse(time.DateOnly, s.Deadline)
+		if err == nil && !d.Before(now.Truncate(24*time.Hour)) && d.Sub(now) <= scoreBatchSoonDays*24*time.Hour {
+			return 0
+		}
+		return 1
+	}
+	slices.SortStableFunc(jobs, func(a, b BatchJobScore) int {
+		if c := tier[a.Priority] - tier[b.Priority]; c != 0 {
+			return c
+		}
+		if c := soon(a) - soon(b); c != 0 {
+			return c
+		}
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	for i := range jobs {
+		if jobs[i].Error == "" {
+			jobs[i].Rank = i + 1
+		}
+	}
+}
+
+func batchSummary(r *JobsScoreBatchResult) string {
+	counts := map[string]int{}
+	for _, s := range r.Jobs {
+		counts[s.Priority]++
+	}
+	summary := fmt.Sprintf("Scored %d of %d job(s) against the master resume: %d high, %d medium, %d low priority.",
+		r.Scored, len(r.Jobs), counts[PriorityHigh], counts[PriorityMedium], counts[PriorityLow])
+	if r.Scored > 0 {
+		top := r.Jobs[0]
+		label := top.Title
+		if top.Company != "" {
+			label += " at " + top.Company
+		}
+		summary += fmt.Sprintf(" Apply first: %s (%.1f/100).", label, top.Score)
+	}
+	if failed := len(r.Jobs) - r.Scored; failed > 0 {
+		summary += fmt.Sprintf(" %d job(s) could not be scored; see error.", failed)
+	}
+	return summary
+}
+
+func roundTenth(f float64) float64 { return float64(int(f*10+0.5)) / 10 }
@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScoreJobsBatch(t *testing.T) {
+	ctx, rs, _ := withMemoryStores(t)
+	if _, err := ScoreJobsBatch(ctx, JobsScoreBatchInput{Jobs: []BatchJob{{JD: "Go developer"}}}); err == nil {
+		t.Fatal("expected an error without a master resume")
+	}
+
+	personID, _ := rs.InsertPerson(ctx, PersonRecord{Name: "Ada Example", Summary: "Backend engineer"})
+	_, _ = rs.InsertExperience(ctx, personID, ExperienceRecord{Title: "Backend Engineer", Description: "Built Go microservices on PostgreSQL and Kubernetes"})
+	for _, name := range []string{"Go", "PostgreSQL", "Kubernetes"} {
+		_, _ = rs.InsertSkillExtended(ctx, personID, SkillRecord{Name: name, LastUsed: "present"})
+	}
+
+	deadline := time.Now().AddDate(0, 0, 3).Format(time.DateOnly)
+	result, err := ScoreJobsBatch(ctx, JobsScoreBatchInput{Jobs: []BatchJob{
+		{ID: "frontend", Title: "Frontend Developer", JD: "React, TypeScript and GraphQL for our web app."},
+		{ID: "go", Title: "Senior Go Engineer", Company: "Acme", JD: "Go, PostgreSQL and Kubernetes microservices. Apply by " + deadline + "."},
+		{ID: "empty"},
+	}})
+	if err != nil {
+		t.Fatalf("ScoreJobsBatch: %v", err)
+	}
+	if result.Method != ScoreByKeywords || result.Scored != 2 || len(result.Jobs) != 3 {
+		t.Fatalf("result = %+v", result)
+	}
+	top := result.Jobs[0]
+	if top.ID != "go" || top.Rank != 1 || top.Priority != PriorityHigh || top.Deadline != deadline {
+		t.Errorf("top job = %+v", top)
+	}
+	if top.SkillCoverage != 100 || len(top.Gaps) != 0 {
+		t.Errorf("go job coverage %.1f, gaps %v", top.SkillCoverage, top.Gaps)
+	}
+	if fe := result.Jobs[1]; fe.ID != "frontend" || fe.Rank != 2 || len(fe.Gaps) == 0 || fe.Score >= top.Score {
+		t.Errorf("frontend job = %+v", fe)
+	}
+	if last := result.Jobs[2]; last.ID != "empty" || last.Rank != 0 || last.Error == "" {
+		t.Errorf("unscored job = %+v", last)
+	}
+	if !strings.Contains(result.Summary, "Apply first: Senior Go Engineer at Acme") {
+		t.Errorf("summary = %q", result.Summary)
+	}
+}
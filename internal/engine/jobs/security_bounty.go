@@ -105,7 +105,7 @@ func fetchAllSecurityPrograms(ctx context.Context) ([]engine.SecurityProgram, er
 }
 
 func fetchSecuritySource(ctx context.Context, url string) ([]byte, error) {
-	fetchCtx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, url, nil)
@@ -114,7 +114,7 @@ func fetchSecuritySource(ctx context.Context, url string) ([]byte, error) {
 	}
 	req.Header.Set("User-Agent", engine.UserAgentBot)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("security request failed: %w", err)
 	}
@@ -17,7 +17,7 @@ const securitySeenIDsKey = "security_seen_ids"
 func StartSecurityMonitor(ctx context.Context) {
 	interval := 30 * time.Minute
 
-	if engine.Cfg.VaelorNotifyURL == "" {
+	if engine.ConfigFrom(ctx).VaelorNotifyURL == "" {
 		slog.Info("security_monitor: disabled (VAELOR_NOTIFY_URL not set)")
 		return
 	}
@@ -0,0 +1,194 @@
+package jobs
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// evergreenAge is how long a posting must stay open to be considered evergreen.
+const evergreenAge = 60 * 24 * time.Hour
+
+// repostGap is the minimum gap between posted dates for a re-listing to count as a repost.
+const repostGap = 28 * 24 * time.Hour
+
+// SeenJob is a listing's sighting history in the persistent seen-jobs store.
+type SeenJob struct {
+	Key         string    `json:"key"`
+	Title       string    `json:"title"`
+	Company     string    `json:"company"`
+	URL         string    `json:"url"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+	FirstPosted time.Time `json:"first_posted,omitzero"`
+	Sightings   int       `json:"sightings"`
+	Reposts     int       `json:"reposts"` // re-listings of identical text at least repostGap apart
+}
+
+// initSeenJobsSchema creates the seen-jobs tables in the tracker database.
+func initSeenJobsSchema(db *sql.DB) error {
+	schema := `CREATE TABLE IF NOT EXISTS seen_jobs (
+		key          TEXT PRIMARY KEY,
+		title        TEXT NOT NULL,
+		company      TEXT NOT NULL,
+		url          TEXT,
+		first_seen   TEXT NOT NULL,
+		last_seen    TEXT NOT NULL,
+		sightings    INTEGER NOT NULL DEFAULT 1
+	);
+	CREATE TABLE IF NOT EXISTS seen_job_posts (
+		key       TEXT NOT NULL,
+		posted    TEXT NOT NULL,
+		text_hash TEXT NOT NULL,
+		PRIMARY KEY (key, posted)
+	);
+	CREATE TABLE IF NOT EXISTS seen_job_skills (
+		key         TEXT NOT NULL,
+		company_key TEXT NOT NULL,
+		skill       TEXT NOT NULL,
+		PRIMARY KEY (key, skill)
+	);
+	CREATE INDEX IF NOT EXISTS idx_seen_job_skills_company ON seen_job_skills(company_key)`
+	_, err := db.Exec(schema) //nolint:noctx // schema init, no user context available
+	return err
+}
+
+// seenJobKey identifies a posting across sources and reposts: company + normalized title + location.
+func seenJobKey(j engine.JobListing) string {
+	return strings.ToLower(strings.TrimSpace(j.Company)) + "|" + engine.CanonicalJobKey(j.Title, j.Location)
+}
+
+// descriptionHash fingerprints the normalized description text.
+func descriptionHash(desc string) string {
+	norm := strings.Join(strings.Fields(strings.ToLower(desc)), " ")
+	if norm == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(norm))
+	return hex.EncodeToString(sum[:8])
+}
+
+// RecordSeenJob upserts a sighting of j and returns its accumulated history.
+func RecordSeenJob(ctx context.Context, j engine.JobListing, now time.Time) (*SeenJob, error) {
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	key := seenJobKey(j)
+	ts := now.UTC().Format(time.RFC3339)
+
+	_, err = db.ExecContext(ctx, `INSERT INTO seen_jobs (key, title, company, url, first_seen, last_seen, sightings)
+		VALUES (?, ?, ?, ?, ?, ?, 1)
+		ON CONFLICT(key) DO UPDATE SET last_seen = excluded.last_seen, url = excluded.url, sightings = sightings + 1`,
+		key, j.Title, j.Company, engine.CanonicalJobURL(j.URL), ts, ts)
+	if err != nil {
+		return nil, fmt.Errorf("seen_jobs: upsert: %w", err)
+	}
+	if posted, ok := ParsePostedDate(j.Posted, now); ok {
+		_, err = db.ExecContext(ctx, `INSERT OR IGNORE INTO seen_job_posts (key, posted, text_hash) VALUES (?, ?, ?)`,
+			key, posted.Format(time.DateOnly), descriptionHash(j.Description))
+		if err != nil {
+			return nil, fmt.Errorf("seen_jobs: record posted date: %w", err)
+		}
+	}
+	if companyKey := CompanyKey(j.Company); companyKey != "" {
+		for _, skill := range MergeSkills(j.Skills, ExtractSkillsFromText(j.Description)) {
+			_, err = db.ExecContext(ctx, `INSERT OR IGNORE INTO seen_job_skills (key, company_key, skill) VALUES (?, ?, ?)`,
+				key, companyKey, skill)
+			if err != nil {
+				return nil, fmt.Errorf("seen_jobs: record skills: %w", err)
+			}
+		}
+	}
+	err = indexLocalDoc(ctx, db, localDoc{
+		Source: LocalSourceSeen, Ref: key, Title: j.Title, Company: j.Company,
+		URL: engine.CanonicalJobURL(j.URL), Body: j.Description, UpdatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return getSeenJob(ctx, db, key)
+}
+
+func getSeenJob(ctx context.Context, db *sql.DB, key string) (*SeenJob, error) {
+	var s SeenJob
+	var firstSeen, lastSeen string
+	err := db.QueryRowContext(ctx, `SELECT key, title, company, COALESCE(url,''), first_seen, last_seen, sightings
+		FROM seen_jobs WHERE key = ?`, key).Scan(&s.Key, &s.Title, &s.Company, &s.URL, &firstSeen, &lastSeen, &s.Sightings)
+	if err != nil {
+		return nil, fmt.Errorf("seen_jobs: get: %w", err)
+	}
+	s.FirstSeen, _ = time.Parse(time.RFC3339, firstSeen)
+	s.LastSeen, _ = time.Parse(time.RFC3339, lastSeen)
+
+	rows, err := db.QueryContext(ctx, `SELECT posted, text_hash FROM seen_job_posts WHERE key = ? ORDER BY posted`, key)
+	if err != nil {
+		return nil, fmt.Errorf("seen_jobs: posted history: %w", err)
+	}
+	defer rows.Close()
+
+	lastByHash := make(map[string]time.Time)
+	for rows.Next() {
+		var postedStr, hash string
+		if err := rows.Scan(&postedStr, &hash); err != nil {
+			return nil, fmt.Errorf("seen_jobs: scan: %w", err)
+		}
+		posted, err := time.Parse(time.DateOnly, postedStr)
+		if err != nil {
+			continue
+		}
+		if s.FirstPosted.IsZero() {
+			s.FirstPosted = posted
+		}
+		// A repost is the same text re-listed with a fresh date at least ~a month later.
+		if hash == "" {
+			continue
+		}
+		if prev, ok := lastByHash[hash]; !ok {
+			lastByHash[hash] = posted
+		} else if posted.Sub(prev) >= repostGap {
+			s.Reposts++
+			lastByHash[hash] = posted
+		}
+	}
+	return &s, rows.Err()
+}
+
+// OpenedAt returns the earliest of first sighting and first posted date.
+func (s *SeenJob) OpenedAt() time.Time {
+	if !s.FirstPosted.IsZero() && s.FirstPosted.Before(s.FirstSeen) {
+		return s.FirstPosted
+	}
+	return s.FirstSeen
+}
+
+// IsEvergreen reports whether a posting has been open 60+ days or is reposted monthly with identical text.
+func (s *SeenJob) IsEvergreen(now time.Time) bool {
+	return now.Sub(s.OpenedAt()) >= evergreenAge || s.Reposts >= 2
+}
+
+// TagEvergreenJobs records each listing in the seen-jobs store and sets Evergreen/DaysOpen.
+// Store errors are logged and leave listings untagged.
+func TagEvergreenJobs(ctx context.Context, listings []engine.JobListing) {
+	now := time.Now()
+	for i := range listings {
+		j := &listings[i]
+		if j.Title == "" {
+			continue
+		}
+		seen, err := RecordSeenJob(ctx, *j, now)
+		if err != nil {
+			slog.Warn("seen_jobs: record failed", slog.Any("error", err))
+			return
+		}
+		j.DaysOpen = int(now.Sub(seen.OpenedAt()).Hours() / 24)
+		j.Evergreen = seen.IsEvergreen(now)
+	}
+}
@@ -0,0 +1,118 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestParsePostedDate(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"2025-05-01", "2025-05-01", true},
+		{"3 days ago", "2025-06-12", true},
+		{"30+ days ago", "2025-05-16", true},
+		{"2 weeks ago", "2025-06-01", true},
+		{"Yesterday", "2025-06-14", true},
+		{"an hour ago", "2025-06-15", true},
+		{"Posted 2 days ago", "2025-06-13", true},
+		{"Fri, 13 Jun 2025 08:00:00 +0000", "2025-06-13", true},
+		{"2025-06-10T08:15:00", "2025-06-10", true},
+		{"3 дня назад", "2025-06-12", true},
+		{"вчера", "2025-06-14", true},
+		{"01.06.2025", "2025-06-01", true},
+		{"not specified", "", false},
+		{"soon", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParsePostedDate(tt.in, now)
+		if ok != tt.ok {
+			t.Errorf("ParsePostedDate(%q) ok = %v, want %v", tt.in, ok, tt.ok)
+			continue
+		}
+		if ok && got.Format(time.DateOnly) != tt.want {
+			t.Errorf("ParsePostedDate(%q) = %s, want %s", tt.in, got.Format(time.DateOnly), tt.want)
+		}
+	}
+}
+
+func TestRecordSeenJob_Evergreen(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	job := engine.JobListing{Title: "Senior Go Engineer", Company: "Acme", Location: "Remote", Description: "Build APIs in Go.", Posted: "today"}
+
+	// Reposted monthly with identical text.
+	var seen *SeenJob
+	for i := range 3 {
+		var err error
+		seen, err = RecordSeenJob(ctx, job, start.AddDate(0, i, 0))
+		if err != nil {
+			t.Fatalf("RecordSeenJob: %v", err)
+		}
+	}
+	if seen.Sightings != 3 || seen.Reposts != 2 {
+		t.Errorf("sightings=%d reposts=%d, want 3/2", seen.Sightings, seen.Reposts)
+	}
+	if !seen.IsEvergreen(start.AddDate(0, 2, 0)) {
+		t.Error("monthly repost not flagged evergreen")
+	}
+
+	// Fresh posting, first seen today.
+	fresh, err := RecordSeenJob(ctx, engine.JobListing{Title: "SRE", Company: "Globex", Posted: "2 days ago"}, start)
+	if err != nil {
+		t.Fatalf("RecordSeenJob: %v", err)
+	}
+	if fresh.IsEvergreen(start) {
+		t.Error("fresh posting flagged evergreen")
+	}
+	if !fresh.IsEvergreen(start.Add(evergreenAge)) {
+		t.Error("posting open 60+ days not flagged evergreen")
+	}
+}
+
+func TestCompanyPostingStack(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, j := range []engine.JobListing{
+		{Title: "Backend Engineer", Company: "Acme", Location: "Berlin", Skills: []string{"Go", "PostgreSQL"}},
+		{Title: "Platform Engineer", Company: "Acme Inc.", Location: "Remote", Description: "We run Go services on Kubernetes"},
+		{Title: "Frontend Engineer", Company: "Acme", Location: "Berlin", Skills: []string{"React"}},
+		{Title: "Data Engineer", Company: "Globex", Location: "Remote", Skills: []string{"Python", "Spark"}},
+	} {
+		if _, err := RecordSeenJob(ctx, j, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stack, n, err := CompanyPostingStack(ctx, "ACME")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || len(stack) == 0 || stack[0].Skill != "Go" || stack[0].Postings != 2 || stack[0].Share != 0.67 {
+		t.Fatalf("stack = %+v (postings %d)", stack, n)
+	}
+
+	weights := map[string]float64{"go": 1, "postgresql": 1, "kubernetes": 0.5}
+	listings := []engine.JobListing{
+		{Title: "Data", Company: "Globex", Scores: &engine.ListingScores{}},
+		{Title: "Unknown", Company: "Initech", Scores: &engine.ListingScores{}},
+		{Title: "Backend", Company: "Acme", Scores: &engine.ListingScores{}},
+	}
+	RankByCompanyStack(ctx, listings, weights)
+	if listings[0].Title != "Backend" || listings[1].Title != "Data" || listings[2].Title != "Unknown" {
+		t.Errorf("order = %s, %s, %s", listings[0].Title, listings[1].Title, listings[2].Title)
+	}
+	if f := listings[0].Scores.StackFit; f < stackFitPromote || f > 1 {
+		t.Errorf("stack_fit = %v", f)
+	}
+}
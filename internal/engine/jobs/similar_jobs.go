@@ -0,0 +1,549 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Similar jobs ---
+
+// similar_jobs starts from one posting the user liked, identified by URL or tracker ID.
+// Candidates come from the seen-jobs store (listings that share a skill or a title
+// synonym with it) and from fresh LinkedIn, Greenhouse and Lever searches built from its
+// title synonyms and main skill. They are ranked by embedding similarity of the job
+// descriptions when an embed server is configured, otherwise by skill and title overlap.
+
+const (
+	similarDefaultLimit  = 15
+	similarMaxLimit      = 50
+	similarMaxCandidates = 150 // seen-store candidates scored per call
+	similarSearchLimit   = 10  // results per source and query
+	similarMaxQueries    = 3
+	similarEmbedRunes    = 2000 // description prefix embedded per posting
+)
+
+// Similarity methods.
+const (
+	SimilarByEmbedding = "embedding"
+	SimilarByOverlap   = "overlap"
+)
+
+// SimilarJobsInput is the input for similar_jobs.
+type SimilarJobsInput struct {
+	URL       string `json:"url,omitempty" jsonschema:"URL of the liked job posting"`
+	TrackerID int64  `json:"tracker_id,omitempty" jsonschema:"ID of a tracked job to start from (instead of url)"`
+	Location  string `json:"location,omitempty" jsonschema:"Location for the fresh searches, e.g. Remote or Berlin"`
+	LocalOnly bool   `json:"local_only,omitempty" jsonschema:"Only rank listings already in the seen-jobs store, without new searches"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"Maximum results (default 15, max 50)"`
+}
+
+// SimilarJobsReference is the posting the search started from.
+type SimilarJobsReference struct {
+	Title   string   `json:"title"`
+	Company string   `json:"company,omitempty"`
+	URL     string   `json:"url,omitempty"`
+	Skills  []string `json:"skills"`
+}
+
+// SimilarJob is one ranked posting.
+type SimilarJob struct {
+	Title        string   `json:"title"`
+	Company      string   `json:"company,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	Source       string   `json:"source"`     // "seen", "linkedin", "greenhouse" or "lever"
+	Similarity   float64  `json:"similarity"` // 0-1
+	SharedSkills []string `json:"shared_skills,omitempty"`
+}
+
+// SimilarJobsResult is the output of similar_jobs.
+type SimilarJobsResult struct {
+	Reference SimilarJobsReference `json:"reference"`
+	Method    string               `json:"method"` // "embedding" or "overlap"
+	Queries   []string             `json:"queries,omitempty"`
+	Jobs      []SimilarJob         `json:"jobs"`
+	Total     int                  `json:"total"`
+	Warnings  []string             `json:"warnings,omitempty"`
+}
+
+// similarCandidate is a posting to score against the reference.
+type similarCandidate struct {
+	job    SimilarJob
+	text   string // description, possibly empty
+	skills []string
+}
+
+// titleSynonyms are role families: a title matching one name is also searched under the
+// others.
+var titleSynonyms = [][]string{
+	{"backend engineer", "backend developer", "server side engineer"},
+	{"frontend engineer", "frontend developer", "ui engineer"},
+	{"full stack engineer", "full stack developer", "fullstack engineer"},
+	{"software engineer", "software developer"},
+	{"devops engineer", "site reliability engineer", "platform engineer", "infrastructure engineer"},
+	{"data engineer", "analytics engineer", "etl developer"},
+	{"machine learning engineer", "ml engineer", "ai engineer", "data scientist"},
+	{"mobile engineer", "mobile developer", "ios engineer", "android engineer"},
+	{"qa engineer", "test engineer", "sdet", "quality engineer"},
+	{"security engineer", "application security engineer", "appsec engineer"},
+	{"engineering manager", "tech lead", "team lead"},
+	{"product manager", "product owner"},
+}
+
+// titleNoise are seniority and filler words dropped from titles before matching.
+var titleNoise = map[string]bool{
+	"senior": true, "sr": true, "junior": true, "jr": true, "mid": true, "middle": true, "level": true,
+	"staff": true, "principal": true, "lead": true, "head": true, "i": true, "ii": true, "iii": true,
+	"remote": true, "hybrid": true, "contract": true, "m": true, "f": true, "d": true, "w": true,
+}
+
+var titleWordRe = regexp.MustCompile(`[\p{L}\p{N}+#]+`)
+
+// coreTitle lowercases title, joins "back end"/"back-end" spellings and drops seniority
+// and filler words.
+func coreTitle(title string) string {
+	t := strings.ToLower(title)
+	if i := strings.Index(t, " at "); i > 0 {
+		t = t[:i]
+	}
+	for _, r := range [][2]string{{"back-end", "backend"}, {"back end", "backend"}, {"front-end", "frontend"},
+		{"front end", "frontend"}, {"full-stack", "full stack"}, {"server-side", "server side"}} {
+		t = strings.ReplaceAll(t, r[0], r[1])
+	}
+	var words []string
+	for _, w := range titleWordRe.FindAllString(t, -1) {
+		if !titleNoise[w] {
+			words = append(words, w)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// titleVariants returns the core title followed by the other names of its role family.
+func titleVariants(title string) []string {
+	core := coreTitle(title)
+	if core == "" {
+		return nil
+	}
+	variants := []string{core}
+	for _, family := range titleSynonyms {
+		if !slices.ContainsFunc(family, func(name string) bool { return strings.Contains(core, name) }) {
+			continue
+		}
+		for _, name := range family {
+			if !strings.Contains(core, name) && !slices.Contains(variants, name) {
+				variants = append(variants, name)
+			}
+		}
+	}
+	return variants
+}
+
+// mainSkill returns the one of skills mentioned most often in text.
+func mainSkill(text string, skills []string) string {
+	best, bestN := "", 0
+	for _, sp := range skillPatterns {
+		if !slices.Contains(skills, sp.name) {
+			continue
+		}
+		if n := len(sp.pattern.FindAllStringIndex(text, -1)); n > bestN {
+			best, bestN = sp.name, n
+		}
+	}
+	return best
+}
+
+// similarQueries builds the fresh search queries: each title variant with the main skill.
+func similarQueries(ref SimilarJobsReference, jd string) []string {
+	skill := mainSkill(jd, ref.Skills)
+	var queries []string
+	for _, v := range titleVariants(ref.Title) {
+		q := v
+		if skill != "" && !strings.Contains(v, strings.ToLower(skill)) {
+			q += " " + skill
+		}
+		queries = append(queries, q)
+		if len(queries) == similarMaxQueries {
+			break
+		}
+	}
+	return queries
+}
+
+// FindSimilarJobs ranks postings similar to the one given by input.URL or input.TrackerID.
+func FindSimilarJobs(ctx context.Context, input SimilarJobsInput) (*SimilarJobsResult, error) {
+	if input.URL == "" && input.TrackerID <= 0 {
+		return nil, errors.New("similar_jobs: url or tracker_id is required")
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = similarDefaultLimit
+	}
+	limit = min(limit, similarMaxLimit)
+
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	ref, jd, err := similarReference(ctx, db, input)
+	if err != nil {
+		return nil, err
+	}
+	result := &SimilarJobsResult{Reference: ref, Jobs: []SimilarJob{}}
+
+	candidates, err := seenCandidates(ctx, db, ref)
+	if err != nil {
+		return nil, err
+	}
+	if !input.LocalOnly {
+		result.Queries = similarQueries(ref, jd)
+		candidates = append(candidates, searchCandidates(ctx, result.Queries, input.Location)...)
+	}
+	candidates = dedupCandidates(candidates, ref)
+
+	result.Method = SimilarByOverlap
+	if embed := StoresFrom(ctx).Embed; embed != nil && len(candidates) > 0 {
+		if err := embedScores(ctx, embed, jd, candidates); err != nil {
+			slog.Warn("similar_jobs: embedding failed, using overlap", slog.Any("error", err))
+			result.Warnings = append(result.Warnings, "Embedding server unavailable; ranked by skill and title overlap.")
+		} else {
+			result.Method = SimilarByEmbedding
+		}
+	}
+	if result.Method == SimilarByOverlap {
+		for i := range candidates {
+			candidates[i].job.Similarity = overlapScore(ref, candidates[i])
+		}
+	}
+	for i := range candidates {
+		candidates[i].job.SharedSkills = sharedSkills(ref.Skills, candidates[i].skills)
+	}
+	slices.SortStableFunc(candidates, func(a, b similarCandidate) int {
+		switch {
+		case a.job.Similarity > b.job.Similarity:
+			return -1
+		case a.job.Similarity < b.job.Similarity:
+			return 1
+		}
+		return 0
+	})
+	for _, c := range candidates {
+		if len(result.Jobs) == limit {
+			break
+		}
+		if c.job.Similarity > 0 {
+			result.Jobs = append(result.Jobs, c.job)
+		}
+	}
+	result.Total = len(result.Jobs)
+	return result, nil
+}
+
+// similarReference resolves the liked posting and its description. A tracked job uses
+// its archived snapshot when there is one; a posting that cannot be fetched falls back
+// to the tracked title and notes.
+func similarReference(ctx context.Context, db *sql.DB, input SimilarJobsInput) (SimilarJobsReference, string, error) {
+	var ref SimilarJobsReference
+	var jd string
+	if input.TrackerID > 0 {
+		job, err := getTrackedJob(db, "similar_jobs", input.TrackerID)
+		if err != nil {
+			return ref, "", err
+		}
+		ref = SimilarJobsReference{Title: job.Title, Company: job.Company, URL: job.URL}
+		if snap, _ := loadJobSnapshot(ctx, db, input.TrackerID); snap != nil {
+			jd = snap.Markdown
+		}
+		if jd == "" && job.URL != "" {
+			if text, err := FetchJobPosting(ctx, job.URL); err == nil {
+				jd = text
+			}
+		}
+		if jd == "" {
+			jd = job.Title + "\n" + job.Notes
+		}
+	} else {
+		ref.URL = engine.CanonicalJobURL(input.URL)
+		text, err := FetchJobPosting(ctx, ref.URL)
+		if err != nil {
+			return ref, "", fmt.Errorf("similar_jobs: fetch posting: %w", err)
+		}
+		jd = text
+	}
+	if ref.Title == "" {
+		for _, m := range postingFieldRe.FindAllStringSubmatch(jd, 2) {
+			if m[1] == "Title" {
+				ref.Title = strings.TrimSpace(m[2])
+			} else if ref.Company == "" {
+				ref.Company = strings.TrimSpace(m[2])
+			}
+		}
+	}
+	if ref.Title == "" {
+		_ = db.QueryRowContext(ctx, `SELECT title, company FROM seen_jobs WHERE url = ? LIMIT 1`, ref.URL).
+			Scan(&ref.Title, &ref.Company)
+	}
+	if ref.Title == "" {
+		ref.Title = firstLine(jd)
+	}
+	ref.Skills = ExtractSkillsFromText(jd)
+	if ref.Skills == nil {
+		ref.Skills = []string{}
+	}
+	return ref, jd, nil
+}
+
+// firstLine returns the first non-empty line of text without markdown markers.
+func firstLine(text string) string {
+	for line := range strings.SplitSeq(text, "\n") {
+		if line = strings.Trim(line, " #*\t\r"); line != "" {
+			return engine.TruncateRunes(line, 120, "")
+		}
+	}
+	return ""
+}
+
+// seenCandidates loads seen-jobs listings sharing a skill or a title synonym with ref,
+// the ones sharing the most skills first.
+func seenCandidates(ctx context.Context, db *sql.DB, ref SimilarJobsReference) ([]similarCandidate, error) {
+	var conds []string
+	var args []any
+	if len(ref.Skills) > 0 {
+		conds = append(conds, `s.key IN (SELECT key FROM seen_job_skills WHERE skill IN (?`+strings.Repeat(",?", len(ref.Skills)-1)+`))`)
+		for _, skill := range ref.Skills {
+			args = append(args, skill)
+		}
+	}
+	for _, v := range titleVariants(ref.Title) {
+		conds = append(conds, `lower(s.title) LIKE ?`)
+		args = append(args, "%"+v+"%")
+	}
+	if len(conds) == 0 {
+		return nil, nil
+	}
+	q := `SELECT s.key, s.title, s.company, COALESCE(s.url, ''),
+		(SELECT COUNT(*) FROM seen_job_skills k WHERE k.key = s.key) AS n
+		FROM seen_jobs s WHERE ` + strings.Join(conds, " OR ") + ` ORDER BY n DESC, s.last_seen DESC LIMIT ?`
+	args = append(args, similarMaxCandidates)
+	rows, err := db.QueryContext(ctx, q, args...) //nolint:gosec // placeholders only
+	if err != nil {
+		return nil, fmt.Errorf("similar_jobs: %w", err)
+	}
+	var out []similarCandidate
+	var keys []string
+	for rows.Next() {
+		var c similarCandidate
+		var key string
+		var n int
+		if err := rows.Scan(&key, &c.job.Title, &c.job.Company, &c.job.URL, &n); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("similar_jobs: %w", err)
+		}
+		c.job.Source = LocalSourceSeen
+		out = append(out, c)
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("similar_jobs: %w", err)
+	}
+	for i, key := range keys {
+		_ = db.QueryRowContext(ctx, `SELECT body FROM local_search WHERE source = ? AND ref = ?`, LocalSourceSeen, key).
+			Scan(&out[i].text)
+		skills, err := db.QueryContext(ctx, `SELECT skill FROM seen_job_skills WHERE key = ? ORDER BY skill`, key)
+		if err != nil {
+			return nil, fmt.Errorf("similar_jobs: %w", err)
+		}
+		for skills.Next() {
+			var s string
+			if skills.Scan(&s) == nil {
+				out[i].skills = append(out[i].skills, s)
+			}
+		}
+		skills.Close()
+	}
+	return out, nil
+}
+
+var companyFieldRe = regexp.MustCompile(`\*\*Company:\*\*\s*([^|\n]+)`)
+
+// searchCandidates runs the fresh LinkedIn, Greenhouse and Lever searches for queries.
+// Failed sources are logged and skipped.
+func searchCandidates(ctx context.Context, queries []string, location string) []similarCandidate {
+	var (
+		mu  sync.Mutex
+		out []similarCandidate
+		wg  sync.WaitGroup
+	)
+	add := func(cs []similarCandidate) {
+		mu.Lock()
+		out = append(out, cs...)
+		mu.Unlock()
+	}
+	fromResults := func(source string, results []engine.SearxngResult) []similarCandidate {
+		cs := make([]similarCandidate, 0, len(results))
+		for _, r := range results {
+			c := similarCandidate{job: SimilarJob{Title: r.Title, URL: r.URL, Source: source}, text: r.Content}
+			if m := companyFieldRe.FindStringSubmatch(r.Content); m != nil {
+				c.job.Company = strings.TrimSpace(m[1])
+			}
+			c.skills = ExtractSkillsFromText(r.Content)
+			cs = append(cs, c)
+		}
+		return cs
+	}
+	for _, q := range queries {
+		for _, source := range []string{"linkedin", "greenhouse", "lever"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				release, err := engine.AcquireSourceSlot(ctx)
+				if err != nil {
+					return
+				}
+				defer release()
+				var cs []similarCandidate
+				switch source {
+				case "linkedin":
+					found, ferr := SearchLinkedInJobs(ctx, q, location, "", "", "", "", "", "", "", similarSearchLimit, false)
+					err = ferr
+					for _, j := range found {
+						cs = append(cs, similarCandidate{job: SimilarJob{Title: j.Title, Company: j.Company, URL: j.URL, Source: source}})
+					}
+				case "greenhouse":
+					var found []engine.SearxngResult
+					found, err = SearchGreenhouseJobs(ctx, q, location, similarSearchLimit)
+					cs = fromResults(source, found)
+				case "lever":
+					var found []engine.SearxngResult
+					found, err = SearchLeverJobs(ctx, q, location, similarSearchLimit)
+					cs = fromResults(source, found)
+				}
+				if err != nil {
+					slog.Debug("similar_jobs: search failed", slog.String("source", source), slog.String("query", q), slog.Any("error", err))
+				}
+				add(cs)
+			}()
+		}
+	}
+	wg.Wait()
+	return out
+}
+
+// dedupCandidates drops the reference posting and repeats of a URL or of a company and
+// title, keeping the first (seen-store entries come before search results).
+func dedupCandidates(cs []similarCandidate, ref SimilarJobsReference) []similarCandidate {
+	seen := map[string]bool{}
+	if ref.URL != "" {
+		seen[engine.CanonicalJobURL(ref.URL)] = true
+	}
+	seen[strings.ToLower(ref.Company)+"|"+coreTitle(ref.Title)] = true
+	out := cs[:0]
+	for _, c := range cs {
+		if c.job.Title == "" {
+			continue
+		}
+		keys := []string{strings.ToLower(c.job.Company) + "|" + coreTitle(c.job.Title)}
+		if c.job.URL != "" {
+			keys = append(keys, engine.CanonicalJobURL(c.job.URL))
+		}
+		if slices.ContainsFunc(keys, func(k string) bool { return seen[k] }) {
+			continue
+		}
+		for _, k := range keys {
+			seen[k] = true
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// embedScores sets each candidate's similarity to the cosine similarity of its title and
+// description with the reference description.
+func embedScores(ctx context.Context, embed *EmbedClient, jd string, cs []similarCandidate) error {
+	query, err := embed.EmbedQuery(ctx, engine.TruncateRunes(jd, similarEmbedRunes, ""))
+	if err != nil {
+		return err
+	}
+	texts := make([]string, len(cs))
+	for i, c := range cs {
+		texts[i] = engine.TruncateRunes(c.job.Title+"\n"+c.text, similarEmbedRunes, "")
+	}
+	vecs, err := embed.EmbedPassages(ctx, texts)
+	if err != nil {
+		return err
+	}
+	if len(vecs) != len(cs) {
+		return fmt.Errorf("embed-server returned %d vectors for %d texts", len(vecs), len(cs))
+	}
+	for i := range cs {
+		cs[i].job.Similarity = roundScore(float64(max(CosineSimilarity(query, vecs[i]), 0)))
+	}
+	return nil
+}
+
+// overlapScore blends the skill Jaccard overlap (60%) and title word overlap (40%).
+// Candidates without a description are scored on the title alone, at half weight.
+func overlapScore(ref SimilarJobsReference, c similarCandidate) float64 {
+	title := jaccard(strings.Fields(coreTitle(ref.Title)), strings.Fields(coreTitle(c.job.Title)))
+	if c.text == "" && len(c.skills) == 0 {
+		return roundScore(title * 0.5)
+	}
+	skills := jaccard(lowerAll(ref.Skills), lowerAll(c.skills))
+	return roundScore(0.6*skills + 0.4*title)
+}
+
+// jaccard returns |a ∩ b| / |a ∪ b| of two word sets.
+func jaccard(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, w := range a {
+		set[w] = true
+	}
+	union := len(set)
+	inter := 0
+	counted := map[string]bool{}
+	for _, w := range b {
+		if counted[w] {
+			continue
+		}
+		counted[w] = true
+		if set[w] {
+			inter++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(inter) / float64(union)
+}
+
+func lowerAll(ss []string) []string {
+	out := make([]string, len(ss))
+	for i, s := range ss {
+		out[i] = strings.ToLower(s)
+	}
+	return out
+}
+
+// sharedSkills returns the skills of ref also in skills, in ref order.
+func sharedSkills(ref, skills []string) []string {
+	var out []string
+	for _, s := range ref {
+		if slices.ContainsFunc(skills, func(o string) bool { return strings.EqualFold(o, s) }) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func roundScore(f float64) float64 { return float64(int(f*1000+0.5)) / 1000 }
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestTitleVariants(t *testing.T) {
+	got := titleVariants("Senior Back-end Engineer (Remote)")
+	want := []string{"backend engineer", "backend developer", "server side engineer"}
+	if !slices.Equal(got, want) {
+		t.Errorf("titleVariants = %q, want %q", got, want)
+	}
+	if got := titleVariants("Rust Wizard"); !slices.Equal(got, []string{"rust wizard"}) {
+		t.Errorf("title without a family = %q", got)
+	}
+
+	ref := SimilarJobsReference{Title: "Backend Engineer", Skills: []string{"Go", "PostgreSQL"}}
+	queries := similarQueries(ref, "We write Go. Go services on PostgreSQL, more Go.")
+	if len(queries) != 3 || queries[0] != "backend engineer Go" || queries[1] != "backend developer Go" {
+		t.Errorf("queries = %q", queries)
+	}
+}
+
+func TestFindSimilarJobsLocal(t *testing.T) {
+	resetTracker(t)
+	ctx, _, _ := withMemoryStores(t)
+	now := time.Now()
+	for _, j := range []engine.JobListing{
+		{Title: "Backend Developer", Company: "Acme", URL: "https://acme.example/jobs/1", Description: "Go services with Redis and PostgreSQL."},
+		{Title: "Go Engineer", Company: "Beta", URL: "https://beta.example/jobs/2", Description: "Go and PostgreSQL."},
+		{Title: "Frontend Engineer", Company: "Gamma", URL: "https://gamma.example/jobs/3", Description: "React and TypeScript."},
+		{Title: "Senior Backend Engineer", Company: "Stripe", URL: "https://stripe.example/jobs/9", Description: "Go, Redis, PostgreSQL."},
+	} {
+		if _, err := RecordSeenJob(ctx, j, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	added, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "Senior Backend Engineer", Company: "Stripe", Notes: "Go, Redis, PostgreSQL"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := FindSimilarJobs(ctx, SimilarJobsInput{TrackerID: added.ID, LocalOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Method != SimilarByOverlap || !slices.Equal(res.Reference.Skills, []string{"Go", "PostgreSQL", "Redis"}) {
+		t.Errorf("method %q, reference %+v", res.Method, res.Reference)
+	}
+	if len(res.Jobs) != 2 || res.Jobs[0].Company != "Acme" || res.Jobs[1].Company != "Beta" {
+		t.Fatalf("jobs = %+v", res.Jobs)
+	}
+	if res.Jobs[0].Similarity <= res.Jobs[1].Similarity || len(res.Jobs[0].SharedSkills) != 3 {
+		t.Errorf("ranking: %+v", res.Jobs)
+	}
+	if res.Queries != nil {
+		t.Errorf("local_only ran searches: %q", res.Queries)
+	}
+
+	if _, err := FindSimilarJobs(ctx, SimilarJobsInput{}); err == nil {
+		t.Error("expected an error without url or tracker_id")
+	}
+}
@@ -0,0 +1,192 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Skill recency: each skill's last_used is the latest end date of the experiences
+// (directly or through their sub-projects) it was used in. Stale skills weigh less in
+// job_match_score and are flagged to the LLM in resume_generate.
+
+const (
+	// lastUsedPresent marks a skill used in a current role.
+	lastUsedPresent = "present"
+	// skillFreshYears is how long a skill keeps full weight after it was last used.
+	skillFreshYears = 2
+	// skillMinWeight is the floor of the recency weight; old skills still count for something.
+	skillMinWeight = 0.25
+)
+
+// experienceEnd parses an experience end date to the last day it names. An empty end
+// date or "Present"/"Current" means the role is ongoing; ok is false for dates that
+// cannot be read.
+func experienceEnd(s string) (end time.Time, present, ok bool) {
+	if strings.TrimSpace(s) == "" {
+		return time.Time{}, true, true
+	}
+	d, ok := ParseResumeDate(s)
+	if !ok {
+		return time.Time{}, false, false
+	}
+	if d.IsPresent() {
+		return time.Time{}, true, true
+	}
+	return d.Last(time.Time{}), false, true
+}
+
+// skillLastUsed derives last_used (YYYY-MM or "present") per skill ID from the build
+// plan's USED_SKILL and PART_OF edges and the experience dates. Implied skills inherit
+// the recency of the skill that implies them.
+func skillLastUsed(plan *buildPlan, exps []ExperienceRecord) map[int]string {
+	type usage struct {
+		end     time.Time
+		present bool
+	}
+	expEnd := make(map[int]usage, len(exps))
+	for _, e := range exps {
+		if end, present, ok := experienceEnd(e.EndDate); ok {
+			expEnd[e.ID] = usage{end: end, present: present}
+		}
+	}
+	projParent := make(map[int]int)
+	for _, op := range plan.Graph {
+		if op.Edge == "PART_OF" && op.Label == "Proj" && op.ToLabel == "Exp" {
+			projParent[op.ID] = op.ToID
+		}
+	}
+
+	latest := make(map[int]usage)
+	use := func(skillID int, u usage) {
+		cur, seen := latest[skillID]
+		if !seen || (!cur.present && (u.present || u.end.After(cur.end))) {
+			latest[skillID] = u
+		}
+	}
+	for _, op := range plan.Graph {
+		if op.Edge != "USED_SKILL" {
+			continue
+		}
+		var expID int
+		switch op.Label {
+		case "Exp":
+			expID = op.ID
+		case "Proj":
+			expID = projParent[op.ID]
+		default:
+			continue // open-source work has no experience dates
+		}
+		if u, ok := expEnd[expID]; ok {
+			use(op.ToID, u)
+		}
+	}
+	for _, op := range plan.Graph {
+		if op.Edge == "IMPLIES_SKILL" {
+			if u, ok := latest[op.ID]; ok {
+				use(op.ToID, u)
+			}
+		}
+	}
+
+	out := make(map[int]string, len(latest))
+	for id, u := range latest {
+		if u.present {
+			out[id] = lastUsedPresent
+		} else {
+			out[id] = u.end.Format("2006-01")
+		}
+	}
+	return out
+}
+
+// SkillRecencyWeight returns the match weight (skillMinWeight–1) of a skill last used
+// at lastUsed: full weight for current skills and those used within skillFreshYears,
+// then 0.1 less per year. Unknown recency keeps full weight.
+func SkillRecencyWeight(lastUsed string, now time.Time) float64 {
+	years, ok := skillAgeYears(lastUsed, now)
+	if !ok || years <= skillFreshYears {
+		return 1
+	}
+	return math.Max(skillMinWeight, 1-0.1*(years-skillFreshYears))
+}
+
+// skillAgeYears is the time since lastUsed in years.
+func skillAgeYears(lastUsed string, now time.Time) (float64, bool) {
+	if lastUsed == "" || lastUsed == lastUsedPresent {
+		return 0, lastUsed != ""
+	}
+	t, err := time.Parse("2006-01", lastUsed)
+	if err != nil {
+		return 0, false
+	}
+	return now.Sub(t).Hours() / 24 / 365.25, true
+}
+
+// skillStale reports whether a skill has not been used for more than skillFreshYears.
+func skillStale(lastUsed string, now time.Time) bool {
+	years, ok := skillAgeYears(lastUsed, now)
+	return ok && years > skillFreshYears
+}
+
+// skillKeywordWeights maps match keywords of the skill names to their recency weight.
+// A keyword shared by several skills takes the freshest one.
+func skillKeywordWeights(skills []SkillRecord, now time.Time) map[string]float64 {
+	weights := make(map[string]float64)
+	for _, s := range skills {
+		w := SkillRecencyWeight(s.LastUsed, now)
+		for kw := range extractMatchKW(s.Name) {
+			if cur, ok := weights[kw]; !ok || w > cur {
+				weights[kw] = w
+			}
+		}
+	}
+	return weights
+}
+
+// MasterSkillWeights returns keyword recency weights from the master resume skills,
+// or nil when the resume database is not configured or holds no resume.
+func MasterSkillWeights(ctx context.Context) map[string]float64 {
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return nil
+	}
+	personID := db.GetLatestPersonID(ctx)
+	if personID == 0 {
+		return nil
+	}
+	skills, err := db.GetAllSkills(ctx, personID)
+	if err != nil || len(skills) == 0 {
+		return nil
+	}
+	return skillKeywordWeights(skills, time.Now())
+}
+
+// staleSkillLabel annotates a skill name with its last use when it is stale.
+func staleSkillLabel(s SkillRecord, now time.Time) string {
+	if !skillStale(s.LastUsed, now) {
+		return s.Name
+	}
+	return fmt.Sprintf("%s (last used %s)", s.Name, s.LastUsed[:4])
+}
+
+// jdStaleSkills returns the JD skills whose matching candidate skill is stale.
+func jdStaleSkills(jdSkills []string, skills []SkillRecord, now time.Time) []string {
+	byName := make(map[string]SkillRecord, len(skills))
+	for _, s := range skills {
+		byName[strings.ToLower(s.Name)] = s
+	}
+	var stale []string
+	seen := make(map[string]bool)
+	for _, name := range jdSkills {
+		key := strings.ToLower(strings.TrimSpace(name))
+		s, ok := byName[key]
+		if ok && !seen[key] && skillStale(s.LastUsed, now) {
+			seen[key] = true
+			stale = append(stale, s.Name)
+		}
+	}
+	return stale
+}
@@ -1,7 +1,12 @@
 package jobs
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
 )
 
 func TestExtractSkillsFromText(t *testing.T) {
@@ -75,3 +80,186 @@ func TestMergeSkills(t *testing.T) {
 		}
 	}
 }
+
+func TestSkillLastUsed(t *testing.T) {
+	plan := &buildPlan{}
+	plan.edge("Exp", 1, "USED_SKILL", "Skill", 10) // Perl, 2012 only
+	plan.edge("Exp", 1, "USED_SKILL", "Skill", 11) // Go, old and current role
+	plan.edge("Exp", 2, "USED_SKILL", "Skill", 11)
+	plan.edge("Proj", 5, "PART_OF", "Exp", 3)
+	plan.edge("Proj", 5, "USED_SKILL", "Skill", 12) // Python via a sub-project of a 2020 role
+	plan.edge("Skill", 12, "IMPLIES_SKILL", "Skill", 13)
+	exps := []ExperienceRecord{
+		{ID: 1, EndDate: "2012-06"},
+		{ID: 2, EndDate: "Present"},
+		{ID: 3, EndDate: "2020-03"},
+	}
+
+	got := skillLastUsed(plan, exps)
+	want := map[int]string{10: "2012-06", 11: "present", 12: "2020-03", 13: "2020-03"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("skill %d last_used = %q, want %q", id, got[id], w)
+		}
+	}
+}
+
+func TestSkillRecencyWeight(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		lastUsed string
+		want     float64
+	}{
+		{"", 1},
+		{"present", 1},
+		{"2023-01", 1},
+		{"2019-06", 0.7},
+		{"2010-01", 0.25},
+	}
+	for _, tt := range tests {
+		got := SkillRecencyWeight(tt.lastUsed, now)
+		if got < tt.want-0.01 || got > tt.want+0.01 {
+			t.Errorf("SkillRecencyWeight(%q) = %.2f, want %.2f", tt.lastUsed, got, tt.want)
+		}
+	}
+}
+
+func TestScoreJobMatchWeighted(t *testing.T) {
+	resumeKW := ExtractResumeKeywords("golang perl kubernetes")
+	job := "Looking for golang and perl engineers"
+
+	plain, _, _ := ScoreJobMatch(resumeKW, job)
+	same, _, _ := ScoreJobMatchWeighted(resumeKW, nil, job)
+	if plain != same {
+		t.Errorf("nil weights changed the score: %v vs %v", plain, same)
+	}
+
+	weights := skillKeywordWeights([]SkillRecord{
+		{Name: "Golang", LastUsed: "present"},
+		{Name: "Perl", LastUsed: "2010-01"},
+	}, time.Now())
+	weighted, matching, _ := ScoreJobMatchWeighted(resumeKW, weights, job)
+	if weighted >= plain {
+		t.Errorf("stale perl should lower the score: %v >= %v", weighted, plain)
+	}
+	if len(matching) != 2 {
+		t.Errorf("matching = %v", matching)
+	}
+	if got := jdStaleSkills([]string{"perl", "Golang", "Rust"}, []SkillRecord{
+		{Name: "Golang", LastUsed: "present"},
+		{Name: "Perl", LastUsed: "2010-01"},
+	}, time.Now()); len(got) != 1 || got[0] != "Perl" {
+		t.Errorf("jdStaleSkills = %v", got)
+	}
+}
+
+func TestExplainMatches(t *testing.T) {
+	weights := map[string]float64{"golang": 1, "postgresql": 0.5, "docker": 1}
+	listings := []engine.JobListing{
+		{Title: "Senior Go Engineer", Description: "Go services on Postgres and Kubernetes.", Skills: []string{"Docker"}},
+		{Title: "Office Manager", Description: "Keep the office running."},
+	}
+	ExplainMatches(listings, weights)
+
+	e := listings[0].MatchExplanation
+	if e == nil {
+		t.Fatal("match_explanation not set")
+	}
+	if !reflect.DeepEqual(e.TitleMatches, []string{"Go"}) {
+		t.Errorf("title_matches = %v, want [Go]", e.TitleMatches)
+	}
+	if !reflect.DeepEqual(e.DescriptionMatches, []string{"Docker", "Go", "PostgreSQL"}) {
+		t.Errorf("description_matches = %v, want [Docker Go PostgreSQL]", e.DescriptionMatches)
+	}
+	if !reflect.DeepEqual(e.Unmet, []string{"Kubernetes"}) {
+		t.Errorf("unmet = %v, want [Kubernetes]", e.Unmet)
+	}
+	if listings[1].MatchExplanation != nil {
+		t.Errorf("listing without skills got %+v", listings[1].MatchExplanation)
+	}
+
+	ExplainMatches(listings[1:], nil)
+	if listings[1].MatchExplanation != nil {
+		t.Error("explanation set without resume skills")
+	}
+}
+
+func TestComputeExperienceYears(t *testing.T) {
+	now := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	exps := []ExperienceRecord{
+		{ID: 1, Title: "Dev", Company: "A", StartDate: "2012-01", EndDate: "2015-12"},
+		{ID: 2, Title: "Dev", Company: "B", StartDate: "2015-07", EndDate: "2019"}, // overlaps the first role by six months
+		{ID: 3, Title: "Lead", Company: "C", StartDate: "April 2020", EndDate: "Present"},
+		{ID: 4, Title: "Side", Company: "D", StartDate: "sometime", EndDate: "2019"}, // unreadable start: skipped
+	}
+	skills := []SkillRecord{{ID: 10, Name: "Perl"}, {ID: 11, Name: "Go"}, {ID: 12, Name: "Rust"}}
+	skillExps := map[int][]int{10: {1}, 11: {2, 3}, 12: {4}}
+
+	y := computeExperienceYears(exps, skills, skillExps, now)
+	if y.TotalYears != 14.5 {
+		t.Errorf("total_years = %v, want 14.5", y.TotalYears)
+	}
+	if len(y.Skills) != 2 || y.Skills[0].Skill != "Go" || y.Skills[0].Years != 11 || y.Skills[1].Years != 4 {
+		t.Errorf("skills = %+v, want Go 11, Perl 4", y.Skills)
+	}
+	if got, ok := y.Years("golang"); !ok || got != 11 {
+		t.Errorf("Years(golang) = %v, %v, want 11", got, ok)
+	}
+	if len(y.Gaps) != 1 || y.Gaps[0] != (EmploymentGap{From: "2020-01", To: "2020-03", Months: 3}) {
+		t.Errorf("gaps = %+v, want 2020-01..2020-03", y.Gaps)
+	}
+	if len(y.DateIssues) != 1 || !strings.Contains(y.DateIssues[0], `unreadable start date "sometime"`) {
+		t.Errorf("date_issues = %v", y.DateIssues)
+	}
+	if want := "Total: 14.5 years. Go: 11 years, Perl: 4 years. 1 gap(s) of 3+ months between roles."; y.Summary != want {
+		t.Errorf("summary = %q, want %q", y.Summary, want)
+	}
+}
+
+func TestParseResumeDate(t *testing.T) {
+	now := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in, want, precision, last string
+	}{
+		{"2019", "2019", DatePrecisionYear, "2019-12-31"},
+		{"2019-03", "2019-03", DatePrecisionMonth, "2019-03-31"},
+		{"2019-03-05", "2019-03-05", DatePrecisionDay, "2019-03-05"},
+		{"03/2019", "2019-03", DatePrecisionMonth, "2019-03-31"},
+		{"05.03.2019", "2019-03-05", DatePrecisionDay, "2019-03-05"},
+		{"Mar 2019", "2019-03", DatePrecisionMonth, "2019-03-31"},
+		{"September, 2019", "2019-09", DatePrecisionMonth, "2019-09-30"},
+		{"февраль 2020", "2020-02", DatePrecisionMonth, "2020-02-29"},
+		{"мая 2018", "2018-05", DatePrecisionMonth, "2018-05-31"},
+		{"весна 2019", "2019-03", DatePrecisionSeason, "2019-05-31"},
+		{"Winter 2019", "2018-12", DatePrecisionSeason, "2019-02-28"},
+		{"Present", "present", DatePrecisionPresent, "2026-10-15"},
+		{"по настоящее время", "present", DatePrecisionPresent, "2026-10-15"},
+	}
+	for _, tt := range tests {
+		d, ok := ParseResumeDate(tt.in)
+		if !ok {
+			t.Errorf("ParseResumeDate(%q) failed", tt.in)
+			continue
+		}
+		if d.String() != tt.want || d.Precision != tt.precision || d.Last(now).Format(time.DateOnly) != tt.last {
+			t.Errorf("ParseResumeDate(%q) = %s (%s, last %s), want %s (%s, last %s)",
+				tt.in, d, d.Precision, d.Last(now).Format(time.DateOnly), tt.want, tt.precision, tt.last)
+		}
+	}
+	for _, in := range []string{"", "sometime", "2019-13", "30.02.2019", "Smarch 2019"} {
+		if d, ok := ParseResumeDate(in); ok {
+			t.Errorf("ParseResumeDate(%q) = %s, want failure", in, d)
+		}
+	}
+
+	_, _, err := parseExperienceDates(ExperienceRecord{StartDate: "2021-05", EndDate: "2020"}, now)
+	if err == nil || !strings.Contains(err.Error(), "before it starts") {
+		t.Errorf("end before start: err = %v", err)
+	}
+	if _, end, err := parseExperienceDates(ExperienceRecord{StartDate: "2021"}, now); err != nil || !end.IsPresent() {
+		t.Errorf("missing end date: end = %+v, err = %v", end, err)
+	}
+}
@@ -0,0 +1,336 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- STAR story bank ---
+
+// The story bank turns the master resume's achievements, with the experience that
+// produced each (PRODUCED edges in the graph), into reusable STAR stories tagged by
+// competency. Stories are stored in resume_star_stories and reused by interview_prep.
+
+const (
+	starStoriesMin = 10
+	starStoriesMax = 15
+)
+
+// StarCompetencies are the competencies stories are tagged with.
+var StarCompetencies = []string{
+	"leadership", "conflict", "scale", "failure", "ownership", "collaboration",
+	"influence", "ambiguity", "customer_focus", "innovation", "mentoring", "delivery",
+}
+
+// StarStoryRecord is one stored STAR story.
+type StarStoryRecord struct {
+	ID            int      `json:"id"`
+	PersonID      int      `json:"person_id"`
+	Title         string   `json:"title"`
+	Situation     string   `json:"situation"`
+	Task          string   `json:"task"`
+	Action        string   `json:"action"`
+	Result        string   `json:"result"`
+	Competencies  []string `json:"competencies"`
+	AchievementID *int     `json:"achievement_id,omitempty"`
+	ExperienceID  *int     `json:"experience_id,omitempty"`
+}
+
+// StarStoriesResult is the structured output of star_stories.
+type StarStoriesResult struct {
+	Stories   []StarStoryRecord `json:"stories"`
+	Coverage  map[string]int    `json:"coverage"` // stories per competency
+	Generated bool              `json:"generated"`
+}
+
+func (db *ResumeDB) GetStarStories(ctx context.Context, personID int) ([]StarStoryRecord, error) {
+	rows, err := db.q.Query(ctx,
+		`SELECT id, person_id, title, situation, task, action, result, competencies, achievement_id, experience_id
+		 FROM public.resume_star_stories WHERE person_id = $1 ORDER BY id`, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []StarStoryRecord
+	for rows.Next() {
+		var r StarStoryRecord
+		if err := rows.Scan(&r.ID, &r.PersonID, &r.Title, &r.Situation, &r.Task, &r.Action, &r.Result,
+			&r.Competencies, &r.AchievementID, &r.ExperienceID); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// ReplaceStarStories replaces the person's story bank in one transaction.
+func (db *ResumeDB) ReplaceStarStories(ctx context.Context, personID int, stories []StarStoryRecord) error {
+	return db.InTx(ctx, func(tx *ResumeDB) error {
+		if _, err := tx.q.Exec(ctx, `DELETE FROM public.resume_star_stories WHERE person_id = $1`, personID); err != nil {
+			return err
+		}
+		for i := range stories {
+			s := &stories[i]
+			err := tx.q.QueryRow(ctx,
+				`INSERT INTO public.resume_star_stories
+				 (person_id, title, situation, task, action, result, competencies, achievement_id, experience_id)
+				 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+				personID, s.Title, s.Situation, s.Task, s.Action, s.Result, s.Competencies, s.AchievementID, s.ExperienceID,
+			).Scan(&s.ID)
+			if err != nil {
+				return err
+			}
+			s.PersonID = personID
+		}
+		return nil
+	})
+}
+
+const starStoriesPrompt = `You are an interview coach building a candidate's STAR story bank.
+
+Below are the candidate's achievements, each with the experience it came from. Write %d-%d reusable STAR stories that together cover as many of these competencies as the material honestly supports: %s.
+
+ACHIEVEMENTS:
+%s
+
+Rules:
+- Use ONLY facts in the achievements and experiences; never invent numbers, employers or outcomes
+- Each story is built on one achievement; reference it by its [A<id>] number
+- Situation and task: 1-2 sentences each; action: 2-4 sentences in first person, on what the candidate did; result: quantified where the achievement is
+- Tag each story with 1-3 competencies from the list above; a "failure" story must involve a setback and what was learned
+
+Return a JSON object with this exact structure:
+{
+  "stories": [
+    {"achievement_id": <id>, "title": "<short memorable title>", "situation": "...", "task": "...", "action": "...", "result": "...", "competencies": ["<competency>"]}
+  ]
+}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+// StarStories returns the stored story bank, generating it first when there is none or
+// refresh is set. competency, when set, filters the returned stories.
+func StarStories(ctx context.Context, refresh bool, competency string) (*StarStoriesResult, error) {
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return nil, errors.New("resume database not configured (set DATABASE_URL)")
+	}
+	personID := db.GetLatestPersonID(ctx)
+	if personID == 0 {
+		return nil, errors.New("no master resume found — run master_resume_build first")
+	}
+
+	stories, err := db.GetStarStories(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("star_stories: load: %w", err)
+	}
+	result := &StarStoriesResult{}
+	if refresh || len(stories) == 0 {
+		stories, err = generateStarStories(ctx, db, personID)
+		if err != nil {
+			return nil, err
+		}
+		if err := db.ReplaceStarStories(ctx, personID, stories); err != nil {
+			return nil, fmt.Errorf("star_stories: save: %w", err)
+		}
+		result.Generated = true
+	}
+
+	result.Coverage = starCoverage(stories)
+	competency = strings.ToLower(strings.TrimSpace(competency))
+	result.Stories = []StarStoryRecord{}
+	for _, s := range stories {
+		if competency == "" || containsString(s.Competencies, competency) {
+			result.Stories = append(result.Stories, s)
+		}
+	}
+	return result, nil
+}
+
+// generateStarStories writes the story bank from the person's achievements.
+func generateStarStories(ctx context.Context, db *ResumeDB, personID int) ([]StarStoryRecord, error) {
+	achievements, err := db.GetAllAchievements(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("star_stories: achievements: %w", err)
+	}
+	if len(achievements) == 0 {
+		return nil, errors.New("master resume has no achievements to build stories from")
+	}
+	exps, err := db.GetAllExperiences(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("star_stories: experiences: %w", err)
+	}
+	parents := achievementParents(ctx, db, achievements, exps)
+
+	var b strings.Builder
+	for _, a := range achievements {
+		fmt.Fprintf(&b, "[A%d] %s", a.ID, a.Text)
+		if a.Metric != "" || a.Value != "" {
+			fmt.Fprintf(&b, " (metric: %s %s)", a.Metric, a.Value)
+		}
+		if exp, ok := parents[a.ID]; ok {
+			fmt.Fprintf(&b, "\n     at: %s, %s (%s – %s)", exp.Title, exp.Company, exp.StartDate, exp.EndDate)
+			if exp.Description != "" {
+				fmt.Fprintf(&b, "\n     role: %s", engine.TruncateRunes(exp.Description, 300, "..."))
+			}
+		} else if a.Context != "" {
+			fmt.Fprintf(&b, "\n     context: %s", a.Context)
+		}
+		b.WriteString("\n")
+	}
+
+	prompt := fmt.Sprintf(starStoriesPrompt, starStoriesMin, starStoriesMax,
+		strings.Join(StarCompetencies, ", "), engine.TruncateRunes(b.String(), 8000, ""))
+	raw, err := engine.CallLLM(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("star_stories LLM: %w", err)
+	}
+	var gen struct {
+		Stories []struct {
+			AchievementID int      `json:"achievement_id"`
+			Title         string   `json:"title"`
+			Situation     string   `json:"situation"`
+			Task          string   `json:"task"`
+			Action        string   `json:"action"`
+			Result        string   `json:"result"`
+			Competencies  []string `json:"competencies"`
+		} `json:"stories"`
+	}
+	if err := json.Unmarshal([]byte(StripMarkdownFences(raw)), &gen); err != nil {
+		return nil, fmt.Errorf("star_stories parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+	}
+
+	known := make(map[int]bool, len(achievements))
+	for _, a := range achievements {
+		known[a.ID] = true
+	}
+	var stories []StarStoryRecord
+	for _, g := range gen.Stories {
+		if g.Situation == "" || g.Action == "" || g.Result == "" {
+			continue
+		}
+		s := StarStoryRecord{
+			Title: g.Title, Situation: g.Situation, Task: g.Task, Action: g.Action, Result: g.Result,
+			Competencies: normalizeCompetencies(g.Competencies),
+		}
+		if known[g.AchievementID] {
+			id := g.AchievementID
+			s.AchievementID = &id
+			if exp, ok := parents[id]; ok {
+				expID := exp.ID
+				s.ExperienceID = &expID
+			}
+		}
+		stories = append(stories, s)
+		if len(stories) == starStoriesMax {
+			break
+		}
+	}
+	if len(stories) == 0 {
+		return nil, errors.New("star_stories: no usable stories generated")
+	}
+	return stories, nil
+}
+
+// achievementParents maps achievement IDs to the experience that produced them: the
+// graph's PRODUCED edges when available, else the achievement's context naming the
+// experience's company or title.
+func achievementParents(ctx context.Context, db *ResumeDB, achievements []AchievementRecord, exps []ExperienceRecord) map[int]ExperienceRecord {
+	parents := make(map[int]ExperienceRecord)
+	for _, exp := range exps {
+		ids, err := db.QueryAchievementIDsByExperience(ctx, exp.ID)
+		if err != nil {
+			slog.Debug("star_stories: graph lookup failed, matching by context", slog.Any("error", err))
+			break
+		}
+		for _, id := range ids {
+			parents[id] = exp
+		}
+	}
+	for _, a := range achievements {
+		if _, ok := parents[a.ID]; ok {
+			continue
+		}
+		if exp, ok := matchAchievementExperience(a, exps); ok {
+			parents[a.ID] = exp
+		}
+	}
+	return parents
+}
+
+// matchAchievementExperience finds the experience an achievement's context names, the
+// same way linkAchievementToParent links them while building the graph.
+func matchAchievementExperience(a AchievementRecord, exps []ExperienceRecord) (ExperienceRecord, bool) {
+	hint := strings.ToLower(a.Context)
+	if hint == "" {
+		return ExperienceRecord{}, false
+	}
+	for _, exp := range exps {
+		if (exp.Company != "" && strings.Contains(hint, strings.ToLower(exp.Company))) ||
+			(exp.Title != "" && strings.Contains(hint, strings.ToLower(exp.Title))) {
+			return exp, true
+		}
+	}
+	return ExperienceRecord{}, false
+}
+
+// normalizeCompetencies lowercases tags and keeps those in StarCompetencies.
+func normalizeCompetencies(tags []string) []string {
+	out := []string{}
+	for _, t := range tags {
+		t = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(t)), " ", "_")
+		if containsString(StarCompetencies, t) && !containsString(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// starCoverage counts stories per competency, listing every competency.
+func starCoverage(stories []StarStoryRecord) map[string]int {
+	cov := make(map[string]int, len(StarCompetencies))
+	for _, c := range StarCompetencies {
+		cov[c] = 0
+	}
+	for _, s := range stories {
+		for _, c := range s.Competencies {
+			cov[c]++
+		}
+	}
+	return cov
+}
+
+// storedStarStoriesContext formats the stored story bank for the interview_prep
+// prompt, or "" when there is none. Stories are not generated here.
+func storedStarStoriesContext(ctx context.Context) string {
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return ""
+	}
+	personID := db.GetLatestPersonID(ctx)
+	if personID == 0 {
+		return ""
+	}
+	stories, err := db.GetStarStories(ctx, personID)
+	if err != nil {
+		slog.Debug("interview_prep: star stories failed", slog.Any("error", err))
+		return ""
+	}
+	if len(stories) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\nCANDIDATE STAR STORIES (reuse these in behavioral model answers, naming the story title):\n")
+	for _, s := range stories {
+		fmt.Fprintf(&b, "- %s [%s]: %s %s %s\n", s.Title, strings.Join(s.Competencies, ", "),
+			engine.TruncateRunes(s.Situation, 160, "..."), engine.TruncateRunes(s.Action, 200, "..."), engine.TruncateRunes(s.Result, 160, "..."))
+	}
+	return b.String()
+}
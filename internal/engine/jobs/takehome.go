@@ -0,0 +1,232 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Take-home assignment research ---
+
+// Publicly shared take-home assignments for a company: GitHub repositories where
+// candidates published their submission or the brief (GitHub search API), and blog
+// and forum write-ups (SearXNG). An LLM summarizes scope, time budget and evaluation
+// criteria from them, citing only what the sources say.
+
+const takeHomeMaxSources = 12
+
+// TakeHomeSource is one public report of a take-home assignment.
+type TakeHomeSource struct {
+	Kind    string `json:"kind"` // "github" or "writeup"
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Snippet string `json:"snippet,omitempty"`
+	Stars   int    `json:"stars,omitempty"`
+}
+
+// TakeHomeResearchResult is the structured output of takehome_research.
+type TakeHomeResearchResult struct {
+	Company            string           `json:"company"`
+	Role               string           `json:"role,omitempty"`
+	Found              bool             `json:"found"` // false when no source describes an assignment
+	Scope              string           `json:"scope"`
+	TimeBudget         string           `json:"time_budget"`
+	EvaluationCriteria []string         `json:"evaluation_criteria"`
+	TypicalTasks       []string         `json:"typical_tasks"`
+	TechStack          []string         `json:"tech_stack"`
+	Tips               []string         `json:"tips"`
+	Summary            string           `json:"summary"`
+	Sources            []TakeHomeSource `json:"sources"`
+}
+
+const takeHomePrompt = `You are an interview coach. Below are public reports of take-home assignments (GitHub repositories with candidates' submissions or briefs, and blog/forum write-ups) for a company.
+
+Company: %s
+Role: %s
+
+Sources:
+%s
+
+Summarize what the take-home assignment looks like, using ONLY what the sources say. Ignore sources about other companies. If the sources describe no assignment for this company, set "found" to false and leave the other fields empty.
+
+Return a JSON object with this exact structure:
+{
+  "found": <true|false>,
+  "scope": "<what candidates are asked to build, 2-3 sentences>",
+  "time_budget": "<stated time limit or reported time spent, e.g. '4 hours suggested, 1 week deadline'; empty if unknown>",
+  "evaluation_criteria": [<what reviewers look at, as reported>],
+  "typical_tasks": [<concrete tasks seen in the sources>],
+  "tech_stack": [<languages/frameworks required or commonly used>],
+  "tips": [<up to 4 practical tips drawn from the reports>],
+  "summary": "<2-3 sentences for a candidate about to receive this assignment>"
+}
+
+Return ONLY the JSON object, no markdown, no explanation.`
+
+// ResearchTakeHome finds public take-home reports for a company (and role) and
+// summarizes them. Cached per company and role.
+func ResearchTakeHome(ctx context.Context, company, role string) (*TakeHomeResearchResult, error) {
+	company, role = strings.TrimSpace(company), strings.TrimSpace(role)
+	cacheKey := engine.CacheKey("takehome_research", strings.ToLower(company), strings.ToLower(role))
+	if cached, ok := engine.CacheLoadJSON[TakeHomeResearchResult](ctx, cacheKey); ok {
+		return &cached, nil
+	}
+
+	reposCh := make(chan []TakeHomeSource, 1)
+	engine.GoOutbound(ctx, func() {
+		repos, err := searchTakeHomeRepos(ctx, company)
+		if err != nil {
+			slog.Debug("takehome_research: github search failed", slog.Any("error", err))
+		}
+		reposCh <- repos
+	})
+	writeups, err := searchTakeHomeWriteups(ctx, company, role)
+	if err != nil {
+		slog.Debug("takehome_research: web search failed", slog.Any("error", err))
+	}
+	sources := append(<-reposCh, writeups...)
+	if len(sources) > takeHomeMaxSources {
+		sources = sources[:takeHomeMaxSources]
+	}
+
+	result := &TakeHomeResearchResult{Company: company, Role: role, Sources: sources}
+	if len(sources) == 0 {
+		result.Summary = fmt.Sprintf("No public take-home reports found for %s.", company)
+		result.Sources = []TakeHomeSource{}
+		return result, nil
+	}
+
+	var b strings.Builder
+	for i, s := range sources {
+		fmt.Fprintf(&b, "[%d] (%s) %s\n%s\n%s\n\n", i+1, s.Kind, s.Title, s.URL, engine.TruncateRunes(s.Snippet, 500, "..."))
+	}
+	promptRole := role
+	if promptRole == "" {
+		promptRole = "any"
+	}
+	prompt := fmt.Sprintf(takeHomePrompt, company, promptRole, engine.TruncateRunes(b.String(), 7000, ""))
+	raw, err := engine.CallLLM(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("takehome_research LLM: %w", err)
+	}
+	if err := json.Unmarshal([]byte(StripMarkdownFences(raw)), result); err != nil {
+		return nil, fmt.Errorf("takehome_research parse: %w (raw: %s)", err, engine.TruncateRunes(raw, 200, "..."))
+	}
+	result.Company, result.Role, result.Sources = company, role, sources
+	engine.CacheStoreJSON(ctx, cacheKey, company+" "+role, *result)
+	return result, nil
+}
+
+// searchTakeHomeRepos searches GitHub for repositories naming the company alongside
+// take-home or coding-challenge terms.
+func searchTakeHomeRepos(ctx context.Context, company string) ([]TakeHomeSource, error) {
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
+	defer cancel()
+
+	query := fmt.Sprintf(`"%s" take-home OR "home assignment" OR "coding challenge" OR "tech test" in:name,description,readme`, company)
+	apiURL := "https://api.github.com/search/repositories?per_page=10&sort=stars&q=" + url.QueryEscape(query)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", engine.UserAgentBot)
+	if engine.ConfigFrom(ctx).GithubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
+	}
+
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github search: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("github search returned %d: %s", resp.StatusCode, string(body))
+	}
+	return parseTakeHomeRepos(resp.Body, company)
+}
+
+// parseTakeHomeRepos reads a GitHub repository search response, keeping repositories
+// whose name or description mentions the company.
+func parseTakeHomeRepos(r io.Reader, company string) ([]TakeHomeSource, error) {
+	var result struct {
+		Items []struct {
+			FullName    string `json:"full_name"`
+			HTMLURL     string `json:"html_url"`
+			Description string `json:"description"`
+			Stars       int    `json:"stargazers_count"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode search: %w", err)
+	}
+	name := strings.ToLower(company)
+	compact := strings.ReplaceAll(name, " ", "")
+	var out []TakeHomeSource
+	for _, it := range result.Items {
+		hay := strings.ToLower(it.FullName + " " + it.Description)
+		if !strings.Contains(hay, name) && !strings.Contains(hay, compact) {
+			continue
+		}
+		out = append(out, TakeHomeSource{Kind: "github", Title: it.FullName, URL: it.HTMLURL, Snippet: it.Description, Stars: it.Stars})
+	}
+	return out, nil
+}
+
+// searchTakeHomeWriteups finds blog and forum write-ups of the company's take-home.
+func searchTakeHomeWriteups(ctx context.Context, company, role string) ([]TakeHomeSource, error) {
+	queries := []string{
+		fmt.Sprintf(`%s %s "take home" assignment interview`, company, role),
+		fmt.Sprintf(`%s take-home challenge experience site:medium.com OR site:dev.to OR site:reddit.com OR site:glassdoor.com`, company),
+	}
+	type searchRes struct {
+		results []engine.SearxngResult
+		err     error
+	}
+	ch := make(chan searchRes, len(queries))
+	for _, q := range queries {
+		engine.GoOutbound(ctx, func() {
+			r, err := engine.SearchSearXNG(ctx, q, "all", "", engine.DefaultSearchEngine)
+			ch <- searchRes{r, err}
+		})
+	}
+	var out []TakeHomeSource
+	var lastErr error
+	seen := make(map[string]bool)
+	for range queries {
+		res := <-ch
+		if res.err != nil {
+			lastErr = res.err
+			continue
+		}
+		out = append(out, filterTakeHomeWriteups(res.results, company, seen)...)
+	}
+	if len(out) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return out, nil
+}
+
+// filterTakeHomeWriteups keeps the web results that mention the company, skipping
+// GitHub pages (covered by the repository search) and URLs already in seen.
+func filterTakeHomeWriteups(results []engine.SearxngResult, company string, seen map[string]bool) []TakeHomeSource {
+	name := strings.ToLower(company)
+	var out []TakeHomeSource
+	for _, r := range results {
+		text := strings.ToLower(r.Title + " " + r.Content)
+		if seen[r.URL] || !strings.Contains(text, name) || strings.Contains(r.URL, "github.com") {
+			continue
+		}
+		seen[r.URL] = true
+		out = append(out, TakeHomeSource{Kind: "writeup", Title: r.Title, URL: r.URL, Snippet: r.Content})
+	}
+	return out
+}
@@ -0,0 +1,230 @@
+package jobs
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Time-zone overlap: remote listings that name team time zones or core hours get the
+// number of hours they overlap with the user's working day (profile timezone and
+// work_hours, default 09:00-17:00). A listing naming several zones gets the best
+// overlap with any of them; listings naming none are left unrated.
+
+// defaultWorkHours is the working day assumed when the profile or listing gives none.
+var defaultWorkHours = hourWindow{9, 17}
+
+// hourWindow is a span of the day in hours, e.g. {9.5, 17} for 09:30–17:00.
+type hourWindow struct{ start, end float64 }
+
+// tzAliases maps time-zone abbreviations and names to IANA zones.
+var tzAliases = map[string]string{
+	"PST": "America/Los_Angeles", "PDT": "America/Los_Angeles", "PT": "America/Los_Angeles", "PACIFIC": "America/Los_Angeles",
+	"MST": "America/Denver", "MDT": "America/Denver", "MT": "America/Denver", "MOUNTAIN": "America/Denver",
+	"CST": "America/Chicago", "CDT": "America/Chicago", "CT": "America/Chicago", "CENTRAL": "America/Chicago",
+	"EST": "America/New_York", "EDT": "America/New_York", "ET": "America/New_York", "EASTERN": "America/New_York",
+	"GMT": "UTC", "UTC": "UTC", "BST": "Europe/London", "WET": "Europe/Lisbon",
+	"CET": "Europe/Berlin", "CEST": "Europe/Berlin", "EET": "Europe/Helsinki", "EEST": "Europe/Helsinki", "MSK": "Europe/Moscow",
+	"IST": "Asia/Kolkata", "SGT": "Asia/Singapore", "JST": "Asia/Tokyo", "AEST": "Australia/Sydney", "AEDT": "Australia/Sydney",
+}
+
+// tzStandardOffsets are used when the system has no zoneinfo database.
+var tzStandardOffsets = map[string]float64{
+	"America/Los_Angeles": -8, "America/Denver": -7, "America/Chicago": -6, "America/New_York": -5,
+	"UTC": 0, "Europe/London": 0, "Europe/Lisbon": 0, "Europe/Berlin": 1, "Europe/Helsinki": 2, "Europe/Moscow": 3,
+	"Asia/Kolkata": 5.5, "Asia/Singapore": 8, "Asia/Tokyo": 9, "Australia/Sydney": 10,
+}
+
+const (
+	tzClock  = `(\d{1,2})(?::(\d{2}))?\s*([ap]\.?m\.?)?`
+	tzOffset = `(?:UTC|GMT)\s*[+\-−]\s*\d{1,2}(?::?\d{2})?`
+)
+
+var (
+	// "10am-2pm PT", "9:00–17:00 CET", "between 9 and 5 EST", "14-18 UTC+3"
+	coreHoursRe = regexp.MustCompile(`(?i)` + tzClock + `\s*(?:-|–|—|to|and)\s*` + tzClock + `\s*\(?(` + tzOffset + `|[A-Za-z]{2,4}\b)`)
+	tzOffsetRe  = regexp.MustCompile(`(?i)\b` + tzOffset)
+	// Bare two-letter forms (PT, ET, CT, MT) are left out: they clash with part-time and US states.
+	tzAbbrevRe  = regexp.MustCompile(`\b(PST|PDT|MST|MDT|CST|CDT|EST|EDT|GMT|UTC|BST|WET|CET|CEST|EET|EEST|MSK|IST|SGT|JST|AEST|AEDT)\b`)
+	tzNameRe    = regexp.MustCompile(`(?i)\b(pacific|mountain|central|eastern)(?: standard| daylight)? time\b`)
+	utcOffRe    = regexp.MustCompile(`(?i)^(?:UTC|GMT)\s*([+\-−])\s*(\d{1,2})(?::?(\d{2}))?$`)
+	workHoursRe = regexp.MustCompile(`(?i)^\s*` + tzClock + `\s*(?:-|–|to)\s*` + tzClock + `\s*$`)
+)
+
+// zoneOffset returns the UTC offset in hours of a zone given as an IANA name, an
+// abbreviation (PST, CET) or a fixed offset (UTC+3, GMT-5) at time now.
+func zoneOffset(zone string, now time.Time) (float64, bool) {
+	zone = strings.TrimSpace(zone)
+	if m := utcOffRe.FindStringSubmatch(zone); m != nil {
+		h, _ := strconv.Atoi(m[2])
+		mins, _ := strconv.Atoi(m[3])
+		off := float64(h) + float64(mins)/60
+		if m[1] != "+" {
+			off = -off
+		}
+		return off, true
+	}
+	name := zone
+	if iana, ok := tzAliases[strings.ToUpper(zone)]; ok {
+		name = iana
+	}
+	if loc, err := time.LoadLocation(name); err == nil && name != "" && name != "Local" {
+		_, secs := now.In(loc).Zone()
+		return float64(secs) / 3600, true
+	}
+	off, ok := tzStandardOffsets[name]
+	return off, ok
+}
+
+// clockHour converts a parsed clock time to hours; ampm may be empty for 24h times.
+func clockHour(h, m, ampm string) float64 {
+	hour, _ := strconv.Atoi(h)
+	mins, _ := strconv.Atoi(m)
+	switch strings.ToLower(strings.ReplaceAll(ampm, ".", "")) {
+	case "pm":
+		if hour < 12 {
+			hour += 12
+		}
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	}
+	return float64(hour) + float64(mins)/60
+}
+
+// clockWindow builds a window from the submatches of two tzClock patterns. Without
+// am/pm, "9-5" is read as 9am-5pm.
+func clockWindow(m []string) (hourWindow, bool) {
+	start, end := clockHour(m[0], m[1], m[2]), clockHour(m[3], m[4], m[5])
+	if m[2] == "" && m[5] == "" && end <= start && end <= 12 {
+		end += 12
+	}
+	if start >= 24 || end > 24 || start == end {
+		return hourWindow{}, false
+	}
+	if end < start {
+		end += 24 // crosses midnight
+	}
+	return hourWindow{start, end}, true
+}
+
+// parseWorkHours reads a profile work_hours value such as "09:00-17:00" or "8am-4pm".
+func parseWorkHours(s string) hourWindow {
+	if m := workHoursRe.FindStringSubmatch(s); m != nil {
+		if w, ok := clockWindow(m[1:]); ok {
+			return w
+		}
+	}
+	return defaultWorkHours
+}
+
+// teamWindowsUTC finds the team working windows a listing names, in UTC hours.
+func teamWindowsUTC(text string, now time.Time) []hourWindow {
+	var windows []hourWindow
+	for _, m := range coreHoursRe.FindAllStringSubmatch(text, -1) {
+		off, ok := zoneOffset(m[7], now)
+		if !ok {
+			continue
+		}
+		if w, ok := clockWindow(m[1:7]); ok {
+			windows = append(windows, hourWindow{w.start - off, w.end - off})
+		}
+	}
+	if len(windows) > 0 {
+		return windows // explicit core hours beat bare zone mentions
+	}
+	var zones []string
+	zones = append(zones, tzOffsetRe.FindAllString(text, -1)...)
+	zones = append(zones, tzAbbrevRe.FindAllString(text, -1)...)
+	for _, m := range tzNameRe.FindAllStringSubmatch(text, -1) {
+		zones = append(zones, m[1])
+	}
+	seen := make(map[float64]bool)
+	for _, z := range zones {
+		off, ok := zoneOffset(z, now)
+		if !ok || seen[off] {
+			continue
+		}
+		seen[off] = true
+		windows = append(windows, hourWindow{defaultWorkHours.start - off, defaultWorkHours.end - off})
+	}
+	return windows
+}
+
+// windowOverlap returns the hours two UTC windows share, across day boundaries.
+func windowOverlap(a, b hourWindow) float64 {
+	total := 0.0
+	for _, shift := range []float64{-24, 0, 24} {
+		total += math.Max(0, math.Min(a.end, b.end+shift)-math.Max(a.start, b.start+shift))
+	}
+	return math.Min(total, a.end-a.start)
+}
+
+// TimezoneOverlap returns the best overlap in hours (rounded to 0.5) between the
+// profile's working day and the team windows named in text. ok is false when the
+// profile has no timezone or the text names no time zone.
+func (p *UserProfile) TimezoneOverlap(text string, now time.Time) (float64, bool) {
+	off, ok := zoneOffset(p.Timezone, now)
+	if p.Timezone == "" || !ok {
+		return 0, false
+	}
+	teams := teamWindowsUTC(text, now)
+	if len(teams) == 0 {
+		return 0, false
+	}
+	local := parseWorkHours(p.WorkHours)
+	user := hourWindow{local.start - off, local.end - off}
+	best := 0.0
+	for _, t := range teams {
+		best = math.Max(best, windowOverlap(user, t))
+	}
+	return math.Round(best*2) / 2, true
+}
+
+// ApplyTimezoneOverlap sets eligibility.overlap_hours and drops listings overlapping
+// less than minOverlap hours. Listings without time-zone information are kept.
+func ApplyTimezoneOverlap(listings []engine.JobListing, p *UserProfile, minOverlap float64, now time.Time) []engine.JobListing {
+	if p.Timezone == "" {
+		return listings
+	}
+	out := listings[:0:0]
+	for _, j := range listings {
+		overlap, ok := p.TimezoneOverlap(j.Location+"\n"+j.Remote+"\n"+j.Description, now)
+		if ok {
+			if overlap < minOverlap {
+				continue
+			}
+			if j.Eligibility == nil {
+				j.Eligibility = &engine.Eligibility{}
+			}
+			j.Eligibility.OverlapHours = &overlap
+		}
+		out = append(out, j)
+	}
+	return out
+}
+
+// ApplyRemoteTimezoneOverlap is ApplyTimezoneOverlap for remote_work_search listings,
+// read from their location, title and tags.
+func ApplyRemoteTimezoneOverlap(listings []engine.RemoteJobListing, p *UserProfile, minOverlap float64, now time.Time) []engine.RemoteJobListing {
+	if p.Timezone == "" {
+		return listings
+	}
+	out := listings[:0:0]
+	for _, j := range listings {
+		overlap, ok := p.TimezoneOverlap(j.Location+"\n"+j.Title+"\n"+strings.Join(j.Tags, ", "), now)
+		if ok {
+			if overlap < minOverlap {
+				continue
+			}
+			j.OverlapHours = &overlap
+		}
+		out = append(out, j)
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestTimezoneOverlap(t *testing.T) {
+	winter := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	p := &UserProfile{Timezone: "Europe/Berlin"} // 09:00-17:00 CET = 08:00-16:00 UTC
+	cases := []struct {
+		text string
+		want float64
+		ok   bool
+	}{
+		{"Core hours 10am-2pm PT.", 0, true},      // 18:00-22:00 UTC
+		{"Must overlap 9:00-13:00 EST.", 2, true}, // 14:00-18:00 UTC
+		{"Remote, UTC-5 to UTC+1.", 8, true},      // best zone: UTC+1
+		{"Team in Eastern Time.", 2, true},        // 14:00-22:00 UTC
+		{"Fully async, work whenever you like.", 0, false},
+	}
+	for _, c := range cases {
+		got, ok := p.TimezoneOverlap(c.text, winter)
+		if got != c.want || ok != c.ok {
+			t.Errorf("TimezoneOverlap(%q) = %v, %v; want %v, %v", c.text, got, ok, c.want, c.ok)
+		}
+	}
+
+	listings := []engine.JobListing{
+		{Title: "West Coast", Description: "Core hours 10am-2pm PT."},
+		{Title: "Europe", Description: "Team based in CET."},
+		{Title: "Async", Description: "No meetings."},
+	}
+	kept := ApplyTimezoneOverlap(listings, p, 2, winter)
+	if len(kept) != 2 || kept[0].Title != "Europe" || *kept[0].Eligibility.OverlapHours != 8 {
+		t.Errorf("kept = %+v", kept)
+	}
+	if kept[1].Eligibility != nil {
+		t.Error("listing without time zones should not be rated")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/anatolykoptev/go_job/internal/engine"
 	_ "modernc.org/sqlite"
 )
 
@@ -27,16 +28,38 @@ const (
 
 // TrackedJob is a single entry in the job tracker.
 type TrackedJob struct {
-	ID        int64     `json:"id"`
-	Title     string    `json:"title"`
-	Company   string    `json:"company"`
-	URL       string    `json:"url"`
-	Status    JobStatus `json:"status"`
-	Notes     string    `json:"notes,omitempty"`
-	Salary    string    `json:"salary,omitempty"`
-	Location  string    `json:"location,omitempty"`
-	CreatedAt string    `json:"created_at"`
-	UpdatedAt string    `json:"updated_at"`
+	ID       int64      `json:"id"`
+	Title    string     `json:"title"`
+	Company  string     `json:"company"`
+	URL      string     `json:"url"`
+	Status   JobStatus  `json:"status"`
+	Notes    string     `json:"notes,omitempty"`
+	Salary   string     `json:"salary,omitempty"`
+	Location string     `json:"location,omitempty"`
+	Deadline string     `json:"deadline,omitempty"`     // YYYY-MM-DD application deadline
+	FollowUp string     `json:"follow_up_at,omitempty"` // when to act on this job (RFC3339)
+	Gig      *Gig       `json:"gig,omitempty"`          // set for freelance gigs (kind=gig)
+	Events   []JobEvent `json:"events,omitempty"`       // interviews, calls and emails logged via job_tracker_update
+	// ResumeVariantID is the resume_variants variant sent with the application.
+	ResumeVariantID int64 `json:"resume_variant_id,omitempty"`
+	// ClosedByEmployer is when the posting monitor found the posting closed (RFC3339).
+	ClosedByEmployer string `json:"closed_by_employer,omitempty"`
+	// StatusPrompt asks to update the status of an active job whose posting closed.
+	StatusPrompt string `json:"status_prompt,omitempty"`
+	// Recruiter is the retained-search recruiter running the process.
+	Recruiter *Recruiter `json:"recruiter,omitempty"`
+	// Confidential marks a confidential search; job_export masks the employer, URL and notes.
+	Confidential bool   `json:"confidential,omitempty"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+// Recruiter is a retained-search (headhunter) contact for a tracked job.
+type Recruiter struct {
+	Name  string `json:"name,omitempty"`
+	Firm  string `json:"firm,omitempty"`
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
 }
 
 // JobTrackerAddInput is the input for job_tracker_add.
@@ -48,12 +71,32 @@ type JobTrackerAddInput struct {
 	Notes    string `json:"notes,omitempty"`
 	Salary   string `json:"salary,omitempty"`
 	Location string `json:"location,omitempty"`
+	Deadline string `json:"deadline,omitempty"` // YYYY-MM-DD or free text ("apply by March 15"); also extracted from notes
+
+	// Freelance mode: kind=gig tracks a freelance project with its rate.
+	Kind     string  `json:"kind,omitempty"`      // "job" (default) or "gig"
+	RateType string  `json:"rate_type,omitempty"` // gig: "hourly" (default) or "fixed"
+	Rate     float64 `json:"rate,omitempty"`      // gig: hourly rate or fixed project price
+	Currency string  `json:"currency,omitempty"`  // gig: rate currency (default USD)
+
+	ResumeVariantID int64 `json:"resume_variant_id,omitempty"` // resume_variants variant sent with the application
+
+	// Executive search: the retained recruiter and whether the search is confidential.
+	RecruiterName  string `json:"recruiter_name,omitempty"`
+	RecruiterFirm  string `json:"recruiter_firm,omitempty"`
+	RecruiterEmail string `json:"recruiter_email,omitempty"`
+	RecruiterPhone string `json:"recruiter_phone,omitempty"`
+	Confidential   bool   `json:"confidential,omitempty"` // company may be omitted; masked in job_export
+
+	IdempotencyKey string `json:"idempotency_key,omitempty"` // retries with the same key return the original result
 }
 
 // JobTrackerListInput is the input for job_tracker_list.
 type JobTrackerListInput struct {
 	Status string `json:"status,omitempty"`
+	Kind   string `json:"kind,omitempty"` // "job" or "gig"; empty lists both
 	Limit  int    `json:"limit,omitempty"`
+	SortBy string `json:"sort_by,omitempty"` // "updated" (default) or "follow_up" (soonest first)
 }
 
 // JobTrackerUpdateInput is the input for job_tracker_update.
@@ -61,6 +104,21 @@ type JobTrackerUpdateInput struct {
 	ID     int64  `json:"id"`
 	Status string `json:"status,omitempty"`
 	Notes  string `json:"notes,omitempty"`
+
+	// Event logging: records an interview, call, email or note on the job.
+	Event        string   `json:"event,omitempty"`        // "interview", "call", "email" or "note"
+	EventDate    string   `json:"event_date,omitempty"`   // YYYY-MM-DD, default today
+	Interviewers []string `json:"interviewers,omitempty"` // names of the people met
+	EventNotes   string   `json:"event_notes,omitempty"`  // what was discussed
+
+	ResumeVariantID int64 `json:"resume_variant_id,omitempty"` // resume_variants variant sent with the application
+
+	// Executive search: set or replace recruiter fields, or change confidentiality.
+	RecruiterName  string `json:"recruiter_name,omitempty"`
+	RecruiterFirm  string `json:"recruiter_firm,omitempty"`
+	RecruiterEmail string `json:"recruiter_email,omitempty"`
+	RecruiterPhone string `json:"recruiter_phone,omitempty"`
+	Confidential   *bool  `json:"confidential,omitempty"`
 }
 
 // JobTrackerResult is the output for add/update operations.
@@ -100,6 +158,58 @@ func openTrackerDB() (*sql.DB, error) {
 			trackerErr = fmt.Errorf("tracker: init schema: %w", err)
 			return
 		}
+		if err := runTrackerMigration(db, "canonical_job_urls", canonicalizeTrackedURLs); err != nil {
+			trackerErr = fmt.Errorf("tracker: migrate canonical_job_urls: %w", err)
+			return
+		}
+		if err := initSeenJobsSchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init seen_jobs schema: %w", err)
+			return
+		}
+		if err := initBackgroundJobsSchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init background_jobs schema: %w", err)
+			return
+		}
+		if err := initIdempotencySchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init idempotency_keys schema: %w", err)
+			return
+		}
+		if err := initGigMilestonesSchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init gig_milestones schema: %w", err)
+			return
+		}
+		if err := initCompaniesSchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init companies schema: %w", err)
+			return
+		}
+		if err := initMockInterviewSchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init mock_interviews schema: %w", err)
+			return
+		}
+		if err := initJobEventsSchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init job_events schema: %w", err)
+			return
+		}
+		if err := initRejectionRetrosSchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init rejection_retros schema: %w", err)
+			return
+		}
+		if err := initResumeVariantsSchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init resume_variants schema: %w", err)
+			return
+		}
+		if err := initJobSnapshotsSchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init job_snapshots schema: %w", err)
+			return
+		}
+		if err := initLocalSearchSchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init local_search schema: %w", err)
+			return
+		}
+		if err := initAuditLogSchema(db); err != nil {
+			trackerErr = fmt.Errorf("tracker: init audit_log schema: %w", err)
+			return
+		}
 		trackerDB = db
 	})
 	return trackerDB, trackerErr
@@ -119,10 +229,124 @@ func initTrackerSchema(db *sql.DB) error {
 		created_at TEXT NOT NULL,
 		updated_at TEXT NOT NULL
 	)`
-	_, err := db.Exec(schema) //nolint:noctx // schema init, no user context available
+	if _, err := db.Exec(schema); err != nil { //nolint:noctx // schema init, no user context available
+		return err
+	}
+	// Columns added after the initial schema.
+	for _, col := range []struct{ name, decl string }{
+		{"deadline", "TEXT"},
+		{"follow_up_at", "TEXT"},
+		{"kind", "TEXT NOT NULL DEFAULT 'job'"},
+		{"rate_type", "TEXT"},
+		{"rate", "REAL"},
+		{"currency", "TEXT"},
+		{"hours_logged", "REAL NOT NULL DEFAULT 0"},
+		{"hours_invoiced", "REAL NOT NULL DEFAULT 0"},
+		{"payment_status", "TEXT"},
+		{"resume_variant_id", "INTEGER"},
+		{"closed_by_employer", "TEXT"},
+		{"recruiter_name", "TEXT"},
+		{"recruiter_firm", "TEXT"},
+		{"recruiter_email", "TEXT"},
+		{"recruiter_phone", "TEXT"},
+		{"confidential", "INTEGER NOT NULL DEFAULT 0"},
+	} {
+		if err := addColumnIfMissing(db, "jobs", col.name, col.decl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumnIfMissing adds a column to an existing SQLite table (no-op if present).
+func addColumnIfMissing(db *sql.DB, table, column, decl string) error {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info(?)`, table) //nolint:noctx // schema init
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	_, err = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, decl)) //nolint:noctx,gosec // constant identifiers
 	return err
 }
 
+// runTrackerMigration applies a one-time data migration, recorded by name in
+// tracker_migrations so it runs once per database.
+func runTrackerMigration(db *sql.DB, name string, migrate func(tx *sql.Tx) error) error {
+	schema := `CREATE TABLE IF NOT EXISTS tracker_migrations (
+		name       TEXT PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`
+	if _, err := db.Exec(schema); err != nil { //nolint:noctx // schema init, no user context available
+		return err
+	}
+	tx, err := db.Begin() //nolint:noctx // schema init
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	res, err := tx.Exec(`INSERT OR IGNORE INTO tracker_migrations (name, applied_at) VALUES (?, ?)`, //nolint:noctx // schema init
+		name, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil // already applied
+	}
+	if err := migrate(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// canonicalizeTrackedURLs rewrites job URLs saved before job_tracker_add stored
+// engine.CanonicalJobURL, so duplicate checks match them.
+func canonicalizeTrackedURLs(tx *sql.Tx) error {
+	rows, err := tx.Query(`SELECT id, url FROM jobs WHERE url IS NOT NULL AND url != ''`) //nolint:noctx // schema init
+	if err != nil {
+		return err
+	}
+	updates := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var jobURL string
+		if err := rows.Scan(&id, &jobURL); err != nil {
+			rows.Close()
+			return err
+		}
+		if c := engine.CanonicalJobURL(jobURL); c != jobURL {
+			updates[id] = c
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for id, jobURL := range updates {
+		if _, err := tx.Exec(`UPDATE jobs SET url = ? WHERE id = ?`, jobURL, id); err != nil { //nolint:noctx // schema init
+			return err
+		}
+	}
+	return nil
+}
+
+// trackedJobColumns is the column list scanTrackedJobs expects.
+const trackedJobColumns = "id, title, company, url, status, notes, salary, location, deadline, follow_up_at, " +
+	"kind, rate_type, rate, currency, hours_logged, hours_invoiced, payment_status, resume_variant_id, closed_by_employer, " +
+	"recruiter_name, recruiter_firm, recruiter_email, recruiter_phone, confidential, created_at, updated_at"
+
 // validStatus checks if a status string is valid.
 func validStatus(s string) bool {
 	switch JobStatus(s) {
@@ -132,8 +356,27 @@ func validStatus(s string) bool {
 	return false
 }
 
-// AddTrackedJob saves a new job to the tracker.
-func AddTrackedJob(_ context.Context, input JobTrackerAddInput) (*JobTrackerResult, error) {
+// AddTrackedJob saves a new job to the tracker. With an idempotency key, retries
+// return the original result instead of adding a duplicate. A job with a URL gets
+// its posting archived in the background (see job_tracker_get).
+func AddTrackedJob(ctx context.Context, input JobTrackerAddInput) (*JobTrackerResult, error) {
+	result, err := Idempotent(ctx, "job_tracker_add", input.IdempotencyKey, input, func() (*JobTrackerResult, error) {
+		return addTrackedJob(input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if input.URL != "" && snapshotsEnabled(ctx) {
+		go archiveTrackedJob(ctx, result.ID, engine.CanonicalJobURL(input.URL))
+		result.Message += "; archiving a snapshot of the posting (job_tracker_get)"
+	}
+	return result, nil
+}
+
+func addTrackedJob(input JobTrackerAddInput) (*JobTrackerResult, error) {
+	if input.Company == "" && input.Confidential {
+		input.Company = confidentialEmployer
+	}
 	if input.Title == "" || input.Company == "" {
 		return nil, errors.New("job_tracker_add: title and company are required")
 	}
@@ -145,31 +388,81 @@ func AddTrackedJob(_ context.Context, input JobTrackerAddInput) (*JobTrackerResu
 	if !validStatus(status) {
 		return nil, fmt.Errorf("job_tracker_add: invalid status %q (valid: saved, applied, interview, offer, rejected)", status)
 	}
+	gig, err := newGigFields(input)
+	if err != nil {
+		return nil, err
+	}
 
 	db, err := openTrackerDB()
 	if err != nil {
 		return nil, err
 	}
+	var variant *int64
+	if input.ResumeVariantID != 0 {
+		if !resumeVariantExists(db, input.ResumeVariantID) {
+			return nil, fmt.Errorf("job_tracker_add: no resume variant with id %d", input.ResumeVariantID)
+		}
+		variant = &input.ResumeVariantID
+	}
 
-	now := time.Now().UTC().Format(time.RFC3339)
+	jobURL := engine.CanonicalJobURL(input.URL)
+	var existing int64
+	if jobURL != "" {
+		_ = db.QueryRow(`SELECT id FROM jobs WHERE url = ? ORDER BY id LIMIT 1`, jobURL).Scan(&existing) //nolint:noctx // SQLite file-based tracker
+	}
+
+	nowT := time.Now().UTC()
+	now := nowT.Format(time.RFC3339)
+	deadline, followUp := trackerDeadline(input.Deadline, input.Notes, nowT)
 	res, err := db.Exec( //nolint:noctx // SQLite file-based tracker, no context
-		`INSERT INTO jobs (title, company, url, status, notes, salary, location, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		input.Title, input.Company, input.URL, status,
-		input.Notes, input.Salary, input.Location, now, now,
+		`INSERT INTO jobs (title, company, url, status, notes, salary, location, deadline, follow_up_at,
+		                   kind, rate_type, rate, currency, payment_status, resume_variant_id,
+		                   recruiter_name, recruiter_firm, recruiter_email, recruiter_phone, confidential, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		input.Title, input.Company, jobURL, status,
+		input.Notes, input.Salary, input.Location, deadline, followUp,
+		gig.kind, gig.rateType, gig.rate, gig.currency, gig.paymentStatus, variant,
+		input.RecruiterName, input.RecruiterFirm, input.RecruiterEmail, input.RecruiterPhone, input.Confidential, now, now,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("job_tracker_add: insert: %w", err)
 	}
 
 	id, _ := res.LastInsertId()
-	return &JobTrackerResult{
-		ID:      id,
-		Message: fmt.Sprintf("Job '%s' at '%s' saved with status '%s' (id=%d)", input.Title, input.Company, status, id),
-	}, nil
+	msg := fmt.Sprintf("Job '%s' at '%s' saved with status '%s' (id=%d)", input.Title, input.Company, status, id)
+	if gig.kind == KindGig {
+		msg = fmt.Sprintf("Gig '%s' for '%s' saved with status '%s', %s rate %.2f %s (id=%d)",
+			input.Title, input.Company, status, *gig.rateType, *gig.rate, *gig.currency, id)
+	}
+	if deadline != nil {
+		msg += fmt.Sprintf("; deadline %s, follow up at %s", *deadline, *followUp)
+	}
+	if input.Confidential {
+		msg += "; marked confidential (masked in job_export)"
+	}
+	if existing > 0 {
+		msg += fmt.Sprintf("; note: the same posting is already tracked as id=%d", existing)
+	}
+	return &JobTrackerResult{ID: id, Message: msg}, nil
 }
 
-// ListTrackedJobs returns tracked jobs, optionally filtered by status.
+// trackerDeadline resolves the deadline for a tracked job from the explicit field or the notes,
+// and schedules a follow-up before it. Returns nils when no deadline is found.
+func trackerDeadline(explicit, notes string, now time.Time) (deadline, followUp *string) {
+	d, err := time.Parse(time.DateOnly, strings.TrimSpace(explicit))
+	if err != nil {
+		dl, ok := ExtractDeadline("deadline "+explicit+"\n"+notes, now)
+		if !ok {
+			return nil, nil
+		}
+		d = dl.Date
+	}
+	ds := d.Format(time.DateOnly)
+	fs := FollowUpForDeadline(d, now).Format(time.RFC3339)
+	return &ds, &fs
+}
+
+// ListTrackedJobs returns tracked jobs, optionally filtered by status and kind.
 func ListTrackedJobs(_ context.Context, input JobTrackerListInput) (*JobTrackerListResult, error) {
 	db, err := openTrackerDB()
 	if err != nil {
@@ -181,65 +474,111 @@ func ListTrackedJobs(_ context.Context, input JobTrackerListInput) (*JobTrackerL
 		limit = 50
 	}
 
-	var rows *sql.Rows
+	order := "updated_at DESC"
+	if input.SortBy == "follow_up" {
+		order = "follow_up_at IS NULL, follow_up_at ASC, updated_at DESC"
+	}
+
+	var where []string
+	var args []any
 	if input.Status != "" {
 		status := strings.ToLower(input.Status)
 		if !validStatus(status) {
 			return nil, fmt.Errorf("job_tracker_list: invalid status %q", status)
 		}
-		rows, err = db.Query( //nolint:noctx // SQLite file-based tracker, no context
-			`SELECT id, title, company, url, status, notes, salary, location, created_at, updated_at
-			 FROM jobs WHERE status = ? ORDER BY updated_at DESC LIMIT ?`,
-			status, limit,
-		)
-	} else {
-		rows, err = db.Query( //nolint:noctx // SQLite file-based tracker, no context
-			`SELECT id, title, company, url, status, notes, salary, location, created_at, updated_at
-			 FROM jobs ORDER BY updated_at DESC LIMIT ?`,
-			limit,
-		)
+		where = append(where, "status = ?")
+		args = append(args, status)
+	}
+	if input.Kind != "" {
+		kind := strings.ToLower(input.Kind)
+		if kind != KindJob && kind != KindGig {
+			return nil, fmt.Errorf("job_tracker_list: invalid kind %q (valid: job, gig)", kind)
+		}
+		where = append(where, "kind = ?")
+		args = append(args, kind)
 	}
+	filter := ""
+	if len(where) > 0 {
+		filter = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	rows, err := db.Query( //nolint:noctx,gosec // SQLite file-based tracker, filter and order are constants
+		`SELECT `+trackedJobColumns+`
+		 FROM jobs`+filter+` ORDER BY `+order+` LIMIT ?`,
+		append(args, limit)...,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("job_tracker_list: query: %w", err)
 	}
 	defer rows.Close()
 
+	jobs := scanTrackedJobs(rows)
+	loadGigMilestones(db, jobs)
+	loadJobEvents(db, jobs)
+
+	// Count total matching rows
+	var total int
+	db.QueryRow(`SELECT COUNT(*) FROM jobs`+filter, args...).Scan(&total) //nolint:errcheck,noctx,gosec // filter is a constant
+
+	if jobs == nil {
+		jobs = []TrackedJob{}
+	}
+	return &JobTrackerListResult{Jobs: jobs, Total: total}, nil
+}
+
+// scanTrackedJobs reads rows selected with the trackedJobColumns column list.
+func scanTrackedJobs(rows *sql.Rows) []TrackedJob {
 	var jobs []TrackedJob
 	for rows.Next() {
 		var j TrackedJob
-		var notes, salary, location, url sql.NullString
+		var notes, salary, location, url, deadline, followUp, closed sql.NullString
+		var recName, recFirm, recEmail, recPhone sql.NullString
+		var kind, rateType, currency, payment sql.NullString
+		var rate sql.NullFloat64
+		var hoursLogged, hoursInvoiced float64
+		var variant sql.NullInt64
 		if err := rows.Scan(&j.ID, &j.Title, &j.Company, &url, &j.Status,
-			&notes, &salary, &location, &j.CreatedAt, &j.UpdatedAt); err != nil {
+			&notes, &salary, &location, &deadline, &followUp,
+			&kind, &rateType, &rate, &currency, &hoursLogged, &hoursInvoiced, &payment, &variant, &closed,
+			&recName, &recFirm, &recEmail, &recPhone, &j.Confidential, &j.CreatedAt, &j.UpdatedAt); err != nil {
 			continue
 		}
+		if kind.String == KindGig {
+			j.Gig = &Gig{
+				RateType:      rateType.String,
+				Rate:          rate.Float64,
+				Currency:      currency.String,
+				HoursLogged:   hoursLogged,
+				HoursUnbilled: hoursLogged - hoursInvoiced,
+				PaymentStatus: payment.String,
+			}
+		}
+		j.Deadline = deadline.String
+		j.FollowUp = followUp.String
 		j.URL = url.String
 		j.Notes = notes.String
 		j.Salary = salary.String
 		j.Location = location.String
+		j.ResumeVariantID = variant.Int64
+		j.ClosedByEmployer = closed.String
+		j.StatusPrompt = closedStatusPrompt(j)
+		if r := (Recruiter{Name: recName.String, Firm: recFirm.String, Email: recEmail.String, Phone: recPhone.String}); r != (Recruiter{}) {
+			j.Recruiter = &r
+		}
 		jobs = append(jobs, j)
 	}
-
-	// Count total matching rows
-	var total int
-	if input.Status != "" {
-		db.QueryRow(`SELECT COUNT(*) FROM jobs WHERE status = ?`, strings.ToLower(input.Status)).Scan(&total) //nolint:errcheck,noctx
-	} else {
-		db.QueryRow(`SELECT COUNT(*) FROM jobs`).Scan(&total) //nolint:errcheck,noctx
-	}
-
-	if jobs == nil {
-		jobs = []TrackedJob{}
-	}
-	return &JobTrackerListResult{Jobs: jobs, Total: total}, nil
+	return jobs
 }
 
-// UpdateTrackedJob updates the status and/or notes of a tracked job.
+// UpdateTrackedJob updates the status and/or notes of a tracked job, and logs an
+// event when one is given.
 func UpdateTrackedJob(_ context.Context, input JobTrackerUpdateInput) (*JobTrackerResult, error) {
 	if input.ID <= 0 {
 		return nil, errors.New("job_tracker_update: id is required")
 	}
-	if input.Status == "" && input.Notes == "" {
-		return nil, errors.New("job_tracker_update: at least one of status or notes must be provided")
+	recruiter := recruiterUpdates(input)
+	if input.Status == "" && input.Notes == "" && input.Event == "" && input.ResumeVariantID == 0 && len(recruiter) == 0 && input.Confidential == nil {
+		return nil, errors.New("job_tracker_update: at least one of status, notes, event, resume_variant_id, recruiter fields or confidential must be provided")
 	}
 
 	db, err := openTrackerDB()
@@ -248,6 +587,9 @@ func UpdateTrackedJob(_ context.Context, input JobTrackerUpdateInput) (*JobTrack
 	}
 
 	now := time.Now().UTC().Format(time.RFC3339)
+	if input.ResumeVariantID != 0 && !resumeVariantExists(db, input.ResumeVariantID) {
+		return nil, fmt.Errorf("job_tracker_update: no resume variant with id %d", input.ResumeVariantID)
+	}
 
 	switch {
 	case input.Status != "" && input.Notes != "":
@@ -264,17 +606,65 @@ func UpdateTrackedJob(_ context.Context, input JobTrackerUpdateInput) (*JobTrack
 		}
 		_, err = db.Exec(`UPDATE jobs SET status=?, updated_at=? WHERE id=?`, //nolint:noctx // SQLite file-based tracker
 			status, now, input.ID)
-	default:
+	case input.Notes != "":
 		_, err = db.Exec(`UPDATE jobs SET notes=?, updated_at=? WHERE id=?`, //nolint:noctx // SQLite file-based tracker
 			input.Notes, now, input.ID)
+	default:
+		var res sql.Result
+		res, err = db.Exec(`UPDATE jobs SET updated_at=? WHERE id=?`, now, input.ID) //nolint:noctx // SQLite file-based tracker
+		if err == nil {
+			if n, _ := res.RowsAffected(); n == 0 {
+				return nil, fmt.Errorf("job_tracker_update: no tracked entry with id %d", input.ID)
+			}
+		}
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("job_tracker_update: %w", err)
 	}
+	if input.Event != "" {
+		if err := addJobEvent(db, input, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	if input.ResumeVariantID != 0 {
+		if _, err := db.Exec(`UPDATE jobs SET resume_variant_id=? WHERE id=?`, input.ResumeVariantID, input.ID); err != nil { //nolint:noctx // SQLite file-based tracker
+			return nil, fmt.Errorf("job_tracker_update: %w", err)
+		}
+	}
+	for col, val := range recruiter {
+		if _, err := db.Exec(`UPDATE jobs SET `+col+`=? WHERE id=?`, val, input.ID); err != nil { //nolint:noctx,gosec // SQLite file-based tracker, col is a constant
+			return nil, fmt.Errorf("job_tracker_update: %w", err)
+		}
+	}
+	if input.Confidential != nil {
+		if _, err := db.Exec(`UPDATE jobs SET confidential=? WHERE id=?`, *input.Confidential, input.ID); err != nil { //nolint:noctx // SQLite file-based tracker
+			return nil, fmt.Errorf("job_tracker_update: %w", err)
+		}
+	}
 
+	msg := fmt.Sprintf("Job #%d updated successfully", input.ID)
+	if strings.EqualFold(input.Status, string(StatusRejected)) {
+		msg += " — run rejection_retro to learn from the rejection"
+	}
 	return &JobTrackerResult{
 		ID:      input.ID,
-		Message: fmt.Sprintf("Job #%d updated successfully", input.ID),
+		Message: msg,
 	}, nil
 }
+
+// recruiterUpdates maps the recruiter fields set in a job_tracker_update to their columns.
+func recruiterUpdates(input JobTrackerUpdateInput) map[string]string {
+	updates := make(map[string]string)
+	for col, val := range map[string]string{
+		"recruiter_name":  input.RecruiterName,
+		"recruiter_firm":  input.RecruiterFirm,
+		"recruiter_email": input.RecruiterEmail,
+		"recruiter_phone": input.RecruiterPhone,
+	} {
+		if val != "" {
+			updates[col] = val
+		}
+	}
+	return updates
+}
@@ -0,0 +1,137 @@
+package jobs
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// --- Tracker events ---
+
+// Events record what happened on a tracked application — interviews, calls, emails —
+// with the people involved and what was discussed. They are logged through
+// job_tracker_update and feed followup_email_generate.
+
+// Event kinds.
+const (
+	EventInterview = "interview"
+	EventCall      = "call"
+	EventEmail     = "email"
+	EventNote      = "note"
+
+	// EventPostingChange is logged by the posting monitor, not by users.
+	EventPostingChange = "posting_change"
+)
+
+// JobEvent is one logged event of a tracked job.
+type JobEvent struct {
+	ID           int64    `json:"id"`
+	Kind         string   `json:"kind"`
+	Date         string   `json:"date"` // YYYY-MM-DD
+	Interviewers []string `json:"interviewers,omitempty"`
+	Notes        string   `json:"notes,omitempty"`
+}
+
+// initJobEventsSchema creates the job_events table in the tracker database.
+func initJobEventsSchema(db *sql.DB) error {
+	schema := `CREATE TABLE IF NOT EXISTS job_events (
+		id           INTEGER PRIMARY KEY AUTOINCREMENT,
+		job_id       INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+		kind         TEXT NOT NULL,
+		date         TEXT NOT NULL,
+		interviewers TEXT,
+		notes        TEXT,
+		created_at   TEXT NOT NULL
+	)`
+	_, err := db.Exec(schema) //nolint:noctx // schema init, no user context available
+	return err
+}
+
+func validEventKind(s string) bool {
+	return s == EventInterview || s == EventCall || s == EventEmail || s == EventNote
+}
+
+// addJobEvent logs the event described by a job_tracker_update input. The date
+// defaults to today.
+func addJobEvent(db *sql.DB, input JobTrackerUpdateInput, now time.Time) error {
+	kind := strings.ToLower(strings.TrimSpace(input.Event))
+	if !validEventKind(kind) {
+		return fmt.Errorf("job_tracker_update: invalid event %q (valid: interview, call, email, note)", input.Event)
+	}
+	date := strings.TrimSpace(input.EventDate)
+	if date == "" {
+		date = now.Format(time.DateOnly)
+	} else if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return fmt.Errorf("job_tracker_update: event_date %q must be YYYY-MM-DD", input.EventDate)
+	}
+	var interviewers *string
+	if names := cleanNames(input.Interviewers); len(names) > 0 {
+		raw, _ := json.Marshal(names)
+		s := string(raw)
+		interviewers = &s
+	}
+	_, err := db.Exec( //nolint:noctx // SQLite file-based tracker
+		`INSERT INTO job_events (job_id, kind, date, interviewers, notes, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		input.ID, kind, date, interviewers, input.EventNotes, now.UTC().Format(time.RFC3339))
+	return err
+}
+
+// cleanNames trims names and drops empty ones and duplicates.
+func cleanNames(names []string) []string {
+	var out []string
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n != "" && !containsString(out, n) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// loadJobEvents attaches the logged events to each job.
+func loadJobEvents(db *sql.DB, jobs []TrackedJob) {
+	for i := range jobs {
+		jobs[i].Events = jobEvents(db, jobs[i].ID)
+	}
+}
+
+// jobEvents returns the events of a job, oldest first.
+func jobEvents(db *sql.DB, jobID int64) []JobEvent {
+	rows, err := db.Query( //nolint:noctx // SQLite file-based tracker
+		`SELECT id, kind, date, interviewers, notes FROM job_events WHERE job_id = ? ORDER BY date, id`, jobID)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	var out []JobEvent
+	for rows.Next() {
+		var e JobEvent
+		var interviewers, notes sql.NullString
+		if err := rows.Scan(&e.ID, &e.Kind, &e.Date, &interviewers, &notes); err != nil {
+			continue
+		}
+		if interviewers.String != "" {
+			_ = json.Unmarshal([]byte(interviewers.String), &e.Interviewers)
+		}
+		e.Notes = notes.String
+		out = append(out, e)
+	}
+	return out
+}
+
+// getTrackedJob loads one tracked entry with its events.
+func getTrackedJob(db *sql.DB, tool string, id int64) (*TrackedJob, error) {
+	rows, err := db.Query(`SELECT `+trackedJobColumns+` FROM jobs WHERE id = ?`, id) //nolint:noctx,gosec // SQLite file-based tracker
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", tool, err)
+	}
+	jobs := scanTrackedJobs(rows)
+	rows.Close()
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("%s: no tracked entry with id %d", tool, id)
+	}
+	jobs[0].Events = jobEvents(db, id)
+	return &jobs[0], nil
+}
@@ -0,0 +1,413 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Freelance mode: tracker entries with kind=gig carry an hourly or fixed rate, logged
+// hours, milestones and a payment status, and can be exported as invoice data. They
+// share the jobs table and statuses (saved → applied → interview → offer) with jobs.
+
+// Tracker entry kinds.
+const (
+	KindJob = "job"
+	KindGig = "gig"
+)
+
+// Gig rate types.
+const (
+	RateHourly = "hourly"
+	RateFixed  = "fixed"
+)
+
+// Payment statuses of a gig.
+const (
+	PaymentUnpaid   = "unpaid"
+	PaymentInvoiced = "invoiced"
+	PaymentPaid     = "paid"
+)
+
+// Milestone statuses. Milestones start pending and become done before they can be
+// invoiced.
+const (
+	MilestonePending  = "pending"
+	MilestoneDone     = "done"
+	MilestoneInvoiced = "invoiced"
+	MilestonePaid     = "paid"
+)
+
+// Gig holds the freelance fields of a tracked gig.
+type Gig struct {
+	RateType      string         `json:"rate_type"`
+	Rate          float64        `json:"rate"`
+	Currency      string         `json:"currency"`
+	HoursLogged   float64        `json:"hours_logged"`
+	HoursUnbilled float64        `json:"hours_unbilled"` // logged but not yet invoiced
+	PaymentStatus string         `json:"payment_status"`
+	Milestones    []GigMilestone `json:"milestones,omitempty"`
+}
+
+// GigMilestone is a deliverable of a gig with its price.
+type GigMilestone struct {
+	ID     int64   `json:"id"`
+	Title  string  `json:"title"`
+	Amount float64 `json:"amount,omitempty"`
+	Due    string  `json:"due,omitempty"` // YYYY-MM-DD
+	Status string  `json:"status"`        // pending, done, invoiced or paid
+}
+
+// GigUpdateInput is the input for gig_tracker_update.
+type GigUpdateInput struct {
+	ID              int64   `json:"id" jsonschema:"Tracker ID of the gig (from job_tracker_list with kind=gig)"`
+	LogHours        float64 `json:"log_hours,omitempty" jsonschema:"Hours worked to add to the gig"`
+	PaymentStatus   string  `json:"payment_status,omitempty" jsonschema:"Set the gig payment status: unpaid, invoiced or paid (paid also marks invoiced milestones paid)"`
+	AddMilestone    string  `json:"add_milestone,omitempty" jsonschema:"Title of a milestone to add"`
+	MilestoneAmount float64 `json:"milestone_amount,omitempty" jsonschema:"Price of the added milestone, in the gig currency"`
+	MilestoneDue    string  `json:"milestone_due,omitempty" jsonschema:"Due date of the added milestone (YYYY-MM-DD)"`
+	MilestoneID     int64   `json:"milestone_id,omitempty" jsonschema:"ID of an existing milestone to update"`
+	MilestoneStatus string  `json:"milestone_status,omitempty" jsonschema:"New status of milestone_id: pending, done, invoiced or paid"`
+}
+
+// GigInvoiceInput is the input for gig_invoice.
+type GigInvoiceInput struct {
+	ID           int64  `json:"id" jsonschema:"Tracker ID of the gig"`
+	Number       string `json:"number,omitempty" jsonschema:"Invoice number (default: INV-<id>-<date>)"`
+	MarkInvoiced bool   `json:"mark_invoiced,omitempty" jsonschema:"Mark the billed hours and milestones as invoiced, so the next invoice only bills new work"`
+}
+
+// GigInvoice is invoice-ready data for unbilled work on a gig.
+type GigInvoice struct {
+	Number   string        `json:"number"`
+	Date     string        `json:"date"`
+	Client   string        `json:"client"`
+	Project  string        `json:"project"`
+	Currency string        `json:"currency"`
+	Items    []InvoiceItem `json:"items"`
+	Total    float64       `json:"total"`
+	CSV      string        `json:"csv"`
+	Message  string        `json:"message"`
+}
+
+// InvoiceItem is one invoice line.
+type InvoiceItem struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+}
+
+// initGigMilestonesSchema creates the gig_milestones table in the tracker database.
+func initGigMilestonesSchema(db *sql.DB) error {
+	schema := `CREATE TABLE IF NOT EXISTS gig_milestones (
+		id         INTEGER PRIMARY KEY AUTOINCREMENT,
+		job_id     INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+		title      TEXT NOT NULL,
+		amount     REAL NOT NULL DEFAULT 0,
+		due        TEXT,
+		status     TEXT NOT NULL DEFAULT 'pending',
+		created_at TEXT NOT NULL
+	)`
+	_, err := db.Exec(schema) //nolint:noctx // schema init, no user context available
+	return err
+}
+
+// gigFields are the gig columns of a new tracker row; all but kind are NULL for jobs.
+type gigFields struct {
+	kind          string
+	rateType      *string
+	rate          *float64
+	currency      *string
+	paymentStatus *string
+}
+
+// newGigFields validates the freelance fields of job_tracker_add.
+func newGigFields(input JobTrackerAddInput) (gigFields, error) {
+	kind := strings.ToLower(input.Kind)
+	switch kind {
+	case "", KindJob:
+		return gigFields{kind: KindJob}, nil
+	case KindGig:
+	default:
+		return gigFields{}, fmt.Errorf("job_tracker_add: invalid kind %q (valid: job, gig)", input.Kind)
+	}
+	rateType := strings.ToLower(input.RateType)
+	if rateType == "" {
+		rateType = RateHourly
+	}
+	if rateType != RateHourly && rateType != RateFixed {
+		return gigFields{}, fmt.Errorf("job_tracker_add: invalid rate_type %q (valid: hourly, fixed)", input.RateType)
+	}
+	if input.Rate < 0 {
+		return gigFields{}, errors.New("job_tracker_add: rate must not be negative")
+	}
+	currency := strings.ToUpper(input.Currency)
+	if currency == "" {
+		currency = "USD"
+	}
+	rate, payment := input.Rate, PaymentUnpaid
+	return gigFields{kind: KindGig, rateType: &rateType, rate: &rate, currency: &currency, paymentStatus: &payment}, nil
+}
+
+// loadGigMilestones attaches milestones to the gigs among jobs.
+func loadGigMilestones(db *sql.DB, jobs []TrackedJob) {
+	for i := range jobs {
+		if jobs[i].Gig != nil {
+			jobs[i].Gig.Milestones = gigMilestones(db, jobs[i].ID)
+		}
+	}
+}
+
+// gigMilestones returns the milestones of a gig in creation order.
+func gigMilestones(db *sql.DB, jobID int64) []GigMilestone {
+	rows, err := db.Query( //nolint:noctx // SQLite file-based tracker
+		`SELECT id, title, amount, due, status FROM gig_milestones WHERE job_id = ? ORDER BY id`, jobID)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	var out []GigMilestone
+	for rows.Next() {
+		var m GigMilestone
+		var due sql.NullString
+		if err := rows.Scan(&m.ID, &m.Title, &m.Amount, &due, &m.Status); err != nil {
+			continue
+		}
+		m.Due = due.String
+		out = append(out, m)
+	}
+	return out
+}
+
+// getGig loads one tracked gig with its milestones.
+func getGig(db *sql.DB, tool string, id int64) (*TrackedJob, error) {
+	rows, err := db.Query(`SELECT `+trackedJobColumns+` FROM jobs WHERE id = ?`, id) //nolint:noctx,gosec // SQLite file-based tracker
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", tool, err)
+	}
+	jobs := scanTrackedJobs(rows)
+	rows.Close()
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("%s: no tracked entry with id %d", tool, id)
+	}
+	if jobs[0].Gig == nil {
+		return nil, fmt.Errorf("%s: entry %d is a job, not a gig (add it with kind=gig)", tool, id)
+	}
+	jobs[0].Gig.Milestones = gigMilestones(db, id)
+	return &jobs[0], nil
+}
+
+func validPaymentStatus(s string) bool {
+	return s == PaymentUnpaid || s == PaymentInvoiced || s == PaymentPaid
+}
+
+func validMilestoneStatus(s string) bool {
+	return s == MilestonePending || s == MilestoneDone || s == MilestoneInvoiced || s == MilestonePaid
+}
+
+// UpdateGig logs hours, adds or updates milestones and sets the payment status of a gig.
+func UpdateGig(_ context.Context, input GigUpdateInput) (*JobTrackerResult, error) {
+	if input.ID <= 0 {
+		return nil, errors.New("gig_tracker_update: id is required")
+	}
+	if input.LogHours == 0 && input.PaymentStatus == "" && input.AddMilestone == "" && input.MilestoneID == 0 {
+		return nil, errors.New("gig_tracker_update: provide log_hours, payment_status, add_milestone or milestone_id")
+	}
+	if input.LogHours < 0 {
+		return nil, errors.New("gig_tracker_update: log_hours must be positive")
+	}
+	payment := strings.ToLower(input.PaymentStatus)
+	if payment != "" && !validPaymentStatus(payment) {
+		return nil, fmt.Errorf("gig_tracker_update: invalid payment_status %q (valid: unpaid, invoiced, paid)", input.PaymentStatus)
+	}
+	milestoneStatus := strings.ToLower(input.MilestoneStatus)
+	if input.MilestoneID != 0 && !validMilestoneStatus(milestoneStatus) {
+		return nil, fmt.Errorf("gig_tracker_update: invalid milestone_status %q (valid: pending, done, invoiced, paid)", input.MilestoneStatus)
+	}
+	if input.MilestoneDue != "" {
+		if _, err := time.Parse(time.DateOnly, input.MilestoneDue); err != nil {
+			return nil, fmt.Errorf("gig_tracker_update: milestone_due must be YYYY-MM-DD, got %q", input.MilestoneDue)
+		}
+	}
+
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	gig, err := getGig(db, "gig_tracker_update", input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	var done []string
+	if input.LogHours > 0 {
+		if _, err := db.Exec(`UPDATE jobs SET hours_logged = hours_logged + ? WHERE id = ?`, input.LogHours, input.ID); err != nil { //nolint:noctx // SQLite file-based tracker
+			return nil, fmt.Errorf("gig_tracker_update: log hours: %w", err)
+		}
+		done = append(done, fmt.Sprintf("logged %g h (%g h total)", input.LogHours, gig.Gig.HoursLogged+input.LogHours))
+	}
+	if input.AddMilestone != "" {
+		var due *string
+		if input.MilestoneDue != "" {
+			due = &input.MilestoneDue
+		}
+		res, err := db.Exec( //nolint:noctx // SQLite file-based tracker
+			`INSERT INTO gig_milestones (job_id, title, amount, due, status, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+			input.ID, input.AddMilestone, input.MilestoneAmount, due, MilestonePending, now)
+		if err != nil {
+			return nil, fmt.Errorf("gig_tracker_update: add milestone: %w", err)
+		}
+		mid, _ := res.LastInsertId()
+		done = append(done, fmt.Sprintf("added milestone '%s' (milestone_id=%d)", input.AddMilestone, mid))
+	}
+	if input.MilestoneID != 0 {
+		res, err := db.Exec(`UPDATE gig_milestones SET status = ? WHERE id = ? AND job_id = ?`, //nolint:noctx // SQLite file-based tracker
+			milestoneStatus, input.MilestoneID, input.ID)
+		if err != nil {
+			return nil, fmt.Errorf("gig_tracker_update: update milestone: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil, fmt.Errorf("gig_tracker_update: gig %d has no milestone %d", input.ID, input.MilestoneID)
+		}
+		done = append(done, fmt.Sprintf("milestone %d is %s", input.MilestoneID, milestoneStatus))
+	}
+	if payment != "" {
+		if _, err := db.Exec(`UPDATE jobs SET payment_status = ? WHERE id = ?`, payment, input.ID); err != nil { //nolint:noctx // SQLite file-based tracker
+			return nil, fmt.Errorf("gig_tracker_update: payment status: %w", err)
+		}
+		if payment == PaymentPaid {
+			db.Exec(`UPDATE gig_milestones SET status = ? WHERE job_id = ? AND status = ?`, MilestonePaid, input.ID, MilestoneInvoiced) //nolint:errcheck,noctx
+		}
+		done = append(done, "payment "+payment)
+	}
+	db.Exec(`UPDATE jobs SET updated_at = ? WHERE id = ?`, now, input.ID) //nolint:errcheck,noctx
+
+	return &JobTrackerResult{
+		ID:      input.ID,
+		Message: fmt.Sprintf("Gig #%d: %s", input.ID, strings.Join(done, "; ")),
+	}, nil
+}
+
+// gigInvoiceItems lists the unbilled work of a gig: unbilled hours for hourly gigs,
+// done milestones, and for fixed-price gigs without milestones the whole price
+// until it is invoiced.
+func gigInvoiceItems(job *TrackedJob) []InvoiceItem {
+	g := job.Gig
+	var items []InvoiceItem
+	if g.RateType == RateHourly && g.HoursUnbilled > 0 {
+		items = append(items, InvoiceItem{
+			Description: job.Title + " — hours",
+			Quantity:    g.HoursUnbilled,
+			UnitPrice:   g.Rate,
+			Amount:      roundCents(g.HoursUnbilled * g.Rate),
+		})
+	}
+	for _, m := range g.Milestones {
+		if m.Status == MilestoneDone {
+			items = append(items, InvoiceItem{Description: m.Title, Quantity: 1, UnitPrice: m.Amount, Amount: roundCents(m.Amount)})
+		}
+	}
+	if g.RateType == RateFixed && len(g.Milestones) == 0 && g.PaymentStatus == PaymentUnpaid && g.Rate > 0 {
+		items = append(items, InvoiceItem{Description: job.Title, Quantity: 1, UnitPrice: g.Rate, Amount: roundCents(g.Rate)})
+	}
+	return items
+}
+
+// BuildGigInvoice returns invoice data for the unbilled work on a gig and, with
+// MarkInvoiced, records it as invoiced. A gig already paid keeps its payment status.
+func BuildGigInvoice(ctx context.Context, input GigInvoiceInput) (*GigInvoice, error) {
+	if input.ID <= 0 {
+		return nil, errors.New("gig_invoice: id is required")
+	}
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	job, err := getGig(db, "gig_invoice", input.ID)
+	if err != nil {
+		return nil, err
+	}
+	items := gigInvoiceItems(job)
+	if len(items) == 0 {
+		return nil, fmt.Errorf("gig_invoice: gig %d has no unbilled hours or done milestones", input.ID)
+	}
+
+	now := time.Now().UTC()
+	inv := &GigInvoice{
+		Number:   input.Number,
+		Date:     now.Format(time.DateOnly),
+		Client:   job.Company,
+		Project:  job.Title,
+		Currency: job.Gig.Currency,
+		Items:    items,
+	}
+	if inv.Number == "" {
+		inv.Number = fmt.Sprintf("INV-%d-%s", job.ID, now.Format("20060102"))
+	}
+	for _, it := range items {
+		inv.Total += it.Amount
+	}
+	inv.Total = roundCents(inv.Total)
+	inv.CSV = invoiceCSV(inv)
+	inv.Message = fmt.Sprintf("Invoice %s for %s: %d item(s), total %.2f %s", inv.Number, inv.Client, len(items), inv.Total, inv.Currency)
+
+	if input.MarkInvoiced {
+		if err := markGigInvoiced(ctx, db, job.ID, now.Format(time.RFC3339)); err != nil {
+			return nil, err
+		}
+		inv.Message += "; marked as invoiced"
+	}
+	return inv, nil
+}
+
+// markGigInvoiced records the unbilled hours and done milestones of a gig as
+// invoiced in one transaction, so a failure cannot bill them twice.
+func markGigInvoiced(ctx context.Context, db *sql.DB, id int64, stamp string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gig_invoice: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	if _, err := tx.ExecContext(ctx, `UPDATE jobs SET hours_invoiced = hours_logged,
+		payment_status = CASE WHEN payment_status = ? THEN payment_status ELSE ? END, updated_at = ? WHERE id = ?`,
+		PaymentPaid, PaymentInvoiced, stamp, id); err != nil {
+		return fmt.Errorf("gig_invoice: mark invoiced: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE gig_milestones SET status = ? WHERE job_id = ? AND status = ?`,
+		MilestoneInvoiced, id, MilestoneDone); err != nil {
+		return fmt.Errorf("gig_invoice: mark milestones invoiced: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gig_invoice: %w", err)
+	}
+	return nil
+}
+
+// invoiceCSV renders invoice lines as CSV with a total row.
+func invoiceCSV(inv *GigInvoice) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	money := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	_ = w.Write([]string{"Invoice", "Date", "Client", "Description", "Quantity", "Unit price", "Amount", "Currency"})
+	for _, it := range inv.Items {
+		_ = w.Write([]string{inv.Number, inv.Date, inv.Client, it.Description,
+			strconv.FormatFloat(it.Quantity, 'f', -1, 64), money(it.UnitPrice), money(it.Amount), inv.Currency})
+	}
+	_ = w.Write([]string{inv.Number, inv.Date, inv.Client, "Total", "", "", money(inv.Total), inv.Currency})
+	w.Flush()
+	return buf.String()
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,307 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// JobTrackerImportInput is the input for job_tracker_import.
+type JobTrackerImportInput struct {
+	CSV       string            `json:"csv" jsonschema:"CSV text with a header row"`
+	Columns   map[string]string `json:"columns,omitempty" jsonschema:"Tracker field → CSV header, e.g. {\"title\":\"Position\",\"created_at\":\"Date Applied\"}. Fields: title, company, url, status, notes, salary, location, deadline, created_at. Unmapped fields are matched by common header names."`
+	StatusMap map[string]string `json:"status_map,omitempty" jsonschema:"Spreadsheet status → tracker status, e.g. {\"Phone screen\":\"interview\"}; common synonyms are recognized without it"`
+	DryRun    bool              `json:"dry_run,omitempty" jsonschema:"Validate and report without writing to the tracker"`
+}
+
+// JobTrackerImportIssue describes a CSV row that was not imported.
+type JobTrackerImportIssue struct {
+	Row    int    `json:"row"` // line number in the CSV, header = 1
+	Reason string `json:"reason"`
+}
+
+// JobTrackerImportResult is the output of job_tracker_import.
+type JobTrackerImportResult struct {
+	Imported   int                     `json:"imported"`
+	Duplicates int                     `json:"duplicates"`
+	Columns    map[string]string       `json:"columns"` // resolved field → header mapping
+	Skipped    []JobTrackerImportIssue `json:"skipped,omitempty"`
+	IDs        []int64                 `json:"ids,omitempty"`
+	DryRun     bool                    `json:"dry_run,omitempty"`
+}
+
+// importFieldAliases are the header names recognized for each tracker field, lowercase.
+var importFieldAliases = map[string][]string{
+	"title":      {"title", "job title", "position", "role", "job"},
+	"company":    {"company", "company name", "employer", "organization"},
+	"url":        {"url", "link", "job url", "job link", "posting"},
+	"status":     {"status", "stage", "application status"},
+	"notes":      {"notes", "note", "comments"},
+	"salary":     {"salary", "compensation", "pay"},
+	"location":   {"location", "city"},
+	"deadline":   {"deadline", "apply by", "closing date"},
+	"created_at": {"date applied", "applied", "applied on", "date", "created", "date added"},
+}
+
+// importFields is the order in which fields are resolved and reported.
+var importFields = []string{"title", "company", "url", "status", "notes", "salary", "location", "deadline", "created_at"}
+
+// importStatusSynonyms maps common spreadsheet statuses onto tracker statuses.
+var importStatusSynonyms = map[string]JobStatus{
+	"wishlist":     StatusSaved,
+	"bookmarked":   StatusSaved,
+	"interested":   StatusSaved,
+	"to apply":     StatusSaved,
+	"submitted":    StatusApplied,
+	"sent":         StatusApplied,
+	"screening":    StatusInterview,
+	"phone screen": StatusInterview,
+	"interviewing": StatusInterview,
+	"onsite":       StatusInterview,
+	"offered":      StatusOffer,
+	"declined":     StatusRejected,
+	"no response":  StatusRejected,
+	"ghosted":      StatusRejected,
+}
+
+// importDateLayouts are the date formats accepted for created_at and deadline.
+var importDateLayouts = []string{time.RFC3339, time.DateOnly, "01/02/2006", "1/2/2006", "02.01.2006", "Jan 2, 2006", "2 Jan 2006"}
+
+// ImportTrackedJobs adds rows from a CSV spreadsheet to the tracker. Rows with an
+// unknown status or missing title/company are skipped; rows matching an existing
+// job (same URL, or same title and company) are counted as duplicates.
+func ImportTrackedJobs(_ context.Context, input JobTrackerImportInput) (*JobTrackerImportResult, error) {
+	r := csv.NewReader(strings.NewReader(input.CSV))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("job_tracker_import: read header: %w", err)
+	}
+
+	cols, err := resolveImportColumns(header, input.Columns)
+	if err != nil {
+		return nil, err
+	}
+	result := &JobTrackerImportResult{Columns: make(map[string]string), DryRun: input.DryRun}
+	for _, f := range importFields {
+		if i, ok := cols[f]; ok {
+			result.Columns[f] = header[i]
+		}
+	}
+
+	statusMap := make(map[string]string, len(input.StatusMap))
+	for k, v := range input.StatusMap {
+		statusMap[strings.ToLower(strings.TrimSpace(k))] = strings.ToLower(strings.TrimSpace(v))
+	}
+
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	seen, err := trackedJobKeys(db)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.Begin() //nolint:noctx // SQLite file-based tracker
+	if err != nil {
+		return nil, fmt.Errorf("job_tracker_import: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	nowT := time.Now().UTC()
+	now := nowT.Format(time.RFC3339)
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			result.Skipped = append(result.Skipped, JobTrackerImportIssue{Row: perr.StartLine, Reason: perr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("job_tracker_import: read: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		get := func(field string) string {
+			if i, ok := cols[field]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+
+		title, company, jobURL := get("title"), get("company"), engine.CanonicalJobURL(get("url"))
+		if title == "" && company == "" && jobURL == "" {
+			continue // blank row
+		}
+		if title == "" || company == "" {
+			result.Skipped = append(result.Skipped, JobTrackerImportIssue{Row: line, Reason: "title and company are required"})
+			continue
+		}
+		status, ok := importStatus(get("status"), statusMap)
+		if !ok {
+			result.Skipped = append(result.Skipped, JobTrackerImportIssue{Row: line,
+				Reason: fmt.Sprintf("unknown status %q (map it with status_map)", get("status"))})
+			continue
+		}
+
+		keys := trackedJobKeysFor(title, company, jobURL)
+		if seen[keys[0]] || (len(keys) > 1 && seen[keys[1]]) {
+			result.Duplicates++
+			continue
+		}
+		for _, k := range keys {
+			seen[k] = true
+		}
+
+		created := now
+		if t, ok := parseImportDate(get("created_at")); ok {
+			created = t.UTC().Format(time.RFC3339)
+		}
+		notes := get("notes")
+		deadlineText := get("deadline")
+		if t, ok := parseImportDate(deadlineText); ok {
+			deadlineText = t.Format(time.DateOnly)
+		}
+		deadline, followUp := trackerDeadline(deadlineText, notes, nowT)
+
+		if input.DryRun {
+			result.Imported++
+			continue
+		}
+		res, err := tx.Exec( //nolint:noctx // SQLite file-based tracker
+			`INSERT INTO jobs (title, company, url, status, notes, salary, location, deadline, follow_up_at, created_at, updated_at)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			title, company, jobURL, status, notes, get("salary"), get("location"), deadline, followUp, created, now,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("job_tracker_import: insert row %d: %w", line, err)
+		}
+		id, _ := res.LastInsertId()
+		result.IDs = append(result.IDs, id)
+		result.Imported++
+	}
+
+	if input.DryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("job_tracker_import: commit: %w", err)
+	}
+	return result, nil
+}
+
+// resolveImportColumns maps tracker fields to header indexes: explicit mappings first,
+// then known aliases. Title and company must resolve.
+func resolveImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		if _, dup := index[h]; !dup {
+			index[h] = i
+		}
+	}
+
+	cols := make(map[string]int)
+	for field, h := range mapping {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if _, known := importFieldAliases[field]; !known {
+			return nil, fmt.Errorf("job_tracker_import: unknown field %q in columns (valid: %s)", field, strings.Join(importFields, ", "))
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(h))]
+		if !ok {
+			return nil, fmt.Errorf("job_tracker_import: column %q for %s not found in header", h, field)
+		}
+		cols[field] = i
+	}
+	for _, field := range importFields {
+		if _, ok := cols[field]; ok {
+			continue
+		}
+		for _, alias := range importFieldAliases[field] {
+			if i, ok := index[alias]; ok {
+				cols[field] = i
+				break
+			}
+		}
+	}
+
+	if _, ok := cols["title"]; !ok {
+		return nil, errors.New("job_tracker_import: no title column (map one with columns.title)")
+	}
+	if _, ok := cols["company"]; !ok {
+		return nil, errors.New("job_tracker_import: no company column (map one with columns.company)")
+	}
+	return cols, nil
+}
+
+// importStatus resolves a spreadsheet status to a tracker status. Empty means saved.
+func importStatus(raw string, statusMap map[string]string) (string, bool) {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	if mapped, ok := statusMap[s]; ok {
+		s = mapped
+	}
+	if s == "" {
+		return string(StatusSaved), true
+	}
+	if validStatus(s) {
+		return s, true
+	}
+	if syn, ok := importStatusSynonyms[s]; ok {
+		return string(syn), true
+	}
+	return "", false
+}
+
+// parseImportDate parses a spreadsheet date in one of importDateLayouts.
+func parseImportDate(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// trackedJobKeys returns the dedup keys of every job already in the tracker.
+func trackedJobKeys(db *sql.DB) (map[string]bool, error) {
+	rows, err := db.Query(`SELECT title, company, url FROM jobs`) //nolint:noctx // SQLite file-based tracker
+	if err != nil {
+		return nil, fmt.Errorf("job_tracker_import: query: %w", err)
+	}
+	defer rows.Close()
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var title, company string
+		var jobURL sql.NullString
+		if err := rows.Scan(&title, &company, &jobURL); err != nil {
+			return nil, fmt.Errorf("job_tracker_import: scan: %w", err)
+		}
+		for _, k := range trackedJobKeysFor(title, company, jobURL.String) {
+			seen[k] = true
+		}
+	}
+	return seen, rows.Err()
+}
+
+// trackedJobKeysFor returns the title+company key and, when present, the URL key.
+func trackedJobKeysFor(title, company, jobURL string) []string {
+	keys := []string{"tc:" + strings.ToLower(strings.TrimSpace(title)) + "|" + strings.ToLower(strings.TrimSpace(company))}
+	if u := strings.TrimRight(strings.TrimSpace(jobURL), "/"); u != "" {
+		keys = append(keys, "url:"+strings.ToLower(u))
+	}
+	return keys
+}
@@ -0,0 +1,177 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// --- Job posting snapshots ---
+
+// Postings are often taken down before the interview. job_tracker_add archives the
+// posting of a tracked job in the background — the JD as markdown and the raw page
+// HTML — and job_tracker_get returns it. The first snapshot is kept: it is the version
+// that was applied to. Jobs without one (imported, or the fetch failed) are archived
+// when job_tracker_get first asks for them, except in read-only mode.
+
+// snapshotTimeout bounds archiving one posting.
+const snapshotTimeout = 2 * time.Minute
+
+// JobSnapshot is the archived posting of a tracked job.
+type JobSnapshot struct {
+	URL       string `json:"url"`
+	Title     string `json:"title,omitempty"`
+	Markdown  string `json:"markdown"`
+	HTML      string `json:"html,omitempty"` // only with include_html
+	HTMLBytes int    `json:"html_bytes"`
+	FetchedAt string `json:"fetched_at"`
+	CheckedAt string `json:"checked_at,omitempty"` // last re-check by the posting monitor
+	ClosedAt  string `json:"closed_at,omitempty"`  // when the monitor found the posting closed
+}
+
+// JobTrackerGetInput is the input for job_tracker_get.
+type JobTrackerGetInput struct {
+	ID          int64 `json:"id"`
+	IncludeHTML bool  `json:"include_html,omitempty"` // include the raw page HTML of the snapshot
+}
+
+// JobTrackerGetResult is the output of job_tracker_get.
+type JobTrackerGetResult struct {
+	Job          TrackedJob   `json:"job"`
+	Snapshot     *JobSnapshot `json:"snapshot,omitempty"`
+	SnapshotNote string       `json:"snapshot_note,omitempty"` // why there is no snapshot
+}
+
+// initJobSnapshotsSchema creates the job_snapshots table.
+func initJobSnapshotsSchema(db *sql.DB) error {
+	schema := `CREATE TABLE IF NOT EXISTS job_snapshots (
+		job_id     INTEGER PRIMARY KEY,
+		url        TEXT NOT NULL,
+		title      TEXT,
+		markdown   TEXT NOT NULL,
+		html       TEXT,
+		fetched_at TEXT NOT NULL
+	)`
+	if _, err := db.Exec(schema); err != nil { //nolint:noctx // schema init, no user context available
+		return err
+	}
+	// The last version seen by the posting monitor.
+	for _, col := range []string{"current_markdown", "checked_at", "closed_at"} {
+		if err := addColumnIfMissing(db, "job_snapshots", col, "TEXT"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotsEnabled reports whether postings can be fetched: archiving needs the fetch
+// engine bound to ctx, which tests and offline tools do not set up.
+func snapshotsEnabled(ctx context.Context) bool {
+	return engine.Bound(ctx)
+}
+
+// archiveTrackedJob archives the posting of a newly tracked job, detached from the
+// request that added it. Failures are logged; job_tracker_get retries.
+func archiveTrackedJob(ctx context.Context, jobID int64, jobURL string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), snapshotTimeout)
+	defer cancel()
+	db, err := openTrackerDB()
+	if err != nil {
+		return
+	}
+	if s, _ := loadJobSnapshot(ctx, db, jobID); s != nil {
+		return
+	}
+	if _, err := archiveJobSnapshot(ctx, db, jobID, jobURL); err != nil {
+		slog.Warn("job_snapshots: archive failed", slog.Int64("job_id", jobID), slog.String("url", jobURL), slog.Any("error", err))
+	}
+}
+
+// archiveJobSnapshot fetches the posting and stores it, unless a snapshot already
+// exists. The markdown comes from the site extractor when there is one, else from
+// the generic job page extraction.
+func archiveJobSnapshot(ctx context.Context, db *sql.DB, jobID int64, jobURL string) (*JobSnapshot, error) {
+	title, md, rawHTML, err := engine.FetchJobPageHTML(ctx, jobURL)
+	if jobExtractorFor(jobURL) != nil || err != nil {
+		if text, perr := FetchJobPosting(ctx, jobURL); perr == nil {
+			md = text
+		}
+	}
+	if md == "" {
+		if err == nil {
+			err = errors.New("no content extracted")
+		}
+		return nil, fmt.Errorf("job_snapshots: fetch %s: %w", jobURL, err)
+	}
+	fetchedAt := time.Now().UTC().Format(time.RFC3339)
+	_, err = db.ExecContext(ctx, `INSERT OR IGNORE INTO job_snapshots (job_id, url, title, markdown, html, fetched_at)
+		VALUES (?, ?, ?, ?, ?, ?)`, jobID, jobURL, title, md, rawHTML, fetchedAt)
+	if err != nil {
+		return nil, fmt.Errorf("job_snapshots: save: %w", err)
+	}
+	return loadJobSnapshot(ctx, db, jobID)
+}
+
+// loadJobSnapshot returns the snapshot of a tracked job, or nil when there is none.
+func loadJobSnapshot(ctx context.Context, db *sql.DB, jobID int64) (*JobSnapshot, error) {
+	var s JobSnapshot
+	var title, rawHTML, checked, closed sql.NullString
+	err := db.QueryRowContext(ctx, `SELECT url, title, markdown, html, fetched_at, checked_at, closed_at
+		FROM job_snapshots WHERE job_id = ?`, jobID).
+		Scan(&s.URL, &title, &s.Markdown, &rawHTML, &s.FetchedAt, &checked, &closed)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("job_snapshots: %w", err)
+	}
+	s.Title, s.HTML, s.HTMLBytes = title.String, rawHTML.String, len(rawHTML.String)
+	s.CheckedAt, s.ClosedAt = checked.String, closed.String
+	return &s, nil
+}
+
+// GetTrackedJob returns a tracked job with its events, milestones and archived posting.
+func GetTrackedJob(ctx context.Context, input JobTrackerGetInput) (*JobTrackerGetResult, error) {
+	if input.ID <= 0 {
+		return nil, errors.New("job_tracker_get: id is required")
+	}
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	job, err := getTrackedJob(db, "job_tracker_get", input.ID)
+	if err != nil {
+		return nil, err
+	}
+	list := []TrackedJob{*job}
+	loadGigMilestones(db, list)
+	result := &JobTrackerGetResult{Job: list[0]}
+
+	snap, err := loadJobSnapshot(ctx, db, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case snap != nil:
+	case job.URL == "":
+		result.SnapshotNote = "No URL tracked for this job, so there is no posting to archive."
+	case !snapshotsEnabled(ctx):
+		result.SnapshotNote = "No snapshot archived yet."
+	case engine.ConfigFrom(ctx).ReadOnly:
+		result.SnapshotNote = "No snapshot archived yet; postings are not archived in read-only mode."
+	default:
+		if snap, err = archiveJobSnapshot(ctx, db, input.ID, job.URL); err != nil {
+			result.SnapshotNote = fmt.Sprintf("No snapshot archived: %v. The posting may have been taken down.", err)
+		}
+	}
+	if snap != nil && !input.IncludeHTML {
+		snap.HTML = ""
+	}
+	result.Snapshot = snap
+	return result, nil
+}
@@ -2,10 +2,16 @@ package jobs
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
 )
 
 // resetTracker resets the singleton so each test gets a fresh DB.
@@ -90,6 +96,41 @@ func TestAddTrackedJob_InvalidStatus(t *testing.T) {
 	}
 }
 
+func TestTrackerMigration_CanonicalURLs(t *testing.T) {
+	resetTracker(t)
+	db, err := openTrackerDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A row saved before URLs were canonicalized, and a database that predates the migration.
+	if _, err := db.Exec(`INSERT INTO jobs (title, company, url, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
+		"SRE", "Corp", "https://www.linkedin.com/jobs/view/sre-at-corp-3912345678/?trk=public_jobs", "2026-01-01T00:00:00Z", "2026-01-01T00:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`DELETE FROM tracker_migrations`); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	trackerDB, trackerErr, trackerOnce = nil, nil, sync.Once{}
+
+	if db, err = openTrackerDB(); err != nil {
+		t.Fatal(err)
+	}
+	var got string
+	if err := db.QueryRow(`SELECT url FROM jobs`).Scan(&got); err != nil || got != "https://linkedin.com/jobs/view/3912345678" {
+		t.Errorf("url = %q, %v", got, err)
+	}
+	res, _ := AddTrackedJob(context.Background(), JobTrackerAddInput{Title: "SRE", Company: "Corp", URL: "https://de.linkedin.com/jobs/view/3912345678"})
+	if !strings.Contains(res.Message, "already tracked as id=1") {
+		t.Errorf("duplicate not detected: %q", res.Message)
+	}
+
+	ran := false
+	if err := runTrackerMigration(db, "canonical_job_urls", func(*sql.Tx) error { ran = true; return nil }); err != nil || ran {
+		t.Errorf("migration ran twice: ran=%v err=%v", ran, err)
+	}
+}
+
 func TestListTrackedJobs_Empty(t *testing.T) {
 	resetTracker(t)
 	ctx := context.Background()
@@ -270,6 +311,48 @@ func TestUpdateTrackedJob_InvalidStatus(t *testing.T) {
 	}
 }
 
+func TestUpdateTrackedJob_EventAndFollowupPlan(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+
+	added, _ := AddTrackedJob(ctx, JobTrackerAddInput{Title: "Dev", Company: "Corp", Status: "interview"})
+	_, err := UpdateTrackedJob(ctx, JobTrackerUpdateInput{
+		ID: added.ID, Event: "Interview", EventDate: "2026-03-02",
+		Interviewers: []string{" Dana Lee ", "Sam Ortiz", "Dana Lee"}, EventNotes: "Discussed the billing migration",
+	})
+	if err != nil {
+		t.Fatalf("UpdateTrackedJob with event: %v", err)
+	}
+	if _, err := UpdateTrackedJob(ctx, JobTrackerUpdateInput{ID: added.ID, Event: "lunch"}); err == nil {
+		t.Error("expected error for invalid event kind")
+	}
+	if _, err := UpdateTrackedJob(ctx, JobTrackerUpdateInput{ID: added.ID, Event: "call", EventDate: "March 3"}); err == nil {
+		t.Error("expected error for a non-ISO event date")
+	}
+
+	list, _ := ListTrackedJobs(ctx, JobTrackerListInput{})
+	if len(list.Jobs) != 1 || len(list.Jobs[0].Events) != 1 {
+		t.Fatalf("want 1 job with 1 event, got %+v", list.Jobs)
+	}
+	ev := list.Jobs[0].Events[0]
+	if ev.Kind != EventInterview || ev.Date != "2026-03-02" || len(ev.Interviewers) != 2 || ev.Interviewers[0] != "Dana Lee" {
+		t.Errorf("event = %+v", ev)
+	}
+
+	job := &list.Jobs[0]
+	typ, sendOn, err := followupPlan(job, "", time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC))
+	if err != nil || typ != FollowupThankYou || sendOn != "2026-03-03" {
+		t.Errorf("day after interview: got %q %q %v, want thank_you 2026-03-03", typ, sendOn, err)
+	}
+	typ, sendOn, _ = followupPlan(job, "", time.Date(2026, 3, 6, 10, 0, 0, 0, time.UTC))
+	if typ != FollowupStatusInquiry || sendOn != "2026-03-09" {
+		t.Errorf("4 days after interview: got %q %q, want status_inquiry 2026-03-09", typ, sendOn)
+	}
+	if _, _, err := followupPlan(job, "reminder", time.Now()); err == nil {
+		t.Error("expected error for invalid email type")
+	}
+}
+
 func TestValidStatus(t *testing.T) {
 	valid := []string{"saved", "applied", "interview", "offer", "rejected"}
 	for _, s := range valid {
@@ -312,3 +395,704 @@ func TestInitTrackerSchema_Idempotent(t *testing.T) {
 		t.Errorf("expected 2 total after re-open, got %d", list.Total)
 	}
 }
+
+func TestTrackedJob_DeadlineFollowUp(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+
+	deadline := time.Now().UTC().AddDate(0, 0, 10).Format(time.DateOnly)
+	if _, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "No deadline", Company: "A"}); err != nil {
+		t.Fatalf("AddTrackedJob: %v", err)
+	}
+	if _, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "From notes", Company: "B", Notes: "Apply by " + deadline}); err != nil {
+		t.Fatalf("AddTrackedJob: %v", err)
+	}
+
+	list, err := ListTrackedJobs(ctx, JobTrackerListInput{SortBy: "follow_up"})
+	if err != nil {
+		t.Fatalf("ListTrackedJobs: %v", err)
+	}
+	first := list.Jobs[0]
+	if first.Title != "From notes" || first.Deadline != deadline || first.FollowUp == "" {
+		t.Errorf("first job = %+v, want deadline %s with follow-up", first, deadline)
+	}
+	if list.Jobs[1].FollowUp != "" {
+		t.Errorf("job without deadline got follow-up %q", list.Jobs[1].FollowUp)
+	}
+}
+
+func TestImportTrackedJobs(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	if _, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "Go Dev", Company: "Acme", URL: "https://acme.com/jobs/1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	csvText := "Position,Employer,Link,Stage,Date Applied,Comments\n" +
+		"Go Dev,Acme,https://acme.com/jobs/1,applied,2026-01-05,dup by url\n" +
+		"Rust Dev,Initech,,Phone screen,01/15/2026,\n" +
+		"SRE,Globex,,Maybe later,,\n" +
+		",NoTitle,,,,\n" +
+		"Platform Eng,Hooli,,Custom Stage,,\n" +
+		"rust dev,INITECH,,,,dup within file\n"
+
+	result, err := ImportTrackedJobs(ctx, JobTrackerImportInput{
+		CSV:       csvText,
+		StatusMap: map[string]string{"Custom Stage": "offer"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Imported != 2 || result.Duplicates != 2 || len(result.Skipped) != 2 {
+		t.Fatalf("imported=%d duplicates=%d skipped=%v", result.Imported, result.Duplicates, result.Skipped)
+	}
+	if result.Skipped[0].Row != 4 {
+		t.Errorf("expected unknown status on line 4, got %+v", result.Skipped[0])
+	}
+	if result.Columns["company"] != "Employer" || result.Columns["created_at"] != "Date Applied" {
+		t.Errorf("unexpected column mapping: %v", result.Columns)
+	}
+
+	list, err := ListTrackedJobs(ctx, JobTrackerListInput{Status: "interview"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Jobs) != 1 || list.Jobs[0].Company != "Initech" || list.Jobs[0].CreatedAt != "2026-01-15T00:00:00Z" {
+		t.Errorf("unexpected interview jobs: %+v", list.Jobs)
+	}
+}
+
+func TestImportTrackedJobs_ExplicitColumnsAndDryRun(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+
+	result, err := ImportTrackedJobs(ctx, JobTrackerImportInput{
+		CSV:     "Role Name,Org\nBackend,Acme\n",
+		Columns: map[string]string{"title": "Role Name", "company": "org"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Imported != 1 || len(result.IDs) != 0 {
+		t.Errorf("dry run: imported=%d ids=%v", result.Imported, result.IDs)
+	}
+	list, _ := ListTrackedJobs(ctx, JobTrackerListInput{})
+	if list.Total != 0 {
+		t.Errorf("dry run wrote %d rows", list.Total)
+	}
+
+	if _, err := ImportTrackedJobs(ctx, JobTrackerImportInput{CSV: "Name,Where\nx,y\n"}); err == nil {
+		t.Error("expected error when no title column can be resolved")
+	}
+	if _, err := ImportTrackedJobs(ctx, JobTrackerImportInput{CSV: "Title,Company\n", Columns: map[string]string{"salary": "Pay"}}); err == nil {
+		t.Error("expected error for a mapped column missing from the header")
+	}
+}
+
+func TestAddTrackedJob_IdempotencyKey(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	in := JobTrackerAddInput{Title: "Go Dev", Company: "Acme", IdempotencyKey: "req-1"}
+
+	first, err := AddTrackedJob(ctx, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	ids := make([]int64, 3)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, err := AddTrackedJob(ctx, in)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			ids[i] = r.ID
+		}(i)
+	}
+	wg.Wait()
+	for _, id := range ids {
+		if id != first.ID {
+			t.Errorf("retry returned id %d, want original %d", id, first.ID)
+		}
+	}
+	list, _ := ListTrackedJobs(ctx, JobTrackerListInput{})
+	if list.Total != 1 {
+		t.Errorf("expected 1 tracked job after retries, got %d", list.Total)
+	}
+
+	in.Title = "Rust Dev"
+	if _, err := AddTrackedJob(ctx, in); err == nil {
+		t.Error("expected error when reusing a key with different input")
+	}
+	in.IdempotencyKey = ""
+	if r, err := AddTrackedJob(ctx, in); err != nil || r.ID == first.ID {
+		t.Errorf("no key should add a new job: %+v, %v", r, err)
+	}
+}
+
+func TestTrackedGig_HoursMilestonesInvoice(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+
+	if _, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "Go Dev", Company: "Stripe"}); err != nil {
+		t.Fatalf("AddTrackedJob job: %v", err)
+	}
+	res, err := AddTrackedJob(ctx, JobTrackerAddInput{
+		Title: "API integration", Company: "Acme", Status: "offer", Kind: "gig", Rate: 80, Currency: "eur",
+	})
+	if err != nil {
+		t.Fatalf("AddTrackedJob gig: %v", err)
+	}
+	if _, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "x", Company: "y", Kind: "gig", RateType: "weekly"}); err == nil {
+		t.Error("expected error for invalid rate_type")
+	}
+
+	for _, in := range []GigUpdateInput{
+		{ID: res.ID, LogHours: 10},
+		{ID: res.ID, LogHours: 2.5, AddMilestone: "Design doc", MilestoneAmount: 300},
+	} {
+		if _, err := UpdateGig(ctx, in); err != nil {
+			t.Fatalf("UpdateGig(%+v): %v", in, err)
+		}
+	}
+	if _, err := UpdateGig(ctx, GigUpdateInput{ID: 1, LogHours: 1}); err == nil {
+		t.Error("expected error updating a job as a gig")
+	}
+
+	gigs, err := ListTrackedJobs(ctx, JobTrackerListInput{Kind: "gig"})
+	if err != nil {
+		t.Fatalf("ListTrackedJobs: %v", err)
+	}
+	if gigs.Total != 1 || gigs.Jobs[0].Gig == nil {
+		t.Fatalf("gigs = %+v, want one gig", gigs)
+	}
+	g := gigs.Jobs[0].Gig
+	if g.RateType != RateHourly || g.Currency != "EUR" || g.HoursUnbilled != 12.5 || len(g.Milestones) != 1 {
+		t.Errorf("gig = %+v", g)
+	}
+
+	// Pending milestones are not billed until done.
+	if _, err := UpdateGig(ctx, GigUpdateInput{ID: res.ID, MilestoneID: g.Milestones[0].ID, MilestoneStatus: "done"}); err != nil {
+		t.Fatalf("UpdateGig milestone: %v", err)
+	}
+	inv, err := BuildGigInvoice(ctx, GigInvoiceInput{ID: res.ID, MarkInvoiced: true})
+	if err != nil {
+		t.Fatalf("BuildGigInvoice: %v", err)
+	}
+	if len(inv.Items) != 2 || inv.Total != 1300 || inv.Client != "Acme" {
+		t.Errorf("invoice = %+v, want 12.5h × 80 + 300 = 1300", inv)
+	}
+
+	// Everything is invoiced now.
+	if _, err := BuildGigInvoice(ctx, GigInvoiceInput{ID: res.ID}); err == nil {
+		t.Error("expected error for a gig with nothing unbilled")
+	}
+	gigs, _ = ListTrackedJobs(ctx, JobTrackerListInput{Kind: "gig"})
+	if g := gigs.Jobs[0].Gig; g.PaymentStatus != PaymentInvoiced || g.HoursUnbilled != 0 || g.Milestones[0].Status != MilestoneInvoiced {
+		t.Errorf("after invoice gig = %+v", g)
+	}
+
+	// Invoicing new hours on a paid gig keeps it paid.
+	if _, err := UpdateGig(ctx, GigUpdateInput{ID: res.ID, PaymentStatus: "paid"}); err != nil {
+		t.Fatalf("UpdateGig paid: %v", err)
+	}
+	if _, err := UpdateGig(ctx, GigUpdateInput{ID: res.ID, LogHours: 2}); err != nil {
+		t.Fatalf("UpdateGig hours: %v", err)
+	}
+	if _, err := BuildGigInvoice(ctx, GigInvoiceInput{ID: res.ID, MarkInvoiced: true}); err != nil {
+		t.Fatalf("BuildGigInvoice: %v", err)
+	}
+	gigs, _ = ListTrackedJobs(ctx, JobTrackerListInput{Kind: "gig"})
+	if g := gigs.Jobs[0].Gig; g.PaymentStatus != PaymentPaid || g.HoursUnbilled != 0 || g.Milestones[0].Status != MilestonePaid {
+		t.Errorf("after invoicing a paid gig = %+v", g)
+	}
+}
+
+func TestCompanyStore(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+
+	if CompanyKey("The Acme Robotics, Inc.") != "acme robotics" {
+		t.Errorf("CompanyKey = %q", CompanyKey("The Acme Robotics, Inc."))
+	}
+
+	RecordListingCompanies(ctx, []engine.JobListing{
+		{Title: "Go Dev", Company: "Acme Robotics", URL: "https://boards.greenhouse.io/acmerobotics/jobs/1"},
+		{Title: "SRE", Company: "Acme Robotics Inc", URL: "https://acme.example/jobs/2"},
+	})
+	recordCompanyResearch(ctx, "acme", &CompanyResearchResult{
+		Name: "Acme Robotics", Size: "200-500", Headquarters: "Berlin, Germany",
+		TechStack: []string{"Go", "Kafka"}, GlassdoorRating: 4.1, Website: "https://www.acme.example/",
+	})
+	if err := UpsertCompany(ctx, Company{Name: "ACME Robotics GmbH", TechStack: []string{"go", "Rust"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := GetCompany(ctx, "acme")
+	if err != nil || c == nil {
+		t.Fatalf("GetCompany by alias: %v, %v", c, err)
+	}
+	if c.ATS != "greenhouse" || c.ATSSlug != "acmerobotics" || c.HQ != "Berlin, Germany" || c.Domain != "acme.example" || c.Rating != 4.1 {
+		t.Errorf("merged record = %+v", c)
+	}
+	if strings.Join(c.TechStack, ",") != "Go,Kafka,Rust" {
+		t.Errorf("tech stack = %v", c.TechStack)
+	}
+	if res := storedCompanyResearch(ctx, "Acme Robotics"); res == nil || res.Size != "200-500" {
+		t.Errorf("stored research = %+v", res)
+	}
+	if c, _ := GetCompany(ctx, "Globex"); c != nil {
+		t.Errorf("unknown company = %+v", c)
+	}
+
+	listings := []engine.JobListing{{Company: "Acme Robotics"}, {Company: "Globex"}}
+	AnnotateKnownCompanies(ctx, listings)
+	if listings[0].CompanyInfo == nil || listings[0].CompanyInfo.Size != "200-500" || listings[1].CompanyInfo != nil {
+		t.Errorf("company_info = %+v / %+v", listings[0].CompanyInfo, listings[1].CompanyInfo)
+	}
+}
+
+func TestMockInterviewSession(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	db, err := openTrackerDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &mockSession{
+		ID:     "mock_test",
+		Status: MockInProgress,
+		Questions: []mockStoredQ{
+			{Category: "behavioral", Question: "Tell me about a conflict."},
+			{Category: "technical", Question: "How does Go schedule goroutines?"},
+			{Category: "technical", Question: "Explain context cancellation."},
+		},
+		Evaluations: []AnswerEvaluation{
+			{Number: 1, Score: 8, Grounded: true, Strengths: []string{"clear STAR structure"}},
+			{Number: 2, Score: 5, Improvements: []string{"mention the GMP model"}},
+		},
+		Current: 2,
+	}
+	if err := saveMockSession(ctx, db, s); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := MockInterview(ctx, engine.MockInterviewInput{SessionID: "mock_test"})
+	if err == nil {
+		t.Fatalf("expected error continuing without an answer, got %+v", res)
+	}
+	res, err = MockInterview(ctx, engine.MockInterviewInput{SessionID: "mock_test", End: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != MockCompleted || res.Question != nil || res.Debrief == nil {
+		t.Fatalf("result = %+v", res)
+	}
+	d := res.Debrief
+	if d.OverallScore != 65 || d.Answered != 2 || d.Skipped != 1 || d.Verdict != "almost_ready" {
+		t.Errorf("debrief = %+v", d)
+	}
+	if d.CategoryScores["behavioral"] != 80 || d.CategoryScores["technical"] != 50 || d.GroundedShare != 0.5 {
+		t.Errorf("scores = %v, grounded = %v", d.CategoryScores, d.GroundedShare)
+	}
+	if len(d.TopStrengths) != 1 || len(d.FocusAreas) != 1 || !strings.Contains(d.FocusAreas[0], "GMP") {
+		t.Errorf("strengths = %q, focus = %q", d.TopStrengths, d.FocusAreas)
+	}
+
+	// A completed session replays its debrief.
+	again, err := MockInterview(ctx, engine.MockInterviewInput{SessionID: "mock_test", Answer: "late"})
+	if err != nil || again.Debrief == nil || again.Feedback != nil {
+		t.Errorf("completed session = %+v, %v", again, err)
+	}
+	if _, err := MockInterview(ctx, engine.MockInterviewInput{SessionID: "mock_missing", End: true}); err == nil {
+		t.Error("expected error for unknown session")
+	}
+}
+
+func TestResumeABReport(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	db, err := openTrackerDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var variantIDs []int64
+	for _, v := range []string{"A", "B"} {
+		res, err := db.Exec(`INSERT INTO resume_variants (variant, angle, resume, created_at) VALUES (?, '', 'resume', ?)`, v, time.Now().UTC().Format(time.RFC3339))
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, _ := res.LastInsertId()
+		variantIDs = append(variantIDs, id)
+	}
+
+	if _, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "Dev", Company: "X", ResumeVariantID: 999}); err == nil {
+		t.Error("expected error for unknown resume variant")
+	}
+	for _, app := range []struct {
+		variant int
+		status  string
+	}{{0, "interview"}, {0, "applied"}, {1, "rejected"}, {1, "applied"}, {1, "saved"}} {
+		added, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "Dev", Company: "X", Status: app.status})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := UpdateTrackedJob(ctx, JobTrackerUpdateInput{ID: added.ID, ResumeVariantID: variantIDs[app.variant]}); err != nil {
+			t.Fatalf("tag variant: %v", err)
+		}
+	}
+
+	r, err := BuildResumeABReport(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ABVariantStats{
+		{Variant: "A", Sent: 2, Responses: 1, Interviews: 1, ResponseRate: 0.5, InterviewRate: 0.5},
+		{Variant: "B", Sent: 2, Responses: 1, ResponseRate: 0.5},
+	}
+	if len(r.Variants) != 2 || r.Variants[0] != want[0] || r.Variants[1] != want[1] {
+		t.Errorf("variants = %+v, want %+v", r.Variants, want)
+	}
+	if len(r.ByMonth) != 1 || r.Leader != "" || !strings.Contains(r.Summary, "Inconclusive") {
+		t.Errorf("unexpected report: %+v", r)
+	}
+}
+
+func TestSearchLocalJobs(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+
+	added, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "Backend Engineer", Company: "Streamco", Notes: "Recruiter said the team runs Kafka"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RecordSeenJob(ctx, engine.JobListing{
+		Title: "Senior Rust Engineer", Company: "Ferrous", URL: "https://example.com/jobs/1?utm_source=x",
+		Description: "Build event pipelines in Rust on top of Kafka and ClickHouse.",
+	}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	indexJobPosting(ctx, "https://example.com/jobs/2", "**Title:** Go Developer\n\n**Company:** Gopherco\n\n**Description:**\nGo services, no Kafka.")
+
+	res, err := SearchLocalJobs(ctx, LocalSearchInput{Query: "that Rust job mentioning Kafka from last week"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Match != "all" || res.Since == "" || strings.Join(res.Terms, " ") != "rust kafka" {
+		t.Errorf("match=%q since=%q terms=%v", res.Match, res.Since, res.Terms)
+	}
+	if res.Total != 1 || res.Hits[0].Source != LocalSourceSeen || res.Hits[0].URL != "https://example.com/jobs/1" {
+		t.Fatalf("hits = %+v", res.Hits)
+	}
+	if !strings.Contains(res.Hits[0].Snippet, "**Kafka**") {
+		t.Errorf("snippet = %q", res.Hits[0].Snippet)
+	}
+
+	res, _ = SearchLocalJobs(ctx, LocalSearchInput{Query: "kafka"})
+	if res.Total != 3 {
+		t.Errorf("kafka: %d hits, want 3: %+v", res.Total, res.Hits)
+	}
+	res, _ = SearchLocalJobs(ctx, LocalSearchInput{Query: "gopherco"})
+	if res.Total != 1 || res.Hits[0].Title != "Go Developer" || res.Hits[0].Source != LocalSourcePosting {
+		t.Errorf("posting hits = %+v", res.Hits)
+	}
+
+	// Tracker notes are re-indexed on update.
+	if _, err := UpdateTrackedJob(ctx, JobTrackerUpdateInput{ID: added.ID, Notes: "Onsite next Tuesday"}); err != nil {
+		t.Fatal(err)
+	}
+	res, _ = SearchLocalJobs(ctx, LocalSearchInput{Query: "onsite", Source: LocalSourceTracked})
+	if res.Total != 1 || res.Hits[0].TrackerID != added.ID {
+		t.Errorf("tracked hits = %+v", res.Hits)
+	}
+
+	// No document has both terms: fall back to any term.
+	res, _ = SearchLocalJobs(ctx, LocalSearchInput{Query: "rust onsite"})
+	if res.Match != "any" || res.Total != 2 {
+		t.Errorf("match=%q total=%d", res.Match, res.Total)
+	}
+	if _, err := SearchLocalJobs(ctx, LocalSearchInput{Query: "jobs from yesterday"}); err == nil {
+		t.Error("expected error for a query without terms")
+	}
+}
+
+func TestGetTrackedJob_Snapshot(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+
+	withURL, _ := AddTrackedJob(ctx, JobTrackerAddInput{Title: "SRE", Company: "Corp", URL: "https://corp.example/jobs/7"})
+	noURL, _ := AddTrackedJob(ctx, JobTrackerAddInput{Title: "Dev", Company: "Corp"})
+
+	res, err := GetTrackedJob(ctx, JobTrackerGetInput{ID: withURL.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Snapshot != nil || res.SnapshotNote == "" {
+		t.Errorf("before archiving: snapshot=%v note=%q", res.Snapshot, res.SnapshotNote)
+	}
+	// Read-only mode never fetches the posting to archive it.
+	roCtx := engine.WithEngine(ctx, engine.New(engine.Config{ReadOnly: true}))
+	res, err = GetTrackedJob(roCtx, JobTrackerGetInput{ID: withURL.ID})
+	if err != nil || res.Snapshot != nil || !strings.Contains(res.SnapshotNote, "read-only") {
+		t.Errorf("read-only: %+v, %v", res, err)
+	}
+
+	db, _ := openTrackerDB()
+	if _, err := db.Exec(`INSERT INTO job_snapshots (job_id, url, title, markdown, html, fetched_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		withURL.ID, "https://corp.example/jobs/7", "SRE", "**Title:** SRE\n\nOn-call 1 week in 6.", "<html>SRE</html>", "2026-01-05T10:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	res, _ = GetTrackedJob(ctx, JobTrackerGetInput{ID: withURL.ID})
+	if res.Snapshot == nil || !strings.Contains(res.Snapshot.Markdown, "On-call") || res.Snapshot.HTML != "" || res.Snapshot.HTMLBytes != 16 {
+		t.Fatalf("snapshot = %+v", res.Snapshot)
+	}
+	if res.Job.Title != "SRE" || res.SnapshotNote != "" {
+		t.Errorf("job=%+v note=%q", res.Job, res.SnapshotNote)
+	}
+	res, _ = GetTrackedJob(ctx, JobTrackerGetInput{ID: withURL.ID, IncludeHTML: true})
+	if res.Snapshot.HTML != "<html>SRE</html>" {
+		t.Errorf("html = %q", res.Snapshot.HTML)
+	}
+
+	res, _ = GetTrackedJob(ctx, JobTrackerGetInput{ID: noURL.ID})
+	if res.Snapshot != nil || !strings.Contains(res.SnapshotNote, "No URL") {
+		t.Errorf("no url: %+v", res)
+	}
+	if _, err := GetTrackedJob(ctx, JobTrackerGetInput{ID: 999}); err == nil {
+		t.Error("expected error for unknown id")
+	}
+}
+
+func TestDiffPostings(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := "**Title:** Backend Engineer\n\n**Description:**\n- Build services in Go\n- Posted 3 days ago\n- 120 applicants\n- Work with PostgreSQL"
+	after := "**Title:** Backend Engineer\n\n**Salary:** 150000-180000 USD per year\n\n**Description:**\n- Build services in Go\n- Posted 5 days ago\n- 300 applicants\n- Work with Kafka and Kubernetes\n- On-call rotation"
+
+	c := diffPostings(before, after, now)
+	if c.Empty() {
+		t.Fatal("expected a change")
+	}
+	if c.SalaryBefore != "" || c.SalaryAfter != "150000-180000 USD per year" {
+		t.Errorf("salary %q → %q", c.SalaryBefore, c.SalaryAfter)
+	}
+	if !containsString(c.SkillsAdded, "Kubernetes") || !containsString(c.SkillsRemoved, "PostgreSQL") {
+		t.Errorf("skills +%v -%v", c.SkillsAdded, c.SkillsRemoved)
+	}
+	// The salary line, two new requirement lines; the volatile posted/applicants lines are ignored.
+	if c.AddedCount != 3 || c.RemovedCount != 1 {
+		t.Errorf("lines +%d -%d", c.AddedCount, c.RemovedCount)
+	}
+	summary := c.Summary()
+	for _, want := range []string{"Salary added: 150000-180000 USD per year", "Requirements added:", "Description changed: 3 lines added, 1 removed"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+
+	if c := diffPostings(before, strings.Replace(before, "3 days ago", "4 days ago", 1), now); !c.Empty() {
+		t.Errorf("volatile change reported: %+v", c)
+	}
+	if c := diffPostings(before, before+"\nThis job is no longer accepting applications.", now); !c.Closed {
+		t.Error("closed notice not detected")
+	}
+	if c := diffPostings(before, before+"\n\n**Valid through:** 2026-02-10", now); !c.Closed || c.ClosedReason != "valid through 2026-02-10" {
+		t.Errorf("expired posting: %+v", c)
+	}
+}
+
+func TestRecordPostingChange(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	added, _ := AddTrackedJob(ctx, JobTrackerAddInput{Title: "SRE", Company: "Corp", URL: "https://corp.example/jobs/7"})
+	db, _ := openTrackerDB()
+	if _, err := db.Exec(`INSERT INTO job_snapshots (job_id, url, markdown, fetched_at) VALUES (?, ?, ?, ?)`,
+		added.ID, "https://corp.example/jobs/7", "Run Go services", "2026-01-05T10:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	postings, err := activeTrackedPostings(ctx, db)
+	if err != nil || len(postings) != 1 || postings[0].last != "Run Go services" {
+		t.Fatalf("active postings = %+v, %v", postings, err)
+	}
+
+	c := &PostingChange{JobID: added.ID, Closed: true, ClosedReason: "the posting URL returns HTTP 404: Not Found"}
+	if err := recordPostingChange(ctx, db, c, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	res, _ := GetTrackedJob(ctx, JobTrackerGetInput{ID: added.ID})
+	if res.Snapshot.ClosedAt == "" || res.Snapshot.Markdown != "Run Go services" {
+		t.Errorf("snapshot = %+v", res.Snapshot)
+	}
+	if len(res.Job.Events) != 1 || res.Job.Events[0].Kind != EventPostingChange || !strings.Contains(res.Job.Events[0].Notes, "HTTP 404") {
+		t.Errorf("events = %+v", res.Job.Events)
+	}
+	if postings, _ := activeTrackedPostings(ctx, db); len(postings) != 0 {
+		t.Errorf("closed posting still active: %+v", postings)
+	}
+	if res.Job.ClosedByEmployer == "" || !strings.Contains(res.Job.StatusPrompt, "closed this posting") {
+		t.Errorf("job not flagged: closed=%q prompt=%q", res.Job.ClosedByEmployer, res.Job.StatusPrompt)
+	}
+
+	// The prompt goes away once the status is updated; the flag stays.
+	if _, err := UpdateTrackedJob(ctx, JobTrackerUpdateInput{ID: added.ID, Status: "rejected"}); err != nil {
+		t.Fatal(err)
+	}
+	list, _ := ListTrackedJobs(ctx, JobTrackerListInput{})
+	if len(list.Jobs) != 1 || list.Jobs[0].ClosedByEmployer == "" || list.Jobs[0].StatusPrompt != "" {
+		t.Errorf("after status update: %+v", list.Jobs)
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+
+	RecordAudit(ctx, "job_tracker_add", []byte(`{"title": "Go Dev", "company": "Acme"}`), nil)
+	RecordAudit(ctx, "master_resume_build", []byte(`{"resume_text":"..."}`), errors.New("llm down"))
+
+	if AuditDigest([]byte(`{"title": "Go Dev", "company": "Acme"}`)) != AuditDigest([]byte(`{"title":"Go Dev","company":"Acme"}`)) {
+		t.Error("digest should ignore whitespace")
+	}
+
+	all, err := ListAuditLog(ctx, engine.AuditLogInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Entries) != 2 || all.Entries[0].Tool != "master_resume_build" || all.Entries[0].Error != "llm down" {
+		t.Fatalf("entries = %+v", all.Entries)
+	}
+	if got := all.Entries[1]; got.Error != "" || got.InputDigest != AuditDigest([]byte(`{"title":"Go Dev","company":"Acme"}`)) {
+		t.Errorf("tracker entry = %+v", got)
+	}
+
+	one, _ := ListAuditLog(ctx, engine.AuditLogInput{Tool: "job_tracker_add"})
+	if len(one.Entries) != 1 {
+		t.Errorf("tool filter: %+v", one.Entries)
+	}
+	future, _ := ListAuditLog(ctx, engine.AuditLogInput{Since: time.Now().AddDate(0, 0, 2).Format(time.DateOnly)})
+	if len(future.Entries) != 0 {
+		t.Errorf("since filter: %+v", future.Entries)
+	}
+	if _, err := ListAuditLog(ctx, engine.AuditLogInput{Since: "yesterday"}); err == nil {
+		t.Error("want error for bad since")
+	}
+}
+
+func TestDataExportImportTracker(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+
+	first, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "Go Developer", Company: "Acme", Notes: "referral"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	RecordAudit(ctx, "job_tracker_add", []byte(`{}`), nil)
+	path := "backup.zip"
+	exp, err := DataExport(ctx, DataExportInput{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, outside := range []string{filepath.Join(t.TempDir(), "backup.zip"), "../tracker.db", "sub/backup.zip"} {
+		if _, err := DataExport(ctx, DataExportInput{Path: outside}); err == nil {
+			t.Errorf("DataExport(%q) should be rejected", outside)
+		}
+		if _, err := DataImport(ctx, DataImportInput{Path: outside}); err == nil {
+			t.Errorf("DataImport(%q) should be rejected", outside)
+		}
+	}
+	if _, err := DataImport(ctx, DataImportInput{Path: exp.Path, DryRun: true}); err != nil {
+		t.Errorf("DataImport of the returned path: %v", err)
+	}
+	if exp.Counts["tracker_jobs"] != 1 || !strings.Contains(strings.Join(exp.Sections, ","), BackupTracker) {
+		t.Fatalf("export = %+v", exp)
+	}
+
+	if _, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "Rust Developer", Company: "Beta"}); err != nil {
+		t.Fatal(err)
+	}
+	dry, err := DataImport(ctx, DataImportInput{Path: path, Sections: []string{"tracker"}, DryRun: true})
+	if err != nil || dry.SafetyBackup != "" {
+		t.Fatalf("dry run = %+v, %v", dry, err)
+	}
+	if list, _ := ListTrackedJobs(ctx, JobTrackerListInput{}); len(list.Jobs) != 2 {
+		t.Fatalf("dry run changed the tracker: %d jobs", len(list.Jobs))
+	}
+
+	imp, err := DataImport(ctx, DataImportInput{Path: path, Sections: []string{"tracker"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(imp.SafetyBackup); err != nil {
+		t.Errorf("safety backup: %v", err)
+	}
+	list, _ := ListTrackedJobs(ctx, JobTrackerListInput{})
+	if len(list.Jobs) != 1 || list.Jobs[0].ID != first.ID || list.Jobs[0].Company != "Acme" {
+		t.Fatalf("after import: %+v", list.Jobs)
+	}
+	if found, _ := SearchLocalJobs(ctx, LocalSearchInput{Query: "Rust", Source: "tracked"}); found == nil || len(found.Hits) != 0 {
+		t.Errorf("local search should drop the replaced job: %+v", found)
+	}
+	if found, _ := SearchLocalJobs(ctx, LocalSearchInput{Query: "Acme", Source: "tracked"}); found == nil || len(found.Hits) != 1 {
+		t.Errorf("local search should hold the restored job once: %+v", found)
+	}
+	if audit, _ := ListAuditLog(ctx, engine.AuditLogInput{}); len(audit.Entries) != 1 {
+		t.Errorf("audit log should survive the import: %+v", audit.Entries)
+	}
+
+	if _, err := DataImport(ctx, DataImportInput{Path: path, Sections: []string{"calendar"}}); err == nil {
+		t.Error("want error for unknown section")
+	}
+	if _, err := DataImport(ctx, DataImportInput{Data: "bm90IGEgemlw"}); err == nil {
+		t.Error("want error for non-zip data")
+	}
+}
+
+func TestTrackedJob_RecruiterAndConfidential(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+
+	res, err := AddTrackedJob(ctx, JobTrackerAddInput{
+		Title:         "VP Engineering",
+		URL:           "https://example.com/jobs/vp",
+		Notes:         "Reports to the CEO",
+		RecruiterName: "Pat Lee",
+		RecruiterFirm: "Spencer Stuart",
+		Confidential:  true,
+	})
+	if err != nil {
+		t.Fatalf("AddTrackedJob: %v", err)
+	}
+	list, err := ListTrackedJobs(ctx, JobTrackerListInput{})
+	if err != nil || len(list.Jobs) != 1 {
+		t.Fatalf("ListTrackedJobs: %v %+v", err, list)
+	}
+	j := list.Jobs[0]
+	if j.Company != confidentialEmployer || !j.Confidential || j.Recruiter == nil || j.Recruiter.Firm != "Spencer Stuart" {
+		t.Fatalf("tracked job = %+v", j)
+	}
+
+	rows, _, err := exportRows(JobExportInput{})
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("exportRows: %v %v", err, rows)
+	}
+	if rows[0][5] != "" || rows[0][10] != "" {
+		t.Errorf("confidential row not masked: %v", rows[0])
+	}
+
+	open := false
+	if _, err := UpdateTrackedJob(ctx, JobTrackerUpdateInput{ID: res.ID, RecruiterEmail: "pat@example.com", Confidential: &open}); err != nil {
+		t.Fatalf("UpdateTrackedJob: %v", err)
+	}
+	list, _ = ListTrackedJobs(ctx, JobTrackerListInput{})
+	j = list.Jobs[0]
+	if j.Confidential || j.Recruiter.Email != "pat@example.com" || j.Recruiter.Name != "Pat Lee" {
+		t.Errorf("updated job = %+v", j)
+	}
+	rows, _, _ = exportRows(JobExportInput{})
+	if rows[0][5] == "" {
+		t.Errorf("non-confidential row masked: %v", rows[0])
+	}
+}
@@ -43,7 +43,7 @@ func buildTwitterJobQuery(query string) string {
 
 // searchViaSocial acquires an account from go-social, searches, and reports back.
 func searchViaSocial(ctx context.Context, query string, limit int) ([]*twitter.Tweet, error) {
-	sc := engine.Cfg.SocialClient
+	sc := engine.ConfigFrom(ctx).SocialClient
 	if sc == nil {
 		return nil, errors.New("social client not configured")
 	}
@@ -54,14 +54,14 @@ func searchViaSocial(ctx context.Context, query string, limit int) ([]*twitter.T
 // Fallback to local only happens when SocialClient is not configured at all.
 func searchTwitter(ctx context.Context, query string, limit int) ([]*twitter.Tweet, error) {
 	// Try go-social pool first
-	if engine.Cfg.SocialClient != nil {
+	if engine.ConfigFrom(ctx).SocialClient != nil {
 		tweets, err := searchViaSocial(ctx, query, limit)
 		if err == nil {
 			slog.Info("twitter search via go-social", slog.Int("tweets", len(tweets)))
 			return tweets, nil
 		}
 		// Social is configured but failed — try local if available, else return social error
-		tw := engine.Cfg.TwitterClient
+		tw := engine.ConfigFrom(ctx).TwitterClient
 		if tw != nil {
 			slog.Warn("go-social search failed, trying local", slog.Any("error", err))
 			return tw.SearchTimeline(ctx, query, limit)
@@ -70,7 +70,7 @@ func searchTwitter(ctx context.Context, query string, limit int) ([]*twitter.Twe
 	}
 
 	// No social client — use local twitter client directly
-	tw := engine.Cfg.TwitterClient
+	tw := engine.ConfigFrom(ctx).TwitterClient
 	if tw == nil {
 		return nil, errors.New("twitter not configured: no go-social and no local client")
 	}
@@ -31,34 +31,29 @@ func TestSearchTwitterJobsRaw_ViaSocial(t *testing.T) {
 	}))
 	defer socialSrv.Close()
 
-	engine.Cfg.SocialClient = social.NewClient(socialSrv.URL, "tok", "go-job")
-	engine.Cfg.TwitterClient = nil
-	defer func() { engine.Cfg.SocialClient = nil }()
+	ctx := engine.WithEngine(context.Background(), engine.New(engine.Config{
+		SocialClient: social.NewClient(socialSrv.URL, "tok", "go-job"),
+	}))
 
 	// Proves the social path is attempted (not the "not configured" fallback).
 	// May succeed or fail depending on whether fake creds happen to work.
-	_, err := SearchTwitterJobsRaw(context.Background(), "golang hiring", 5)
+	_, err := SearchTwitterJobsRaw(ctx, "golang hiring", 5)
 	if err != nil {
 		assert.NotContains(t, err.Error(), "not configured")
 	}
 }
 
 func TestSearchTwitterJobsRaw_FallbackToLocal(t *testing.T) {
-	engine.Cfg.SocialClient = nil
 	tw, _ := twitter.NewClient(twitter.ClientConfig{OpenAccountCount: 1})
-	engine.Cfg.TwitterClient = tw
-	defer func() { engine.Cfg.TwitterClient = nil }()
+	ctx := engine.WithEngine(context.Background(), engine.New(engine.Config{TwitterClient: tw}))
 
-	_, err := SearchTwitterJobsRaw(context.Background(), "test", 5)
+	_, err := SearchTwitterJobsRaw(ctx, "test", 5)
 	if err != nil {
 		assert.NotContains(t, err.Error(), "not configured")
 	}
 }
 
 func TestSearchTwitter_BothNil(t *testing.T) {
-	engine.Cfg.SocialClient = nil
-	engine.Cfg.TwitterClient = nil
-
 	_, err := searchTwitter(context.Background(), "test", 5)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not configured")
@@ -0,0 +1,348 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// USAJobs: US federal government vacancies from the USAJobs search API (free key from
+// developer.usajobs.gov). Occupational series, pay plan and grade map into the listing's
+// salary range and experience level.
+
+const (
+	usaJobsAPIURL = "https://data.usajobs.gov/api/search"
+	usaJobsSource = "USAJobs"
+)
+
+type usaJobsResponse struct {
+	SearchResult struct {
+		Items []struct {
+			Descriptor usaJobsDescriptor `json:"MatchedObjectDescriptor"`
+		} `json:"SearchResultItems"`
+	} `json:"SearchResult"`
+}
+
+type usaJobsDescriptor struct {
+	PositionID       string `json:"PositionID"`
+	PositionTitle    string `json:"PositionTitle"`
+	PositionURI      string `json:"PositionURI"`
+	PositionLocation string `json:"PositionLocationDisplay"`
+	OrganizationName string `json:"OrganizationName"`
+	DepartmentName   string `json:"DepartmentName"`
+	JobCategory      []struct {
+		Name string `json:"Name"`
+		Code string `json:"Code"`
+	} `json:"JobCategory"`
+	JobGrade []struct {
+		Code string `json:"Code"`
+	} `json:"JobGrade"`
+	PositionSchedule []struct {
+		Name string `json:"Name"`
+	} `json:"PositionSchedule"`
+	Remuneration []struct {
+		MinimumRange     string `json:"MinimumRange"`
+		MaximumRange     string `json:"MaximumRange"`
+		RateIntervalCode string `json:"RateIntervalCode"`
+	} `json:"PositionRemuneration"`
+	PublicationStartDate string `json:"PublicationStartDate"`
+	ApplicationCloseDate string `json:"ApplicationCloseDate"`
+	QualificationSummary string `json:"QualificationSummary"`
+	UserArea             struct {
+		Details struct {
+			JobSummary       string `json:"JobSummary"`
+			LowGrade         string `json:"LowGrade"`
+			HighGrade        string `json:"HighGrade"`
+			TeleworkEligible bool   `json:"TeleworkEligible"`
+			RemoteIndicator  bool   `json:"RemoteIndicator"`
+		} `json:"Details"`
+	} `json:"UserArea"`
+}
+
+// usaJobsGradeRanges are the GS grades searched for each job_search experience level.
+var usaJobsGradeRanges = map[string][2]int{
+	"internship": {1, 4},
+	"entry":      {5, 7},
+	"associate":  {9, 11},
+	"mid-senior": {12, 14},
+	"director":   {15, 15},
+}
+
+// SearchUSAJobs queries the USAJobs search API. Results are cached.
+func SearchUSAJobs(ctx context.Context, query, location, experience string, limit int) ([]engine.JobListing, error) {
+	if engine.ConfigFrom(ctx).USAJobsAPIKey == "" || engine.ConfigFrom(ctx).USAJobsEmail == "" {
+		return nil, errors.New("usajobs: USAJOBS_API_KEY and USAJOBS_EMAIL are not set")
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 25
+	}
+	cacheKey := engine.CacheKey("usajobs", query, location, experience, strconv.Itoa(limit))
+	if cached, ok := engine.CacheLoadJSON[[]engine.JobListing](ctx, cacheKey); ok {
+		return cached, nil
+	}
+	engine.IncrUSAJobsRequests(ctx)
+
+	params := url.Values{}
+	params.Set("Keyword", query)
+	params.Set("ResultsPerPage", strconv.Itoa(limit))
+	if location != "" && !strings.EqualFold(location, "remote") {
+		params.Set("LocationName", location)
+	}
+	if strings.EqualFold(location, "remote") {
+		params.Set("RemoteIndicator", "True")
+	}
+	if r, ok := usaJobsGradeRanges[strings.ToLower(experience)]; ok {
+		params.Set("PayGradeLow", fmt.Sprintf("%02d", r[0]))
+		params.Set("PayGradeHigh", fmt.Sprintf("%02d", r[1]))
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, usaJobsAPIURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	// USAJobs identifies API users by the email in User-Agent.
+	req.Header.Set("User-Agent", engine.ConfigFrom(ctx).USAJobsEmail)
+	req.Header.Set("Authorization-Key", engine.ConfigFrom(ctx).USAJobsAPIKey)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("usajobs request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("usajobs returned status %d", resp.StatusCode)
+	}
+
+	var listings []engine.JobListing
+	err = engine.ReadBody(resp.Body, 5*1024*1024, func(body []byte) (err error) {
+		listings, err = parseUSAJobsResponse(body)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	engine.CacheStoreJSON(ctx, cacheKey, query, listings)
+	slog.Debug("usajobs: search complete", slog.Int("results", len(listings)))
+	return listings, nil
+}
+
+func parseUSAJobsResponse(data []byte) ([]engine.JobListing, error) {
+	var resp usaJobsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("usajobs: JSON parse failed: %w", err)
+	}
+	listings := make([]engine.JobListing, 0, len(resp.SearchResult.Items))
+	for _, item := range resp.SearchResult.Items {
+		d := item.Descriptor
+		if d.PositionURI == "" || d.PositionTitle == "" {
+			continue
+		}
+		j := engine.JobListing{
+			Title:    d.PositionTitle,
+			Company:  d.OrganizationName,
+			URL:      d.PositionURI,
+			JobID:    d.PositionID,
+			Source:   usaJobsSource,
+			Location: d.PositionLocation,
+			Posted:   dateOnly(d.PublicationStartDate),
+			Deadline: dateOnly(d.ApplicationCloseDate),
+		}
+		if j.Deadline != "" {
+			j.DeadlineKind = "application"
+		}
+		if j.Company == "" {
+			j.Company = d.DepartmentName
+		}
+		switch {
+		case d.UserArea.Details.RemoteIndicator:
+			j.Remote = "remote"
+		case d.UserArea.Details.TeleworkEligible:
+			j.Remote = "hybrid"
+		default:
+			j.Remote = "onsite"
+		}
+		if len(d.PositionSchedule) > 0 {
+			j.JobType = d.PositionSchedule[0].Name
+		}
+		if len(d.Remuneration) > 0 {
+			r := d.Remuneration[0]
+			j.SalaryMin, j.SalaryMax = parseUSAJobsPay(r.MinimumRange), parseUSAJobsPay(r.MaximumRange)
+			j.SalaryInterval = usaJobsInterval(r.RateIntervalCode)
+			if j.SalaryMin != nil || j.SalaryMax != nil {
+				j.SalaryCurrency = "USD"
+				j.Salary = formatRemoteSalary(derefInt(j.SalaryMin), derefInt(j.SalaryMax)) + " per " + j.SalaryInterval
+			}
+		}
+		j.Experience = usaJobsExperience(usaJobsPayPlan(d), d.UserArea.Details.LowGrade, d.UserArea.Details.HighGrade)
+		j.Description = usaJobsDescription(d)
+		listings = append(listings, j)
+	}
+	return listings, nil
+}
+
+// parseUSAJobsPay reads a pay amount such as "86962.0"; nil when absent or zero.
+func parseUSAJobsPay(s string) *int {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || v <= 0 {
+		return nil
+	}
+	n := int(v)
+	return &n
+}
+
+// usaJobsInterval maps a USAJobs rate interval ("PA", "Per Year", "PH", ...) to the
+// listing salary interval.
+func usaJobsInterval(code string) string {
+	switch strings.ToLower(strings.TrimSpace(code)) {
+	case "ph", "per hour":
+		return "hour"
+	case "pm", "per month":
+		return "month"
+	}
+	return "year"
+}
+
+// usaJobsPayPlan returns the pay plan code, e.g. "GS", "GG", "ES" or "WG".
+func usaJobsPayPlan(d usaJobsDescriptor) string {
+	if len(d.JobGrade) == 0 {
+		return ""
+	}
+	return strings.ToUpper(d.JobGrade[0].Code)
+}
+
+// usaJobsExperience maps a pay plan and grade range to a job_search experience level.
+// Senior Executive Service plans are executive; General Schedule-style grades follow
+// the GS ladder (5-7 entry, 8-11 associate, 12-14 mid-senior, 15 director). Other plans
+// (wage grade, pay bands) are left unmapped.
+func usaJobsExperience(plan, lowGrade, highGrade string) string {
+	switch plan {
+	case "ES", "SES", "SL", "ST":
+		return "executive"
+	case "GS", "GG", "GM", "GL":
+	default:
+		return ""
+	}
+	grade, err := strconv.Atoi(strings.TrimLeft(highGrade, "0"))
+	if err != nil {
+		if grade, err = strconv.Atoi(strings.TrimLeft(lowGrade, "0")); err != nil {
+			return ""
+		}
+	}
+	switch {
+	case grade <= 4:
+		return "internship"
+	case grade <= 7:
+		return "entry"
+	case grade <= 11:
+		return "associate"
+	case grade <= 14:
+		return "mid-senior"
+	}
+	return "director"
+}
+
+// usaJobsDescription renders the series, grade and summary for the job summarizer.
+func usaJobsDescription(d usaJobsDescriptor) string {
+	var parts []string
+	for _, c := range d.JobCategory {
+		parts = append(parts, fmt.Sprintf("Series %s %s", c.Code, c.Name))
+	}
+	if plan := usaJobsPayPlan(d); plan != "" {
+		grade := d.UserArea.Details.LowGrade
+		if hi := d.UserArea.Details.HighGrade; hi != "" && hi != grade {
+			grade += "-" + hi
+		}
+		parts = append(parts, strings.TrimSuffix(plan+"-"+grade, "-"))
+	}
+	if d.DepartmentName != "" && d.DepartmentName != d.OrganizationName {
+		parts = append(parts, d.DepartmentName)
+	}
+	desc := strings.Join(parts, "; ")
+	summary := d.UserArea.Details.JobSummary
+	if summary == "" {
+		summary = d.QualificationSummary
+	}
+	if summary != "" {
+		desc = strings.TrimPrefix(desc+"\n\n"+engine.TrimContent(summary, 2000), "\n\n")
+	}
+	return desc
+}
+
+func derefInt(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// USAJobsListingsToSearxngResults renders USAJobs listings in the "**Field:** value"
+// form the job summarizer reads.
+func USAJobsListingsToSearxngResults(listings []engine.JobListing) []engine.SearxngResult {
+	results := make([]engine.SearxngResult, 0, len(listings))
+	for _, j := range listings {
+		parts := []string{"**Source:** " + usaJobsSource, "**Company:** " + j.Company, "**Location:** " + j.Location}
+		if j.Salary != "" {
+			parts = append(parts, "**Salary:** "+j.Salary)
+		}
+		if j.Experience != "" {
+			parts = append(parts, "**Level:** "+j.Experience)
+		}
+		if j.Remote != "" {
+			parts = append(parts, "**Remote:** "+j.Remote)
+		}
+		if j.Posted != "" {
+			parts = append(parts, "**Posted:** "+j.Posted)
+		}
+		if j.Deadline != "" {
+			parts = append(parts, "**Apply by:** "+j.Deadline)
+		}
+		results = append(results, engine.SearxngResult{
+			Title:   j.Title + " at " + j.Company,
+			URL:     j.URL,
+			Content: strings.Join(parts, " | ") + "\n\n" + j.Description,
+			Score:   0.85,
+		})
+	}
+	return results
+}
+
+// MergeUSAJobsListing overwrites the pay, level and deadline the LLM summary read from
+// text with the structured USAJobs fields for the same URL.
+func MergeUSAJobsListing(dst *engine.JobListing, src engine.JobListing) {
+	if dst.Company == "" {
+		dst.Company = src.Company
+	}
+	if dst.Location == "" {
+		dst.Location = src.Location
+	}
+	if dst.Posted == "" || dst.Posted == "not specified" {
+		dst.Posted = src.Posted
+	}
+	if src.Remote != "" {
+		dst.Remote = src.Remote
+	}
+	if src.Experience != "" {
+		dst.Experience = src.Experience
+	}
+	if src.SalaryMin != nil || src.SalaryMax != nil {
+		dst.SalaryMin, dst.SalaryMax = src.SalaryMin, src.SalaryMax
+		dst.SalaryCurrency, dst.SalaryInterval = src.SalaryCurrency, src.SalaryInterval
+		if dst.Salary == "" || dst.Salary == "not specified" {
+			dst.Salary = src.Salary
+		}
+	}
+	if src.Deadline != "" {
+		dst.Deadline, dst.DeadlineKind = src.Deadline, src.DeadlineKind
+	}
+	dst.JobID = src.JobID
+	dst.Source = src.Source
+}
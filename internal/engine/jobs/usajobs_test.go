@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+const usaJobsFixture = `{"SearchResult":{"SearchResultItems":[
+	{"MatchedObjectDescriptor":{
+		"PositionID":"CISA-123","PositionTitle":"IT Specialist (APPSW)",
+		"PositionURI":"https://www.usajobs.gov/job/812345600",
+		"PositionLocationDisplay":"Arlington, Virginia",
+		"OrganizationName":"Cybersecurity and Infrastructure Security Agency",
+		"DepartmentName":"Department of Homeland Security",
+		"JobCategory":[{"Name":"Information Technology Management","Code":"2210"}],
+		"JobGrade":[{"Code":"GS"}],
+		"PositionSchedule":[{"Name":"Full-time"}],
+		"PositionRemuneration":[{"MinimumRange":"117962.0","MaximumRange":"153354.0","RateIntervalCode":"PA"}],
+		"PublicationStartDate":"2026-10-01T00:00:00.0000","ApplicationCloseDate":"2026-10-20T23:59:59.9970",
+		"UserArea":{"Details":{"JobSummary":"Build and maintain mission applications.","LowGrade":"13","HighGrade":"13","TeleworkEligible":true}}}},
+	{"MatchedObjectDescriptor":{
+		"PositionTitle":"Maintenance Mechanic","PositionURI":"https://www.usajobs.gov/job/812345601",
+		"OrganizationName":"National Park Service","JobGrade":[{"Code":"WG"}],
+		"PositionRemuneration":[{"MinimumRange":"28.5","MaximumRange":"33.25","RateIntervalCode":"Per Hour"}],
+		"UserArea":{"Details":{"LowGrade":"10","HighGrade":"10"}}}}
+]}}`
+
+func TestParseUSAJobsResponse(t *testing.T) {
+	listings, err := parseUSAJobsResponse([]byte(usaJobsFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listings) != 2 {
+		t.Fatalf("got %d listings", len(listings))
+	}
+	it := listings[0]
+	if it.Experience != "mid-senior" || it.Remote != "hybrid" || it.JobType != "Full-time" {
+		t.Errorf("GS-13 listing = %+v", it)
+	}
+	if it.SalaryMin == nil || *it.SalaryMin != 117962 || *it.SalaryMax != 153354 || it.SalaryInterval != "year" || it.SalaryCurrency != "USD" {
+		t.Errorf("salary = %v-%v %s/%s", it.SalaryMin, it.SalaryMax, it.SalaryCurrency, it.SalaryInterval)
+	}
+	if it.Deadline != "2026-10-20" || it.Posted != "2026-10-01" {
+		t.Errorf("dates: posted %q, deadline %q", it.Posted, it.Deadline)
+	}
+	if !strings.Contains(it.Description, "Series 2210") || !strings.Contains(it.Description, "GS-13") {
+		t.Errorf("description = %q", it.Description)
+	}
+
+	wg := listings[1]
+	if wg.Experience != "" || wg.SalaryInterval != "hour" || *wg.SalaryMin != 28 {
+		t.Errorf("wage grade listing = %+v", wg)
+	}
+	BuildListingV2(listings[:1])
+	if n := listings[0].SalaryNorm; n == nil || *n.AnnualMax != 153354 {
+		t.Errorf("salary_normalized = %+v", n)
+	}
+}
+
+func TestUSAJobsExperience(t *testing.T) {
+	for _, tc := range []struct{ plan, low, high, want string }{
+		{"GS", "07", "09", "associate"},
+		{"GS", "05", "", "entry"},
+		{"GS", "15", "15", "director"},
+		{"ES", "", "", "executive"},
+		{"NH", "03", "03", ""},
+	} {
+		if got := usaJobsExperience(tc.plan, tc.low, tc.high); got != tc.want {
+			t.Errorf("usaJobsExperience(%s, %s, %s) = %q, want %q", tc.plan, tc.low, tc.high, got, tc.want)
+		}
+	}
+
+	dst := engine.JobListing{Title: "IT Specialist", Salary: "$117k-$153k", Experience: "senior"}
+	src := engine.JobListing{Experience: "mid-senior", Source: usaJobsSource, Deadline: "2026-10-20", DeadlineKind: "application"}
+	MergeUSAJobsListing(&dst, src)
+	if dst.Experience != "mid-senior" || dst.Deadline != "2026-10-20" || dst.Source != usaJobsSource {
+		t.Errorf("merged = %+v", dst)
+	}
+}
@@ -20,11 +20,11 @@ type vaelorToolRequest struct {
 
 // SendTelegramNotification sends a message via vaelor's message tool.
 func SendTelegramNotification(ctx context.Context, message string) error {
-	baseURL := engine.Cfg.VaelorNotifyURL
+	baseURL := engine.ConfigFrom(ctx).VaelorNotifyURL
 	if baseURL == "" {
 		return fmt.Errorf("VAELOR_NOTIFY_URL not configured")
 	}
-	chatID := engine.Cfg.BountyNotifyChatID
+	chatID := engine.ConfigFrom(ctx).BountyNotifyChatID
 	if chatID == "" {
 		chatID = "428660"
 	}
@@ -42,7 +42,7 @@ func SendTelegramNotification(ctx context.Context, message string) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := engine.Cfg.HTTPClient.Do(req)
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("vaelor notify failed: %w", err)
 	}
@@ -0,0 +1,218 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// vectorsListLimit bounds how many MemDB entries a resync inspects.
+const vectorsListLimit = 2000
+
+// resumeVectorTypes are the info types master_resume_build writes, one vector per SQL record.
+// Other memories (agent notes, goals) are never touched by a resync.
+var resumeVectorTypes = map[string]bool{
+	"experience": true, "project": true, "achievement": true,
+	"publication": true, "talk": true, "patent": true, "open_source": true,
+}
+
+// vectorKey identifies the SQL record behind a vector.
+type vectorKey struct {
+	Type string
+	ID   int
+}
+
+// VectorsResyncResult is the structured output of vectors_resync.
+type VectorsResyncResult struct {
+	Expected   int    `json:"expected"`   // vectors implied by ResumeDB records
+	Present    int    `json:"present"`    // resume vectors found in MemDB
+	Missing    int    `json:"missing"`    // records without a vector
+	Orphans    int    `json:"orphans"`    // vectors whose record no longer exists
+	Duplicates int    `json:"duplicates"` // extra vectors for the same record
+	Added      int    `json:"added"`
+	Removed    int    `json:"removed"`
+	Failed     int    `json:"failed"`
+	DryRun     bool   `json:"dry_run"`
+	Summary    string `json:"summary"`
+}
+
+// ResyncVectors compares ResumeDB records against MemDB resume vectors by (type, id),
+// re-adds missing vectors and deletes orphans and duplicates. With dryRun it only reports.
+func ResyncVectors(ctx context.Context, dryRun bool) (*VectorsResyncResult, error) {
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return nil, errors.New("resume database not configured (set DATABASE_URL)")
+	}
+	mdb := StoresFrom(ctx).MemDB
+	if mdb == nil {
+		return nil, errors.New("MemDB not configured (set MEMDB_URL)")
+	}
+
+	personID := db.GetLatestPersonID(ctx)
+	expected, err := expectedResumeVectors(ctx, db, personID)
+	if err != nil {
+		return nil, fmt.Errorf("vectors_resync: %w", err)
+	}
+	present, err := mdb.ListAll(ctx, vectorsListLimit)
+	if err != nil {
+		return nil, fmt.Errorf("vectors_resync: %w", err)
+	}
+
+	missing, orphans, dupes, found := diffVectors(expected, present)
+	res := &VectorsResyncResult{
+		Expected:   len(expected),
+		Present:    found,
+		Missing:    len(missing),
+		Orphans:    len(orphans) - dupes,
+		Duplicates: dupes,
+		DryRun:     dryRun,
+	}
+
+	if !dryRun {
+		if len(orphans) > 0 {
+			if err := mdb.DeleteByUser(ctx, orphans); err != nil {
+				slog.Warn("vectors_resync: delete failed", slog.Any("error", err))
+				res.Failed += len(orphans)
+			} else {
+				res.Removed = len(orphans)
+			}
+		}
+		for _, err := range mdb.AddBatch(ctx, missing) {
+			if err != nil {
+				slog.Debug("memdb add failed", slog.Any("error", err))
+				res.Failed++
+				continue
+			}
+			res.Added++
+		}
+		if res.Failed == 0 && personID > 0 {
+			if err := db.MarkVectorsSynced(ctx, personID); err != nil {
+				slog.Debug("mark vectors synced failed", slog.Int("person_id", personID), slog.Any("error", err))
+			}
+		}
+	}
+
+	verb := "Resynced"
+	if dryRun {
+		verb = "Dry run"
+	}
+	res.Summary = fmt.Sprintf("%s: %d expected, %d present, %d missing, %d orphans, %d duplicates. Added %d, removed %d, failed %d.",
+		verb, res.Expected, res.Present, res.Missing, res.Orphans, res.Duplicates, res.Added, res.Removed, res.Failed)
+	return res, nil
+}
+
+// expectedResumeVectors returns the vector each ResumeDB record should have. Texts come from
+// the stored build plan when available so re-added vectors match the original build exactly.
+func expectedResumeVectors(ctx context.Context, db *ResumeDB, personID int) (map[vectorKey]MemDBItem, error) {
+	expected := make(map[vectorKey]MemDBItem)
+	if personID == 0 {
+		return expected, nil
+	}
+
+	planned := make(map[vectorKey]MemDBItem)
+	if raw, err := db.LoadBuildPlan(ctx, personID); err == nil && len(raw) > 0 {
+		var plan buildPlan
+		if err := json.Unmarshal(raw, &plan); err == nil {
+			for _, ve := range plan.Vectors {
+				typ, id := memoryInfoRef(ve.Info)
+				planned[vectorKey{typ, id}] = ve
+			}
+		}
+	}
+	add := func(typ string, id int, content string) {
+		k := vectorKey{typ, id}
+		if ve, ok := planned[k]; ok {
+			expected[k] = ve
+			return
+		}
+		expected[k] = MemDBItem{Content: content, Info: map[string]any{"type": typ, "id": float64(id)}}
+	}
+
+	exps, err := db.GetAllExperiences(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("load experiences: %w", err)
+	}
+	for _, e := range exps {
+		add("experience", e.ID, formatExperienceTextExtended(e.Title, e.Company, e.StartDate, e.EndDate, e.Description, e.Highlights, e.Domain))
+	}
+	projs, err := db.GetAllProjects(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("load projects: %w", err)
+	}
+	for _, p := range projs {
+		add("project", p.ID, formatProjectText(p.Name, p.Description, p.Tech, p.Highlights))
+	}
+	achvs, err := db.GetAllAchievements(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("load achievements: %w", err)
+	}
+	for _, a := range achvs {
+		add("achievement", a.ID, a.Text)
+	}
+	works, err := loadResumeWorks(ctx, db, personID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range works.Publications {
+		add("publication", p.ID, formatPublicationText(p))
+	}
+	for _, t := range works.Talks {
+		add("talk", t.ID, formatTalkText(t))
+	}
+	for _, p := range works.Patents {
+		add("patent", p.ID, formatPatentText(p))
+	}
+	for _, o := range works.OpenSource {
+		add("open_source", o.ID, formatOpenSourceText(o))
+	}
+	return expected, nil
+}
+
+// diffVectors matches MemDB entries to expected vectors by (type, id). It returns the vectors
+// to add (sorted by type and id), the memory IDs to delete (orphans plus duplicates), the
+// duplicate count and how many resume vectors were found in MemDB.
+func diffVectors(expected map[vectorKey]MemDBItem, present []MemDBSearchResult) (missing []MemDBItem, remove []string, dupes, found int) {
+	have := make(map[vectorKey]bool)
+	for _, r := range present {
+		typ, id := memoryInfoRef(r.Info)
+		if !resumeVectorTypes[typ] || id == 0 {
+			continue
+		}
+		found++
+		k := vectorKey{typ, id}
+		switch {
+		case have[k]:
+			remove = append(remove, r.MemoryID)
+			dupes++
+		case !hasVectorKey(expected, k):
+			remove = append(remove, r.MemoryID)
+		default:
+			have[k] = true
+		}
+	}
+
+	keys := make([]vectorKey, 0, len(expected))
+	for k := range expected {
+		if !have[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Type != keys[j].Type {
+			return keys[i].Type < keys[j].Type
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	for _, k := range keys {
+		missing = append(missing, expected[k])
+	}
+	return missing, remove, dupes, found
+}
+
+func hasVectorKey(m map[vectorKey]MemDBItem, k vectorKey) bool {
+	_, ok := m[k]
+	return ok
+}
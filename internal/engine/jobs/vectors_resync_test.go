@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffVectors(t *testing.T) {
+	expected := map[vectorKey]MemDBItem{
+		{"experience", 1}:  {Content: "exp 1"},
+		{"project", 2}:     {Content: "proj 2"},
+		{"achievement", 3}: {Content: "achv 3"},
+	}
+	present := []MemDBSearchResult{
+		{MemoryID: "m1", Info: map[string]any{"type": "experience", "id": float64(1)}},
+		{MemoryID: "m2", Info: map[string]any{"type": "experience", "id": float64(1)}}, // duplicate
+		{MemoryID: "m3", Info: map[string]any{"type": "project", "id": "9"}},           // orphan
+		{MemoryID: "m4", Info: map[string]any{"type": "note", "source": "agent"}},      // not a resume vector
+		{MemoryID: "m5", Info: map[string]any{"type": "achievement", "id": float64(3)}},
+	}
+
+	missing, remove, dupes, found := diffVectors(expected, present)
+	if len(missing) != 1 || missing[0].Content != "proj 2" {
+		t.Errorf("missing = %+v, want [proj 2]", missing)
+	}
+	slices.Sort(remove)
+	if !slices.Equal(remove, []string{"m2", "m3"}) {
+		t.Errorf("remove = %v, want [m2 m3]", remove)
+	}
+	if dupes != 1 || found != 4 {
+		t.Errorf("dupes = %d, found = %d; want 1, 4", dupes, found)
+	}
+}
@@ -0,0 +1,321 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WeeklyReviewInput is the input for weekly_review.
+type WeeklyReviewInput struct {
+	Days int `json:"days,omitempty" jsonschema:"Review window in days (default 7, max 31)"`
+}
+
+// WeeklyReviewResult is the output of weekly_review: structured sections plus a markdown rendering.
+type WeeklyReviewResult struct {
+	From       string             `json:"from"`
+	To         string             `json:"to"`
+	NewMatches ReviewNewMatches   `json:"new_matches"`
+	Funnel     []ReviewFunnelStep `json:"funnel"`
+	FollowUps  []TrackedJob       `json:"follow_ups"`
+	SkillGaps  []ReviewSkillGap   `json:"skill_gaps"`
+	Rejections *ReviewRejections  `json:"rejections,omitempty"` // learnings from rejection_retro
+	Actions    []string           `json:"actions"`
+	Markdown   string             `json:"markdown"`
+}
+
+// ReviewNewMatches summarizes listings first seen by job_search during the window.
+type ReviewNewMatches struct {
+	Total     int                  `json:"total"`
+	ByCompany []ReviewCompanyMatch `json:"by_company,omitempty"`
+}
+
+// ReviewCompanyMatch is one company's new listings.
+type ReviewCompanyMatch struct {
+	Company string   `json:"company"`
+	Count   int      `json:"count"`
+	Titles  []string `json:"titles"` // up to 3
+}
+
+// ReviewFunnelStep is one tracker status with its movement during the window.
+type ReviewFunnelStep struct {
+	Status  JobStatus `json:"status"`
+	Total   int       `json:"total"`
+	Added   int       `json:"added"`   // created during the window with this status
+	Updated int       `json:"updated"` // existing jobs moved or edited into this status during the window
+}
+
+// ReviewSkillGap is a skill in demand across recent listings.
+type ReviewSkillGap struct {
+	Skill    string `json:"skill"`
+	Listings int    `json:"listings"`
+}
+
+// reviewStatuses is the funnel order.
+var reviewStatuses = []JobStatus{StatusSaved, StatusApplied, StatusInterview, StatusOffer, StatusRejected}
+
+// WeeklyReview composes the tracker, seen-jobs store, last job_search and master
+// resume into a weekly job hunt report. The master resume is optional; without it
+// skill gaps list the most demanded skills.
+func WeeklyReview(ctx context.Context, input WeeklyReviewInput) (*WeeklyReviewResult, error) {
+	days := input.Days
+	if days <= 0 || days > 31 {
+		days = 7
+	}
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -days)
+
+	result := &WeeklyReviewResult{From: since.Format(time.DateOnly), To: now.Format(time.DateOnly)}
+
+	var err error
+	if result.NewMatches, err = reviewNewMatches(ctx, since); err != nil {
+		return nil, err
+	}
+
+	tracked, err := allTrackedJobs("")
+	if err != nil {
+		return nil, err
+	}
+	sinceStr := since.Format(time.RFC3339)
+	horizon := now.AddDate(0, 0, 7).Format(time.RFC3339)
+	steps := make(map[JobStatus]*ReviewFunnelStep)
+	for _, s := range reviewStatuses {
+		result.Funnel = append(result.Funnel, ReviewFunnelStep{Status: s})
+	}
+	for i := range result.Funnel {
+		steps[result.Funnel[i].Status] = &result.Funnel[i]
+	}
+	for _, j := range tracked {
+		step := steps[j.Status]
+		if step == nil {
+			continue
+		}
+		step.Total++
+		switch {
+		case j.CreatedAt >= sinceStr:
+			step.Added++
+		case j.UpdatedAt >= sinceStr:
+			step.Updated++
+		}
+		if j.FollowUp != "" && j.FollowUp <= horizon && j.Status != StatusRejected && j.Status != StatusOffer {
+			result.FollowUps = append(result.FollowUps, j)
+		}
+	}
+	sort.Slice(result.FollowUps, func(a, b int) bool { return result.FollowUps[a].FollowUp < result.FollowUps[b].FollowUp })
+
+	var resumeKW map[string]bool
+	if feed, err := BuildResumeFeed(ctx); err == nil {
+		resumeKW = ExtractResumeKeywords(ResumeFeedText(feed))
+	}
+	_, listings := lastSearch()
+	demand := make(map[string]int)
+	for _, l := range listings {
+		seen := make(map[string]bool)
+		for _, s := range l.Skills {
+			key := strings.ToLower(strings.TrimSpace(s))
+			if key == "" || seen[key] || resumeKW[key] {
+				continue
+			}
+			seen[key] = true
+			demand[key]++
+		}
+	}
+	for s, n := range demand {
+		result.SkillGaps = append(result.SkillGaps, ReviewSkillGap{Skill: s, Listings: n})
+	}
+	sort.Slice(result.SkillGaps, func(a, b int) bool {
+		if result.SkillGaps[a].Listings != result.SkillGaps[b].Listings {
+			return result.SkillGaps[a].Listings > result.SkillGaps[b].Listings
+		}
+		return result.SkillGaps[a].Skill < result.SkillGaps[b].Skill
+	})
+	if len(result.SkillGaps) > 10 {
+		result.SkillGaps = result.SkillGaps[:10]
+	}
+
+	db, err := openTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+	if result.Rejections, err = reviewRejections(db); err != nil {
+		return nil, err
+	}
+
+	result.Actions = reviewActions(result, tracked, now)
+	result.Markdown = renderWeeklyReview(result, resumeKW != nil)
+	return result, nil
+}
+
+// reviewNewMatches groups seen-jobs listings first sighted since the given time by company.
+func reviewNewMatches(ctx context.Context, since time.Time) (ReviewNewMatches, error) {
+	var out ReviewNewMatches
+	db, err := openTrackerDB()
+	if err != nil {
+		return out, err
+	}
+	rows, err := db.QueryContext(ctx, `SELECT title, company FROM seen_jobs WHERE first_seen >= ? ORDER BY first_seen DESC`,
+		since.Format(time.RFC3339))
+	if err != nil {
+		return out, fmt.Errorf("weekly_review: new matches: %w", err)
+	}
+	defer rows.Close()
+
+	byCompany := make(map[string]*ReviewCompanyMatch)
+	for rows.Next() {
+		var title, company string
+		if err := rows.Scan(&title, &company); err != nil {
+			return out, fmt.Errorf("weekly_review: scan: %w", err)
+		}
+		out.Total++
+		if company == "" {
+			company = "Unknown"
+		}
+		m := byCompany[company]
+		if m == nil {
+			m = &ReviewCompanyMatch{Company: company}
+			byCompany[company] = m
+		}
+		m.Count++
+		if len(m.Titles) < 3 {
+			m.Titles = append(m.Titles, title)
+		}
+	}
+	for _, m := range byCompany {
+		out.ByCompany = append(out.ByCompany, *m)
+	}
+	sort.Slice(out.ByCompany, func(a, b int) bool {
+		if out.ByCompany[a].Count != out.ByCompany[b].Count {
+			return out.ByCompany[a].Count > out.ByCompany[b].Count
+		}
+		return out.ByCompany[a].Company < out.ByCompany[b].Company
+	})
+	if len(out.ByCompany) > 15 {
+		out.ByCompany = out.ByCompany[:15]
+	}
+	return out, rows.Err()
+}
+
+// reviewActions suggests next steps from the report sections.
+func reviewActions(r *WeeklyReviewResult, tracked []TrackedJob, now time.Time) []string {
+	var actions []string
+	nowStr := now.Format(time.RFC3339)
+	overdue := 0
+	for _, j := range r.FollowUps {
+		if j.FollowUp <= nowStr {
+			overdue++
+		}
+	}
+	if overdue > 0 {
+		actions = append(actions, fmt.Sprintf("Follow up on %d overdue application(s) — see follow_ups.", overdue))
+	}
+
+	staleCutoff := now.AddDate(0, 0, -7).Format(time.RFC3339)
+	stale := 0
+	for _, j := range tracked {
+		if j.Status == StatusSaved && j.UpdatedAt < staleCutoff {
+			stale++
+		}
+	}
+	if stale > 0 {
+		actions = append(actions, fmt.Sprintf("Apply to or drop %d job(s) saved more than a week ago.", stale))
+	}
+
+	for _, step := range r.Funnel {
+		if step.Status == StatusInterview && step.Total > 0 {
+			actions = append(actions, fmt.Sprintf("Prepare for %d interview(s) with interview_prep.", step.Total))
+		}
+		if step.Status == StatusOffer && step.Total > 1 {
+			actions = append(actions, "Compare your offers with offer_compare.")
+		}
+	}
+	if len(r.SkillGaps) > 0 {
+		actions = append(actions, fmt.Sprintf("Close the top skill gap: %s appears in %d recent listing(s) — run skill_gap against one of them.",
+			r.SkillGaps[0].Skill, r.SkillGaps[0].Listings))
+	}
+	if r.NewMatches.Total == 0 {
+		actions = append(actions, "No new listings this period — run job_search with broader filters.")
+	}
+
+	pending := 0
+	for _, j := range tracked {
+		if j.Status == StatusRejected && (r.Rejections == nil || !r.Rejections.jobIDs[j.ID]) {
+			pending++
+		}
+	}
+	if pending > 0 {
+		actions = append(actions, fmt.Sprintf("Run rejection_retro on %d rejected application(s) to learn what screened them out.", pending))
+	}
+	if rj := r.Rejections; rj != nil {
+		if len(rj.RecurringGaps) > 0 {
+			actions = append(actions, fmt.Sprintf("%s was missing in %d rejected applications — add evidence of it to your resume or skip roles that require it.",
+				rj.RecurringGaps[0].Keyword, rj.RecurringGaps[0].Rejections))
+		}
+		if rj.SeniorityMismatches["under"] >= 2 {
+			actions = append(actions, fmt.Sprintf("%d rejections were for roles a level above your resume — target your current level or make your scope more visible.",
+				rj.SeniorityMismatches["under"]))
+		}
+	}
+	return actions
+}
+
+// renderWeeklyReview renders the report as markdown.
+func renderWeeklyReview(r *WeeklyReviewResult, haveResume bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Job hunt review %s – %s\n\n", r.From, r.To)
+
+	fmt.Fprintf(&b, "## New matches (%d)\n\n", r.NewMatches.Total)
+	for _, m := range r.NewMatches.ByCompany {
+		fmt.Fprintf(&b, "- **%s** (%d): %s\n", m.Company, m.Count, strings.Join(m.Titles, "; "))
+	}
+	if r.NewMatches.Total == 0 {
+		b.WriteString("No new listings seen.\n")
+	}
+
+	b.WriteString("\n## Tracker funnel\n\n| Status | Total | Added | Moved |\n|---|---|---|---|\n")
+	for _, s := range r.Funnel {
+		fmt.Fprintf(&b, "| %s | %d | %d | %d |\n", s.Status, s.Total, s.Added, s.Updated)
+	}
+
+	fmt.Fprintf(&b, "\n## Follow-ups due (%d)\n\n", len(r.FollowUps))
+	for _, j := range r.FollowUps {
+		due := j.FollowUp
+		if len(due) >= 10 {
+			due = due[:10]
+		}
+		fmt.Fprintf(&b, "- %s — %s at %s (#%d, %s)\n", due, j.Title, j.Company, j.ID, j.Status)
+	}
+
+	b.WriteString("\n## Skill gaps in recent listings\n\n")
+	if !haveResume {
+		b.WriteString("_Master resume not available — showing the most demanded skills._\n\n")
+	}
+	for _, g := range r.SkillGaps {
+		fmt.Fprintf(&b, "- %s (%d listings)\n", g.Skill, g.Listings)
+	}
+	if len(r.SkillGaps) == 0 {
+		b.WriteString("No skill data — run job_search first.\n")
+	}
+
+	if rj := r.Rejections; rj != nil {
+		fmt.Fprintf(&b, "\n## Rejection learnings (%d retros)\n\n", rj.Retros)
+		for _, g := range rj.RecurringGaps {
+			fmt.Fprintf(&b, "- Missing in %d rejections: %s\n", g.Rejections, g.Keyword)
+		}
+		for _, kind := range []string{"under", "over"} {
+			if n := rj.SeniorityMismatches[kind]; n > 0 {
+				fmt.Fprintf(&b, "- Seniority mismatch (%s-leveled): %d\n", kind, n)
+			}
+		}
+		for _, l := range rj.RecentLearnings {
+			fmt.Fprintf(&b, "- %s\n", l)
+		}
+	}
+
+	b.WriteString("\n## Suggested actions\n\n")
+	for _, a := range r.Actions {
+		fmt.Fprintf(&b, "- %s\n", a)
+	}
+	return b.String()
+}
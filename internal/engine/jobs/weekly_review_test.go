@@ -0,0 +1,108 @@
+package jobs
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestWeeklyReview(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+	t.Cleanup(func() { SetLastSearch("", nil) })
+
+	for _, in := range []JobTrackerAddInput{
+		{Title: "Go Dev", Company: "Acme", Status: "interview"},
+		{Title: "SRE", Company: "Initech", Status: "applied", Deadline: time.Now().AddDate(0, 0, 2).Format(time.DateOnly)},
+		{Title: "Rust Dev", Company: "Globex"},
+	} {
+		if _, err := AddTrackedJob(ctx, in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	listings := []engine.JobListing{
+		{Title: "Backend Engineer", Company: "Acme", Skills: []string{"Go", "Kafka"}},
+		{Title: "Platform Engineer", Company: "Acme", Skills: []string{"kafka", "Terraform"}},
+	}
+	if _, err := RecordSeenJob(ctx, listings[0], time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RecordSeenJob(ctx, listings[1], time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	SetLastSearch("go", listings)
+
+	r, err := WeeklyReview(ctx, WeeklyReviewInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.NewMatches.Total != 2 || len(r.NewMatches.ByCompany) != 1 || r.NewMatches.ByCompany[0].Count != 2 {
+		t.Errorf("unexpected new matches: %+v", r.NewMatches)
+	}
+	if r.Funnel[0].Status != StatusSaved || r.Funnel[0].Added != 1 || r.Funnel[2].Total != 1 {
+		t.Errorf("unexpected funnel: %+v", r.Funnel)
+	}
+	if len(r.FollowUps) != 1 || r.FollowUps[0].Company != "Initech" {
+		t.Errorf("expected Initech follow-up, got %+v", r.FollowUps)
+	}
+	if len(r.SkillGaps) == 0 || r.SkillGaps[0] != (ReviewSkillGap{Skill: "kafka", Listings: 2}) {
+		t.Errorf("expected kafka as top gap, got %+v", r.SkillGaps)
+	}
+	for _, want := range []string{"## New matches (2)", "| interview | 1 | 1 | 0 |", "interview_prep", "kafka"} {
+		if !strings.Contains(r.Markdown, want) {
+			t.Errorf("markdown missing %q", want)
+		}
+	}
+}
+
+func TestWeeklyReviewRejections(t *testing.T) {
+	resetTracker(t)
+	ctx := context.Background()
+
+	db, err := openTrackerDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int64
+	for _, c := range []string{"Acme", "Globex", "Initech"} {
+		added, err := AddTrackedJob(ctx, JobTrackerAddInput{Title: "Senior Go Dev", Company: c, Status: "rejected"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, added.ID)
+	}
+	for i, missing := range [][]string{{"kubernetes", "grpc"}, {"kubernetes"}} {
+		r := &RejectionRetroResult{ID: ids[i], MissingKeywords: missing, SeniorityMismatch: "under", Learnings: []string{"Lead with platform work"}}
+		if err := saveRejectionRetro(db, r, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := WeeklyReview(ctx, WeeklyReviewInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rj := r.Rejections
+	if rj == nil || rj.Retros != 2 || len(rj.RecurringGaps) != 1 || rj.RecurringGaps[0] != (ReviewRejectionGap{Keyword: "kubernetes", Rejections: 2}) {
+		t.Fatalf("unexpected rejections: %+v", rj)
+	}
+	if rj.SeniorityMismatches["under"] != 2 {
+		t.Errorf("seniority mismatches = %v, want under=2", rj.SeniorityMismatches)
+	}
+	for _, want := range []string{"Run rejection_retro on 1 rejected", "kubernetes was missing in 2", "a level above your resume", "## Rejection learnings (2 retros)"} {
+		if !strings.Contains(strings.Join(r.Actions, "\n")+r.Markdown, want) {
+			t.Errorf("review missing %q", want)
+		}
+	}
+
+	jd, resume, mismatch := seniorityMismatch("Staff Engineer", "", "Software Engineer (mid-level) at Acme")
+	if jd != "staff" || resume != "mid" || mismatch != "under" {
+		t.Errorf("seniorityMismatch = %q %q %q, want staff mid under", jd, resume, mismatch)
+	}
+	if _, _, mismatch := seniorityMismatch("Junior Developer", "", "Principal Engineer, 15 years"); mismatch != "over" {
+		t.Errorf("principal applying to junior: mismatch = %q, want over", mismatch)
+	}
+}
@@ -0,0 +1,152 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Work authorization: listings open only to citizens of a country are detected from
+// their text and dropped from job_search when the master resume records the person's
+// work authorization and it holds no matching citizenship.
+
+// authCitizen is the WorkAuthorization status of a citizen.
+const authCitizen = "citizen"
+
+const citizenRegion = `(u\.s\.|us|usa|united states|american|uk|u\.k\.|british|united kingdom|canadian|canada|australian|australia|eu|european union)`
+
+var (
+	citizenshipRequiredRes = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(?:must|required to|need to) (?:be|hold|have) (?:an? )?` + citizenRegion + ` citizen(?:ship)?\b`),
+		regexp.MustCompile(`(?i)\b` + citizenRegion + ` citizen(?:ship|s)? (?:is |are )?(?:required|only|mandatory)\b`),
+		regexp.MustCompile(`(?i)\brequires? ` + citizenRegion + ` citizenship\b`),
+	}
+	// A US security clearance implies US citizenship.
+	usClearanceRe = regexp.MustCompile(`(?i)\b(ts/sci|top secret|public trust)\b`)
+	// "US citizen or green card holder" is open to permanent residents too.
+	citizenOrResidentRe = regexp.MustCompile(`(?i)citizen(?:s|ship)?,? (?:or|and/or|/) (?:an? )?(permanent resident|green card|lawful|authori[sz]ed)`)
+
+	euCountries = map[string]bool{
+		"austria": true, "belgium": true, "bulgaria": true, "croatia": true, "cyprus": true, "czechia": true,
+		"czech republic": true, "denmark": true, "estonia": true, "finland": true, "france": true, "germany": true,
+		"greece": true, "hungary": true, "ireland": true, "italy": true, "latvia": true, "lithuania": true,
+		"luxembourg": true, "malta": true, "netherlands": true, "poland": true, "portugal": true, "romania": true,
+		"slovakia": true, "slovenia": true, "spain": true, "sweden": true,
+	}
+)
+
+// authRegion normalizes a country or nationality to the regions used by
+// Eligibility.CitizenshipRequired; other countries are returned lowercased.
+func authRegion(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "u.s.", "us", "usa", "united states", "american":
+		return "us"
+	case "uk", "u.k.", "british", "united kingdom", "gb", "great britain":
+		return "uk"
+	case "canadian", "canada":
+		return "canada"
+	case "australian", "australia":
+		return "australia"
+	case "eu", "european union":
+		return "eu"
+	}
+	return s
+}
+
+// detectCitizenship returns the region whose citizenship a listing requires, or "".
+func detectCitizenship(text string) string {
+	if citizenOrResidentRe.MatchString(text) {
+		return ""
+	}
+	for _, re := range citizenshipRequiredRes {
+		if m := re.FindStringSubmatch(text); m != nil {
+			return authRegion(m[1])
+		}
+	}
+	if usClearanceRe.MatchString(text) {
+		return "us"
+	}
+	return ""
+}
+
+// holdsCitizenship reports whether the work authorizations include citizenship of region.
+// Citizens of an EU member state count as EU citizens.
+func holdsCitizenship(auths []WorkAuthorization, region string) bool {
+	for _, a := range auths {
+		if a.Status != authCitizen {
+			continue
+		}
+		r := authRegion(a.Country)
+		if r == region || (region == "eu" && euCountries[r]) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByWorkAuthorization drops listings that require a citizenship the person does
+// not hold. Without recorded work authorization nothing is dropped.
+func FilterByWorkAuthorization(listings []engine.JobListing, auths []WorkAuthorization) []engine.JobListing {
+	if len(auths) == 0 {
+		return listings
+	}
+	out := listings[:0:0]
+	for _, j := range listings {
+		if j.Eligibility != nil && j.Eligibility.CitizenshipRequired != "" && !holdsCitizenship(auths, j.Eligibility.CitizenshipRequired) {
+			continue
+		}
+		out = append(out, j)
+	}
+	return out
+}
+
+// MasterWorkAuthorization returns the work authorization recorded on the master resume,
+// or nil when the resume database is not configured or holds no resume.
+func MasterWorkAuthorization(ctx context.Context) []WorkAuthorization {
+	db := StoresFrom(ctx).ResumeDB
+	if db == nil {
+		return nil
+	}
+	personID := db.GetLatestPersonID(ctx)
+	if personID == 0 {
+		return nil
+	}
+	person, err := db.GetPerson(ctx, personID)
+	if err != nil {
+		return nil
+	}
+	return person.WorkAuthorization
+}
+
+// formatPersonFacts renders spoken languages and work authorization for LLM prompts.
+func formatPersonFacts(p *PersonRecord) string {
+	if p == nil {
+		return ""
+	}
+	var b strings.Builder
+	if len(p.Languages) > 0 {
+		b.WriteString("\n=== LANGUAGES ===\n")
+		for _, l := range p.Languages {
+			fmt.Fprintf(&b, "• %s", l.Language)
+			if l.Proficiency != "" {
+				fmt.Fprintf(&b, " (%s)", l.Proficiency)
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(p.WorkAuthorization) > 0 {
+		b.WriteString("\n=== WORK AUTHORIZATION ===\n")
+		for _, a := range p.WorkAuthorization {
+			fmt.Fprintf(&b, "• %s: %s", a.Country, strings.ReplaceAll(a.Status, "_", " "))
+			if a.Note != "" {
+				fmt.Fprintf(&b, " (%s)", a.Note)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestDetectCitizenship(t *testing.T) {
+	cases := map[string]string{
+		"Applicants must be a U.S. citizen due to contract requirements.": "us",
+		"UK citizenship required.":                     "uk",
+		"Requires Canadian citizenship.":               "canada",
+		"Active TS/SCI clearance.":                     "us",
+		"Must be a US citizen or permanent resident.":  "",
+		"We sponsor visas and welcome all applicants.": "",
+	}
+	for text, want := range cases {
+		if got := detectCitizenship(text); got != want {
+			t.Errorf("detectCitizenship(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestFilterByWorkAuthorization(t *testing.T) {
+	listings := []engine.JobListing{
+		{Title: "Cleared Go Engineer", Description: "US citizenship required."},
+		{Title: "EU Platform Engineer", Description: "EU citizens only."},
+		{Title: "Remote Go Engineer", Description: "Work from anywhere."},
+	}
+	BuildListingV2(listings)
+
+	if got := FilterByWorkAuthorization(listings, nil); len(got) != 3 {
+		t.Errorf("without work authorization nothing should be dropped, got %d", len(got))
+	}
+	got := FilterByWorkAuthorization(listings, []WorkAuthorization{
+		{Country: "Germany", Status: "citizen"},
+		{Country: "US", Status: "needs_sponsorship"},
+	})
+	if len(got) != 2 || got[0].Title != "EU Platform Engineer" {
+		t.Errorf("filtered = %+v", got)
+	}
+}
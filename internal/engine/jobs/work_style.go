@@ -0,0 +1,167 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+// Work-culture signals: 4-day week, async-first and no-meetings cultures are detected
+// from the posting text and from employers publicly known for them, surfaced in
+// work_style (output_version 2) and filterable with job_search work_style.
+
+// Work style names accepted by the work_style filter.
+const (
+	WorkStyleFourDayWeek = "four_day_week"
+	WorkStyleAsyncFirst  = "async_first"
+	WorkStyleNoMeetings  = "no_meetings"
+)
+
+var (
+	fourDayWeekRe = regexp.MustCompile(`(?i)\b((?:4|four)[- ]day (?:work ?)?weeks?|32[- ]hour (?:work ?)?weeks?|work(?:ing)? (?:only )?four days|fridays off|every friday off)\b`)
+	asyncFirstRe  = regexp.MustCompile(`(?i)\b(async(?:hronous)?[- ]first|async(?:hronous)? by default|async(?:hronous)? (?:culture|communication|collaboration|work(?:ing|flows?)?)|(?:we )?work asynchronously|fully async(?:hronous)?)\b`)
+	noMeetingsRe  = regexp.MustCompile(`(?i)\b(no[- ]meetings?(?: days?| culture| wednesdays?| policy)?|meeting[- ]free (?:days?|culture|weeks?|mondays?|tuesdays?|wednesdays?|thursdays?|fridays?)|minimal meetings|few(?:er)? meetings|meeting[- ]light|no (?:daily )?stand-?ups?)\b`)
+)
+
+// knownWorkStyles are employers publicly known for a work style, by CompanyKey. Extend
+// the 4-day-week list with GO_JOB_FOUR_DAY_WEEK_COMPANIES.
+var knownWorkStyles = map[string][]string{
+	"buffer":      {WorkStyleFourDayWeek, WorkStyleAsyncFirst},
+	"bolt":        {WorkStyleFourDayWeek},
+	"kickstarter": {WorkStyleFourDayWeek},
+	"awin":        {WorkStyleFourDayWeek},
+	"atom bank":   {WorkStyleFourDayWeek},
+	"doist":       {WorkStyleAsyncFirst, WorkStyleNoMeetings},
+	"gitlab":      {WorkStyleAsyncFirst},
+	"automattic":  {WorkStyleAsyncFirst},
+	"zapier":      {WorkStyleAsyncFirst},
+	"37signals":   {WorkStyleAsyncFirst, WorkStyleNoMeetings},
+	"basecamp":    {WorkStyleAsyncFirst, WorkStyleNoMeetings},
+	"shopify":     {WorkStyleNoMeetings},
+}
+
+// ParseWorkStyles splits a comma-separated work_style input, rejecting unknown names.
+func ParseWorkStyles(s string) ([]string, error) {
+	var names []string
+	for _, part := range strings.Split(s, ",") {
+		name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(part)), "-", "_")
+		switch name {
+		case "":
+			continue
+		case "4_day_week", "4day":
+			name = WorkStyleFourDayWeek
+		case "async":
+			name = WorkStyleAsyncFirst
+		case "no_meeting", "meeting_free":
+			name = WorkStyleNoMeetings
+		}
+		if name != WorkStyleFourDayWeek && name != WorkStyleAsyncFirst && name != WorkStyleNoMeetings {
+			return nil, fmt.Errorf("unknown work_style %q (valid: four_day_week, async_first, no_meetings)", part)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// companyWorkStyles returns the work styles an employer is known for.
+func companyWorkStyles(ctx context.Context, company string) []string {
+	key := CompanyKey(company)
+	if key == "" {
+		return nil
+	}
+	styles := knownWorkStyles[key]
+	for _, c := range engine.ConfigFrom(ctx).FourDayWeekCompanies {
+		if CompanyKey(c) == key && !slices.Contains(styles, WorkStyleFourDayWeek) {
+			styles = append(slices.Clone(styles), WorkStyleFourDayWeek)
+		}
+	}
+	return styles
+}
+
+// detectWorkStyle returns the work-culture signals of a listing, or nil when it shows none.
+func detectWorkStyle(ctx context.Context, j engine.JobListing) *engine.WorkStyle {
+	text := j.Title + "\n" + j.Description + "\n" + strings.Join(j.Benefits, "\n")
+	var ws engine.WorkStyle
+	for _, d := range []struct {
+		re   *regexp.Regexp
+		flag *bool
+	}{
+		{fourDayWeekRe, &ws.FourDayWeek},
+		{asyncFirstRe, &ws.AsyncFirst},
+		{noMeetingsRe, &ws.NoMeetings},
+	} {
+		if m := d.re.FindString(text); m != "" {
+			*d.flag = true
+			ws.Signals = append(ws.Signals, strings.ToLower(m))
+		}
+	}
+	if slices.Contains(j.Benefits, "four_day_week") && !ws.FourDayWeek {
+		ws.FourDayWeek = true
+		ws.Signals = append(ws.Signals, "four_day_week benefit")
+	}
+	flags := map[string]*bool{
+		WorkStyleFourDayWeek: &ws.FourDayWeek,
+		WorkStyleAsyncFirst:  &ws.AsyncFirst,
+		WorkStyleNoMeetings:  &ws.NoMeetings,
+	}
+	for _, style := range companyWorkStyles(ctx, j.Company) {
+		if flag := flags[style]; !*flag {
+			*flag = true
+			ws.Signals = append(ws.Signals, "known employer: "+style)
+		}
+	}
+	if !ws.FourDayWeek && !ws.AsyncFirst && !ws.NoMeetings {
+		return nil
+	}
+	return &ws
+}
+
+// AnnotateWorkStyle sets work_style on each listing.
+func AnnotateWorkStyle(ctx context.Context, listings []engine.JobListing) {
+	for i := range listings {
+		listings[i].WorkStyle = detectWorkStyle(ctx, listings[i])
+	}
+}
+
+// hasWorkStyle reports whether ws shows the named work style.
+func hasWorkStyle(ws *engine.WorkStyle, name string) bool {
+	if ws == nil {
+		return false
+	}
+	switch name {
+	case WorkStyleFourDayWeek:
+		return ws.FourDayWeek
+	case WorkStyleAsyncFirst:
+		return ws.AsyncFirst
+	case WorkStyleNoMeetings:
+		return ws.NoMeetings
+	}
+	return false
+}
+
+// FilterByWorkStyle keeps listings showing every one of required, returning how many
+// were dropped.
+func FilterByWorkStyle(listings []engine.JobListing, required []string) ([]engine.JobListing, int) {
+	if len(required) == 0 {
+		return listings, 0
+	}
+	out := listings[:0:0]
+	for _, j := range listings {
+		if !slices.ContainsFunc(required, func(name string) bool { return !hasWorkStyle(j.WorkStyle, name) }) {
+			out = append(out, j)
+		}
+	}
+	return out, len(listings) - len(out)
+}
+
+// WorkStyleDroppedNote is the summary suffix for FilterByWorkStyle.
+func WorkStyleDroppedNote(dropped int, required []string) string {
+	if dropped == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" Hid %d listing(s) without a %s signal.", dropped, strings.Join(required, "/"))
+}
@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestDetectWorkStyle(t *testing.T) {
+	ctx := engine.WithEngine(context.Background(), engine.New(engine.Config{FourDayWeekCompanies: []string{"Acme Inc."}}))
+
+	for _, tc := range []struct {
+		listing                    engine.JobListing
+		fourDay, async, noMeetings bool
+	}{
+		{engine.JobListing{Description: "We work a 32-hour week with Fridays off."}, true, false, false},
+		{engine.JobListing{Description: "An async-first team with no-meeting Wednesdays."}, false, true, true},
+		{engine.JobListing{Company: "GitLab", Description: "Go services."}, false, true, false},
+		{engine.JobListing{Company: "Acme", Description: "Go services."}, true, false, false},
+		{engine.JobListing{Description: "Daily standups and weekly planning meetings."}, false, false, false},
+	} {
+		ws := detectWorkStyle(ctx, tc.listing)
+		if !tc.fourDay && !tc.async && !tc.noMeetings {
+			if ws != nil {
+				t.Errorf("%+v: want no work style, got %+v", tc.listing, ws)
+			}
+			continue
+		}
+		if ws == nil || ws.FourDayWeek != tc.fourDay || ws.AsyncFirst != tc.async || ws.NoMeetings != tc.noMeetings || len(ws.Signals) == 0 {
+			t.Errorf("%+v: got %+v", tc.listing, ws)
+		}
+	}
+}
+
+func TestFilterByWorkStyle(t *testing.T) {
+	required, err := ParseWorkStyles("four-day-week, async")
+	if err != nil || len(required) != 2 {
+		t.Fatalf("ParseWorkStyles = %v, %v", required, err)
+	}
+	if _, err := ParseWorkStyles("remote"); err == nil {
+		t.Error("expected an error for an unknown work_style")
+	}
+
+	listings := []engine.JobListing{
+		{Title: "Both", Company: "Buffer"},
+		{Title: "Async only", Description: "Asynchronous communication by default."},
+		{Title: "Neither"},
+	}
+	AnnotateWorkStyle(context.Background(), listings)
+	kept, dropped := FilterByWorkStyle(listings, required)
+	if len(kept) != 1 || kept[0].Title != "Both" || dropped != 2 {
+		t.Errorf("kept %+v, dropped %d", kept, dropped)
+	}
+	if note := WorkStyleDroppedNote(dropped, required); note == "" {
+		t.Error("expected a note for dropped listings")
+	}
+}
@@ -18,7 +18,7 @@ const ycSiteSearch = "site:workatastartup.com"
 // SearchYCJobs searches workatastartup.com for YC startup job listings.
 // Strategy: SearXNG site: query to find job URLs + optional direct page scrape.
 func SearchYCJobs(ctx context.Context, query, location string, limit int) ([]engine.SearxngResult, error) {
-	engine.IncrYCJobsRequests()
+	engine.IncrYCJobsRequests(ctx)
 
 	// Primary: SearXNG site: search — fast, good coverage.
 	searxQuery := query + " " + ycSiteSearch
@@ -86,10 +86,10 @@ func scrapeYCJobsPage(ctx context.Context, query, location string) ([]engine.Sea
 	targetURL := u.String()
 
 	var bodyBytes []byte
-	if engine.Cfg.BrowserClient != nil {
+	if engine.ConfigFrom(ctx).BrowserClient != nil {
 		headers := engine.ChromeHeaders()
 		headers["referer"] = "https://www.workatastartup.com/"
-		data, _, status, err := engine.Cfg.BrowserClient.Do("GET", targetURL, headers, nil)
+		data, _, status, err := engine.ConfigFrom(ctx).BrowserClient.Do("GET", targetURL, headers, nil)
 		if err != nil {
 			return nil, fmt.Errorf("yc browser fetch: %w", err)
 		}
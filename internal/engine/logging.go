@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Logging: LOG_LEVEL sets the level (debug, info, warn, error; default info),
+// LOG_LEVEL_<module> overrides it for one module and LOG_FORMAT=json switches to
+// JSON lines. A module is a source file name prefix ("linkedin" covers linkedin.go
+// and linkedin_optimize.go) or a package directory ("jobs", "jobserver"), so one
+// flaky source can log at debug while everything else stays at info.
+
+// LogConfig is the parsed logging configuration.
+type LogConfig struct {
+	Level   slog.Level
+	Modules map[string]slog.Level // lowercase module → level
+	JSON    bool
+	Invalid []string // env entries with an unknown level, ignored
+}
+
+// ParseLogConfig reads LOG_LEVEL, LOG_LEVEL_<module> and LOG_FORMAT from
+// KEY=value entries as returned by os.Environ.
+func ParseLogConfig(environ []string) LogConfig {
+	c := LogConfig{Level: slog.LevelInfo, Modules: make(map[string]slog.Level)}
+	for _, kv := range environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		switch {
+		case key == "LOG_FORMAT":
+			c.JSON = strings.EqualFold(strings.TrimSpace(value), "json")
+		case key == "LOG_LEVEL":
+			if !parseLogLevel(value, &c.Level) {
+				c.Invalid = append(c.Invalid, kv)
+			}
+		case strings.HasPrefix(key, "LOG_LEVEL_"):
+			var level slog.Level
+			module := strings.ToLower(strings.TrimPrefix(key, "LOG_LEVEL_"))
+			if module == "" || !parseLogLevel(value, &level) {
+				c.Invalid = append(c.Invalid, kv)
+				continue
+			}
+			c.Modules[module] = level
+		}
+	}
+	return c
+}
+
+func parseLogLevel(s string, level *slog.Level) bool {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "warning") {
+		s = "warn"
+	}
+	return s != "" && level.UnmarshalText([]byte(s)) == nil
+}
+
+// NewLogger builds the process logger writing to w.
+func NewLogger(w io.Writer, c LogConfig) *slog.Logger {
+	minLevel := c.Level
+	for _, l := range c.Modules {
+		minLevel = min(minLevel, l)
+	}
+	opts := &slog.HandlerOptions{Level: minLevel}
+	var inner slog.Handler = slog.NewTextHandler(w, opts)
+	if c.JSON {
+		inner = slog.NewJSONHandler(w, opts)
+	}
+	if len(c.Modules) == 0 {
+		return slog.New(inner)
+	}
+	return slog.New(&moduleLevelHandler{
+		inner:   inner,
+		level:   c.Level,
+		modules: c.Modules,
+		cache:   &sync.Map{},
+	})
+}
+
+// moduleLevelHandler filters records by the level of the module that logged them.
+// Enabled admits the lowest configured level, since the caller is only known once
+// the record exists.
+type moduleLevelHandler struct {
+	inner   slog.Handler
+	level   slog.Level
+	modules map[string]slog.Level
+	cache   *sync.Map // record PC → slog.Level
+}
+
+func (h *moduleLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *moduleLevelHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < h.levelFor(r.PC) {
+		return nil
+	}
+	return h.inner.Handle(ctx, r)
+}
+
+func (h *moduleLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.inner = h.inner.WithAttrs(attrs)
+	return &c
+}
+
+func (h *moduleLevelHandler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.inner = h.inner.WithGroup(name)
+	return &c
+}
+
+// levelFor resolves the level for the caller at pc: a file-name module wins over a
+// package module, the longest file-name match over shorter ones.
+func (h *moduleLevelHandler) levelFor(pc uintptr) slog.Level {
+	if pc == 0 {
+		return h.level
+	}
+	if l, ok := h.cache.Load(pc); ok {
+		return l.(slog.Level)
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	file := strings.TrimSuffix(filepath.Base(frame.File), ".go")
+	dir := filepath.Base(filepath.Dir(frame.File))
+
+	level, best := h.level, -1
+	for module, l := range h.modules {
+		switch {
+		case (file == module || strings.HasPrefix(file, module+"_")) && len(module) > best:
+			level, best = l, len(module)
+		case dir == module && best < 0:
+			level = l
+		}
+	}
+	h.cache.Store(pc, level)
+	return level
+}
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -17,6 +18,8 @@ const (
 	MetricFreelancerAPIRequests   = "freelancer_api_requests"
 	MetricRemoteOKRequests        = "remoteok_requests"
 	MetricWWRRequests             = "wwr_requests"
+	MetricJobicyRequests          = "jobicy_requests"
+	MetricJustRemoteRequests      = "justremote_requests"
 	MetricGitingestRequests       = "gitingest_requests"
 	MetricYouTubeSearchRequests   = "youtube_search_requests"
 	MetricYouTubeTranscriptReqs   = "youtube_transcript_requests"
@@ -28,32 +31,41 @@ const (
 	MetricHabrRequests            = "habr_requests"
 	MetricCraigslistRequests      = "craigslist_requests"
 	MetricAlgoraRequests          = "algora_requests"
+	MetricImpactRequests          = "impact_requests"
+	MetricUSAJobsRequests         = "usajobs_requests"
+	MetricAcademicRequests        = "academic_requests"
+	MetricExecutiveRequests       = "executive_requests"
+	MetricFallbackSearchRequests  = "fallback_search_requests"
 	MetricToolCalls               = "tool_calls"
 )
 
-// GetMetrics returns a snapshot of all metrics including cache stats.
-func GetMetrics() map[string]int64 {
-	m := reg.Snapshot()
-	hits, misses := CacheStats()
+// GetMetrics returns a snapshot of the engine's metrics including cache stats.
+func (e *Engine) GetMetrics() map[string]int64 {
+	m := e.reg.Snapshot()
+	hits, misses := e.CacheStats()
 	m["cache_hits"] = hits
 	m["cache_misses"] = misses
 	return m
 }
 
 // FormatMetrics returns metrics as a simple text format for HTTP endpoint.
-func FormatMetrics() string {
-	m := GetMetrics()
+func (e *Engine) FormatMetrics() string {
+	m := e.GetMetrics()
 	keys := []string{
 		MetricSearchRequests, MetricLLMCalls, MetricLLMErrors,
 		MetricFetchRequests, MetricFetchErrors,
 		MetricDirectDDGRequests, MetricDirectStartpageRequests,
 		MetricFreelancerAPIRequests,
-		MetricRemoteOKRequests, MetricWWRRequests,
+		MetricRemoteOKRequests, MetricWWRRequests, MetricJobicyRequests, MetricJustRemoteRequests,
 		MetricGitingestRequests,
 		MetricYouTubeSearchRequests, MetricYouTubeTranscriptReqs,
 		MetricHNJobsRequests, MetricGreenhouseRequests, MetricLeverRequests, MetricYCJobsRequests,
 		MetricIndeedRequests, MetricHabrRequests, MetricCraigslistRequests, MetricAlgoraRequests,
+		MetricImpactRequests, MetricUSAJobsRequests, MetricAcademicRequests, MetricExecutiveRequests,
+		MetricFallbackSearchRequests,
 		MetricToolCalls,
+		"outbound_queued", "outbound_running", "outbound_tasks", "outbound_wait_ms",
+		"source_queued", "source_running", "source_tasks", "source_wait_ms",
 		"cache_hits", "cache_misses",
 	}
 	var sb strings.Builder
@@ -63,20 +75,25 @@ func FormatMetrics() string {
 	return sb.String()
 }
 
-// Job-domain metric incrementors for sub-packages.
+// Job-domain metric incrementors for sub-packages; they count on the engine bound to ctx.
 
-func IncrGitingestRequests()     { reg.Incr(MetricGitingestRequests) }
-func IncrHNJobsRequests()        { reg.Incr(MetricHNJobsRequests) }
-func IncrGreenhouseRequests()    { reg.Incr(MetricGreenhouseRequests) }
-func IncrLeverRequests()         { reg.Incr(MetricLeverRequests) }
-func IncrYCJobsRequests()        { reg.Incr(MetricYCJobsRequests) }
-func IncrRemoteOKRequests()      { reg.Incr(MetricRemoteOKRequests) }
-func IncrWWRRequests()           { reg.Incr(MetricWWRRequests) }
-func IncrIndeedRequests()        { reg.Incr(MetricIndeedRequests) }
-func IncrHabrRequests()          { reg.Incr(MetricHabrRequests) }
-func IncrCraigslistRequests()    { reg.Incr(MetricCraigslistRequests) }
-func IncrFreelancerAPIRequests() { reg.Incr(MetricFreelancerAPIRequests) }
-func IncrAlgoraRequests()        { reg.Incr(MetricAlgoraRequests) }
-func IncrYouTubeSearch()         { reg.Incr(MetricYouTubeSearchRequests) }
-func IncrYouTubeTranscript()     { reg.Incr(MetricYouTubeTranscriptReqs) }
-func IncrToolCall()              { reg.Incr(MetricToolCalls) }
+func IncrGitingestRequests(ctx context.Context)     { From(ctx).reg.Incr(MetricGitingestRequests) }
+func IncrHNJobsRequests(ctx context.Context)        { From(ctx).reg.Incr(MetricHNJobsRequests) }
+func IncrGreenhouseRequests(ctx context.Context)    { From(ctx).reg.Incr(MetricGreenhouseRequests) }
+func IncrLeverRequests(ctx context.Context)         { From(ctx).reg.Incr(MetricLeverRequests) }
+func IncrYCJobsRequests(ctx context.Context)        { From(ctx).reg.Incr(MetricYCJobsRequests) }
+func IncrRemoteOKRequests(ctx context.Context)      { From(ctx).reg.Incr(MetricRemoteOKRequests) }
+func IncrWWRRequests(ctx context.Context)           { From(ctx).reg.Incr(MetricWWRRequests) }
+func IncrJobicyRequests(ctx context.Context)        { From(ctx).reg.Incr(MetricJobicyRequests) }
+func IncrJustRemoteRequests(ctx context.Context)    { From(ctx).reg.Incr(MetricJustRemoteRequests) }
+func IncrIndeedRequests(ctx context.Context)        { From(ctx).reg.Incr(MetricIndeedRequests) }
+func IncrHabrRequests(ctx context.Context)          { From(ctx).reg.Incr(MetricHabrRequests) }
+func IncrCraigslistRequests(ctx context.Context)    { From(ctx).reg.Incr(MetricCraigslistRequests) }
+func IncrFreelancerAPIRequests(ctx context.Context) { From(ctx).reg.Incr(MetricFreelancerAPIRequests) }
+func IncrAlgoraRequests(ctx context.Context)        { From(ctx).reg.Incr(MetricAlgoraRequests) }
+func IncrImpactRequests(ctx context.Context)        { From(ctx).reg.Incr(MetricImpactRequests) }
+func IncrUSAJobsRequests(ctx context.Context)       { From(ctx).reg.Incr(MetricUSAJobsRequests) }
+func IncrAcademicRequests(ctx context.Context)      { From(ctx).reg.Incr(MetricAcademicRequests) }
+func IncrExecutiveRequests(ctx context.Context)     { From(ctx).reg.Incr(MetricExecutiveRequests) }
+func IncrYouTubeSearch(ctx context.Context)         { From(ctx).reg.Incr(MetricYouTubeSearchRequests) }
+func IncrYouTubeTranscript(ctx context.Context)     { From(ctx).reg.Incr(MetricYouTubeTranscriptReqs) }
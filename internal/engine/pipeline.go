@@ -44,34 +44,34 @@ func runSearchPipeline(ctx context.Context, query string, opts PipelineOpts) (Sm
 	}
 	contentLimit := opts.ContentLimit
 	if contentLimit == 0 {
-		contentLimit = cfg.MaxContentChars
+		contentLimit = From(ctx).cfg.MaxContentChars
 	}
 	maxDomain := opts.MaxPerDomain
 	if maxDomain == 0 {
 		maxDomain = 2
 	}
 	// deep mode: allow more URLs per domain and more total URLs
-	maxFetchURLs := cfg.MaxFetchURLs
+	maxFetchURLs := From(ctx).cfg.MaxFetchURLs
 	if opts.Depth == "deep" {
 		maxDomain = max(maxDomain, 3)
 		maxFetchURLs = maxFetchURLs * 3 / 2 // ×1.5
 	}
 
-	// --- Parallel search (SearXNG, skipped when not configured) ---
+	// --- Parallel search (SearXNG or fallback API, skipped when neither is configured) ---
 	type searchResult struct {
 		results []SearxngResult
 		err     error
 	}
 	var channels []chan searchResult
-	if cfg.SearxngURL != "" {
+	if HasWebSearch(ctx) {
 		channels = make([]chan searchResult, len(opts.Queries))
 		for i, sq := range opts.Queries {
 			ch := make(chan searchResult, 1)
 			channels[i] = ch
-			go func(sq SearchQuery, ch chan searchResult) {
+			GoOutbound(ctx, func() {
 				r, err := SearchSearXNG(ctx, sq.Query, lang, opts.TimeRange, sq.Engines)
 				ch <- searchResult{r, err}
-			}(sq, ch)
+			})
 		}
 	}
 
@@ -20,7 +20,8 @@ func fetchContentsWithRewriter(ctx context.Context, results []SearxngResult, rew
 	var wg sync.WaitGroup
 	for _, r := range results {
 		wg.Add(1)
-		go func(originalURL string) {
+		originalURL := r.URL
+		GoOutbound(ctx, func() {
 			defer wg.Done()
 			fetchURL := rewriter(originalURL)
 			var text string
@@ -35,7 +36,7 @@ func fetchContentsWithRewriter(ctx context.Context, results []SearxngResult, rew
 				contents[originalURL] = text // key is original URL for citation matching
 				mu.Unlock()
 			}
-		}(r.URL)
+		})
 	}
 	wg.Wait()
 	return contents
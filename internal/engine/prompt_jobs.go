@@ -23,7 +23,12 @@ Respond with valid JSON only (no markdown wrapping):
       "experience": "senior" or "mid" or "junior" or "not specified",
       "skills": ["skill1", "skill2"],
       "description": "1-2 sentence summary of key responsibilities and requirements",
-      "posted": "date or relative time (e.g. 2 days ago, 2026-01-18)"
+      "posted": "date or relative time (e.g. 2 days ago, 2026-01-18)",
+      "equity": "0.1%–0.5%" or "stock options" or "RSUs",
+      "benefits": ["health insurance", "parental leave", "learning budget"],
+      "pto_policy": "unlimited" or "25 days",
+      "401k_match": "4%",
+      "tenure_track": true
     }
   ],
   "summary": "1-2 sentence recommendation: which jobs look most promising and why"
@@ -37,6 +42,8 @@ Rules:
 - salary_currency: ISO 4217 code (USD, EUR, GBP, RUB, etc.)
 - salary_interval: "year", "month", or "hour"
 - Extract specific skills and technologies mentioned in the listing
+- equity, benefits, pto_policy, 401k_match: only what the listing states; omit the field otherwise
+- tenure_track: true only for tenure-track or tenured faculty posts; omit the field otherwise
 - Keep description concise — focus on key responsibilities and must-have requirements
 - Determine remote/onsite from content. If not found, use "not specified"
 - For HN comments: extract company name from "Company | Role | ..." format
@@ -128,6 +135,7 @@ Rules:
 - Extract ALL jobs found in sources (up to 15)
 - Preserve salary data from sources. If not found, use "not specified"
 - Preserve tags/skills as listed in the source
+- Location is where candidates must live, exactly as the source states it (e.g. "USA only", "Europe", "Germany, Poland", "Worldwide"). Check the description for restrictions like "must be based in the US"
 - Keep source field to identify where the listing came from
 - Do NOT invent data — only extract what's in the sources
 - Summary should be in the SAME LANGUAGE as the query`
@@ -7,16 +7,21 @@ import (
 	"golang.org/x/time/rate"
 )
 
-// DefaultSearchEngine is the SearXNG engine used for site: queries.
-const DefaultSearchEngine = "bing"
+// DefaultSearchEngine lets SearchSearXNG pick from the configured engine set
+// (SEARXNG_ENGINES) with failover, instead of pinning one engine.
+const DefaultSearchEngine = ""
 
-// SearchSearXNG queries the SearXNG instance and returns raw results.
-// Returns nil, nil when SearXNG is not configured (searxngInst == nil).
+// SearchSearXNG queries the SearXNG instances of the engine bound to ctx and returns
+// raw results. Returns nil, nil when SearXNG is not configured.
 func SearchSearXNG(ctx context.Context, query, language, timeRange, engines string) ([]SearxngResult, error) {
-	if searxngInst == nil {
-		return nil, nil
-	}
-	return searxngInst.Search(ctx, query, language, timeRange, engines)
+	defer TrackPhase(ctx, PhaseSearch)()
+	RecordSource(ctx, "searxng")
+	return From(ctx).SearchSearXNG(ctx, query, language, timeRange, engines)
+}
+
+// HasWebSearch reports whether the engine bound to ctx can run web searches.
+func HasWebSearch(ctx context.Context) bool {
+	return From(ctx).HasWebSearch()
 }
 
 // FilterByScore removes results below minScore, keeping at least minKeep.
@@ -32,20 +37,20 @@ func DedupByDomain(results []SearxngResult, maxPerDomain int) []SearxngResult {
 // SearchDirect queries enabled direct scrapers in parallel.
 // Returns merged results from all direct sources. Failures are non-fatal.
 func SearchDirect(ctx context.Context, query, language string) []SearxngResult {
-	return search.SearchDirect(ctx, directSearchConfig(), query, language)
+	return search.SearchDirect(ctx, From(ctx).directSearchConfig(), query, language)
 }
 
 // directSearchConfig builds a search.DirectConfig from engine state.
-func directSearchConfig() search.DirectConfig {
+func (e *Engine) directSearchConfig() search.DirectConfig {
 	return search.DirectConfig{
-		Browser:       fetcherProxy.BrowserClient(),
-		DDG:           cfg.DirectDDG,
-		Startpage:     cfg.DirectStartpage,
-		Brave:         cfg.DirectBrave,
-		Reddit:        cfg.DirectReddit,
+		Browser:       e.fetcherProxy.BrowserClient(),
+		DDG:           e.cfg.DirectDDG,
+		Startpage:     e.cfg.DirectStartpage,
+		Brave:         e.cfg.DirectBrave,
+		Reddit:        e.cfg.DirectReddit,
 		BraveLimiter:  rate.NewLimiter(1, 2),
 		RedditLimiter: rate.NewLimiter(1, 2),
 		Retry:         DefaultRetryConfig,
-		Metrics:       reg,
+		Metrics:       e.reg,
 	}
 }
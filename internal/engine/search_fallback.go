@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SearchProvider is a web search backend used when SearXNG is down or not configured.
+type SearchProvider interface {
+	Name() string
+	Search(ctx context.Context, query, language, timeRange string) ([]SearxngResult, error)
+}
+
+// fallbackProviders builds the configured fallback providers, in priority order.
+func fallbackProviders(c Config, client *http.Client) []SearchProvider {
+	var ps []SearchProvider
+	if c.BraveAPIKey != "" {
+		ps = append(ps, &braveProvider{key: c.BraveAPIKey, client: client, baseURL: braveAPIURL})
+	}
+	if c.SerpAPIKey != "" {
+		ps = append(ps, &serpAPIProvider{key: c.SerpAPIKey, client: client, baseURL: serpAPIURL})
+	}
+	return ps
+}
+
+// searchFallback tries each fallback provider until one returns results.
+func (e *Engine) searchFallback(ctx context.Context, query, language, timeRange string) ([]SearxngResult, error) {
+	var lastErr error
+	for _, p := range e.fallback {
+		e.reg.Incr(MetricFallbackSearchRequests)
+		results, err := p.Search(ctx, query, language, timeRange)
+		if err != nil {
+			slog.Warn("fallback search failed", slog.String("provider", p.Name()), slog.Any("error", err))
+			lastErr = err
+			continue
+		}
+		if len(results) > 0 {
+			return results, nil
+		}
+	}
+	return nil, lastErr
+}
+
+const (
+	braveAPIURL = "https://api.search.brave.com/res/v1/web/search"
+	serpAPIURL  = "https://serpapi.com/search.json"
+)
+
+// braveProvider queries the Brave Search API (BRAVE_API_KEY).
+type braveProvider struct {
+	key     string
+	client  *http.Client
+	baseURL string
+}
+
+func (p *braveProvider) Name() string { return "brave_api" }
+
+// braveFreshness maps SearXNG time ranges to Brave freshness codes.
+var braveFreshness = map[string]string{"day": "pd", "week": "pw", "month": "pm", "year": "py"}
+
+func (p *braveProvider) Search(ctx context.Context, query, language, timeRange string) ([]SearxngResult, error) {
+	q := url.Values{"q": {query}, "count": {"20"}}
+	if language != "" && language != "all" {
+		q.Set("search_lang", language)
+	}
+	if f := braveFreshness[timeRange]; f != "" {
+		q.Set("freshness", f)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Subscription-Token", p.key)
+
+	var raw struct {
+		Web struct {
+			Results []struct {
+				Title       string `json:"title"`
+				URL         string `json:"url"`
+				Description string `json:"description"`
+			} `json:"results"`
+		} `json:"web"`
+	}
+	if err := doJSON(p.client, req, &raw); err != nil {
+		return nil, fmt.Errorf("brave api: %w", err)
+	}
+	out := make([]SearxngResult, 0, len(raw.Web.Results))
+	for i, r := range raw.Web.Results {
+		out = append(out, fallbackResult(r.Title, r.URL, r.Description, i, len(raw.Web.Results), p.Name()))
+	}
+	return out, nil
+}
+
+// serpAPIProvider queries Google through SerpAPI (SERPAPI_KEY).
+type serpAPIProvider struct {
+	key     string
+	client  *http.Client
+	baseURL string
+}
+
+func (p *serpAPIProvider) Name() string { return "serpapi" }
+
+// serpAPITimeRange maps SearXNG time ranges to Google tbs values.
+var serpAPITimeRange = map[string]string{"day": "qdr:d", "week": "qdr:w", "month": "qdr:m", "year": "qdr:y"}
+
+func (p *serpAPIProvider) Search(ctx context.Context, query, language, timeRange string) ([]SearxngResult, error) {
+	q := url.Values{"engine": {"google"}, "q": {query}, "num": {"20"}, "api_key": {p.key}}
+	if language != "" && language != "all" {
+		q.Set("hl", language)
+	}
+	if tbs := serpAPITimeRange[timeRange]; tbs != "" {
+		q.Set("tbs", tbs)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		Error   string `json:"error"`
+		Organic []struct {
+			Title   string `json:"title"`
+			Link    string `json:"link"`
+			Snippet string `json:"snippet"`
+		} `json:"organic_results"`
+	}
+	if err := doJSON(p.client, req, &raw); err != nil {
+		return nil, fmt.Errorf("serpapi: %w", err)
+	}
+	if raw.Error != "" && len(raw.Organic) == 0 {
+		return nil, fmt.Errorf("serpapi: %s", raw.Error)
+	}
+	out := make([]SearxngResult, 0, len(raw.Organic))
+	for i, r := range raw.Organic {
+		out = append(out, fallbackResult(r.Title, r.Link, r.Snippet, i, len(raw.Organic), p.Name()))
+	}
+	return out, nil
+}
+
+// fallbackResult converts a ranked provider hit, scoring by position so score
+// filters treat it like a SearXNG result.
+func fallbackResult(title, link, snippet string, rank, total int, provider string) SearxngResult {
+	return SearxngResult{
+		Title:    title,
+		URL:      link,
+		Content:  snippet,
+		Score:    1 - float64(rank)/float64(max(total, 1)),
+		Metadata: map[string]string{"engine": provider, "position": strconv.Itoa(rank + 1)},
+	}
+}
+
+func doJSON(client *http.Client, req *http.Request, v any) error {
+	resp, err := client.Do(req) //nolint:gosec // fixed provider API URL
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, body)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
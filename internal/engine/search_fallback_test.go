@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBraveProvider_Search(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Subscription-Token") != "key" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if got := r.URL.Query().Get("freshness"); got != "pw" {
+			t.Errorf("freshness = %q, want pw", got)
+		}
+		_, _ = w.Write([]byte(`{"web":{"results":[
+			{"title":"Go Engineer","url":"https://a.example/job","description":"remote"},
+			{"title":"Backend","url":"https://b.example/job","description":"golang"}]}}`))
+	}))
+	defer srv.Close()
+
+	p := &braveProvider{key: "key", client: srv.Client(), baseURL: srv.URL}
+	res, err := p.Search(context.Background(), "golang jobs", "en", "week")
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(res) != 2 || res[0].URL != "https://a.example/job" {
+		t.Fatalf("results = %+v", res)
+	}
+	if res[0].Score <= res[1].Score {
+		t.Errorf("scores not rank-ordered: %v, %v", res[0].Score, res[1].Score)
+	}
+	if res[0].Metadata["engine"] != "brave_api" {
+		t.Errorf("engine = %q", res[0].Metadata["engine"])
+	}
+}
+
+func TestSearchSearXNG_FallsBackWhenSearxngDown(t *testing.T) {
+	searx := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer searx.Close()
+	serp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"organic_results":[{"title":"t","link":"https://c.example","snippet":"s"}]}`))
+	}))
+	defer serp.Close()
+
+	e := New(Config{SearxngURL: searx.URL})
+	e.fallback = []SearchProvider{&serpAPIProvider{key: "k", client: serp.Client(), baseURL: serp.URL}}
+
+	res, err := e.SearchSearXNG(context.Background(), "golang jobs", "en", "", "")
+	if err != nil {
+		t.Fatalf("SearchSearXNG: %v", err)
+	}
+	if len(res) != 1 || res[0].URL != "https://c.example" {
+		t.Fatalf("results = %+v, want the SerpAPI hit", res)
+	}
+}
+
+func TestSearchSearXNG_FallbackWithoutSearxng(t *testing.T) {
+	e := New(Config{})
+	if e.HasWebSearch() {
+		t.Fatal("HasWebSearch = true with nothing configured")
+	}
+	res, err := e.SearchSearXNG(context.Background(), "q", "en", "", "")
+	if res != nil || err != nil {
+		t.Errorf("got %v, %v; want nil, nil", res, err)
+	}
+}
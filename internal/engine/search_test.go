@@ -1,6 +1,9 @@
 package engine
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestFilterByScore(t *testing.T) {
 	results := []SearxngResult{
@@ -82,3 +85,86 @@ func TestDedupByDomain(t *testing.T) {
 		}
 	})
 }
+
+func TestCanonicalFreelanceURL(t *testing.T) {
+	tests := []struct{ a, b string }{
+		{"https://www.upwork.com/freelance-jobs/apply/Go-API-Developer_~01abcdef0123456789/?utm_source=feed&ref=rss",
+			"https://upwork.com/jobs/~01abcdef0123456789"},
+		{"https://www.freelancer.com/projects/golang/build-scraper-api/?utm_medium=email",
+			"https://www.freelancer.in/projects/web-scraping/build-scraper-api"},
+		{"https://gigs.example.com/job/42?utm_campaign=x#apply", "https://gigs.example.com/job/42/"},
+	}
+	for _, tt := range tests {
+		if a, b := CanonicalFreelanceURL(tt.a), CanonicalFreelanceURL(tt.b); a != b {
+			t.Errorf("CanonicalFreelanceURL mismatch:\n  %s → %s\n  %s → %s", tt.a, a, tt.b, b)
+		}
+	}
+	if a, b := CanonicalFreelanceURL("https://gigs.example.com/job/42?page=1"), CanonicalFreelanceURL("https://gigs.example.com/job/42?page=2"); a == b {
+		t.Errorf("non-tracking params must be kept: %s", a)
+	}
+}
+
+func TestCanonicalJobURL(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"https://www.linkedin.com/jobs/view/senior-go-engineer-at-acme-3912345678/?refId=abc&trackingId=xyz%3D%3D&trk=public_jobs",
+			"https://linkedin.com/jobs/view/3912345678"},
+		{"https://de.linkedin.com/jobs/search/?currentJobId=3912345678&keywords=go", "https://linkedin.com/jobs/view/3912345678"},
+		{"https://www.indeed.com/rc/clk?jk=a1b2c3d4e5f6&from=serp&vjs=3", "https://indeed.com/viewjob?jk=a1b2c3d4e5f6"},
+		{"https://uk.indeed.com/jobs?q=golang&vjk=a1b2c3d4e5f6", "https://uk.indeed.com/viewjob?jk=a1b2c3d4e5f6"},
+		{"https://job-boards.greenhouse.io/Acme/jobs/4012345?gh_src=feed", "https://boards.greenhouse.io/acme/jobs/4012345"},
+		{"https://boards.greenhouse.io/embed/job_app?for=acme&token=4012345", "https://boards.greenhouse.io/acme/jobs/4012345"},
+		{"https://acme.com/careers/?gh_jid=4012345&utm_source=linkedin", "https://acme.com/careers?gh_jid=4012345"},
+		{"https://jobs.lever.co/acme/0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b/apply?lever-source=x", "https://jobs.lever.co/acme/0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b"},
+		{"http://www.example.com/jobs/42/?utm_campaign=x&page=2#apply", "https://example.com/jobs/42?page=2"},
+		{"https://www.glassdoor.com/job-listing/go-engineer-JV_KO0,11.htm?jl=1009&source=jobalert&ref=mail", "https://glassdoor.com/job-listing/go-engineer-JV_KO0,11.htm?jl=1009"},
+		{"https://hh.ru/vacancy/123456?from=vacancy_search_list", "https://hh.ru/vacancy/123456"},
+		{"https://acme.com/careers/feed?source=rss&from=2024-01&ref=main", "https://acme.com/careers/feed?from=2024-01&ref=main&source=rss"},
+		{"not a url", "not a url"},
+	}
+	for _, tt := range tests {
+		if got := CanonicalJobURL(tt.in); got != tt.want {
+			t.Errorf("CanonicalJobURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDedupFreelanceResults(t *testing.T) {
+	desc := "We need an experienced Go developer to build a REST API for our logistics platform, integrate with Stripe and deploy on AWS."
+	results := []SearxngResult{
+		{Title: "Go REST API for logistics", URL: "https://gigaggregator.example/p/991", Content: "Posted 2h ago. " + desc},
+		{Title: "Golang backend — Upwork", URL: "https://www.upwork.com/freelance-jobs/apply/Golang_~01aaaabbbbcccc1111/", Content: desc + " Budget $2,000."},
+		{Title: "Go REST API for logistics - Freelance Job in Web Development - Upwork", URL: "https://www.upwork.com/jobs/~01ffff000011112222?utm_source=x", Content: "Experienced Go developer to build a REST API for our logistics platform, integrate with Stripe."},
+		{Title: "Logo design for bakery", URL: "https://www.freelancer.com/projects/logo-design/bakery-logo", Content: "Design a logo for a small family bakery."},
+		// Same title, different project: a title alone is not enough to merge.
+		{Title: "Go REST API for logistics", URL: "https://www.freelancer.com/projects/golang/rest-api-fleet", Content: "Looking for someone to maintain an existing fleet tracking service written in Go, with on-call support and weekly reports."},
+	}
+	got := DedupFreelanceResults(results)
+	if len(got) != 3 {
+		t.Fatalf("got %d results, want 2: %+v", len(got), got)
+	}
+	if !strings.Contains(got[0].URL, "upwork.com") {
+		t.Errorf("mirror should be replaced by the platform URL, got %s", got[0].URL)
+	}
+
+	if s := ShingleSimilarity("Design a logo", "Design a logo"); s != 0 {
+		t.Errorf("short texts must not be compared, got %v", s)
+	}
+}
+
+func TestDedupFreelanceProjects_TitleNeedsSecondSignal(t *testing.T) {
+	projects := []FreelanceProject{
+		{Title: "WordPress developer needed", URL: "https://gigs.example/1", ClientInfo: "Acme Ltd, US", Description: "Fix the checkout"},
+		{Title: "WordPress developer needed", URL: "https://gigs.example/2", ClientInfo: "Globex, DE", Description: "New theme"},
+		{Title: "WordPress developer needed - Upwork", URL: "https://www.upwork.com/jobs/~01abcdef0123456789", ClientInfo: "acme ltd, us", Budget: "$500", Description: "Checkout bug"},
+	}
+	got := DedupFreelanceProjects(projects)
+	if len(got) != 2 {
+		t.Fatalf("got %d projects, want 2: %+v", len(got), got)
+	}
+	if got[0].Budget != "$500" || !strings.Contains(got[0].URL, "upwork.com") {
+		t.Errorf("same title and client should merge into the first entry: %+v", got[0])
+	}
+	if got[1].ClientInfo != "Globex, DE" {
+		t.Errorf("same title, other client should stay separate: %+v", got[1])
+	}
+}
@@ -0,0 +1,226 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/anatolykoptev/go-engine/metrics"
+	"github.com/anatolykoptev/go-engine/search"
+)
+
+// DefaultSearxngEngines is the engine set used when SEARXNG_ENGINES is unset.
+const DefaultSearxngEngines = "bing:3,google:2,duckduckgo:1"
+
+const (
+	// searxngFailThreshold consecutive failures put an engine or instance on cooldown.
+	searxngFailThreshold = 3
+	// searxngCooldown is how long a failing engine or instance is skipped.
+	searxngCooldown = 2 * time.Minute
+)
+
+// searxngEngine is one weighted SearXNG engine with its health state.
+type searxngEngine struct {
+	name   string
+	weight int
+	health searxngHealth
+}
+
+// searxngInstance is one SearXNG server with its health state.
+type searxngInstance struct {
+	url    string
+	client *search.SearXNG
+	health searxngHealth
+}
+
+// searxngHealth tracks consecutive failures and the cooldown they trigger.
+type searxngHealth struct {
+	mu        sync.Mutex
+	failures  int
+	downUntil time.Time
+}
+
+func (h *searxngHealth) available(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return now.After(h.downUntil)
+}
+
+// record updates the state after a request; it reports whether the target just went on cooldown.
+func (h *searxngHealth) record(ok bool) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if ok {
+		h.failures = 0
+		return false
+	}
+	h.failures++
+	if h.failures < searxngFailThreshold {
+		return false
+	}
+	h.failures = 0
+	h.downUntil = time.Now().Add(searxngCooldown)
+	return true
+}
+
+// searxngPool spreads queries across SearXNG instances (round-robin) and, when the
+// caller does not name engines, across a weighted engine set. Failing instances and
+// engines are skipped for searxngCooldown; the next one is tried in their place.
+type searxngPool struct {
+	instances []*searxngInstance
+	engines   []*searxngEngine
+	next      atomic.Uint64
+}
+
+// newSearxngPool builds a pool from a comma-separated URL list and an engine spec
+// ("bing:3,google:2,duckduckgo", weight defaults to 1). Returns nil without URLs.
+func newSearxngPool(urls, engineSpec string, reg *metrics.Registry) *searxngPool {
+	p := &searxngPool{engines: parseSearxngEngines(engineSpec)}
+	for _, u := range strings.Split(urls, ",") {
+		u = strings.TrimRight(strings.TrimSpace(u), "/")
+		if u == "" {
+			continue
+		}
+		p.instances = append(p.instances, &searxngInstance{url: u, client: search.NewSearXNG(u, search.WithMetrics(reg))})
+	}
+	if len(p.instances) == 0 {
+		return nil
+	}
+	return p
+}
+
+// parseSearxngEngines parses "name[:weight]" entries; invalid weights count as 1.
+func parseSearxngEngines(spec string) []*searxngEngine {
+	if strings.TrimSpace(spec) == "" {
+		spec = DefaultSearxngEngines
+	}
+	var engines []*searxngEngine
+	for _, part := range strings.Split(spec, ",") {
+		name, w, _ := strings.Cut(strings.TrimSpace(part), ":")
+		if name == "" {
+			continue
+		}
+		weight, err := strconv.Atoi(w)
+		if err != nil || weight < 1 {
+			weight = 1
+		}
+		engines = append(engines, &searxngEngine{name: name, weight: weight})
+	}
+	if len(engines) == 0 {
+		return parseSearxngEngines(DefaultSearxngEngines)
+	}
+	return engines
+}
+
+// search runs the query. An empty engines string selects from the engine set: a
+// weighted pick first, then the remaining healthy engines by weight if it errors or
+// returns nothing. An explicit engines string is passed through unchanged.
+func (p *searxngPool) search(ctx context.Context, query, language, timeRange, engines string) ([]SearxngResult, error) {
+	if engines != "" {
+		return p.searchInstances(ctx, query, language, timeRange, engines)
+	}
+	var lastErr error
+	for _, eng := range p.engineOrder(time.Now()) {
+		results, err := p.searchInstances(ctx, query, language, timeRange, eng.name)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// Only errors count against an engine; an empty answer may just be an obscure query.
+		if eng.health.record(err == nil) {
+			slog.Warn("searxng: engine on cooldown", slog.String("engine", eng.name), slog.Duration("for", searxngCooldown))
+		}
+		if err == nil && len(results) > 0 {
+			return results, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return nil, lastErr
+}
+
+// searchInstances tries each available instance once, starting at the round-robin cursor.
+func (p *searxngPool) searchInstances(ctx context.Context, query, language, timeRange, engines string) ([]SearxngResult, error) {
+	start := int(p.next.Add(1)-1) % len(p.instances)
+	now := time.Now()
+	var lastErr error
+	tried := 0
+	for i := range p.instances {
+		inst := p.instances[(start+i)%len(p.instances)]
+		if !inst.health.available(now) {
+			continue
+		}
+		tried++
+		results, err := inst.client.Search(ctx, query, language, timeRange, engines)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if inst.health.record(err == nil) {
+			slog.Warn("searxng: instance on cooldown", slog.String("url", inst.url), slog.Duration("for", searxngCooldown))
+		}
+		if err == nil {
+			return results, nil
+		}
+		lastErr = err
+	}
+	if tried == 0 {
+		// Everything is cooling down; one attempt against the next instance beats failing outright.
+		return p.instances[start].client.Search(ctx, query, language, timeRange, engines)
+	}
+	return nil, lastErr
+}
+
+// engineOrder returns the available engines: a weighted random pick first, the rest
+// by descending weight. If every engine is cooling down, all are returned by weight.
+func (p *searxngPool) engineOrder(now time.Time) []*searxngEngine {
+	var avail []*searxngEngine
+	total := 0
+	for _, e := range p.engines {
+		if e.health.available(now) {
+			avail = append(avail, e)
+			total += e.weight
+		}
+	}
+	if len(avail) == 0 {
+		avail = append(avail, p.engines...)
+		for _, e := range avail {
+			total += e.weight
+		}
+	}
+	sort.SliceStable(avail, func(i, j int) bool { return avail[i].weight > avail[j].weight })
+
+	r := rand.IntN(total) //nolint:gosec // load spreading, not security
+	for i, e := range avail {
+		if r < e.weight {
+			avail[0], avail[i] = avail[i], avail[0]
+			break
+		}
+		r -= e.weight
+	}
+	if len(avail) > 1 {
+		rest := avail[1:]
+		sort.SliceStable(rest, func(i, j int) bool { return rest[i].weight > rest[j].weight })
+	}
+	return avail
+}
+
+// ping probes every instance; it fails only when none is healthy.
+func (p *searxngPool) ping(ctx context.Context, probe func(ctx context.Context, url string) error) error {
+	var errs []error
+	for _, inst := range p.instances {
+		err := probe(ctx, inst.url+"/healthz")
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", inst.url, err))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeSearxng answers /search with one result unless the requested engine is in empty.
+func fakeSearxng(t *testing.T, status int, empty map[string]bool, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		if empty[r.URL.Query().Get("engines")] {
+			_, _ = w.Write([]byte(`{"results":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"url":"https://example.com","title":"hit","content":"c"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSearxngPool_InstanceFailover(t *testing.T) {
+	var badHits, goodHits atomic.Int32
+	bad := fakeSearxng(t, http.StatusBadGateway, nil, &badHits)
+	good := fakeSearxng(t, http.StatusOK, nil, &goodHits)
+
+	p := newSearxngPool(bad.URL+", "+good.URL, "bing", nil)
+	for i := 0; i < 4; i++ {
+		res, err := p.search(context.Background(), "go jobs", "en", "", "")
+		if err != nil || len(res) != 1 {
+			t.Fatalf("search %d: got %d results, err %v", i, len(res), err)
+		}
+	}
+	// The bad instance goes on cooldown after searxngFailThreshold failures.
+	if n := badHits.Load(); n > searxngFailThreshold {
+		t.Errorf("bad instance hit %d times, want at most %d", n, searxngFailThreshold)
+	}
+	if goodHits.Load() != 4 {
+		t.Errorf("good instance hit %d times, want 4", goodHits.Load())
+	}
+}
+
+func TestSearxngPool_EngineFailover(t *testing.T) {
+	var hits atomic.Int32
+	srv := fakeSearxng(t, http.StatusOK, map[string]bool{"bing": true}, &hits)
+
+	p := newSearxngPool(srv.URL, "bing:100,google:1", nil)
+	res, err := p.search(context.Background(), "go jobs", "en", "", "")
+	if err != nil || len(res) != 1 {
+		t.Fatalf("got %d results, err %v; want failover to google", len(res), err)
+	}
+}
+
+func TestSearxngPool_ExplicitEnginesPassThrough(t *testing.T) {
+	var hits atomic.Int32
+	srv := fakeSearxng(t, http.StatusOK, map[string]bool{"bing": true}, &hits)
+
+	p := newSearxngPool(srv.URL, "bing,google", nil)
+	res, err := p.search(context.Background(), "go jobs", "en", "", "bing")
+	if err != nil || len(res) != 0 {
+		t.Fatalf("got %d results, err %v; want the empty bing answer unchanged", len(res), err)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("hits = %d, want 1", hits.Load())
+	}
+}
+
+func TestSearxngPool_EngineOrder(t *testing.T) {
+	p := newSearxngPool("http://a", "bing:3,google:2,duckduckgo", nil)
+	if len(p.engines) != 3 || p.engines[2].weight != 1 {
+		t.Fatalf("parsed engines = %+v", p.engines)
+	}
+	p.engines[0].health.downUntil = time.Now().Add(time.Minute)
+
+	order := p.engineOrder(time.Now())
+	if len(order) != 2 {
+		t.Fatalf("order has %d engines, want 2 (bing cooling down)", len(order))
+	}
+	for _, e := range order {
+		if e.name == "bing" {
+			t.Error("engine on cooldown was selected")
+		}
+	}
+}
+
+func TestNewSearxngPool_Empty(t *testing.T) {
+	if p := newSearxngPool(" , ", "", nil); p != nil {
+		t.Error("want nil pool without URLs")
+	}
+}
@@ -68,7 +68,7 @@ func resolveLibrary(ctx context.Context, query, libraryName string) (*C7Library,
 	}
 	setC7Headers(req)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec // context7 API URL from config, intentional outbound request
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // context7 API URL from config, intentional outbound request
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +106,7 @@ func queryDocs(ctx context.Context, query, libraryID string) (*c7ContextResponse
 	}
 	setC7Headers(req)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec // context7 API URL from config, intentional outbound request
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // context7 API URL from config, intentional outbound request
 	if err != nil {
 		return nil, err
 	}
@@ -124,8 +124,8 @@ func queryDocs(ctx context.Context, query, libraryID string) (*c7ContextResponse
 }
 
 func setC7Headers(req *http.Request) {
-	if engine.Cfg.Context7APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+engine.Cfg.Context7APIKey)
+	if key := engine.ConfigFrom(req.Context()).Context7APIKey; key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
 	}
 	req.Header.Set("User-Agent", engine.UserAgentBot)
 	req.Header.Set("X-Context7-Source", "go-search")
@@ -61,7 +61,7 @@ type freelancerJob struct {
 
 // SearchFreelancerAPI queries the Freelancer.com public API for active projects.
 func SearchFreelancerAPI(ctx context.Context, query string, limit int) ([]engine.FreelanceProject, error) {
-	engine.IncrFreelancerAPIRequests()
+	engine.IncrFreelancerAPIRequests(ctx)
 
 	if limit <= 0 || limit > 20 {
 		limit = 10
@@ -80,7 +80,7 @@ func SearchFreelancerAPI(ctx context.Context, query string, limit int) ([]engine
 	q.Set("full_description", "true")
 	u.RawQuery = q.Encode()
 
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
@@ -91,7 +91,7 @@ func SearchFreelancerAPI(ctx context.Context, query string, limit int) ([]engine
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // Freelancer API URL from config, intentional outbound request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // Freelancer API URL from config, intentional outbound request
 	})
 	if err != nil {
 		return nil, err
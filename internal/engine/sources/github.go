@@ -46,7 +46,7 @@ func ExtractOwnerRepo(u string) (owner, repo string, ok bool) {
 
 // FetchRepoMeta fetches repository metadata from GitHub REST API.
 func FetchRepoMeta(ctx context.Context, owner, repo string) (*RepoMeta, error) {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
@@ -56,12 +56,12 @@ func FetchRepoMeta(ctx context.Context, owner, repo string) (*RepoMeta, error) {
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	if engine.Cfg.GithubToken != "" {
-		req.Header.Set("Authorization", "Bearer "+engine.Cfg.GithubToken)
+	if engine.ConfigFrom(ctx).GithubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
 	}
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // GitHub API URL, intentional outbound request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // GitHub API URL, intentional outbound request
 	})
 	if err != nil {
 		return nil, err
@@ -93,7 +93,7 @@ type ghRepoSearchResponse struct {
 // SearchGitHubRepos searches repositories via GitHub REST API.
 // Supports full GitHub search syntax: language:go topic:ai stars:>100 user:owner
 func SearchGitHubRepos(ctx context.Context, query, sort string) ([]engine.SearxngResult, error) {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	params := url.Values{
@@ -113,12 +113,12 @@ func SearchGitHubRepos(ctx context.Context, query, sort string) ([]engine.Searxn
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	if engine.Cfg.GithubToken != "" {
-		req.Header.Set("Authorization", "Bearer "+engine.Cfg.GithubToken)
+	if engine.ConfigFrom(ctx).GithubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
 	}
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // GitHub API URL, intentional outbound request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // GitHub API URL, intentional outbound request
 	})
 	if err != nil {
 		return nil, err
@@ -188,7 +188,7 @@ type ghIssueItem struct {
 // SearchGitHubIssues searches issues and pull requests via the GitHub Issues Search API.
 // query should include "is:pr" or "is:issue" and optionally "repo:owner/repo".
 func SearchGitHubIssues(ctx context.Context, query string) ([]engine.IssueItem, error) {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	apiURL := "https://api.github.com/search/issues?" + url.Values{
@@ -203,12 +203,12 @@ func SearchGitHubIssues(ctx context.Context, query string) ([]engine.IssueItem,
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	if engine.Cfg.GithubToken != "" {
-		req.Header.Set("Authorization", "Bearer "+engine.Cfg.GithubToken)
+	if engine.ConfigFrom(ctx).GithubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
 	}
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // GitHub API URL, intentional outbound request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // GitHub API URL, intentional outbound request
 	})
 	if err != nil {
 		return nil, err
@@ -277,7 +277,7 @@ type ghCodeSearchItem struct {
 
 // SearchGitHubCode searches code within the given repos using the GitHub Code Search API.
 func SearchGitHubCode(ctx context.Context, query string, repos []string) ([]engine.SearxngResult, error) {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	// Build query: "search terms repo:owner/repo1 repo:owner/repo2"
@@ -298,12 +298,12 @@ func SearchGitHubCode(ctx context.Context, query string, repos []string) ([]engi
 	req.Header.Set("Accept", "application/vnd.github.v3.text-match+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	if engine.Cfg.GithubToken != "" {
-		req.Header.Set("Authorization", "Bearer "+engine.Cfg.GithubToken)
+	if engine.ConfigFrom(ctx).GithubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).GithubToken)
 	}
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // GitHub Code Search API URL, intentional outbound request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // GitHub Code Search API URL, intentional outbound request
 	})
 	if err != nil {
 		return nil, err
@@ -60,7 +60,7 @@ func SearchHackerNews(ctx context.Context, input engine.HNSearchInput) ([]engine
 	req.Header.Set("User-Agent", engine.UserAgentBot)
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // HN Algolia API URL, intentional outbound request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // HN Algolia API URL, intentional outbound request
 	})
 	if err != nil {
 		return nil, err
@@ -115,7 +115,7 @@ func fetchHNComments(ctx context.Context, storyID string, maxComments int) ([]st
 	}
 	req.Header.Set("User-Agent", engine.UserAgentBot)
 
-	resp, err := engine.Cfg.HTTPClient.Do(req) //nolint:gosec // HN Firebase API URL, intentional outbound request
+	resp, err := engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // HN Firebase API URL, intentional outbound request
 	if err != nil {
 		return nil, err
 	}
@@ -187,17 +187,17 @@ func SummarizeHNResults(ctx context.Context, query string, results []engine.HNRe
 		for _, ts := range topStories {
 			if results[ts.idx].ObjectID == "" { continue }
 			wg.Add(1)
-			go func(idx int, objectID string) {
+			engine.GoOutbound(ctx, func() {
 				defer wg.Done()
-				comments, err := fetchHNComments(ctx, objectID, 5)
+				comments, err := fetchHNComments(ctx, results[ts.idx].ObjectID, 5)
 				if err != nil {
-					slog.Debug("hn: failed to fetch comments", slog.String("story", objectID), slog.Any("error", err))
+					slog.Debug("hn: failed to fetch comments", slog.String("story", results[ts.idx].ObjectID), slog.Any("error", err))
 					return
 				}
 				mu.Lock()
-				commentMap[idx] = comments
+				commentMap[ts.idx] = comments
 				mu.Unlock()
-			}(ts.idx, results[ts.idx].ObjectID)
+			})
 		}
 		wg.Wait()
 	}
@@ -222,7 +222,7 @@ func SummarizeHNResults(ctx context.Context, query string, results []engine.HNRe
 		}
 	}
 
-	sources := engine.BuildSourcesText(searxResults, contents, engine.Cfg.MaxContentChars)
+	sources := engine.BuildSourcesText(searxResults, contents, engine.ConfigFrom(ctx).MaxContentChars)
 	prompt := fmt.Sprintf(hnSummarizePrompt, query, sources)
 
 	raw, err := engine.CallLLM(ctx, prompt)
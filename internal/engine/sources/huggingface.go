@@ -178,12 +178,12 @@ func fetchHFJSON(ctx context.Context, rawURL string, dst any) error {
 		return err
 	}
 	req.Header.Set("User-Agent", engine.UserAgentBot)
-	if engine.Cfg.HuggingFaceToken != "" {
-		req.Header.Set("Authorization", "Bearer "+engine.Cfg.HuggingFaceToken)
+	if engine.ConfigFrom(ctx).HuggingFaceToken != "" {
+		req.Header.Set("Authorization", "Bearer "+engine.ConfigFrom(ctx).HuggingFaceToken)
 	}
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return err
@@ -253,25 +253,25 @@ func SearchHuggingFace(ctx context.Context, input engine.HFModelSearchInput) ([]
 	if task != "" {
 		// Request 1: by pipeline_tag (sorted by downloads — gives popular models)
 		wg.Add(1)
-		go func() {
+		engine.GoOutbound(ctx, func() {
 			defer wg.Done()
 			r := fetch(buildHFModelsURL("", task, input.Library, sort, limit))
 			mu.Lock()
 			results = append(results, r)
 			mu.Unlock()
-		}()
+		})
 	}
 
 	if input.Query != "" {
 		// Request 2: text search (finds models matching query by name/description)
 		wg.Add(1)
-		go func() {
+		engine.GoOutbound(ctx, func() {
 			defer wg.Done()
 			r := fetch(buildHFModelsURL(input.Query, task, input.Library, sort, limit))
 			mu.Lock()
 			results = append(results, r)
 			mu.Unlock()
-		}()
+		})
 	}
 
 	wg.Wait()
@@ -418,18 +418,18 @@ func SummarizeHFResults(ctx context.Context, query string, models []engine.HFMod
 	var wg sync.WaitGroup
 	for i := 0; i < cardLimit; i++ {
 		wg.Add(1)
-		go func(idx int, modelID string) {
+		engine.GoOutbound(ctx, func() {
 			defer wg.Done()
-			card, err := FetchHFModelCard(ctx, modelID)
+			card, err := FetchHFModelCard(ctx, models[i].ID)
 			if err != nil {
-				slog.Debug("hf: model card fetch failed", slog.String("model", modelID), slog.Any("error", err))
+				slog.Debug("hf: model card fetch failed", slog.String("model", models[i].ID), slog.Any("error", err))
 				return
 			}
 			if len(card) > 4000 {
 				card = card[:4000] + "..."
 			}
-			cards[idx] = card
-		}(i, models[i].ID)
+			cards[i] = card
+		})
 	}
 	wg.Wait()
 
@@ -459,7 +459,7 @@ func SummarizeHFResults(ctx context.Context, query string, models []engine.HFMod
 		}
 	}
 
-	llmOut, err := engine.SummarizeWithInstruction(ctx, query, engine.HFModelSearchInstruction, engine.Cfg.MaxContentChars, results, contents)
+	llmOut, err := engine.SummarizeWithInstruction(ctx, query, engine.HFModelSearchInstruction, engine.ConfigFrom(ctx).MaxContentChars, results, contents)
 	if err != nil {
 		return engine.HFModelSearchOutput{Query: query, Models: models, Summary: "LLM summarization failed: " + err.Error()}, nil
 	}
@@ -490,7 +490,7 @@ func SummarizeHFDatasets(ctx context.Context, query string, datasets []engine.HF
 		}
 	}
 
-	llmOut, err := engine.SummarizeWithInstruction(ctx, query, engine.HFDatasetSearchInstruction, engine.Cfg.MaxContentChars, results, nil)
+	llmOut, err := engine.SummarizeWithInstruction(ctx, query, engine.HFDatasetSearchInstruction, engine.ConfigFrom(ctx).MaxContentChars, results, nil)
 	if err != nil {
 		return engine.HFDatasetSearchOutput{Query: query, Datasets: datasets, Summary: "LLM summarization failed: " + err.Error()}, nil
 	}
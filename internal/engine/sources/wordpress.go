@@ -39,11 +39,11 @@ func SearchWordPressAPI(ctx context.Context, query string) ([]engine.SearxngResu
 
 	for _, pt := range wpPostTypes {
 		wg.Add(1)
-		go func(postType, label string) {
+		engine.GoOutbound(ctx, func() {
 			defer wg.Done()
-			items, err := fetchWPPostType(ctx, query, postType, label)
+			items, err := fetchWPPostType(ctx, query, pt.PostType, pt.Label)
 			ch <- result{items, err}
-		}(pt.PostType, pt.Label)
+		})
 	}
 
 	go func() {
@@ -64,7 +64,7 @@ func SearchWordPressAPI(ctx context.Context, query string) ([]engine.SearxngResu
 
 // fetchWPPostType fetches results for a single WordPress post type.
 func fetchWPPostType(ctx context.Context, query, postType, label string) ([]engine.SearxngResult, error) {
-	ctx, cancel := context.WithTimeout(ctx, engine.Cfg.FetchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, engine.ConfigFrom(ctx).FetchTimeout)
 	defer cancel()
 
 	apiURL := fmt.Sprintf("https://developer.wordpress.org/wp-json/wp/v2/%s?%s",
@@ -79,7 +79,7 @@ func fetchWPPostType(ctx context.Context, query, postType, label string) ([]engi
 	req.Header.Set("User-Agent", engine.UserAgentBot)
 
 	resp, err := engine.RetryHTTP(ctx, engine.DefaultRetryConfig, func() (*http.Response, error) {
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return nil, err
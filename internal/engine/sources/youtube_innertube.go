@@ -146,7 +146,7 @@ func ytWebContext(visitorData string) map[string]any {
 }
 
 // postInnerTubeWEB POSTs to a YouTube Innertube endpoint with WEB client headers.
-// Uses engine.Cfg.HTTPClient and engine.RetryHTTP for consistent retry/timeout behavior.
+// Uses engine.ConfigFrom(ctx).HTTPClient and engine.RetryHTTP for consistent retry/timeout behavior.
 func postInnerTubeWEB(ctx context.Context, endpoint string, payload any, visitorData string) ([]byte, error) {
 	bodyBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -166,7 +166,7 @@ func postInnerTubeWEB(ctx context.Context, endpoint string, payload any, visitor
 		req.Header.Set("X-Goog-Visitor-Id", visitorData)
 		req.Header.Set("Origin", "https://www.youtube.com")
 		req.Header.Set("Referer", "https://www.youtube.com/")
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return nil, fmt.Errorf("innertube WEB [%s]: %w", endpoint, err)
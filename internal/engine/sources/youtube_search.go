@@ -65,18 +65,18 @@ type ytSearchResult struct {
 }
 
 // YouTubeTranscriptsEnabled reports whether transcript fetching is enabled in config.
-func YouTubeTranscriptsEnabled() bool {
-	return engine.Cfg.YouTubeTranscriptsEnabled
+func YouTubeTranscriptsEnabled(ctx context.Context) bool {
+	return engine.ConfigFrom(ctx).YouTubeTranscriptsEnabled
 }
 
 // SearchYouTube searches YouTube videos.
 // Uses YouTube Data API v3 when a key is configured; otherwise scrapes ytInitialData.
 func SearchYouTube(ctx context.Context, query, language string, limit int) ([]engine.YouTubeVideo, error) {
-	engine.IncrYouTubeSearch()
+	engine.IncrYouTubeSearch(ctx)
 	if limit <= 0 || limit > 10 {
 		limit = 5
 	}
-	if engine.Cfg.YouTubeAPIKey != "" {
+	if engine.ConfigFrom(ctx).YouTubeAPIKey != "" {
 		return searchYouTubeDataAPI(ctx, query, language, limit)
 	}
 	return searchYouTubeInitialData(ctx, query, limit)
@@ -85,9 +85,9 @@ func SearchYouTube(ctx context.Context, query, language string, limit int) ([]en
 // searchYouTubeDataAPI searches via YouTube Data API v3.
 // Automatically falls back to the secondary key on quota errors (403).
 func searchYouTubeDataAPI(ctx context.Context, query, language string, limit int) ([]engine.YouTubeVideo, error) {
-	keys := []string{engine.Cfg.YouTubeAPIKey}
-	if engine.Cfg.YouTubeAPIKeyFallback != "" {
-		keys = append(keys, engine.Cfg.YouTubeAPIKeyFallback)
+	keys := []string{engine.ConfigFrom(ctx).YouTubeAPIKey}
+	if engine.ConfigFrom(ctx).YouTubeAPIKeyFallback != "" {
+		keys = append(keys, engine.ConfigFrom(ctx).YouTubeAPIKeyFallback)
 	}
 	var lastErr error
 	for _, key := range keys {
@@ -119,7 +119,7 @@ func doYouTubeDataSearch(ctx context.Context, query, language string, limit int,
 			return nil, err
 		}
 		req.Header.Set("User-Agent", engine.UserAgentBot)
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return nil, fmt.Errorf("youtube data API: %w", err)
@@ -164,7 +164,7 @@ func searchYouTubeInitialData(ctx context.Context, query string, limit int) ([]e
 		req.Header.Set("User-Agent", engine.RandomUserAgent())
 		req.Header.Set("Accept-Language", "en-US,en;q=0.9")
 		req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
-		return engine.Cfg.HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
+		return engine.ConfigFrom(ctx).HTTPClient.Do(req) //nolint:gosec // intentional outbound HTTP request
 	})
 	if err != nil {
 		return nil, fmt.Errorf("youtube search page: %w", err)
@@ -291,21 +291,21 @@ func FetchTranscriptsParallel(ctx context.Context, videos []engine.YouTubeVideo,
 
 	for i := 0; i < limit; i++ {
 		wg.Add(1)
-		go func(idx int) {
+		engine.GoOutbound(fetchCtx, func() {
 			defer wg.Done()
-			transcript, err := FetchYouTubeTranscript(fetchCtx, videos[idx].ID, langs)
+			transcript, err := FetchYouTubeTranscript(fetchCtx, videos[i].ID, langs)
 			if err != nil {
 				slog.Debug("youtube: transcript failed",
-					slog.String("id", videos[idx].ID), slog.Any("err", err))
+					slog.String("id", videos[i].ID), slog.Any("err", err))
 				return
 			}
 			if len(transcript) > ytTranscriptMaxLen {
 				transcript = transcript[:ytTranscriptMaxLen] + "..."
 			}
 			mu.Lock()
-			videos[idx].Transcript = transcript
+			videos[i].Transcript = transcript
 			mu.Unlock()
-		}(i)
+		})
 	}
 	wg.Wait()
 	return videos
@@ -330,7 +330,7 @@ func SummarizeYouTubeResults(ctx context.Context, query string, videos []engine.
 		}
 	}
 
-	llmOut, err := engine.SummarizeWithInstruction(ctx, query, engine.YouTubeSearchInstruction, engine.Cfg.MaxContentChars, results, contents)
+	llmOut, err := engine.SummarizeWithInstruction(ctx, query, engine.YouTubeSearchInstruction, engine.ConfigFrom(ctx).MaxContentChars, results, contents)
 	if err != nil {
 		return engine.YouTubeSearchOutput{Query: query, Videos: videos, Summary: "LLM summarization failed: " + err.Error()}, nil
 	}
@@ -3,19 +3,49 @@ package engine
 // --- Job search types ---
 
 type JobSearchInput struct {
-	Query      string `json:"query" jsonschema:"Job search keywords (e.g. golang developer, data engineer)"`
-	Location   string `json:"location,omitempty" jsonschema:"City, country, or Remote (e.g. Berlin, United States, Remote)"`
-	Experience string `json:"experience,omitempty" jsonschema:"Experience level: internship, entry, associate, mid-senior, director, executive"`
-	JobType    string `json:"job_type,omitempty" jsonschema:"Job type: full-time, part-time, contract, temporary"`
-	Remote     string `json:"remote,omitempty" jsonschema:"Work type: onsite, hybrid, remote"`
-	TimeRange  string `json:"time_range,omitempty" jsonschema:"Time posted: day, week, month"`
-	Platform   string `json:"platform,omitempty" jsonschema:"Source filter: linkedin, greenhouse, lever, ats (greenhouse+lever), yc (workatastartup.com), hn (HN Who is Hiring), indeed, habr (Хабр Карьера), twitter (X/Twitter job tweets), google (Google Jobs), startup (yc+hn+ats), all (default)"`
-	Salary     string `json:"salary,omitempty" jsonschema:"Minimum salary filter for LinkedIn: 40k+, 60k+, 80k+, 100k+, 120k+, 140k+, 160k+, 180k+, 200k+"`
-	EasyApply  bool   `json:"easy_apply,omitempty" jsonschema:"LinkedIn only: filter to Easy Apply jobs (one-click apply)"`
-	Language   string `json:"language,omitempty" jsonschema:"Language code for the answer (default: all)"`
-	Limit    int    `json:"limit,omitempty" jsonschema:"Max results to return (default 15, max 50)"`
-	Offset   int    `json:"offset,omitempty" jsonschema:"Skip first N results for pagination (default 0)"`
-	Blacklist string `json:"blacklist,omitempty" jsonschema:"Comma-separated company names or keywords to exclude from results (e.g. Google, Meta, staffing)"`
+	Query           string  `json:"query" jsonschema:"Job search keywords (e.g. golang developer, data engineer)"`
+	Location        string  `json:"location,omitempty" jsonschema:"City, country, or Remote (e.g. Berlin, United States, Remote)"`
+	Experience      string  `json:"experience,omitempty" jsonschema:"Experience level: internship, entry, associate, mid-senior, director, executive"`
+	JobType         string  `json:"job_type,omitempty" jsonschema:"Job type: full-time, part-time, contract, temporary"`
+	Remote          string  `json:"remote,omitempty" jsonschema:"Work type: onsite, hybrid, remote"`
+	TimeRange       string  `json:"time_range,omitempty" jsonschema:"Time posted: day, week, month. Listings dated before the window are dropped, also for sources without a date filter."`
+	Platform        string  `json:"platform,omitempty" jsonschema:"Source filter: linkedin, greenhouse, lever, ats (greenhouse+lever), yc (workatastartup.com), hn (HN Who is Hiring), indeed, habr (Хабр Карьера), twitter (X/Twitter job tweets), google (Google Jobs), remote (remoteok+weworkremotely+remotive+jobicy+himalayas+justremote) or any one of those, startup (yc+hn+ats), impact (idealist+80000hours+reliefweb nonprofit/NGO boards, not part of all) or any one of those, usajobs (US federal jobs; needs USAJOBS_API_KEY, then also part of all), academic (euraxess+higheredjobs research and faculty boards, not part of all) or any one of those, executive (linkedin+execthread+bluesteps+theladders; default with executive=true), all (default)"`
+	Salary          string  `json:"salary,omitempty" jsonschema:"Minimum salary filter for LinkedIn: 40k+, 60k+, 80k+, 100k+, 120k+, 140k+, 160k+, 180k+, 200k+"`
+	EasyApply       bool    `json:"easy_apply,omitempty" jsonschema:"LinkedIn only: filter to Easy Apply jobs (one-click apply)"`
+	Company         string  `json:"company,omitempty" jsonschema:"Only jobs at this company: LinkedIn company filter plus the company's own Greenhouse/Lever board (e.g. Stripe)"`
+	Language        string  `json:"language,omitempty" jsonschema:"Language code for the answer (default: all)"`
+	Limit           int     `json:"limit,omitempty" jsonschema:"Max results to return (default 15, max 50)"`
+	Offset          int     `json:"offset,omitempty" jsonschema:"Skip first N results for pagination (default 0)"`
+	Blacklist       string  `json:"blacklist,omitempty" jsonschema:"Comma-separated company names or keywords to exclude from results (e.g. Google, Meta, staffing)"`
+	HideScams       bool    `json:"hide_scams,omitempty" jsonschema:"Drop listings with high scam_risk instead of only annotating them"`
+	SortBy          string  `json:"sort_by,omitempty" jsonschema:"Result order: relevance (default), deadline (soonest application deadline first) or benefits (most stated perks first)"`
+	KeepIneligible  bool    `json:"keep_ineligible,omitempty" jsonschema:"Keep listings that require a citizenship the master resume does not hold (dropped by default when work authorization is recorded)"`
+	KeepBelowFloor  bool    `json:"keep_below_floor,omitempty" jsonschema:"Keep listings paying below the profile salary_floor or lacking required equity (hidden by default); scores.comp_fit marks them below_floor"`
+	MinOverlapHours float64 `json:"min_overlap_hours,omitempty" jsonschema:"Drop listings whose team time zone overlaps the profile working day by fewer hours (listings without time zones are kept)"`
+	EligibleFrom    string  `json:"eligible_from,omitempty" jsonschema:"Country or region you can work from (e.g. Germany, EU, US): drops remote listings restricted to other countries or regions (listings stating no restriction are kept)"`
+	OutputVersion   int     `json:"output_version,omitempty" jsonschema:"Output shape: 1 (default, stable) or 2 (adds salary_normalized, eligibility, scores, company_info blocks; flat score fields move into scores)"`
+
+	// Drop listings at companies recently applied to (job tracker).
+	ExcludeAppliedWithinDays int `json:"exclude_applied_within_days,omitempty" jsonschema:"Drop listings at companies the job tracker shows you applied to, interviewed with or were rejected by in the last N days. With 0 they are kept and, in output_version 2, tagged with prior_application"`
+
+	// Company filters: LinkedIn industry codes at the source, the company store after the merge.
+	CompanySize string `json:"company_size,omitempty" jsonschema:"Company size: startup (up to 200 employees), smb (201-1000) or enterprise. Classified from the company store; listings at unclassified companies are kept"`
+	Industry    string `json:"industry,omitempty" jsonschema:"Company industry, e.g. software, fintech, healthcare, gaming, education. Sent to LinkedIn as industry codes; other sources are matched against the company store and unclassified companies are kept"`
+
+	// Regulated postings: security clearance or professional license requirements.
+	ExcludeClearanceRequired bool `json:"exclude_clearance_required,omitempty" jsonschema:"Drop listings requiring a security clearance (TS/SCI, public trust, polygraph) or a professional license (FINRA Series 7/63, medical, bar admission, CPA); output_version 2 tags them in eligibility.clearance_required"`
+
+	// Benefits every listing must state.
+	RequireBenefits string `json:"require_benefits,omitempty" jsonschema:"Comma-separated benefits every listing must mention, e.g. equity or equity,401k_match. Known: equity, 401k_match, unlimited_pto, health, dental, vision, 401k, pension, parental_leave, remote_stipend, learning_budget, wellness, relocation, bonus, flexible_hours, four_day_week"`
+
+	// Work-culture signals every listing must show.
+	WorkStyle string `json:"work_style,omitempty" jsonschema:"Comma-separated work-culture signals every listing must show: four_day_week, async_first, no_meetings. Detected from the posting text and lists of employers known for them; output_version 2 returns them in work_style"`
+
+	// Internship-focused search.
+	Internships bool `json:"internships,omitempty" jsonschema:"Search internships only: sets experience and job_type to internship (LinkedIn f_E=1, f_JT=I), adds internship to free-text queries (Indeed, SearXNG), drops non-internship listings and warns when the master resume lacks education, a graduation date or projects"`
+
+	// Executive search.
+	Executive bool `json:"executive,omitempty" jsonschema:"Search director+ roles: sets experience to director (LinkedIn f_E=5; keep executive for f_E=6), searches LinkedIn and the executive boards ExecThread, BlueSteps and The Ladders unless platform is set, and drops listings below director level"`
 }
 
 // JobListing is a structured representation of a job listing.
@@ -31,12 +61,117 @@ type JobListing struct {
 	SalaryMax      *int     `json:"salary_max,omitempty"`      // numeric max
 	SalaryCurrency string   `json:"salary_currency,omitempty"` // e.g. "USD", "EUR", "RUB"
 	SalaryInterval string   `json:"salary_interval,omitempty"` // "year", "month", "hour"
+	CompanyRating  float64  `json:"company_rating,omitempty"`  // employer rating where the source has one (Habr Career; output_version 2)
 	JobType        string   `json:"job_type"`
 	Remote         string   `json:"remote"`
 	Experience     string   `json:"experience,omitempty"`
 	Skills         []string `json:"skills"`
 	Description    string   `json:"description"`
 	Posted         string   `json:"posted"`
+	ScamRisk       string   `json:"scam_risk,omitempty"`        // "low", "medium", "high"
+	ScamSignals    []string `json:"scam_signals,omitempty"`     // e.g. "pay_to_start", "personal_email_big"
+	Evergreen      bool     `json:"likely_evergreen,omitempty"` // open 60+ days or reposted monthly
+	DaysOpen       int      `json:"days_open,omitempty"`        // days since first seen/posted
+	Deadline       string   `json:"deadline,omitempty"`         // YYYY-MM-DD, explicit "apply by" date
+	DeadlineKind   string   `json:"deadline_kind,omitempty"`    // "application" or "visa_lottery"
+	DeadlineUrgent bool     `json:"deadline_urgent,omitempty"`  // deadline within 7 days
+
+	MatchExplanation *MatchExplanation `json:"match_explanation,omitempty"` // resume skills matched and JD skills unmet (output_version 2)
+
+	// Compensation extras stated in the posting (output_version 2).
+	Equity    string   `json:"equity,omitempty"`     // e.g. "0.1%-0.5% equity", "stock options", "rsus"
+	Benefits  []string `json:"benefits,omitempty"`   // canonical names such as "health", "parental_leave", "learning_budget"
+	PTOPolicy string   `json:"pto_policy,omitempty"` // "unlimited" or e.g. "25 days"
+	Match401k string   `json:"401k_match,omitempty"` // e.g. "4%", or "yes" when the rate is not stated
+
+	// Academic postings (output_version 2).
+	Institution string `json:"institution,omitempty"`  // university or research institute
+	TenureTrack bool   `json:"tenure_track,omitempty"` // tenure-track or tenured faculty post
+
+	// output_version 2 blocks (omitted in v1).
+	SalaryNorm  *NormalizedSalary `json:"salary_normalized,omitempty"`
+	Eligibility *Eligibility      `json:"eligibility,omitempty"`
+	Scores      *ListingScores    `json:"scores,omitempty"`
+	CompanyInfo *CompanyInfo      `json:"company_info,omitempty"` // known company data from the company store
+	PriorApp    *PriorApplication `json:"prior_application,omitempty"`
+	WorkStyle   *WorkStyle        `json:"work_style,omitempty"`
+}
+
+// WorkStyle flags work-culture signals of a listing, from its text or a list of
+// employers known for them.
+type WorkStyle struct {
+	FourDayWeek bool     `json:"four_day_week,omitempty"`
+	AsyncFirst  bool     `json:"async_first,omitempty"`
+	NoMeetings  bool     `json:"no_meetings,omitempty"` // no-meeting days or a minimal-meetings culture
+	Signals     []string `json:"signals,omitempty"`     // the phrases or "known employer" evidence behind the flags
+}
+
+// PriorApplication is the latest job tracker application at a listing's company.
+type PriorApplication struct {
+	Status string `json:"status"` // applied, interview, offer or rejected
+	Title  string `json:"title"`  // the tracked role
+	Date   string `json:"date"`   // YYYY-MM-DD of the last status change
+}
+
+// MatchExplanation lists which of the user's resume skills a listing mentions, and
+// where, and which of its required skills the resume lacks. Skill names are the
+// canonical names of the skill normalizer.
+type MatchExplanation struct {
+	TitleMatches       []string `json:"title_matches,omitempty"`
+	DescriptionMatches []string `json:"description_matches,omitempty"` // description and skill tags
+	Unmet              []string `json:"unmet,omitempty"`
+}
+
+// Output versions for search tools (job_search, remote_work_search, freelance_search,
+// linkedin_jobs). V1 is the default and its shape is frozen.
+const (
+	OutputV1 = 1
+	OutputV2 = 2
+)
+
+// NormalizedSalary is a listing's salary range converted to annual figures.
+type NormalizedSalary struct {
+	AnnualMin *int   `json:"annual_min,omitempty"`
+	AnnualMax *int   `json:"annual_max,omitempty"`
+	Currency  string `json:"currency,omitempty"`
+}
+
+// Eligibility describes who can apply to a listing.
+type Eligibility struct {
+	RemoteScope         string   `json:"remote_scope,omitempty"`         // comma-separated: "worldwide", "us", "eu", "uk", "canada", "latam", "apac" or countries ("germany")
+	VisaSponsorship     string   `json:"visa_sponsorship,omitempty"`     // "yes", "no", or empty when not stated
+	CitizenshipRequired string   `json:"citizenship_required,omitempty"` // "us", "uk", "canada", "australia", "eu": only citizens may apply
+	ClearanceRequired   string   `json:"clearance_required,omitempty"`   // comma-separated: "security_clearance", "finra_license", "medical_license", "bar_admission", "cpa_license"
+	ImmediateStart      bool     `json:"immediate_start,omitempty"`      // the listing asks for an immediate or ASAP start
+	StartConflict       string   `json:"start_conflict,omitempty"`       // why the user cannot make an immediate start, e.g. "earliest start 2026-11-12"
+	OverlapHours        *float64 `json:"overlap_hours,omitempty"`        // working hours shared with the team's time zone(s)
+	Deadline            string   `json:"deadline,omitempty"`             // YYYY-MM-DD
+}
+
+// CompanyInfo is what the company store knows about a listing's employer.
+type CompanyInfo struct {
+	Domain       string   `json:"domain,omitempty"`
+	ATS          string   `json:"ats,omitempty"`
+	Size         string   `json:"size,omitempty"`
+	HQ           string   `json:"hq,omitempty"`
+	Industry     string   `json:"industry,omitempty"`
+	Rating       float64  `json:"rating,omitempty"`
+	TechStack    []string `json:"tech_stack,omitempty"`
+	EmployerRisk string   `json:"employer_risk,omitempty"` // "low", "medium", "high": layoffs, hiring freeze or slowing hiring
+	RiskSignals  []string `json:"risk_signals,omitempty"`
+}
+
+// ListingScores groups the computed quality signals for a listing.
+type ListingScores struct {
+	ScamRisk       string   `json:"scam_risk,omitempty"`
+	ScamSignals    []string `json:"scam_signals,omitempty"`
+	Evergreen      bool     `json:"likely_evergreen,omitempty"`
+	DaysOpen       int      `json:"days_open,omitempty"`
+	DeadlineUrgent bool     `json:"deadline_urgent,omitempty"`
+	CompFit        string   `json:"comp_fit,omitempty"`  // "below_floor", "below_target", "meets_target" vs the profile salary floor/target
+	Equity         bool     `json:"equity,omitempty"`    // the listing mentions equity or stock options
+	StackFit       float64  `json:"stack_fit,omitempty"` // 0-1 share of the company's posting stack the user has
+	Freshness      float64  `json:"freshness,omitempty"` // 1 if posted today, halving every 14 days
 }
 
 // JobSearchOutput is the structured output for job_search.
@@ -47,21 +182,23 @@ type JobSearchOutput struct {
 }
 
 type FreelanceSearchInput struct {
-	Query    string `json:"query" jsonschema:"Search query for freelance projects (e.g. golang API developer, React frontend)"`
-	Platform string `json:"platform,omitempty" jsonschema:"Platform filter: upwork, freelancer, all (default: all)"`
-	Language string `json:"language,omitempty" jsonschema:"Language code (default: all)"`
+	Query         string `json:"query" jsonschema:"Search query for freelance projects (e.g. golang API developer, React frontend)"`
+	Platform      string `json:"platform,omitempty" jsonschema:"Platform filter: upwork, freelancer, all (default: all)"`
+	Language      string `json:"language,omitempty" jsonschema:"Language code (default: all)"`
+	OutputVersion int    `json:"output_version,omitempty" jsonschema:"Output shape: 1 (default, stable) or 2 (adds a scores block with scam_risk and scam_signals)"`
 }
 
 // FreelanceProject is a structured representation of a freelance project listing.
 type FreelanceProject struct {
-	Title       string   `json:"title"`
-	URL         string   `json:"url"`
-	Platform    string   `json:"platform"`
-	Budget      string   `json:"budget"`
-	Skills      []string `json:"skills"`
-	Description string   `json:"description"`
-	Posted      string   `json:"posted"`
-	ClientInfo  string   `json:"client_info,omitempty"`
+	Title       string         `json:"title"`
+	URL         string         `json:"url"`
+	Platform    string         `json:"platform"`
+	Budget      string         `json:"budget"`
+	Skills      []string       `json:"skills"`
+	Description string         `json:"description"`
+	Posted      string         `json:"posted"`
+	ClientInfo  string         `json:"client_info,omitempty"`
+	Scores      *ListingScores `json:"scores,omitempty"` // output_version 2
 }
 
 // FreelanceSearchOutput is the structured output for freelance_search.
@@ -73,21 +210,29 @@ type FreelanceSearchOutput struct {
 
 // RemoteWorkSearchInput is the input for the remote_work_search tool.
 type RemoteWorkSearchInput struct {
-	Query    string `json:"query" jsonschema:"Search keywords for remote jobs (e.g. golang, react developer, devops)"`
-	Language string `json:"language,omitempty" jsonschema:"Language code for the answer (default: all)"`
+	Query           string  `json:"query" jsonschema:"Search keywords for remote jobs (e.g. golang, react developer, devops)"`
+	Language        string  `json:"language,omitempty" jsonschema:"Language code for the answer (default: all)"`
+	KeepBelowFloor  bool    `json:"keep_below_floor,omitempty" jsonschema:"Keep listings paying below the profile salary_floor (hidden by default) and mark them comp_fit=below_floor"`
+	MinOverlapHours float64 `json:"min_overlap_hours,omitempty" jsonschema:"Drop listings whose team time zone overlaps the profile working day by fewer hours (listings without time zones are kept)"`
+	EligibleFrom    string  `json:"eligible_from,omitempty" jsonschema:"Country or region you can work from (e.g. Germany, EU, US): drops listings restricted to other countries or regions (listings stating no restriction are kept)"`
+	OutputVersion   int     `json:"output_version,omitempty" jsonschema:"Output shape: 1 (default, stable) or 2 (adds salary_normalized, comp_fit, overlap_hours and eligible_from; the filters apply in both)"`
 }
 
 // RemoteJobListing is a structured representation of a remote job listing.
 type RemoteJobListing struct {
-	Title    string   `json:"title"`
-	Company  string   `json:"company"`
-	URL      string   `json:"url"`
-	Source   string   `json:"source"`
-	Salary   string   `json:"salary"`
-	Location string   `json:"location"`
-	Tags     []string `json:"tags"`
-	Posted   string   `json:"posted"`
-	JobType  string   `json:"job_type"`
+	Title        string            `json:"title"`
+	Company      string            `json:"company"`
+	URL          string            `json:"url"`
+	Source       string            `json:"source"`
+	Salary       string            `json:"salary"`
+	SalaryNorm   *NormalizedSalary `json:"salary_normalized,omitempty"` // parsed from salary when it states amounts (output_version 2, like the fields below)
+	Location     string            `json:"location"`
+	Tags         []string          `json:"tags"`
+	Posted       string            `json:"posted"`
+	JobType      string            `json:"job_type"`
+	CompFit      string            `json:"comp_fit,omitempty"`      // "below_floor", "below_target", "meets_target" vs the profile salary floor/target
+	OverlapHours *float64          `json:"overlap_hours,omitempty"` // working hours shared with the team's time zone(s)
+	EligibleFrom []string          `json:"eligible_from,omitempty"` // "worldwide", regions ("us", "eu", "uk", "canada", "latam", "apac") or countries ("germany")
 }
 
 // RemoteWorkSearchOutput is the structured output for remote_work_search.
@@ -115,9 +260,11 @@ type JobMatchResult struct {
 	Location         string   `json:"location,omitempty"`
 	Source           string   `json:"source,omitempty"`
 	Snippet          string   `json:"snippet,omitempty"`
-	MatchScore       float64  `json:"match_score"`        // 0–100 Jaccard keyword overlap
-	MatchingKeywords []string `json:"matching_keywords"` // resume skills this job wants
-	MissingKeywords  []string `json:"missing_keywords"`  // job keywords absent from resume
+	MatchScore       float64  `json:"match_score"`              // 0–100 Jaccard keyword overlap, recency-weighted with a master resume
+	MatchingKeywords []string `json:"matching_keywords"`        // resume skills this job wants
+	MissingKeywords  []string `json:"missing_keywords"`         // job keywords absent from resume
+	StaleKeywords    []string `json:"stale_keywords,omitempty"` // matching skills last used over 2 years ago (weigh less)
+	MatchingYears    []string `json:"matching_years,omitempty"` // years with matching skills per the master resume, e.g. "Go: 6.5 years"
 }
 
 // JobMatchScoreOutput is the structured output for job_match_score.
@@ -137,6 +284,18 @@ type SalaryResearchInput struct {
 // CompanyResearchInput is the input for company_research.
 type CompanyResearchInput struct {
 	Company string `json:"company"`
+	Refresh bool   `json:"refresh,omitempty" jsonschema:"Research again instead of returning the stored result (results are kept for 30 days)"`
+}
+
+// TakeHomeResearchInput is the input for takehome_research.
+type TakeHomeResearchInput struct {
+	Company string `json:"company" jsonschema:"Company name"`
+	Role    string `json:"role,omitempty" jsonschema:"Role title, e.g. Backend Engineer (narrows the search)"`
+}
+
+// CompanyNewsInput is the input for company_news.
+type CompanyNewsInput struct {
+	Company string `json:"company" jsonschema:"Company name"`
 }
 
 // ResumeAnalyzeInput is the input for resume_analyze.
@@ -150,6 +309,7 @@ type CoverLetterInput struct {
 	Resume         string `json:"resume"`
 	JobDescription string `json:"job_description"`
 	Tone           string `json:"tone,omitempty"`
+	Company        string `json:"company,omitempty"`
 }
 
 // ResumeTailorInput is the input for resume_tailor.
@@ -166,6 +326,30 @@ type InterviewPrepInput struct {
 	Focus          string `json:"focus,omitempty" jsonschema:"Focus area: all (default), behavioral, technical, system_design"`
 }
 
+// StarStoriesInput is the input for star_stories.
+type StarStoriesInput struct {
+	Refresh    bool   `json:"refresh,omitempty" jsonschema:"Regenerate the story bank from the master resume instead of returning the stored one"`
+	Competency string `json:"competency,omitempty" jsonschema:"Only return stories tagged with this competency (e.g. leadership, conflict, scale, failure)"`
+}
+
+// CompetencyCoverageInput is the input for competency_coverage.
+type CompetencyCoverageInput struct {
+	Company   string `json:"company,omitempty" jsonschema:"Company whose values or leadership principles to map against (default: standard competency framework)"`
+	ValuesURL string `json:"values_url,omitempty" jsonschema:"URL of the company's values page (found by search when omitted)"`
+}
+
+// MockInterviewInput is the input for mock_interview.
+type MockInterviewInput struct {
+	SessionID      string `json:"session_id,omitempty" jsonschema:"Session to continue; omit to start a new mock interview"`
+	Answer         string `json:"answer,omitempty" jsonschema:"Your answer to the current question (continuing a session)"`
+	End            bool   `json:"end,omitempty" jsonschema:"Finish the session now and get the debrief"`
+	JobDescription string `json:"job_description,omitempty" jsonschema:"Job description to interview for (required to start)"`
+	Company        string `json:"company,omitempty" jsonschema:"Company name (adds company research and reported interview questions)"`
+	Resume         string `json:"resume,omitempty" jsonschema:"Your resume text (default: master resume)"`
+	Focus          string `json:"focus,omitempty" jsonschema:"Focus area: all (default), behavioral, technical, system_design"`
+	Questions      int    `json:"questions,omitempty" jsonschema:"Number of questions (default 5, max 10)"`
+}
+
 // PersonResearchInput is the input for person_research.
 type PersonResearchInput struct {
 	Name     string `json:"name" jsonschema:"Full name of the person to research"`
@@ -176,6 +360,44 @@ type PersonResearchInput struct {
 // MasterResumeBuildInput is the input for master_resume_build.
 type MasterResumeBuildInput struct {
 	Resume string `json:"resume" jsonschema:"Full resume text — all experience, education, skills, projects, achievements, certifications"`
+	Async  bool   `json:"async,omitempty" jsonschema:"Run in the background and return a job_id immediately; poll job_status for progress and the result"`
+
+	IdempotencyKey string `json:"idempotency_key,omitempty" jsonschema:"Optional client key; retrying with the same key within 24h returns the original result instead of rebuilding"`
+}
+
+// JobStatusInput is the input for job_status.
+type JobStatusInput struct {
+	JobID string `json:"job_id" jsonschema:"Job ID returned by a tool started with async=true"`
+}
+
+// MasterResumeStatusInput is the input for master_resume_status.
+type MasterResumeStatusInput struct {
+	Repair bool `json:"repair,omitempty" jsonschema:"Fix detected problems: drop stray persons and orphan rows, replay the last build into the graph and vector store"`
+}
+
+// MasterResumeRollbackInput is the input for master_resume_rollback.
+type MasterResumeRollbackInput struct {
+	DryRun bool `json:"dry_run,omitempty" jsonschema:"Only compare the previous build with the current one"`
+}
+
+// ExperienceYearsInput is the input for experience_years.
+type ExperienceYearsInput struct {
+	Skill string `json:"skill,omitempty" jsonschema:"Only this skill (name or alias, e.g. golang)"`
+}
+
+// AuditLogInput is the input for audit_log.
+type AuditLogInput struct {
+	Tool  string `json:"tool,omitempty" jsonschema:"Only calls of this tool, e.g. master_resume_build"`
+	Since string `json:"since,omitempty" jsonschema:"Only calls at or after this date (YYYY-MM-DD or RFC 3339)"`
+	Limit int    `json:"limit,omitempty" jsonschema:"Maximum entries, newest first (default 50, max 500)"`
+}
+
+// HHResumeSyncInput is the input for hh_resume_sync.
+type HHResumeSyncInput struct {
+	ResumeID  string `json:"resume_id,omitempty" jsonschema:"Existing hh.ru resume ID to update; empty creates a new resume"`
+	Title     string `json:"title,omitempty" jsonschema:"Desired position (default: the most recent experience title)"`
+	SalaryRUB int    `json:"salary_rub,omitempty" jsonschema:"Desired monthly salary in RUB (optional)"`
+	DryRun    bool   `json:"dry_run,omitempty" jsonschema:"Return the mapped hh.ru payload and field gaps without publishing"`
 }
 
 // ResumeGenerateInput is the input for resume_generate.
@@ -183,11 +405,31 @@ type ResumeGenerateInput struct {
 	JobDescription string `json:"job_description" jsonschema:"Job description to tailor the resume for"`
 	Company        string `json:"company,omitempty" jsonschema:"Company name (enriches with company research)"`
 	Format         string `json:"format,omitempty" jsonschema:"Output format: text (default), markdown, json"`
+	Metrics        string `json:"metrics,omitempty" jsonschema:"Figures not backed by an achievement metric: ask (default, returns resume_enrich questions) or remove (drops the claim)"`
+}
+
+// ResumeVariantsInput is the input for resume_variants.
+type ResumeVariantsInput struct {
+	JobDescription string `json:"job_description" jsonschema:"Job description to tailor both variants for"`
+	Title          string `json:"title,omitempty" jsonschema:"Job title, to recognize the variants later"`
+	Company        string `json:"company,omitempty" jsonschema:"Company name (enriches with company research)"`
+	Format         string `json:"format,omitempty" jsonschema:"Output format: text (default), markdown, json"`
+}
+
+// ResumeABReportInput is the input for resume_ab_report.
+type ResumeABReportInput struct {
+	Months int `json:"months,omitempty" jsonschema:"Only applications added in the last N months (default 6, max 24)"`
+}
+
+// ResumePDFCheckInput is the input for resume_pdf_check.
+type ResumePDFCheckInput struct {
+	Path          string `json:"path,omitempty" jsonschema:"File name of the rendered resume PDF in ~/.go_job/resumes"`
+	ContentBase64 string `json:"content_base64,omitempty" jsonschema:"The PDF file as base64, instead of path"`
 }
 
 // ResumeProfileInput is the input for resume_profile.
 type ResumeProfileInput struct {
-	Section string `json:"section,omitempty" jsonschema:"Optional: filter by section (experiences, skills, projects, achievements, educations, certifications, domains, methodologies, summary). Empty = return all."`
+	Section string `json:"section,omitempty" jsonschema:"Optional: filter by section (experiences, skills, projects, achievements, educations, certifications, domains, methodologies, publications, talks, patents, open_source, summary). Empty = return all."`
 }
 
 // ResumeMemorySearchInput is the input for resume_memory_search.
@@ -208,6 +450,11 @@ type ResumeMemoryUpdateInput struct {
 	Content  string `json:"content" jsonschema:"New content to replace the existing memory"`
 }
 
+// VectorsResyncInput is the input for vectors_resync.
+type VectorsResyncInput struct {
+	DryRun bool `json:"dry_run,omitempty" jsonschema:"Only report missing and orphan vectors without changing MemDB"`
+}
+
 // ProjectShowcaseInput is the input for project_showcase.
 type ProjectShowcaseInput struct {
 	Projects   string `json:"projects" jsonschema:"Project descriptions, GitHub URLs, or resume section with projects"`
@@ -221,12 +468,38 @@ type PitchGenerateInput struct {
 	Company    string `json:"company,omitempty" jsonschema:"Company name (enriches with company research for why-this-company answer)"`
 }
 
+// ProposalGenerateInput is the input for proposal_generate.
+type ProposalGenerateInput struct {
+	Project        string   `json:"project,omitempty" jsonschema:"Freelance project description (e.g. from freelance_search)"`
+	URL            string   `json:"url,omitempty" jsonschema:"Project page URL to fetch the description from (used when project is empty)"`
+	Platform       string   `json:"platform,omitempty" jsonschema:"upwork (default) or freelancer"`
+	Length         string   `json:"length,omitempty" jsonschema:"short (80-120 words), medium (150-220, default) or long (250-350)"`
+	Rate           float64  `json:"rate,omitempty" jsonschema:"Your usual hourly rate, used for the bid suggestion (default: derived from the profile's target compensation)"`
+	Currency       string   `json:"currency,omitempty" jsonschema:"Currency of rate (default: USD)"`
+	PortfolioLinks []string `json:"portfolio_links,omitempty" jsonschema:"Portfolio links to reference, in addition to the URLs of resume projects"`
+}
+
 // SkillGapInput is the input for skill_gap.
 type SkillGapInput struct {
 	Resume         string `json:"resume" jsonschema:"Your resume text"`
 	JobDescription string `json:"job_description" jsonschema:"Target job description to analyze gaps against"`
 }
 
+// CertRecommendInput is the input for cert_recommend.
+type CertRecommendInput struct {
+	Skills         []string `json:"skills,omitempty" jsonschema:"Skills to find certifications/courses for (default: top gaps from skill_gap on resume + job_description)"`
+	Resume         string   `json:"resume,omitempty" jsonschema:"Your resume text (default: master resume)"`
+	JobDescription string   `json:"job_description,omitempty" jsonschema:"Target job description to find the skill gaps against"`
+	MaxSkills      int      `json:"max_skills,omitempty" jsonschema:"How many gap skills to cover (default 3, max 5)"`
+}
+
+// JDRedFlagsInput is the input for jd_red_flags.
+type JDRedFlagsInput struct {
+	JobDescription string `json:"job_description,omitempty" jsonschema:"Job description text to analyze"`
+	URL            string `json:"url,omitempty" jsonschema:"Job posting URL to fetch the description from (used when job_description is empty)"`
+	Company        string `json:"company,omitempty" jsonschema:"Employer name; adds red flags from its recent news (layoffs, lawsuits)"`
+}
+
 // ApplicationPrepInput is the input for application_prep.
 type ApplicationPrepInput struct {
 	Resume         string `json:"resume" jsonschema:"Your resume text"`
@@ -251,6 +524,21 @@ type NegotiationPrepInput struct {
 	Leverage     string `json:"leverage,omitempty" jsonschema:"Your leverage: competing offers, unique skills, market demand"`
 }
 
+// IncomeCompareInput is the input for income_compare.
+type IncomeCompareInput struct {
+	Amount          float64 `json:"amount" jsonschema:"Rate or salary to convert (gross)"`
+	Unit            string  `json:"unit,omitempty" jsonschema:"Per hour, day, month or year (default: hour for contract, year for salary)"`
+	Type            string  `json:"type,omitempty" jsonschema:"What amount is: contract (freelance rate, converted to a salary) or salary (converted to a contract rate). Default: contract"`
+	Currency        string  `json:"currency,omitempty" jsonschema:"Currency of amount: USD, EUR, RUB, KZT, BYN, UAH (default: USD)"`
+	TargetCurrency  string  `json:"target_currency,omitempty" jsonschema:"Currency of the equivalent figure (default: same as currency)"`
+	HoursPerWeek    float64 `json:"hours_per_week,omitempty" jsonschema:"Working hours per week (default: 40)"`
+	UnpaidWeeks     float64 `json:"unpaid_weeks,omitempty" jsonschema:"Weeks per year a contractor does not bill: vacation, holidays, sick days, gaps (default: 6)"`
+	BenefitsRate    float64 `json:"benefits_rate,omitempty" jsonschema:"Value of employer benefits as a fraction of salary (default: 0.2)"`
+	ContractTaxRate float64 `json:"contract_tax_rate,omitempty" jsonschema:"Effective tax rate on contract income, as a fraction (default: 0.3)"`
+	SalaryTaxRate   float64 `json:"salary_tax_rate,omitempty" jsonschema:"Effective tax rate on a salary, as a fraction (default: 0.25)"`
+	ContractCosts   float64 `json:"contract_costs,omitempty" jsonschema:"Contractor business costs (insurance, accounting, equipment) as a fraction of revenue (default: 0.05)"`
+}
+
 // --- Bounty search types ---
 
 // BountySearchInput is the input for the bounty_search tool.
@@ -327,5 +615,8 @@ type ResumeEnrichInput struct {
 	Answers []struct {
 		QuestionID string `json:"question_id" jsonschema:"ID of the question being answered"`
 		Answer     string `json:"answer" jsonschema:"Your answer to the question"`
+		Question   string `json:"question,omitempty" jsonschema:"Question text, for questions not from action='start' (e.g. resume_generate metric_questions)"`
+		Context    string `json:"context,omitempty" jsonschema:"Question context, passed back as returned"`
 	} `json:"answers,omitempty" jsonschema:"Answers to enrichment questions (required when action='answer')"`
+	Async bool `json:"async,omitempty" jsonschema:"Run in the background and return a job_id immediately; poll job_status for progress and the result"`
 }
@@ -20,7 +20,8 @@ type FreelanceJob struct {
 	Tags      []string `json:"tags"`
 	SalaryMin int      `json:"salary_min,omitempty"`
 	SalaryMax int      `json:"salary_max,omitempty"`
-	Source    string   `json:"source"` // remoteok, himalayas
+	Currency  string   `json:"currency,omitempty"` // salary currency when not USD-implied
+	Source    string   `json:"source"`             // remoteok, himalayas
 	Posted    string   `json:"posted"`
 	Location  string   `json:"location,omitempty"`
 }
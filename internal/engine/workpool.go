@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/anatolykoptev/go-engine/metrics"
+)
+
+// Worker pool metrics; <pool> is "outbound" or "source".
+// <pool>_queued and <pool>_running are current values, the others totals.
+const (
+	metricPoolQueued  = "_queued"
+	metricPoolRunning = "_running"
+	metricPoolTasks   = "_tasks"
+	metricPoolWaitMS  = "_wait_ms"
+)
+
+// WorkPool bounds how many tasks run at once across every caller sharing it.
+// Tasks past the limit queue until a worker frees up. A nil pool runs everything
+// immediately.
+type WorkPool struct {
+	name  string
+	slots chan struct{}
+	reg   *metrics.Registry
+}
+
+// newWorkPool returns a pool of size workers, nil when size <= 0 (no limit).
+func newWorkPool(name string, size int, reg *metrics.Registry) *WorkPool {
+	if size <= 0 {
+		return nil
+	}
+	return &WorkPool{name: name, slots: make(chan struct{}, size), reg: reg}
+}
+
+// Acquire waits for a free worker and returns the func that frees it.
+// It fails only when ctx ends first.
+func (p *WorkPool) Acquire(ctx context.Context) (release func(), err error) {
+	if p == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+	p.reg.Add(p.name+metricPoolQueued, 1)
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		p.reg.Add(p.name+metricPoolQueued, -1)
+		return nil, ctx.Err()
+	}
+	p.reg.Add(p.name+metricPoolQueued, -1)
+	p.reg.Add(p.name+metricPoolWaitMS, time.Since(start).Milliseconds())
+	p.reg.Incr(p.name + metricPoolTasks)
+	p.reg.Add(p.name+metricPoolRunning, 1)
+	return func() {
+		p.reg.Add(p.name+metricPoolRunning, -1)
+		<-p.slots
+	}, nil
+}
+
+// Go runs fn in a new goroutine once a worker is free. If ctx ends while queued,
+// fn still runs, without a worker: its requests fail fast on the done ctx, and
+// callers that count on one result per task are not left waiting.
+func (p *WorkPool) Go(ctx context.Context, fn func()) {
+	go func() {
+		if release, err := p.Acquire(ctx); err == nil {
+			defer release()
+		}
+		fn()
+	}()
+}
+
+// GoOutbound runs fn on the outbound pool of the engine bound to ctx, which every
+// tool shares for detail-page, per-item API and search sub-query fetches
+// (GO_JOB_MAX_OUTBOUND_FETCHES). Use it in place of a go statement around a leaf
+// fetch; a task that itself fans out to GoOutbound must stay a plain goroutine so
+// it never holds a slot its children are waiting for.
+func GoOutbound(ctx context.Context, fn func()) {
+	From(ctx).outbound.Go(ctx, fn)
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anatolykoptev/go-engine/metrics"
+)
+
+func TestWorkPoolBoundsConcurrency(t *testing.T) {
+	reg := metrics.New()
+	p := newWorkPool("outbound", 3, reg)
+	var running, peak atomic.Int64
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		p.Go(context.Background(), func() {
+			defer wg.Done()
+			n := running.Add(1)
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+		})
+	}
+	wg.Wait()
+	if got := peak.Load(); got > 3 {
+		t.Errorf("peak concurrency %d, want <= 3", got)
+	}
+	if q, r, n := reg.Value("outbound_queued"), reg.Value("outbound_running"), reg.Value("outbound_tasks"); q != 0 || r != 0 || n != 20 {
+		t.Errorf("queued=%d running=%d tasks=%d, want 0/0/20", q, r, n)
+	}
+}
+
+func TestWorkPoolAcquireCanceled(t *testing.T) {
+	p := newWorkPool("source", 1, metrics.New())
+	release, err := p.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Acquire(ctx); err == nil {
+		t.Error("Acquire on a full pool with a done ctx should fail")
+	}
+
+	// A nil pool (size 0) never blocks.
+	unlimited := newWorkPool("outbound", 0, nil)
+	if _, err := unlimited.Acquire(ctx); err != nil {
+		t.Errorf("nil pool: %v", err)
+	}
+}
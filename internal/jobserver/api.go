@@ -0,0 +1,179 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+)
+
+// REST facade over a few MCP tools, for clients that don't speak MCP
+// (e.g. a browser extension saving the job page the user is looking at).
+
+const apiMaxBody = 1 << 20
+
+// apiAnalyzeRequest is the body of POST /api/v1/analyze.
+type apiAnalyzeRequest struct {
+	URL            string `json:"url,omitempty"`
+	JobDescription string `json:"job_description,omitempty"`
+	Company        string `json:"company,omitempty"`
+}
+
+// apiMatchResponse is the body returned by GET /api/v1/match.
+type apiMatchResponse struct {
+	URL              string   `json:"url"`
+	Title            string   `json:"title,omitempty"`
+	MatchScore       float64  `json:"match_score"`
+	MatchingKeywords []string `json:"matching_keywords"`
+	MissingKeywords  []string `json:"missing_keywords"`
+}
+
+// registerAPIRoutes registers the /api/v1 endpoints behind the API token.
+func registerAPIRoutes(mux *http.ServeMux, deps *Deps, token string) {
+	mux.HandleFunc("POST /api/v1/track", deps.handler(apiCORS(requireToken(token, serveAPITrack))))
+	mux.HandleFunc("POST /api/v1/analyze", deps.handler(apiCORS(requireToken(token, serveAPIAnalyze))))
+	mux.HandleFunc("GET /api/v1/match", deps.handler(apiCORS(requireToken(token, serveAPIMatch))))
+	mux.HandleFunc("GET /api/v1/bookmarks", deps.handler(apiCORS(requireToken(token, serveAPIBookmarks))))
+	mux.HandleFunc("OPTIONS /api/v1/", apiCORS(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+// apiCORS allows browser-extension origins to call the API.
+func apiCORS(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if strings.HasPrefix(origin, "chrome-extension://") || strings.HasPrefix(origin, "moz-extension://") {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			w.Header().Set("Vary", "Origin")
+		}
+		h(w, r)
+	}
+}
+
+// serveAPITrack saves a job to the tracker (job_tracker_add). A missing title is
+// taken from the page title when url is given.
+func serveAPITrack(w http.ResponseWriter, r *http.Request) {
+	if engine.ConfigFrom(r.Context()).ReadOnly {
+		writeAPIJSON(w, http.StatusForbidden, readOnlyRejection("job_tracker_add"))
+		return
+	}
+	var input jobs.JobTrackerAddInput
+	if err := decodeAPIBody(r, &input); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	if input.Title == "" && input.URL != "" {
+		if title, _, err := engine.FetchJobPage(r.Context(), input.URL); err == nil {
+			input.Title = strings.TrimSpace(title)
+		}
+	}
+	if input.Title == "" || input.Company == "" {
+		writeAPIError(w, http.StatusBadRequest, errors.New("title and company are required"))
+		return
+	}
+	result, err := jobs.AddTrackedJob(r.Context(), input)
+	if body, mErr := json.Marshal(input); mErr == nil { // audited like the tool it mirrors
+		jobs.RecordAudit(r.Context(), "job_tracker_add", body, err)
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusCreated, result)
+}
+
+// serveAPIAnalyze runs jd_red_flags on a job description or URL.
+func serveAPIAnalyze(w http.ResponseWriter, r *http.Request) {
+	var input apiAnalyzeRequest
+	if err := decodeAPIBody(r, &input); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	jd := input.JobDescription
+	if jd == "" && input.URL != "" {
+		text, err := jobs.FetchJobPosting(r.Context(), input.URL)
+		if err != nil {
+			writeAPIError(w, http.StatusBadGateway, fmt.Errorf("fetch job description: %w", err))
+			return
+		}
+		jd = text
+	}
+	if jd == "" {
+		writeAPIError(w, http.StatusBadRequest, errors.New("job_description or url is required"))
+		return
+	}
+	result, err := jobs.AnalyzeJDRedFlags(r.Context(), jd, input.Company)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, result)
+}
+
+// serveAPIMatch scores the job page at ?url= against the master resume.
+func serveAPIMatch(w http.ResponseWriter, r *http.Request) {
+	jobURL := r.URL.Query().Get("url")
+	if jobURL == "" {
+		writeAPIError(w, http.StatusBadRequest, errors.New("url is required"))
+		return
+	}
+	feed, err := jobs.BuildResumeFeed(r.Context())
+	if err != nil {
+		writeAPIError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	title, text, err := engine.FetchJobPage(r.Context(), jobURL)
+	if err != nil {
+		writeAPIError(w, http.StatusBadGateway, fmt.Errorf("fetch job page: %w", err))
+		return
+	}
+	score, matching, missing := jobs.ScoreJobMatchWeighted(jobs.ExtractResumeKeywords(jobs.ResumeFeedText(feed)), jobs.MasterSkillWeights(r.Context()), title+" "+text)
+	writeAPIJSON(w, http.StatusOK, apiMatchResponse{
+		URL:              jobURL,
+		Title:            title,
+		MatchScore:       score,
+		MatchingKeywords: matching,
+		MissingKeywords:  missing,
+	})
+}
+
+// serveAPIBookmarks downloads the last search (or ?source=tracker&status=) as a
+// Netscape bookmarks file. The page goes only to the response; nothing is written
+// to ~/.go_job/exports.
+func serveAPIBookmarks(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	result, fileName, err := jobs.RenderJobBookmarks(jobs.JobBookmarksInput{Source: q.Get("source"), Status: q.Get("status")})
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+	_, _ = w.Write([]byte(result.HTML))
+}
+
+func decodeAPIBody(r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, apiMaxBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return nil
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	writeAPIJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,66 @@
+package jobserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAPIRoutes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	mux := http.NewServeMux()
+	registerAPIRoutes(mux, nil, "secret")
+
+	do := func(method, path, body, token, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodPost, "/api/v1/track", `{"title":"Go Dev","company":"Acme"}`, "wrong", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("bad token: status %d, want 401", rec.Code)
+	}
+	if rec := do(http.MethodPost, "/api/v1/track", `{"company":"Acme"}`, "secret", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("missing title: status %d, want 400", rec.Code)
+	}
+	if rec := do(http.MethodPost, "/api/v1/track", `{"title":"Go Dev","company":"Acme","bogus":1}`, "secret", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown field: status %d, want 400", rec.Code)
+	}
+	rec := do(http.MethodPost, "/api/v1/track", `{"title":"Go Dev","company":"Acme","status":"applied"}`, "secret", "chrome-extension://abc")
+	if rec.Code != http.StatusCreated || !strings.Contains(rec.Body.String(), `"id"`) {
+		t.Errorf("track: status %d body %s", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "chrome-extension://abc" {
+		t.Errorf("CORS origin = %q", got)
+	}
+
+	do(http.MethodPost, "/api/v1/track", `{"title":"SRE","company":"Acme","url":"https://acme.example/jobs/1"}`, "secret", "")
+	rec = do(http.MethodGet, "/api/v1/bookmarks?source=tracker", "", "secret", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "https://acme.example/jobs/1") {
+		t.Errorf("bookmarks: status %d body %s", rec.Code, rec.Body)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".go_job", "exports")); !os.IsNotExist(err) {
+		t.Errorf("bookmarks wrote to the exports directory: %v", err)
+	}
+
+	if rec := do(http.MethodPost, "/api/v1/analyze", `{}`, "secret", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("analyze without input: status %d, want 400", rec.Code)
+	}
+	if rec := do(http.MethodGet, "/api/v1/match", "", "secret", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("match without url: status %d, want 400", rec.Code)
+	}
+	if rec := do(http.MethodOptions, "/api/v1/track", "", "", "moz-extension://x"); rec.Code != http.StatusNoContent {
+		t.Errorf("preflight: status %d, want 204", rec.Code)
+	}
+}
@@ -0,0 +1,73 @@
+package jobserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// mutatingTools are the tools that change stored data — the tracker, the master
+// resume and its graph, resume memory, the story bank, interview sessions, published
+// profiles — and get an audit_log entry per call. master_resume_status is audited only
+// with repair=true (see mutatingCall).
+var mutatingTools = map[string]bool{
+	"job_tracker_add":         true,
+	"job_tracker_update":      true,
+	"job_tracker_import":      true,
+	"gig_tracker_update":      true,
+	"gig_invoice":             true, // mark_invoiced
+	"rejection_retro":         true,
+	"resume_variants":         true,
+	"master_resume_build":     true,
+	"master_resume_rollback":  true,
+	"resume_enrich":           true,
+	"resume_memory_add":       true,
+	"resume_memory_update":    true,
+	"vectors_resync":          true,
+	"hh_resume_sync":          true,
+	"linkedin_profile_ingest": true,
+	"bounty_attempt":          true,
+	"opportunity_claim":       true,
+	"data_import":             true,
+	"star_stories":            true,
+	"mock_interview":          true,
+}
+
+// mutatingCall reports whether a call of tool with args changes stored data:
+// a mutatingTools call, or master_resume_status with repair=true, which deletes other
+// persons and orphan rows and replays the graph and vectors.
+func mutatingCall(tool string, args json.RawMessage) bool {
+	if tool == "master_resume_status" {
+		return repairRequested(args)
+	}
+	return mutatingTools[tool]
+}
+
+// auditMiddleware records every tools/call of a mutating tool in the audit log,
+// with its outcome.
+func auditMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if !ok || !mutatingCall(params.Name, params.Arguments) {
+			return next(ctx, method, req)
+		}
+		res, err := next(ctx, method, req)
+		callErr := err
+		if r, ok := res.(*mcp.CallToolResult); ok && r != nil && r.IsError && callErr == nil {
+			callErr = errors.New("tool error")
+			if len(r.Content) > 0 {
+				if text, ok := r.Content[0].(*mcp.TextContent); ok && text.Text != "" {
+					callErr = errors.New(text.Text)
+				}
+			}
+		}
+		jobs.RecordAudit(context.WithoutCancel(ctx), params.Name, params.Arguments, callErr)
+		return res, err
+	}
+}
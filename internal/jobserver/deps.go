@@ -0,0 +1,54 @@
+package jobserver
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Deps carries everything the tools depend on: the engine (config, HTTP/LLM
+// clients, cache) and the data stores. It is built once (from env in main, or by
+// hand in tests and embedders) and passed to RegisterTools and RegisterRoutes,
+// which bind it to the context of every tool call and HTTP request. Two Deps in
+// one process share no state.
+type Deps struct {
+	Engine *engine.Engine
+	Stores jobs.Stores
+}
+
+// Bind returns ctx carrying d's engine and stores, which the tool handlers and the
+// engine and jobs functions they call read. A nil d leaves ctx unchanged.
+func (d *Deps) Bind(ctx context.Context) context.Context {
+	if d == nil {
+		return ctx
+	}
+	if d.Engine != nil {
+		ctx = engine.WithEngine(ctx, d.Engine)
+	}
+	return jobs.WithStores(ctx, d.Stores)
+}
+
+// config returns the engine configuration, the zero Config when d has no engine.
+func (d *Deps) config() *engine.Config {
+	if d == nil || d.Engine == nil {
+		return &engine.Config{}
+	}
+	return d.Engine.Config()
+}
+
+// middleware binds d to the context of every MCP request.
+func (d *Deps) middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return next(d.Bind(ctx), method, req)
+	}
+}
+
+// handler binds d to the context of every HTTP request.
+func (d *Deps) handler(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h(w, r.WithContext(d.Bind(r.Context())))
+	}
+}
@@ -0,0 +1,221 @@
+package jobserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+)
+
+// Overall and per-dependency health states.
+const (
+	healthOK        = "ok"
+	healthDegraded  = "degraded"
+	healthUnhealthy = "unhealthy"
+	healthDown      = "down"
+	healthDisabled  = "disabled"
+)
+
+const (
+	// healthCheckTimeout bounds each dependency probe.
+	healthCheckTimeout = 3 * time.Second
+	// healthCacheTTL reuses a report across frequent orchestrator probes.
+	healthCacheTTL = 5 * time.Second
+)
+
+// DependencyHealth is the state of one external dependency.
+type DependencyHealth struct {
+	Status    string `json:"status"` // ok, down or disabled
+	Required  bool   `json:"required"`
+	LatencyMS int64  `json:"latency_ms,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// HealthReport is the /health response body.
+type HealthReport struct {
+	Status       string                       `json:"status"` // ok, degraded or unhealthy
+	Service      string                       `json:"service"`
+	Version      string                       `json:"version"`
+	Dependencies map[string]*DependencyHealth `json:"dependencies,omitempty"`
+}
+
+// healthCheck probes one dependency. Required dependencies make the service
+// unhealthy when down; optional ones only degrade it.
+type healthCheck struct {
+	name     string
+	required bool
+	ping     func(ctx context.Context) error
+}
+
+// healthChecks lists the dependencies /health probes: the stores and, when one is
+// bound, the engine's backends.
+func healthChecks(ctx context.Context) []healthCheck {
+	checks := []healthCheck{
+		{name: "postgres", ping: func(ctx context.Context) error {
+			if db := jobs.StoresFrom(ctx).ResumeDB; db != nil {
+				return db.Ping(ctx)
+			}
+			return engine.ErrNotConfigured
+		}},
+		{name: "memdb", ping: func(ctx context.Context) error {
+			if mdb := jobs.StoresFrom(ctx).MemDB; mdb != nil {
+				return mdb.Ping(ctx)
+			}
+			return engine.ErrNotConfigured
+		}},
+	}
+	if engine.Bound(ctx) {
+		e := engine.From(ctx)
+		checks = append(checks,
+			healthCheck{name: "redis", ping: e.PingRedis},
+			// With a fallback search API configured, a SearXNG outage only degrades results.
+			healthCheck{name: "searxng", required: !e.HasSearchFallback(), ping: e.PingSearXNG},
+			healthCheck{name: "llm", required: true, ping: e.PingLLM},
+		)
+	}
+	return checks
+}
+
+// checkHealth runs all checks concurrently and folds them into a report.
+func checkHealth(ctx context.Context, service, version string, checks []healthCheck) *HealthReport {
+	rep := &HealthReport{
+		Status:       healthOK,
+		Service:      service,
+		Version:      version,
+		Dependencies: make(map[string]*DependencyHealth, len(checks)+1),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Go(func() {
+			cctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			start := time.Now()
+			err := c.ping(cctx)
+			d := &DependencyHealth{Status: healthOK, Required: c.required}
+			switch {
+			case errors.Is(err, engine.ErrNotConfigured):
+				d.Status = healthDisabled
+			case err != nil:
+				d.Status = healthDown
+				d.Error = err.Error()
+				d.LatencyMS = time.Since(start).Milliseconds()
+			default:
+				d.LatencyMS = time.Since(start).Milliseconds()
+			}
+			mu.Lock()
+			rep.Dependencies[c.name] = d
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+
+	if engine.Bound(ctx) {
+		d := &DependencyHealth{Status: healthOK}
+		switch n := engine.From(ctx).ProxyPoolSize(); {
+		case n < 0:
+			d.Status = healthDisabled
+		case n == 0:
+			d.Status = healthDown
+			d.Error = "proxy pool is empty"
+		default:
+			d.Detail = strconv.Itoa(n) + " proxies"
+		}
+		rep.Dependencies["proxy_pool"] = d
+	}
+
+	for _, d := range rep.Dependencies {
+		if d.Status != healthDown {
+			continue
+		}
+		if d.Required {
+			rep.Status = healthUnhealthy
+		} else if rep.Status == healthOK {
+			rep.Status = healthDegraded
+		}
+	}
+	return rep
+}
+
+// healthCache serves a recent report instead of re-probing on every request.
+type healthCache struct {
+	mu      sync.Mutex
+	report  *HealthReport
+	expires time.Time
+}
+
+func (c *healthCache) get(ctx context.Context, service, version string) *HealthReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.report == nil || time.Now().After(c.expires) {
+		c.report = checkHealth(ctx, service, version, healthChecks(ctx))
+		c.expires = time.Now().Add(healthCacheTTL)
+	}
+	return c.report
+}
+
+// RegisterHealth registers /health, /ready and the /health/live and /health/ready
+// probes in place of the go-mcpserver defaults (run with Config.DisableHealth).
+//
+// /health reports ok, degraded (an optional dependency is down) or unhealthy (SearXNG
+// or the LLM API is down, answered with 503). Per-dependency states are included;
+// ?verbose=1 adds latency, details and errors. /ready answers 503 only when unhealthy,
+// so a degraded instance keeps receiving traffic.
+func RegisterHealth(mux *http.ServeMux, deps *Deps, name, version string) {
+	cache := &healthCache{}
+
+	mux.HandleFunc("GET /health", deps.handler(func(w http.ResponseWriter, r *http.Request) {
+		rep := cache.get(r.Context(), name, version)
+		body := any(healthSummary(rep))
+		if r.URL.Query().Get("verbose") == "1" {
+			body = rep
+		}
+		writeHealth(w, healthCode(rep), body)
+	}))
+
+	ready := deps.handler(func(w http.ResponseWriter, r *http.Request) {
+		rep := cache.get(r.Context(), name, version)
+		writeHealth(w, healthCode(rep), map[string]string{"status": rep.Status})
+	})
+	mux.HandleFunc("GET /ready", ready)
+	mux.HandleFunc("GET /health/ready", ready)
+
+	mux.HandleFunc("GET /health/live", func(w http.ResponseWriter, _ *http.Request) {
+		writeHealth(w, http.StatusOK, map[string]string{"status": healthOK})
+	})
+}
+
+// healthSummary is the non-verbose body: overall status plus one state per dependency.
+func healthSummary(rep *HealthReport) map[string]any {
+	deps := make(map[string]string, len(rep.Dependencies))
+	for name, d := range rep.Dependencies {
+		deps[name] = d.Status
+	}
+	return map[string]any{
+		"status":       rep.Status,
+		"service":      rep.Service,
+		"version":      rep.Version,
+		"dependencies": deps,
+	}
+}
+
+func healthCode(rep *HealthReport) int {
+	if rep.Status == healthUnhealthy {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+func writeHealth(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
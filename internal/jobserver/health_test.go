@@ -0,0 +1,56 @@
+package jobserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestCheckHealth_Status(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("connection refused") }
+	off := func(context.Context) error { return engine.ErrNotConfigured }
+
+	tests := []struct {
+		name   string
+		checks []healthCheck
+		want   string
+	}{
+		{"all ok", []healthCheck{{name: "llm", required: true, ping: ok}, {name: "memdb", ping: ok}}, healthOK},
+		{"disabled is ok", []healthCheck{{name: "llm", required: true, ping: ok}, {name: "redis", ping: off}}, healthOK},
+		{"optional down", []healthCheck{{name: "llm", required: true, ping: ok}, {name: "memdb", ping: down}}, healthDegraded},
+		{"required down", []healthCheck{{name: "llm", required: true, ping: down}, {name: "memdb", ping: down}}, healthUnhealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rep := checkHealth(context.Background(), "go_job", "test", tt.checks)
+			if rep.Status != tt.want {
+				t.Errorf("status = %q, want %q", rep.Status, tt.want)
+			}
+			for _, c := range tt.checks {
+				if rep.Dependencies[c.name] == nil {
+					t.Errorf("missing dependency %q", c.name)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckHealth_DependencyDetail(t *testing.T) {
+	rep := checkHealth(context.Background(), "go_job", "test", []healthCheck{
+		{name: "redis", ping: func(context.Context) error { return engine.ErrNotConfigured }},
+		{name: "memdb", ping: func(context.Context) error { return errors.New("timeout") }},
+	})
+	if got := rep.Dependencies["redis"].Status; got != healthDisabled {
+		t.Errorf("redis = %q, want disabled", got)
+	}
+	memdb := rep.Dependencies["memdb"]
+	if memdb.Status != healthDown || memdb.Error != "timeout" {
+		t.Errorf("memdb = %+v, want down with error", memdb)
+	}
+	if code := healthCode(rep); code != 200 {
+		t.Errorf("degraded code = %d, want 200", code)
+	}
+}
@@ -0,0 +1,28 @@
+package jobserver
+
+import (
+	"context"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// callMetaMiddleware attaches cost/latency metadata (engine.ToolMeta) to every
+// tools/call result under _meta.go_job: elapsed time per phase, estimated LLM
+// tokens, cache hits and the sources consulted.
+func callMetaMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+		ctx, meta := engine.WithCallMeta(ctx)
+		res, err := next(ctx, method, req)
+		if r, ok := res.(*mcp.CallToolResult); ok && r != nil {
+			if r.Meta == nil {
+				r.Meta = mcp.Meta{}
+			}
+			r.Meta["go_job"] = meta.Snapshot()
+		}
+		return res, err
+	}
+}
@@ -0,0 +1,100 @@
+package jobserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestCallMetaMiddleware(t *testing.T) {
+	next := func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		engine.RecordSource(ctx, "linkedin")
+		return &mcp.CallToolResult{}, nil
+	}
+	h := callMetaMiddleware(next)
+
+	res, err := h(context.Background(), "tools/call", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta, ok := res.(*mcp.CallToolResult).Meta["go_job"].(engine.ToolMeta)
+	if !ok {
+		t.Fatalf("missing go_job meta: %+v", res)
+	}
+	if len(meta.Sources) != 1 || meta.Sources[0] != "linkedin" {
+		t.Errorf("sources = %v", meta.Sources)
+	}
+
+	res, _ = h(context.Background(), "tools/list", nil)
+	if r, ok := res.(*mcp.CallToolResult); ok && r.Meta != nil {
+		t.Error("meta should only be attached to tools/call")
+	}
+}
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	ran := false
+	next := func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		ran = true
+		return &mcp.CallToolResult{}, nil
+	}
+	h := readOnlyMiddleware(next)
+	ctx := context.Background()
+	callArgs := func(tool, args string) *mcp.CallToolResult {
+		ran = false
+		params := &mcp.CallToolParamsRaw{Name: tool}
+		if args != "" {
+			params.Arguments = json.RawMessage(args)
+		}
+		res, err := h(ctx, "tools/call", &mcp.CallToolRequest{Params: params})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.(*mcp.CallToolResult)
+	}
+	call := func(tool string) *mcp.CallToolResult { return callArgs(tool, "") }
+
+	if call("job_tracker_add"); !ran {
+		t.Error("writes should run when read-only mode is off")
+	}
+
+	ctx = engine.WithEngine(ctx, engine.New(engine.Config{ReadOnly: true}))
+	for _, tool := range []string{"job_tracker_add", "master_resume_build", "data_export"} {
+		res := call(tool)
+		if ran || !res.IsError {
+			t.Errorf("%s should be rejected: ran=%v result=%+v", tool, ran, res)
+			continue
+		}
+		if e, ok := res.StructuredContent.(ReadOnlyError); !ok || e.Error != "read_only" || e.Tool != tool {
+			t.Errorf("%s: structured error = %+v", tool, res.StructuredContent)
+		}
+	}
+	if call("job_search"); !ran {
+		t.Error("search should run in read-only mode")
+	}
+	if callArgs("master_resume_status", `{}`); !ran {
+		t.Error("master_resume_status check should run in read-only mode")
+	}
+	if res := callArgs("master_resume_status", `{"repair":true}`); ran || !res.IsError {
+		t.Errorf("master_resume_status repair should be rejected: ran=%v", ran)
+	}
+}
+
+func TestMutatingCall(t *testing.T) {
+	for _, tool := range []string{"job_tracker_add", "star_stories", "mock_interview"} {
+		if !mutatingCall(tool, nil) {
+			t.Errorf("%s should be audited", tool)
+		}
+	}
+	if mutatingCall("job_search", nil) {
+		t.Error("job_search should not be audited")
+	}
+	if mutatingCall("master_resume_status", json.RawMessage(`{}`)) {
+		t.Error("master_resume_status check should not be audited")
+	}
+	if !mutatingCall("master_resume_status", json.RawMessage(`{"repair":true}`)) {
+		t.Error("master_resume_status repair should be audited")
+	}
+}
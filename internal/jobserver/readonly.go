@@ -0,0 +1,63 @@
+package jobserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// readOnlyBlocked are the tools rejected in read-only mode (GO_JOB_READONLY=1): the
+// mutating calls (see mutatingCall), plus tools that write files on the server.
+func readOnlyBlocked(tool string, args json.RawMessage) bool {
+	switch tool {
+	case "data_export", "job_export", "job_bookmarks":
+		return true
+	}
+	return mutatingCall(tool, args)
+}
+
+// repairRequested reports whether tool arguments set repair=true.
+func repairRequested(args json.RawMessage) bool {
+	var in struct {
+		Repair bool `json:"repair"`
+	}
+	return json.Unmarshal(args, &in) == nil && in.Repair
+}
+
+// ReadOnlyError is the structured content of a tool call rejected in read-only mode.
+type ReadOnlyError struct {
+	Error   string `json:"error"` // always "read_only"
+	Tool    string `json:"tool"`
+	Message string `json:"message"`
+}
+
+// readOnlyMiddleware rejects calls of readOnlyBlocked tools while engine.ConfigFrom(ctx).ReadOnly
+// is set, with a tool error result instead of running them.
+func readOnlyMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || !engine.ConfigFrom(ctx).ReadOnly {
+			return next(ctx, method, req)
+		}
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if !ok || !readOnlyBlocked(params.Name, params.Arguments) {
+			return next(ctx, method, req)
+		}
+		e := readOnlyRejection(params.Name)
+		return &mcp.CallToolResult{
+			IsError:           true,
+			Content:           []mcp.Content{&mcp.TextContent{Text: e.Message}},
+			StructuredContent: e,
+		}, nil
+	}
+}
+
+func readOnlyRejection(tool string) ReadOnlyError {
+	return ReadOnlyError{
+		Error:   "read_only",
+		Tool:    tool,
+		Message: fmt.Sprintf("%s is disabled: this server runs in read-only mode (GO_JOB_READONLY), only search and analysis tools are available", tool),
+	}
+}
@@ -1,36 +1,113 @@
 package jobserver
 
-import "github.com/modelcontextprotocol/go-sdk/mcp"
+import (
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
 
-// RegisterTools registers all work-related search tools on the given MCP server.
-func RegisterTools(server *mcp.Server) {
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RegisterTools registers all work-related search tools on the given MCP server,
+// except those disabled by GO_JOB_DISABLED_TOOLS, and returns the names of the tools
+// registered. deps is bound to the context of every request the server receives;
+// pass nil to register without an engine or stores (e.g. for schema export).
+func RegisterTools(server *mcp.Server, deps *Deps) []string {
+	server.AddReceivingMiddleware(deps.middleware, callMetaMiddleware, readOnlyMiddleware, auditMiddleware)
+
+	c := deps.config()
+	var enabled, disabled []string
+	for _, name := range registerAllTools(server) {
+		if c.ToolDisabled(name) {
+			disabled = append(disabled, name)
+		} else {
+			enabled = append(enabled, name)
+		}
+	}
+	if len(disabled) > 0 {
+		server.RemoveTools(disabled...)
+		slog.Info("tools disabled", slog.Any("tools", disabled))
+	}
+	for _, p := range c.DisabledTools {
+		one := engine.Config{DisabledTools: []string{p}}
+		if !slices.ContainsFunc(disabled, one.ToolDisabled) {
+			slog.Warn("GO_JOB_DISABLED_TOOLS entry matches no tool", slog.String("entry", p))
+		}
+	}
+	return enabled
+}
+
+// toolNames records the tools addTool adds to each server while registerAllTools
+// runs, so RegisterTools knows the catalog without listing it back over a session.
+var (
+	toolNamesMu sync.Mutex
+	toolNames   = make(map[*mcp.Server][]string)
+)
+
+// addTool registers a tool like mcp.AddTool and records its name for server.
+func addTool[In, Out any](server *mcp.Server, t *mcp.Tool, h mcp.ToolHandlerFor[In, Out]) {
+	toolNamesMu.Lock()
+	toolNames[server] = append(toolNames[server], t.Name)
+	toolNamesMu.Unlock()
+	mcp.AddTool(server, t, h)
+}
+
+// registerAllTools registers every tool on server and returns their names, sorted.
+func registerAllTools(server *mcp.Server) []string {
 	// Search
 	registerJobSearch(server)
 	registerRemoteWorkSearch(server)
 	registerFreelanceSearch(server)
 	registerJobMatchScore(server)
+	registerJobsScoreBatch(server)
+	registerJDRedFlags(server)
 	// Research
 	registerSalaryResearch(server)
 	registerCompanyResearch(server)
+	registerCompanyNews(server)
+	registerTakeHomeResearch(server)
 	// Resume
 	registerResumeAnalyze(server)
 	registerCoverLetterGenerate(server)
 	registerResumeTailor(server)
+	registerResumePDFCheck(server)
 	// Tracker
 	registerJobTrackerAdd(server)
 	registerJobTrackerList(server)
 	registerJobTrackerUpdate(server)
+	registerJobTrackerGet(server)
+	registerFollowupEmail(server)
+	registerRejectionRetro(server)
+	registerJobTrackerImport(server)
+	registerGigTrackerUpdate(server)
+	registerGigInvoice(server)
+	registerJobExport(server)
+	registerJobBookmarks(server)
+	registerJobsLocalSearch(server)
+	registerSimilarJobs(server)
+	registerWeeklyReview(server)
+	registerAuditLog(server)
+	registerDataExport(server)
+	registerDataImport(server)
 	// Person research
 	registerPersonResearch(server)
 	// Interview & Career Prep
 	registerInterviewPrep(server)
+	registerMockInterview(server)
+	registerStarStories(server)
+	registerCompetencyCoverage(server)
 	registerProjectShowcase(server)
 	registerPitchGenerate(server)
+	registerProposalGenerate(server)
 	registerSkillGap(server)
+	registerCertRecommend(server)
 	// Application Workflow
 	registerApplicationPrep(server)
 	registerOfferCompare(server)
 	registerNegotiationPrep(server)
+	registerIncomeCompare(server)
 	// Bounties
 	registerBountySearch(server)
 	registerBountyAttempt(server)
@@ -51,13 +128,29 @@ func RegisterTools(server *mcp.Server) {
 	registerLinkedInPosts(server)
 	registerLinkedInRating(server)
 	registerLinkedInProfileIngest(server)
+	registerLinkedInProfileOptimize(server)
 	// Master Resume
 	registerMasterResumeBuild(server)
+	registerMasterResumeStatus(server)
+	registerMasterResumeRollback(server)
+	registerExperienceYears(server)
+	registerJobStatus(server)
 	registerResumeGenerate(server)
+	registerResumeVariants(server)
+	registerResumeABReport(server)
 	registerResumeEnrich(server)
+	registerHHResumeSync(server)
 	// Resume Profile & Memory
 	registerResumeProfile(server)
 	registerResumeMemorySearch(server)
 	registerResumeMemoryAdd(server)
 	registerResumeMemoryUpdate(server)
+	registerVectorsResync(server)
+
+	toolNamesMu.Lock()
+	names := toolNames[server]
+	delete(toolNames, server)
+	toolNamesMu.Unlock()
+	sort.Strings(names)
+	return names
 }
@@ -0,0 +1,70 @@
+package jobserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+)
+
+// RegisterRoutes registers the non-MCP HTTP endpoints on the server mux. Each
+// handler runs with deps bound to the request context.
+func RegisterRoutes(mux *http.ServeMux, deps *Deps) {
+	c := deps.config()
+	mux.HandleFunc("GET /schemas", deps.handler(serveSchemas))
+	if c.ResumeSiteToken != "" {
+		mux.HandleFunc("GET /resume", deps.handler(requireToken(c.ResumeSiteToken, serveResumeHTML)))
+		mux.HandleFunc("GET /resume.json", deps.handler(requireToken(c.ResumeSiteToken, serveResumeJSON)))
+	}
+	if c.APIToken != "" {
+		registerAPIRoutes(mux, deps, c.APIToken)
+	}
+}
+
+// requireToken wraps h with a bearer/query token check.
+// Accepts "Authorization: Bearer <token>" or "?token=<token>".
+func requireToken(token string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if got == "" {
+			got = r.URL.Query().Get("token")
+		}
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h(w, r)
+	}
+}
+
+func serveResumeHTML(w http.ResponseWriter, r *http.Request) {
+	version, _, page, err := jobs.RenderedResumeSite(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	writeVersioned(w, r, version, "text/html; charset=utf-8", page)
+}
+
+func serveResumeJSON(w http.ResponseWriter, r *http.Request) {
+	version, feed, _, err := jobs.RenderedResumeSite(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	writeVersioned(w, r, version, "application/json", feed)
+}
+
+// writeVersioned writes body with an ETag and honours If-None-Match.
+func writeVersioned(w http.ResponseWriter, r *http.Request, version, contentType string, body []byte) {
+	etag := `"` + version + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(body)
+}
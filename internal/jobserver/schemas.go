@@ -0,0 +1,81 @@
+package jobserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ToolContract is the machine-readable contract of a single tool.
+type ToolContract struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	InputSchema  any    `json:"input_schema"`
+	OutputSchema any    `json:"output_schema"`
+}
+
+var (
+	contractsOnce sync.Once
+	contractsJSON []byte
+	contractsErr  error
+)
+
+// ToolContracts lists every registered tool with its input and output JSON Schema.
+// Output schemas are derived by the MCP SDK from each handler's result type, and the
+// SDK validates every structured result against them before it is returned.
+func ToolContracts(ctx context.Context) ([]ToolContract, error) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "go_job-schemas"}, nil)
+	RegisterTools(server, &Deps{Engine: engine.From(ctx)})
+
+	serverT, clientT := mcp.NewInMemoryTransports()
+	ss, err := server.Connect(ctx, serverT, nil)
+	if err != nil {
+		return nil, fmt.Errorf("schemas: connect server: %w", err)
+	}
+	defer ss.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "go_job-schemas-client"}, nil)
+	cs, err := client.Connect(ctx, clientT, nil)
+	if err != nil {
+		return nil, fmt.Errorf("schemas: connect client: %w", err)
+	}
+	defer cs.Close()
+
+	var contracts []ToolContract
+	for tool, err := range cs.Tools(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("schemas: list tools: %w", err)
+		}
+		contracts = append(contracts, ToolContract{
+			Name:         tool.Name,
+			Description:  tool.Description,
+			InputSchema:  tool.InputSchema,
+			OutputSchema: tool.OutputSchema,
+		})
+	}
+	sort.Slice(contracts, func(i, j int) bool { return contracts[i].Name < contracts[j].Name })
+	return contracts, nil
+}
+
+// serveSchemas publishes all tool contracts as JSON. The result is computed once per process.
+func serveSchemas(w http.ResponseWriter, r *http.Request) {
+	contractsOnce.Do(func() {
+		var contracts []ToolContract
+		contracts, contractsErr = ToolContracts(context.WithoutCancel(r.Context()))
+		if contractsErr == nil {
+			contractsJSON, contractsErr = json.MarshalIndent(contracts, "", "  ")
+		}
+	})
+	if contractsErr != nil {
+		http.Error(w, contractsErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(contractsJSON)
+}
@@ -0,0 +1,64 @@
+package jobserver
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestToolContracts_EveryToolHasObjectOutputSchema(t *testing.T) {
+	contracts, err := ToolContracts(context.Background())
+	if err != nil {
+		t.Fatalf("ToolContracts: %v", err)
+	}
+	if len(contracts) == 0 {
+		t.Fatal("no tools registered")
+	}
+	for _, c := range contracts {
+		if c.OutputSchema == nil {
+			t.Errorf("%s: missing output schema", c.Name)
+			continue
+		}
+		raw, err := json.Marshal(c.OutputSchema)
+		if err != nil {
+			t.Fatalf("%s: marshal schema: %v", c.Name, err)
+		}
+		var schema struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(raw, &schema); err != nil || schema.Type != "object" {
+			t.Errorf("%s: output schema type = %q, want object", c.Name, schema.Type)
+		}
+	}
+}
+
+func TestRegisterToolsDisabled(t *testing.T) {
+	all := RegisterTools(mcp.NewServer(&mcp.Implementation{Name: "all"}, nil), nil)
+	if len(all) < 50 {
+		t.Fatalf("catalog has %d tools", len(all))
+	}
+
+	e := engine.New(engine.Config{DisabledTools: []string{"twitter_job_search", "resume_*"}})
+	enabled := RegisterTools(mcp.NewServer(&mcp.Implementation{Name: "test"}, nil), &Deps{Engine: e})
+
+	for _, name := range enabled {
+		if name == "twitter_job_search" || strings.HasPrefix(name, "resume_") {
+			t.Errorf("%s should be disabled", name)
+		}
+	}
+	if !slices.Contains(enabled, "job_search") || !slices.Contains(enabled, "master_resume_build") {
+		t.Error("tools not matching a pattern should stay registered")
+	}
+	contracts, err := ToolContracts(engine.WithEngine(context.Background(), e))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contracts) != len(enabled) {
+		t.Errorf("schemas list %d tools, want the %d enabled", len(contracts), len(enabled))
+	}
+}
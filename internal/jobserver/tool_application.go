@@ -10,7 +10,7 @@ import (
 )
 
 func registerApplicationPrep(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "application_prep",
 		Description: "Generate a complete application package in one call: ATS resume analysis, tailored cover letter, interview prep questions with model answers, and optional company research. Combines resume_analyze + cover_letter_generate + interview_prep into a single workflow.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
@@ -0,0 +1,23 @@
+package jobserver
+
+import (
+	"context"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerAuditLog(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "audit_log",
+		Description: "List recorded data-mutating calls, newest first: tracker changes (job_tracker_add/update/import, gig_tracker_update, gig_invoice), resume rebuilds (master_resume_build, which replaces the previous master resume), enrichment and memory updates, vector resyncs and profile publishing (hh_resume_sync, linkedin_profile_ingest). Each entry has the UTC time, tool name, SHA-256 digest of the input (inputs themselves are not stored) and the error if the call failed. Filter by tool and since date.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.AuditLogInput) (*mcp.CallToolResult, *jobs.AuditLogResult, error) {
+		result, err := jobs.ListAuditLog(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
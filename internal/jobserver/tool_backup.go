@@ -0,0 +1,34 @@
+package jobserver
+
+import (
+	"context"
+
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerDataExport(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "data_export",
+		Description: "Back up all user data into one zip archive: the master resume tables (Postgres, IDs kept), the resume knowledge graph, the job tracker (applications, events, gigs, companies, resume variants, ...), the search profile and the last job_search results. Writes ~/.go_job/backups/<timestamp>_go_job_backup.zip (or path, a file name in that directory); include_data=true also returns it as base64 to move it to another deployment. Run before master_resume_build to be able to undo the rebuild with data_import.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.DataExportInput) (*mcp.CallToolResult, *jobs.DataExportResult, error) {
+		result, err := jobs.DataExport(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerDataImport(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "data_import",
+		Description: "Restore a data_export archive (path, which must be in ~/.go_job/backups, or base64 data), replacing the current data of each restored section: resume, graph, tracker, profile, last_search (default all in the archive). The replaced data is first backed up to a safety archive, returned as safety_backup, so the import can be undone. Resume vectors are rebuilt with vectors_resync when MemDB is configured. Use dry_run=true to see what the archive holds.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.DataImportInput) (*mcp.CallToolResult, *jobs.DataImportResult, error) {
+		result, err := jobs.DataImport(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
@@ -13,7 +13,7 @@ import (
 )
 
 func registerBountySearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "bounty_search",
 		Description: "Search for open-source bounties on Algora.io, Opire.dev, BountyHub.dev, Boss.dev, Lightning Bounties, and Collaborators.build. Returns paid GitHub issues with bounty amounts. Filter by technology, keyword, minimum amount, or required skills.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
@@ -86,7 +86,7 @@ func registerBountySearch(server *mcp.Server) {
 		}
 
 		// Try embedding pipeline (query only — bounty vectors are precomputed).
-		embedClient := jobs.GetEmbedClient()
+		embedClient := jobs.StoresFrom(ctx).Embed
 		hasVectors := len(bvecs) > 0 && len(bvecs[0].Vector) > 0
 		if embedClient != nil && hasVectors {
 			result, err := bountyEmbedPipeline(ctx, embedClient, input.Query, bvecs)
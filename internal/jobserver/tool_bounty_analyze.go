@@ -11,7 +11,7 @@ import (
 )
 
 func registerBountyAnalyze(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "bounty_analyze",
 		Description: "Analyze a bounty's complexity, estimate hours, $/hr rate, and whether it's worth taking. Fetches the GitHub issue body and uses AI to assess effort vs reward.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
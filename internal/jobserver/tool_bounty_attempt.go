@@ -12,7 +12,7 @@ import (
 )
 
 func registerBountyAttempt(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "bounty_attempt",
 		Description: "Claim a bounty by commenting /attempt on a GitHub issue. This signals to the maintainer that you are starting work on the bounty.",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, input engine.BountyAttemptInput) (*mcp.CallToolResult, engine.SmartSearchOutput, error) {
@@ -11,38 +11,38 @@ import (
 )
 
 // bountyDefaults returns config values with defaults applied.
-func bountyHighConfidence() float32 {
-	if v := engine.Cfg.BountyHighConfidence; v > 0 {
+func bountyHighConfidence(c *engine.Config) float32 {
+	if v := c.BountyHighConfidence; v > 0 {
 		return v
 	}
 	return 0.82
 }
-func bountyHighConfGap() float32 {
-	if v := engine.Cfg.BountyHighConfGap; v > 0 {
+func bountyHighConfGap(c *engine.Config) float32 {
+	if v := c.BountyHighConfGap; v > 0 {
 		return v
 	}
 	return 0.04
 }
-func bountyHighConfMax() int {
-	if v := engine.Cfg.BountyHighConfMax; v > 0 {
+func bountyHighConfMax(c *engine.Config) int {
+	if v := c.BountyHighConfMax; v > 0 {
 		return v
 	}
 	return 10
 }
-func bountyMedConfMax() int {
-	if v := engine.Cfg.BountyMedConfMax; v > 0 {
+func bountyMedConfMax(c *engine.Config) int {
+	if v := c.BountyMedConfMax; v > 0 {
 		return v
 	}
 	return 3
 }
-func bountySkillBoost() float32 {
-	if v := engine.Cfg.BountySkillBoost; v > 0 {
+func bountySkillBoost(c *engine.Config) float32 {
+	if v := c.BountySkillBoost; v > 0 {
 		return v
 	}
 	return 0.05
 }
-func bountyMinRelevance() float32 {
-	if v := engine.Cfg.BountyMinRelevance; v > 0 {
+func bountyMinRelevance(c *engine.Config) float32 {
+	if v := c.BountyMinRelevance; v > 0 {
 		return v
 	}
 	return 0.75
@@ -52,6 +52,7 @@ const minAbsThreshold float32 = 0.3
 
 // bountyEmbedPipeline uses precomputed bounty vectors + query embedding for matching.
 func bountyEmbedPipeline(ctx context.Context, client *jobs.EmbedClient, query string, bvecs []jobs.BountyWithVector) (engine.BountySearchOutput, error) {
+	c := engine.ConfigFrom(ctx)
 	// Extract bounties.
 	bounties := make([]engine.BountyListing, len(bvecs))
 	for i, bv := range bvecs {
@@ -95,7 +96,7 @@ func bountyEmbedPipeline(ctx context.Context, client *jobs.EmbedClient, query st
 		}
 
 		b := bounties[i]
-		boost := skillsMatchBoost(querySkills, queryWords, b.Skills, b.Title)
+		boost := skillsMatchBoost(c, querySkills, queryWords, b.Skills, b.Title)
 		finalScore := sim + boost
 		b.Relevance = finalScore
 		results = append(results, scored{bounty: b, score: finalScore})
@@ -112,11 +113,11 @@ func bountyEmbedPipeline(ctx context.Context, client *jobs.EmbedClient, query st
 		var maxResults int
 		var cutoff float32
 
-		if bestScore >= bountyHighConfidence() {
-			maxResults = bountyHighConfMax()
-			cutoff = bestScore - bountyHighConfGap()
+		if bestScore >= bountyHighConfidence(c) {
+			maxResults = bountyHighConfMax(c)
+			cutoff = bestScore - bountyHighConfGap(c)
 		} else {
-			maxResults = bountyMedConfMax()
+			maxResults = bountyMedConfMax(c)
 			cutoff = minAbsThreshold
 		}
 
@@ -131,7 +132,7 @@ func bountyEmbedPipeline(ctx context.Context, client *jobs.EmbedClient, query st
 	}
 
 	// If best score is below minimum relevance, return empty with guidance.
-	if len(results) > 0 && results[0].score < bountyMinRelevance() {
+	if len(results) > 0 && results[0].score < bountyMinRelevance(c) {
 		return engine.BountySearchOutput{
 			Query:   query,
 			Summary: fmt.Sprintf("No bounties closely matching %q found. Try broader keywords.", query),
@@ -150,7 +151,7 @@ func bountyEmbedPipeline(ctx context.Context, client *jobs.EmbedClient, query st
 }
 
 // skillsMatchBoost returns a boost score if query keywords match the bounty's skills or title.
-func skillsMatchBoost(querySkills, queryWords []string, bountySkills []string, bountyTitle string) float32 {
+func skillsMatchBoost(c *engine.Config, querySkills, queryWords []string, bountySkills []string, bountyTitle string) float32 {
 	if len(querySkills) == 0 && len(queryWords) == 0 {
 		return 0
 	}
@@ -163,13 +164,13 @@ func skillsMatchBoost(querySkills, queryWords []string, bountySkills []string, b
 
 	for _, qs := range querySkills {
 		if skillSet[strings.ToLower(qs)] {
-			return bountySkillBoost()
+			return bountySkillBoost(c)
 		}
 	}
 
 	for _, w := range queryWords {
 		if len(w) >= 3 && strings.Contains(titleLower, w) {
-			return bountySkillBoost()
+			return bountySkillBoost(c)
 		}
 	}
 
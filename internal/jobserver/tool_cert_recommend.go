@@ -0,0 +1,27 @@
+package jobserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerCertRecommend(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "cert_recommend",
+		Description: "Recommend reputable certifications and courses (vendor certs, university MOOCs) for your top skill gaps. Pass skills directly, or a job_description to run skill_gap against your resume (default: master resume). Returns cost, duration and level per option, and which target jobs (the JD and the last job_search) list each skill.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.CertRecommendInput) (*mcp.CallToolResult, *jobs.CertRecommendResult, error) {
+		if len(input.Skills) == 0 && input.JobDescription == "" {
+			return nil, nil, errors.New("skills or job_description is required")
+		}
+		result, err := jobs.RecommendCertifications(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
@@ -0,0 +1,32 @@
+package jobserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerExperienceYears(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "experience_years",
+		Description: "Total and per-skill years of experience computed from the master resume: experience date ranges, with skills counted over the roles (and their sub-projects) they were used in. Overlapping roles count once; figures are rounded to half years, e.g. \"Go: 6.5 years\". Also lists gaps of 3+ months between roles and experiences whose dates could not be read. The same figures feed interview_prep answers, resume_analyze years requirements and job_match_score. Requires master_resume_build.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ExperienceYearsInput) (*mcp.CallToolResult, *jobs.ExperienceYears, error) {
+		result, err := jobs.MasterExperienceYears(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if input.Skill != "" {
+			s, ok := result.Skill(input.Skill)
+			if !ok {
+				return nil, nil, fmt.Errorf("experience_years: no dated experience with %q", input.Skill)
+			}
+			result.Skills = []jobs.SkillYears{s}
+			result.Summary = s.String() + "."
+		}
+		return nil, result, nil
+	})
+}
@@ -9,21 +9,22 @@ import (
 	"strings"
 
 	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
 	"github.com/anatolykoptev/go_job/internal/engine/sources"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 func registerFreelanceSearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "freelance_search",
-		Description: "Search for freelance projects and gigs on Upwork and Freelancer.com. Returns structured JSON with project details (title, budget, skills, platform, URL). Freelancer.com uses direct API for rich data (budgets, bids, skills). Filter by platform.",
+		Description: "Search for freelance projects and gigs on Upwork and Freelancer.com. Returns structured JSON with project details (title, budget, skills, platform, URL). Freelancer.com uses direct API for rich data (budgets, bids, skills). Filter by platform. Track a project with job_tracker_add kind=gig. output_version=2 adds a scores block with scam_risk and scam_signals.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, req *mcp.CallToolRequest, input engine.FreelanceSearchInput) (*mcp.CallToolResult, engine.FreelanceSearchOutput, error) {
 		if input.Query == "" {
 			return nil, engine.FreelanceSearchOutput{}, errors.New("query is required")
 		}
 
-		cacheKey := engine.CacheKey("freelance_search", input.Query, input.Platform, input.Language)
+		cacheKey := engine.CacheKey("freelance_search", input.Query, input.Platform, input.Language, fmt.Sprintf("v%d", input.OutputVersion))
 		if out, ok := engine.CacheLoadJSON[engine.FreelanceSearchOutput](ctx, cacheKey); ok {
 			return nil, out, nil
 		}
@@ -31,8 +32,9 @@ func registerFreelanceSearch(server *mcp.Server) {
 		platform := strings.ToLower(input.Platform)
 		lang := engine.NormLang(input.Language)
 
-		useUpwork := platform == "" || platform == "all" || platform == "upwork"
-		useFreelancer := platform == "" || platform == "all" || platform == "freelancer"
+		disabled := engine.ConfigFrom(ctx).SourceDisabled
+		useUpwork := (platform == "" || platform == "all" || platform == "upwork") && !disabled("upwork")
+		useFreelancer := (platform == "" || platform == "all" || platform == "freelancer") && !disabled(platFreelancer)
 
 		var freelancerAPIResults []engine.SearxngResult
 		freelancerAPISuccess := false
@@ -95,14 +97,7 @@ func registerFreelanceSearch(server *mcp.Server) {
 			return nil, engine.FreelanceSearchOutput{Query: input.Query, Summary: "No results found."}, nil
 		}
 
-		seen := make(map[string]bool)
-		var deduped []engine.SearxngResult
-		for _, r := range merged {
-			if !seen[r.URL] {
-				seen[r.URL] = true
-				deduped = append(deduped, r)
-			}
-		}
+		deduped := engine.DedupFreelanceResults(merged)
 
 		var filtered []engine.SearxngResult
 		for _, r := range deduped {
@@ -154,6 +149,9 @@ func registerFreelanceSearch(server *mcp.Server) {
 			}
 		}
 
+		freelanceOut.Projects = engine.DedupFreelanceProjects(freelanceOut.Projects)
+		jobs.ApplyFreelanceOutputVersion(freelanceOut.Projects, input.OutputVersion)
+
 		engine.CacheStoreJSON(ctx, cacheKey, input.Query, *freelanceOut)
 		return nil, *freelanceOut, nil
 	})
@@ -0,0 +1,22 @@
+package jobserver
+
+import (
+	"context"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerHHResumeSync(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "hh_resume_sync",
+		Description: "Publish the master resume to hh.ru (HeadHunter) for the Russian market. Maps the resume to hh.ru's schema and creates a new resume, or updates resume_id, using the HH_ACCESS_TOKEN OAuth token. Reports field-mapping gaps (language, citizenship, unparseable dates, unsupported links) to fix on hh.ru. Use dry_run=true to preview the payload.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.HHResumeSyncInput) (*mcp.CallToolResult, *jobs.HHResumeSyncResult, error) {
+		result, err := jobs.SyncHHResume(ctx, input.ResumeID, input.Title, input.SalaryRUB, input.DryRun)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
@@ -0,0 +1,23 @@
+package jobserver
+
+import (
+	"context"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerIncomeCompare(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "income_compare",
+		Description: "Convert a freelance/contract rate to the equivalent salary, or a salary to the equivalent contract rate, accounting for benefits, taxes (configurable rates), unpaid weeks, contractor costs and currency. Use it to compare freelance_search rates with job_search salaries.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, input engine.IncomeCompareInput) (*mcp.CallToolResult, *jobs.IncomeCompareResult, error) {
+		result, err := jobs.CompareIncome(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
@@ -10,9 +10,9 @@ import (
 )
 
 func registerInterviewPrep(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "interview_prep",
-		Description: "Generate personalized interview questions with model answers based on your resume and the job description. Optionally enriches with company research for company-specific questions. Returns behavioral, technical, and system design Q&A with answers grounded in your actual projects.",
+		Description: "Generate personalized interview questions with model answers based on your resume and the job description. Reuses your stored STAR stories (star_stories) in behavioral answers. Optionally enriches with company research, recent company news and interview experiences candidates reported on Glassdoor, Blind and levels.fyi (process and asked questions, returned as reported_experience with source links). Returns behavioral, technical, and system design Q&A with answers grounded in your actual projects.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.InterviewPrepInput) (*mcp.CallToolResult, *jobs.InterviewPrepResult, error) {
 		if input.Resume == "" {
@@ -28,3 +28,43 @@ func registerInterviewPrep(server *mcp.Server) {
 		return nil, result, nil
 	})
 }
+
+func registerMockInterview(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "mock_interview",
+		Description: "Run a mock interview one question at a time. Start with job_description (and optionally company, focus, questions) to get a session_id and the first question; then call again with session_id and your answer to get feedback (1-10 score, whether the answer drew on your real experience, improvements) and the next question. After the last question, or with end=true, returns a scored debrief (overall and per-category 0-100, strengths, focus areas, verdict). Answers are evaluated against your resume (default: master resume).",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.MockInterviewInput) (*mcp.CallToolResult, *jobs.MockInterviewResult, error) {
+		result, err := jobs.MockInterview(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerStarStories(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "star_stories",
+		Description: "Build and return your STAR story bank: 10-15 reusable Situation-Task-Action-Result stories generated from the master resume's achievements and the experiences that produced them, tagged by competency (leadership, conflict, scale, failure, ownership, collaboration, influence, ambiguity, customer_focus, innovation, mentoring, delivery). Stories are stored and reused by interview_prep; the first call generates them, refresh=true regenerates. Returns per-competency coverage counts.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.StarStoriesInput) (*mcp.CallToolResult, *jobs.StarStoriesResult, error) {
+		result, err := jobs.StarStories(ctx, input.Refresh, input.Competency)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerCompetencyCoverage(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "competency_coverage",
+		Description: "Map your STAR story bank (star_stories) against a behavioral competency framework: the standard one (leadership, conflict, scale, failure, ...) or, with company or values_url, the values and leadership principles on the company's values page. Returns a matrix of competencies with the supporting stories and strength (none/thin/good), the gaps with no supporting story, and enrichment questions to recall experience for each gap.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.CompetencyCoverageInput) (*mcp.CallToolResult, *jobs.CompetencyCoverageResult, error) {
+		result, err := jobs.AnalyzeCompetencyCoverage(ctx, input.Company, input.ValuesURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
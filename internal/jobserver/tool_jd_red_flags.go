@@ -0,0 +1,36 @@
+package jobserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerJDRedFlags(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "jd_red_flags",
+		Description: "Analyze a job description (text or URL) for warning signs: unpaid trial work, many hats on low pay, crunch culture, vague equity. With company set, also flags the employer's layoffs and lawsuits in the news from the last 12 months. Returns a 0-100 risk score with verbatim evidence quotes.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.JDRedFlagsInput) (*mcp.CallToolResult, *jobs.JDRedFlagsResult, error) {
+		jd := input.JobDescription
+		if jd == "" && input.URL != "" {
+			text, err := jobs.FetchJobPosting(ctx, input.URL)
+			if err != nil {
+				return nil, nil, fmt.Errorf("fetch job description: %w", err)
+			}
+			jd = text
+		}
+		if jd == "" {
+			return nil, nil, errors.New("job_description or url is required")
+		}
+		result, err := jobs.AnalyzeJDRedFlags(ctx, jd, input.Company)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
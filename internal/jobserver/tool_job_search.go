@@ -1,12 +1,15 @@
 package jobserver
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/anatolykoptev/go_job/internal/engine"
 	"github.com/anatolykoptev/go_job/internal/engine/jobs"
@@ -24,26 +27,62 @@ const (
 	platStartup    = "startup"
 	platGoogle     = "google"
 	platCraigslist = "craigslist"
-	platRemoteOK    = "remoteok"
-	platWWR         = "weworkremotely"
-	platFreelancer  = "freelancer"
-	platRemotive    = "remotive"
-	platRemote      = "remote"
+	platCompanyATS = "company_ats"
+	platRemoteOK   = "remoteok"
+	platWWR        = "weworkremotely"
+	platFreelancer = "freelancer"
+	platRemotive   = "remotive"
+	platJobicy     = "jobicy"
+	platHimalayas  = "himalayas"
+	platJustRemote = "justremote"
+	platRemote     = "remote"
+	platImpact     = "impact"
+	platIdealist   = "idealist"
+	plat80kHours   = "80000hours"
+	platReliefWeb  = "reliefweb"
+	platUSAJobs    = "usajobs"
+	platAcademic   = "academic"
+	platEuraxess   = "euraxess"
+	platHigherEd   = "higheredjobs"
+	platExecutive  = "executive"
+)
+
+// Per-source bounds applied before merge, so platform=all holds at most
+// jobSourceMaxResults trimmed results per source instead of every raw listing.
+const (
+	jobSourceMaxResults = 50
+	jobContentLimit     = 5000 // summarizer token budget per source; longer content is cut anyway
 )
 
 //nolint:funlen // multi-platform aggregation
 func registerJobSearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "job_search",
-		Description: "Search for job listings on LinkedIn, Greenhouse, Lever, YC workatastartup.com, HN Who is Hiring, Craigslist, RemoteOK, WeWorkRemotely, Remotive, and Freelancer. Returns structured JSON with job details (title, company, location, salary, skills, URL). Supports filters for experience level, job type, remote/onsite, time range, and platform.",
+		Description: "Search for job listings on LinkedIn, Greenhouse, Lever, YC workatastartup.com, HN Who is Hiring, Craigslist, RemoteOK, WeWorkRemotely, Remotive, Jobicy, Himalayas, JustRemote, Freelancer, USAJobs (US federal jobs, with USAJOBS_API_KEY), the impact-sector boards Idealist, 80,000 Hours and ReliefWeb (platform=impact), and the academic boards EURAXESS and HigherEdJobs (platform=academic; listings carry institution, tenure_track and deadline). Returns structured JSON with job details (title, company, location, salary, skills, URL). Supports filters for experience level, job type, remote/onsite, time range, and platform. Listings requiring a citizenship the master resume does not hold are dropped unless keep_ineligible=true; listings below the profile salary_floor are dropped unless keep_below_floor=true. With a profile timezone, listings naming team time zones or core hours get eligibility.overlap_hours (filter with min_overlap_hours). eligible_from (e.g. Germany, EU) drops remote listings restricted to other countries or regions. internships=true searches internships only and checks the master resume for education and projects. executive=true searches director+ roles on LinkedIn and the executive boards ExecThread, BlueSteps and The Ladders (platform=executive). With output_version=2, listings at companies already researched carry company_info, including employer_risk from layoffs and hiring freezes.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, req *mcp.CallToolRequest, input engine.JobSearchInput) (*mcp.CallToolResult, engine.JobSearchOutput, error) {
 		if input.Query == "" {
 			return nil, engine.JobSearchOutput{}, errors.New("query is required")
 		}
+		if _, err := jobs.ParseCompanySize(input.CompanySize); err != nil {
+			return nil, engine.JobSearchOutput{}, err
+		}
+		if _, err := jobs.ParseRequiredBenefits(input.RequireBenefits); err != nil {
+			return nil, engine.JobSearchOutput{}, err
+		}
+		if _, err := jobs.ParseWorkStyles(input.WorkStyle); err != nil {
+			return nil, engine.JobSearchOutput{}, err
+		}
+		if input.Internships {
+			jobs.ApplyInternshipMode(&input)
+		}
+		if input.Executive {
+			jobs.ApplyExecutiveMode(&input)
+		}
 
-		cacheKey := engine.CacheKey("job_search", input.Query, input.Location, input.Experience, input.JobType, input.Remote, input.TimeRange, input.Platform, fmt.Sprintf("limit_%d_offset_%d", input.Limit, input.Offset))
+		cacheKey := engine.CacheKey("job_search", input.Query, input.Location, input.Experience, input.JobType, input.Remote, input.TimeRange, input.Platform, input.Company, input.Industry, fmt.Sprintf("limit_%d_offset_%d_internships_%t_executive_%t", input.Limit, input.Offset, input.Internships, input.Executive))
 		if out, ok := engine.CacheLoadJSON[engine.JobSearchOutput](ctx, cacheKey); ok {
+			finishJobSearch(ctx, input, &out)
 			return nil, out, nil
 		}
 
@@ -66,6 +105,11 @@ func registerJobSearch(server *mcp.Server) {
 		}
 
 		lang := engine.NormLang(input.Language)
+		// Free-text sources have no internship filter; LinkedIn uses f_E/f_JT instead.
+		textQuery := input.Query
+		if input.Internships {
+			textQuery = jobs.InternshipQuery(textQuery)
+		}
 
 		platform := strings.ToLower(strings.TrimSpace(input.Platform))
 		if platform == "" {
@@ -80,7 +124,7 @@ func registerJobSearch(server *mcp.Server) {
 			limit = 50
 		}
 
-		useLinkedIn := platform == platAll || platform == platLinkedIn
+		useLinkedIn := platform == platAll || platform == platLinkedIn || platform == platExecutive
 		useGreenhouse := platform == platAll || platform == platGreenhouse || platform == platATS || platform == platStartup
 		useLever := platform == platAll || platform == platLever || platform == platATS || platform == platStartup
 		useYC := platform == platAll || platform == "yc" || platform == platStartup
@@ -92,14 +136,38 @@ func registerJobSearch(server *mcp.Server) {
 		useRemoteOK := platform == platAll || platform == platRemoteOK || platform == platRemote
 		useWWR := platform == platAll || platform == platWWR || platform == platRemote
 		useRemotive := platform == platAll || platform == platRemotive || platform == platRemote
+		useJobicy := platform == platAll || platform == platJobicy || platform == platRemote
+		useHimalayas := platform == platAll || platform == platHimalayas || platform == platRemote
+		useJustRemote := platform == platAll || platform == platJustRemote || platform == platRemote
 		useFreelancer := platform == platAll || platform == platFreelancer
 		useGoogle := platform == platAll || platform == platGoogle
+		useIdealist := platform == platIdealist || platform == platImpact
+		use80kHours := platform == plat80kHours || platform == platImpact
+		useReliefWeb := platform == platReliefWeb || platform == platImpact
+		useUSAJobs := platform == platUSAJobs || (platform == platAll && engine.ConfigFrom(ctx).USAJobsAPIKey != "")
+		useEuraxess := platform == platEuraxess || platform == platAcademic
+		useHigherEd := platform == platHigherEd || platform == platAcademic
+		useExecutive := platform == platExecutive
+
+		// A company filter narrows the search to LinkedIn (f_C) and the company's own ATS board.
+		useCompanyATS := false
+		if input.Company != "" {
+			useCompanyATS = useGreenhouse || useLever
+			useGreenhouse, useLever, useYC, useHN, useIndeed, useHabr, useTwitter = false, false, false, false, false, false, false
+			useCraigslist, useRemoteOK, useWWR, useRemotive, useFreelancer, useGoogle = false, false, false, false, false, false
+			useJobicy, useHimalayas, useJustRemote = false, false, false
+			useIdealist, use80kHours, useReliefWeb, useUSAJobs = false, false, false, false
+			useEuraxess, useHigherEd, useExecutive = false, false, false
+		}
 
 		type sourceResult struct {
-			name    string
-			results []engine.SearxngResult
-			liJobs  []jobs.LinkedInJob
-			err     error
+			name     string
+			results  []engine.SearxngResult
+			liJobs   []jobs.LinkedInJob
+			habr     []engine.JobListing
+			usajobs  []engine.JobListing
+			academic []engine.JobListing
+			err      error
 		}
 
 		var srcs []string
@@ -139,111 +207,233 @@ func registerJobSearch(server *mcp.Server) {
 		if useRemotive {
 			srcs = append(srcs, platRemotive)
 		}
+		if useJobicy {
+			srcs = append(srcs, platJobicy)
+		}
+		if useHimalayas {
+			srcs = append(srcs, platHimalayas)
+		}
+		if useJustRemote {
+			srcs = append(srcs, platJustRemote)
+		}
 		if useFreelancer {
 			srcs = append(srcs, platFreelancer)
 		}
 		if useGoogle {
 			srcs = append(srcs, platGoogle)
 		}
+		if useIdealist {
+			srcs = append(srcs, platIdealist)
+		}
+		if use80kHours {
+			srcs = append(srcs, plat80kHours)
+		}
+		if useReliefWeb {
+			srcs = append(srcs, platReliefWeb)
+		}
+		if useUSAJobs {
+			srcs = append(srcs, platUSAJobs)
+		}
+		if useEuraxess {
+			srcs = append(srcs, platEuraxess)
+		}
+		if useHigherEd {
+			srcs = append(srcs, platHigherEd)
+		}
+		if useExecutive {
+			srcs = append(srcs, platExecutive)
+		}
+		if useCompanyATS {
+			srcs = append(srcs, platCompanyATS)
+		}
+		srcs = slices.DeleteFunc(srcs, engine.ConfigFrom(ctx).SourceDisabled)
 
 		ch := make(chan sourceResult, len(srcs)+1)
+		// send trims a source's results before they reach the merge.
+		send := func(r sourceResult) {
+			r.results = engine.TrimSourceResults(r.results, jobSourceMaxResults, jobContentLimit)
+			ch <- r
+		}
 
+		endSources := engine.TrackPhase(ctx, engine.PhaseSources)
 		for _, src := range srcs {
+			engine.RecordSource(ctx, src)
 			go func(name string) {
+				release, err := engine.AcquireSourceSlot(ctx)
+				if err != nil {
+					send(sourceResult{name: name, err: err})
+					return
+				}
+				defer release()
 				switch name {
 				case platLinkedIn:
-					liJobs, err := jobs.SearchLinkedInJobs(ctx, input.Query, input.Location, input.Experience, input.JobType, input.Remote, input.TimeRange, input.Salary, 50, input.EasyApply)
+					liJobs, err := jobs.SearchLinkedInJobs(ctx, input.Query, input.Location, input.Experience, input.JobType, input.Remote, input.TimeRange, input.Salary, input.Company, input.Industry, 50, input.EasyApply)
 					if err != nil {
 						slog.Warn("job_search: linkedin error", slog.Any("error", err))
-						ch <- sourceResult{name: name, err: err}
+						send(sourceResult{name: name, err: err})
 						return
 					}
 					slog.Info("job_search: linkedin returned jobs", slog.Int("count", len(liJobs)))
-					ch <- sourceResult{name: name, results: jobs.LinkedInJobsToSearxngResults(ctx, liJobs, 8), liJobs: liJobs}
+					send(sourceResult{name: name, results: jobs.LinkedInJobsToSearxngResults(ctx, liJobs, 8), liJobs: liJobs})
 
 				case "greenhouse":
 					results, err := jobs.SearchGreenhouseJobs(ctx, input.Query, input.Location, 10)
 					if err != nil {
 						slog.Warn("job_search: greenhouse error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: results, err: err}
+					send(sourceResult{name: name, results: results, err: err})
 
 				case "lever":
 					results, err := jobs.SearchLeverJobs(ctx, input.Query, input.Location, 10)
 					if err != nil {
 						slog.Warn("job_search: lever error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: results, err: err}
+					send(sourceResult{name: name, results: results, err: err})
 
 				case "yc":
 					results, err := jobs.SearchYCJobs(ctx, input.Query, input.Location, 10)
 					if err != nil {
 						slog.Warn("job_search: yc error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: results, err: err}
+					send(sourceResult{name: name, results: results, err: err})
 
 				case "hn":
 					results, err := jobs.SearchHNJobs(ctx, input.Query, 20)
 					if err != nil {
 						slog.Warn("job_search: hn error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: results, err: err}
+					send(sourceResult{name: name, results: results, err: err})
 
 				case "indeed":
-					results, err := jobs.SearchIndeedJobsFiltered(ctx, input.Query, input.Location, input.JobType, input.TimeRange, 15)
+					results, err := jobs.SearchIndeedJobsFiltered(ctx, textQuery, input.Location, input.JobType, input.TimeRange, max(limit, 15))
 					if err != nil {
 						slog.Warn("job_search: indeed error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: results, err: err}
+					send(sourceResult{name: name, results: results, err: err})
 
 				case "habr":
-					results, err := jobs.SearchHabrJobs(ctx, input.Query, input.Location, 10)
+					filters := jobs.HabrFilters{Remote: input.Remote == "remote", Experience: input.Experience}
+					listings, err := jobs.SearchHabrVacancies(ctx, input.Query, input.Location, filters, 10)
 					if err != nil {
 						slog.Warn("job_search: habr error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: results, err: err}
+					send(sourceResult{name: name, results: jobs.HabrListingsToSearxngResults(listings), habr: listings, err: err})
 
 				case "twitter":
 					results, err := jobs.SearchTwitterJobs(ctx, input.Query, 30)
 					if err != nil {
 						slog.Warn("job_search: twitter error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: results, err: err}
+					send(sourceResult{name: name, results: results, err: err})
 
 				case platCraigslist:
 					results, err := jobs.SearchCraigslistJobs(ctx, input.Query, input.Location, 15)
 					if err != nil {
 						slog.Warn("job_search: craigslist error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: results, err: err}
+					send(sourceResult{name: name, results: results, err: err})
 
 				case platRemoteOK:
 					rjobs, err := jobs.SearchRemoteOK(ctx, input.Query, 15)
 					if err != nil {
 						slog.Warn("job_search: remoteok error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: jobs.RemoteJobsToSearxngResults(rjobs), err: err}
+					send(sourceResult{name: name, results: jobs.RemoteJobsToSearxngResults(rjobs), err: err})
 
 				case platWWR:
 					rjobs, err := jobs.SearchWeWorkRemotely(ctx, input.Query, 15)
 					if err != nil {
 						slog.Warn("job_search: weworkremotely error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: jobs.RemoteJobsToSearxngResults(rjobs), err: err}
+					send(sourceResult{name: name, results: jobs.RemoteJobsToSearxngResults(rjobs), err: err})
 
 				case platRemotive:
-					rjobs, err := jobs.SearchRemotive(ctx, input.Query, 15)
+					rjobs, err := jobs.SearchRemotive(ctx, input.Query, jobs.RemotiveOptions{JobType: input.JobType, Experience: input.Experience}, 15)
 					if err != nil {
 						slog.Warn("job_search: remotive error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: jobs.RemoteJobsToSearxngResults(rjobs), err: err}
+					send(sourceResult{name: name, results: jobs.RemoteJobsToSearxngResults(rjobs), err: err})
+
+				case platJobicy:
+					rjobs, err := jobs.SearchJobicy(ctx, input.Query, 15)
+					if err != nil {
+						slog.Warn("job_search: jobicy error", slog.Any("error", err))
+					}
+					send(sourceResult{name: name, results: jobs.RemoteJobsToSearxngResults(rjobs), err: err})
+
+				case platHimalayas:
+					rjobs, err := jobs.SearchHimalayasRemote(ctx, input.Query, 15)
+					if err != nil {
+						slog.Warn("job_search: himalayas error", slog.Any("error", err))
+					}
+					send(sourceResult{name: name, results: jobs.RemoteJobsToSearxngResults(rjobs), err: err})
+
+				case platJustRemote:
+					rjobs, err := jobs.SearchJustRemote(ctx, input.Query, 15)
+					if err != nil {
+						slog.Warn("job_search: justremote error", slog.Any("error", err))
+					}
+					send(sourceResult{name: name, results: jobs.RemoteJobsToSearxngResults(rjobs), err: err})
 
 				case platFreelancer:
 					projects, err := sources.SearchFreelancerAPI(ctx, input.Query, 10)
 					if err != nil {
 						slog.Warn("job_search: freelancer error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: sources.FreelancerProjectsToSearxngResults(projects), err: err}
+					send(sourceResult{name: name, results: sources.FreelancerProjectsToSearxngResults(projects), err: err})
+
+				case platIdealist:
+					results, err := jobs.SearchIdealistJobs(ctx, input.Query, input.Location, 15)
+					if err != nil {
+						slog.Warn("job_search: idealist error", slog.Any("error", err))
+					}
+					send(sourceResult{name: name, results: results, err: err})
+
+				case plat80kHours:
+					results, err := jobs.Search80000HoursJobs(ctx, input.Query, input.Location, 15)
+					if err != nil {
+						slog.Warn("job_search: 80000hours error", slog.Any("error", err))
+					}
+					send(sourceResult{name: name, results: results, err: err})
+
+				case platReliefWeb:
+					results, err := jobs.SearchReliefWebJobs(ctx, input.Query, input.Location, 15)
+					if err != nil {
+						slog.Warn("job_search: reliefweb error", slog.Any("error", err))
+					}
+					send(sourceResult{name: name, results: results, err: err})
+
+				case platUSAJobs:
+					listings, err := jobs.SearchUSAJobs(ctx, input.Query, input.Location, input.Experience, 25)
+					if err != nil {
+						slog.Warn("job_search: usajobs error", slog.Any("error", err))
+					}
+					send(sourceResult{name: name, results: jobs.USAJobsListingsToSearxngResults(listings), usajobs: listings, err: err})
+
+				case platEuraxess, platHigherEd:
+					search := jobs.SearchEuraxessJobs
+					if name == platHigherEd {
+						search = jobs.SearchHigherEdJobs
+					}
+					listings, err := search(ctx, input.Query, input.Location, 15)
+					if err != nil {
+						slog.Warn("job_search: academic source error", slog.String("source", name), slog.Any("error", err))
+					}
+					send(sourceResult{name: name, results: jobs.AcademicListingsToSearxngResults(listings), academic: listings, err: err})
+
+				case platExecutive:
+					results, err := jobs.SearchExecutiveBoards(ctx, input.Query, input.Location, 15)
+					if err != nil {
+						slog.Warn("job_search: executive boards error", slog.Any("error", err))
+					}
+					send(sourceResult{name: name, results: results, err: err})
+
+				case platCompanyATS:
+					results, err := jobs.SearchCompanyATSJobs(ctx, input.Company, input.Query, limit)
+					if err != nil {
+						slog.Warn("job_search: company ats error", slog.Any("error", err))
+					}
+					send(sourceResult{name: name, results: results, err: err})
 
 				case platGoogle:
 					searxQuery := input.Query + " " + input.Location + " site:careers.google.com OR site:jobs.google.com"
@@ -251,41 +441,49 @@ func registerJobSearch(server *mcp.Server) {
 					if err != nil {
 						slog.Warn("job_search: google error", slog.Any("error", err))
 					}
-					ch <- sourceResult{name: name, results: results, err: err}
+					send(sourceResult{name: name, results: results, err: err})
 				}
 			}(src)
 		}
 
 		go func() {
-			searxQuery := buildJobSearxQuery(input.Query, input.Location, platform)
+			searxQuery := buildJobSearxQuery(strings.TrimSpace(textQuery+" "+input.Company), input.Location, platform)
 			results, err := engine.SearchSearXNG(ctx, searxQuery, lang, input.TimeRange, engine.DefaultSearchEngine)
 			if err != nil {
 				slog.Warn("job_search: searxng error", slog.Any("error", err))
 			}
-			ch <- sourceResult{name: "searxng", results: results, err: err}
+			send(sourceResult{name: "searxng", results: results, err: err})
 		}()
 
 		totalGoroutines := len(srcs) + 1
-		var merged []engine.SearxngResult
+		bySource := make(map[string][]engine.SearxngResult, totalGoroutines)
 		var linkedInJobs []jobs.LinkedInJob
+		var habrListings, usaJobsListings, academicListings []engine.JobListing
 		for i := 0; i < totalGoroutines; i++ {
 			r := <-ch
-			merged = append(merged, r.results...)
+			bySource[r.name] = r.results
 			if r.name == platLinkedIn && len(r.liJobs) > 0 {
 				linkedInJobs = r.liJobs
 			}
+			habrListings = append(habrListings, r.habr...)
+			usaJobsListings = append(usaJobsListings, r.usajobs...)
+			academicListings = append(academicListings, r.academic...)
 		}
+		endSources()
+		// Completion order is random; merge in a fixed order so dedup keeps the same
+		// copy and identical inputs cache and paginate identically.
+		merged := orderMergedResults(append(srcs, "searxng"), bySource)
 
 		if len(merged) == 0 {
 			return nil, engine.JobSearchOutput{Query: input.Query, Summary: "No results found."}, nil
 		}
 
-		// Dedup pass 1: by URL.
+		// Dedup pass 1: by canonical URL.
 		seen := make(map[string]bool)
 		var deduped []engine.SearxngResult
 		for _, r := range merged {
-			if r.URL != "" && !seen[r.URL] {
-				seen[r.URL] = true
+			if key := engine.CanonicalJobURL(r.URL); key != "" && !seen[key] {
+				seen[key] = true
 				deduped = append(deduped, r)
 			}
 		}
@@ -317,6 +515,9 @@ func registerJobSearch(server *mcp.Server) {
 			top = top[:limit]
 		}
 
+		// Greenhouse/Lever hits only carry snippets; swap in the full posting.
+		jobs.EnrichATSResults(ctx, top)
+
 		contents := make(map[string]string)
 		var mu sync.Mutex
 		var wg sync.WaitGroup
@@ -334,19 +535,20 @@ func registerJobSearch(server *mcp.Server) {
 				continue
 			}
 			wg.Add(1)
-			go func(u string) {
+			u := r.URL
+			engine.GoOutbound(ctx, func() {
 				defer wg.Done()
-				_, text, err := engine.FetchURLContent(ctx, u)
+				text, err := jobs.FetchJobPosting(ctx, u)
 				if err == nil && text != "" {
 					mu.Lock()
-					contents[u] = text
+					contents[u] = engine.TrimContent(text, jobContentLimit)
 					mu.Unlock()
 				}
-			}(r.URL)
+			})
 		}
 		wg.Wait()
 
-		jobOut, err := engine.SummarizeJobResults(ctx, input.Query, engine.JobSearchInstruction, 5000, top, contents)
+		jobOut, err := engine.SummarizeJobResults(ctx, input.Query, engine.JobSearchInstruction, jobContentLimit, top, contents)
 		if err != nil {
 			return nil, engine.JobSearchOutput{}, fmt.Errorf("LLM summarization failed: %w", err)
 		}
@@ -358,6 +560,19 @@ func registerJobSearch(server *mcp.Server) {
 			}
 		}
 
+		habrByURL := make(map[string]engine.JobListing, len(habrListings))
+		for _, h := range habrListings {
+			habrByURL[h.URL] = h
+		}
+		usaJobsByURL := make(map[string]engine.JobListing, len(usaJobsListings))
+		for _, u := range usaJobsListings {
+			usaJobsByURL[u.URL] = u
+		}
+		academicByURL := make(map[string]engine.JobListing, len(academicListings))
+		for _, a := range academicListings {
+			academicByURL[a.URL] = a
+		}
+
 		for i := range jobOut.Jobs {
 			j := &jobOut.Jobs[i]
 			if j.URL == "" && i < len(top) {
@@ -377,13 +592,102 @@ func registerJobSearch(server *mcp.Server) {
 					j.Posted = lj.Posted
 				}
 			}
+			if h, ok := habrByURL[j.URL]; ok {
+				jobs.MergeHabrListing(j, h)
+			}
+			if u, ok := usaJobsByURL[j.URL]; ok {
+				jobs.MergeUSAJobsListing(j, u)
+			}
+			if a, ok := academicByURL[j.URL]; ok {
+				jobs.MergeAcademicListing(j, a)
+			}
 		}
 
+		jobs.NormalizePostedDates(jobOut.Jobs, time.Now())
+		jobs.AnnotateScamRisk(jobOut.Jobs)
+		jobs.TagEvergreenJobs(ctx, jobOut.Jobs)
+		jobs.RecordListingCompanies(ctx, jobOut.Jobs)
+		jobs.AnnotateDeadlines(jobOut.Jobs, time.Now())
+		jobs.BuildListingV2(jobOut.Jobs)
+
 		engine.CacheStoreJSON(ctx, cacheKey, input.Query, *jobOut)
+		finishJobSearch(ctx, input, jobOut)
 		return nil, *jobOut, nil
 	})
 }
 
+// finishJobSearch applies the per-user filters and annotations to (possibly cached)
+// results: scam filter, work authorization, availability, compensation preferences,
+// time-zone overlap, remote eligibility, time range, prior applications and blacklist,
+// freshness, known company data, company stack fit and the resume skill match explanation.
+func finishJobSearch(ctx context.Context, input engine.JobSearchInput, out *engine.JobSearchOutput) {
+	profile := jobs.LoadProfile()
+	if input.HideScams {
+		out.Jobs = jobs.FilterScams(out.Jobs)
+	}
+	if input.ExcludeClearanceRequired {
+		var dropped int
+		out.Jobs, dropped = jobs.FilterClearanceRequired(out.Jobs)
+		out.Summary += jobs.ClearanceDroppedNote(dropped)
+	}
+	if !input.KeepIneligible {
+		out.Jobs = jobs.FilterByWorkAuthorization(out.Jobs, jobs.MasterWorkAuthorization(ctx))
+	}
+	jobs.FlagStartConflicts(out.Jobs, profile, time.Now())
+	var dropped int
+	out.Jobs, dropped = jobs.ApplyCompPreferences(ctx, out.Jobs, profile, input.KeepBelowFloor)
+	out.Summary += jobs.CompDroppedNote(dropped, profile)
+	out.Jobs = jobs.ApplyTimezoneOverlap(out.Jobs, profile, input.MinOverlapHours, time.Now())
+	out.Jobs = jobs.FilterEligibleFrom(out.Jobs, input.EligibleFrom)
+	out.Jobs = jobs.FilterByTimeRange(out.Jobs, input.TimeRange, time.Now())
+	blacklist := input.Blacklist
+	if blacklist == "" {
+		blacklist = profile.Blacklist
+	}
+	out.Jobs, dropped = jobs.ExcludeAppliedCompanies(ctx, out.Jobs, blacklist, input.ExcludeAppliedWithinDays, time.Now())
+	out.Summary += jobs.AppliedDroppedNote(dropped, input.ExcludeAppliedWithinDays)
+	var unknown int
+	out.Jobs, dropped, unknown = jobs.FilterByCompanyProfile(ctx, out.Jobs, input.CompanySize, input.Industry)
+	out.Summary += jobs.CompanyProfileNote(dropped, unknown)
+	jobs.AnnotateBenefits(out.Jobs)
+	if required, _ := jobs.ParseRequiredBenefits(input.RequireBenefits); len(required) > 0 {
+		out.Jobs, dropped = jobs.FilterByBenefits(out.Jobs, required)
+		out.Summary += jobs.BenefitsDroppedNote(dropped, required)
+	}
+	if input.Internships {
+		out.Jobs, dropped = jobs.FilterInternships(out.Jobs)
+		out.Summary += jobs.InternshipDroppedNote(dropped)
+		out.Summary += jobs.StudentProfileNote(jobs.StudentProfileWarnings(ctx))
+	}
+	if input.Executive {
+		out.Jobs, dropped = jobs.FilterExecutive(out.Jobs)
+		out.Summary += jobs.ExecutiveDroppedNote(dropped)
+	}
+	jobs.AnnotateAcademic(out.Jobs)
+	jobs.AnnotateWorkStyle(ctx, out.Jobs)
+	if required, _ := jobs.ParseWorkStyles(input.WorkStyle); len(required) > 0 {
+		out.Jobs, dropped = jobs.FilterByWorkStyle(out.Jobs, required)
+		out.Summary += jobs.WorkStyleDroppedNote(dropped, required)
+	}
+	if input.OutputVersion == engine.OutputV2 {
+		jobs.AnnotateKnownCompanies(ctx, out.Jobs)
+	}
+	jobs.RankByFreshness(out.Jobs, time.Now())
+	weights := jobs.MasterStackWeights(ctx)
+	jobs.RankByCompanyStack(ctx, out.Jobs, weights)
+	if input.OutputVersion == engine.OutputV2 {
+		jobs.ExplainMatches(out.Jobs, weights)
+	}
+	switch input.SortBy {
+	case "deadline":
+		jobs.SortByDeadline(out.Jobs)
+	case "benefits":
+		jobs.SortByBenefits(out.Jobs)
+	}
+	jobs.SetLastSearch(input.Query, out.Jobs)
+	jobs.ApplyOutputVersion(out.Jobs, input.OutputVersion)
+}
+
 func buildJobSearxQuery(query, location, platform string) string {
 	var sitePart string
 	switch platform {
@@ -406,11 +710,35 @@ func buildJobSearxQuery(query, location, platform string) string {
 	case platRemotive:
 		sitePart = "site:remotive.com"
 	case platRemote:
-		sitePart = "site:remoteok.com OR site:weworkremotely.com OR site:remotive.com"
+		sitePart = "site:remoteok.com OR site:weworkremotely.com OR site:remotive.com OR site:jobicy.com OR site:himalayas.app OR site:justremote.co"
+	case platJobicy:
+		sitePart = "site:jobicy.com"
+	case platHimalayas:
+		sitePart = "site:himalayas.app"
+	case platJustRemote:
+		sitePart = "site:justremote.co"
 	case platFreelancer:
 		sitePart = "site:freelancer.com/projects"
 	case platGoogle:
 		sitePart = "site:careers.google.com OR site:jobs.google.com"
+	case platImpact:
+		sitePart = "site:idealist.org OR site:jobs.80000hours.org OR site:reliefweb.int/job"
+	case platIdealist:
+		sitePart = "site:idealist.org"
+	case plat80kHours:
+		sitePart = "site:jobs.80000hours.org"
+	case platReliefWeb:
+		sitePart = "site:reliefweb.int/job"
+	case platUSAJobs:
+		sitePart = "site:usajobs.gov/job"
+	case platAcademic:
+		sitePart = "site:euraxess.ec.europa.eu/jobs OR site:higheredjobs.com"
+	case platEuraxess:
+		sitePart = "site:euraxess.ec.europa.eu/jobs"
+	case platHigherEd:
+		sitePart = "site:higheredjobs.com"
+	case platExecutive:
+		sitePart = "site:linkedin.com/jobs OR site:execthread.com OR site:bluesteps.com OR site:theladders.com"
 	default:
 		sitePart = "jobs"
 	}
@@ -420,33 +748,53 @@ func buildJobSearxQuery(query, location, platform string) string {
 	return query + " " + sitePart
 }
 
+// applyBlacklist drops raw results that mention a blacklisted company, matched the way
+// ExcludeAppliedCompanies later matches extracted listings (jobs.CompanyMatcher).
 func applyBlacklist(results []engine.SearxngResult, blacklist string) []engine.SearxngResult {
-	if blacklist == "" {
-		return results
-	}
-	var terms []string
-	for _, t := range strings.Split(blacklist, ",") {
-		t = strings.ToLower(strings.TrimSpace(t))
-		if t != "" {
-			terms = append(terms, t)
-		}
-	}
-	if len(terms) == 0 {
+	blocked := jobs.NewCompanyMatcher(blacklist)
+	if len(blocked) == 0 {
 		return results
 	}
 	var filtered []engine.SearxngResult
 	for _, r := range results {
-		lower := strings.ToLower(r.Title + " " + r.Content)
-		blocked := false
-		for _, term := range terms {
-			if strings.Contains(lower, term) {
-				blocked = true
-				break
-			}
-		}
-		if !blocked {
+		if !blocked.Matches(r.Title + " " + r.Content) {
 			filtered = append(filtered, r)
 		}
 	}
 	return filtered
 }
+
+// orderMergedResults concatenates per-source results deterministically: sources in
+// priority order, each source's results by posted date (newest first, undated last),
+// then by score, then in the order the source returned them.
+func orderMergedResults(priority []string, bySource map[string][]engine.SearxngResult) []engine.SearxngResult {
+	var merged []engine.SearxngResult
+	for _, name := range priority {
+		results := slices.Clone(bySource[name])
+		slices.SortStableFunc(results, func(a, b engine.SearxngResult) int {
+			if c := strings.Compare(postedDate(b.Content), postedDate(a.Content)); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.Score, a.Score)
+		})
+		merged = append(merged, results...)
+	}
+	return merged
+}
+
+// postedDate returns the YYYY-MM-DD posting date a source wrote into Content
+// ("**Posted:** 2026-10-01" or LinkedIn's "Posted: 2026-10-01"), "" when absent or relative.
+func postedDate(content string) string {
+	_, rest, ok := strings.Cut(content, "Posted:")
+	if !ok {
+		return ""
+	}
+	rest = strings.TrimLeft(rest, "* ")
+	if len(rest) < 10 {
+		return ""
+	}
+	if _, err := time.Parse(time.DateOnly, rest[:10]); err != nil {
+		return ""
+	}
+	return rest[:10]
+}
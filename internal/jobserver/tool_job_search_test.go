@@ -0,0 +1,38 @@
+package jobserver
+
+import (
+	"testing"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+)
+
+func TestOrderMergedResults(t *testing.T) {
+	bySource := map[string][]engine.SearxngResult{
+		"searxng": {{URL: "s1", Score: 0.9}},
+		platRemoteOK: {
+			{URL: "r-undated", Content: "**Source:** RemoteOK"},
+			{URL: "r-old", Content: "**Source:** RemoteOK | **Posted:** 2026-09-01"},
+			{URL: "r-new", Content: "**Source:** RemoteOK | **Posted:** 2026-10-01"},
+		},
+		platLinkedIn: {
+			{URL: "l-low", Content: "Acme | Posted: 3 hours ago", Score: 0.1},
+			{URL: "l-high", Content: "Acme | Posted: 3 hours ago", Score: 0.5},
+		},
+	}
+	want := []string{"l-high", "l-low", "r-new", "r-old", "r-undated", "s1"}
+	for range 3 {
+		got := orderMergedResults([]string{platLinkedIn, platRemoteOK, "searxng"}, bySource)
+		if len(got) != len(want) {
+			t.Fatalf("got %d results, want %d", len(got), len(want))
+		}
+		for i := range want {
+			if got[i].URL != want[i] {
+				t.Fatalf("order[%d] = %s, want %s", i, got[i].URL, want[i])
+			}
+		}
+	}
+	// The per-source slices are left as the sources returned them.
+	if bySource[platRemoteOK][0].URL != "r-undated" {
+		t.Error("orderMergedResults reordered its input")
+	}
+}
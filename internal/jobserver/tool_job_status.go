@@ -0,0 +1,27 @@
+package jobserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerJobStatus(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "job_status",
+		Description: "Report the status of a background job started with async=true: queued, running (with progress 0-100 and current stage), succeeded (with the tool's full result) or failed (with the error).",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.JobStatusInput) (*mcp.CallToolResult, *jobs.BackgroundJob, error) {
+		if input.JobID == "" {
+			return nil, nil, errors.New("job_id is required")
+		}
+		job, err := jobs.GetBackgroundJob(ctx, input.JobID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, job, nil
+	})
+}
@@ -16,7 +16,7 @@ type linkedInProfileInput struct {
 }
 
 func registerLinkedInProfile(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "linkedin_profile",
 		Description: "Full LinkedIn profile by handle or URL. Returns experience, education, skills, contact info.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
@@ -39,7 +39,7 @@ type linkedInCompanyInput struct {
 }
 
 func registerLinkedInCompany(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "linkedin_company",
 		Description: "LinkedIn company page. Returns description, size, industry, headquarters, specialties.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
@@ -62,18 +62,20 @@ type linkedInJobsInput struct {
 	Location string `json:"location,omitempty" jsonschema:"Location filter (optional)"`
 	Remote   string `json:"remote,omitempty" jsonschema:"Work type: remote, hybrid, onsite (optional)"`
 	Limit    int    `json:"limit,omitempty" jsonschema:"Max results (default 10, max 25)"`
+
+	OutputVersion int `json:"output_version,omitempty" jsonschema:"Output shape: 1 (default, stable) or 2 (adds eligibility and scores blocks)"`
 }
 
 type linkedInJobsOutput struct {
-	Query string         `json:"query"`
-	Count int            `json:"count"`
-	Jobs  []linkedin.Job `json:"jobs"`
+	Query string            `json:"query"`
+	Count int               `json:"count"`
+	Jobs  []jobs.VoyagerJob `json:"jobs"`
 }
 
 func registerLinkedInJobs(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "linkedin_jobs",
-		Description: "Search LinkedIn job listings via Voyager API (authenticated). Requires LinkedIn credentials.",
+		Description: "Search LinkedIn job listings via Voyager API (authenticated). Requires LinkedIn credentials. output_version=2 adds eligibility (remote scope, visa sponsorship, citizenship, clearance) and scores (scam risk) blocks.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input linkedInJobsInput) (*mcp.CallToolResult, linkedInJobsOutput, error) {
 		if input.Query == "" {
@@ -94,7 +96,7 @@ func registerLinkedInJobs(server *mcp.Server) {
 		if err != nil {
 			return nil, linkedInJobsOutput{}, err
 		}
-		return nil, linkedInJobsOutput{Query: input.Query, Count: len(result), Jobs: result}, nil
+		return nil, linkedInJobsOutput{Query: input.Query, Count: len(result), Jobs: jobs.ApplyLinkedInOutputVersion(result, input.OutputVersion)}, nil
 	})
 }
 
@@ -114,7 +116,7 @@ type linkedInSearchOutput struct {
 }
 
 func registerLinkedInSearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "linkedin_search",
 		Description: "Search LinkedIn for people or companies via Voyager API.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
@@ -157,7 +159,7 @@ type linkedInPostsOutput struct {
 }
 
 func registerLinkedInPosts(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "linkedin_posts",
 		Description: "Get profile posts with engagement metrics (likes, comments, reposts).",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
@@ -183,7 +185,7 @@ type linkedInRatingInput struct {
 }
 
 func registerLinkedInRating(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "linkedin_rating",
 		Description: "Computed profile rating: influence score, completeness, engagement metrics.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
@@ -31,7 +31,7 @@ type linkedInProfileIngestOutput struct {
 }
 
 func registerLinkedInProfileIngest(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "linkedin_profile_ingest",
 		Description: "Fetch full LinkedIn profile and save to go-nerv intelligence graph (person, company, skill entities + WORKS_AT/STUDIED_AT edges).",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input linkedInProfileIngestInput) (*mcp.CallToolResult, *linkedInProfileIngestOutput, error) {
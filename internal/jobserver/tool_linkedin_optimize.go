@@ -0,0 +1,56 @@
+package jobserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// --- linkedin_profile_optimize ---
+
+type linkedInProfileOptimizeInput struct {
+	Headline    string `json:"headline,omitempty" jsonschema:"Current LinkedIn headline (optional if handle is given)"`
+	About       string `json:"about,omitempty" jsonschema:"Current LinkedIn About section (optional if handle is given)"`
+	Handle      string `json:"handle,omitempty" jsonschema:"LinkedIn handle or profile URL to fetch the current headline/about from (optional)"`
+	TargetRoles string `json:"target_roles" jsonschema:"Comma-separated target roles (e.g. Senior Go Engineer, Platform Engineer)"`
+	Resume      string `json:"resume,omitempty" jsonschema:"Resume text used as evidence when no master resume is stored (optional)"`
+}
+
+func registerLinkedInProfileOptimize(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "linkedin_profile_optimize",
+		Description: "Rewrite LinkedIn headline and About for target roles. Grounded in master resume achievements; returns keyword coverage before/after.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input linkedInProfileOptimizeInput) (*mcp.CallToolResult, *jobs.LinkedInOptimizeResult, error) {
+		var roles []string
+		for _, r := range strings.Split(input.TargetRoles, ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				roles = append(roles, r)
+			}
+		}
+		if len(roles) == 0 {
+			return nil, nil, errors.New("target_roles is required")
+		}
+
+		if input.Handle != "" && input.Headline == "" && input.About == "" {
+			profile, err := jobs.VoyagerProfile(ctx, input.Handle)
+			if err != nil {
+				return nil, nil, fmt.Errorf("fetch profile: %w", err)
+			}
+			input.Headline, input.About = profile.Headline, profile.About
+		}
+		if input.Headline == "" && input.About == "" {
+			return nil, nil, errors.New("headline or about is required (or pass handle)")
+		}
+
+		result, err := jobs.OptimizeLinkedInProfile(ctx, input.Headline, input.About, roles, input.Resume)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
@@ -10,14 +10,28 @@ import (
 )
 
 func registerMasterResumeBuild(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "master_resume_build",
-		Description: "Build a master resume from your full resume text. Parses into a structured knowledge graph (skills, experiences, projects, achievements) with vector embeddings for semantic search. Run once, then use resume_generate to create tailored versions.",
+		Description: "Build a master resume from your full resume text. Parses into a structured knowledge graph (skills, experiences, projects, achievements) with vector embeddings for semantic search. Run once, then use resume_generate to create tailored versions. A rebuild archives the previous build; master_resume_rollback restores it. Set async=true to get a job_id immediately and poll job_status; background builds of the master resume run one at a time, at most 4 waiting. Pass idempotency_key so client retries return the original result instead of rebuilding.",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.MasterResumeBuildInput) (*mcp.CallToolResult, *jobs.MasterResumeBuildResult, error) {
 		if input.Resume == "" {
 			return nil, nil, errors.New("resume is required")
 		}
-		result, err := jobs.BuildMasterResume(ctx, input.Resume)
+		result, err := jobs.Idempotent(ctx, "master_resume_build", input.IdempotencyKey, input, func() (*jobs.MasterResumeBuildResult, error) {
+			if !input.Async {
+				return jobs.BuildMasterResume(ctx, input.Resume)
+			}
+			job, err := jobs.StartBackgroundJob(ctx, "master_resume_build", jobs.MasterResumeJobKey, func(ctx context.Context) (any, error) {
+				return jobs.BuildMasterResume(ctx, input.Resume)
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &jobs.MasterResumeBuildResult{
+				JobID:   job.ID,
+				Summary: "Master resume build started in the background. Poll job_status with job_id " + job.ID + " for progress and the result.",
+			}, nil
+		})
 		if err != nil {
 			return nil, nil, err
 		}
@@ -0,0 +1,35 @@
+package jobserver
+
+import (
+	"context"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerMasterResumeStatus(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "master_resume_status",
+		Description: "Check master resume integrity: one complete person in SQL, no orphan rows, and the knowledge graph and vector store in sync with the last build. Set repair=true to clean up leftovers and replay the last build into the graph and vectors.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.MasterResumeStatusInput) (*mcp.CallToolResult, *jobs.MasterResumeStatusResult, error) {
+		result, err := jobs.MasterResumeStatus(ctx, input.Repair)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerMasterResumeRollback(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "master_resume_rollback",
+		Description: "Undo the last master_resume_build: restore the previous build, which the rebuild archived instead of deleting, and rebuild the knowledge graph and vectors from it. The replaced build is archived in turn, so a second rollback redoes the rebuild. Returns row counts per table of both builds; use dry_run=true to compare them first, e.g. when the new parse lost experiences or skills. The last 3 replaced builds are kept.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.MasterResumeRollbackInput) (*mcp.CallToolResult, *jobs.MasterResumeRollbackResult, error) {
+		result, err := jobs.MasterResumeRollback(ctx, input.DryRun)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
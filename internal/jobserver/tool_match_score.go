@@ -16,9 +16,9 @@ import (
 )
 
 func registerJobMatchScore(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "job_match_score",
-		Description: "Score job listings against a resume using keyword overlap analysis (Jaccard similarity). Searches jobs across LinkedIn, Indeed, and YC, then ranks each result by how well it matches the resume text. Returns jobs sorted by match_score (0–100) with lists of matching and missing keywords.",
+		Description: "Score job listings against a resume using keyword overlap analysis (Jaccard similarity). Searches jobs across LinkedIn, Indeed, and YC, then ranks each result by how well it matches the resume text. When a master resume is built, skills last used years ago weigh less (stale_keywords) and matching_years lists your years with each matching skill. Returns jobs sorted by match_score (0–100) with lists of matching and missing keywords.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, req *mcp.CallToolRequest, input engine.JobMatchScoreInput) (*mcp.CallToolResult, engine.JobMatchScoreOutput, error) {
 		if input.Resume == "" {
@@ -29,6 +29,9 @@ func registerJobMatchScore(server *mcp.Server) {
 		}
 
 		resumeKW := jobs.ExtractResumeKeywords(input.Resume)
+		// Skills last used long ago (per the master resume, when built) weigh less.
+		weights := jobs.MasterSkillWeights(ctx)
+		years, _ := jobs.MasterExperienceYears(ctx) // nil without a master resume
 
 		platform := strings.ToLower(strings.TrimSpace(input.Platform))
 		if platform == "" {
@@ -43,7 +46,7 @@ func registerJobMatchScore(server *mcp.Server) {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				liJobs, err := jobs.SearchLinkedInJobs(ctx, input.Query, input.Location, "", "", "", "", "", 50, false)
+				liJobs, err := jobs.SearchLinkedInJobs(ctx, input.Query, input.Location, "", "", "", "", "", "", "", 50, false)
 				if err != nil {
 					slog.Warn("job_match_score: linkedin error", slog.Any("error", err))
 					return
@@ -106,21 +109,35 @@ func registerJobMatchScore(server *mcp.Server) {
 			return nil, engine.JobMatchScoreOutput{Query: input.Query, Summary: "No jobs found."}, nil
 		}
 
-		// Dedup by URL.
+		// Dedup by canonical URL.
 		seen := make(map[string]bool)
 		var deduped []engine.SearxngResult
 		for _, r := range allResults {
-			if r.URL != "" && !seen[r.URL] {
-				seen[r.URL] = true
+			if key := engine.CanonicalJobURL(r.URL); key != "" && !seen[key] {
+				seen[key] = true
 				deduped = append(deduped, r)
 			}
 		}
 
+		// Score ATS postings on their full description, not the search snippet.
+		jobs.EnrichATSResults(ctx, deduped)
+
 		// Score each result against resume keywords.
 		scored := make([]engine.JobMatchResult, 0, len(deduped))
 		for _, r := range deduped {
 			jobText := r.Title + " " + r.Content
-			score, matching, missing := jobs.ScoreJobMatch(resumeKW, jobText)
+			score, matching, missing := jobs.ScoreJobMatchWeighted(resumeKW, weights, jobText)
+			var stale, matchingYears []string
+			for _, kw := range matching {
+				if w, ok := weights[kw]; ok && w < 1 {
+					stale = append(stale, kw)
+				}
+				if years != nil {
+					if s, ok := years.Skill(kw); ok {
+						matchingYears = append(matchingYears, s.String())
+					}
+				}
+			}
 
 			// Split "Title at Company" LinkedIn format into separate fields.
 			title, company := r.Title, ""
@@ -140,6 +157,8 @@ func registerJobMatchScore(server *mcp.Server) {
 				MatchScore:       score,
 				MatchingKeywords: matching,
 				MissingKeywords:  missing,
+				StaleKeywords:    stale,
+				MatchingYears:    matchingYears,
 			})
 		}
 
@@ -165,6 +184,20 @@ func registerJobMatchScore(server *mcp.Server) {
 	})
 }
 
+func registerJobsScoreBatch(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "jobs_score_batch",
+		Description: "Score up to 50 job descriptions, given as jd text or url (fetched), against the master resume in one call, without searching — e.g. jobs gathered by another agent. Each job gets keyword_score (the job_match_score keyword overlap, stale skills weighing less), skill_coverage (share of the JD's skills on the resume), semantic_score when an embedding server is configured, the blended score (0-100), matching_skills, gaps and stale_skills. Jobs are returned in recommended priority order (rank, priority high/medium/low; close deadlines first within a tier). Requires master_resume_build.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.JobsScoreBatchInput) (*mcp.CallToolResult, *jobs.JobsScoreBatchResult, error) {
+		result, err := jobs.ScoreJobsBatch(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
 // extractSource guesses the job board name from a URL hostname.
 func extractSource(jobURL string) string {
 	u, err := url.Parse(jobURL)
@@ -10,7 +10,7 @@ import (
 )
 
 func registerNegotiationPrep(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "negotiation_prep",
 		Description: "Generate a salary negotiation playbook with market data, opening/closing scripts, talking points with anticipated counters, BATNA analysis, and red flags. Optionally enriches with salary research benchmarks.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
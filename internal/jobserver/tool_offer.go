@@ -10,7 +10,7 @@ import (
 )
 
 func registerOfferCompare(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "offer_compare",
 		Description: "Compare multiple job offers side-by-side across compensation, benefits, work-life balance, growth potential, and stability. Scores each offer 0-100 and recommends the best choice based on your priorities.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
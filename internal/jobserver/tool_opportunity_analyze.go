@@ -11,7 +11,7 @@ import (
 )
 
 func registerOpportunityAnalyze(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "opportunity_analyze",
 		Description: "Analyze any income opportunity by URL. Auto-detects type: GitHub issue URLs are analyzed as code bounties (complexity, $/hr, competing PRs), security platform URLs show program details, freelance URLs show job details.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
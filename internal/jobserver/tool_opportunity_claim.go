@@ -12,7 +12,7 @@ import (
 )
 
 func registerOpportunityClaim(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "opportunity_claim",
 		Description: "Claim an income opportunity. For code bounties: posts /attempt comment on the GitHub issue. For security programs: advises manual process. For freelance: advises using application_prep.",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.OpportunityClaimInput) (*mcp.CallToolResult, engine.SmartSearchOutput, error) {
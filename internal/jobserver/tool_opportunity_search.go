@@ -11,7 +11,7 @@ import (
 )
 
 func registerOpportunitySearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "opportunity_search",
 		Description: "Search for income opportunities across all sources: code bounties (Algora, Opire, BountyHub, Boss, Lightning, Collaborators), security bug bounties (HackerOne, Bugcrowd, Intigriti, YesWeHack, Immunefi), and freelance jobs (RemoteOK, Himalayas). Filter by type and keyword.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
@@ -10,7 +10,7 @@ import (
 )
 
 func registerPitchGenerate(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "pitch_generate",
 		Description: "Generate personalized elevator pitches (30-second and 2-minute) for a target role based on your resume. Optionally enriches with company research for a tailored 'Why this company?' answer.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
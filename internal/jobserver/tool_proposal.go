@@ -0,0 +1,36 @@
+package jobserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerProposalGenerate(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "proposal_generate",
+		Description: "Write a tailored Upwork or Freelancer.com proposal for a freelance project (text or URL) from the most relevant projects and achievements in your master resume (ResumeDB). Configurable length; includes a bid suggestion (from your rate or the profile's target compensation), clarifying questions for the client, and portfolio links. Requires master_resume_build.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ProposalGenerateInput) (*mcp.CallToolResult, *jobs.ProposalResult, error) {
+		project := input.Project
+		if project == "" && input.URL != "" {
+			_, text, err := engine.FetchURLContent(ctx, input.URL)
+			if err != nil {
+				return nil, nil, fmt.Errorf("fetch project: %w", err)
+			}
+			project = text
+		}
+		if project == "" {
+			return nil, nil, errors.New("project or url is required")
+		}
+		result, err := jobs.GenerateProposal(ctx, input, project)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/anatolykoptev/go_job/internal/engine"
 	"github.com/anatolykoptev/go_job/internal/engine/jobs"
@@ -13,16 +16,21 @@ import (
 )
 
 func registerRemoteWorkSearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "remote_work_search",
-		Description: "Search for remote jobs on RemoteOK, WeWorkRemotely, and the web via SearXNG. Returns structured JSON with job details (title, company, salary, tags, source). Best for remote-first positions worldwide.",
+		Description: "Search for remote jobs on RemoteOK, WeWorkRemotely, Remotive, Jobicy, Himalayas, JustRemote, and the web via SearXNG. Returns structured JSON with job details (title, company, salary, tags, source). Best for remote-first positions worldwide. Listings below the profile salary_floor are dropped unless keep_below_floor=true; the rest carry comp_fit against the floor and target_comp. With a profile timezone, listings naming team time zones get overlap_hours (filter with min_overlap_hours). Each listing is tagged eligible_from (worldwide, us, eu, uk, … or specific countries); set eligible_from (e.g. Germany, EU) to drop listings restricted elsewhere. salary_normalized, comp_fit, overlap_hours and eligible_from are returned with output_version=2.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, req *mcp.CallToolRequest, input engine.RemoteWorkSearchInput) (*mcp.CallToolResult, engine.SmartSearchOutput, error) {
 		if input.Query == "" {
 			return nil, engine.SmartSearchOutput{}, errors.New("query is required")
 		}
 
-		cacheKey := engine.CacheKey("remote_work_search", input.Query, input.Language)
+		profile := jobs.LoadProfile()
+		cacheKey := engine.CacheKey("remote_work_search", input.Query, input.Language,
+			fmt.Sprintf("comp_%d_%d_%s_%t", profile.SalaryFloor, profile.TargetComp, profile.SalaryCurrency, input.KeepBelowFloor),
+			fmt.Sprintf("tz_%s_%s_%g", profile.Timezone, profile.WorkHours, input.MinOverlapHours),
+			"from_"+strings.ToLower(strings.TrimSpace(input.EligibleFrom)),
+			fmt.Sprintf("v%d", input.OutputVersion))
 		if cached, ok := engine.CacheGet(ctx, cacheKey); ok {
 			return nil, cached, nil
 		}
@@ -33,22 +41,32 @@ func registerRemoteWorkSearch(server *mcp.Server) {
 			jobList []engine.RemoteJobListing
 			err     error
 		}
-		rokCh := make(chan apiResult, 1)
-		wwrCh := make(chan apiResult, 1)
-		remCh := make(chan apiResult, 1)
-
-		go func() {
-			j, err := jobs.SearchRemoteOK(ctx, input.Query, 20)
-			rokCh <- apiResult{j, err}
-		}()
-		go func() {
-			j, err := jobs.SearchWeWorkRemotely(ctx, input.Query, 20)
-			wwrCh <- apiResult{j, err}
-		}()
-		go func() {
-			j, err := jobs.SearchRemotive(ctx, input.Query, 15)
-			remCh <- apiResult{j, err}
-		}()
+		type apiSource struct {
+			name     string
+			platform string // job_search platform name, for GO_JOB_DISABLED_SOURCES
+			search   func() ([]engine.RemoteJobListing, error)
+		}
+		apiSources := []apiSource{
+			{"RemoteOK", platRemoteOK, func() ([]engine.RemoteJobListing, error) { return jobs.SearchRemoteOK(ctx, input.Query, 20) }},
+			{"WWR", platWWR, func() ([]engine.RemoteJobListing, error) { return jobs.SearchWeWorkRemotely(ctx, input.Query, 20) }},
+			{"Remotive", platRemotive, func() ([]engine.RemoteJobListing, error) {
+				return jobs.SearchRemotive(ctx, input.Query, jobs.RemotiveOptions{}, 15)
+			}},
+			{"Jobicy", platJobicy, func() ([]engine.RemoteJobListing, error) { return jobs.SearchJobicy(ctx, input.Query, 15) }},
+			{"Himalayas", platHimalayas, func() ([]engine.RemoteJobListing, error) { return jobs.SearchHimalayasRemote(ctx, input.Query, 15) }},
+			{"JustRemote", platJustRemote, func() ([]engine.RemoteJobListing, error) { return jobs.SearchJustRemote(ctx, input.Query, 15) }},
+		}
+		apiSources = slices.DeleteFunc(apiSources, func(s apiSource) bool {
+			return engine.ConfigFrom(ctx).SourceDisabled(s.platform)
+		})
+		apiChannels := make([]chan apiResult, len(apiSources))
+		for i, src := range apiSources {
+			apiChannels[i] = make(chan apiResult, 1)
+			go func() {
+				j, err := src.search()
+				apiChannels[i] <- apiResult{j, err}
+			}()
+		}
 
 		type searchResult struct {
 			results []engine.SearxngResult
@@ -67,55 +85,27 @@ func registerRemoteWorkSearch(server *mcp.Server) {
 		addQuery(input.Query+" remote job", engine.DefaultSearchEngine)
 		addQuery(input.Query+" remote job", engine.DefaultSearchEngine)
 
-		var rokRes, wwrRes, remRes apiResult
-		for i := 0; i < 3; i++ {
+		var apiSearxResults []engine.SearxngResult
+		apiURLs := make(map[string]bool)
+		apiFailures := 0
+		for i, ch := range apiChannels {
 			select {
-			case r := <-rokCh:
-				rokRes = r
-			case r := <-wwrCh:
-				wwrRes = r
-			case r := <-remCh:
-				remRes = r
+			case res := <-ch:
+				if res.err != nil {
+					slog.Warn("remote_work_search: "+apiSources[i].name+" error", slog.Any("error", res.err))
+					apiFailures++
+					continue
+				}
+				converted := jobs.RemoteJobsToSearxngResults(res.jobList)
+				for _, r := range converted {
+					apiURLs[r.URL] = true
+				}
+				apiSearxResults = append(apiSearxResults, converted...)
 			case <-ctx.Done():
 				return nil, engine.SmartSearchOutput{}, ctx.Err()
 			}
 		}
 
-		if rokRes.err != nil {
-			slog.Warn("remote_work_search: RemoteOK error", slog.Any("error", rokRes.err))
-		}
-		if wwrRes.err != nil {
-			slog.Warn("remote_work_search: WWR error", slog.Any("error", wwrRes.err))
-		}
-		if remRes.err != nil {
-			slog.Warn("remote_work_search: Remotive error", slog.Any("error", remRes.err))
-		}
-
-		var apiSearxResults []engine.SearxngResult
-		apiURLs := make(map[string]bool)
-
-		if len(rokRes.jobList) > 0 {
-			converted := jobs.RemoteJobsToSearxngResults(rokRes.jobList)
-			for _, r := range converted {
-				apiURLs[r.URL] = true
-			}
-			apiSearxResults = append(apiSearxResults, converted...)
-		}
-		if len(wwrRes.jobList) > 0 {
-			converted := jobs.RemoteJobsToSearxngResults(wwrRes.jobList)
-			for _, r := range converted {
-				apiURLs[r.URL] = true
-			}
-			apiSearxResults = append(apiSearxResults, converted...)
-		}
-		if len(remRes.jobList) > 0 {
-			converted := jobs.RemoteJobsToSearxngResults(remRes.jobList)
-			for _, r := range converted {
-				apiURLs[r.URL] = true
-			}
-			apiSearxResults = append(apiSearxResults, converted...)
-		}
-
 		var webResults []engine.SearxngResult
 		for _, ch := range searxChannels {
 			select {
@@ -135,7 +125,7 @@ func registerRemoteWorkSearch(server *mcp.Server) {
 		merged = append(merged, webResults...)
 
 		if len(merged) == 0 {
-			if rokRes.err != nil && wwrRes.err != nil && remRes.err != nil {
+			if apiFailures == len(apiSources) {
 				return nil, engine.SmartSearchOutput{}, errors.New("all sources failed")
 			}
 			out := engine.RemoteWorkSearchOutput{Query: input.Query, Summary: "No remote jobs found."}
@@ -145,8 +135,8 @@ func registerRemoteWorkSearch(server *mcp.Server) {
 		seen := make(map[string]bool)
 		var deduped []engine.SearxngResult
 		for _, r := range merged {
-			if !seen[r.URL] {
-				seen[r.URL] = true
+			if key := engine.CanonicalJobURL(r.URL); !seen[key] {
+				seen[key] = true
 				deduped = append(deduped, r)
 			}
 		}
@@ -170,10 +160,15 @@ func registerRemoteWorkSearch(server *mcp.Server) {
 			enrichedJobs[i] = job
 		}
 
+		enrichedJobs, dropped := jobs.ApplyRemoteCompPreferences(enrichedJobs, profile, input.KeepBelowFloor)
+		enrichedJobs = jobs.ApplyRemoteTimezoneOverlap(enrichedJobs, profile, input.MinOverlapHours, time.Now())
+		enrichedJobs = jobs.ApplyRemoteEligibility(enrichedJobs, input.EligibleFrom)
+		jobs.ApplyRemoteOutputVersion(enrichedJobs, input.OutputVersion)
+
 		return remoteWorkResult(ctx, cacheKey, engine.RemoteWorkSearchOutput{
 			Query:   remoteOut.Query,
 			Jobs:    enrichedJobs,
-			Summary: remoteOut.Summary,
+			Summary: remoteOut.Summary + jobs.CompDroppedNote(dropped, profile),
 		})
 	})
 }
@@ -10,9 +10,9 @@ import (
 )
 
 func registerSalaryResearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "salary_research",
-		Description: "Research salary ranges for a role and location. Returns p25/median/p75 percentiles with sources (levels.fyi, Glassdoor, LinkedIn, hh.ru, Хабр). For Russian locations returns RUB, otherwise USD.",
+		Description: "Research salary ranges for a role and location. Returns p25/median/p75 percentiles with sources (levels.fyi, Glassdoor, LinkedIn, hh.ru, Хабр). For Russian locations returns RUB, otherwise USD. Comp datapoints from Blind posts are returned separately under anecdotal.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.SalaryResearchInput) (*mcp.CallToolResult, *jobs.SalaryResearchResult, error) {
 		if input.Role == "" {
@@ -27,15 +27,53 @@ func registerSalaryResearch(server *mcp.Server) {
 }
 
 func registerCompanyResearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "company_research",
-		Description: "Research a company for interview preparation or job evaluation. Returns size, funding, tech stack, culture notes, recent news, Glassdoor rating, and an overall summary for job seekers.",
+		Description: "Research a company for interview preparation or job evaluation. Returns size, headquarters, funding, tech stack, culture notes, recent news, Glassdoor rating, the skills across the company's job postings seen by job_search (posting_stack, with frequencies), reported layoffs and hiring freezes, hiring velocity from seen postings, an employer_risk rating (low/medium/high), anonymous employee sentiment and comp datapoints from Blind (labeled anecdotal), and an overall summary for job seekers. Results are stored in the company store and reused for 30 days (refresh=true researches again).",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.CompanyResearchInput) (*mcp.CallToolResult, *jobs.CompanyResearchResult, error) {
 		if input.Company == "" {
 			return nil, nil, errors.New("company is required")
 		}
-		result, err := jobs.ResearchCompany(ctx, input.Company)
+		research := jobs.ResearchCompany
+		if input.Refresh {
+			research = jobs.RefreshCompanyResearch
+		}
+		result, err := research(ctx, input.Company)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerCompanyNews(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "company_news",
+		Description: "Recent news about a company as a dated timeline, newest first. Each item is classified by category (funding, layoffs, product_launch, lawsuit, acquisition, leadership, other) and sentiment (positive/negative/neutral), with an overall sentiment and per-category counts. Cached per company; also feeds interview_prep and jd_red_flags.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.CompanyNewsInput) (*mcp.CallToolResult, *jobs.CompanyNewsResult, error) {
+		if input.Company == "" {
+			return nil, nil, errors.New("company is required")
+		}
+		result, err := jobs.CompanyNews(ctx, input.Company)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerTakeHomeResearch(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "takehome_research",
+		Description: "Find publicly shared take-home assignment reports for a company and role — GitHub repositories with candidates' submissions or briefs, and blog/forum write-ups — and summarize the expected scope, time budget, evaluation criteria, typical tasks and tech stack, with source links. found=false when no source describes an assignment.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.TakeHomeResearchInput) (*mcp.CallToolResult, *jobs.TakeHomeResearchResult, error) {
+		if input.Company == "" {
+			return nil, nil, errors.New("company is required")
+		}
+		result, err := jobs.ResearchTakeHome(ctx, input.Company, input.Role)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -44,7 +82,7 @@ func registerCompanyResearch(server *mcp.Server) {
 }
 
 func registerPersonResearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "person_research",
 		Description: "Research a person (hiring manager, interviewer, recruiter) from open sources: LinkedIn, GitHub, web, Habr, and Twitter/X via go-hully. Returns background, skills, interests, recent activity, common ground, and specific interview tips. Use before interviews to build rapport and prepare relevant talking points.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
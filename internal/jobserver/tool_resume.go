@@ -10,9 +10,9 @@ import (
 )
 
 func registerResumeAnalyze(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "resume_analyze",
-		Description: "Analyze a resume against a job description. Returns ATS score (0-100), matching/missing keywords, experience gaps, and specific recommendations to improve match rate.",
+		Description: "Analyze a resume against a job description. Returns ATS score (0-100), matching/missing keywords, experience gaps, specific recommendations to improve match rate, the JD's must-have vs nice-to-have requirements and years-of-experience demands scored separately (hard blockers vs soft gaps), and deterministic lint findings (bullet length, passive voice, first-person pronouns, buzzword density, date-format consistency, page-length estimate).",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ResumeAnalyzeInput) (*mcp.CallToolResult, *jobs.ResumeAnalysisResult, error) {
 		if input.Resume == "" {
@@ -30,9 +30,9 @@ func registerResumeAnalyze(server *mcp.Server) {
 }
 
 func registerCoverLetterGenerate(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "cover_letter_generate",
-		Description: "Generate a tailored cover letter from a resume and job description. Tone options: professional (default), friendly, concise. Returns the cover letter text with word count.",
+		Description: "Generate a tailored cover letter from a resume and job description. Tone options: professional (default), friendly, concise. With company set, uses its cached company_research (run company_research first) and requires at least one concrete company-specific reference — recent news, product, culture or stack — returned in company_reference with the research field it came from. States your availability when available_from or notice_period_days is set in ~/.go_job/profile.json. Returns the cover letter text with word count.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.CoverLetterInput) (*mcp.CallToolResult, *jobs.CoverLetterResult, error) {
 		if input.Resume == "" {
@@ -41,7 +41,7 @@ func registerCoverLetterGenerate(server *mcp.Server) {
 		if input.JobDescription == "" {
 			return nil, nil, errors.New("job_description is required")
 		}
-		result, err := jobs.GenerateCoverLetter(ctx, input.Resume, input.JobDescription, input.Tone)
+		result, err := jobs.GenerateCoverLetter(ctx, input.Resume, input.JobDescription, input.Tone, input.Company)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -50,9 +50,9 @@ func registerCoverLetterGenerate(server *mcp.Server) {
 }
 
 func registerResumeTailor(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "resume_tailor",
-		Description: "Rewrite resume sections to better match a specific job description. Incorporates missing keywords naturally, reorders bullet points by relevance, quantifies achievements. Returns tailored resume + diff summary.",
+		Description: "Rewrite resume sections to better match a specific job description. Incorporates missing keywords naturally, reorders bullet points by relevance, quantifies achievements. Returns tailored resume + diff summary, with lint findings for the tailored resume (bullet length, passive voice, pronouns, buzzwords, date formats, page length).",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ResumeTailorInput) (*mcp.CallToolResult, *jobs.ResumeTailorResult, error) {
 		if input.Resume == "" {
@@ -68,3 +68,17 @@ func registerResumeTailor(server *mcp.Server) {
 		return nil, result, nil
 	})
 }
+
+func registerResumePDFCheck(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "resume_pdf_check",
+		Description: "Check a rendered resume PDF for ATS machine readability: extractable text layer, embedded fonts, single-column layout without tables, and text stored in reading order. Takes a file name in ~/.go_job/resumes or the PDF as base64 (10 MB max). Returns pass/fail checks with details, the fonts used, and the extracted text as an ATS would read it.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, input engine.ResumePDFCheckInput) (*mcp.CallToolResult, *jobs.ResumePDFCheckResult, error) {
+		result, err := jobs.CheckResumePDF(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
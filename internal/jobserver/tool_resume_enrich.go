@@ -10,9 +10,9 @@ import (
 )
 
 func registerResumeEnrich(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "resume_enrich",
-		Description: "Interactively enrich your master resume. Use action='start' to get enrichment questions about gaps (missing metrics, hidden skills, unclear roles). Use action='answer' with your answers to apply enrichments to the knowledge graph.",
+		Description: "Interactively enrich your master resume. Use action='start' to get enrichment questions about gaps (missing metrics, hidden skills, unclear roles). Use action='answer' with your answers to apply enrichments to the knowledge graph. Set async=true to get a job_id immediately and poll job_status; background enrichments and master_resume_build runs apply one at a time.",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ResumeEnrichInput) (*mcp.CallToolResult, *jobs.ResumeEnrichResult, error) {
 		if input.Action == "" {
 			return nil, nil, errors.New("action is required ('start' or 'answer')")
@@ -23,9 +23,25 @@ func registerResumeEnrich(server *mcp.Server) {
 			answers = append(answers, jobs.AnswerPair{
 				QuestionID: a.QuestionID,
 				Answer:     a.Answer,
+				Question:   a.Question,
+				Context:    a.Context,
 			})
 		}
 
+		if input.Async {
+			job, err := jobs.StartBackgroundJob(ctx, "resume_enrich", jobs.MasterResumeJobKey, func(ctx context.Context) (any, error) {
+				return jobs.EnrichResume(ctx, input.Action, answers)
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, &jobs.ResumeEnrichResult{
+				JobID:   job.ID,
+				Status:  "started",
+				Summary: "Resume enrichment started in the background. Poll job_status with job_id " + job.ID + " for progress and the result.",
+			}, nil
+		}
+
 		result, err := jobs.EnrichResume(ctx, input.Action, answers)
 		if err != nil {
 			return nil, nil, err
@@ -10,14 +10,47 @@ import (
 )
 
 func registerResumeGenerate(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "resume_generate",
-		Description: "Generate an ATS-optimized resume tailored to a specific job description. Uses your master resume graph to select the most relevant experiences, projects, and achievements. Injects keywords from the JD for maximum ATS pass rate.",
+		Description: "Generate an ATS-optimized resume tailored to a specific job description. Uses your master resume graph to select the most relevant experiences, projects, and achievements. Injects keywords from the JD for maximum ATS pass rate. Figures not backed by a recorded achievement metric are returned as metric_questions for resume_enrich, or dropped with metrics=remove. Text and markdown resumes come with lint findings next to the ATS score (bullet length, passive voice, pronouns, buzzwords, date formats, page length). For internship and junior roles, or candidates with under two years of experience, education and projects come before experience (early_career); director+ roles get an executive summary format (executive).",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ResumeGenerateInput) (*mcp.CallToolResult, *jobs.ResumeGenerateResult, error) {
 		if input.JobDescription == "" {
 			return nil, nil, errors.New("job_description is required")
 		}
-		result, err := jobs.GenerateResume(ctx, input.JobDescription, input.Company, input.Format)
+		if input.Metrics != "" && input.Metrics != jobs.MetricPolicyAsk && input.Metrics != jobs.MetricPolicyRemove {
+			return nil, nil, errors.New("metrics must be 'ask' or 'remove'")
+		}
+		result, err := jobs.GenerateResume(ctx, input.JobDescription, input.Company, input.Format, input.Metrics)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerResumeVariants(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "resume_variants",
+		Description: "Generate two resume variants for one job description for A/B testing: variant A is the resume_generate output (unverified figures removed), variant B presents the same facts with a different summary angle and bullet order. Both are stored with IDs; tag each application with the variant you sent (resume_variant_id in job_tracker_add or job_tracker_update) and compare response rates with resume_ab_report.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ResumeVariantsInput) (*mcp.CallToolResult, *jobs.ResumeVariantsResult, error) {
+		if input.JobDescription == "" {
+			return nil, nil, errors.New("job_description is required")
+		}
+		result, err := jobs.GenerateResumeVariants(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerResumeABReport(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "resume_ab_report",
+		Description: "Compare outcomes of tracked applications per resume variant (from resume_variants, tagged with resume_variant_id): sent, response rate (moved to interview, offer or rejected) and interview rate, overall and by month the application was added, over the last 6 months by default. Names a leading variant once each has at least 10 applications.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ResumeABReportInput) (*mcp.CallToolResult, *jobs.ResumeABReport, error) {
+		result, err := jobs.BuildResumeABReport(ctx, input.Months)
 		if err != nil {
 			return nil, nil, err
 		}
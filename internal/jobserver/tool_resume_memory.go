@@ -10,7 +10,7 @@ import (
 )
 
 func registerResumeMemorySearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "resume_memory_search",
 		Description: "Semantically search the user's resume vectors in MemDB. Find relevant experiences, projects, skills, and agent-added notes by meaning, not just keywords. Use this to explore what the resume contains before generating content.",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ResumeMemorySearchInput) (*mcp.CallToolResult, *jobs.ResumeMemorySearchResult, error) {
@@ -26,7 +26,7 @@ func registerResumeMemorySearch(server *mcp.Server) {
 }
 
 func registerResumeMemoryAdd(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "resume_memory_add",
 		Description: "Add a note, career goal, preference, or other context to the user's resume memory in MemDB. These are stored as vectors and will be found by resume_memory_search. Use this to store insights discovered during conversation.",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ResumeMemoryAddInput) (*mcp.CallToolResult, *jobs.ResumeMemoryAddResult, error) {
@@ -42,7 +42,7 @@ func registerResumeMemoryAdd(server *mcp.Server) {
 }
 
 func registerResumeMemoryUpdate(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "resume_memory_update",
 		Description: "Update an existing memory in MemDB by its ID (from resume_memory_search results). Replaces the old content while preserving the memory type. Use this to correct facts or update goals.",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ResumeMemoryUpdateInput) (*mcp.CallToolResult, *jobs.ResumeMemoryUpdateResult, error) {
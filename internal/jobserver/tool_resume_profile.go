@@ -9,7 +9,7 @@ import (
 )
 
 func registerResumeProfile(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "resume_profile",
 		Description: "Read the stored resume profile from the database. Returns structured data: personal info, experiences, skills, projects, achievements, educations, certifications, domains, methodologies. Optionally filter by section. Use this to see what the user's resume contains before generating tailored versions.",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.ResumeProfileInput) (*mcp.CallToolResult, *jobs.ResumeProfileResult, error) {
@@ -17,7 +17,7 @@ type securitySearchInput struct {
 }
 
 func registerSecurityBountySearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "security_bounty_search",
 		Description: "Search for security bug bounty programs across HackerOne, Bugcrowd, Intigriti, YesWeHack, and Immunefi. Returns program name, platform, max bounty, and in-scope targets.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
@@ -10,7 +10,7 @@ import (
 )
 
 func registerProjectShowcase(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "project_showcase",
 		Description: "Transform project descriptions into STAR-format interview narratives with quantified impact and talking points. Helps you articulate projects compellingly in interviews.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
@@ -10,7 +10,7 @@ import (
 )
 
 func registerSkillGap(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "skill_gap",
 		Description: "Analyze skill gaps between your resume and a target job description. Returns match score, matching skills, missing skills with priority and learning time estimates, and a prioritized learning plan.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
@@ -9,9 +9,9 @@ import (
 )
 
 func registerJobTrackerAdd(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "job_tracker_add",
-		Description: "Save a job to the local tracker (SQLite). Status options: saved (default), applied, interview, offer, rejected. Returns the assigned ID for future updates.",
+		Description: "Save a job to the local tracker (SQLite). Status options: saved (default), applied, interview, offer, rejected. An application deadline (explicit or found in notes) schedules a follow-up 3 days before it. Freelance gigs (e.g. from freelance_search) use kind=gig with rate_type (hourly or fixed), rate and currency; track hours, milestones and payment with gig_tracker_update. Set resume_variant_id to the resume_variants variant you sent, for resume_ab_report. For executive searches, record the retained recruiter (recruiter_name, recruiter_firm, recruiter_email, recruiter_phone) and set confidential=true to allow an undisclosed company and mask the entry in job_export. A job with a URL gets its posting archived (JD markdown and raw HTML) for job_tracker_get. Returns the assigned ID for future updates. Pass idempotency_key so client retries return the original result instead of adding a duplicate.",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.JobTrackerAddInput) (*mcp.CallToolResult, *jobs.JobTrackerResult, error) {
 		if input.Title == "" || input.Company == "" {
 			return nil, nil, errors.New("title and company are required")
@@ -25,9 +25,9 @@ func registerJobTrackerAdd(server *mcp.Server) {
 }
 
 func registerJobTrackerList(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "job_tracker_list",
-		Description: "List tracked job applications. Optionally filter by status: saved, applied, interview, offer, rejected. Returns jobs sorted by most recently updated.",
+		Description: "List tracked job applications. Optionally filter by status: saved, applied, interview, offer, rejected, and by kind: job or gig (gigs include rate, logged hours, milestones and payment status). Jobs whose posting the employer closed (404 or \"no longer accepting applications\", found by the posting monitor) carry closed_by_employer and a status_prompt asking to update their status. Returns jobs sorted by most recently updated, or by soonest follow-up with sort_by=follow_up.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.JobTrackerListInput) (*mcp.CallToolResult, *jobs.JobTrackerListResult, error) {
 		result, err := jobs.ListTrackedJobs(ctx, input)
@@ -39,9 +39,9 @@ func registerJobTrackerList(server *mcp.Server) {
 }
 
 func registerJobTrackerUpdate(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "job_tracker_update",
-		Description: "Update status or notes for a tracked job by ID. Status options: saved, applied, interview, offer, rejected. Get IDs from job_tracker_list.",
+		Description: "Update status or notes for a tracked job by ID. Status options: saved, applied, interview, offer, rejected. Log an interview, call, email or note with event (plus event_date, interviewers and event_notes); events are listed by job_tracker_list and used by followup_email_generate. Tag the resume variant sent with resume_variant_id (from resume_variants). Set or replace retained-recruiter fields (recruiter_name, recruiter_firm, recruiter_email, recruiter_phone) and toggle confidential. Get IDs from job_tracker_list.",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.JobTrackerUpdateInput) (*mcp.CallToolResult, *jobs.JobTrackerResult, error) {
 		if input.ID <= 0 {
 			return nil, nil, errors.New("id is required")
@@ -53,3 +53,158 @@ func registerJobTrackerUpdate(server *mcp.Server) {
 		return nil, result, nil
 	})
 }
+
+func registerJobTrackerGet(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "job_tracker_get",
+		Description: "Get one tracked job by ID with its events, gig milestones and the archived snapshot of its posting: the full JD as markdown, captured when the job was added with job_tracker_add, so the original requirements stay available after the posting is taken down. The posting monitor re-checks active jobs daily and logs posting_change events (salary added, requirements changed, posting closed); closed_at is set once the posting is gone. Set include_html for the raw page HTML. Jobs without a snapshot yet are archived on first request, except in read-only mode.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.JobTrackerGetInput) (*mcp.CallToolResult, *jobs.JobTrackerGetResult, error) {
+		if input.ID <= 0 {
+			return nil, nil, errors.New("id is required")
+		}
+		result, err := jobs.GetTrackedJob(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerFollowupEmail(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "followup_email_generate",
+		Description: "Write a post-interview thank-you note or a status-inquiry email for a tracked job. The email is tailored to the job's stage (applied, interview, offer, rejected), addressed to the interviewers of the last interview logged with job_tracker_update, and references its event notes plus any discussion notes given. Without type, a thank-you is written within 3 days of an interview, else a status inquiry. Tone: professional (default), friendly, concise. Returns subject, body and a suggested send date.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.FollowupEmailInput) (*mcp.CallToolResult, *jobs.FollowupEmailResult, error) {
+		if input.ID <= 0 {
+			return nil, nil, errors.New("id is required")
+		}
+		result, err := jobs.GenerateFollowupEmail(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerRejectionRetro(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "rejection_retro",
+		Description: "Learn from a rejected application (tracked job with status rejected). Compares the resume version you sent (default: the master resume) against the JD (default: fetched from the job URL): keyword overlap, JD keywords missing from the resume, seniority mismatch, likely gaps and reusable learnings. Learnings are stored and aggregated by weekly_review, which reports keywords missing across several rejections.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.RejectionRetroInput) (*mcp.CallToolResult, *jobs.RejectionRetroResult, error) {
+		if input.ID <= 0 {
+			return nil, nil, errors.New("id is required")
+		}
+		result, err := jobs.RejectionRetro(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerGigTrackerUpdate(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "gig_tracker_update",
+		Description: "Update a tracked freelance gig (job_tracker_add with kind=gig): log worked hours, add milestones with a price and due date, move milestones through pending → done → invoiced → paid, and set the payment status (unpaid, invoiced, paid). Use job_tracker_update for the application status.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.GigUpdateInput) (*mcp.CallToolResult, *jobs.JobTrackerResult, error) {
+		if input.ID <= 0 {
+			return nil, nil, errors.New("id is required")
+		}
+		result, err := jobs.UpdateGig(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerGigInvoice(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "gig_invoice",
+		Description: "Build invoice data for a tracked freelance gig: unbilled hours × hourly rate, done milestones, or the fixed price. Returns line items, total and CSV. With mark_invoiced the billed work is recorded as invoiced so the next invoice only covers new work.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.GigInvoiceInput) (*mcp.CallToolResult, *jobs.GigInvoice, error) {
+		if input.ID <= 0 {
+			return nil, nil, errors.New("id is required")
+		}
+		result, err := jobs.BuildGigInvoice(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerJobExport(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "job_export",
+		Description: "Export tracked jobs (optionally filtered by status) or the last job_search results. format=csv (default) or xlsx writes a file under ~/.go_job/exports and returns its content (XLSX as base64); format=notion appends one page per job to a Notion database (needs NOTION_TOKEN and notion_database_id), matching columns to database properties by name. Confidential tracker entries are exported with the employer, URL and notes masked unless include_confidential=true.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.JobExportInput) (*mcp.CallToolResult, *jobs.JobExportResult, error) {
+		result, err := jobs.ExportJobs(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerJobTrackerImport(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "job_tracker_import",
+		Description: "Import an existing application spreadsheet (CSV text with a header row) into the tracker. Columns are matched by common header names (Position, Company, Link, Stage, Date Applied, ...) or an explicit columns mapping. Statuses must map to saved, applied, interview, offer or rejected (common synonyms and status_map are honored); rows with unknown statuses are reported and skipped. Rows already tracked (same URL, or same title and company) are counted as duplicates. Use dry_run to preview.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.JobTrackerImportInput) (*mcp.CallToolResult, *jobs.JobTrackerImportResult, error) {
+		if input.CSV == "" {
+			return nil, nil, errors.New("csv is required")
+		}
+		result, err := jobs.ImportTrackedJobs(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerJobBookmarks(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "job_bookmarks",
+		Description: "Render the last job_search results (or tracked jobs) as a Netscape bookmarks HTML file, one folder per company, so a triage session can be opened in the browser with a single bookmarks import. Writes the file under ~/.go_job/exports and returns its content.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, input jobs.JobBookmarksInput) (*mcp.CallToolResult, *jobs.JobBookmarksResult, error) {
+		result, err := jobs.ExportJobBookmarks(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerJobsLocalSearch(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "jobs_local_search",
+		Description: "Full-text search over everything collected so far, without hitting external sources: listings returned by earlier searches, job descriptions fetched from job URLs, and tracked jobs with their notes. Accepts natural queries like \"that Rust job mentioning Kafka from last week\": time phrases (today, yesterday, last week, past 3 days) become the since filter and filler words are dropped. Documents matching all terms are ranked first; if none do, documents matching any term are returned. Filter with source (seen, posting, tracked) and since (YYYY-MM-DD). Returns title, company, URL, tracker_id for tracked jobs and a highlighted snippet.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.LocalSearchInput) (*mcp.CallToolResult, *jobs.LocalSearchResult, error) {
+		if input.Query == "" {
+			return nil, nil, errors.New("query is required")
+		}
+		result, err := jobs.SearchLocalJobs(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
+
+func registerSimilarJobs(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "similar_jobs",
+		Description: "Find postings similar to one the user liked, given by url or tracker_id. Ranks listings from earlier searches (seen-jobs store) together with fresh LinkedIn, Greenhouse and Lever searches for the posting's title synonyms and main skill (skip them with local_only). Similarity (0-1) is the embedding similarity of the job descriptions when an embedding server is configured, otherwise skill and title overlap. Returns the reference posting's skills, the queries run and the ranked jobs with shared skills.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.SimilarJobsInput) (*mcp.CallToolResult, *jobs.SimilarJobsResult, error) {
+		result, err := jobs.FindSimilarJobs(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
@@ -25,7 +25,7 @@ type TwitterJobSearchOutput struct {
 }
 
 func registerTwitterJobSearch(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &mcp.Tool{
 		Name:        "twitter_job_search",
 		Description: "Search Twitter/X for job postings and hiring tweets. Returns raw tweets from recruiters and companies posting job openings (#hiring, we're hiring, etc.). Fast — no LLM processing, returns tweet data directly.",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
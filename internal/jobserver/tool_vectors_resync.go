@@ -0,0 +1,22 @@
+package jobserver
+
+import (
+	"context"
+
+	"github.com/anatolykoptev/go_job/internal/engine"
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerVectorsResync(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "vectors_resync",
+		Description: "Reconcile MemDB resume vectors with the resume database by (type, id): re-add vectors missing for experiences, projects and achievements, and remove vectors whose record no longer exists or is duplicated. Agent notes are left alone. Use dry_run=true to only report counts.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input engine.VectorsResyncInput) (*mcp.CallToolResult, *jobs.VectorsResyncResult, error) {
+		result, err := jobs.ResyncVectors(ctx, input.DryRun)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
@@ -0,0 +1,22 @@
+package jobserver
+
+import (
+	"context"
+
+	"github.com/anatolykoptev/go_job/internal/engine/jobs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func registerWeeklyReview(server *mcp.Server) {
+	addTool(server, &mcp.Tool{
+		Name:        "weekly_review",
+		Description: "Weekly job hunt review: new listings seen by job_search grouped by company, tracker funnel movement, follow-ups due in the next 7 days, skills from recent listings missing from the master resume, learnings from rejection_retro (keywords missing across several rejections, seniority mismatches), and suggested next actions. Returns markdown plus the same data as structured fields.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, input jobs.WeeklyReviewInput) (*mcp.CallToolResult, *jobs.WeeklyReviewResult, error) {
+		result, err := jobs.WeeklyReview(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	})
+}
@@ -7,7 +7,9 @@ package main
 import (
 	"context"
 	"log/slog"
+	"net/http"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/anatolykoptev/go-kit/env"
@@ -28,7 +30,14 @@ var (
 )
 
 func main() {
-	initEngine()
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		os.Exit(runDoctor(os.Stdout))
+	}
+
+	logger := newLogger()
+	slog.SetDefault(logger)
+
+	deps := initDeps()
 
 	slog.Info("starting go_job",
 		slog.String("port", mcpPort),
@@ -39,12 +48,13 @@ func main() {
 		Version: version,
 	}, nil)
 
-	jobserver.RegisterTools(server)
-	slog.Info("tools registered", slog.Int("count", 36))
+	tools := jobserver.RegisterTools(server, deps)
+	startMonitors(deps)
+	slog.Info("tools registered", slog.Int("count", len(tools)))
 
 	hooks := mcpserver.MCPHooks{
 		OnToolCall: func(_ context.Context, _ string) {
-			engine.IncrToolCall()
+			deps.Engine.Metrics().Incr(engine.MetricToolCalls)
 		},
 		OnToolResult: func(_ context.Context, name string, dur time.Duration, isErr bool) {
 			slog.Info("tool_result", slog.String("tool", name), slog.Duration("duration", dur), slog.Bool("error", isErr))
@@ -57,17 +67,48 @@ func main() {
 		Port:                   mcpPort,
 		WriteTimeout:           600 * time.Second,
 		SessionTimeout:         10 * time.Minute,
-		MCPLogger:              slog.Default(),
-		Metrics:                engine.FormatMetrics,
+		Logger:                 logger,
+		MCPLogger:              logger,
+		Metrics:                deps.Engine.FormatMetrics,
+		DisableHealth:          true,
+		Routes:                 routes(deps),
 		MCPReceivingMiddleware: []mcp.Middleware{hooks.Middleware()},
 	}); err != nil {
 		slog.Error("server failed", slog.Any("error", err))
 	}
 }
 
-func initEngine() {
-	c := engine.Config{
+// routes returns the func that registers go_job's HTTP endpoints, including its
+// own /health, bound to deps.
+func routes(deps *jobserver.Deps) func(mux *http.ServeMux) {
+	return func(mux *http.ServeMux) {
+		jobserver.RegisterRoutes(mux, deps)
+		jobserver.RegisterHealth(mux, deps, "go_job", version)
+	}
+}
+
+// newLogger builds the logger from LOG_LEVEL, LOG_LEVEL_<module> and LOG_FORMAT. Like
+// go-mcpserver's default it writes to stdout, or stderr in stdio mode.
+func newLogger() *slog.Logger {
+	w := os.Stdout
+	if slices.Contains(os.Args[1:], "--stdio") {
+		w = os.Stderr
+	}
+	c := engine.ParseLogConfig(os.Environ())
+	logger := engine.NewLogger(w, c)
+	for _, kv := range c.Invalid {
+		logger.Warn("ignoring log level setting", slog.String("env", kv))
+	}
+	return logger
+}
+
+// loadConfig reads the engine configuration from env.
+func loadConfig() engine.Config {
+	return engine.Config{
 		SearxngURL:            env.Str("SEARXNG_URL", ""),
+		SearxngEngines:        env.Str("SEARXNG_ENGINES", engine.DefaultSearxngEngines),
+		BraveAPIKey:           env.Str("BRAVE_API_KEY", ""),
+		SerpAPIKey:            env.Str("SERPAPI_KEY", ""),
 		LLMAPIKey:             env.Str("LLM_API_KEY", ""),
 		LLMAPIKeyFallbacks:    env.List("LLM_API_KEY_FALLBACKS", ""),
 		LLMAPIBase:            env.Str("LLM_API_BASE", "http://127.0.0.1:8317/v1"),
@@ -81,10 +122,25 @@ func initEngine() {
 		CacheMaxEntries:       env.Int("CACHE_MAX_ENTRIES", 1000),
 		CacheCleanupInterval:  env.Duration("CACHE_CLEANUP_INTERVAL", 300*time.Second),
 		IndeedAPIKey:          env.Str("INDEED_API_KEY", ""),
+		USAJobsAPIKey:         env.Str("USAJOBS_API_KEY", ""),
+		USAJobsEmail:          env.Str("USAJOBS_EMAIL", ""),
 		DatabaseURL:           env.Str("DATABASE_URL", ""),
 		MemDBURL:              env.Str("MEMDB_URL", ""),
 		MemDBServiceSecret:    env.Str("INTERNAL_SERVICE_SECRET", ""),
 		EmbedURL:              env.Str("EMBED_URL", ""),
+		ResumeSiteToken:       env.Str("RESUME_SITE_TOKEN", ""),
+		APIToken:              env.Str("API_TOKEN", ""),
+		HHAccessToken:         env.Str("HH_ACCESS_TOKEN", ""),
+		HHUserAgent:           env.Str("HH_USER_AGENT", ""),
+		NotionToken:           env.Str("NOTION_TOKEN", ""),
+		ReadOnly:              env.Bool("GO_JOB_READONLY", false),
+		DisabledTools:         env.List("GO_JOB_DISABLED_TOOLS", ""),
+		DisabledSources:       env.List("GO_JOB_DISABLED_SOURCES", ""),
+		MaxSourceFetches:      env.Int("GO_JOB_MAX_SOURCE_FETCHES", 24),
+		MaxOutboundFetches:    env.Int("GO_JOB_MAX_OUTBOUND_FETCHES", 32),
+		FourDayWeekCompanies:  env.List("GO_JOB_FOUR_DAY_WEEK_COMPANIES", ""),
+		ImpactSalaryDiscount:  env.Int("GO_JOB_IMPACT_SALARY_DISCOUNT", 20),
+		ReliefWebAppName:      env.Str("GO_JOB_RELIEFWEB_APPNAME", "go_job"),
 		BountyHighConfidence:  float32(env.Float("BOUNTY_HIGH_CONF", 0.82)),
 		BountyHighConfGap:     float32(env.Float("BOUNTY_HIGH_CONF_GAP", 0.04)),
 		BountyHighConfMax:     env.Int("BOUNTY_HIGH_CONF_MAX", 10),
@@ -94,11 +150,18 @@ func initEngine() {
 		VaelorNotifyURL:       env.Str("VAELOR_NOTIFY_URL", ""),
 		BountyNotifyChatID:    env.Str("BOUNTY_NOTIFY_CHAT_ID", "428660"),
 		BountyMonitorInterval: env.Duration("BOUNTY_MONITOR_INTERVAL", 15*time.Minute),
+		PostingCheckInterval:  env.Duration("POSTING_CHECK_INTERVAL", 24*time.Hour),
 		DirectDDG:             env.Bool("DIRECT_DDG", false),
 		DirectStartpage:       env.Bool("DIRECT_STARTPAGE", false),
 		DirectBrave:           env.Bool("DIRECT_BRAVE", false),
 		DirectReddit:          env.Bool("DIRECT_REDDIT", false),
 	}
+}
+
+// initDeps builds the engine and data stores from env. Nothing is installed
+// globally: the deps are passed to jobserver.RegisterTools and the routes.
+func initDeps() *jobserver.Deps {
+	c := loadConfig()
 
 	// Initialize proxy pool from Webshare API (optional).
 	if apiKey := os.Getenv("WEBSHARE_API_KEY"); apiKey != "" {
@@ -154,7 +217,8 @@ func initEngine() {
 		slog.Info("twitter client ready", slog.Int("pool_size", tw.Pool().Size()))
 	}
 
-	engine.Init(c)
+	deps := &jobserver.Deps{Engine: engine.New(c)}
+	deps.Engine.LogSettings()
 
 	// Resume DB (PostgreSQL + AGE graph)
 	if c.DatabaseURL != "" {
@@ -162,28 +226,37 @@ func initEngine() {
 		if err != nil {
 			slog.Warn("resume DB init failed", slog.Any("error", err))
 		} else {
-			jobs.SetResumeDB(rdb)
+			deps.Stores.ResumeDB = rdb
 			slog.Info("resume DB initialized")
 		}
 	}
 
 	// MemDB vector client
 	if c.MemDBURL != "" && c.MemDBServiceSecret != "" {
-		jobs.SetMemDB(jobs.NewMemDBClient(c.MemDBURL, c.MemDBServiceSecret))
-		slog.Info("memdb client initialized", slog.String("url", c.MemDBURL))
+		deps.Stores.MemDB = jobs.NewMemDBClient(c.MemDBURL, c.MemDBServiceSecret)
+		if err := deps.Stores.MemDB.Ping(context.Background()); err != nil {
+			slog.Warn("memdb unreachable at startup", slog.String("url", c.MemDBURL), slog.Any("error", err))
+		} else {
+			slog.Info("memdb client initialized", slog.String("url", c.MemDBURL))
+		}
 	}
 
 	// Embed client (for embedding-based bounty matching)
 	if c.EmbedURL != "" {
-		jobs.SetEmbedClient(jobs.NewEmbedClient(c.EmbedURL))
+		deps.Stores.Embed = jobs.NewEmbedClient(c.EmbedURL)
 		slog.Info("embed client initialized", slog.String("url", c.EmbedURL))
 	}
 
 	cacheTTL := env.Duration("CACHE_TTL", 15*time.Minute)
-	engine.InitCache(env.Str("REDIS_URL", ""), cacheTTL, c.CacheMaxEntries, c.CacheCleanupInterval)
+	deps.Engine.InitCache(env.Str("REDIS_URL", ""), cacheTTL, c.CacheMaxEntries)
+	return deps
+}
 
-	// Start background monitors.
-	jobs.StartBountyMonitor(context.Background())
-	jobs.StartSecurityMonitor(context.Background())
-	jobs.StartFreelanceMonitor(context.Background())
+// startMonitors starts background monitors bound to deps.
+func startMonitors(deps *jobserver.Deps) {
+	ctx := deps.Bind(context.Background())
+	jobs.StartBountyMonitor(ctx)
+	jobs.StartSecurityMonitor(ctx)
+	jobs.StartFreelanceMonitor(ctx)
+	jobs.StartPostingMonitor(ctx)
 }